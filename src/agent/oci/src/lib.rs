@@ -468,6 +468,8 @@ pub struct LinuxResources {
     pub network: Option<LinuxNetwork>,
     #[serde(default, skip_serializing_if = "HashMap::is_empty")]
     pub rdma: HashMap<String, LinuxRdma>,
+    #[serde(default, skip_serializing_if = "HashMap::is_empty")]
+    pub unified: HashMap<String, String>,
 }
 
 #[derive(Serialize, Deserialize, Debug, Default, Clone, PartialEq)]
@@ -1524,6 +1526,7 @@ mod tests {
                         ],
                     }),
                     rdma: Default::default(),
+                    unified: Default::default(),
                 }),
                 cgroups_path: "/myRuntime/myContainer".to_string(),
                 namespaces: vec![
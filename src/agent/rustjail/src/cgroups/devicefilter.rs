@@ -0,0 +1,376 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// The cgroup v2 hierarchy has no devices controller: the device accesses of
+// the processes of a cgroup are checked by a BPF_PROG_TYPE_CGROUP_DEVICE
+// program attached to it. This module builds the program implementing the
+// device cgroup rules of a container, with the semantics of the cgroup v1
+// devices controller, and attaches it to the cgroup of the container.
+
+use anyhow::{anyhow, Context, Result};
+use oci::LinuxDeviceCgroup;
+use std::ffi::CString;
+use std::fs::File;
+use std::os::unix::io::{AsRawFd, FromRawFd};
+use std::path::Path;
+
+// bpf(2) commands
+const BPF_PROG_LOAD: libc::c_long = 5;
+const BPF_PROG_ATTACH: libc::c_long = 8;
+
+const BPF_PROG_TYPE_CGROUP_DEVICE: u32 = 15;
+const BPF_CGROUP_DEVICE: u32 = 6;
+
+// Device and access types of the bpf_cgroup_dev_ctx context of the program
+const BPF_DEVCG_DEV_BLOCK: i32 = 1;
+const BPF_DEVCG_DEV_CHAR: i32 = 2;
+const BPF_DEVCG_ACC_MKNOD: i32 = 1;
+const BPF_DEVCG_ACC_READ: i32 = 2;
+const BPF_DEVCG_ACC_WRITE: i32 = 4;
+const BPF_DEVCG_ACC_ALL: i32 = BPF_DEVCG_ACC_MKNOD | BPF_DEVCG_ACC_READ | BPF_DEVCG_ACC_WRITE;
+
+// Opcodes of the instructions used by the program
+const BPF_LDX_MEM_W: u8 = 0x61;
+const BPF_ALU_AND_K: u8 = 0x54;
+const BPF_ALU_RSH_K: u8 = 0x74;
+const BPF_ALU_MOV_X: u8 = 0xbc;
+const BPF_ALU64_MOV_K: u8 = 0xb7;
+const BPF_JMP_JEQ_K: u8 = 0x15;
+const BPF_JMP_JNE_K: u8 = 0x55;
+const BPF_JMP_JNE_X: u8 = 0x5d;
+const BPF_JMP_EXIT: u8 = 0x95;
+
+// Registers: R1 points to the context when the program starts, which is
+// then loaded into R2 (device type), R3 (access type), R4 (major) and R5
+// (minor). R0 holds the verdict.
+const R0: u8 = 0;
+const R1: u8 = 1;
+const R2: u8 = 2;
+const R3: u8 = 3;
+const R4: u8 = 4;
+const R5: u8 = 5;
+
+const WILDCARD: i64 = -1;
+
+#[repr(C)]
+#[derive(Clone, Copy, Debug, PartialEq)]
+pub struct BpfInsn {
+    code: u8,
+    regs: u8,
+    off: i16,
+    imm: i32,
+}
+
+fn insn(code: u8, dst: u8, src: u8, off: i16, imm: i32) -> BpfInsn {
+    BpfInsn {
+        code,
+        regs: (src << 4) | (dst & 0xf),
+        off,
+        imm,
+    }
+}
+
+fn device_type(r: &LinuxDeviceCgroup) -> Option<i32> {
+    match r.r#type.as_str() {
+        "b" => Some(BPF_DEVCG_DEV_BLOCK),
+        "c" => Some(BPF_DEVCG_DEV_CHAR),
+        _ => None,
+    }
+}
+
+fn access_type(access: &str) -> i32 {
+    access.chars().fold(0, |acc, c| match c {
+        'm' => acc | BPF_DEVCG_ACC_MKNOD,
+        'r' => acc | BPF_DEVCG_ACC_READ,
+        'w' => acc | BPF_DEVCG_ACC_WRITE,
+        _ => acc,
+    })
+}
+
+// is_all_devices checks if a rule applies to all the devices, which resets
+// the default verdict and drops the previous rules, as when written to the
+// devices.allow or devices.deny files of cgroup v1.
+fn is_all_devices(r: &LinuxDeviceCgroup) -> bool {
+    r.r#type.is_empty() || r.r#type == "a"
+}
+
+// effective_rules returns the default verdict of the rules, and the rules
+// which are exceptions to it.
+fn effective_rules(rules: &[LinuxDeviceCgroup]) -> (bool, Vec<&LinuxDeviceCgroup>) {
+    let mut default_allow = true;
+    let mut exceptions = Vec::new();
+
+    for r in rules {
+        if is_all_devices(r) {
+            default_allow = r.allow;
+            exceptions.clear();
+        } else if device_type(r).is_some() {
+            exceptions.push(r);
+        }
+    }
+
+    (default_allow, exceptions)
+}
+
+// rule_block returns the instructions checking a device access against a
+// rule, which exit with the verdict of the rule when it matches and jump to
+// the instructions following them otherwise. An allow rule matches when all
+// the accesses requested are allowed, a deny rule when any of them is.
+fn rule_block(r: &LinuxDeviceCgroup) -> Vec<BpfInsn> {
+    let mut block = Vec::new();
+
+    // device_type() is set for the exceptions
+    block.push(insn(BPF_JMP_JNE_K, R2, 0, 0, device_type(r).unwrap_or(0)));
+
+    let access = access_type(&r.access) & BPF_DEVCG_ACC_ALL;
+    if access != BPF_DEVCG_ACC_ALL {
+        block.push(insn(BPF_ALU_MOV_X, R1, R3, 0, 0));
+        block.push(insn(BPF_ALU_AND_K, R1, 0, 0, access));
+        if r.allow {
+            block.push(insn(BPF_JMP_JNE_X, R1, R3, 0, 0));
+        } else {
+            block.push(insn(BPF_JMP_JEQ_K, R1, 0, 0, 0));
+        }
+    }
+
+    if let Some(major) = r.major.filter(|m| *m != WILDCARD) {
+        block.push(insn(BPF_JMP_JNE_K, R4, 0, 0, major as i32));
+    }
+
+    if let Some(minor) = r.minor.filter(|m| *m != WILDCARD) {
+        block.push(insn(BPF_JMP_JNE_K, R5, 0, 0, minor as i32));
+    }
+
+    block.push(insn(BPF_ALU64_MOV_K, R0, 0, 0, r.allow as i32));
+    block.push(insn(BPF_JMP_EXIT, 0, 0, 0, 0));
+
+    // The jumps skip the rest of the block
+    let len = block.len();
+    for (i, ins) in block.iter_mut().enumerate() {
+        if ins.code != BPF_JMP_EXIT && ins.code & 0x07 == 0x05 {
+            ins.off = (len - i - 1) as i16;
+        }
+    }
+
+    block
+}
+
+// device_filter_program returns the program implementing the device cgroup
+// rules, the last rule matching a device access deciding its verdict.
+pub fn device_filter_program(rules: &[LinuxDeviceCgroup]) -> Vec<BpfInsn> {
+    let (default_allow, exceptions) = effective_rules(rules);
+
+    let mut program = vec![
+        insn(BPF_LDX_MEM_W, R2, R1, 0, 0),
+        insn(BPF_ALU_AND_K, R2, 0, 0, 0xffff),
+        insn(BPF_LDX_MEM_W, R3, R1, 0, 0),
+        insn(BPF_ALU_RSH_K, R3, 0, 0, 16),
+        insn(BPF_LDX_MEM_W, R4, R1, 4, 0),
+        insn(BPF_LDX_MEM_W, R5, R1, 8, 0),
+    ];
+
+    for r in exceptions.iter().rev() {
+        program.extend(rule_block(r));
+    }
+
+    program.push(insn(BPF_ALU64_MOV_K, R0, 0, 0, default_allow as i32));
+    program.push(insn(BPF_JMP_EXIT, 0, 0, 0, 0));
+
+    program
+}
+
+// The bpf_attr union members of the commands used
+#[repr(C)]
+#[derive(Default)]
+struct BpfProgLoadAttr {
+    prog_type: u32,
+    insn_cnt: u32,
+    insns: u64,
+    license: u64,
+    log_level: u32,
+    log_size: u32,
+    log_buf: u64,
+    kern_version: u32,
+    prog_flags: u32,
+}
+
+#[repr(C)]
+#[derive(Default)]
+struct BpfProgAttachAttr {
+    target_fd: u32,
+    attach_bpf_fd: u32,
+    attach_type: u32,
+    attach_flags: u32,
+}
+
+fn bpf<T>(cmd: libc::c_long, attr: &mut T) -> Result<libc::c_long> {
+    let ret =
+        unsafe { libc::syscall(libc::SYS_bpf, cmd, attr as *mut T, std::mem::size_of::<T>()) };
+    if ret < 0 {
+        return Err(std::io::Error::last_os_error().into());
+    }
+
+    Ok(ret)
+}
+
+// apply_device_filter attaches the program implementing the device cgroup
+// rules to the cgroup v2 directory cgroup_path, replacing the program
+// previously attached by the agent.
+pub fn apply_device_filter(cgroup_path: &Path, rules: &[LinuxDeviceCgroup]) -> Result<()> {
+    let program = device_filter_program(rules);
+    let license = CString::new("Apache")?;
+
+    let mut load = BpfProgLoadAttr {
+        prog_type: BPF_PROG_TYPE_CGROUP_DEVICE,
+        insn_cnt: program.len() as u32,
+        insns: program.as_ptr() as u64,
+        license: license.as_ptr() as u64,
+        ..Default::default()
+    };
+    let fd = bpf(BPF_PROG_LOAD, &mut load)
+        .map_err(|e| anyhow!("failed to load the device cgroup program: {}", e))?;
+    let prog = unsafe { File::from_raw_fd(fd as i32) };
+
+    let cgroup =
+        File::open(cgroup_path).with_context(|| format!("failed to open {:?}", cgroup_path))?;
+
+    // Without BPF_F_ALLOW_MULTI, the program replaces the previous one
+    let mut attach = BpfProgAttachAttr {
+        target_fd: cgroup.as_raw_fd() as u32,
+        attach_bpf_fd: prog.as_raw_fd() as u32,
+        attach_type: BPF_CGROUP_DEVICE,
+        attach_flags: 0,
+    };
+    bpf(BPF_PROG_ATTACH, &mut attach).map_err(|e| {
+        anyhow!(
+            "failed to attach the device cgroup program to {:?}: {}",
+            cgroup_path,
+            e
+        )
+    })?;
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    // run interprets the instructions of the program for a device access
+    fn run(program: &[BpfInsn], dev: i32, access: i32, major: u32, minor: u32) -> u64 {
+        let ctx = [((access as u32) << 16) | dev as u32, major, minor];
+        let mut regs = [0u64; 11];
+        let mut pc = 0;
+
+        loop {
+            let ins = program[pc];
+            let (dst, src) = ((ins.regs & 0xf) as usize, (ins.regs >> 4) as usize);
+            let imm = ins.imm as i64 as u64;
+            pc += 1;
+
+            match ins.code {
+                BPF_LDX_MEM_W => {
+                    assert_eq!(src, R1 as usize);
+                    regs[dst] = ctx[ins.off as usize / 4] as u64;
+                }
+                BPF_ALU_AND_K => regs[dst] = (regs[dst] as u32 & ins.imm as u32) as u64,
+                BPF_ALU_RSH_K => regs[dst] = (regs[dst] as u32 >> ins.imm) as u64,
+                BPF_ALU_MOV_X => regs[dst] = regs[src] as u32 as u64,
+                BPF_ALU64_MOV_K => regs[dst] = imm,
+                BPF_JMP_JEQ_K if regs[dst] == imm => pc += ins.off as usize,
+                BPF_JMP_JNE_K if regs[dst] != imm => pc += ins.off as usize,
+                BPF_JMP_JNE_X if regs[dst] != regs[src] => pc += ins.off as usize,
+                BPF_JMP_JEQ_K | BPF_JMP_JNE_K | BPF_JMP_JNE_X => {}
+                BPF_JMP_EXIT => return regs[R0 as usize],
+                _ => panic!("unexpected instruction {:?}", ins),
+            }
+        }
+    }
+
+    fn rule(
+        allow: bool,
+        t: &str,
+        major: Option<i64>,
+        minor: Option<i64>,
+        access: &str,
+    ) -> LinuxDeviceCgroup {
+        LinuxDeviceCgroup {
+            allow,
+            r#type: t.to_string(),
+            major,
+            minor,
+            access: access.to_string(),
+        }
+    }
+
+    const B: i32 = BPF_DEVCG_DEV_BLOCK;
+    const C: i32 = BPF_DEVCG_DEV_CHAR;
+    const M: i32 = BPF_DEVCG_ACC_MKNOD;
+    const R: i32 = BPF_DEVCG_ACC_READ;
+    const W: i32 = BPF_DEVCG_ACC_WRITE;
+
+    #[test]
+    fn test_device_filter_no_rules() {
+        let program = device_filter_program(&[]);
+        assert_eq!(run(&program, C, R | W, 1, 3), 1);
+        assert_eq!(run(&program, B, M, 8, 0), 1);
+    }
+
+    #[test]
+    fn test_device_filter_deny_all() {
+        let program = device_filter_program(&[
+            rule(false, "a", None, None, "rwm"),
+            rule(true, "c", Some(1), Some(3), "rwm"),
+            rule(true, "c", Some(136), Some(WILDCARD), "rw"),
+            rule(true, "b", None, None, "m"),
+            rule(true, "c", Some(1), Some(9), "r"),
+        ]);
+
+        assert_eq!(run(&program, C, R | W, 1, 3), 1);
+        assert_eq!(run(&program, C, M, 1, 3), 1);
+        assert_eq!(run(&program, C, R | W, 1, 5), 0);
+        assert_eq!(run(&program, C, R | W, 136, 4), 1);
+        assert_eq!(run(&program, C, M, 136, 4), 0);
+        assert_eq!(run(&program, B, M, 8, 0), 1);
+        assert_eq!(run(&program, B, R, 8, 0), 0);
+        assert_eq!(run(&program, C, R, 1, 9), 1);
+        assert_eq!(run(&program, C, R | W, 1, 9), 0);
+    }
+
+    #[test]
+    fn test_device_filter_allow_all() {
+        let program = device_filter_program(&[
+            rule(true, "c", Some(1), Some(3), "rwm"),
+            rule(true, "", None, None, "rwm"),
+            rule(false, "b", Some(8), Some(0), "rw"),
+        ]);
+
+        assert_eq!(run(&program, B, R, 8, 0), 0);
+        assert_eq!(run(&program, B, W | M, 8, 0), 0);
+        assert_eq!(run(&program, B, M, 8, 0), 1);
+        assert_eq!(run(&program, B, R, 8, 1), 1);
+        assert_eq!(run(&program, C, R, 10, 200), 1);
+    }
+
+    #[test]
+    fn test_device_filter_last_rule_wins() {
+        let program = device_filter_program(&[
+            rule(false, "a", None, None, "rwm"),
+            rule(true, "c", Some(10), None, "rwm"),
+            rule(false, "c", Some(10), Some(229), "rwm"),
+        ]);
+
+        assert_eq!(run(&program, C, R, 10, 200), 1);
+        assert_eq!(run(&program, C, R, 10, 229), 0);
+
+        // A rule for all the devices drops the previous ones
+        let program = device_filter_program(&[
+            rule(true, "c", Some(10), None, "rwm"),
+            rule(false, "a", None, None, "rwm"),
+        ]);
+        assert_eq!(program.len(), 8);
+        assert_eq!(run(&program, C, R, 10, 200), 0);
+    }
+}
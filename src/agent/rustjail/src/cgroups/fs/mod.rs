@@ -18,6 +18,7 @@ use cgroups::{
     DeviceResource, HugePageResource, MaxValue, NetworkPriority,
 };
 
+use crate::cgroups::devicefilter;
 use crate::cgroups::Manager as CgroupManager;
 use crate::container::DEFAULT_DEVICES;
 use anyhow::{anyhow, Context, Result};
@@ -38,6 +39,7 @@ use std::fs;
 use std::path::Path;
 
 const GUEST_CPUS_PATH: &str = "/sys/devices/system/cpu/online";
+const CGROUP2_PATH: &str = "/sys/fs/cgroup";
 
 // Convenience macro to obtain the scope logger
 macro_rules! sl {
@@ -125,6 +127,20 @@ impl CgroupManager for Manager {
         // apply resources
         self.cgroup.apply(res)?;
 
+        // cgroup v2 has no devices controller, the device rules are
+        // enforced by a BPF program. The updates without device rules keep
+        // the program of the container.
+        if cgroups::hierarchies::is_cgroup2_unified_mode() && !(update && r.devices.is_empty()) {
+            let path = Path::new(CGROUP2_PATH).join(self.cpath.trim_start_matches('/'));
+            devicefilter::apply_device_filter(&path, &device_cgroup_rules(&r.devices))?;
+        }
+
+        // set unified resources, last for them to override the values
+        // derived from the other resources
+        if !r.unified.is_empty() {
+            set_unified_resources(&self.cpath, &r.unified)?;
+        }
+
         Ok(())
     }
 
@@ -252,6 +268,27 @@ fn set_devices_resources(
     res.devices.devices = devices;
 }
 
+// device_cgroup_rules returns the device rules of a container followed by
+// the ones of the devices every container is allowed to use, as set by
+// set_devices_resources.
+fn device_cgroup_rules(device_resources: &[LinuxDeviceCgroup]) -> Vec<LinuxDeviceCgroup> {
+    let mut rules = device_resources.to_vec();
+
+    for d in DEFAULT_DEVICES.iter() {
+        rules.push(LinuxDeviceCgroup {
+            allow: true,
+            r#type: d.r#type.clone(),
+            major: Some(d.major),
+            minor: Some(d.minor),
+            access: "rwm".to_string(),
+        });
+    }
+
+    rules.extend(DEFAULT_ALLOWED_DEVICES.iter().cloned());
+
+    rules
+}
+
 fn set_hugepages_resources(
     _cg: &cgroups::Cgroup,
     hugepage_limits: &[LinuxHugepageLimit],
@@ -421,6 +458,64 @@ fn set_pids_resources(cg: &cgroups::Cgroup, pids: &LinuxPids) -> Result<()> {
         .context("failed to set pids resources")
 }
 
+// validate_unified_key checks that the key of a unified resource names an
+// interface file of a controller in the cgroup directory, e.g. memory.high.
+fn validate_unified_key(key: &str) -> Result<()> {
+    let parts: Vec<&str> = key.splitn(2, '.').collect();
+
+    if parts.len() != 2
+        || parts[0].is_empty()
+        || parts[1].is_empty()
+        || parts[0] == "cgroup"
+        || key.contains('/')
+    {
+        return Err(anyhow!("invalid unified resource {:?}", key));
+    }
+
+    Ok(())
+}
+
+// set_unified_resources writes the unified resources of the OCI spec to the
+// interface files of the cgroup v2 directory of the container, as runc does.
+fn set_unified_resources(cpath: &str, unified: &HashMap<String, String>) -> Result<()> {
+    info!(sl!(), "cgroup manager set unified");
+
+    if !cgroups::hierarchies::is_cgroup2_unified_mode() {
+        return Err(anyhow!(
+            "unified resources require the cgroup v2 unified hierarchy"
+        ));
+    }
+
+    let cg_path = Path::new(CGROUP2_PATH).join(cpath.trim_start_matches('/'));
+
+    for (key, value) in unified {
+        validate_unified_key(key)?;
+
+        if let Err(e) = fs::write(cg_path.join(key), value) {
+            // The file is missing when the controller is not enabled
+            if e.kind() == std::io::ErrorKind::NotFound {
+                let controller = key.split('.').next().unwrap_or_default();
+                let controllers =
+                    fs::read_to_string(cg_path.join("cgroup.controllers")).unwrap_or_default();
+                if !controllers.split_whitespace().any(|c| c == controller) {
+                    return Err(anyhow!(
+                        "unified resource {}: controller {} is not available",
+                        key,
+                        controller
+                    ));
+                }
+            }
+
+            return Err(anyhow!(e).context(format!(
+                "failed to set unified resource {} to {:?}",
+                key, value
+            )));
+        }
+    }
+
+    Ok(())
+}
+
 fn build_blk_io_device_throttle_resource(
     input: &[oci::LinuxThrottleDevice],
 ) -> Vec<BlkIoDeviceThrottleResource> {
@@ -477,8 +572,8 @@ fn linux_device_group_to_cgroup_device(d: &LinuxDeviceCgroup) -> Option<DeviceRe
     Some(DeviceResource {
         allow: d.allow,
         devtype: dev_type,
-        major: d.major.unwrap_or(0),
-        minor: d.minor.unwrap_or(0),
+        major: d.major.unwrap_or(WILDCARD),
+        minor: d.minor.unwrap_or(WILDCARD),
         access: permissions,
     })
 }
@@ -1042,7 +1137,7 @@ impl Manager {
 
     pub fn get_cg_path(&self, cg: &str) -> Option<String> {
         if cgroups::hierarchies::is_cgroup2_unified_mode() {
-            let cg_path = format!("/sys/fs/cgroup/{}", self.cpath);
+            let cg_path = format!("{}/{}", CGROUP2_PATH, self.cpath);
             return Some(cg_path);
         }
 
@@ -1119,6 +1214,58 @@ mod tests {
         }
     }
 
+    #[test]
+    fn test_validate_unified_key() {
+        for key in &["memory.high", "cpu.max.burst", "io.weight"] {
+            assert!(validate_unified_key(key).is_ok(), "key {}", key);
+        }
+
+        for key in &[
+            "",
+            "memory",
+            "memory.",
+            ".high",
+            "cgroup.procs",
+            "../memory.high",
+            "memory.high/..",
+        ] {
+            assert!(validate_unified_key(key).is_err(), "key {}", key);
+        }
+    }
+
+    #[test]
+    fn test_linux_device_group_to_cgroup_device() {
+        let dev = linux_device_group_to_cgroup_device(&LinuxDeviceCgroup {
+            allow: true,
+            r#type: "c".to_string(),
+            major: Some(10),
+            minor: None,
+            access: "rw".to_string(),
+        })
+        .unwrap();
+        assert_eq!(dev.major, 10);
+        assert_eq!(dev.minor, WILDCARD);
+    }
+
+    #[test]
+    fn test_device_cgroup_rules() {
+        let deny_all = LinuxDeviceCgroup {
+            allow: false,
+            r#type: "a".to_string(),
+            major: None,
+            minor: None,
+            access: "rwm".to_string(),
+        };
+
+        let rules = device_cgroup_rules(&[deny_all.clone()]);
+        assert_eq!(rules[0], deny_all);
+        assert_eq!(
+            rules.len(),
+            1 + DEFAULT_DEVICES.len() + DEFAULT_ALLOWED_DEVICES.len()
+        );
+        assert!(rules[1..].iter().all(|r| r.allow));
+    }
+
     #[test]
     fn test_lines_to_map() {
         let hm1: HashMap<String, u64> = [
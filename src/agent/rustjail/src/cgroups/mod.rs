@@ -9,6 +9,7 @@ use protocols::agent::CgroupStats;
 
 use cgroups::freezer::FreezerState;
 
+pub mod devicefilter;
 pub mod fs;
 pub mod mock;
 pub mod notifier;
@@ -30,6 +30,19 @@ pub async fn notify_oom(cid: &str, cg_dir: String) -> Result<Receiver<String>> {
     notify_on_oom(cid, cg_dir).await
 }
 
+// get_oom_kill_count returns the number of processes of the memory cgroup
+// killed by the OOM killer, whether the OOM was caused by the cgroup limit
+// or not.
+pub fn get_oom_kill_count(cg_dir: &str) -> Result<i64> {
+    let event_file = if cgroups::hierarchies::is_cgroup2_unified_mode() {
+        "memory.events"
+    } else {
+        "memory.oom_control"
+    };
+
+    get_value_from_cgroup(&Path::new(cg_dir).join(event_file), "oom_kill")
+}
+
 // get_value_from_cgroup parse cgroup file with `Flat keyed`
 // and get the value of `key`.
 // Flat keyed file format:
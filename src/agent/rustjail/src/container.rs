@@ -783,7 +783,8 @@ impl BaseContainer for LinuxContainer {
                 SingularPtrField::some(self.cgroup_manager.as_ref().unwrap().get_stats()?);
         }
 
-        // what about network interface stats?
+        // network interface stats are shared by all the containers of
+        // the sandbox and are filled by the agent.
 
         Ok(r)
     }
@@ -1394,6 +1395,18 @@ impl LinuxContainer {
             logger: logger.new(o!("module" => "rustjail", "subsystem" => "container", "cid" => id)),
         })
     }
+
+    // restored makes p the init process of the container, its process tree
+    // having been restored from a checkpoint out of the container start.
+    pub fn restored(&mut self, p: Process) {
+        self.init_process_pid = p.pid;
+        self.init_process_start_time = SystemTime::now()
+            .duration_since(SystemTime::UNIX_EPOCH)
+            .unwrap()
+            .as_secs();
+        self.processes.insert(p.pid, p);
+        self.status.transition(ContainerState::Running);
+    }
 }
 
 fn setgroups(grps: &[libc::gid_t]) -> Result<()> {
@@ -1890,6 +1903,19 @@ mod tests {
         });
     }
 
+    #[test]
+    fn test_linuxcontainer_restored() {
+        let _ = new_linux_container_and_then(|mut c: LinuxContainer| {
+            let mut p = Process::new(&sl!(), &oci::Process::default(), "123", true, 1).unwrap();
+            p.pid = 42;
+            c.restored(p);
+            assert_eq!(c.init_process_pid, 42);
+            assert!(c.processes.contains_key(&42));
+            assert_eq!(c.status(), ContainerState::Running);
+            Ok(())
+        });
+    }
+
     #[test]
     fn test_linuxcontainer_stats() {
         let ret = new_linux_container_and_then(|c: LinuxContainer| c.stats());
@@ -340,6 +340,7 @@ pub fn resources_grpc_to_oci(res: &grpc::LinuxResources) -> oci::LinuxResources
         hugepage_limits,
         network,
         rdma: HashMap::new(),
+        unified: res.Unified.clone(),
     }
 }
 
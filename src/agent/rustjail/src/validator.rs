@@ -188,6 +188,30 @@ fn sysctl(oci: &Spec) -> Result<()> {
     Ok(())
 }
 
+// The range of /proc/[pid]/oom_score_adj
+const OOM_SCORE_ADJ_MIN: i32 = -1000;
+const OOM_SCORE_ADJ_MAX: i32 = 1000;
+
+fn process(oci: &Spec) -> Result<()> {
+    let process = match oci.process.as_ref() {
+        Some(p) => p,
+        None => return Ok(()),
+    };
+
+    if let Some(oom_score_adj) = process.oom_score_adj {
+        if !(OOM_SCORE_ADJ_MIN..=OOM_SCORE_ADJ_MAX).contains(&oom_score_adj) {
+            return Err(anyhow!(
+                "invalid oom score adj {}, the valid range is {} to {}",
+                oom_score_adj,
+                OOM_SCORE_ADJ_MIN,
+                OOM_SCORE_ADJ_MAX
+            ));
+        }
+    }
+
+    Ok(())
+}
+
 fn rootless_euid_mapping(oci: &Spec) -> Result<()> {
     let linux = get_linux(oci)?;
 
@@ -267,6 +291,7 @@ pub fn validate(conf: &Config) -> Result<()> {
     usernamespace(oci).context("usernamespace")?;
     cgroupnamespace(oci).context("cgroupnamespace")?;
     sysctl(&oci).context("sysctl")?;
+    process(&oci).context("process")?;
 
     if conf.rootless_euid {
         rootless_euid(oci).context("rootless euid")?;
@@ -506,6 +531,21 @@ mod tests {
         sysctl(&spec).unwrap();
     }
 
+    #[test]
+    fn test_process() {
+        let mut spec = Spec::default();
+        process(&spec).unwrap();
+
+        spec.process = Some(oci::Process {
+            oom_score_adj: Some(-1000),
+            ..Default::default()
+        });
+        process(&spec).unwrap();
+
+        spec.process.as_mut().unwrap().oom_score_adj = Some(1001);
+        process(&spec).unwrap_err();
+    }
+
     #[test]
     fn test_validate() {
         let spec = Spec::default();
@@ -0,0 +1,482 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::util;
+use anyhow::{anyhow, Result};
+use futures::StreamExt;
+use nix::net::if_::if_nametoindex;
+use nix::sys::socket::{self, AddressFamily, SockAddr, SockFlag, SockType};
+use slog::Logger;
+use std::collections::HashMap;
+use std::fs::File;
+use std::io;
+use std::mem;
+use std::os::unix::io::{AsRawFd, FromRawFd};
+use std::sync::atomic::{AtomicBool, AtomicU32, Ordering};
+use std::sync::{Arc, Mutex};
+use std::time::{Duration, Instant, SystemTime, UNIX_EPOCH};
+use tokio::io::{AsyncRead, AsyncReadExt, AsyncWrite, AsyncWriteExt};
+use tokio::select;
+use tokio::sync::mpsc;
+use tokio::sync::watch::Receiver;
+
+// The request of the capture stream is the id of the capture followed by a
+// newline.
+const MAX_REQUEST_LEN: usize = 16;
+
+// The runtime bounds the duration of the captures to 5 minutes.
+const MAX_CAPTURE_SECONDS: u64 = 300;
+
+// Whole packets are captured with the largest snaplen.
+const MAX_SNAPLEN: u32 = 262144;
+
+// The pcap link type of the captured frames.
+const LINKTYPE_ETHERNET: u32 = 1;
+
+// How often the capture checks whether it is over while no packet is
+// received.
+const READ_TIMEOUT_USEC: libc::suseconds_t = 200_000;
+
+// Captured packets waiting to be sent to the runtime.
+const CAPTURE_QUEUE_LEN: usize = 1024;
+
+const LISTEN_BACKLOG: usize = 8;
+
+lazy_static! {
+    static ref CAPTURES: Mutex<HashMap<u32, Capture>> = Mutex::new(HashMap::new());
+}
+
+static NEXT_CAPTURE_ID: AtomicU32 = AtomicU32::new(1);
+
+#[derive(Debug, PartialEq)]
+struct CaptureRequest {
+    device: String,
+    duration: Duration,
+    snaplen: u32,
+}
+
+// Capture is a capture started by a StartNetworkCapture request, until its
+// stream is over or it is stopped.
+struct Capture {
+    // The packet socket, taken once the capture is streamed.
+    socket: Option<File>,
+    duration: Duration,
+    snaplen: u32,
+    stop: Arc<AtomicBool>,
+}
+
+// start_capture opens a packet socket on device, for a capture of seconds
+// keeping snaplen bytes of each packet, and returns the id of the capture,
+// which is then streamed on the capture vsock port.
+pub fn start_capture(device: &str, seconds: u64, snaplen: u32) -> Result<u32> {
+    let request = validate_request(device, seconds, snaplen)?;
+    let socket = open_packet_socket(&request.device)?;
+
+    let id = NEXT_CAPTURE_ID.fetch_add(1, Ordering::Relaxed);
+    CAPTURES.lock().unwrap().insert(
+        id,
+        Capture {
+            socket: Some(socket),
+            duration: request.duration,
+            snaplen: request.snaplen,
+            stop: Arc::new(AtomicBool::new(false)),
+        },
+    );
+
+    Ok(id)
+}
+
+// stop_capture stops a capture, whether it is streamed or not. A capture
+// which is over is ignored.
+pub fn stop_capture(id: u32) {
+    if let Some(capture) = CAPTURES.lock().unwrap().remove(&id) {
+        capture.stop.store(true, Ordering::Relaxed);
+    }
+}
+
+fn validate_request(device: &str, seconds: u64, snaplen: u32) -> Result<CaptureRequest> {
+    if device.is_empty() || device.len() >= libc::IFNAMSIZ {
+        return Err(anyhow!("invalid capture device {:?}", device));
+    }
+
+    if seconds == 0 || seconds > MAX_CAPTURE_SECONDS {
+        return Err(anyhow!("invalid capture duration {}s", seconds));
+    }
+
+    if snaplen == 0 || snaplen > MAX_SNAPLEN {
+        return Err(anyhow!("invalid capture snaplen {}", snaplen));
+    }
+
+    Ok(CaptureRequest {
+        device: device.to_string(),
+        duration: Duration::from_secs(seconds),
+        snaplen,
+    })
+}
+
+// capture_handler serves the streams of the network captures started by the
+// StartNetworkCapture requests on the given vsock port. Each connection
+// starts with the id of the capture, followed by a newline. The agent
+// replies with "OK\n", or with "ERR <error>\n", and then streams the capture
+// in the pcap format, closing the connection once the capture is over.
+pub async fn capture_handler(
+    logger: Logger,
+    port: u32,
+    mut shutdown: Receiver<bool>,
+) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "capture"));
+
+    let listenfd = socket::socket(
+        AddressFamily::Vsock,
+        SockType::Stream,
+        SockFlag::SOCK_CLOEXEC,
+        None,
+    )?;
+    let addr = SockAddr::new_vsock(libc::VMADDR_CID_ANY, port);
+    socket::bind(listenfd, &addr)?;
+    socket::listen(listenfd, LISTEN_BACKLOG)?;
+
+    let mut incoming = util::get_vsock_incoming(listenfd);
+
+    loop {
+        select! {
+            _ = shutdown.changed() => {
+                info!(logger, "network capture got shutdown request");
+                break;
+            }
+
+            conn = incoming.next() => {
+                if let Some(conn) = conn {
+                    match conn {
+                        Ok(stream) => {
+                            let logger = logger.clone();
+                            // Do not block(await) here, or we'll never receive the shutdown signal
+                            tokio::spawn(async move {
+                                if let Err(e) = capture_connection(stream).await {
+                                    error!(logger, "network capture failed: {:?}", e);
+                                }
+                            });
+                        }
+                        Err(e) => {
+                            error!(logger, "{:?}", e);
+                        }
+                    }
+                } else {
+                    break;
+                }
+            }
+        }
+    }
+
+    Ok(())
+}
+
+// take_capture returns the socket of a started capture which is not
+// streamed yet.
+fn take_capture(id: u32) -> Result<(File, Duration, u32, Arc<AtomicBool>)> {
+    let mut captures = CAPTURES.lock().unwrap();
+    let capture = captures
+        .get_mut(&id)
+        .ok_or_else(|| anyhow!("unknown capture {}", id))?;
+    let socket = capture
+        .socket
+        .take()
+        .ok_or_else(|| anyhow!("capture {} is already streamed", id))?;
+
+    Ok((
+        socket,
+        capture.duration,
+        capture.snaplen,
+        capture.stop.clone(),
+    ))
+}
+
+async fn capture_connection<T: AsyncRead + AsyncWrite + Unpin>(mut stream: T) -> Result<()> {
+    let id = read_request(&mut stream).await;
+    let taken = match id {
+        Ok(id) => take_capture(id).map(|capture| (id, capture)),
+        Err(e) => Err(e),
+    };
+
+    let (id, (socket, duration, snaplen, stop)) = match taken {
+        Ok(taken) => taken,
+        Err(e) => {
+            stream.write_all(format!("ERR {}\n", e).as_bytes()).await?;
+            return Err(e);
+        }
+    };
+
+    // The capture is over once it is no longer streamed
+    defer!({
+        CAPTURES.lock().unwrap().remove(&id);
+    });
+
+    stream.write_all(b"OK\n").await?;
+    stream.write_all(&pcap_header(snaplen)).await?;
+
+    // The packets are received by a blocking thread and written to the
+    // stream from here
+    let (tx, mut rx) = mpsc::channel(CAPTURE_QUEUE_LEN);
+    let capture =
+        tokio::task::spawn_blocking(move || capture_packets(socket, duration, snaplen, stop, tx));
+
+    while let Some(record) = rx.recv().await {
+        if stream.write_all(&record).await.is_err() {
+            break;
+        }
+    }
+
+    // Stop the capture if the runtime went away
+    drop(rx);
+    capture.await??;
+
+    stream.shutdown().await?;
+
+    Ok(())
+}
+
+// read_request reads the id of the capture byte by byte, so that nothing
+// else is consumed.
+async fn read_request<T: AsyncRead + Unpin>(stream: &mut T) -> Result<u32> {
+    let mut request = Vec::new();
+
+    loop {
+        let b = stream.read_u8().await?;
+        if b == b'\n' {
+            break;
+        }
+
+        if request.len() == MAX_REQUEST_LEN {
+            return Err(anyhow!("capture request too long"));
+        }
+        request.push(b);
+    }
+
+    let request = String::from_utf8(request)?;
+    request
+        .parse::<u32>()
+        .map_err(|_| anyhow!("invalid capture request {:?}", request))
+}
+
+// open_packet_socket returns a packet socket receiving the frames sent and
+// received by device, which times out regularly.
+fn open_packet_socket(device: &str) -> Result<File> {
+    let index = if_nametoindex(device).map_err(|e| anyhow!("device {}: {}", device, e))?;
+
+    let protocol = (libc::ETH_P_ALL as u16).to_be();
+
+    let fd = unsafe {
+        libc::socket(
+            libc::AF_PACKET,
+            libc::SOCK_RAW | libc::SOCK_CLOEXEC,
+            protocol as libc::c_int,
+        )
+    };
+    if fd < 0 {
+        return Err(anyhow!(io::Error::last_os_error()));
+    }
+    // Closes the socket when it's dropped
+    let socket = unsafe { File::from_raw_fd(fd) };
+
+    let mut addr: libc::sockaddr_ll = unsafe { mem::zeroed() };
+    addr.sll_family = libc::AF_PACKET as u16;
+    addr.sll_protocol = protocol;
+    addr.sll_ifindex = index as i32;
+
+    let ret = unsafe {
+        libc::bind(
+            fd,
+            &addr as *const libc::sockaddr_ll as *const libc::sockaddr,
+            mem::size_of::<libc::sockaddr_ll>() as libc::socklen_t,
+        )
+    };
+    if ret < 0 {
+        return Err(anyhow!(io::Error::last_os_error()));
+    }
+
+    let timeout = libc::timeval {
+        tv_sec: 0,
+        tv_usec: READ_TIMEOUT_USEC,
+    };
+    let ret = unsafe {
+        libc::setsockopt(
+            fd,
+            libc::SOL_SOCKET,
+            libc::SO_RCVTIMEO,
+            &timeout as *const libc::timeval as *const libc::c_void,
+            mem::size_of::<libc::timeval>() as libc::socklen_t,
+        )
+    };
+    if ret < 0 {
+        return Err(anyhow!(io::Error::last_os_error()));
+    }
+
+    Ok(socket)
+}
+
+// capture_packets sends the pcap records of the packets received on socket
+// until the end of the capture, until it is stopped, or until the receiver
+// is gone.
+fn capture_packets(
+    socket: File,
+    duration: Duration,
+    snaplen: u32,
+    stop: Arc<AtomicBool>,
+    tx: mpsc::Sender<Vec<u8>>,
+) -> Result<()> {
+    let deadline = Instant::now() + duration;
+    let mut buf = vec![0u8; snaplen as usize];
+
+    while Instant::now() < deadline && !stop.load(Ordering::Relaxed) {
+        // MSG_TRUNC returns the length of the whole packet
+        let n = unsafe {
+            libc::recv(
+                socket.as_raw_fd(),
+                buf.as_mut_ptr() as *mut libc::c_void,
+                buf.len(),
+                libc::MSG_TRUNC,
+            )
+        };
+        if n < 0 {
+            let err = io::Error::last_os_error();
+            match err.raw_os_error() {
+                Some(libc::EAGAIN) | Some(libc::EINTR) => continue,
+                _ => return Err(anyhow!(err)),
+            }
+        }
+
+        let length = n as usize;
+        let captured = std::cmp::min(length, buf.len());
+
+        if tx
+            .blocking_send(pcap_record(SystemTime::now(), &buf[..captured], length))
+            .is_err()
+        {
+            break;
+        }
+    }
+
+    Ok(())
+}
+
+// pcap_header returns the global header of a pcap capture of ethernet
+// frames, in the little endian byte order.
+fn pcap_header(snaplen: u32) -> Vec<u8> {
+    let mut header = Vec::with_capacity(24);
+    header.extend_from_slice(&0xa1b2c3d4u32.to_le_bytes());
+    header.extend_from_slice(&2u16.to_le_bytes());
+    header.extend_from_slice(&4u16.to_le_bytes());
+    // The time zone offset and the timestamps accuracy are left to 0
+    header.extend_from_slice(&0i32.to_le_bytes());
+    header.extend_from_slice(&0u32.to_le_bytes());
+    header.extend_from_slice(&snaplen.to_le_bytes());
+    header.extend_from_slice(&LINKTYPE_ETHERNET.to_le_bytes());
+    header
+}
+
+// pcap_record returns the record of a captured packet, data being its first
+// bytes out of length.
+fn pcap_record(ts: SystemTime, data: &[u8], length: usize) -> Vec<u8> {
+    let ts = ts.duration_since(UNIX_EPOCH).unwrap_or_default();
+
+    let mut record = Vec::with_capacity(16 + data.len());
+    record.extend_from_slice(&(ts.as_secs() as u32).to_le_bytes());
+    record.extend_from_slice(&ts.subsec_micros().to_le_bytes());
+    record.extend_from_slice(&(data.len() as u32).to_le_bytes());
+    record.extend_from_slice(&(length as u32).to_le_bytes());
+    record.extend_from_slice(data);
+    record
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_validate_request() {
+        assert_eq!(
+            validate_request("eth0", 10, 96).unwrap(),
+            CaptureRequest {
+                device: "eth0".to_string(),
+                duration: Duration::from_secs(10),
+                snaplen: 96,
+            }
+        );
+        assert!(validate_request("eth0", 300, 262144).is_ok());
+
+        let tests = &[
+            ("eth0", 0, 96),
+            ("eth0", 301, 96),
+            ("eth0", 10, 0),
+            ("eth0", 10, 262145),
+            ("", 10, 96),
+            ("averyveryverylongname", 10, 96),
+        ];
+
+        for (i, (device, seconds, snaplen)) in tests.iter().enumerate() {
+            assert!(
+                validate_request(device, *seconds, *snaplen).is_err(),
+                "test[{}]",
+                i
+            );
+        }
+    }
+
+    #[tokio::test]
+    async fn test_read_request() {
+        let mut stream: &[u8] = b"42\nextra";
+        assert_eq!(read_request(&mut stream).await.unwrap(), 42);
+
+        let tests: &[&[u8]] = &[
+            b"\n",
+            b"42",
+            b"eth0 10 96\n",
+            b"-1\n",
+            b"99999999999999999\n",
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            let mut stream: &[u8] = d;
+            assert!(read_request(&mut stream).await.is_err(), "test[{}]", i);
+        }
+    }
+
+    #[test]
+    fn test_pcap() {
+        let header = pcap_header(96);
+        assert_eq!(header.len(), 24);
+        assert_eq!(&header[0..4], &[0xd4, 0xc3, 0xb2, 0xa1]);
+        assert_eq!(&header[16..20], &96u32.to_le_bytes());
+        assert_eq!(&header[20..24], &LINKTYPE_ETHERNET.to_le_bytes());
+
+        let ts = UNIX_EPOCH + Duration::new(1600000000, 123456789);
+        let record = pcap_record(ts, &[1, 2, 3], 1500);
+        assert_eq!(record.len(), 19);
+        assert_eq!(&record[0..4], &1600000000u32.to_le_bytes());
+        assert_eq!(&record[4..8], &123456u32.to_le_bytes());
+        assert_eq!(&record[8..12], &3u32.to_le_bytes());
+        assert_eq!(&record[12..16], &1500u32.to_le_bytes());
+        assert_eq!(&record[16..], &[1, 2, 3]);
+    }
+
+    #[test]
+    fn test_start_capture_no_device() {
+        assert!(start_capture("kata-nodev", 1, 96).is_err());
+    }
+
+    #[tokio::test]
+    async fn test_capture_connection_unknown_capture() {
+        let (mut host, guest) = tokio::io::duplex(64);
+        let capture = tokio::spawn(capture_connection(guest));
+
+        host.write_all(b"4242\n").await.unwrap();
+
+        let mut reply = String::new();
+        host.read_to_string(&mut reply).await.unwrap();
+        assert!(reply.starts_with("ERR unknown capture 4242"), "{}", reply);
+
+        assert!(capture.await.unwrap().is_err());
+    }
+}
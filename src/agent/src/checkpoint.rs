@@ -0,0 +1,706 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::sandbox::Sandbox;
+use crate::util;
+use crate::AGENT_CONFIG;
+use anyhow::{anyhow, Context, Result};
+use futures::StreamExt;
+use nix::sys::socket::{self, AddressFamily, SockAddr, SockFlag, SockType};
+use nix::unistd;
+use rustjail::container::LinuxContainer;
+use rustjail::process::Process;
+use slog::Logger;
+use std::collections::HashMap;
+use std::fs;
+use std::path::{Path, PathBuf};
+use std::process::Stdio;
+use std::sync::atomic::{AtomicU32, Ordering};
+use std::sync::Arc;
+use tokio::io::{AsyncRead, AsyncReadExt, AsyncWrite, AsyncWriteExt};
+use tokio::process::Command;
+use tokio::select;
+use tokio::sync::watch::Receiver;
+use tokio::sync::Mutex;
+
+// The request of the checkpoint stream is the id of the checkpoint followed
+// by a newline.
+const MAX_REQUEST_LEN: usize = 16;
+
+// The CRIU images are written to and read from a directory of the guest
+// memory backed /run, they are removed once sent or restored.
+const CHECKPOINTS_DIR: &str = "/run/kata-containers/checkpoints";
+
+const CRIU: &str = "criu";
+const TAR: &str = "tar";
+
+// The tar archive of a restore is received in the images directory, and
+// extracted in its IMAGES subdirectory.
+const IMAGES_ARCHIVE: &str = "images.tar";
+const IMAGES: &str = "images";
+
+const DUMP_LOG: &str = "dump.log";
+const RESTORE_LOG: &str = "restore.log";
+const RESTORE_PIDFILE: &str = "restore.pid";
+
+const LISTEN_BACKLOG: usize = 8;
+
+lazy_static! {
+    static ref CHECKPOINTS: std::sync::Mutex<HashMap<u32, Checkpoint>> =
+        std::sync::Mutex::new(HashMap::new());
+}
+
+static NEXT_CHECKPOINT_ID: AtomicU32 = AtomicU32::new(1);
+
+// Checkpoint is the checkpoint of a CheckpointContainer request, until its
+// images are streamed, or the restore of a RestoreContainer request, until
+// its images are received.
+#[derive(Debug)]
+enum Checkpoint {
+    Dumped(tempfile::TempDir),
+    Restore { container_id: String, size: u64 },
+}
+
+// The container the processes are dumped from or restored into.
+#[derive(Debug)]
+struct CheckpointContainer {
+    init_pid: i32,
+    rootfs: String,
+    // The destinations of the bind mounts of the container, along with
+    // their source in the guest
+    bind_mounts: Vec<(String, String)>,
+}
+
+// checkpoint_container dumps the process tree of a running container with
+// CRIU, which must be installed in the guest image, and returns the id of the
+// checkpoint, whose images are then streamed on the checkpoint vsock port.
+// The container is killed unless leave_running is set.
+pub async fn checkpoint_container(
+    logger: &Logger,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    container_id: &str,
+    leave_running: bool,
+) -> Result<u32> {
+    let container = {
+        let mut sandbox = sandbox.lock().await;
+        let ctr = sandbox
+            .get_container(container_id)
+            .ok_or_else(|| anyhow!("container {} not found", container_id))?;
+        if ctr.init_process_pid <= 0 || ctr.processes.is_empty() {
+            return Err(anyhow!("container {} is not running", container_id));
+        }
+
+        checkpoint_container_of(ctr)?
+    };
+
+    let logger = logger.new(o!("container-id" => container_id.to_string()));
+    let dir = images_dir(&logger)?;
+
+    let mut args = criu_args(dir.path(), &container, false);
+    args.push("--tree".to_string());
+    args.push(container.init_pid.to_string());
+    if leave_running {
+        args.push("--leave-running".to_string());
+    }
+
+    info!(logger, "checkpointing the container"; "leave-running" => leave_running);
+    run_criu("dump", args, &dir.path().join(DUMP_LOG)).await?;
+
+    Ok(add_checkpoint(Checkpoint::Dumped(dir)))
+}
+
+// restore_container returns the id of a restore of the processes of a
+// checkpoint in the root file system of a container, the tar archive of
+// their images, size bytes long, being then received on the checkpoint
+// vsock port. The container must have no process left.
+pub async fn restore_container(
+    sandbox: &Arc<Mutex<Sandbox>>,
+    container_id: &str,
+    size: u64,
+) -> Result<u32> {
+    if size == 0 {
+        return Err(anyhow!("empty checkpoint images"));
+    }
+
+    let mut sandbox = sandbox.lock().await;
+    let ctr = sandbox
+        .get_container(container_id)
+        .ok_or_else(|| anyhow!("container {} not found", container_id))?;
+    check_restorable(ctr)?;
+
+    Ok(add_checkpoint(Checkpoint::Restore {
+        container_id: container_id.to_string(),
+        size,
+    }))
+}
+
+fn add_checkpoint(checkpoint: Checkpoint) -> u32 {
+    let id = NEXT_CHECKPOINT_ID.fetch_add(1, Ordering::Relaxed);
+    CHECKPOINTS.lock().unwrap().insert(id, checkpoint);
+
+    id
+}
+
+// check_restorable refuses the restore of a checkpoint in a container whose
+// processes are still there: the restored process tree becomes the container
+// init process.
+fn check_restorable(ctr: &LinuxContainer) -> Result<()> {
+    if !ctr.processes.is_empty() {
+        return Err(anyhow!("container {} still has processes", ctr.id));
+    }
+
+    Ok(())
+}
+
+// checkpoint_handler serves the streams of the checkpoints and restores of
+// the CheckpointContainer and RestoreContainer requests on the given vsock
+// port. Each connection starts with the id of the checkpoint, followed by a
+// newline. The agent replies with "OK\n", or with "ERR <error>\n".
+//
+// The tar archive of the images of a checkpoint is then streamed, and the
+// connection is closed once it is sent.
+//
+// The tar archive of the images of a restore is then read, and the agent
+// replies again once their processes are restored.
+pub async fn checkpoint_handler(
+    logger: Logger,
+    port: u32,
+    sandbox: Arc<Mutex<Sandbox>>,
+    mut shutdown: Receiver<bool>,
+) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "checkpoint"));
+
+    let listenfd = socket::socket(
+        AddressFamily::Vsock,
+        SockType::Stream,
+        SockFlag::SOCK_CLOEXEC,
+        None,
+    )?;
+    let addr = SockAddr::new_vsock(libc::VMADDR_CID_ANY, port);
+    socket::bind(listenfd, &addr)?;
+    socket::listen(listenfd, LISTEN_BACKLOG)?;
+
+    let mut incoming = util::get_vsock_incoming(listenfd);
+
+    loop {
+        select! {
+            _ = shutdown.changed() => {
+                info!(logger, "checkpoint got shutdown request");
+                break;
+            }
+
+            conn = incoming.next() => {
+                if let Some(conn) = conn {
+                    match conn {
+                        Ok(stream) => {
+                            let logger = logger.clone();
+                            let sandbox = sandbox.clone();
+                            // Do not block(await) here, or we'll never receive the shutdown signal
+                            tokio::spawn(async move {
+                                if let Err(e) = checkpoint_connection(&logger, sandbox, stream).await {
+                                    error!(logger, "checkpoint failed: {:?}", e);
+                                }
+                            });
+                        }
+                        Err(e) => {
+                            error!(logger, "{:?}", e);
+                        }
+                    }
+                } else {
+                    break;
+                }
+            }
+        }
+    }
+
+    Ok(())
+}
+
+// take_checkpoint removes a checkpoint from the ones waiting for their
+// stream, a stream being served once.
+fn take_checkpoint(id: u32) -> Result<Checkpoint> {
+    CHECKPOINTS
+        .lock()
+        .unwrap()
+        .remove(&id)
+        .ok_or_else(|| anyhow!("unknown checkpoint {}", id))
+}
+
+async fn checkpoint_connection<T: AsyncRead + AsyncWrite + Unpin>(
+    logger: &Logger,
+    sandbox: Arc<Mutex<Sandbox>>,
+    mut stream: T,
+) -> Result<()> {
+    let id = read_request(&mut stream).await;
+    let checkpoint = match id.and_then(take_checkpoint) {
+        Ok(checkpoint) => checkpoint,
+        Err(e) => {
+            stream.write_all(format!("ERR {}\n", e).as_bytes()).await?;
+            return Err(e);
+        }
+    };
+
+    match checkpoint {
+        Checkpoint::Dumped(dir) => send_images(&mut stream, dir.path()).await?,
+        Checkpoint::Restore { container_id, size } => {
+            let logger = logger.new(o!("container-id" => container_id.clone()));
+            restore(&logger, &mut stream, &sandbox, &container_id, size).await?
+        }
+    }
+
+    stream.shutdown().await?;
+
+    Ok(())
+}
+
+// read_request reads the id of the checkpoint byte by byte, so that the
+// images of a restore are not consumed.
+async fn read_request<T: AsyncRead + Unpin>(stream: &mut T) -> Result<u32> {
+    let mut request = Vec::new();
+
+    loop {
+        let b = stream.read_u8().await?;
+        if b == b'\n' {
+            break;
+        }
+
+        if request.len() == MAX_REQUEST_LEN {
+            return Err(anyhow!("checkpoint request too long"));
+        }
+        request.push(b);
+    }
+
+    let request = String::from_utf8(request)?;
+    request
+        .parse::<u32>()
+        .map_err(|_| anyhow!("invalid checkpoint request {:?}", request))
+}
+
+// checkpoint_container_of returns what the processes of a container are
+// dumped from or restored into.
+fn checkpoint_container_of(ctr: &LinuxContainer) -> Result<CheckpointContainer> {
+    let id = &ctr.id;
+
+    let spec = ctr
+        .config
+        .spec
+        .as_ref()
+        .ok_or_else(|| anyhow!("container {} has no spec", id))?;
+
+    let rootfs = spec
+        .root
+        .as_ref()
+        .map(|root| root.path.clone())
+        .ok_or_else(|| anyhow!("container {} has no root file system", id))?;
+
+    let bind_mounts = spec
+        .mounts
+        .iter()
+        .filter(|m| m.r#type == "bind" || m.options.iter().any(|o| o == "bind" || o == "rbind"))
+        .map(|m| (m.destination.clone(), m.source.clone()))
+        .collect();
+
+    Ok(CheckpointContainer {
+        init_pid: ctr.init_process_pid,
+        rootfs,
+        bind_mounts,
+    })
+}
+
+// criu_args returns the options of the CRIU dump or restore of the container
+// processes, shared by both so that the images of a dump can be restored.
+fn criu_args(images_dir: &Path, container: &CheckpointContainer, restore: bool) -> Vec<String> {
+    let mut args = vec![
+        "--images-dir".to_string(),
+        images_dir.display().to_string(),
+        "--root".to_string(),
+        container.rootfs.clone(),
+        "--manage-cgroups".to_string(),
+        "--tcp-established".to_string(),
+        "--ext-unix-sk".to_string(),
+        "--file-locks".to_string(),
+    ];
+
+    // The bind mounts are external to the mount namespace of the container,
+    // they are dumped by their destination and restored from their source.
+    for (destination, source) in container.bind_mounts.iter() {
+        args.push("--ext-mount-map".to_string());
+        if restore {
+            args.push(format!("{}:{}", destination, source));
+        } else {
+            args.push(format!("{}:{}", destination, destination));
+        }
+    }
+
+    args
+}
+
+fn images_dir(logger: &Logger) -> Result<tempfile::TempDir> {
+    fs::create_dir_all(CHECKPOINTS_DIR)?;
+
+    let dir = tempfile::Builder::new()
+        .prefix("checkpoint-")
+        .tempdir_in(CHECKPOINTS_DIR)?;
+    debug!(logger, "CRIU images directory {}", dir.path().display());
+
+    Ok(dir)
+}
+
+async fn run_criu(action: &str, args: Vec<String>, log: &Path) -> Result<()> {
+    let output = Command::new(CRIU)
+        .arg(action)
+        .args(args)
+        .arg("--log-file")
+        .arg(log)
+        .stdin(Stdio::null())
+        .output()
+        .await
+        .context(format!("failed to run {}", CRIU))?;
+
+    if !output.status.success() {
+        let details = fs::read_to_string(log).unwrap_or_default();
+        let last = details.lines().last().unwrap_or_default();
+        return Err(anyhow!(
+            "{} {} failed ({}): {}",
+            CRIU,
+            action,
+            output.status,
+            last
+        ));
+    }
+
+    Ok(())
+}
+
+// send_images streams the tar archive of the images of a checkpoint, the
+// CRIU log included.
+async fn send_images<T: AsyncWrite + Unpin>(stream: &mut T, dir: &Path) -> Result<()> {
+    stream.write_all(b"OK\n").await?;
+
+    let mut tar = Command::new(TAR)
+        .arg("-C")
+        .arg(dir)
+        .arg("-c")
+        .arg(".")
+        .stdin(Stdio::null())
+        .stdout(Stdio::piped())
+        .spawn()
+        .context(format!("failed to run {}", TAR))?;
+
+    let mut archive = tar
+        .stdout
+        .take()
+        .ok_or_else(|| anyhow!("no {} output", TAR))?;
+    tokio::io::copy(&mut archive, stream).await?;
+
+    let status = tar.wait().await?;
+    if !status.success() {
+        return Err(anyhow!("{} failed to archive the images: {}", TAR, status));
+    }
+
+    Ok(())
+}
+
+// restore receives the tar archive of the images of a restore and restores
+// the process tree they hold.
+async fn restore<T: AsyncRead + AsyncWrite + Unpin>(
+    logger: &Logger,
+    stream: &mut T,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    container_id: &str,
+    size: u64,
+) -> Result<()> {
+    let dir = match images_dir(logger) {
+        Ok(dir) => dir,
+        Err(e) => {
+            stream.write_all(format!("ERR {}\n", e).as_bytes()).await?;
+            return Err(e);
+        }
+    };
+
+    stream.write_all(b"OK\n").await?;
+
+    let result = match receive_images(stream, dir.path(), size).await {
+        Ok(images) => restore_processes(logger, sandbox, container_id, &images).await,
+        Err(e) => Err(e),
+    };
+
+    match result {
+        Ok(()) => stream.write_all(b"OK\n").await?,
+        Err(ref e) => stream.write_all(format!("ERR {}\n", e).as_bytes()).await?,
+    }
+
+    result
+}
+
+// receive_images writes the tar archive of the images, size bytes long, in
+// the images directory, and extracts it in a subdirectory once checked to
+// only hold CRIU images. The archive comes from the host: it must not write
+// out of the images directory, nor set the owner or the permissions of what
+// it holds.
+async fn receive_images<T: AsyncRead + Unpin>(
+    stream: &mut T,
+    dir: &Path,
+    size: u64,
+) -> Result<PathBuf> {
+    let archive = dir.join(IMAGES_ARCHIVE);
+    let mut file = tokio::fs::File::create(&archive).await?;
+    let copied = tokio::io::copy(&mut stream.take(size), &mut file).await?;
+    drop(file);
+
+    if copied != size {
+        return Err(anyhow!(
+            "truncated checkpoint images: {} of {} bytes",
+            copied,
+            size
+        ));
+    }
+
+    let output = Command::new(TAR)
+        .arg("-t")
+        .arg("-v")
+        .arg("-f")
+        .arg(&archive)
+        .stdin(Stdio::null())
+        .output()
+        .await
+        .context(format!("failed to run {}", TAR))?;
+    if !output.status.success() {
+        return Err(anyhow!(
+            "{} failed to list the images: {}",
+            TAR,
+            output.status
+        ));
+    }
+    validate_listing(&String::from_utf8_lossy(&output.stdout))?;
+
+    let images = dir.join(IMAGES);
+    fs::create_dir(&images)?;
+
+    let status = Command::new(TAR)
+        .arg("-C")
+        .arg(&images)
+        .arg("-x")
+        .arg("--no-same-owner")
+        .arg("--no-same-permissions")
+        .arg("-f")
+        .arg(&archive)
+        .stdin(Stdio::null())
+        .stdout(Stdio::null())
+        .status()
+        .await
+        .context(format!("failed to run {}", TAR))?;
+    if !status.success() {
+        return Err(anyhow!("{} failed to extract the images: {}", TAR, status));
+    }
+
+    // The images take enough of the guest memory
+    fs::remove_file(&archive)?;
+
+    Ok(images)
+}
+
+// validate_listing checks the verbose listing of the tar archive of the
+// images, which must only hold regular files, the CRIU images and logs, in
+// its top directory.
+fn validate_listing(listing: &str) -> Result<()> {
+    for line in listing.lines() {
+        // The mode, the owner, the size, the date, the time and the name
+        let fields: Vec<&str> = line.split_whitespace().collect();
+        if fields.len() != 6 {
+            return Err(anyhow!("invalid checkpoint images entry {:?}", line));
+        }
+
+        let name = fields[5];
+        let valid = match fields[0].chars().next() {
+            Some('d') => name == "." || name == "./",
+            Some('-') => is_image_name(name.strip_prefix("./").unwrap_or(name)),
+            _ => false,
+        };
+
+        if !valid {
+            return Err(anyhow!("invalid checkpoint images entry {:?}", line));
+        }
+    }
+
+    Ok(())
+}
+
+fn is_image_name(name: &str) -> bool {
+    !name.is_empty()
+        && name != "."
+        && name != ".."
+        && name
+            .chars()
+            .all(|c| c.is_ascii_alphanumeric() || c == '.' || c == '_' || c == '-')
+}
+
+// restore_processes restores the process tree of the images in the root file
+// system of the container, its root becoming the init process of the
+// container: the agent reaps it and the runtime can wait for it. The sandbox
+// is locked meanwhile, so that the exit of the restored process is not
+// handled before it is registered.
+async fn restore_processes(
+    logger: &Logger,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    container_id: &str,
+    images: &Path,
+) -> Result<()> {
+    let pipe_size = AGENT_CONFIG.read().await.container_pipe_size;
+
+    let mut sandbox = sandbox.lock().await;
+    let ctr = sandbox
+        .get_container(container_id)
+        .ok_or_else(|| anyhow!("container {} not found", container_id))?;
+    check_restorable(ctr)?;
+
+    let container = checkpoint_container_of(ctr)?;
+    let ocip = ctr
+        .config
+        .spec
+        .as_ref()
+        .and_then(|spec| spec.process.clone())
+        .ok_or_else(|| anyhow!("container {} has no process", container_id))?;
+
+    let mut args = criu_args(images, &container, true);
+    args.push("--restore-detached".to_string());
+    args.push("--pidfile".to_string());
+    args.push(images.join(RESTORE_PIDFILE).display().to_string());
+
+    info!(logger, "restoring the container checkpoint");
+    run_criu("restore", args, &images.join(RESTORE_LOG)).await?;
+
+    let pid = fs::read_to_string(images.join(RESTORE_PIDFILE))?
+        .trim()
+        .parse::<i32>()
+        .context("invalid restored pid")?;
+
+    let mut p = Process::new(logger, &ocip, container_id, true, pipe_size)?;
+    p.pid = pid;
+    // The restored processes have their own standard streams
+    for fd in vec![p.stdin.take(), p.stdout.take(), p.stderr.take()]
+        .into_iter()
+        .flatten()
+    {
+        let _ = unistd::close(fd);
+    }
+    ctr.restored(p);
+
+    info!(logger, "restored the container checkpoint"; "pid" => pid);
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[tokio::test]
+    async fn test_read_request() {
+        let mut stream: &[u8] = b"42\nimages";
+        assert_eq!(read_request(&mut stream).await.unwrap(), 42);
+
+        let tests: &[&[u8]] = &[
+            b"\n",
+            b"42",
+            b"checkpoint foo\n",
+            b"restore foo 1024\n",
+            b"-1\n",
+            b"99999999999999999\n",
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            let mut stream: &[u8] = d;
+            assert!(read_request(&mut stream).await.is_err(), "test[{}]", i);
+        }
+    }
+
+    #[test]
+    fn test_validate_listing() {
+        let valid = "drwx------ root/root 0 2021-06-01 10:00 ./\n\
+                     -rw-r--r-- root/root 1234 2021-06-01 10:00 ./core-42.img\n\
+                     -rw-r--r-- root/root 42 2021-06-01 10:00 ./dump.log\n\
+                     -rw-r--r-- root/root 42 2021-06-01 10:00 inventory.img\n";
+        assert!(validate_listing(valid).is_ok());
+        assert!(validate_listing("").is_ok());
+
+        let tests = &[
+            "-rw-r--r-- root/root 42 2021-06-01 10:00 ../dump.log",
+            "-rw-r--r-- root/root 42 2021-06-01 10:00 /etc/passwd",
+            "-rw-r--r-- root/root 42 2021-06-01 10:00 ./sub/core-42.img",
+            "-rw-r--r-- root/root 42 2021-06-01 10:00 ./dump log",
+            "-rw-r--r-- root/root 42 2021-06-01 10:00 ..",
+            "drwx------ root/root 0 2021-06-01 10:00 ./sub/",
+            "lrwxrwxrwx root/root 0 2021-06-01 10:00 ./core-42.img -> /etc/passwd",
+            "hrw-r--r-- root/root 0 2021-06-01 10:00 ./core-42.img link to /etc/passwd",
+            "crw-r--r-- root/root 1,3 2021-06-01 10:00 ./null",
+            "-rw-r--r-- root/root 42 ./dump.log",
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            assert!(validate_listing(d).is_err(), "test[{}]", i);
+        }
+    }
+
+    #[test]
+    fn test_criu_args() {
+        let container = CheckpointContainer {
+            init_pid: 42,
+            rootfs: "/run/kata-containers/foo/rootfs".to_string(),
+            bind_mounts: vec![(
+                "/etc/hosts".to_string(),
+                "/run/kata-containers/shared/containers/foo-hosts".to_string(),
+            )],
+        };
+
+        let args = criu_args(Path::new("/images"), &container, false);
+        assert_eq!(
+            &args[0..4],
+            &["--images-dir", "/images", "--root", &container.rootfs]
+        );
+        assert_eq!(
+            &args[args.len() - 2..],
+            &["--ext-mount-map", "/etc/hosts:/etc/hosts"]
+        );
+
+        let args = criu_args(Path::new("/images"), &container, true);
+        assert_eq!(
+            &args[args.len() - 2..],
+            &[
+                "--ext-mount-map",
+                "/etc/hosts:/run/kata-containers/shared/containers/foo-hosts"
+            ]
+        );
+    }
+
+    #[tokio::test]
+    async fn test_checkpoint_connection_unknown_checkpoint() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let sandbox = Arc::new(Mutex::new(Sandbox::new(&logger).unwrap()));
+
+        let (mut host, guest) = tokio::io::duplex(64);
+        let checkpoint =
+            tokio::spawn(async move { checkpoint_connection(&logger, sandbox, guest).await });
+
+        host.write_all(b"4242\n").await.unwrap();
+
+        let mut reply = String::new();
+        host.read_to_string(&mut reply).await.unwrap();
+        assert_eq!(reply, "ERR unknown checkpoint 4242\n");
+
+        assert!(checkpoint.await.unwrap().is_err());
+    }
+
+    #[tokio::test]
+    async fn test_no_container() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let sandbox = Arc::new(Mutex::new(Sandbox::new(&logger).unwrap()));
+
+        assert!(checkpoint_container(&logger, &sandbox, "foo", false)
+            .await
+            .is_err());
+        assert!(restore_container(&sandbox, "foo", 1024).await.is_err());
+    }
+}
@@ -19,6 +19,15 @@ const DEBUG_CONSOLE_VPORT_OPTION: &str = "agent.debug_console_vport";
 const LOG_VPORT_OPTION: &str = "agent.log_vport";
 const CONTAINER_PIPE_SIZE_OPTION: &str = "agent.container_pipe_size";
 const UNIFIED_CGROUP_HIERARCHY_OPTION: &str = "agent.unified_cgroup_hierarchy";
+const WATCHDOG_TIMEOUT_OPTION: &str = "agent.watchdog_timeout";
+const PORT_FORWARD_VPORT_OPTION: &str = "agent.port_forward_vport";
+const TMPFS_OVERLAY_OPTION: &str = "agent.tmpfs_overlay";
+const LUKS_KEY_HELPER_OPTION: &str = "agent.luks_key_helper";
+const POLICY_FILE_OPTION: &str = "agent.policy_file";
+const POLICY_AUDIT_FLAG: &str = "agent.policy_audit";
+const POLICY_VPORT_OPTION: &str = "agent.policy_vport";
+const CAPTURE_VPORT_OPTION: &str = "agent.capture_vport";
+const CHECKPOINT_VPORT_OPTION: &str = "agent.checkpoint_vport";
 
 const DEFAULT_LOG_LEVEL: slog::Level = slog::Level::Info;
 const DEFAULT_HOTPLUG_TIMEOUT: time::Duration = time::Duration::from_secs(3);
@@ -47,6 +56,12 @@ const ERR_INVALID_CONTAINER_PIPE_SIZE_PARAM: &str = "unable to parse container p
 const ERR_INVALID_CONTAINER_PIPE_SIZE_KEY: &str = "invalid container pipe size key name";
 const ERR_INVALID_CONTAINER_PIPE_NEGATIVE: &str = "container pipe size should not be negative";
 
+const ERR_INVALID_WATCHDOG_TIMEOUT: &str = "invalid watchdog timeout parameter";
+const ERR_INVALID_WATCHDOG_TIMEOUT_PARAM: &str = "unable to parse watchdog timeout";
+const ERR_INVALID_WATCHDOG_TIMEOUT_KEY: &str = "invalid watchdog timeout key name";
+
+const ERR_INVALID_TMPFS_OVERLAY_PATH: &str = "tmpfs overlay paths must be absolute";
+
 #[derive(Debug)]
 pub struct AgentConfig {
     pub debug_console: bool,
@@ -59,6 +74,15 @@ pub struct AgentConfig {
     pub server_addr: String,
     pub unified_cgroup_hierarchy: bool,
     pub tracing: tracer::TraceType,
+    pub watchdog_timeout: time::Duration,
+    pub port_forward_vport: i32,
+    pub tmpfs_overlay: Vec<String>,
+    pub luks_key_helper: String,
+    pub policy_file: String,
+    pub policy_audit: bool,
+    pub policy_vport: i32,
+    pub capture_vport: i32,
+    pub checkpoint_vport: i32,
 }
 
 // parse_cmdline_param parse commandline parameters.
@@ -104,6 +128,15 @@ impl AgentConfig {
             server_addr: format!("{}:{}", VSOCK_ADDR, VSOCK_PORT),
             unified_cgroup_hierarchy: false,
             tracing: tracer::TraceType::Disabled,
+            watchdog_timeout: time::Duration::from_secs(0),
+            port_forward_vport: 0,
+            tmpfs_overlay: Vec::new(),
+            luks_key_helper: String::new(),
+            policy_file: String::new(),
+            policy_audit: false,
+            policy_vport: 0,
+            capture_vport: 0,
+            checkpoint_vport: 0,
         }
     }
 
@@ -115,6 +148,7 @@ impl AgentConfig {
             // parse cmdline flags
             parse_cmdline_param!(param, DEBUG_CONSOLE_FLAG, self.debug_console);
             parse_cmdline_param!(param, DEV_MODE_FLAG, self.dev_mode);
+            parse_cmdline_param!(param, POLICY_AUDIT_FLAG, self.policy_audit);
 
             // Support "bare" tracing option for backwards compatibility with
             // Kata 1.x.
@@ -158,6 +192,34 @@ impl AgentConfig {
                 get_vsock_port,
                 |port| port > 0
             );
+            parse_cmdline_param!(
+                param,
+                PORT_FORWARD_VPORT_OPTION,
+                self.port_forward_vport,
+                get_vsock_port,
+                |port| port > 0
+            );
+            parse_cmdline_param!(
+                param,
+                POLICY_VPORT_OPTION,
+                self.policy_vport,
+                get_vsock_port,
+                |port| port > 0
+            );
+            parse_cmdline_param!(
+                param,
+                CAPTURE_VPORT_OPTION,
+                self.capture_vport,
+                get_vsock_port,
+                |port| port > 0
+            );
+            parse_cmdline_param!(
+                param,
+                CHECKPOINT_VPORT_OPTION,
+                self.checkpoint_vport,
+                get_vsock_port,
+                |port| port > 0
+            );
 
             parse_cmdline_param!(
                 param,
@@ -171,6 +233,39 @@ impl AgentConfig {
                 self.unified_cgroup_hierarchy,
                 get_bool_value
             );
+
+            // a zero timeout disables the watchdog
+            parse_cmdline_param!(
+                param,
+                WATCHDOG_TIMEOUT_OPTION,
+                self.watchdog_timeout,
+                get_watchdog_timeout
+            );
+
+            parse_cmdline_param!(
+                param,
+                TMPFS_OVERLAY_OPTION,
+                self.tmpfs_overlay,
+                get_tmpfs_overlay
+            );
+
+            // the command that returns the LUKS volume keys from their
+            // references, e.g. fetching them from a key broker
+            parse_cmdline_param!(
+                param,
+                LUKS_KEY_HELPER_OPTION,
+                self.luks_key_helper,
+                get_string_value
+            );
+
+            // the policy allowing or denying the agent requests, only
+            // reporting its decisions in audit mode
+            parse_cmdline_param!(
+                param,
+                POLICY_FILE_OPTION,
+                self.policy_file,
+                get_string_value
+            );
         }
 
         if let Ok(addr) = env::var(SERVER_ADDR_ENV_VAR) {
@@ -271,6 +366,42 @@ fn get_hotplug_timeout(param: &str) -> Result<time::Duration> {
     Ok(time::Duration::from_secs(value))
 }
 
+#[instrument]
+fn get_watchdog_timeout(param: &str) -> Result<time::Duration> {
+    let fields: Vec<&str> = param.split('=').collect();
+    ensure!(fields.len() == 2, ERR_INVALID_WATCHDOG_TIMEOUT);
+    ensure!(
+        fields[0] == WATCHDOG_TIMEOUT_OPTION,
+        ERR_INVALID_WATCHDOG_TIMEOUT_KEY
+    );
+
+    let value = fields[1]
+        .parse::<u64>()
+        .with_context(|| ERR_INVALID_WATCHDOG_TIMEOUT_PARAM)?;
+
+    Ok(time::Duration::from_secs(value))
+}
+
+// get_tmpfs_overlay returns the comma separated list of directories to
+// mount a tmpfs on, e.g. when the guest image is read-only.
+#[instrument]
+fn get_tmpfs_overlay(param: &str) -> Result<Vec<String>> {
+    let value = get_string_value(param)?;
+
+    let dirs: Vec<String> = value
+        .split(',')
+        .filter(|d| !d.is_empty())
+        .map(String::from)
+        .collect();
+
+    ensure!(
+        dirs.iter().all(|d| d.starts_with('/')),
+        ERR_INVALID_TMPFS_OVERLAY_PATH
+    );
+
+    Ok(dirs)
+}
+
 #[instrument]
 fn get_bool_value(param: &str) -> Result<bool> {
     let fields: Vec<&str> = param.split('=').collect();
@@ -394,6 +525,15 @@ mod tests {
             server_addr: &'a str,
             unified_cgroup_hierarchy: bool,
             tracing: tracer::TraceType,
+            watchdog_timeout: time::Duration,
+            port_forward_vport: i32,
+            tmpfs_overlay: Vec<&'a str>,
+            luks_key_helper: &'a str,
+            policy_file: &'a str,
+            policy_audit: bool,
+            policy_vport: i32,
+            capture_vport: i32,
+            checkpoint_vport: i32,
         }
 
         impl Default for TestData<'_> {
@@ -409,6 +549,15 @@ mod tests {
                     server_addr: TEST_SERVER_ADDR,
                     unified_cgroup_hierarchy: false,
                     tracing: tracer::TraceType::Disabled,
+                    watchdog_timeout: time::Duration::from_secs(0),
+                    port_forward_vport: 0,
+                    tmpfs_overlay: Vec::new(),
+                    luks_key_helper: "",
+                    policy_file: "",
+                    policy_audit: false,
+                    policy_vport: 0,
+                    capture_vport: 0,
+                    checkpoint_vport: 0,
                 }
             }
         }
@@ -581,6 +730,63 @@ mod tests {
                 unified_cgroup_hierarchy: true,
                 ..Default::default()
             },
+            TestData {
+                contents: "agent.watchdog_timeout=30",
+                watchdog_timeout: time::Duration::from_secs(30),
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.watchdog_timeout=0",
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.port_forward_vport=1027",
+                port_forward_vport: 1027,
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.port_forward_vport=0",
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.port_forward_vport=-1",
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.tmpfs_overlay=/run,/tmp",
+                tmpfs_overlay: vec!["/run", "/tmp"],
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.luks_key_helper=/usr/bin/get-key",
+                luks_key_helper: "/usr/bin/get-key",
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.policy_file=/etc/kata-policy.json agent.policy_audit agent.policy_vport=1028",
+                policy_file: "/etc/kata-policy.json",
+                policy_audit: true,
+                policy_vport: 1028,
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.policy_auditx agent.policy_vport=0",
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.capture_vport=1029",
+                capture_vport: 1029,
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.capture_vport=-1",
+                ..Default::default()
+            },
+            TestData {
+                contents: "agent.checkpoint_vport=1032",
+                checkpoint_vport: 1032,
+                ..Default::default()
+            },
             TestData {
                 contents: "",
                 env_vars: vec!["KATA_AGENT_SERVER_ADDR=foo"],
@@ -782,6 +988,15 @@ mod tests {
             assert_eq!(d.container_pipe_size, config.container_pipe_size, "{}", msg);
             assert_eq!(d.server_addr, config.server_addr, "{}", msg);
             assert_eq!(d.tracing, config.tracing, "{}", msg);
+            assert_eq!(d.watchdog_timeout, config.watchdog_timeout, "{}", msg);
+            assert_eq!(d.port_forward_vport, config.port_forward_vport, "{}", msg);
+            assert_eq!(d.tmpfs_overlay, config.tmpfs_overlay, "{}", msg);
+            assert_eq!(d.luks_key_helper, config.luks_key_helper, "{}", msg);
+            assert_eq!(d.policy_file, config.policy_file, "{}", msg);
+            assert_eq!(d.policy_audit, config.policy_audit, "{}", msg);
+            assert_eq!(d.policy_vport, config.policy_vport, "{}", msg);
+            assert_eq!(d.capture_vport, config.capture_vport, "{}", msg);
+            assert_eq!(d.checkpoint_vport, config.checkpoint_vport, "{}", msg);
 
             for v in vars_to_unset {
                 env::remove_var(v);
@@ -1273,6 +1488,47 @@ Caused by:
 
             let msg = format!("{}: result: {:?}", msg, result);
 
+            assert_result!(d.result, result, msg);
+        }
+    }
+    #[test]
+    fn test_get_tmpfs_overlay() {
+        #[derive(Debug)]
+        struct TestData<'a> {
+            param: &'a str,
+            result: Result<Vec<String>>,
+        }
+
+        let tests = &[
+            TestData {
+                param: "agent.tmpfs_overlay",
+                result: Err(anyhow!(ERR_INVALID_GET_VALUE_PARAM)),
+            },
+            TestData {
+                param: "agent.tmpfs_overlay=",
+                result: Err(anyhow!(ERR_INVALID_GET_VALUE_NO_VALUE)),
+            },
+            TestData {
+                param: "agent.tmpfs_overlay=/tmp",
+                result: Ok(vec!["/tmp".to_string()]),
+            },
+            TestData {
+                param: "agent.tmpfs_overlay=/run,,/tmp,",
+                result: Ok(vec!["/run".to_string(), "/tmp".to_string()]),
+            },
+            TestData {
+                param: "agent.tmpfs_overlay=/run,tmp",
+                result: Err(anyhow!(ERR_INVALID_TMPFS_OVERLAY_PATH)),
+            },
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            let msg = format!("test[{}]: {:?}", i, d);
+
+            let result = get_tmpfs_overlay(d.param);
+
+            let msg = format!("{}: result: {:?}", msg, result);
+
             assert_result!(d.result, result, msg);
         }
     }
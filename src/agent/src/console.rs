@@ -8,7 +8,7 @@ use crate::util;
 use anyhow::{anyhow, Result};
 use nix::fcntl::{self, FcntlArg, FdFlag, OFlag};
 use nix::libc::{STDERR_FILENO, STDIN_FILENO, STDOUT_FILENO};
-use nix::pty::{openpty, OpenptyResult};
+use nix::pty::{openpty, OpenptyResult, Winsize};
 use nix::sys::socket::{self, AddressFamily, SockAddr, SockFlag, SockType};
 use nix::sys::stat::Mode;
 use nix::sys::wait;
@@ -21,14 +21,30 @@ use std::path::PathBuf;
 use std::process::Stdio;
 use std::sync::Arc;
 use std::sync::Mutex as SyncMutex;
+use std::time::Duration;
 
 use futures::StreamExt;
-use tokio::io::{AsyncRead, AsyncWrite};
+use tokio::io::{AsyncRead, AsyncReadExt, AsyncWrite, AsyncWriteExt};
 use tokio::select;
 use tokio::sync::watch::Receiver;
 
 const CONSOLE_PATH: &str = "/dev/console";
 
+// The clients which send this header, followed by the JSON session
+// parameters and a newline, right after connecting to the vsock debug
+// console talk the framed protocol: the data, the window size changes and
+// the exit code go through frames of a type byte and a big endian u16
+// payload length. Other clients, e.g. socat, get the raw shell as before.
+const SESSION_MAGIC: &[u8] = b"KATA-DEBUG-CONSOLE/1 ";
+const SESSION_HEADER_TIMEOUT: Duration = Duration::from_millis(500);
+const SESSION_HEADER_MAX_SIZE: usize = 64 * 1024;
+
+const FRAME_DATA: u8 = 0;
+const FRAME_RESIZE: u8 = 1;
+const FRAME_EXIT: u8 = 2;
+
+const LISTEN_BACKLOG: usize = 16;
+
 lazy_static! {
     static ref SHELLS: Arc<SyncMutex<Vec<String>>> = {
         let mut v = Vec::new();
@@ -69,7 +85,7 @@ pub async fn debug_console_handler(
         )?;
         let addr = SockAddr::new_vsock(libc::VMADDR_CID_ANY, port);
         socket::bind(listenfd, &addr)?;
-        socket::listen(listenfd, 1)?;
+        socket::listen(listenfd, LISTEN_BACKLOG)?;
 
         let mut incoming = util::get_vsock_incoming(listenfd);
 
@@ -130,7 +146,193 @@ pub async fn debug_console_handler(
     Ok(())
 }
 
-fn run_in_child(slave_fd: libc::c_int, shell: String) -> Result<()> {
+// Session is a debug console session of the framed protocol.
+#[derive(Debug, Default, PartialEq)]
+struct Session {
+    rows: u16,
+    cols: u16,
+    // command is run with "shell -c" instead of the interactive shell.
+    command: Option<String>,
+}
+
+impl Session {
+    fn parse(header: &[u8]) -> Result<Session> {
+        let params: serde_json::Value = serde_json::from_slice(header)?;
+
+        let size = |name: &str| -> Result<u16> {
+            match params.get(name) {
+                None => Ok(0),
+                Some(v) => v
+                    .as_u64()
+                    .filter(|v| *v <= u16::MAX as u64)
+                    .map(|v| v as u16)
+                    .ok_or_else(|| anyhow!("invalid debug console session {}: {}", name, v)),
+            }
+        };
+
+        let command = match params.get("command") {
+            None => None,
+            Some(v) => Some(
+                v.as_str()
+                    .ok_or_else(|| anyhow!("invalid debug console session command: {}", v))?
+                    .to_string(),
+            ),
+        };
+
+        Ok(Session {
+            rows: size("rows")?,
+            cols: size("cols")?,
+            command,
+        })
+    }
+
+    fn window_size(&self) -> Option<Winsize> {
+        if self.rows == 0 || self.cols == 0 {
+            return None;
+        }
+
+        Some(Winsize {
+            ws_row: self.rows,
+            ws_col: self.cols,
+            ws_xpixel: 0,
+            ws_ypixel: 0,
+        })
+    }
+}
+
+// read_session reads the framed protocol header of a new connection. It
+// returns no session for the raw clients, along with the bytes they
+// already sent.
+async fn read_session<T: AsyncRead + Unpin>(stream: &mut T) -> Result<(Option<Session>, Vec<u8>)> {
+    let deadline = tokio::time::Instant::now() + SESSION_HEADER_TIMEOUT;
+    let mut buf = Vec::new();
+    let mut chunk = [0u8; 1024];
+
+    loop {
+        let n = buf.len().min(SESSION_MAGIC.len());
+        if buf[..n] != SESSION_MAGIC[..n] {
+            return Ok((None, buf));
+        }
+
+        if n == SESSION_MAGIC.len() {
+            if let Some(pos) = buf.iter().position(|b| *b == b'\n') {
+                let rest = buf.split_off(pos + 1);
+                let session = Session::parse(&buf[n..pos])?;
+                return Ok((Some(session), rest));
+            }
+
+            if buf.len() > SESSION_HEADER_MAX_SIZE {
+                return Err(anyhow!("debug console session header too long"));
+            }
+        }
+
+        let len = match tokio::time::timeout_at(deadline, stream.read(&mut chunk)).await {
+            Ok(res) => res?,
+            // Raw clients only send what is typed
+            Err(_) if n < SESSION_MAGIC.len() => return Ok((None, buf)),
+            Err(_) => return Err(anyhow!("timeout reading the debug console session header")),
+        };
+
+        if len == 0 {
+            return Err(anyhow!("debug console connection closed"));
+        }
+
+        buf.extend_from_slice(&chunk[..len]);
+    }
+}
+
+fn set_window_size(fd: RawFd, rows: u16, cols: u16) -> Result<()> {
+    let ws = Winsize {
+        ws_row: rows,
+        ws_col: cols,
+        ws_xpixel: 0,
+        ws_ypixel: 0,
+    };
+
+    let ret = unsafe { libc::ioctl(fd, libc::TIOCSWINSZ, &ws) };
+    if ret < 0 {
+        return Err(anyhow!(
+            "failed to set the debug console window size: {}",
+            std::io::Error::last_os_error()
+        ));
+    }
+
+    Ok(())
+}
+
+async fn write_frame<W: AsyncWrite + Unpin>(
+    writer: &mut W,
+    frame_type: u8,
+    payload: &[u8],
+) -> Result<()> {
+    let mut frame = Vec::with_capacity(3 + payload.len());
+    frame.push(frame_type);
+    frame.extend_from_slice(&(payload.len() as u16).to_be_bytes());
+    frame.extend_from_slice(payload);
+
+    writer.write_all(&frame).await?;
+    Ok(())
+}
+
+// copy_from_client writes the data frames of the client to the pty master
+// and applies its window size changes.
+async fn copy_from_client<R: AsyncRead + Unpin, W: AsyncWrite + Unpin>(
+    reader: &mut R,
+    master: &mut W,
+    master_fd: RawFd,
+) -> Result<()> {
+    let mut header = [0u8; 3];
+    let mut payload = vec![0u8; u16::MAX as usize];
+
+    loop {
+        reader.read_exact(&mut header).await?;
+        let len = u16::from_be_bytes([header[1], header[2]]) as usize;
+        reader.read_exact(&mut payload[..len]).await?;
+
+        match header[0] {
+            FRAME_DATA => master.write_all(&payload[..len]).await?,
+            FRAME_RESIZE if len == 4 => set_window_size(
+                master_fd,
+                u16::from_be_bytes([payload[0], payload[1]]),
+                u16::from_be_bytes([payload[2], payload[3]]),
+            )?,
+            t => {
+                return Err(anyhow!(
+                    "unexpected debug console frame type {} of {} bytes",
+                    t,
+                    len
+                ))
+            }
+        }
+    }
+}
+
+// copy_to_client sends the output of the pty master as data frames.
+async fn copy_to_client<R: AsyncRead + Unpin, W: AsyncWrite + Unpin>(
+    master: &mut R,
+    writer: &mut W,
+) -> Result<()> {
+    let mut buf = vec![0u8; 4096];
+
+    loop {
+        let len = master.read(&mut buf).await?;
+        if len == 0 {
+            return Ok(());
+        }
+
+        write_frame(writer, FRAME_DATA, &buf[..len]).await?;
+    }
+}
+
+fn exit_code(status: wait::WaitStatus) -> i32 {
+    match status {
+        wait::WaitStatus::Exited(_, code) => code,
+        wait::WaitStatus::Signaled(_, signal, _) => 128 + signal as i32,
+        _ => -1,
+    }
+}
+
+fn run_in_child(slave_fd: libc::c_int, shell: String, command: Option<String>) -> Result<()> {
     // create new session with child as session leader
     setsid()?;
 
@@ -145,7 +347,14 @@ fn run_in_child(slave_fd: libc::c_int, shell: String) -> Result<()> {
     }
 
     let cmd = CString::new(shell).unwrap();
-    let args: Vec<CString> = Vec::new();
+    let args: Vec<CString> = match command {
+        Some(command) => vec![
+            cmd.clone(),
+            CString::new("-c").unwrap(),
+            CString::new(command)?,
+        ],
+        None => Vec::new(),
+    };
 
     // run shell
     let _ = unistd::execvp(cmd.as_c_str(), &args).map_err(|e| match e {
@@ -163,6 +372,8 @@ async fn run_in_parent<T: AsyncRead + AsyncWrite>(
     stream: T,
     pseudo: OpenptyResult,
     child_pid: Pid,
+    framed: bool,
+    pending: Vec<u8>,
 ) -> Result<()> {
     info!(logger, "get debug shell pid {:?}", child_pid);
 
@@ -172,44 +383,73 @@ async fn run_in_parent<T: AsyncRead + AsyncWrite>(
     let (mut socket_reader, mut socket_writer) = tokio::io::split(stream);
     let (mut master_reader, mut master_writer) = tokio::io::split(PipeStream::from_fd(master_fd));
 
-    select! {
-        res = tokio::io::copy(&mut master_reader, &mut socket_writer) => {
-            debug!(
-                logger,
-                "master closed: {:?}", res
-            );
+    if framed {
+        // The frames which came along with the session header
+        let mut socket_reader = (&pending[..]).chain(&mut socket_reader);
+
+        select! {
+            res = copy_to_client(&mut master_reader, &mut socket_writer) => {
+                debug!(logger, "master closed: {:?}", res);
+            }
+            res = copy_from_client(&mut socket_reader, &mut master_writer, master_fd) => {
+                info!(logger, "socket closed: {:?}", res);
+            }
         }
-        res = tokio::io::copy(&mut socket_reader, &mut master_writer) => {
-            info!(
-                logger,
-                "socket closed: {:?}", res
-            );
+    } else {
+        master_writer.write_all(&pending).await?;
+
+        select! {
+            res = tokio::io::copy(&mut master_reader, &mut socket_writer) => {
+                debug!(
+                    logger,
+                    "master closed: {:?}", res
+                );
+            }
+            res = tokio::io::copy(&mut socket_reader, &mut master_writer) => {
+                info!(
+                    logger,
+                    "socket closed: {:?}", res
+                );
+            }
         }
     }
 
     let wait_status = wait::waitpid(child_pid, None);
     info!(logger, "debug console process exit code: {:?}", wait_status);
 
+    if framed {
+        if let Ok(status) = wait_status {
+            let code = exit_code(status).to_be_bytes();
+            let _ = write_frame(&mut socket_writer, FRAME_EXIT, &code).await;
+        }
+    }
+
     Ok(())
 }
 
-async fn run_debug_console_vsock<T: AsyncRead + AsyncWrite>(
+async fn run_debug_console_vsock<T: AsyncRead + AsyncWrite + Unpin>(
     logger: Logger,
     shell: String,
-    stream: T,
+    mut stream: T,
 ) -> Result<()> {
     let logger = logger.new(o!("subsystem" => "debug-console-shell"));
 
-    let pseudo = openpty(None, None)?;
+    let (session, pending) = read_session(&mut stream).await?;
+    let framed = session.is_some();
+    let session = session.unwrap_or_default();
+
+    info!(logger, "new debug console session"; "framed" => framed, "command" => format!("{:?}", session.command));
+
+    let pseudo = openpty(session.window_size().as_ref(), None)?;
     let _ = fcntl::fcntl(pseudo.master, FcntlArg::F_SETFD(FdFlag::FD_CLOEXEC));
     let _ = fcntl::fcntl(pseudo.slave, FcntlArg::F_SETFD(FdFlag::FD_CLOEXEC));
 
     let slave_fd = pseudo.slave;
 
     match unsafe { fork() } {
-        Ok(ForkResult::Child) => run_in_child(slave_fd, shell),
+        Ok(ForkResult::Child) => run_in_child(slave_fd, shell, session.command),
         Ok(ForkResult::Parent { child: child_pid }) => {
-            run_in_parent(logger.clone(), stream, pseudo, child_pid).await
+            run_in_parent(logger.clone(), stream, pseudo, child_pid, framed, pending).await
         }
         Err(err) => Err(anyhow!("fork error: {:?}", err)),
     }
@@ -239,6 +479,83 @@ mod tests {
     use tempfile::tempdir;
     use tokio::sync::watch;
 
+    #[test]
+    fn test_session_parse() {
+        let session = Session::parse(br#"{"rows": 24, "cols": 80}"#).unwrap();
+        assert_eq!(
+            session,
+            Session {
+                rows: 24,
+                cols: 80,
+                command: None,
+            }
+        );
+        assert!(session.window_size().is_some());
+
+        let session = Session::parse(br#"{"command": "ls -l /"}"#).unwrap();
+        assert_eq!(session.command, Some("ls -l /".to_string()));
+        assert!(session.window_size().is_none());
+
+        assert!(Session::parse(b"{").is_err());
+        assert!(Session::parse(br#"{"rows": 65536}"#).is_err());
+        assert!(Session::parse(br#"{"command": 1}"#).is_err());
+    }
+
+    #[tokio::test]
+    async fn test_read_session() {
+        // Raw clients
+        let mut stream: &[u8] = b"ls\n";
+        let (session, pending) = read_session(&mut stream).await.unwrap();
+        assert!(session.is_none());
+        assert_eq!(pending, b"ls\n");
+
+        let (_client, mut server) = tokio::io::duplex(64);
+        let (session, pending) = read_session(&mut server).await.unwrap();
+        assert!(session.is_none());
+        assert!(pending.is_empty());
+
+        // Framed clients, with a first data frame
+        let mut stream: &[u8] = b"KATA-DEBUG-CONSOLE/1 {\"rows\": 24, \"cols\": 80}\n\x00\x00\x01a";
+        let (session, pending) = read_session(&mut stream).await.unwrap();
+        assert_eq!(session.unwrap().cols, 80);
+        assert_eq!(pending, b"\x00\x00\x01a");
+
+        let mut stream: &[u8] = b"KATA-DEBUG-CONSOLE/1 {";
+        assert!(read_session(&mut stream).await.is_err());
+    }
+
+    #[tokio::test]
+    async fn test_copy_from_client() {
+        let (mut master, mut master_peer) = tokio::io::duplex(64);
+
+        let mut frames: &[u8] = b"\x00\x00\x02ab\x00\x00\x01c";
+        // The frames are consumed until the end of the stream
+        assert!(copy_from_client(&mut frames, &mut master, -1)
+            .await
+            .is_err());
+
+        let mut buf = [0u8; 3];
+        master_peer.read_exact(&mut buf).await.unwrap();
+        assert_eq!(&buf, b"abc");
+
+        let mut frames: &[u8] = b"\x07\x00\x00";
+        let err = copy_from_client(&mut frames, &mut master, -1)
+            .await
+            .unwrap_err();
+        assert!(err
+            .to_string()
+            .contains("unexpected debug console frame type 7"));
+    }
+
+    #[tokio::test]
+    async fn test_copy_to_client() {
+        let mut master: &[u8] = b"hello";
+        let mut frames = Vec::new();
+
+        copy_to_client(&mut master, &mut frames).await.unwrap();
+        assert_eq!(frames, b"\x00\x00\x05hello");
+    }
+
     #[tokio::test]
     async fn test_setup_debug_console_no_shells() {
         {
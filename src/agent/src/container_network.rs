@@ -0,0 +1,173 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use anyhow::{anyhow, Context, Result};
+use nix::errno::Errno;
+use nix::mount::{umount2, MntFlags};
+use nix::sched::{setns, CloneFlags};
+use protocols::types::{Interface, Route};
+use slog::Logger;
+use std::fs::{self, File};
+use std::os::unix::io::AsRawFd;
+use std::path::{Path, PathBuf};
+use std::thread;
+use std::time::Duration;
+use tracing::instrument;
+
+use crate::namespace::Namespace;
+use crate::netlink::Handle;
+
+// The network namespaces of the containers which have their own network
+// interfaces are persisted in CONTAINER_NETNS_DIR/<container id>.
+const CONTAINER_NETNS_DIR: &str = "/var/run/sandbox-ns/containers";
+
+// The time the interfaces hotplugged for a container take to show up.
+const INTERFACE_WAIT_TIMEOUT: Duration = Duration::from_secs(10);
+
+fn container_netns_dir(cid: &str) -> PathBuf {
+    Path::new(CONTAINER_NETNS_DIR).join(cid)
+}
+
+// setup_container_network creates the network namespace of a container and
+// moves into it the interfaces of the container, found in the sandbox network
+// namespace by their hardware address. The interfaces and the routes are then
+// configured in the container network namespace, whose path is returned.
+#[instrument]
+pub async fn setup_container_network(
+    logger: &Logger,
+    rtnl: &Handle,
+    cid: &str,
+    interfaces: Vec<Interface>,
+    routes: Vec<Route>,
+) -> Result<String> {
+    let dir = container_netns_dir(cid);
+    if dir.exists() {
+        return Err(anyhow!("container {} already has a network namespace", cid));
+    }
+
+    let netns = Namespace::new(logger)
+        .get_net()
+        .set_root_dir(&dir.to_string_lossy())
+        .setup()
+        .await
+        .context("Failed to create the container network namespace")?;
+
+    if let Err(e) = move_interfaces(rtnl, &netns.path, interfaces, routes).await {
+        if let Err(e) = remove_container_network(cid) {
+            warn!(
+                logger,
+                "failed to remove the container network namespace: {:?}", e
+            );
+        }
+        return Err(e);
+    }
+
+    Ok(netns.path)
+}
+
+async fn move_interfaces(
+    rtnl: &Handle,
+    netns_path: &str,
+    interfaces: Vec<Interface>,
+    routes: Vec<Route>,
+) -> Result<()> {
+    let netns = File::open(netns_path)?;
+    for iface in interfaces.iter() {
+        rtnl.move_link(&iface.hwAddr, netns.as_raw_fd(), INTERFACE_WAIT_TIMEOUT)
+            .await?;
+    }
+
+    // The netlink socket of the sandbox is bound to the sandbox network
+    // namespace: the interfaces are configured by a thread of their own,
+    // in the container network namespace, with a netlink socket of its own.
+    let (tx, rx) = tokio::sync::oneshot::channel();
+    thread::spawn(move || {
+        let _ = tx.send(configure_interfaces(netns, &interfaces, routes));
+    });
+
+    rx.await
+        .map_err(|e| anyhow!("Failed to configure the interfaces: {:?}", e))?
+}
+
+fn configure_interfaces(netns: File, interfaces: &[Interface], routes: Vec<Route>) -> Result<()> {
+    setns(netns.as_raw_fd(), CloneFlags::CLONE_NEWNET)
+        .context("Failed to join the container network namespace")?;
+
+    let rt = tokio::runtime::Builder::new_current_thread()
+        .enable_all()
+        .build()?;
+
+    rt.block_on(async {
+        let mut rtnl = Handle::new()?;
+
+        rtnl.handle_localhost().await?;
+        for iface in interfaces {
+            rtnl.update_interface(iface).await?;
+        }
+        if !routes.is_empty() {
+            rtnl.update_routes(routes).await?;
+        }
+
+        Ok(())
+    })
+}
+
+// remove_container_network removes the network namespace of a container, if
+// it has one. The interfaces are moved back by the kernel to the sandbox
+// network namespace once the container processes are gone.
+pub fn remove_container_network(cid: &str) -> Result<()> {
+    let dir = container_netns_dir(cid);
+    if !dir.exists() {
+        return Ok(());
+    }
+
+    // The namespace is not mounted when its setup failed.
+    let netns_path = dir.join("net");
+    match umount2(netns_path.as_path(), MntFlags::MNT_DETACH) {
+        Ok(()) | Err(nix::Error::Sys(Errno::EINVAL)) | Err(nix::Error::Sys(Errno::ENOENT)) => {}
+        Err(e) => return Err(anyhow!(e).context(format!("Failed to umount {:?}", netns_path))),
+    }
+
+    fs::remove_dir_all(&dir)?;
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use crate::skip_if_not_root;
+
+    #[test]
+    fn test_container_netns_dir() {
+        assert_eq!(
+            container_netns_dir("abc"),
+            PathBuf::from("/var/run/sandbox-ns/containers/abc")
+        );
+    }
+
+    #[test]
+    fn test_remove_container_network_none() {
+        assert!(remove_container_network("container-network-none").is_ok());
+    }
+
+    #[tokio::test]
+    async fn test_setup_container_network() {
+        skip_if_not_root!();
+
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let rtnl = Handle::new().unwrap();
+        let cid = "container-network-test";
+
+        // A network namespace with no interface but the loopback one
+        let path = setup_container_network(&logger, &rtnl, cid, vec![], vec![])
+            .await
+            .unwrap();
+        assert!(Path::new(&path).exists());
+
+        assert!(remove_container_network(cid).is_ok());
+        assert!(!container_netns_dir(cid).exists());
+    }
+}
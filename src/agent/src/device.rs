@@ -19,8 +19,9 @@ use crate::ccw;
 use crate::linux_abi::*;
 use crate::mount::{
     DRIVER_BLK_CCW_TYPE, DRIVER_BLK_TYPE, DRIVER_MMIO_BLK_TYPE, DRIVER_NVDIMM_TYPE,
-    DRIVER_SCSI_TYPE,
+    DRIVER_SCSI_MPATH_TYPE, DRIVER_SCSI_TYPE,
 };
+use crate::multipath::get_multipath_device_name;
 use crate::pci;
 use crate::sandbox::Sandbox;
 use crate::uevent::{wait_for_uevent, Uevent, UeventMatcher};
@@ -38,6 +39,8 @@ macro_rules! sl {
 
 const VM_ROOTFS: &str = "/";
 
+pub const DRIVER_VFIO_AP_TYPE: &str = "vfio-ap";
+
 #[derive(Debug)]
 struct DevIndexEntry {
     idx: usize,
@@ -209,6 +212,59 @@ pub async fn get_virtio_blk_ccw_device_name(
     };
 }
 
+#[cfg(target_arch = "s390x")]
+#[derive(Debug)]
+struct ApMatcher {
+    suffix: String,
+}
+
+#[cfg(target_arch = "s390x")]
+impl ApMatcher {
+    fn new(apqn: &str) -> Result<ApMatcher> {
+        let card = match apqn.split('.').collect::<Vec<&str>>()[..] {
+            [card, domain]
+                if !card.is_empty()
+                    && !domain.is_empty()
+                    && u8::from_str_radix(card, 16).is_ok()
+                    && u16::from_str_radix(domain, 16).is_ok() =>
+            {
+                card
+            }
+            _ => return Err(anyhow!("invalid AP queue {}", apqn)),
+        };
+
+        Ok(ApMatcher {
+            suffix: format!("/card{}/{}", card, apqn),
+        })
+    }
+}
+
+#[cfg(target_arch = "s390x")]
+impl UeventMatcher for ApMatcher {
+    fn is_match(&self, uev: &Uevent) -> bool {
+        uev.action == "add"
+            && uev.devpath.starts_with(AP_DEV_PATH)
+            && uev.devpath.ends_with(&self.suffix)
+    }
+}
+
+// wait_for_ap_device waits for the AP queue apqn, "<card>.<domain>", to be
+// available in the guest.
+#[cfg(target_arch = "s390x")]
+#[instrument]
+pub async fn wait_for_ap_device(sandbox: &Arc<Mutex<Sandbox>>, apqn: &str) -> Result<()> {
+    let matcher = ApMatcher::new(apqn)?;
+
+    // The queues of the cold plugged devices are there before the agent
+    // starts listening to uevents.
+    if Path::new(SYSFS_AP_DEVICES_PATH).join(apqn).exists() {
+        return Ok(());
+    }
+
+    wait_for_uevent(sandbox, matcher).await?;
+    Ok(())
+}
+
 #[derive(Debug)]
 struct PmemBlockMatcher {
     suffix: String,
@@ -437,6 +493,49 @@ async fn virtio_scsi_device_handler(
     update_spec_device_list(&dev, spec, devidx)
 }
 
+// device.Id should be the WWID of the multipath device, and device.options
+// the SCSI addresses of its paths.
+#[instrument]
+async fn virtio_scsi_mpath_device_handler(
+    device: &Device,
+    spec: &mut Spec,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    devidx: &DevIndex,
+) -> Result<()> {
+    let mut dev = device.clone();
+    dev.vm_path = get_multipath_device_name(&sl!(), sandbox, &device.id, &device.options).await?;
+    update_spec_device_list(&dev, spec, devidx)
+}
+
+// device.options are the AP queues, "<card>.<domain>", of the vfio-ap device.
+// The crypto cards are reached through the zcrypt device of the guest, the
+// spec is left unchanged.
+#[cfg(target_arch = "s390x")]
+#[instrument]
+async fn vfio_ap_device_handler(
+    device: &Device,
+    _: &mut Spec,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    _: &DevIndex,
+) -> Result<()> {
+    for apqn in device.options.iter() {
+        wait_for_ap_device(sandbox, apqn).await?;
+    }
+
+    Ok(())
+}
+
+#[cfg(not(target_arch = "s390x"))]
+#[instrument]
+async fn vfio_ap_device_handler(
+    _: &Device,
+    _: &mut Spec,
+    _: &Arc<Mutex<Sandbox>>,
+    _: &DevIndex,
+) -> Result<()> {
+    Err(anyhow!("AP devices are only supported on s390x"))
+}
+
 #[instrument]
 async fn virtio_nvdimm_device_handler(
     device: &Device,
@@ -520,6 +619,10 @@ async fn add_device(
         DRIVER_MMIO_BLK_TYPE => virtiommio_blk_device_handler(device, spec, sandbox, devidx).await,
         DRIVER_NVDIMM_TYPE => virtio_nvdimm_device_handler(device, spec, sandbox, devidx).await,
         DRIVER_SCSI_TYPE => virtio_scsi_device_handler(device, spec, sandbox, devidx).await,
+        DRIVER_SCSI_MPATH_TYPE => {
+            virtio_scsi_mpath_device_handler(device, spec, sandbox, devidx).await
+        }
+        DRIVER_VFIO_AP_TYPE => vfio_ap_device_handler(device, spec, sandbox, devidx).await,
         _ => Err(anyhow!("Unknown device type {}", device.field_type)),
     }
 }
@@ -1039,6 +1142,29 @@ mod tests {
         assert!(!matcher.is_match(&uev));
     }
 
+    #[cfg(target_arch = "s390x")]
+    #[tokio::test]
+    async fn test_ap_matcher() {
+        let mut uev = crate::uevent::Uevent::default();
+        uev.action = crate::linux_abi::U_EVENT_ACTION_ADD.to_string();
+        uev.subsystem = "ap".to_string();
+        uev.devpath = format!("{}/card05/05.0032", AP_DEV_PATH);
+
+        let matcher = ApMatcher::new("05.0032").unwrap();
+        assert!(matcher.is_match(&uev));
+
+        let matcher = ApMatcher::new("05.0033").unwrap();
+        assert!(!matcher.is_match(&uev));
+
+        uev.devpath = format!("{}/card05", AP_DEV_PATH);
+        assert!(!matcher.is_match(&uev));
+
+        assert!(ApMatcher::new("05").is_err());
+        assert!(ApMatcher::new("05.").is_err());
+        assert!(ApMatcher::new(".0032").is_err());
+        assert!(ApMatcher::new("zz.0032").is_err());
+    }
+
     #[tokio::test]
     async fn test_scsi_block_matcher() {
         let root_bus = create_pci_root_bus_path();
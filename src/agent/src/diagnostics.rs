@@ -0,0 +1,241 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use anyhow::Result;
+use nix::errno::Errno;
+use nix::sys::statvfs::statvfs;
+use protobuf::RepeatedField;
+use protocols::agent::{FilesystemUsage, GuestDiagnostics, GuestDiagnosticsRequest};
+use std::collections::HashMap;
+use std::fs;
+use tracing::instrument;
+
+pub const PROC_MOUNTINFO: &str = "/proc/self/mountinfo";
+pub const PROC_MOUNTS: &str = "/proc/self/mounts";
+pub const PROC_MEMINFO: &str = "/proc/meminfo";
+pub const PROC_MODULES: &str = "/proc/modules";
+
+// The bounds of the diagnostics, whatever the runtime asks for.
+const DEFAULT_DMESG_LINES: usize = 100;
+const MAX_DMESG_LINES: usize = 10000;
+const MAX_SECTION_SIZE: usize = 256 * 1024;
+
+// The actions of syslog(2)
+const SYSLOG_ACTION_READ_ALL: libc::c_int = 3;
+const SYSLOG_ACTION_SIZE_BUFFER: libc::c_int = 10;
+
+// get_guest_diagnostics returns a snapshot of the state of the guest OS. A
+// section which cannot be collected is left empty, and the failure reported
+// along with the other sections.
+#[instrument]
+pub fn get_guest_diagnostics(req: &GuestDiagnosticsRequest) -> GuestDiagnostics {
+    let max_size = match req.max_section_size as usize {
+        0 => MAX_SECTION_SIZE,
+        size => size.min(MAX_SECTION_SIZE),
+    };
+    let dmesg_lines = match req.dmesg_lines as usize {
+        0 => DEFAULT_DMESG_LINES,
+        lines => lines.min(MAX_DMESG_LINES),
+    };
+
+    let mut errors = HashMap::new();
+    let mut diagnostics = GuestDiagnostics::new();
+
+    diagnostics.dmesg = section(&mut errors, "dmesg", dmesg(dmesg_lines), max_size);
+    diagnostics.mounts = section(&mut errors, "mounts", read(PROC_MOUNTINFO), max_size);
+    diagnostics.meminfo = section(&mut errors, "meminfo", read(PROC_MEMINFO), max_size);
+    diagnostics.modules = section(&mut errors, "modules", read(PROC_MODULES), max_size);
+
+    match filesystems() {
+        Ok(usages) => diagnostics.filesystems = RepeatedField::from_vec(usages),
+        Err(e) => {
+            errors.insert("filesystems".to_string(), format!("{:?}", e));
+        }
+    }
+
+    diagnostics.errors = errors;
+    diagnostics
+}
+
+fn section(
+    errors: &mut HashMap<String, String>,
+    name: &str,
+    result: Result<String>,
+    max_size: usize,
+) -> String {
+    match result {
+        Ok(text) => truncate(text, max_size),
+        Err(e) => {
+            errors.insert(name.to_string(), format!("{:?}", e));
+            String::new()
+        }
+    }
+}
+
+fn read(path: &str) -> Result<String> {
+    Ok(fs::read_to_string(path)?)
+}
+
+// truncate keeps the last max_size bytes of text, the most recent ones for
+// the logs.
+fn truncate(text: String, max_size: usize) -> String {
+    if text.len() <= max_size {
+        return text;
+    }
+
+    let mut start = text.len() - max_size;
+    while !text.is_char_boundary(start) {
+        start += 1;
+    }
+
+    text[start..].to_string()
+}
+
+// dmesg returns the last lines of the kernel log, without their priority.
+fn dmesg(lines: usize) -> Result<String> {
+    let size = unsafe { libc::klogctl(SYSLOG_ACTION_SIZE_BUFFER, std::ptr::null_mut(), 0) };
+    let size = Errno::result(size)?;
+
+    let mut buf = vec![0u8; size as usize];
+    let len = unsafe {
+        libc::klogctl(
+            SYSLOG_ACTION_READ_ALL,
+            buf.as_mut_ptr() as *mut libc::c_char,
+            size,
+        )
+    };
+    let len = Errno::result(len)?;
+    buf.truncate(len as usize);
+
+    let log = String::from_utf8_lossy(&buf);
+    let log: Vec<&str> = log.lines().map(strip_priority).collect();
+    let start = log.len().saturating_sub(lines);
+
+    Ok(log[start..].join("\n"))
+}
+
+// strip_priority removes the priority prefix of a kernel log line, e.g. "<6>".
+fn strip_priority(line: &str) -> &str {
+    if let Some(rest) = line.strip_prefix('<') {
+        if let Some(end) = rest.find('>') {
+            if end > 0 && rest[..end].chars().all(|c| c.is_ascii_digit()) {
+                return &rest[end + 1..];
+            }
+        }
+    }
+
+    line
+}
+
+// filesystems returns the usage of the mounted filesystems, the pseudo
+// filesystems with no blocks are skipped like df(1) does.
+fn filesystems() -> Result<Vec<FilesystemUsage>> {
+    let mounts = fs::read_to_string(PROC_MOUNTS)?;
+    let mut usages = Vec::new();
+
+    for line in mounts.lines() {
+        let fields: Vec<&str> = line.split_whitespace().collect();
+        if fields.len() < 3 {
+            continue;
+        }
+
+        let mount_point = unescape(fields[1]);
+        let stat = match statvfs(mount_point.as_str()) {
+            Ok(stat) => stat,
+            Err(_) => continue,
+        };
+        if stat.blocks() == 0 {
+            continue;
+        }
+
+        let block_size = stat.fragment_size() as u64;
+        let mut usage = FilesystemUsage::new();
+        usage.mount_point = mount_point;
+        usage.fstype = fields[2].to_string();
+        usage.source = unescape(fields[0]);
+        usage.size = stat.blocks() as u64 * block_size;
+        usage.used = (stat.blocks() - stat.blocks_free()) as u64 * block_size;
+        usage.available = stat.blocks_available() as u64 * block_size;
+        usage.inodes = stat.files() as u64;
+        usage.inodes_free = stat.files_free() as u64;
+        usages.push(usage);
+    }
+
+    Ok(usages)
+}
+
+// unescape decodes the octal escapes of the fields of /proc/self/mounts, e.g.
+// "\040" for a space.
+fn unescape(field: &str) -> String {
+    let bytes = field.as_bytes();
+    let mut unescaped = Vec::with_capacity(bytes.len());
+    let mut i = 0;
+
+    while i < bytes.len() {
+        if bytes[i] == b'\\' && i + 4 <= bytes.len() {
+            let code = std::str::from_utf8(&bytes[i + 1..i + 4])
+                .ok()
+                .and_then(|s| u8::from_str_radix(s, 8).ok());
+            if let Some(c) = code {
+                unescaped.push(c);
+                i += 4;
+                continue;
+            }
+        }
+
+        unescaped.push(bytes[i]);
+        i += 1;
+    }
+
+    String::from_utf8_lossy(&unescaped).into_owned()
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_truncate() {
+        assert_eq!(truncate("abcdef".to_string(), 10), "abcdef");
+        assert_eq!(truncate("abcdef".to_string(), 3), "def");
+        // The multi-byte characters are not split
+        assert_eq!(truncate("aé".to_string(), 1), "");
+    }
+
+    #[test]
+    fn test_strip_priority() {
+        assert_eq!(
+            strip_priority("<6>[    0.000000] Linux"),
+            "[    0.000000] Linux"
+        );
+        assert_eq!(strip_priority("<>[    0.000000]"), "<>[    0.000000]");
+        assert_eq!(strip_priority("<a>b"), "<a>b");
+        assert_eq!(strip_priority("no priority"), "no priority");
+    }
+
+    #[test]
+    fn test_unescape() {
+        assert_eq!(unescape("/run/a\\040b"), "/run/a b");
+        assert_eq!(unescape("/run/a\\134b"), "/run/a\\b");
+        assert_eq!(unescape("/run/a\\04"), "/run/a\\04");
+        assert_eq!(unescape("/run/a\\xyzb"), "/run/a\\xyzb");
+    }
+
+    #[test]
+    fn test_get_guest_diagnostics() {
+        let mut req = GuestDiagnosticsRequest::new();
+        req.max_section_size = 64;
+
+        let diagnostics = get_guest_diagnostics(&req);
+
+        assert!(!diagnostics.meminfo.is_empty());
+        assert!(diagnostics.meminfo.len() <= 64);
+        assert!(diagnostics.mounts.len() <= 64);
+        assert!(diagnostics
+            .filesystems
+            .iter()
+            .any(|fs| fs.mount_point == "/"));
+    }
+}
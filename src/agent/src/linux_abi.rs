@@ -69,6 +69,13 @@ pub fn create_pci_root_bus_path() -> String {
 pub fn create_ccw_root_bus_path() -> String {
     String::from("/devices/css0")
 }
+
+// The AP queues, "<card>.<domain>", of the s390x crypto cards are under
+// /sys/devices/ap/card<card>.
+#[cfg(target_arch = "s390x")]
+pub const AP_DEV_PATH: &str = "/devices/ap";
+#[cfg(target_arch = "s390x")]
+pub const SYSFS_AP_DEVICES_PATH: &str = "/sys/bus/ap/devices";
 // From https://www.kernel.org/doc/Documentation/acpi/namespace.txt
 // The Linux kernel's core ACPI subsystem creates struct acpi_device
 // objects for ACPI namespace objects representing devices, power resources
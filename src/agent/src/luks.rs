@@ -0,0 +1,334 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use anyhow::{anyhow, Context, Result};
+use slog::Logger;
+use std::fs;
+use std::path::Path;
+use std::process::Stdio;
+use tokio::io::{AsyncReadExt, AsyncWriteExt};
+use tokio::process::Command;
+use tracing::instrument;
+
+use crate::protocols::agent::Storage;
+
+// LUKS_KEY_DRIVER_OPTION is the storage driver option carrying the reference
+// of the key of a LUKS encrypted block volume.
+pub const LUKS_KEY_DRIVER_OPTION: &str = "luks_key=";
+
+// EPHEMERAL_ENCRYPTION_DRIVER_OPTION is the storage driver option of the
+// scratch block volumes encrypted with a random key, which never leaves the
+// guest, and formatted on first use.
+pub const EPHEMERAL_ENCRYPTION_DRIVER_OPTION: &str = "encryption=ephemeral";
+
+const FILE_KEY_SCHEME: &str = "file://";
+const MAPPING_PREFIX: &str = "luks-";
+const SCRATCH_MAPPING_PREFIX: &str = "scratch-";
+const DEV_MAPPER_DIR: &str = "/dev/mapper";
+const CRYPTSETUP: &str = "cryptsetup";
+const MKFS: &str = "mkfs";
+const RANDOM_KEY_FILE: &str = "/dev/urandom";
+const EPHEMERAL_KEY_SIZE: usize = 64;
+
+// get_key_ref returns the key reference of the storage when it is a LUKS
+// volume.
+pub fn get_key_ref(storage: &Storage) -> Option<String> {
+    storage
+        .driver_options
+        .iter()
+        .find_map(|o| o.strip_prefix(LUKS_KEY_DRIVER_OPTION))
+        .map(String::from)
+}
+
+// is_ephemeral returns true if the storage is a scratch volume encrypted with
+// an ephemeral key.
+pub fn is_ephemeral(storage: &Storage) -> bool {
+    storage
+        .driver_options
+        .iter()
+        .any(|o| o == EPHEMERAL_ENCRYPTION_DRIVER_OPTION)
+}
+
+// get_key returns the key of a LUKS volume from its reference. A "file://"
+// reference is a guest path, e.g. of a secret provisioned in the guest, any
+// other reference, e.g. of a key broker resource, is passed to the key helper
+// that prints the key on its standard output.
+#[instrument]
+pub async fn get_key(key_ref: &str, helper: &str) -> Result<Vec<u8>> {
+    let key = if let Some(path) = key_ref.strip_prefix(FILE_KEY_SCHEME) {
+        fs::read(path).context(format!("failed to read LUKS key file {}", path))?
+    } else {
+        if helper.is_empty() {
+            return Err(anyhow!(
+                "no LUKS key helper configured to get the key {}",
+                key_ref
+            ));
+        }
+
+        let output = Command::new(helper)
+            .arg(key_ref)
+            .stdin(Stdio::null())
+            .output()
+            .await
+            .context(format!("failed to run LUKS key helper {}", helper))?;
+
+        if !output.status.success() {
+            return Err(anyhow!(
+                "LUKS key helper {} failed to get the key {}: {}",
+                helper,
+                key_ref,
+                String::from_utf8_lossy(&output.stderr)
+            ));
+        }
+
+        output.stdout
+    };
+
+    if key.is_empty() {
+        return Err(anyhow!("empty LUKS key {}", key_ref));
+    }
+
+    Ok(key)
+}
+
+// open unlocks the LUKS2 volume on device and returns the name of its device
+// mapping. The volume stays unlocked if it already is, e.g. when it is shared
+// by several containers.
+#[instrument(skip(key))]
+pub async fn open(logger: &Logger, device: &str, key: &[u8]) -> Result<String> {
+    let name = mapping_name(MAPPING_PREFIX, device)?;
+
+    if Path::new(&mapped_device(&name)).exists() {
+        return Ok(name);
+    }
+
+    info!(logger, "unlocking LUKS volume"; "device" => device, "mapping" => &name);
+
+    cryptsetup(
+        &["open", "--type", "luks2", "--key-file", "-", device, &name],
+        key,
+    )
+    .await
+    .context(format!("failed to unlock LUKS volume {}", device))?;
+
+    Ok(name)
+}
+
+// mapped_device returns the path of the device of the mapping name.
+pub fn mapped_device(name: &str) -> String {
+    Path::new(DEV_MAPPER_DIR)
+        .join(name)
+        .to_string_lossy()
+        .to_string()
+}
+
+// mapping_name returns the name of the device mapping of device.
+fn mapping_name(prefix: &str, device: &str) -> Result<String> {
+    let dev_name = Path::new(device)
+        .file_name()
+        .ok_or_else(|| anyhow!("invalid encrypted device {}", device))?
+        .to_string_lossy();
+
+    Ok(format!("{}{}", prefix, dev_name))
+}
+
+// open_ephemeral sets the scratch volume on device up as a LUKS2 volume with a
+// random key, which never leaves the guest, formats it with the fstype file
+// system and returns the name of its device mapping. The data of the volume
+// cannot be read back once the mapping goes away, e.g. when the sandbox is
+// stopped.
+//
+// aes-xts only keeps the data confidential, the volume is also authenticated
+// with dm-integrity so that the host cannot modify it unnoticed: the reads of
+// the tampered sectors fail. The integrity tags are not wiped on format,
+// which would write the whole disk, so a sector must be written before it is
+// read. With 4 KiB sectors, the file system blocks are always written whole,
+// never read and modified.
+#[instrument]
+pub async fn open_ephemeral(logger: &Logger, device: &str, fstype: &str) -> Result<String> {
+    let name = mapping_name(SCRATCH_MAPPING_PREFIX, device)?;
+    let mapped = mapped_device(&name);
+
+    if Path::new(&mapped).exists() {
+        return Ok(name);
+    }
+
+    info!(logger, "setting up encrypted scratch volume"; "device" => device, "mapping" => &name);
+
+    let mut key = vec![0u8; EPHEMERAL_KEY_SIZE];
+    tokio::fs::File::open(RANDOM_KEY_FILE)
+        .await?
+        .read_exact(&mut key)
+        .await
+        .context("failed to generate the scratch volume key")?;
+
+    // The key is random, it doesn't need to be stretched.
+    cryptsetup(
+        &[
+            "luksFormat",
+            "--batch-mode",
+            "--type",
+            "luks2",
+            "--cipher",
+            "aes-xts-plain64",
+            "--integrity",
+            "hmac-sha256",
+            "--integrity-no-wipe",
+            "--sector-size",
+            "4096",
+            "--pbkdf",
+            "pbkdf2",
+            "--pbkdf-force-iterations",
+            "1000",
+            "--key-file",
+            "-",
+            device,
+        ],
+        &key,
+    )
+    .await
+    .context(format!("failed to format scratch volume {}", device))?;
+
+    cryptsetup(
+        &["open", "--type", "luks2", "--key-file", "-", device, &name],
+        &key,
+    )
+    .await
+    .context(format!("failed to open scratch volume {}", device))?;
+
+    // mkfs is forced as the unwritten sectors it probes cannot be read.
+    let output = Command::new(MKFS)
+        .args(&["-t", fstype, "-F", &mapped])
+        .output()
+        .await
+        .context(format!("failed to run {}", MKFS))?;
+
+    if !output.status.success() {
+        let _ = cryptsetup(&["close", &name], &[]).await;
+        return Err(anyhow!(
+            "failed to format scratch volume {}: {}",
+            device,
+            String::from_utf8_lossy(&output.stderr)
+        ));
+    }
+
+    Ok(name)
+}
+
+// cryptsetup runs cryptsetup with args, the key is passed on its standard
+// input.
+async fn cryptsetup(args: &[&str], key: &[u8]) -> Result<()> {
+    let mut child = Command::new(CRYPTSETUP)
+        .args(args)
+        .stdin(Stdio::piped())
+        .stdout(Stdio::null())
+        .stderr(Stdio::piped())
+        .spawn()
+        .context(format!("failed to run {}", CRYPTSETUP))?;
+
+    // cryptsetup reads the key until the end of its standard input.
+    if let Some(mut stdin) = child.stdin.take() {
+        stdin.write_all(key).await?;
+    }
+
+    let output = child.wait_with_output().await?;
+    if !output.status.success() {
+        return Err(anyhow!(
+            "{} {} failed: {}",
+            CRYPTSETUP,
+            args.first().unwrap_or(&""),
+            String::from_utf8_lossy(&output.stderr)
+        ));
+    }
+
+    Ok(())
+}
+
+// close removes the device mapping name, locking its volume back. It is
+// called when the storages are removed, which is not done asynchronously.
+#[instrument]
+pub fn close(name: &str) -> Result<()> {
+    let output = std::process::Command::new(CRYPTSETUP)
+        .args(&["close", name])
+        .output()
+        .context(format!("failed to run {}", CRYPTSETUP))?;
+
+    if !output.status.success() {
+        return Err(anyhow!(
+            "failed to close mapping {}: {}",
+            name,
+            String::from_utf8_lossy(&output.stderr)
+        ));
+    }
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::os::unix::fs::PermissionsExt;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_get_key_ref() {
+        let mut storage = Storage::default();
+        assert_eq!(get_key_ref(&storage), None);
+
+        storage.driver_options = vec![
+            "foo=bar".to_string(),
+            "luks_key=kbs:///default/key/1".to_string(),
+        ];
+        assert_eq!(
+            get_key_ref(&storage),
+            Some("kbs:///default/key/1".to_string())
+        );
+    }
+
+    #[test]
+    fn test_is_ephemeral() {
+        let mut storage = Storage::default();
+        assert!(!is_ephemeral(&storage));
+
+        storage.driver_options = vec![EPHEMERAL_ENCRYPTION_DRIVER_OPTION.to_string()];
+        assert!(is_ephemeral(&storage));
+    }
+
+    #[tokio::test]
+    async fn test_get_key() {
+        let dir = tempdir().unwrap();
+
+        let key_file = dir.path().join("key");
+        fs::write(&key_file, b"secret").unwrap();
+        let key_ref = format!("{}{}", FILE_KEY_SCHEME, key_file.display());
+        assert_eq!(get_key(&key_ref, "").await.unwrap(), b"secret".to_vec());
+
+        let empty_file = dir.path().join("empty");
+        fs::write(&empty_file, b"").unwrap();
+        let key_ref = format!("{}{}", FILE_KEY_SCHEME, empty_file.display());
+        assert!(get_key(&key_ref, "").await.is_err());
+
+        assert!(get_key("kbs:///default/key/1", "").await.is_err());
+
+        let helper = dir.path().join("helper");
+        fs::write(&helper, "#!/bin/sh\nprintf \"key-of-$1\"\n").unwrap();
+        fs::set_permissions(&helper, fs::Permissions::from_mode(0o755)).unwrap();
+        assert_eq!(
+            get_key("kbs:///default/key/1", helper.to_str().unwrap())
+                .await
+                .unwrap(),
+            b"key-of-kbs:///default/key/1".to_vec()
+        );
+
+        assert!(get_key("kbs:///default/key/1", "/does/not/exist")
+            .await
+            .is_err());
+    }
+
+    #[test]
+    fn test_mapped_device() {
+        assert_eq!(mapped_device("luks-vdb"), "/dev/mapper/luks-vdb");
+    }
+}
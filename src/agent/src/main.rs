@@ -34,29 +34,43 @@ use std::process::exit;
 use std::sync::Arc;
 use tracing::{instrument, span};
 
+mod capture;
 #[cfg(target_arch = "s390x")]
 mod ccw;
+mod checkpoint;
 mod config;
 mod console;
+mod container_network;
 mod device;
+mod diagnostics;
 mod linux_abi;
+mod luks;
 mod metrics;
 mod mount;
+mod multipath;
 mod namespace;
 mod netlink;
 mod network;
+mod oom;
 mod pci;
+mod policy;
+mod port_forward;
 pub mod random;
+mod resize;
 mod sandbox;
 mod signal;
+mod sysctl;
 #[cfg(test)]
 mod test_utils;
 mod uevent;
 mod util;
 mod version;
+mod virtiofs;
+mod watchdog;
 mod watcher;
 
-use mount::{cgroups_mount, general_mount};
+use mount::{cgroups_mount, general_mount, tmpfs_overlay_mount};
+use oom::watch_global_oom;
 use sandbox::Sandbox;
 use signal::setup_signal_handler;
 use slog::{error, info, o, warn, Logger};
@@ -191,6 +205,10 @@ async fn real_main() -> std::result::Result<(), Box<dyn std::error::Error>> {
 
     announce(&logger, &config);
 
+    if !config.tmpfs_overlay.is_empty() {
+        tmpfs_overlay_mount(&logger, &config.tmpfs_overlay)?;
+    }
+
     // This variable is required as it enables the global (and crucially static) logger,
     // which is required to satisfy the the lifetime constraints of the auto-generated gRPC code.
     let global_logger = slog_scope::set_global_logger(logger.new(o!("subsystem" => "rpc")));
@@ -274,6 +292,10 @@ fn main() -> std::result::Result<(), Box<dyn std::error::Error>> {
         exit(0);
     }
 
+    if args.len() == 2 && args[1] == namespace::PIDNS_INIT_ARG {
+        namespace::pid_ns_init();
+    }
+
     let rt = tokio::runtime::Builder::new_multi_thread()
         .enable_all()
         .build()?;
@@ -291,6 +313,8 @@ async fn start_sandbox(
 ) -> Result<()> {
     let debug_console_vport = config.debug_console_vport as u32;
 
+    random::check_entropy(logger);
+
     if config.debug_console {
         let debug_console_task = tokio::task::spawn(console::debug_console_handler(
             logger.clone(),
@@ -301,6 +325,51 @@ async fn start_sandbox(
         tasks.push(debug_console_task);
     }
 
+    if config.watchdog_timeout.as_secs() > 0 {
+        let watchdog_task = tokio::task::spawn(watchdog::pet_watchdog(
+            logger.clone(),
+            config.watchdog_timeout,
+            shutdown.clone(),
+        ));
+
+        tasks.push(watchdog_task);
+    }
+
+    if config.port_forward_vport > 0 {
+        let port_forward_task = tokio::task::spawn(port_forward::port_forward_handler(
+            logger.clone(),
+            config.port_forward_vport as u32,
+            shutdown.clone(),
+        ));
+
+        tasks.push(port_forward_task);
+    }
+
+    if !config.policy_file.is_empty() {
+        policy::load(&config.policy_file, config.policy_audit)
+            .context("Failed to load the policy")?;
+    }
+
+    if config.policy_vport > 0 {
+        let policy_task = tokio::task::spawn(policy::policy_decisions_handler(
+            logger.clone(),
+            config.policy_vport as u32,
+            shutdown.clone(),
+        ));
+
+        tasks.push(policy_task);
+    }
+
+    if config.capture_vport > 0 {
+        let capture_task = tokio::task::spawn(capture::capture_handler(
+            logger.clone(),
+            config.capture_vport as u32,
+            shutdown.clone(),
+        ));
+
+        tasks.push(capture_task);
+    }
+
     // Initialize unique sandbox structure.
     let s = Sandbox::new(&logger).context("Failed to create sandbox")?;
     if init_mode {
@@ -309,6 +378,17 @@ async fn start_sandbox(
 
     let sandbox = Arc::new(Mutex::new(s));
 
+    if config.checkpoint_vport > 0 {
+        let checkpoint_task = tokio::task::spawn(checkpoint::checkpoint_handler(
+            logger.clone(),
+            config.checkpoint_vport as u32,
+            sandbox.clone(),
+            shutdown.clone(),
+        ));
+
+        tasks.push(checkpoint_task);
+    }
+
     let signal_handler_task = tokio::spawn(setup_signal_handler(
         logger.clone(),
         sandbox.clone(),
@@ -321,6 +401,10 @@ async fn start_sandbox(
 
     tasks.push(uevents_handler_task);
 
+    let global_oom_task = tokio::spawn(watch_global_oom(sandbox.clone(), shutdown.clone()));
+
+    tasks.push(global_oom_task);
+
     let (tx, rx) = tokio::sync::oneshot::channel();
     sandbox.lock().await.sender = Some(tx);
 
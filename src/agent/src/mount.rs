@@ -28,9 +28,12 @@ use crate::device::{
     get_scsi_device_name, get_virtio_blk_pci_device_name, online_device, wait_for_pmem_device,
 };
 use crate::linux_abi::*;
+use crate::luks;
+use crate::multipath;
 use crate::pci;
 use crate::protocols::agent::Storage;
 use crate::Sandbox;
+use crate::AGENT_CONFIG;
 #[cfg(target_arch = "s390x")]
 use crate::{ccw, device::get_virtio_blk_ccw_device_name};
 use anyhow::{anyhow, Context, Result};
@@ -43,10 +46,12 @@ pub const DRIVER_BLK_TYPE: &str = "blk";
 pub const DRIVER_BLK_CCW_TYPE: &str = "blk-ccw";
 pub const DRIVER_MMIO_BLK_TYPE: &str = "mmioblk";
 pub const DRIVER_SCSI_TYPE: &str = "scsi";
+pub const DRIVER_SCSI_MPATH_TYPE: &str = "scsi-mpath";
 pub const DRIVER_NVDIMM_TYPE: &str = "nvdimm";
 pub const DRIVER_EPHEMERAL_TYPE: &str = "ephemeral";
 pub const DRIVER_LOCAL_TYPE: &str = "local";
 pub const DRIVER_WATCHABLE_BIND_TYPE: &str = "watchable-bind";
+pub const DRIVER_OVERLAYFS_TYPE: &str = "overlayfs";
 
 pub const TYPE_ROOTFS: &str = "rootfs";
 
@@ -55,6 +60,13 @@ pub const MOUNT_GUEST_TAG: &str = "kataShared";
 // Allocating an FSGroup that owns the pod's volumes
 const FS_GID: &str = "fsgid";
 
+// FORMAT_DRIVER_OPTION is the storage driver option of the scratch block
+// volumes formatted before being mounted, whatever they contain, e.g. the
+// guest image store.
+pub const FORMAT_DRIVER_OPTION: &str = "format=always";
+
+const MKFS: &str = "mkfs";
+
 #[rustfmt::skip]
 lazy_static! {
     pub static ref FLAGS: HashMap<&'static str, (bool, MsFlags)> = {
@@ -145,8 +157,10 @@ pub const STORAGE_HANDLER_LIST: &[&str] = &[
     DRIVER_MMIO_BLK_TYPE,
     DRIVER_LOCAL_TYPE,
     DRIVER_SCSI_TYPE,
+    DRIVER_SCSI_MPATH_TYPE,
     DRIVER_NVDIMM_TYPE,
     DRIVER_WATCHABLE_BIND_TYPE,
+    DRIVER_OVERLAYFS_TYPE,
 ];
 
 #[derive(Debug, Clone)]
@@ -349,10 +363,10 @@ async fn virtio9p_storage_handler(
 async fn virtiommio_blk_storage_handler(
     logger: &Logger,
     storage: &Storage,
-    _sandbox: Arc<Mutex<Sandbox>>,
+    sandbox: Arc<Mutex<Sandbox>>,
 ) -> Result<String> {
     //The source path is VmPath
-    common_storage_handler(logger, storage)
+    block_storage_handler(logger, storage, sandbox).await
 }
 
 // virtiofs_storage_handler handles the storage for virtio-fs.
@@ -389,7 +403,7 @@ async fn virtio_blk_storage_handler(
         storage.source = dev_path;
     }
 
-    common_storage_handler(logger, &storage)
+    block_storage_handler(logger, &storage, sandbox).await
 }
 
 // virtio_blk_ccw_storage_handler handles storage for the blk-ccw driver (s390x)
@@ -404,7 +418,7 @@ async fn virtio_blk_ccw_storage_handler(
     let ccw_device = ccw::Device::from_str(&storage.source)?;
     let dev_path = get_virtio_blk_ccw_device_name(&sandbox, &ccw_device).await?;
     storage.source = dev_path;
-    common_storage_handler(logger, &storage)
+    block_storage_handler(logger, &storage, sandbox).await
 }
 
 #[cfg(not(target_arch = "s390x"))]
@@ -430,7 +444,25 @@ async fn virtio_scsi_storage_handler(
     let dev_path = get_scsi_device_name(&sandbox, &storage.source).await?;
     storage.source = dev_path;
 
-    common_storage_handler(logger, &storage)
+    block_storage_handler(logger, &storage, sandbox).await
+}
+
+// virtio_scsi_mpath_storage_handler handles the storage for scsi-mpath
+// driver, the multipath device assembled from the SCSI disks of its paths.
+#[instrument]
+async fn virtio_scsi_mpath_storage_handler(
+    logger: &Logger,
+    storage: &Storage,
+    sandbox: Arc<Mutex<Sandbox>>,
+) -> Result<String> {
+    let mut storage = storage.clone();
+
+    let paths = multipath::get_paths(&storage);
+    let dev_path =
+        multipath::get_multipath_device_name(logger, &sandbox, &storage.source, &paths).await?;
+    storage.source = dev_path;
+
+    block_storage_handler(logger, &storage, sandbox).await
 }
 
 #[instrument]
@@ -441,6 +473,86 @@ fn common_storage_handler(logger: &Logger, storage: &Storage) -> Result<String>
     mount_storage(logger, storage).and(Ok(mount_point))
 }
 
+// block_storage_handler mounts the storage of a block device, once unlocked
+// when it is a LUKS encrypted volume.
+#[instrument]
+async fn block_storage_handler(
+    logger: &Logger,
+    storage: &Storage,
+    sandbox: Arc<Mutex<Sandbox>>,
+) -> Result<String> {
+    let mut storage = storage.clone();
+
+    // The encrypted scratch volumes are shared by the containers of the
+    // sandbox, like the ephemeral storages they are set up once and stay
+    // mounted as long as the sandbox.
+    if luks::is_ephemeral(&storage) {
+        let mut sb = sandbox.lock().await;
+        if !sb.set_sandbox_storage(&storage.mount_point) {
+            return Ok(String::new());
+        }
+
+        fs::create_dir_all(&storage.mount_point)?;
+        let name = luks::open_ephemeral(logger, &storage.source, &storage.fstype).await?;
+        storage.source = luks::mapped_device(&name);
+        common_storage_handler(logger, &storage)?;
+        sb.luks_mappings.insert(storage.mount_point.clone(), name);
+
+        return Ok(String::new());
+    }
+
+    if needs_format(&storage) {
+        format_block_device(logger, &storage.source, &storage.fstype).await?;
+    }
+
+    if let Some(key_ref) = luks::get_key_ref(&storage) {
+        let helper = AGENT_CONFIG.read().await.luks_key_helper.clone();
+        let key = luks::get_key(&key_ref, &helper).await?;
+        let name = luks::open(logger, &storage.source, &key).await?;
+        storage.source = luks::mapped_device(&name);
+
+        // The volume is locked back once unmounted, see
+        // Sandbox::close_luks_mapping.
+        sandbox
+            .lock()
+            .await
+            .luks_mappings
+            .insert(storage.mount_point.clone(), name);
+    }
+
+    common_storage_handler(logger, &storage)
+}
+
+// needs_format returns true if the storage is a scratch volume to format.
+fn needs_format(storage: &Storage) -> bool {
+    storage
+        .driver_options
+        .iter()
+        .any(|o| o == FORMAT_DRIVER_OPTION)
+}
+
+// format_block_device formats device with the fstype file system.
+#[instrument]
+async fn format_block_device(logger: &Logger, device: &str, fstype: &str) -> Result<()> {
+    info!(logger, "formatting scratch volume"; "device" => device, "fstype" => fstype);
+
+    let output = tokio::process::Command::new(MKFS)
+        .args(&["-t", fstype, "-F", device])
+        .output()
+        .await
+        .context(format!("failed to run {}", MKFS))?;
+
+    if !output.status.success() {
+        return Err(anyhow!(
+            "failed to format scratch volume {}: {}",
+            device,
+            String::from_utf8_lossy(&output.stderr)
+        ));
+    }
+
+    Ok(())
+}
+
 // nvdimm_storage_handler handles the storage for NVDIMM driver.
 #[instrument]
 async fn nvdimm_storage_handler(
@@ -470,6 +582,42 @@ async fn bind_watcher_storage_handler(
         .await
 }
 
+// overlayfs_storage_handler stacks the layers of a container rootfs, mounted
+// by the previous storages, with a writable layer in the guest memory. The
+// writable layer is removed with the container.
+#[instrument]
+async fn overlayfs_storage_handler(
+    logger: &Logger,
+    storage: &Storage,
+    sandbox: Arc<Mutex<Sandbox>>,
+) -> Result<String> {
+    let options = parse_options(storage.options.to_vec());
+
+    let mut dirs = Vec::new();
+    for key in &["upperdir", "workdir"] {
+        let dir = options
+            .get(*key)
+            .ok_or_else(|| anyhow!("overlay storage {} has no {}", storage.mount_point, key))?;
+        dirs.push(dir);
+    }
+
+    let rw_dir = Path::new(dirs[0])
+        .parent()
+        .ok_or_else(|| anyhow!("invalid overlay upperdir {}", dirs[0]))?;
+
+    for dir in dirs {
+        fs::create_dir_all(dir)?;
+    }
+
+    let mount_point = common_storage_handler(logger, storage)?;
+
+    let mut sb = sandbox.lock().await;
+    sb.overlay_rw_dirs
+        .insert(mount_point.clone(), rw_dir.to_string_lossy().to_string());
+
+    Ok(mount_point)
+}
+
 // mount_storage performs the mount described by the storage structure.
 #[instrument]
 fn mount_storage(logger: &Logger, storage: &Storage) -> Result<()> {
@@ -541,7 +689,7 @@ pub fn is_mounted(mount_point: &str) -> Result<bool> {
 }
 
 #[instrument]
-fn parse_mount_flags_and_options(options_vec: Vec<&str>) -> (MsFlags, String) {
+pub fn parse_mount_flags_and_options(options_vec: Vec<&str>) -> (MsFlags, String) {
     let mut flags = MsFlags::empty();
     let mut options: String = "".to_string();
 
@@ -602,7 +750,13 @@ pub async fn add_storages(
             DRIVER_SCSI_TYPE => {
                 virtio_scsi_storage_handler(&logger, &storage, sandbox.clone()).await
             }
+            DRIVER_SCSI_MPATH_TYPE => {
+                virtio_scsi_mpath_storage_handler(&logger, &storage, sandbox.clone()).await
+            }
             DRIVER_NVDIMM_TYPE => nvdimm_storage_handler(&logger, &storage, sandbox.clone()).await,
+            DRIVER_OVERLAYFS_TYPE => {
+                overlayfs_storage_handler(&logger, &storage, sandbox.clone()).await
+            }
             DRIVER_WATCHABLE_BIND_TYPE => {
                 bind_watcher_storage_handler(&logger, &storage, sandbox.clone()).await?;
                 // Don't register watch mounts, they're hanlded separately by the watcher.
@@ -664,6 +818,38 @@ pub fn general_mount(logger: &Logger) -> Result<()> {
     Ok(())
 }
 
+// tmpfs_overlay_mount mounts a tmpfs on each of the given directories
+// which is not a tmpfs mount already, so that they are writable when the
+// guest image is read-only.
+#[instrument]
+pub fn tmpfs_overlay_mount(logger: &Logger, dirs: &[String]) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "mount"));
+
+    for dir in dirs {
+        if let Ok(fs_type) = get_mount_fs_type(dir) {
+            if fs_type == "tmpfs" {
+                continue;
+            }
+        }
+
+        // Directories such as /tmp must be writable by everyone.
+        let options = if dir == "/tmp" {
+            "mode=1777"
+        } else {
+            "mode=0755"
+        };
+        let flags = MsFlags::MS_NOSUID | MsFlags::MS_NODEV;
+
+        BareMount::new("tmpfs", dir, "tmpfs", flags, options, &logger)
+            .mount()
+            .with_context(|| format!("failed to mount tmpfs overlay on {}", dir))?;
+
+        info!(logger, "mounted tmpfs overlay"; "path" => dir.as_str());
+    }
+
+    Ok(())
+}
+
 #[inline]
 pub fn get_mount_fs_type(mount_point: &str) -> Result<String> {
     get_mount_fs_type_from_file(PROC_MOUNTSTATS, mount_point)
@@ -811,7 +997,9 @@ pub fn cgroups_mount(logger: &Logger, unified_cgroup_hierarchy: bool) -> Result<
 
 #[instrument]
 pub fn remove_mounts(mounts: &[String]) -> Result<()> {
-    for m in mounts.iter() {
+    // In the reverse order of the mounts, which may be stacked, e.g. the
+    // overlay rootfs on its layers.
+    for m in mounts.iter().rev() {
         mount::umount(m.as_str()).context(format!("failed to umount {:?}", m))?;
     }
     Ok(())
@@ -1037,6 +1225,20 @@ mod tests {
         }
     }
 
+    #[test]
+    fn test_needs_format() {
+        let mut storage = Storage::default();
+        assert!(!needs_format(&storage));
+
+        storage.driver_options = vec![luks::EPHEMERAL_ENCRYPTION_DRIVER_OPTION.to_string()];
+        assert!(!needs_format(&storage));
+
+        storage
+            .driver_options
+            .push(FORMAT_DRIVER_OPTION.to_string());
+        assert!(needs_format(&storage));
+    }
+
     #[test]
     fn test_is_mounted() {
         assert!(is_mounted("/proc").unwrap());
@@ -0,0 +1,193 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use anyhow::{anyhow, Context, Result};
+use slog::Logger;
+use std::fs;
+use std::path::Path;
+use std::sync::Arc;
+use tokio::process::Command;
+use tokio::sync::Mutex;
+use tracing::instrument;
+
+use crate::device::get_scsi_device_name;
+use crate::linux_abi::SYSTEM_DEV_PATH;
+use crate::protocols::agent::Storage;
+use crate::sandbox::Sandbox;
+
+// MPATH_PATH_DRIVER_OPTION is the storage driver option carrying the SCSI
+// address of a path of a multipath volume.
+pub const MPATH_PATH_DRIVER_OPTION: &str = "mpath_path=";
+
+const MULTIPATH: &str = "multipath";
+const MULTIPATHD: &str = "multipathd";
+const MULTIPATHD_PID_FILE: &str = "/run/multipathd.pid";
+const MULTIPATH_CONF: &str = "/etc/multipath.conf";
+const SYSFS_BLOCK_PATH: &str = "/sys/block";
+
+// The runtime gives the paths of a device the same serial number, so they are
+// grouped by WWID. The runtime only attaches several paths for multipath
+// devices, every device is multipathed.
+const DEFAULT_MULTIPATH_CONF: &str = "defaults {
+    user_friendly_names no
+    find_multipaths no
+}
+";
+
+// get_paths returns the SCSI addresses of the paths of a multipath volume.
+pub fn get_paths(storage: &Storage) -> Vec<String> {
+    storage
+        .driver_options
+        .iter()
+        .filter_map(|o| o.strip_prefix(MPATH_PATH_DRIVER_OPTION))
+        .map(String::from)
+        .collect()
+}
+
+// get_multipath_device_name waits for the SCSI disks of the paths of the
+// multipath device wwid, assembles the device and returns its path.
+#[instrument]
+pub async fn get_multipath_device_name(
+    logger: &Logger,
+    sandbox: &Arc<Mutex<Sandbox>>,
+    wwid: &str,
+    scsi_addrs: &[String],
+) -> Result<String> {
+    if scsi_addrs.is_empty() {
+        return Err(anyhow!("multipath device {} has no path", wwid));
+    }
+
+    let mut paths = Vec::new();
+    for scsi_addr in scsi_addrs {
+        paths.push(get_scsi_device_name(sandbox, scsi_addr).await?);
+    }
+
+    setup_multipathd(logger).await;
+
+    // The device may already be assembled by multipathd, multipath then
+    // leaves it as is.
+    let output = Command::new(MULTIPATH)
+        .arg(&paths[0])
+        .output()
+        .await
+        .context(format!("failed to run {}", MULTIPATH))?;
+
+    if !output.status.success() {
+        return Err(anyhow!(
+            "failed to assemble multipath device {}: {}",
+            wwid,
+            String::from_utf8_lossy(&output.stderr)
+        ));
+    }
+
+    let holder = find_holder(SYSFS_BLOCK_PATH, &paths)?;
+
+    info!(logger, "assembled multipath device";
+        "wwid" => wwid,
+        "device" => &holder,
+        "paths" => format!("{:?}", paths),
+    );
+
+    Ok(format!("{}/{}", SYSTEM_DEV_PATH, holder))
+}
+
+// setup_multipathd writes a default multipath configuration, unless the
+// guest image provides one, and starts multipathd, which checks the failed
+// paths and reinstates them. The kernel fails over between the paths without
+// multipathd, so it is not an error for the guest image not to provide it.
+async fn setup_multipathd(logger: &Logger) {
+    if !Path::new(MULTIPATH_CONF).exists() {
+        if let Err(e) = fs::write(MULTIPATH_CONF, DEFAULT_MULTIPATH_CONF) {
+            warn!(logger, "failed to write {}: {:?}", MULTIPATH_CONF, e);
+        }
+    }
+
+    if Path::new(MULTIPATHD_PID_FILE).exists() {
+        return;
+    }
+
+    match Command::new(MULTIPATHD).status().await {
+        Ok(status) if status.success() => info!(logger, "started multipathd"),
+        Ok(status) => warn!(logger, "multipathd failed: {}", status),
+        Err(e) => warn!(logger, "failed to start multipathd: {:?}", e),
+    }
+}
+
+// find_holder returns the name of the device mapper device holding all the
+// devices, i.e. the multipath device they are the paths of.
+fn find_holder(sysfs_block: &str, devices: &[String]) -> Result<String> {
+    let mut holder: Option<String> = None;
+
+    for device in devices {
+        let name = Path::new(device)
+            .file_name()
+            .ok_or_else(|| anyhow!("invalid device {}", device))?;
+        let holders = Path::new(sysfs_block).join(name).join("holders");
+
+        let h = fs::read_dir(&holders)
+            .context(format!("failed to read {:?}", holders))?
+            .filter_map(|e| e.ok())
+            .map(|e| e.file_name().to_string_lossy().to_string())
+            .next()
+            .ok_or_else(|| anyhow!("{} is not the path of a multipath device", device))?;
+
+        match holder {
+            Some(ref other) if *other != h => {
+                return Err(anyhow!(
+                    "paths of different multipath devices {} and {}",
+                    other,
+                    h
+                ));
+            }
+            _ => holder = Some(h),
+        }
+    }
+
+    holder.ok_or_else(|| anyhow!("no multipath device path"))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_get_paths() {
+        let mut storage = Storage::default();
+        assert!(get_paths(&storage).is_empty());
+
+        storage.driver_options = vec![
+            "mpath_path=0:1".to_string(),
+            "luks_key=file:///key".to_string(),
+            "mpath_path=0:2".to_string(),
+        ];
+        assert_eq!(get_paths(&storage), vec!["0:1", "0:2"]);
+    }
+
+    #[test]
+    fn test_find_holder() {
+        let dir = tempdir().expect("failed to create tmpdir");
+        let sysfs_block = dir.path().to_str().unwrap();
+
+        for (device, holder) in &[("sda", "dm-0"), ("sdb", "dm-0"), ("sdc", "dm-1")] {
+            fs::create_dir_all(dir.path().join(device).join("holders").join(holder)).unwrap();
+        }
+        fs::create_dir_all(dir.path().join("sdd").join("holders")).unwrap();
+
+        let paths = vec!["/dev/sda".to_string(), "/dev/sdb".to_string()];
+        assert_eq!(find_holder(sysfs_block, &paths).unwrap(), "dm-0");
+
+        let paths = vec!["/dev/sda".to_string(), "/dev/sdc".to_string()];
+        assert!(find_holder(sysfs_block, &paths).is_err());
+
+        let paths = vec!["/dev/sdd".to_string()];
+        assert!(find_holder(sysfs_block, &paths).is_err());
+
+        let paths = vec!["/dev/sde".to_string()];
+        assert!(find_holder(sysfs_block, &paths).is_err());
+
+        assert!(find_holder(sysfs_block, &[]).is_err());
+    }
+}
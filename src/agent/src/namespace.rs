@@ -5,12 +5,17 @@
 
 use anyhow::{anyhow, Result};
 use nix::mount::MsFlags;
-use nix::sched::{unshare, CloneFlags};
-use nix::unistd::{getpid, gettid};
+use nix::sched::{clone, unshare, CloneCb, CloneFlags};
+use nix::sys::signal::{SigSet, Signal};
+use nix::sys::wait::{waitpid, WaitPidFlag, WaitStatus};
+use nix::unistd::{getpid, gettid, Pid};
+use std::ffi::CString;
 use std::fmt;
 use std::fs;
 use std::fs::File;
 use std::path::{Path, PathBuf};
+use std::process::exit;
+use std::thread;
 use tracing::instrument;
 
 use crate::mount::{BareMount, FLAGS};
@@ -20,6 +25,13 @@ const PERSISTENT_NS_DIR: &str = "/var/run/sandbox-ns";
 pub const NSTYPEIPC: &str = "ipc";
 pub const NSTYPEUTS: &str = "uts";
 pub const NSTYPEPID: &str = "pid";
+pub const NSTYPENETWORK: &str = "network";
+
+// PIDNS_INIT_ARG is the argument the agent binary is run with to be the
+// init process of the sandbox pid namespace.
+pub const PIDNS_INIT_ARG: &str = "pidns-init";
+
+const PIDNS_INIT_STACK_SIZE: usize = 64 * 1024;
 
 #[instrument]
 pub fn get_current_thread_ns_path(ns_type: &str) -> String {
@@ -74,7 +86,12 @@ impl Namespace {
         self
     }
 
-    #[allow(dead_code)]
+    #[instrument]
+    pub fn get_net(mut self) -> Self {
+        self.ns_type = NamespaceType::Net;
+        self
+    }
+
     pub fn set_root_dir(mut self, dir: &str) -> Self {
         self.persistent_ns_dir = dir.to_string();
         self
@@ -100,8 +117,12 @@ impl Namespace {
         self.path = new_ns_path.clone().into_os_string().into_string().unwrap();
         let hostname = self.hostname.clone();
 
-        let new_thread = tokio::spawn(async move {
-            if let Err(err) = || -> Result<()> {
+        // The namespace is created by a thread of its own, which exits
+        // afterwards: the threads of the agent stay in their namespaces.
+        // The result is awaited, the tokio worker is not blocked meanwhile.
+        let (tx, rx) = tokio::sync::oneshot::channel();
+        thread::spawn(move || {
+            let _ = tx.send(|| -> Result<()> {
                 let origin_ns_path = get_current_thread_ns_path(&ns_type.get());
 
                 File::open(Path::new(&origin_ns_path))?;
@@ -136,27 +157,75 @@ impl Namespace {
                 })?;
 
                 Ok(())
-            }() {
-                return Err(err);
-            }
-
-            Ok(())
+            }());
         });
 
-        new_thread
-            .await
-            .map_err(|e| anyhow!("Failed to join thread {:?}!", e))??;
+        rx.await
+            .map_err(|e| anyhow!("Failed to create the namespace {:?}!", e))??;
 
         Ok(self)
     }
 }
 
+// spawn_pid_ns_init starts the init process of a new pid namespace, shared
+// by the containers of the sandbox, and returns its pid. The namespace and
+// the processes left in it are gone once the init process is killed.
+pub fn spawn_pid_ns_init() -> Result<Pid> {
+    let exe = CString::new("/proc/self/exe")?;
+    let arg = CString::new(PIDNS_INIT_ARG)?;
+    let argv = [exe.as_ptr(), arg.as_ptr(), std::ptr::null()];
+    let mut stack = vec![0u8; PIDNS_INIT_STACK_SIZE];
+
+    // The child is a copy of the multi-threaded agent, it does not
+    // allocate before running the agent binary again.
+    let cb: CloneCb = Box::new(|| {
+        unsafe { libc::execv(argv[0], argv.as_ptr()) };
+        127
+    });
+
+    let pid = clone(
+        cb,
+        &mut stack,
+        CloneFlags::CLONE_NEWPID,
+        Some(Signal::SIGCHLD as i32),
+    )?;
+
+    Ok(pid)
+}
+
+// pid_ns_init is run by the init process of the sandbox pid namespace. It
+// reaps the processes of the containers orphaned in the namespace. The
+// signals sent from the containers to the init process of their namespace
+// are ignored by the kernel, unless it has a handler.
+pub fn pid_ns_init() -> ! {
+    let mut sigchld = SigSet::empty();
+    sigchld.add(Signal::SIGCHLD);
+    if let Err(e) = sigchld.thread_block() {
+        eprintln!("failed to block SIGCHLD: {:?}", e);
+        exit(1);
+    }
+
+    loop {
+        if sigchld.wait().is_err() {
+            continue;
+        }
+
+        loop {
+            match waitpid(Pid::from_raw(-1), Some(WaitPidFlag::WNOHANG)) {
+                Ok(WaitStatus::StillAlive) | Err(_) => break,
+                Ok(_) => continue,
+            }
+        }
+    }
+}
+
 /// Represents the Namespace type.
 #[derive(Clone, Copy, PartialEq)]
 enum NamespaceType {
     Ipc,
     Uts,
     Pid,
+    Net,
 }
 
 impl NamespaceType {
@@ -166,6 +235,7 @@ impl NamespaceType {
             Self::Ipc => "ipc",
             Self::Uts => "uts",
             Self::Pid => "pid",
+            Self::Net => "net",
         }
     }
 
@@ -175,6 +245,7 @@ impl NamespaceType {
             Self::Ipc => CloneFlags::CLONE_NEWIPC,
             Self::Uts => CloneFlags::CLONE_NEWUTS,
             Self::Pid => CloneFlags::CLONE_NEWPID,
+            Self::Net => CloneFlags::CLONE_NEWNET,
         }
     }
 }
@@ -220,6 +291,18 @@ mod tests {
         assert!(ns_uts.is_ok());
         assert!(remove_mounts(&[ns_uts.unwrap().path]).is_ok());
 
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let tmpdir = Builder::new().prefix("net").tempdir().unwrap();
+
+        let ns_net = Namespace::new(&logger)
+            .get_net()
+            .set_root_dir(tmpdir.path().to_str().unwrap())
+            .setup()
+            .await;
+
+        assert!(ns_net.is_ok());
+        assert!(remove_mounts(&[ns_net.unwrap().path]).is_ok());
+
         // Check it cannot persist pid namespaces.
         let logger = slog::Logger::root(slog::Discard, o!());
         let tmpdir = Builder::new().prefix("pid").tempdir().unwrap();
@@ -246,5 +329,9 @@ mod tests {
         let pid = NamespaceType::Pid;
         assert_eq!("pid", pid.get());
         assert_eq!(CloneFlags::CLONE_NEWPID, pid.get_flags());
+
+        let net = NamespaceType::Net;
+        assert_eq!("net", net.get());
+        assert_eq!(CloneFlags::CLONE_NEWNET, net.get_flags());
     }
 }
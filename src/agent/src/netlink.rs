@@ -11,9 +11,18 @@ use protocols::types::{ARPNeighbor, IPAddress, IPFamily, Interface, Route};
 use rtnetlink::{new_connection, packet, IpVersion};
 use std::convert::{TryFrom, TryInto};
 use std::fmt;
+use std::fs;
 use std::net::{IpAddr, Ipv4Addr, Ipv6Addr};
 use std::ops::Deref;
+use std::os::unix::io::RawFd;
+use std::path::Path;
 use std::str::{self, FromStr};
+use std::time::{Duration, Instant};
+
+const IPV6_CONF_PATH: &str = "/proc/sys/net/ipv6/conf";
+
+// The interval between the lookups of a link being hotplugged.
+const LINK_WAIT_INTERVAL: Duration = Duration::from_millis(100);
 
 /// Search criteria to use when looking for a link in `find_link`.
 pub enum LinkFilter<'a> {
@@ -80,6 +89,19 @@ impl Handle {
             .await?;
         self.delete_addresses(addresses).await?;
 
+        // The IPv6 configuration is static, it comes from the CNI result
+        // on the host: the guest must not autoconfigure addresses or
+        // routes from the router advertisements, nor keep the addresses
+        // tentative while running a duplicate address detection the host
+        // already did.
+        if iface
+            .IPAddresses
+            .iter()
+            .any(|ip_address| is_ipv6(ip_address.get_address()))
+        {
+            disable_ipv6_autoconf(IPV6_CONF_PATH, &link.name())?;
+        }
+
         // Add new ip addresses from request
         for ip_address in &iface.IPAddresses {
             let ip = IpAddr::from_str(&ip_address.get_address())?;
@@ -216,6 +238,35 @@ impl Handle {
         Ok(())
     }
 
+    /// Move the link with the given hardware address to the network namespace
+    /// of the given file descriptor. The link may have just been hotplugged,
+    /// it is waited for until `timeout`.
+    pub async fn move_link(&self, hw_addr: &str, netns_fd: RawFd, timeout: Duration) -> Result<()> {
+        let deadline = Instant::now() + timeout;
+
+        let link = loop {
+            match self.find_link(LinkFilter::Address(hw_addr)).await {
+                Ok(link) => break link,
+                Err(e) if Instant::now() >= deadline => return Err(e),
+                Err(_) => tokio::time::sleep(LINK_WAIT_INTERVAL).await,
+            }
+        };
+
+        if link.is_up() {
+            self.enable_link(link.index(), false).await?;
+        }
+
+        self.handle
+            .link()
+            .set(link.index())
+            .setns_by_fd(netns_fd)
+            .execute()
+            .await
+            .with_context(|| format!("Failed to move link {}", link.name()))?;
+
+        Ok(())
+    }
+
     async fn query_routes(
         &self,
         ip_version: Option<IpVersion>,
@@ -279,11 +330,13 @@ impl Handle {
             if let Some(addr) = msg.gateway() {
                 route.gateway = addr.to_string();
 
-                // For gateway, destination is 0.0.0.0
-                route.dest = if addr.is_ipv4() {
-                    String::from("0.0.0.0")
-                } else {
-                    String::from("::1")
+                // For a default gateway, destination is 0.0.0.0 or ::
+                if route.dest.is_empty() {
+                    route.dest = if addr.is_ipv4() {
+                        String::from("0.0.0.0")
+                    } else {
+                        String::from("::")
+                    }
                 }
             }
 
@@ -594,8 +647,27 @@ fn format_address(data: &[u8]) -> Result<String> {
     }
 }
 
+/// Returns whether `str` is an IPv6 address, with or without a prefix length.
 fn is_ipv6(str: &str) -> bool {
-    Ipv6Addr::from_str(str).is_ok()
+    let addr = str.split('/').next().unwrap_or_default();
+    Ipv6Addr::from_str(addr).is_ok()
+}
+
+/// Disables the IPv6 stateless autoconfiguration and the duplicate address
+/// detection of the interface `name`. Nothing is done when the guest kernel
+/// has no IPv6 support.
+fn disable_ipv6_autoconf(conf_path: &str, name: &str) -> Result<()> {
+    let dir = Path::new(conf_path).join(name);
+    if !dir.exists() {
+        return Ok(());
+    }
+
+    for key in &["accept_ra", "autoconf", "accept_dad"] {
+        fs::write(dir.join(key), "0")
+            .with_context(|| format!("Failed to disable IPv6 {} on interface {}", key, name))?;
+    }
+
+    Ok(())
 }
 
 fn parse_mac_address(addr: &str) -> Result<[u8; 6]> {
@@ -701,9 +773,9 @@ impl TryFrom<Address> for IPAddress {
 
     fn try_from(value: Address) -> Result<Self, Self::Error> {
         let family = if value.is_ipv6() {
-            IPFamily::v4
-        } else {
             IPFamily::v6
+        } else {
+            IPFamily::v4
         };
 
         let mut address = value.address();
@@ -937,9 +1009,33 @@ mod tests {
         assert!(is_ipv6("::1"));
         assert!(is_ipv6("2001:0:3238:DFE1:63::FEFB"));
 
+        assert!(is_ipv6("fd00:10:244::/64"));
+
         assert!(!is_ipv6(""));
         assert!(!is_ipv6("127.0.0.1"));
         assert!(!is_ipv6("10.10.10.10"));
+        assert!(!is_ipv6("10.244.0.0/16"));
+    }
+
+    #[test]
+    fn disable_ipv6_autoconf_for_static_config() {
+        let dir = tempfile::tempdir().expect("failed to create tmpdir");
+        let conf_path = dir.path().to_str().unwrap();
+
+        // No IPv6 support for the interface
+        disable_ipv6_autoconf(conf_path, "eth0").expect("failed to skip the interface");
+
+        let eth0 = dir.path().join("eth0");
+        fs::create_dir(&eth0).unwrap();
+        for key in &["accept_ra", "autoconf", "accept_dad"] {
+            fs::write(eth0.join(key), "1").unwrap();
+        }
+
+        disable_ipv6_autoconf(conf_path, "eth0").expect("failed to disable autoconf");
+
+        for key in &["accept_ra", "autoconf", "accept_dad"] {
+            assert_eq!(fs::read_to_string(eth0.join(key)).unwrap(), "0");
+        }
     }
 
     fn clean_env_for_test_add_one_arp_neighbor(dummy_name: &str, ip: &str) {
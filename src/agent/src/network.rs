@@ -5,6 +5,7 @@
 
 use anyhow::{anyhow, Result};
 use nix::mount::{self, MsFlags};
+use protocols::agent::NetworkStats;
 use protocols::types::{Interface, Route};
 use slog::Logger;
 use std::collections::HashMap;
@@ -81,6 +82,34 @@ fn do_setup_guest_dns(logger: Logger, dns_list: Vec<String>, src: &str, dst: &st
     Ok(())
 }
 
+// get_network_stats returns the statistics of the guest network interfaces,
+// which are shared by all the containers of the sandbox.
+pub fn get_network_stats() -> Result<Vec<NetworkStats>> {
+    let devs = procfs::net::dev_status()
+        .map_err(|e| anyhow!("failed to get net device stats: {:?}", e))?;
+
+    let mut stats: Vec<NetworkStats> = devs
+        .values()
+        .filter(|s| s.name != "lo")
+        .map(|s| NetworkStats {
+            name: s.name.clone(),
+            rx_bytes: s.recv_bytes,
+            rx_packets: s.recv_packets,
+            rx_errors: s.recv_errs,
+            rx_dropped: s.recv_drop,
+            tx_bytes: s.sent_bytes,
+            tx_packets: s.sent_packets,
+            tx_errors: s.sent_errs,
+            tx_dropped: s.sent_drop,
+            ..Default::default()
+        })
+        .collect();
+
+    stats.sort_by(|a, b| a.name.cmp(&b.name));
+
+    Ok(stats)
+}
+
 #[cfg(test)]
 mod tests {
     use super::*;
@@ -147,4 +176,20 @@ mod tests {
         // umount /etc/resolv.conf
         let _ = mount::umount(dst_filename);
     }
+
+    #[test]
+    fn test_get_network_stats() {
+        let stats = get_network_stats();
+        assert!(stats.is_ok(), "{:?}", stats);
+
+        let stats = stats.unwrap();
+        assert!(stats.iter().all(|s| s.name != "lo"));
+
+        let mut names: Vec<&str> = stats.iter().map(|s| s.name.as_str()).collect();
+        names.sort_unstable();
+        assert_eq!(
+            names,
+            stats.iter().map(|s| s.name.as_str()).collect::<Vec<_>>()
+        );
+    }
 }
@@ -0,0 +1,260 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::sandbox::Sandbox;
+use anyhow::{anyhow, Result};
+use rustjail::cgroups::notifier;
+use std::collections::HashMap;
+use std::fs;
+use std::sync::atomic::Ordering;
+use std::sync::Arc;
+use std::time::Duration;
+use tokio::select;
+use tokio::sync::watch::Receiver;
+use tokio::sync::Mutex;
+use tracing::instrument;
+
+const VMSTAT_FILE: &str = "/proc/vmstat";
+const GLOBAL_OOM_POLL_INTERVAL: Duration = Duration::from_secs(1);
+
+// get_oom_kills returns the number of processes killed by the guest kernel
+// OOM killer, whatever the reason of the OOM.
+fn get_oom_kills(vmstat: &str) -> Result<u64> {
+    let content = fs::read_to_string(vmstat)?;
+
+    for line in content.lines() {
+        let mut fields = line.split_whitespace();
+        if fields.next() == Some("oom_kill") {
+            if let Some(value) = fields.next() {
+                return Ok(value.parse::<u64>()?);
+            }
+        }
+    }
+
+    Err(anyhow!("oom_kill not found in {}", vmstat))
+}
+
+// ContainerKills is the last seen OOM kill counter of a container memory
+// cgroup, along with the number of OOM events of the cgroup at that time.
+#[derive(Clone, Copy, Debug, Default, PartialEq)]
+struct ContainerKills {
+    kills: i64,
+    memcg_events: u64,
+}
+
+// global_oom_kills returns how many of the new OOM kills of a container are
+// caused by a global OOM. The kills caused by the container memory cgroup
+// limit, which come with a memory cgroup OOM event, are already reported by
+// Sandbox::run_oom_event_monitor.
+fn global_oom_kills(last: ContainerKills, current: ContainerKills) -> i64 {
+    let kills = current.kills.saturating_sub(last.kills);
+    let memcg_events = current.memcg_events.saturating_sub(last.memcg_events) as i64;
+
+    kills.saturating_sub(memcg_events).max(0)
+}
+
+// watch_global_oom reports the containers whose processes are killed when
+// the whole guest runs out of memory. With cgroup v1 the memory cgroups are
+// only notified of the OOMs caused by their own limit, so these kills would
+// go unnoticed otherwise. The containers are found from the oom_kill counter
+// of their memory cgroup, which the kernel updates for all the OOM kills.
+// The counter of a container is only a baseline when it's first seen, the
+// kills which happened before are not reported.
+#[instrument]
+pub async fn watch_global_oom(
+    sandbox: Arc<Mutex<Sandbox>>,
+    mut shutdown: Receiver<bool>,
+) -> Result<()> {
+    let s = sandbox.lock().await;
+    let logger = s.logger.new(o!("subsystem" => "oom"));
+    drop(s);
+
+    if cgroups::hierarchies::is_cgroup2_unified_mode() {
+        // memory.events already accounts for the global OOM kills.
+        return Ok(());
+    }
+
+    let mut last_kills = match get_oom_kills(VMSTAT_FILE) {
+        Ok(kills) => kills,
+        Err(e) => {
+            warn!(logger, "cannot watch global OOM kills: {:?}", e);
+            return Ok(());
+        }
+    };
+
+    info!(logger, "starting global OOM watcher");
+
+    let mut container_kills: HashMap<String, ContainerKills> = HashMap::new();
+
+    loop {
+        select! {
+            _ = shutdown.changed() => {
+                info!(logger, "got shutdown request");
+                break;
+            }
+            _ = tokio::time::sleep(GLOBAL_OOM_POLL_INTERVAL) => {}
+        }
+
+        let kills = match get_oom_kills(VMSTAT_FILE) {
+            Ok(kills) => kills,
+            Err(e) => {
+                warn!(logger, "failed to get OOM kills: {:?}", e);
+                continue;
+            }
+        };
+
+        // The containers are polled even without new OOM kills, so that
+        // the baseline of the new ones is set before they get killed.
+        let global_oom = kills != last_kills;
+        last_kills = kills;
+
+        let s = sandbox.lock().await;
+
+        let tx = match s.event_tx.as_ref() {
+            Some(tx) => tx.clone(),
+            // the sandbox is being destroyed
+            None => break,
+        };
+
+        container_kills.retain(|cid, _| s.containers.contains_key(cid));
+
+        let mut oom_containers = Vec::new();
+        for (cid, ctr) in s.containers.iter() {
+            if *cid == s.id {
+                continue;
+            }
+
+            let cg_path = match ctr
+                .cgroup_manager
+                .as_ref()
+                .and_then(|m| m.get_cg_path("memory"))
+            {
+                Some(path) => path,
+                None => continue,
+            };
+
+            // Read the memory cgroup OOM events first: the event of a
+            // cgroup limit OOM comes before the kill.
+            let memcg_events = s
+                .memcg_oom_events
+                .get(cid)
+                .map(|events| events.load(Ordering::SeqCst))
+                .unwrap_or(0);
+
+            let count = match notifier::get_oom_kill_count(&cg_path) {
+                Ok(count) => count,
+                Err(e) => {
+                    warn!(
+                        logger,
+                        "failed to get OOM kills of container {}: {:?}", cid, e
+                    );
+                    continue;
+                }
+            };
+
+            let current = ContainerKills {
+                kills: count,
+                memcg_events,
+            };
+
+            let last = match container_kills.insert(cid.clone(), current) {
+                Some(last) => last,
+                None => continue,
+            };
+
+            if global_oom && global_oom_kills(last, current) > 0 {
+                oom_containers.push(cid.clone());
+            }
+        }
+
+        drop(s);
+
+        for cid in oom_containers {
+            info!(logger, "container {} got an OOM kill", cid);
+
+            let _ = tx
+                .send(cid)
+                .await
+                .map_err(|e| error!(logger, "failed to send message: {:?}", e));
+        }
+    }
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::io::Write;
+    use tempfile::NamedTempFile;
+
+    #[test]
+    fn test_get_oom_kills() {
+        let mut vmstat = NamedTempFile::new().unwrap();
+        writeln!(vmstat, "nr_free_pages 123\noom_kill 3\nnr_dirtied 4").unwrap();
+
+        let kills = get_oom_kills(vmstat.path().to_str().unwrap());
+        assert_eq!(kills.unwrap(), 3);
+
+        let mut vmstat = NamedTempFile::new().unwrap();
+        writeln!(vmstat, "nr_free_pages 123").unwrap();
+
+        assert!(get_oom_kills(vmstat.path().to_str().unwrap()).is_err());
+        assert!(get_oom_kills("/does/not/exist").is_err());
+    }
+
+    #[test]
+    fn test_global_oom_kills() {
+        #[derive(Debug)]
+        struct TestData {
+            last: ContainerKills,
+            current: ContainerKills,
+            result: i64,
+        }
+
+        let kills = |kills, memcg_events| ContainerKills {
+            kills,
+            memcg_events,
+        };
+
+        let tests = &[
+            // nothing happened
+            TestData {
+                last: kills(2, 1),
+                current: kills(2, 1),
+                result: 0,
+            },
+            // global OOM kill
+            TestData {
+                last: kills(2, 1),
+                current: kills(3, 1),
+                result: 1,
+            },
+            // memory cgroup limit OOM kill
+            TestData {
+                last: kills(2, 1),
+                current: kills(3, 2),
+                result: 0,
+            },
+            // both
+            TestData {
+                last: kills(2, 1),
+                current: kills(5, 2),
+                result: 2,
+            },
+            // memory cgroup OOM event without kill
+            TestData {
+                last: kills(2, 1),
+                current: kills(2, 2),
+                result: 0,
+            },
+        ];
+
+        for (i, d) in tests.iter().enumerate() {
+            let msg = format!("test[{}]: {:?}", i, d);
+            assert_eq!(global_oom_kills(d.last, d.current), d.result, "{}", msg);
+        }
+    }
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::util;
+use anyhow::{anyhow, Context, Result};
+use futures::StreamExt;
+use nix::sys::socket::{self, AddressFamily, SockAddr, SockFlag, SockType};
+use serde_json::{json, Value};
+use slog::Logger;
+use std::fs;
+use std::sync::RwLock;
+use std::time::{SystemTime, UNIX_EPOCH};
+use tokio::io::AsyncWriteExt;
+use tokio::select;
+use tokio::sync::broadcast;
+use tokio::sync::watch::Receiver;
+
+// Decisions buffered for each subscriber, the slow subscribers miss the
+// oldest ones.
+const DECISIONS_CHANNEL_SIZE: usize = 1024;
+
+const LISTEN_BACKLOG: usize = 16;
+
+// The rule name reported when no rule matches a request.
+const DEFAULT_RULE: &str = "default";
+
+const ANY_REQUEST: &str = "*";
+
+macro_rules! sl {
+    () => {
+        slog_scope::logger().new(o!("subsystem" => "policy"))
+    };
+}
+
+lazy_static! {
+    static ref POLICY: RwLock<Option<Policy>> = RwLock::new(None);
+    static ref DECISIONS: broadcast::Sender<String> = {
+        let (tx, _) = broadcast::channel(DECISIONS_CHANNEL_SIZE);
+        tx
+    };
+}
+
+#[derive(Debug, Clone, PartialEq)]
+struct Rule {
+    name: String,
+    requests: Vec<String>,
+    allow: bool,
+}
+
+impl Rule {
+    fn matches(&self, request: &str) -> bool {
+        self.requests
+            .iter()
+            .any(|r| r == ANY_REQUEST || r == request)
+    }
+}
+
+// Policy allows or denies the agent requests by their type, e.g.
+// "ExecProcessRequest". The first matching rule applies, and the default
+// action when none does. In audit mode the denied requests are still
+// served, the decisions only being reported.
+#[derive(Debug, Clone, PartialEq)]
+pub struct Policy {
+    default_allow: bool,
+    rules: Vec<Rule>,
+    audit: bool,
+}
+
+// Decision is the outcome of the policy for a request.
+#[derive(Debug, Clone, PartialEq)]
+struct Decision<'a> {
+    rule: &'a str,
+    allowed: bool,
+}
+
+impl Policy {
+    // parse parses a policy like:
+    //
+    // {"default": "allow", "rules": [{"name": "no-exec", "requests": ["ExecProcessRequest"], "action": "deny"}]}
+    pub fn parse(data: &str, audit: bool) -> Result<Policy> {
+        let v: Value = serde_json::from_str(data).context("invalid policy")?;
+
+        let default_allow = match v.get("default") {
+            Some(action) => parse_action(action)?,
+            None => true,
+        };
+
+        let mut rules = Vec::new();
+
+        if let Some(list) = v.get("rules") {
+            let list = list
+                .as_array()
+                .ok_or_else(|| anyhow!("policy rules must be a list"))?;
+
+            for (i, r) in list.iter().enumerate() {
+                let name = match r.get("name").and_then(|n| n.as_str()) {
+                    Some(name) if !name.is_empty() => name.to_string(),
+                    _ => return Err(anyhow!("policy rule {} has no name", i)),
+                };
+
+                let requests = r
+                    .get("requests")
+                    .and_then(|l| l.as_array())
+                    .ok_or_else(|| anyhow!("policy rule {} has no requests", name))?
+                    .iter()
+                    .map(|r| {
+                        r.as_str()
+                            .map(|s| s.to_string())
+                            .ok_or_else(|| anyhow!("policy rule {} has an invalid request", name))
+                    })
+                    .collect::<Result<Vec<String>>>()?;
+
+                let allow = parse_action(
+                    r.get("action")
+                        .ok_or_else(|| anyhow!("policy rule {} has no action", name))?,
+                )?;
+
+                rules.push(Rule {
+                    name,
+                    requests,
+                    allow,
+                });
+            }
+        }
+
+        Ok(Policy {
+            default_allow,
+            rules,
+            audit,
+        })
+    }
+
+    fn decide(&self, request: &str) -> Decision {
+        match self.rules.iter().find(|r| r.matches(request)) {
+            Some(r) => Decision {
+                rule: &r.name,
+                allowed: r.allow,
+            },
+            None => Decision {
+                rule: DEFAULT_RULE,
+                allowed: self.default_allow,
+            },
+        }
+    }
+}
+
+fn parse_action(action: &Value) -> Result<bool> {
+    match action.as_str() {
+        Some("allow") => Ok(true),
+        Some("deny") => Ok(false),
+        _ => Err(anyhow!("invalid policy action {}", action)),
+    }
+}
+
+// load loads the policy file, all the requests are allowed without one.
+pub fn load(path: &str, audit: bool) -> Result<()> {
+    let data = fs::read_to_string(path).context(format!("failed to read policy {}", path))?;
+    let policy = Policy::parse(&data, audit)?;
+
+    info!(sl!(), "loaded policy";
+        "path" => path,
+        "rules" => policy.rules.len(),
+        "audit" => audit);
+
+    *POLICY.write().unwrap() = Some(policy);
+
+    Ok(())
+}
+
+// is_allowed checks the request against the policy, reporting the
+// decision. It only fails for the denied requests when the policy is
+// enforced.
+pub fn is_allowed<M: protobuf::Message>(req: &M) -> Result<()> {
+    check(request_name::<M>(), || {
+        req.write_to_bytes().unwrap_or_default()
+    })
+}
+
+// is_stream_allowed checks a request of the agent vsock ports against the
+// policy, like is_allowed. The request is named like the agent requests,
+// e.g. "PortForwardRequest", and data is what it asks for, for its digest.
+//
+// The streams of the network captures and of the checkpoints follow a
+// request checked by is_allowed, they are not checked again. Neither the
+// policy decisions stream nor the debug console are checked.
+pub fn is_stream_allowed(request: &str, data: &[u8]) -> Result<()> {
+    check(request, || data.to_vec())
+}
+
+fn check<F: FnOnce() -> Vec<u8>>(request: &str, data: F) -> Result<()> {
+    let guard = POLICY.read().unwrap();
+    let policy = match guard.as_ref() {
+        Some(policy) => policy,
+        None => return Ok(()),
+    };
+
+    let decision = policy.decide(request);
+    let digest = format!("{:016x}", fnv1a(&data()));
+
+    if decision.allowed {
+        debug!(sl!(), "request allowed by policy";
+            "request" => request,
+            "rule" => decision.rule,
+            "digest" => &digest);
+    } else {
+        warn!(sl!(), "request denied by policy";
+            "request" => request,
+            "rule" => decision.rule,
+            "digest" => &digest,
+            "enforced" => !policy.audit);
+    }
+
+    // Nobody may be listening, which is fine
+    let _ = DECISIONS.send(
+        json!({
+            "time": SystemTime::now()
+                .duration_since(UNIX_EPOCH)
+                .map(|d| d.as_secs())
+                .unwrap_or_default(),
+            "request": request,
+            "rule": decision.rule,
+            "allowed": decision.allowed,
+            "enforced": !policy.audit,
+            "digest": digest,
+        })
+        .to_string(),
+    );
+
+    if decision.allowed || policy.audit {
+        return Ok(());
+    }
+
+    Err(anyhow!(
+        "{} is blocked by policy rule {}",
+        request,
+        decision.rule
+    ))
+}
+
+// request_name returns the request type name, without its module path.
+fn request_name<M>() -> &'static str {
+    let name = std::any::type_name::<M>();
+    name.rsplit("::").next().unwrap_or(name)
+}
+
+// fnv1a identifies the requests in the decisions, it is not meant to be
+// collision resistant.
+fn fnv1a(data: &[u8]) -> u64 {
+    data.iter().fold(0xcbf29ce484222325, |hash, b| {
+        (hash ^ *b as u64).wrapping_mul(0x100000001b3)
+    })
+}
+
+// policy_decisions_handler streams the policy decisions to the runtime on
+// the given vsock port, one JSON object per line, so the policies can be
+// tried in audit mode before enforcing them.
+pub async fn policy_decisions_handler(
+    logger: Logger,
+    port: u32,
+    mut shutdown: Receiver<bool>,
+) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "policy"));
+
+    let listenfd = socket::socket(
+        AddressFamily::Vsock,
+        SockType::Stream,
+        SockFlag::SOCK_CLOEXEC,
+        None,
+    )?;
+    let addr = SockAddr::new_vsock(libc::VMADDR_CID_ANY, port);
+    socket::bind(listenfd, &addr)?;
+    socket::listen(listenfd, LISTEN_BACKLOG)?;
+
+    let mut incoming = util::get_vsock_incoming(listenfd);
+
+    loop {
+        select! {
+            _ = shutdown.changed() => {
+                info!(logger, "policy decisions got shutdown request");
+                break;
+            }
+
+            conn = incoming.next() => {
+                if let Some(conn) = conn {
+                    match conn {
+                        Ok(mut stream) => {
+                            let logger = logger.clone();
+                            let mut decisions = DECISIONS.subscribe();
+                            // Do not block(await) here, or we'll never receive the shutdown signal
+                            tokio::spawn(async move {
+                                loop {
+                                    let line = match decisions.recv().await {
+                                        Ok(line) => line,
+                                        Err(broadcast::error::RecvError::Lagged(n)) => {
+                                            warn!(logger, "dropped {} policy decisions", n);
+                                            continue;
+                                        }
+                                        Err(broadcast::error::RecvError::Closed) => break,
+                                    };
+
+                                    let line = format!("{}\n", line);
+                                    if stream.write_all(line.as_bytes()).await.is_err() {
+                                        break;
+                                    }
+                                }
+                            });
+                        }
+                        Err(e) => {
+                            error!(logger, "{:?}", e);
+                        }
+                    }
+                } else {
+                    break;
+                }
+            }
+        }
+    }
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use protocols::agent::{ExecProcessRequest, StartContainerRequest};
+
+    const TEST_POLICY: &str = r#"{
+        "default": "deny",
+        "rules": [
+            {"name": "no-exec", "requests": ["ExecProcessRequest"], "action": "deny"},
+            {"name": "containers", "requests": ["CreateContainerRequest", "StartContainerRequest"], "action": "allow"}
+        ]
+    }"#;
+
+    #[test]
+    fn test_policy_parse() {
+        let policy = Policy::parse(TEST_POLICY, false).unwrap();
+        assert!(!policy.default_allow);
+        assert_eq!(policy.rules.len(), 2);
+        assert_eq!(policy.rules[0].name, "no-exec");
+        assert!(!policy.rules[0].allow);
+        assert!(policy.rules[1].allow);
+
+        let policy = Policy::parse("{}", true).unwrap();
+        assert!(policy.default_allow);
+        assert!(policy.rules.is_empty());
+
+        for invalid in &[
+            "",
+            r#"{"default": "maybe"}"#,
+            r#"{"rules": {}}"#,
+            r#"{"rules": [{"requests": ["*"], "action": "deny"}]}"#,
+            r#"{"rules": [{"name": "r", "action": "deny"}]}"#,
+            r#"{"rules": [{"name": "r", "requests": [1], "action": "deny"}]}"#,
+            r#"{"rules": [{"name": "r", "requests": ["*"]}]}"#,
+        ] {
+            assert!(Policy::parse(invalid, false).is_err(), "{}", invalid);
+        }
+    }
+
+    #[test]
+    fn test_policy_decide() {
+        let policy = Policy::parse(TEST_POLICY, false).unwrap();
+
+        let d = policy.decide("ExecProcessRequest");
+        assert_eq!(d.rule, "no-exec");
+        assert!(!d.allowed);
+
+        let d = policy.decide("StartContainerRequest");
+        assert_eq!(d.rule, "containers");
+        assert!(d.allowed);
+
+        let d = policy.decide("CopyFileRequest");
+        assert_eq!(d.rule, DEFAULT_RULE);
+        assert!(!d.allowed);
+
+        let policy = Policy::parse(
+            r#"{"rules": [{"name": "all", "requests": ["*"], "action": "deny"}]}"#,
+            false,
+        )
+        .unwrap();
+        assert_eq!(policy.decide("CopyFileRequest").rule, "all");
+    }
+
+    #[test]
+    fn test_request_name() {
+        assert_eq!(request_name::<ExecProcessRequest>(), "ExecProcessRequest");
+        assert_eq!(
+            request_name::<StartContainerRequest>(),
+            "StartContainerRequest"
+        );
+    }
+
+    #[test]
+    fn test_fnv1a() {
+        assert_eq!(fnv1a(b""), 0xcbf29ce484222325);
+        assert_eq!(fnv1a(b"a"), 0xaf63dc4c8601ec8c);
+        assert_ne!(fnv1a(b"ab"), fnv1a(b"ba"));
+    }
+}
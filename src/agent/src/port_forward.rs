@@ -0,0 +1,211 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::policy;
+use crate::util;
+use anyhow::{anyhow, Result};
+use futures::StreamExt;
+use nix::sys::socket::{self, AddressFamily, SockAddr, SockFlag, SockType};
+use slog::Logger;
+use tokio::io::{AsyncRead, AsyncReadExt, AsyncWrite, AsyncWriteExt};
+use tokio::net::TcpStream;
+use tokio::select;
+use tokio::sync::watch::Receiver;
+
+// The longest request is a 5 digit port followed by a newline.
+const MAX_REQUEST_LEN: usize = 6;
+
+// The port forward connections are checked against the agent policy as
+// this request.
+const PORT_FORWARD_REQUEST: &str = "PortForwardRequest";
+
+// Connections pending acceptance, several forwarded connections can be
+// opened at once.
+const LISTEN_BACKLOG: usize = 128;
+
+// port_forward_handler serves the port forward requests of the runtime on
+// the given vsock port. Each connection starts with the guest TCP port to
+// connect to, followed by a newline. The agent replies with "OK\n", or with
+// "ERR <error>\n", and then forwards the connection to the TCP port.
+pub async fn port_forward_handler(
+    logger: Logger,
+    port: u32,
+    mut shutdown: Receiver<bool>,
+) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "port-forward"));
+
+    let listenfd = socket::socket(
+        AddressFamily::Vsock,
+        SockType::Stream,
+        SockFlag::SOCK_CLOEXEC,
+        None,
+    )?;
+    let addr = SockAddr::new_vsock(libc::VMADDR_CID_ANY, port);
+    socket::bind(listenfd, &addr)?;
+    socket::listen(listenfd, LISTEN_BACKLOG)?;
+
+    let mut incoming = util::get_vsock_incoming(listenfd);
+
+    loop {
+        select! {
+            _ = shutdown.changed() => {
+                info!(logger, "port forward got shutdown request");
+                break;
+            }
+
+            conn = incoming.next() => {
+                if let Some(conn) = conn {
+                    match conn {
+                        Ok(stream) => {
+                            let logger = logger.clone();
+                            // Do not block(await) here, or we'll never receive the shutdown signal
+                            tokio::spawn(async move {
+                                if let Err(e) = forward_connection(stream).await {
+                                    error!(logger, "port forward failed: {:?}", e);
+                                }
+                            });
+                        }
+                        Err(e) => {
+                            error!(logger, "{:?}", e);
+                        }
+                    }
+                } else {
+                    break;
+                }
+            }
+        }
+    }
+
+    Ok(())
+}
+
+async fn forward_connection<T: AsyncRead + AsyncWrite + Unpin>(mut stream: T) -> Result<()> {
+    let port = read_port(&mut stream).await?;
+
+    if let Err(e) = policy::is_stream_allowed(PORT_FORWARD_REQUEST, port.to_string().as_bytes()) {
+        stream.write_all(format!("ERR {}\n", e).as_bytes()).await?;
+        return Err(e);
+    }
+
+    let tcp = match TcpStream::connect(("127.0.0.1", port)).await {
+        Ok(tcp) => tcp,
+        Err(e) => {
+            stream.write_all(format!("ERR {}\n", e).as_bytes()).await?;
+            return Err(anyhow!("failed to connect to port {}: {}", port, e));
+        }
+    };
+
+    stream.write_all(b"OK\n").await?;
+
+    tunnel(stream, tcp).await
+}
+
+// read_port reads the request byte by byte, not to consume the forwarded
+// stream.
+async fn read_port<T: AsyncRead + Unpin>(stream: &mut T) -> Result<u16> {
+    let mut request = Vec::new();
+
+    loop {
+        let b = stream.read_u8().await?;
+        if b == b'\n' {
+            break;
+        }
+
+        if request.len() == MAX_REQUEST_LEN {
+            return Err(anyhow!("port forward request too long"));
+        }
+        request.push(b);
+    }
+
+    let port = String::from_utf8(request)?.parse::<u16>()?;
+    if port == 0 {
+        return Err(anyhow!("invalid port 0"));
+    }
+
+    Ok(port)
+}
+
+// tunnel copies the data in both directions, shutting down the write side
+// of a stream once its peer stops sending.
+async fn tunnel<A, B>(a: A, b: B) -> Result<()>
+where
+    A: AsyncRead + AsyncWrite,
+    B: AsyncRead + AsyncWrite,
+{
+    let (mut a_reader, mut a_writer) = tokio::io::split(a);
+    let (mut b_reader, mut b_writer) = tokio::io::split(b);
+
+    let a_to_b = async {
+        let res = tokio::io::copy(&mut a_reader, &mut b_writer).await;
+        let _ = b_writer.shutdown().await;
+        res
+    };
+    let b_to_a = async {
+        let res = tokio::io::copy(&mut b_reader, &mut a_writer).await;
+        let _ = a_writer.shutdown().await;
+        res
+    };
+
+    let (r1, r2) = tokio::join!(a_to_b, b_to_a);
+    r1?;
+    r2?;
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use tokio::net::TcpListener;
+
+    #[tokio::test]
+    async fn test_read_port() {
+        let tests: &[(&[u8], Option<u16>)] = &[
+            (b"8080\n", Some(8080)),
+            (b"65535\nextra", Some(65535)),
+            (b"0\n", None),
+            (b"65536\n", None),
+            (b"foo\n", None),
+            (b"\n", None),
+            (b"8080", None),
+            (b"00000008080\n", None),
+        ];
+
+        for (i, (request, expected)) in tests.iter().enumerate() {
+            let mut stream = *request;
+            let result = read_port(&mut stream).await;
+            match expected {
+                Some(port) => assert_eq!(result.unwrap(), *port, "test[{}]", i),
+                None => assert!(result.is_err(), "test[{}]", i),
+            }
+        }
+    }
+
+    #[tokio::test]
+    async fn test_forward_connection() {
+        let listener = TcpListener::bind("127.0.0.1:0").await.unwrap();
+        let port = listener.local_addr().unwrap().port();
+
+        tokio::spawn(async move {
+            let (mut conn, _) = listener.accept().await.unwrap();
+            let (mut r, mut w) = conn.split();
+            tokio::io::copy(&mut r, &mut w).await.unwrap();
+        });
+
+        let (mut host, guest) = tokio::io::duplex(64);
+        let forward = tokio::spawn(forward_connection(guest));
+
+        host.write_all(format!("{}\nping", port).as_bytes())
+            .await
+            .unwrap();
+
+        let mut reply = [0u8; 7];
+        host.read_exact(&mut reply).await.unwrap();
+        assert_eq!(&reply, b"OK\nping");
+
+        drop(host);
+        assert!(forward.await.unwrap().is_ok());
+    }
+}
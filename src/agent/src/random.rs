@@ -7,6 +7,7 @@ use anyhow::Result;
 use nix::errno::Errno;
 use nix::fcntl::{self, OFlag};
 use nix::sys::stat::Mode;
+use slog::{info, warn, Logger};
 use std::fs;
 use std::os::unix::io::{AsRawFd, FromRawFd};
 use tracing::instrument;
@@ -14,6 +15,7 @@ use tracing::instrument;
 pub const RNGDEV: &str = "/dev/random";
 pub const RNDADDTOENTCNT: libc::c_int = 0x40045201;
 pub const RNDRESEEDRNG: libc::c_int = 0x5207;
+pub const HWRNG_CURRENT: &str = "/sys/class/misc/hw_random/rng_current";
 
 // Handle the differing ioctl(2) request types for different targets
 #[cfg(target_env = "musl")]
@@ -46,3 +48,62 @@ pub fn reseed_rng(data: &[u8]) -> Result<()> {
 
     Ok(())
 }
+
+// crng_ready tells if the kernel random number generator is initialized,
+// i.e. if getrandom(2) does not block.
+pub fn crng_ready() -> bool {
+    let mut buf = [0u8; 1];
+    let ret = unsafe {
+        libc::syscall(
+            libc::SYS_getrandom,
+            buf.as_mut_ptr(),
+            buf.len(),
+            libc::GRND_NONBLOCK,
+        )
+    };
+
+    !(ret < 0 && Errno::last() == Errno::EAGAIN)
+}
+
+// hw_rng returns the hardware random number generator feeding the kernel one,
+// e.g. the virtio-rng device of the VM, if any.
+pub fn hw_rng() -> Option<String> {
+    fs::read_to_string(HWRNG_CURRENT)
+        .ok()
+        .map(|rng| rng.trim().to_string())
+        .filter(|rng| !rng.is_empty() && rng != "none")
+}
+
+// check_entropy logs whether the guest has enough boot entropy, without a
+// hardware random number generator the getrandom(2) calls of the containers
+// may block until the runtime seeds the kernel one.
+pub fn check_entropy(logger: &Logger) {
+    let hw_rng = hw_rng();
+
+    if crng_ready() {
+        info!(logger, "random number generator initialized";
+            "hw-rng" => hw_rng.as_deref().unwrap_or("none"));
+        return;
+    }
+
+    match hw_rng {
+        Some(rng) => info!(logger, "random number generator not initialized yet";
+            "hw-rng" => rng),
+        None => warn!(
+            logger,
+            "random number generator not initialized and no hardware one, getrandom(2) may block"
+        ),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_crng_ready() {
+        // The random number generator of the host running the tests is
+        // initialized long ago.
+        assert!(crng_ready());
+    }
+}
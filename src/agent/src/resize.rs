@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::mount::get_mount_fs_type;
+use anyhow::{anyhow, Context, Result};
+use nix::errno::Errno;
+use nix::sys::stat;
+use nix::sys::statfs;
+use std::convert::TryInto;
+use std::fs;
+use std::os::unix::io::AsRawFd;
+use std::path::Path;
+use tracing::instrument;
+
+pub const SYSFS_DEV_BLOCK_PATH: &str = "/sys/dev/block";
+
+// _IOW('f', 16, __u64)
+pub const EXT4_IOC_RESIZE_FS: libc::c_ulong = 0x40086610;
+// _IOR('X', 100, struct xfs_fsop_geom_v1)
+pub const XFS_IOC_FSGEOMETRY_V1: libc::c_ulong = 0x80705864;
+// _IOW('X', 110, struct xfs_growfs_data)
+pub const XFS_IOC_FSGROWFSDATA: libc::c_ulong = 0x4010586e;
+
+// The size of struct xfs_fsop_geom_v1, and the offsets of its blocksize and
+// imaxpct fields.
+const XFS_GEOMETRY_V1_SIZE: usize = 112;
+const XFS_GEOMETRY_BLOCKSIZE_OFFSET: usize = 0;
+const XFS_GEOMETRY_IMAXPCT_OFFSET: usize = 28;
+
+// Handle the differing ioctl(2) request types for different targets
+#[cfg(target_env = "musl")]
+type IoctlRequestType = libc::c_int;
+#[cfg(target_env = "gnu")]
+type IoctlRequestType = libc::c_ulong;
+
+// struct xfs_growfs_data
+#[repr(C)]
+struct XfsGrowfsData {
+    newblocks: u64,
+    imaxpct: u32,
+}
+
+// resize_volume grows the filesystem mounted at mount_point to the size in
+// bytes of its block device, once the device has been resized by the
+// hypervisor. Only the ext4 and xfs filesystems are grown, online.
+#[instrument]
+pub fn resize_volume(mount_point: &str, size: u64) -> Result<()> {
+    let fs_type = get_mount_fs_type(mount_point)?;
+
+    rescan_device(mount_point)?;
+
+    let dir = fs::File::open(mount_point)
+        .with_context(|| format!("failed to open mount point {}", mount_point))?;
+
+    match fs_type.as_str() {
+        "ext4" => {
+            let block_size = statfs::fstatfs(&dir)?.block_size() as u64;
+            resize_ext4(&dir, size / block_size)
+        }
+        "xfs" => resize_xfs(&dir, size),
+        _ => Err(anyhow!("cannot grow {} filesystems", fs_type)),
+    }
+    .with_context(|| {
+        format!(
+            "failed to grow the {} filesystem at {}",
+            fs_type, mount_point
+        )
+    })
+}
+
+// rescan_device has the SCSI disks mounted at mount_point read their new
+// capacity, the virtio-blk ones are notified of it by the device.
+fn rescan_device(mount_point: &str) -> Result<()> {
+    let dev = stat::stat(mount_point)?.st_dev;
+    let rescan = Path::new(SYSFS_DEV_BLOCK_PATH)
+        .join(format!("{}:{}", stat::major(dev), stat::minor(dev)))
+        .join("device/rescan");
+
+    if !rescan.exists() {
+        return Ok(());
+    }
+
+    fs::write(&rescan, "1").with_context(|| format!("failed to rescan {:?}", rescan))
+}
+
+fn resize_ext4(dir: &fs::File, blocks: u64) -> Result<()> {
+    let ret = unsafe {
+        libc::ioctl(
+            dir.as_raw_fd(),
+            EXT4_IOC_RESIZE_FS as IoctlRequestType,
+            &blocks as *const u64,
+        )
+    };
+    Errno::result(ret).map(drop)?;
+
+    Ok(())
+}
+
+fn resize_xfs(dir: &fs::File, size: u64) -> Result<()> {
+    let mut geometry = [0u8; XFS_GEOMETRY_V1_SIZE];
+    let ret = unsafe {
+        libc::ioctl(
+            dir.as_raw_fd(),
+            XFS_IOC_FSGEOMETRY_V1 as IoctlRequestType,
+            geometry.as_mut_ptr(),
+        )
+    };
+    Errno::result(ret).map(drop)?;
+
+    let field =
+        |offset: usize| u32::from_ne_bytes(geometry[offset..offset + 4].try_into().unwrap());
+    let block_size = field(XFS_GEOMETRY_BLOCKSIZE_OFFSET) as u64;
+    if block_size == 0 {
+        return Err(anyhow!("invalid xfs block size"));
+    }
+
+    let data = XfsGrowfsData {
+        newblocks: size / block_size,
+        imaxpct: field(XFS_GEOMETRY_IMAXPCT_OFFSET),
+    };
+    let ret = unsafe {
+        libc::ioctl(
+            dir.as_raw_fd(),
+            XFS_IOC_FSGROWFSDATA as IoctlRequestType,
+            &data as *const XfsGrowfsData,
+        )
+    };
+    Errno::result(ret).map(drop)?;
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_resize_volume_no_mount_point() {
+        assert!(resize_volume("", 1 << 30).is_err());
+    }
+
+    #[test]
+    fn test_xfs_growfs_data_layout() {
+        assert_eq!(std::mem::size_of::<XfsGrowfsData>(), 16);
+    }
+}
@@ -20,8 +20,10 @@ use anyhow::{anyhow, Context, Result};
 use oci::{LinuxNamespace, Root, Spec};
 use protobuf::{RepeatedField, SingularPtrField};
 use protocols::agent::{
-    AgentDetails, CopyFileRequest, GuestDetailsResponse, Interfaces, Metrics, OOMEvent,
-    ReadStreamResponse, Routes, StatsContainerResponse, WaitProcessResponse, WriteStreamResponse,
+    AgentDetails, CheckpointContainerResponse, CopyFileRequest, GuestDetailsResponse,
+    GuestDiagnostics, Interfaces, Metrics, OOMEvent, ReadStreamResponse, RestoreContainerResponse,
+    Routes, StartNetworkCaptureResponse, StatsContainerResponse, WaitProcessResponse,
+    WriteStreamResponse,
 };
 use protocols::empty::Empty;
 use protocols::health::{
@@ -40,15 +42,23 @@ use nix::sys::stat;
 use nix::unistd::{self, Pid};
 use rustjail::process::ProcessOperations;
 
+use crate::capture;
+use crate::checkpoint;
+use crate::container_network::{remove_container_network, setup_container_network};
 use crate::device::{add_devices, rescan_pci_bus, update_device_cgroup};
+use crate::diagnostics;
 use crate::linux_abi::*;
 use crate::metrics::get_metrics;
 use crate::mount::{add_storages, remove_mounts, BareMount, STORAGE_HANDLER_LIST};
-use crate::namespace::{NSTYPEIPC, NSTYPEPID, NSTYPEUTS};
-use crate::network::setup_guest_dns;
+use crate::namespace::{NSTYPEIPC, NSTYPENETWORK, NSTYPEPID, NSTYPEUTS};
+use crate::network::{get_network_stats, setup_guest_dns};
+use crate::policy;
 use crate::random;
+use crate::resize;
 use crate::sandbox::Sandbox;
+use crate::sysctl::set_guest_sysctls;
 use crate::version::{AGENT_VERSION, API_VERSION};
+use crate::virtiofs;
 use crate::AGENT_CONFIG;
 
 use crate::trace_rpc_call;
@@ -82,6 +92,15 @@ macro_rules! sl {
     };
 }
 
+// Returns the permission denied error when the policy denies the request.
+macro_rules! is_allowed {
+    ($req:ident) => {
+        if let Err(e) = policy::is_allowed(&$req) {
+            return Err(ttrpc_error(ttrpc::Code::PERMISSION_DENIED, e.to_string()));
+        }
+    };
+}
+
 #[derive(Clone, Debug)]
 pub struct AgentService {
     sandbox: Arc<Mutex<Sandbox>>,
@@ -114,6 +133,12 @@ impl AgentService {
 
         verify_cid(&cid)?;
 
+        // The network namespace of an existing container would be replaced
+        // by the one set up for the request.
+        if self.sandbox.lock().await.get_container(&cid).is_some() {
+            return Err(anyhow!("container {} already exists", cid));
+        }
+
         let mut oci_spec = req.OCI.clone();
         let use_sandbox_pidns = req.get_sandbox_pidns();
 
@@ -155,7 +180,33 @@ impl AgentService {
             s.container_mounts.insert(cid.clone(), m);
         }
 
-        update_container_namespaces(&s, &mut oci, use_sandbox_pidns)?;
+        // A container given its own interfaces has its own network
+        // namespace, rather than the one of the sandbox.
+        let netns = if req.interfaces.is_empty() {
+            None
+        } else {
+            let path = setup_container_network(
+                &sl!(),
+                &s.rtnl,
+                &cid,
+                req.interfaces.to_vec(),
+                req.routes.to_vec(),
+            )
+            .await?;
+            Some(path)
+        };
+
+        // The network namespace set up for the request is removed if the
+        // container fails to be created.
+        let netns_guard = scopeguard::guard(netns.is_some(), |created| {
+            if created {
+                if let Err(e) = remove_container_network(&cid) {
+                    warn!(sl!(), "failed to remove the container network: {:?}", e);
+                }
+            }
+        });
+
+        update_container_namespaces(&s, &mut oci, use_sandbox_pidns, netns)?;
 
         // Add the root partition to the device cgroup to prevent access
         update_device_cgroup(&mut oci)?;
@@ -200,6 +251,7 @@ impl AgentService {
 
         s.update_shared_pidns(&ctr)?;
         s.add_container(ctr);
+        scopeguard::ScopeGuard::into_inner(netns_guard);
         info!(sl!(), "created container!");
 
         Ok(())
@@ -241,11 +293,14 @@ impl AgentService {
 
         let mut remove_container_resources = |sandbox: &mut Sandbox| -> Result<()> {
             // Find the sandbox storage used by this container
-            let mounts = sandbox.container_mounts.get(&cid);
+            let mounts = sandbox.container_mounts.get(&cid).cloned();
             if let Some(mounts) = mounts {
                 remove_mounts(&mounts)?;
 
                 for m in mounts.iter() {
+                    sandbox.close_luks_mapping(m)?;
+                    sandbox.remove_overlay_rw_dir(m)?;
+
                     if sandbox.storages.get(m).is_some() {
                         cmounts.push(m.to_string());
                     }
@@ -256,8 +311,11 @@ impl AgentService {
                 sandbox.unset_and_remove_sandbox_storage(m)?;
             }
 
+            remove_container_network(&cid)?;
+
             sandbox.container_mounts.remove(cid.as_str());
             sandbox.containers.remove(cid.as_str());
+            sandbox.memcg_oom_events.remove(cid.as_str());
             Ok(())
         };
 
@@ -531,6 +589,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::CreateContainerRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "create_container", req);
+        is_allowed!(req);
         match self.do_create_container(req).await {
             Err(e) => Err(ttrpc_error(ttrpc::Code::INTERNAL, e.to_string())),
             Ok(_) => Ok(Empty::new()),
@@ -543,6 +602,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::StartContainerRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "start_container", req);
+        is_allowed!(req);
         match self.do_start_container(req).await {
             Err(e) => Err(ttrpc_error(ttrpc::Code::INTERNAL, e.to_string())),
             Ok(_) => Ok(Empty::new()),
@@ -555,6 +615,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::RemoveContainerRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "remove_container", req);
+        is_allowed!(req);
         match self.do_remove_container(req).await {
             Err(e) => Err(ttrpc_error(ttrpc::Code::INTERNAL, e.to_string())),
             Ok(_) => Ok(Empty::new()),
@@ -567,6 +628,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::ExecProcessRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "exec_process", req);
+        is_allowed!(req);
         match self.do_exec_process(req).await {
             Err(e) => Err(ttrpc_error(ttrpc::Code::INTERNAL, e.to_string())),
             Ok(_) => Ok(Empty::new()),
@@ -579,6 +641,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::SignalProcessRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "signal_process", req);
+        is_allowed!(req);
         match self.do_signal_process(req).await {
             Err(e) => Err(ttrpc_error(ttrpc::Code::INTERNAL, e.to_string())),
             Ok(_) => Ok(Empty::new()),
@@ -591,6 +654,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::WaitProcessRequest,
     ) -> ttrpc::Result<WaitProcessResponse> {
         trace_rpc_call!(ctx, "wait_process", req);
+        is_allowed!(req);
         self.do_wait_process(req)
             .await
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))
@@ -602,6 +666,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::UpdateContainerRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "update_container", req);
+        is_allowed!(req);
         let cid = req.container_id.clone();
         let res = req.resources;
 
@@ -637,6 +702,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::StatsContainerRequest,
     ) -> ttrpc::Result<StatsContainerResponse> {
         trace_rpc_call!(ctx, "stats_container", req);
+        is_allowed!(req);
         let cid = req.container_id;
         let s = Arc::clone(&self.sandbox);
         let mut sandbox = s.lock().await;
@@ -648,8 +714,16 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
             )
         })?;
 
-        ctr.stats()
-            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))
+        let mut resp = ctr
+            .stats()
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
+
+        match get_network_stats() {
+            Ok(stats) => resp.set_network_stats(RepeatedField::from_vec(stats)),
+            Err(e) => warn!(sl!(), "failed to get network stats: {:?}", e),
+        }
+
+        Ok(resp)
     }
 
     async fn pause_container(
@@ -658,6 +732,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::PauseContainerRequest,
     ) -> ttrpc::Result<protocols::empty::Empty> {
         trace_rpc_call!(ctx, "pause_container", req);
+        is_allowed!(req);
         let cid = req.get_container_id();
         let s = Arc::clone(&self.sandbox);
         let mut sandbox = s.lock().await;
@@ -681,6 +756,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::ResumeContainerRequest,
     ) -> ttrpc::Result<protocols::empty::Empty> {
         trace_rpc_call!(ctx, "resume_container", req);
+        is_allowed!(req);
         let cid = req.get_container_id();
         let s = Arc::clone(&self.sandbox);
         let mut sandbox = s.lock().await;
@@ -703,6 +779,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         _ctx: &TtrpcContext,
         req: protocols::agent::WriteStreamRequest,
     ) -> ttrpc::Result<WriteStreamResponse> {
+        is_allowed!(req);
         self.do_write_stream(req)
             .await
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))
@@ -713,6 +790,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         _ctx: &TtrpcContext,
         req: protocols::agent::ReadStreamRequest,
     ) -> ttrpc::Result<ReadStreamResponse> {
+        is_allowed!(req);
         self.do_read_stream(req, true)
             .await
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))
@@ -723,6 +801,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         _ctx: &TtrpcContext,
         req: protocols::agent::ReadStreamRequest,
     ) -> ttrpc::Result<ReadStreamResponse> {
+        is_allowed!(req);
         self.do_read_stream(req, false)
             .await
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))
@@ -734,6 +813,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::CloseStdinRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "close_stdin", req);
+        is_allowed!(req);
 
         let cid = req.container_id.clone();
         let eid = req.exec_id;
@@ -770,6 +850,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::TtyWinResizeRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "tty_win_resize", req);
+        is_allowed!(req);
 
         let cid = req.container_id.clone();
         let eid = req.exec_id.clone();
@@ -810,6 +891,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::UpdateInterfaceRequest,
     ) -> ttrpc::Result<Interface> {
         trace_rpc_call!(ctx, "update_interface", req);
+        is_allowed!(req);
 
         let interface = req.interface.into_option().ok_or_else(|| {
             ttrpc_error(
@@ -837,6 +919,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::UpdateRoutesRequest,
     ) -> ttrpc::Result<Routes> {
         trace_rpc_call!(ctx, "update_routes", req);
+        is_allowed!(req);
 
         let new_routes = req
             .routes
@@ -877,6 +960,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::ListInterfacesRequest,
     ) -> ttrpc::Result<Interfaces> {
         trace_rpc_call!(ctx, "list_interfaces", req);
+        is_allowed!(req);
 
         let list = self
             .sandbox
@@ -904,6 +988,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::ListRoutesRequest,
     ) -> ttrpc::Result<Routes> {
         trace_rpc_call!(ctx, "list_routes", req);
+        is_allowed!(req);
 
         let list = self
             .sandbox
@@ -926,14 +1011,16 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::StartTracingRequest,
     ) -> ttrpc::Result<Empty> {
         info!(sl!(), "start_tracing {:?}", req);
+        is_allowed!(req);
         Ok(Empty::new())
     }
 
     async fn stop_tracing(
         &self,
         _ctx: &TtrpcContext,
-        _req: protocols::agent::StopTracingRequest,
+        req: protocols::agent::StopTracingRequest,
     ) -> ttrpc::Result<Empty> {
+        is_allowed!(req);
         Ok(Empty::new())
     }
 
@@ -943,6 +1030,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::CreateSandboxRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "create_sandbox", req);
+        is_allowed!(req);
 
         {
             let sandbox = self.sandbox.clone();
@@ -972,9 +1060,17 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
                     .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
             }
 
+            set_guest_sysctls(&sl!(), &req.sysctls)
+                .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
+
             s.setup_shared_namespaces()
                 .await
                 .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
+
+            if req.sandbox_pidns {
+                s.setup_sandbox_pidns()
+                    .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
+            }
         }
 
         match add_storages(sl!(), req.storages.to_vec(), self.sandbox.clone()).await {
@@ -1008,6 +1104,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::DestroySandboxRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "destroy_sandbox", req);
+        is_allowed!(req);
 
         let s = Arc::clone(&self.sandbox);
         let mut sandbox = s.lock().await;
@@ -1029,6 +1126,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::AddARPNeighborsRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "add_arp_neighbors", req);
+        is_allowed!(req);
 
         let neighs = req
             .neighbors
@@ -1065,6 +1163,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         let s = Arc::clone(&self.sandbox);
         let sandbox = s.lock().await;
         trace_rpc_call!(ctx, "online_cpu_mem", req);
+        is_allowed!(req);
 
         sandbox
             .online_cpu_memory(&req)
@@ -1079,10 +1178,14 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::ReseedRandomDevRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "reseed_random_dev", req);
+        is_allowed!(req);
 
         random::reseed_rng(req.data.as_slice())
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
 
+        info!(sl!(), "reseeded random number generator";
+            "crng-ready" => random::crng_ready());
+
         Ok(Empty::new())
     }
 
@@ -1092,6 +1195,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::GuestDetailsRequest,
     ) -> ttrpc::Result<GuestDetailsResponse> {
         trace_rpc_call!(ctx, "get_guest_details", req);
+        is_allowed!(req);
 
         info!(sl!(), "get guest details!");
         let mut resp = GuestDetailsResponse::new();
@@ -1120,6 +1224,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::MemHotplugByProbeRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "mem_hotplug_by_probe", req);
+        is_allowed!(req);
 
         do_mem_hotplug_by_probe(&req.memHotplugProbeAddr)
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
@@ -1133,6 +1238,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::SetGuestDateTimeRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "set_guest_date_time", req);
+        is_allowed!(req);
 
         do_set_guest_date_time(req.Sec, req.Usec)
             .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
@@ -1146,6 +1252,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::CopyFileRequest,
     ) -> ttrpc::Result<Empty> {
         trace_rpc_call!(ctx, "copy_file", req);
+        is_allowed!(req);
 
         do_copy_file(&req).map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
 
@@ -1158,6 +1265,7 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
         req: protocols::agent::GetMetricsRequest,
     ) -> ttrpc::Result<Metrics> {
         trace_rpc_call!(ctx, "get_metrics", req);
+        is_allowed!(req);
 
         match get_metrics(&req) {
             Err(e) => Err(ttrpc_error(ttrpc::Code::INTERNAL, e.to_string())),
@@ -1172,8 +1280,10 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
     async fn get_oom_event(
         &self,
         _ctx: &TtrpcContext,
-        _req: protocols::agent::GetOOMEventRequest,
+        req: protocols::agent::GetOOMEventRequest,
     ) -> ttrpc::Result<OOMEvent> {
+        is_allowed!(req);
+
         let sandbox = self.sandbox.clone();
         let s = sandbox.lock().await;
         let event_rx = &s.event_rx.clone();
@@ -1192,6 +1302,163 @@ impl protocols::agent_ttrpc::AgentService for AgentService {
 
         Err(ttrpc_error(ttrpc::Code::INTERNAL, ""))
     }
+
+    async fn resize_volume(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::ResizeVolumeRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "resize_volume", req);
+        is_allowed!(req);
+
+        let mount_point = req.volume_guest_path.clone();
+        let size = req.size;
+
+        // Growing a filesystem may take a while
+        tokio::task::spawn_blocking(move || resize::resize_volume(&mount_point, size))
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, format!("{:?}", e)))?;
+
+        info!(sl!(), "resized volume";
+            "guest-path" => &req.volume_guest_path,
+            "size" => req.size);
+
+        Ok(Empty::new())
+    }
+
+    async fn get_guest_diagnostics(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::GuestDiagnosticsRequest,
+    ) -> ttrpc::Result<GuestDiagnostics> {
+        trace_rpc_call!(ctx, "get_guest_diagnostics", req);
+        is_allowed!(req);
+
+        // statvfs(2) may block on an unresponsive filesystem
+        tokio::task::spawn_blocking(move || diagnostics::get_guest_diagnostics(&req))
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))
+    }
+
+    async fn remount_shared_fs(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::RemountSharedFSRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "remount_shared_fs", req);
+        is_allowed!(req);
+
+        let mount_point = req.mount_point.clone();
+        let options = req.options.to_vec();
+
+        // The mount waits for the restarted virtiofsd
+        tokio::task::spawn_blocking(move || {
+            virtiofs::remount_shared_fs(&sl!(), &mount_point, &options)
+        })
+        .await
+        .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?
+        .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, format!("{:?}", e)))?;
+
+        Ok(Empty::new())
+    }
+
+    async fn sync_filesystems(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::SyncFilesystemsRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "sync_filesystems", req);
+        is_allowed!(req);
+
+        info!(sl!(), "syncing the guest file systems");
+
+        // sync(2) blocks until the data is written
+        tokio::task::spawn_blocking(unistd::sync)
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, e.to_string()))?;
+
+        Ok(Empty::new())
+    }
+
+    async fn start_network_capture(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::StartNetworkCaptureRequest,
+    ) -> ttrpc::Result<StartNetworkCaptureResponse> {
+        trace_rpc_call!(ctx, "start_network_capture", req);
+        is_allowed!(req);
+
+        let id = capture::start_capture(&req.device, req.seconds as u64, req.snaplen)
+            .map_err(|e| ttrpc_error(ttrpc::Code::INVALID_ARGUMENT, format!("{:?}", e)))?;
+
+        info!(sl!(), "network capture started";
+            "device" => &req.device,
+            "capture-id" => id);
+
+        let mut resp = StartNetworkCaptureResponse::new();
+        resp.set_capture_id(id);
+
+        Ok(resp)
+    }
+
+    async fn stop_network_capture(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::StopNetworkCaptureRequest,
+    ) -> ttrpc::Result<Empty> {
+        trace_rpc_call!(ctx, "stop_network_capture", req);
+        is_allowed!(req);
+
+        capture::stop_capture(req.capture_id);
+
+        Ok(Empty::new())
+    }
+
+    async fn checkpoint_container(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::CheckpointContainerRequest,
+    ) -> ttrpc::Result<CheckpointContainerResponse> {
+        trace_rpc_call!(ctx, "checkpoint_container", req);
+        is_allowed!(req);
+
+        let id = checkpoint::checkpoint_container(
+            &sl!(),
+            &self.sandbox,
+            &req.container_id,
+            req.leave_running,
+        )
+        .await
+        .map_err(|e| ttrpc_error(ttrpc::Code::INTERNAL, format!("{:?}", e)))?;
+
+        let mut resp = CheckpointContainerResponse::new();
+        resp.set_checkpoint_id(id);
+
+        Ok(resp)
+    }
+
+    async fn restore_container(
+        &self,
+        ctx: &TtrpcContext,
+        req: protocols::agent::RestoreContainerRequest,
+    ) -> ttrpc::Result<RestoreContainerResponse> {
+        trace_rpc_call!(ctx, "restore_container", req);
+        is_allowed!(req);
+
+        let id = checkpoint::restore_container(&self.sandbox, &req.container_id, req.size)
+            .await
+            .map_err(|e| ttrpc_error(ttrpc::Code::INVALID_ARGUMENT, format!("{:?}", e)))?;
+
+        info!(sl!(), "container restore started";
+            "container-id" => &req.container_id,
+            "checkpoint-id" => id);
+
+        let mut resp = RestoreContainerResponse::new();
+        resp.set_checkpoint_id(id);
+
+        Ok(resp)
+    }
 }
 
 #[derive(Clone)]
@@ -1365,6 +1632,7 @@ fn update_container_namespaces(
     sandbox: &Sandbox,
     spec: &mut Spec,
     sandbox_pidns: bool,
+    netns: Option<String>,
 ) -> Result<()> {
     let linux = spec
         .linux
@@ -1397,6 +1665,16 @@ fn update_container_namespaces(
     }
 
     linux.namespaces.push(pid_ns);
+
+    // The network namespace of the container, when it is not the one of
+    // the sandbox, shared by the agent.
+    if let Some(path) = netns {
+        linux.namespaces.push(LinuxNamespace {
+            r#type: NSTYPENETWORK.to_string(),
+            path,
+        });
+    }
+
     Ok(())
 }
 
@@ -1660,7 +1938,7 @@ fn load_kernel_module(module: &protocols::agent::KernelModule) -> Result<()> {
 mod tests {
     use super::*;
     use crate::protocols::agent_ttrpc::AgentService as _;
-    use oci::{Hook, Hooks};
+    use oci::{Hook, Hooks, Linux};
     use ttrpc::{r#async::TtrpcContext, MessageHeader};
 
     fn mk_ttrpc_context() -> TtrpcContext {
@@ -1712,6 +1990,32 @@ mod tests {
         assert_eq!(s.hooks, oci.hooks);
     }
 
+    #[tokio::test]
+    async fn test_update_container_namespaces() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let sandbox = Sandbox::new(&logger).unwrap();
+        let mut oci = Spec {
+            linux: Some(Linux::default()),
+            ..Default::default()
+        };
+
+        update_container_namespaces(&sandbox, &mut oci, false, None).unwrap();
+        let namespaces = &oci.linux.as_ref().unwrap().namespaces;
+        assert!(namespaces.iter().all(|ns| ns.r#type != NSTYPENETWORK));
+
+        let netns = "/var/run/sandbox-ns/containers/abc/net";
+        let mut oci = Spec {
+            linux: Some(Linux::default()),
+            ..Default::default()
+        };
+
+        update_container_namespaces(&sandbox, &mut oci, false, Some(netns.to_string())).unwrap();
+        let namespaces = &oci.linux.as_ref().unwrap().namespaces;
+        assert!(namespaces
+            .iter()
+            .any(|ns| ns.r#type == NSTYPENETWORK && ns.path == netns));
+    }
+
     #[tokio::test]
     async fn test_update_interface() {
         let logger = slog::Logger::root(slog::Discard, o!());
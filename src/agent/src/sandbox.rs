@@ -4,14 +4,17 @@
 //
 
 use crate::linux_abi::*;
+use crate::luks;
 use crate::mount::{get_mount_fs_type, remove_mounts, TYPE_ROOTFS};
-use crate::namespace::Namespace;
+use crate::namespace::{spawn_pid_ns_init, Namespace};
 use crate::netlink::Handle;
 use crate::network::Network;
 use crate::uevent::{Uevent, UeventMatcher};
 use crate::watcher::BindWatcher;
 use anyhow::{anyhow, Context, Result};
 use libc::pid_t;
+use nix::sys::signal::{self, Signal};
+use nix::unistd::Pid;
 use oci::{Hook, Hooks};
 use protocols::agent::OnlineCPUMemRequest;
 use regex::Regex;
@@ -24,6 +27,7 @@ use std::collections::HashMap;
 use std::fs;
 use std::os::unix::fs::PermissionsExt;
 use std::path::Path;
+use std::sync::atomic::{AtomicU64, Ordering};
 use std::sync::Arc;
 use std::{thread, time};
 use tokio::sync::mpsc::{channel, Receiver, Sender};
@@ -47,6 +51,9 @@ pub struct Sandbox {
     pub shared_utsns: Namespace,
     pub shared_ipcns: Namespace,
     pub sandbox_pidns: Option<Namespace>,
+    // pidns_init is the init process of the sandbox pid namespace, when
+    // the sandbox has its own rather than the one of its first container.
+    pub pidns_init: Option<Pid>,
     pub storages: HashMap<String, u32>,
     pub running: bool,
     pub no_pivot_root: bool,
@@ -56,6 +63,15 @@ pub struct Sandbox {
     pub event_rx: Arc<Mutex<Receiver<String>>>,
     pub event_tx: Option<Sender<String>>,
     pub bind_watcher: BindWatcher,
+    // memcg_oom_events counts the OOM events of the container memory
+    // cgroups, i.e. the OOMs caused by the container memory limit.
+    pub memcg_oom_events: HashMap<String, Arc<AtomicU64>>,
+    // luks_mappings are the device mappings of the unlocked LUKS volumes,
+    // indexed by mount point.
+    pub luks_mappings: HashMap<String, String>,
+    // overlay_rw_dirs are the writable layers of the overlay rootfs,
+    // indexed by mount point.
+    pub overlay_rw_dirs: HashMap<String, String>,
 }
 
 impl Sandbox {
@@ -79,6 +95,7 @@ impl Sandbox {
             shared_utsns: Namespace::new(&logger),
             shared_ipcns: Namespace::new(&logger),
             sandbox_pidns: None,
+            pidns_init: None,
             storages: HashMap::new(),
             running: false,
             no_pivot_root: fs_type.eq(TYPE_ROOTFS),
@@ -88,6 +105,9 @@ impl Sandbox {
             event_rx,
             event_tx: Some(tx),
             bind_watcher: BindWatcher::new(),
+            memcg_oom_events: HashMap::new(),
+            luks_mappings: HashMap::new(),
+            overlay_rw_dirs: HashMap::new(),
         })
     }
 
@@ -143,13 +163,48 @@ impl Sandbox {
     // It's assumed that caller is calling this method after
     // acquiring a lock on sandbox.
     #[instrument]
-    pub fn remove_sandbox_storage(&self, path: &str) -> Result<()> {
+    pub fn remove_sandbox_storage(&mut self, path: &str) -> Result<()> {
         let mounts = vec![path.to_string()];
         remove_mounts(&mounts)?;
+        self.close_luks_mapping(path)?;
         fs::remove_dir_all(path).context(format!("failed to remove dir {:?}", path))?;
         Ok(())
     }
 
+    // close_luks_mapping locks the LUKS volume which was mounted on
+    // mount_point back, unless it is still mounted elsewhere.
+    //
+    // It's assumed that caller is calling this method after
+    // acquiring a lock on sandbox.
+    #[instrument]
+    pub fn close_luks_mapping(&mut self, mount_point: &str) -> Result<()> {
+        let name = match self.luks_mappings.remove(mount_point) {
+            Some(name) => name,
+            None => return Ok(()),
+        };
+
+        if self.luks_mappings.values().any(|n| *n == name) {
+            return Ok(());
+        }
+
+        luks::close(&name)
+    }
+
+    // remove_overlay_rw_dir removes the writable layer of the overlay rootfs
+    // which was mounted on mount_point.
+    //
+    // It's assumed that caller is calling this method after
+    // acquiring a lock on sandbox.
+    #[instrument]
+    pub fn remove_overlay_rw_dir(&mut self, mount_point: &str) -> Result<()> {
+        match self.overlay_rw_dirs.remove(mount_point) {
+            Some(dir) => {
+                fs::remove_dir_all(&dir).context(format!("failed to remove dir {:?}", dir))
+            }
+            None => Ok(()),
+        }
+    }
+
     // unset_and_remove_sandbox_storage unsets the storage from sandbox
     // and if there are no containers using this storage it will
     // remove it from the sandbox.
@@ -188,6 +243,24 @@ impl Sandbox {
         self.containers.insert(c.id.clone(), c);
     }
 
+    // setup_sandbox_pidns creates the pid namespace shared by all the
+    // containers of the sandbox. Its init process reaps the orphaned
+    // processes, and the namespace outlives any of the containers.
+    #[instrument]
+    pub fn setup_sandbox_pidns(&mut self) -> Result<()> {
+        let pid = spawn_pid_ns_init().context("Failed to setup sandbox pid namespace")?;
+
+        let mut pid_ns = Namespace::new(&self.logger).get_pid();
+        pid_ns.path = format!("/proc/{}/ns/pid", pid);
+
+        info!(self.logger, "sandbox pid namespace created"; "init-pid" => pid.as_raw());
+
+        self.sandbox_pidns = Some(pid_ns);
+        self.pidns_init = Some(pid);
+
+        Ok(())
+    }
+
     #[instrument]
     pub fn update_shared_pidns(&mut self, c: &LinuxContainer) -> Result<()> {
         // Populate the shared pid path only if this is an infra container and
@@ -231,6 +304,13 @@ impl Sandbox {
         for ctr in self.containers.values_mut() {
             ctr.destroy().await?;
         }
+
+        // Killing the init process kills the processes left in the sandbox
+        // pid namespace, it is reaped by the agent.
+        if let Some(pid) = self.pidns_init.take() {
+            let _ = signal::kill(pid, Signal::SIGKILL);
+        }
+
         Ok(())
     }
 
@@ -332,7 +412,7 @@ impl Sandbox {
     }
 
     #[instrument]
-    pub async fn run_oom_event_monitor(&self, mut rx: Receiver<String>, container_id: String) {
+    pub async fn run_oom_event_monitor(&mut self, mut rx: Receiver<String>, container_id: String) {
         let logger = self.logger.clone();
 
         if self.event_tx.is_none() {
@@ -345,6 +425,10 @@ impl Sandbox {
 
         let tx = self.event_tx.as_ref().unwrap().clone();
 
+        let events = Arc::new(AtomicU64::new(0));
+        self.memcg_oom_events
+            .insert(container_id.clone(), events.clone());
+
         tokio::spawn(async move {
             loop {
                 let event = rx.recv().await;
@@ -354,6 +438,7 @@ impl Sandbox {
                     return;
                 }
                 info!(logger, "got an OOM event {:?}", event);
+                events.fetch_add(1, Ordering::SeqCst);
 
                 let _ = tx
                     .send(container_id.clone())
@@ -504,7 +589,7 @@ mod tests {
         skip_if_not_root!();
 
         let logger = slog::Logger::root(slog::Discard, o!());
-        let s = Sandbox::new(&logger).unwrap();
+        let mut s = Sandbox::new(&logger).unwrap();
 
         let tmpdir = Builder::new().tempdir().unwrap();
         let tmpdir_path = tmpdir.path().to_str().unwrap();
@@ -555,6 +640,25 @@ mod tests {
         assert!(s.remove_sandbox_storage(destdir_path).is_ok());
     }
 
+    #[tokio::test]
+    async fn remove_overlay_rw_dir() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let mut s = Sandbox::new(&logger).unwrap();
+
+        let tmpdir = Builder::new().tempdir().unwrap();
+        let rw_dir = tmpdir.path().join("rw");
+        fs::create_dir_all(rw_dir.join("upper")).unwrap();
+
+        // Not an overlay rootfs
+        assert!(s.remove_overlay_rw_dir("/rootfs").is_ok());
+
+        s.overlay_rw_dirs
+            .insert("/rootfs".to_string(), rw_dir.to_str().unwrap().to_string());
+        assert!(s.remove_overlay_rw_dir("/rootfs").is_ok());
+        assert!(!rw_dir.exists());
+        assert!(s.overlay_rw_dirs.is_empty());
+    }
+
     #[tokio::test]
     async fn unset_and_remove_sandbox_storage() {
         skip_if_not_root!();
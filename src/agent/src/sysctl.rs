@@ -0,0 +1,134 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use anyhow::{anyhow, Context, Result};
+use slog::Logger;
+use std::collections::HashMap;
+use std::path::Path;
+
+const PROC_SYS: &str = "/proc/sys";
+
+// set_guest_sysctls sets the sysctls of the sandbox in the namespaces of the
+// agent, i.e. for the whole VM, before any container is created.
+pub fn set_guest_sysctls(logger: &Logger, sysctls: &HashMap<String, String>) -> Result<()> {
+    let mut sysctls: Vec<(String, String)> = sysctls
+        .iter()
+        .map(|(key, value)| validate_sysctl(key, value))
+        .collect::<Result<_>>()?;
+    sysctls.sort();
+
+    set_sysctls(logger, Path::new(PROC_SYS), &sysctls)
+}
+
+// validate_sysctl checks a sysctl, the key being the dot separated names of
+// its /proc/sys entry, which cannot escape /proc/sys.
+fn validate_sysctl(key: &str, value: &str) -> Result<(String, String)> {
+    let (key, value) = (key.trim(), value.trim());
+
+    let valid_name = |name: &str| {
+        !name.is_empty()
+            && name
+                .chars()
+                .all(|c| c.is_ascii_alphanumeric() || c == '_' || c == '-')
+    };
+    if !key.split('.').all(valid_name) {
+        return Err(anyhow!("invalid sysctl name {:?}", key));
+    }
+
+    if value.is_empty() {
+        return Err(anyhow!("no value for sysctl {}", key));
+    }
+
+    Ok((key.to_string(), value.to_string()))
+}
+
+fn set_sysctls(logger: &Logger, root: &Path, sysctls: &[(String, String)]) -> Result<()> {
+    for (key, value) in sysctls {
+        let path = root.join(key.replace('.', "/"));
+        std::fs::write(&path, value).with_context(|| format!("failed to set sysctl {}", key))?;
+
+        info!(logger, "sysctl set"; "key" => key.as_str(), "value" => value.as_str());
+    }
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use std::fs;
+    use tempfile::tempdir;
+
+    #[test]
+    fn test_validate_sysctl() {
+        assert_eq!(
+            validate_sysctl("vm.max_map_count", "262144").unwrap(),
+            ("vm.max_map_count".to_string(), "262144".to_string())
+        );
+        assert_eq!(
+            validate_sysctl(" net.ipv4.ip_local_port_range ", " 1024 65000").unwrap(),
+            (
+                "net.ipv4.ip_local_port_range".to_string(),
+                "1024 65000".to_string()
+            )
+        );
+        assert_eq!(
+            validate_sysctl("net.ipv4.conf.eth-0.forwarding", "1")
+                .unwrap()
+                .0,
+            "net.ipv4.conf.eth-0.forwarding"
+        );
+
+        let tests = &[
+            ("", "1"),
+            ("vm.swappiness", ""),
+            ("vm..swappiness", "1"),
+            (".vm.swappiness", "1"),
+            ("vm/swappiness", "1"),
+            ("../../etc/passwd", "1"),
+            ("vm.*", "1"),
+        ];
+
+        for (i, (key, value)) in tests.iter().enumerate() {
+            assert!(validate_sysctl(key, value).is_err(), "test[{}]: {}", i, key);
+        }
+    }
+
+    #[test]
+    fn test_set_guest_sysctls_invalid() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let mut sysctls = HashMap::new();
+        sysctls.insert("../../etc/passwd".to_string(), "1".to_string());
+
+        assert!(set_guest_sysctls(&logger, &sysctls).is_err());
+    }
+
+    #[test]
+    fn test_set_sysctls() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+        let dir = tempdir().unwrap();
+        fs::create_dir_all(dir.path().join("vm")).unwrap();
+        fs::write(dir.path().join("vm/swappiness"), "60").unwrap();
+
+        set_sysctls(
+            &logger,
+            dir.path(),
+            &[("vm.swappiness".to_string(), "10".to_string())],
+        )
+        .unwrap();
+        assert_eq!(
+            fs::read_to_string(dir.path().join("vm/swappiness")).unwrap(),
+            "10"
+        );
+
+        let err = set_sysctls(
+            &logger,
+            dir.path(),
+            &[("kernel.foo.bar".to_string(), "1".to_string())],
+        )
+        .unwrap_err();
+        assert!(format!("{:#}", err).contains("failed to set sysctl kernel.foo.bar"));
+    }
+}
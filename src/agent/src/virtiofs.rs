@@ -0,0 +1,62 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use crate::mount::{parse_mount_flags_and_options, BareMount, MOUNT_GUEST_TAG};
+use anyhow::{anyhow, Result};
+use nix::errno::Errno;
+use nix::mount::{umount2, MntFlags};
+use slog::Logger;
+use std::path::Path;
+
+const VIRTIOFS_FSTYPE: &str = "virtiofs";
+
+// remount_shared_fs detaches the shared file system served by the previous
+// virtiofsd and mounts it again at mount_point, once the runtime restarted
+// virtiofsd. The mount waits for the new virtiofsd to serve the file system.
+pub fn remount_shared_fs(logger: &Logger, mount_point: &str, options: &[String]) -> Result<()> {
+    if !Path::new(mount_point).is_absolute() {
+        return Err(anyhow!("invalid remount mount point {:?}", mount_point));
+    }
+
+    let logger = logger.new(o!("subsystem" => "virtiofs"));
+    info!(logger, "remounting the shared file system";
+        "tag" => MOUNT_GUEST_TAG,
+        "mount-point" => mount_point,
+    );
+
+    match umount2(mount_point, MntFlags::MNT_DETACH) {
+        // Nothing is mounted anymore
+        Err(nix::Error::Sys(Errno::EINVAL)) => {}
+        Err(e) => return Err(anyhow!("failed to unmount {}: {}", mount_point, e)),
+        Ok(()) => {}
+    }
+
+    let (flags, options) =
+        parse_mount_flags_and_options(options.iter().map(|o| o.as_str()).collect());
+
+    BareMount::new(
+        MOUNT_GUEST_TAG,
+        mount_point,
+        VIRTIOFS_FSTYPE,
+        flags,
+        &options,
+        &logger,
+    )
+    .mount()
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_remount_shared_fs_relative_mount_point() {
+        let logger = slog::Logger::root(slog::Discard, o!());
+
+        for mount_point in &["", "relative/path"] {
+            assert!(remount_shared_fs(&logger, mount_point, &[]).is_err());
+        }
+    }
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+use anyhow::{anyhow, Result};
+use nix::errno::Errno;
+use slog::{info, o, warn, Logger};
+use std::fs::{File, OpenOptions};
+use std::io::Write;
+use std::os::unix::io::AsRawFd;
+use std::time::Duration;
+use tokio::select;
+use tokio::sync::watch::Receiver;
+
+pub const WATCHDOG_DEV: &str = "/dev/watchdog";
+
+// WDIOC_SETTIMEOUT is _IOWR('W', 6, int)
+const WDIOC_SETTIMEOUT: libc::c_ulong = 0xc004_5706;
+
+// Writing the magic character before closing the device disarms the
+// watchdog.
+const WATCHDOG_MAGIC_CLOSE: &[u8] = b"V";
+
+// Handle the differing ioctl(2) request types for different targets
+#[cfg(target_env = "musl")]
+type IoctlRequestType = libc::c_int;
+#[cfg(target_env = "gnu")]
+type IoctlRequestType = libc::c_ulong;
+
+fn set_timeout(dev: &File, timeout: Duration) -> Result<()> {
+    let mut secs = timeout.as_secs() as libc::c_int;
+
+    let ret = unsafe {
+        libc::ioctl(
+            dev.as_raw_fd(),
+            WDIOC_SETTIMEOUT as IoctlRequestType,
+            &mut secs as *mut libc::c_int,
+        )
+    };
+    Errno::result(ret).map(drop)?;
+
+    Ok(())
+}
+
+// petting_interval returns how often the watchdog is petted, so that a
+// couple of missed ticks don't make it expire.
+fn petting_interval(timeout: Duration) -> Duration {
+    std::cmp::max(timeout / 3, Duration::from_secs(1))
+}
+
+// pet_watchdog arms the guest watchdog with the given timeout and pets it
+// until shutdown. The hypervisor takes the configured recovery action if
+// the agent stops petting it, i.e. if the guest is hung.
+pub async fn pet_watchdog(
+    logger: Logger,
+    timeout: Duration,
+    mut shutdown: Receiver<bool>,
+) -> Result<()> {
+    let logger = logger.new(o!("subsystem" => "watchdog"));
+
+    let mut dev = OpenOptions::new()
+        .write(true)
+        .open(WATCHDOG_DEV)
+        .map_err(|e| anyhow!(e).context("failed to open watchdog device"))?;
+
+    if let Err(e) = set_timeout(&dev, timeout) {
+        // Keep going with the default timeout of the device.
+        warn!(logger, "failed to set watchdog timeout"; "error" => format!("{:?}", e));
+    }
+
+    let interval = petting_interval(timeout);
+    info!(logger, "watchdog armed";
+        "timeout" => timeout.as_secs(),
+        "interval" => interval.as_secs());
+
+    let mut ticker = tokio::time::interval(interval);
+
+    loop {
+        select! {
+            _ = shutdown.changed() => {
+                info!(logger, "got shutdown request");
+                break;
+            }
+
+            _ = ticker.tick() => {
+                if let Err(e) = dev.write_all(b"\0") {
+                    warn!(logger, "failed to pet watchdog"; "error" => format!("{:?}", e));
+                }
+            }
+        }
+    }
+
+    dev.write_all(WATCHDOG_MAGIC_CLOSE)
+        .map_err(|e| anyhow!(e).context("failed to disarm watchdog"))?;
+
+    Ok(())
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_petting_interval() {
+        assert_eq!(
+            petting_interval(Duration::from_secs(30)),
+            Duration::from_secs(10)
+        );
+        assert_eq!(
+            petting_interval(Duration::from_secs(2)),
+            Duration::from_secs(1)
+        );
+    }
+}
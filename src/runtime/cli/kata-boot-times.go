@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/urfave/cli"
+)
+
+// BootTimesInfo is the boot time breakdown of a sandbox, in milliseconds.
+type BootTimesInfo struct {
+	VMCreate      float64
+	KernelBoot    float64
+	AgentReady    float64
+	WorkloadStart float64
+	Total         float64
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func getBootTimesInfo(bt vc.BootTimes) BootTimesInfo {
+	return BootTimesInfo{
+		VMCreate:      toMilliseconds(bt.VMCreate),
+		KernelBoot:    toMilliseconds(bt.KernelBoot),
+		AgentReady:    toMilliseconds(bt.AgentReady),
+		WorkloadStart: toMilliseconds(bt.WorkloadStart),
+		Total:         toMilliseconds(bt.Total()),
+	}
+}
+
+var kataBootTimesCLICommand = cli.Command{
+	Name:      "boot-times",
+	Usage:     "display the boot time breakdown of a sandbox, in milliseconds. Default to TOML",
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "Format output as JSON",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		bt, err := vc.ReadSandboxBootTimes(sandboxID)
+		if err != nil {
+			return err
+		}
+
+		info := struct {
+			BootTimes BootTimesInfo
+		}{getBootTimesInfo(bt)}
+
+		if context.Bool("json") {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(info)
+		}
+
+		return toml.NewEncoder(os.Stdout).Encode(info)
+	},
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBootTimesInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	info := getBootTimesInfo(vc.BootTimes{
+		VMCreate:      100 * time.Millisecond,
+		KernelBoot:    time.Second,
+		AgentReady:    1500 * time.Microsecond,
+		WorkloadStart: 0,
+	})
+
+	assert.Equal(BootTimesInfo{
+		VMCreate:      100,
+		KernelBoot:    1000,
+		AgentReady:    1.5,
+		WorkloadStart: 0,
+		Total:         1101.5,
+	}, info)
+}
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/BurntSushi/toml"
 	"github.com/containerd/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
@@ -58,12 +59,14 @@ type vmContainerCapableDetails struct {
 	requiredCPUFlags      map[string]string
 	requiredCPUAttribs    map[string]string
 	requiredKernelModules map[string]kernelModule
+	confidentialGuest     bool
 }
 
 const (
 	moduleParamDir        = "parameters"
 	successMessageCapable = "System is capable of running " + project
 	successMessageCreate  = "System can currently create " + project
+	successMessageProbe   = "System can currently boot a " + project + " sandbox"
 	failMessage           = "System is not capable of running " + project
 	kernelPropertyCorrect = "Kernel property value correct"
 
@@ -322,6 +325,10 @@ var kataCheckCLICommand = cli.Command{
 			Name:  "check-version-only",
 			Usage: "Only compare the current and latest available versions (requires network, non-root only)",
 		},
+		cli.BoolFlag{
+			Name:  "full",
+			Usage: "also boot a throwaway sandbox with the current configuration (root only)",
+		},
 		cli.BoolFlag{
 			Name:  "include-all-releases",
 			Usage: "Don't filter out pre-release release versions",
@@ -363,6 +370,10 @@ EXAMPLES:
 
   $ sudo %s check
 
+- Also boot a throwaway sandbox, and display its boot times:
+
+  $ sudo %s check --full
+
 - Just check if a newer version is available:
 
   $ %s check --check-version-only
@@ -383,6 +394,7 @@ EXAMPLES:
 		name,
 		name,
 		name,
+		name,
 	),
 
 	Action: func(context *cli.Context) error {
@@ -432,6 +444,7 @@ EXAMPLES:
 			requiredCPUFlags:      archRequiredCPUFlags,
 			requiredCPUAttribs:    archRequiredCPUAttribs,
 			requiredKernelModules: archRequiredKernelModules,
+			confidentialGuest:     runtimeConfig.HypervisorConfig.ConfidentialGuest,
 		}
 
 		err = hostIsVMContainerCapable(details)
@@ -449,10 +462,45 @@ EXAMPLES:
 			fmt.Println(successMessageCreate)
 		}
 
+		if context.Bool("full") {
+			if os.Geteuid() != 0 {
+				return errors.New("check --full must be run as root")
+			}
+
+			return probeSandbox(context, runtimeConfig)
+		}
+
 		return nil
 	},
 }
 
+// probeSandbox boots a throwaway sandbox with the current configuration, as
+// a container would be, and displays its boot times.
+func probeSandbox(context *cli.Context, runtimeConfig oci.RuntimeConfig) error {
+	ctx, err := cliContextToContext(context)
+	if err != nil {
+		return err
+	}
+
+	bootTimes, err := vc.ProbeSandbox(ctx, vc.SandboxConfig{
+		HypervisorType:      runtimeConfig.HypervisorType,
+		HypervisorConfig:    runtimeConfig.HypervisorConfig,
+		AgentConfig:         runtimeConfig.AgentConfig,
+		DisableGuestSeccomp: runtimeConfig.DisableGuestSeccomp,
+		Experimental:        runtimeConfig.Experimental,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to boot a sandbox: %v", err)
+	}
+	fmt.Println(successMessageProbe)
+
+	info := struct {
+		BootTimes BootTimesInfo
+	}{getBootTimesInfo(bootTimes)}
+
+	return toml.NewEncoder(os.Stdout).Encode(info)
+}
+
 func genericArchKernelParamHandler(onVMM bool, fields logrus.Fields, msg string) bool {
 	param, ok := fields["parameter"].(string)
 	if !ok {
@@ -7,6 +7,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -30,6 +31,10 @@ var (
 	_               = genericCPUModelField
 )
 
+// ultravisorFirmwareDir exists when the ultravisor of the Protected Execution
+// Facility is running.
+var ultravisorFirmwareDir = "/sys/firmware/ultravisor"
+
 // archRequiredCPUFlags maps a CPU flag value to search for and a
 // human-readable description of that value.
 var archRequiredCPUFlags = map[string]string{}
@@ -96,6 +101,13 @@ func hostIsVMContainerCapable(details vmContainerCapableDetails) error {
 		return err
 	}
 
+	if details.confidentialGuest {
+		if err := checkPEF(details.cpuInfoFile, ultravisorFirmwareDir); err != nil {
+			kataLog.WithError(err).Error("Protected Execution Facility is not available")
+			count++
+		}
+	}
+
 	if count == 0 {
 		return nil
 	}
@@ -103,6 +115,25 @@ func hostIsVMContainerCapable(details vmContainerCapableDetails) error {
 	return fmt.Errorf("ERROR: %s", failMessage)
 }
 
+// checkPEF checks that the host is ready to run PEF secure guests: the
+// ultravisor must be running and the host must use the radix MMU.
+func checkPEF(cpuInfoFile, firmwareDir string) error {
+	if d, err := os.Stat(firmwareDir); err != nil || !d.IsDir() {
+		return fmt.Errorf("the ultravisor is not running, %s not found", firmwareDir)
+	}
+
+	mmu, err := vc.HostMMU(cpuInfoFile)
+	if err != nil {
+		return err
+	}
+
+	if mmu != vc.MMURadix {
+		return fmt.Errorf("PEF guests require the radix MMU, the host uses the %s MMU", mmu)
+	}
+
+	return nil
+}
+
 // kvmIsUsable determines if it will be possible to create a full virtual machine
 // by creating a minimal VM and then deleting it.
 func kvmIsUsable() error {
@@ -173,3 +173,28 @@ foo     : bar
 func TestSetCPUtype(t *testing.T) {
 	testSetCPUTypeGeneric(t)
 }
+
+func TestCheckPEF(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	cpuInfoFile := filepath.Join(dir, "cpuinfo")
+	firmwareDir := filepath.Join(dir, "ultravisor")
+
+	err = ioutil.WriteFile(cpuInfoFile, []byte("MMU\t\t: Radix\n"), testFileMode)
+	assert.NoError(err)
+
+	// No ultravisor
+	assert.Error(checkPEF(cpuInfoFile, firmwareDir))
+
+	err = os.MkdirAll(firmwareDir, testDirMode)
+	assert.NoError(err)
+	assert.NoError(checkPEF(cpuInfoFile, firmwareDir))
+
+	err = ioutil.WriteFile(cpuInfoFile, []byte("MMU\t\t: Hash\n"), testFileMode)
+	assert.NoError(err)
+	assert.Error(checkPEF(cpuInfoFile, firmwareDir))
+}
@@ -20,6 +20,18 @@ const (
 	// Example:
 	// processor 0: version = FF,  identification = 3FEC87,  machine = 2964
 	archCPUModelField = "machine"
+
+	// Secure Execution (Protected Virtualization) requires the CPU facility
+	// 158 and the host kernel booted with prot_virt enabled.
+	// https://www.kernel.org/doc/html/latest/virt/kvm/s390-pv.html
+	seCPUFacilityBit = 158
+	seCmdlineParam   = "prot_virt"
+)
+
+// variables rather than consts to allow tests to modify them
+var (
+	procKernelCmdline = "/proc/cmdline"
+	seCmdlineValues   = []string{"1", "on", "y", "yes"}
 )
 
 // archRequiredCPUFlags maps a CPU flag value to search for and a
@@ -72,6 +84,13 @@ func hostIsVMContainerCapable(details vmContainerCapableDetails) error {
 		return err
 	}
 
+	if details.confidentialGuest {
+		if err := checkSecureExecution(details.cpuInfoFile, procKernelCmdline); err != nil {
+			kataLog.WithError(err).Error("Secure Execution is not available")
+			count++
+		}
+	}
+
 	if count == 0 {
 		return nil
 	}
@@ -80,6 +99,31 @@ func hostIsVMContainerCapable(details vmContainerCapableDetails) error {
 
 }
 
+// checkSecureExecution checks that the host is ready to run Secure Execution
+// guests: the CPU must have the facility and the kernel must have enabled it.
+func checkSecureExecution(cpuInfoFile, cmdlineFile string) error {
+	facilities, err := vc.CPUFacilities(cpuInfoFile)
+	if err != nil {
+		return err
+	}
+
+	if !facilities[seCPUFacilityBit] {
+		return fmt.Errorf("CPU facility %d (Secure Execution) is not available", seCPUFacilityBit)
+	}
+
+	enabled, err := vc.CheckCmdline(cmdlineFile, seCmdlineParam, seCmdlineValues)
+	if err != nil {
+		return err
+	}
+
+	if !enabled {
+		return fmt.Errorf("Protected Virtualization is not enabled on the kernel command line, need %s=%s",
+			seCmdlineParam, seCmdlineValues[0])
+	}
+
+	return nil
+}
+
 func archKernelParamHandler(onVMM bool, fields logrus.Fields, msg string) bool {
 	return genericArchKernelParamHandler(onVMM, fields, msg)
 }
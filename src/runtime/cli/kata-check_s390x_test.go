@@ -166,3 +166,42 @@ foo     : bar
 	}
 	genericTestGetCPUDetails(t, validVendor, validModel, validContents, data)
 }
+
+func TestCheckSecureExecution(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	cpuInfoFile := filepath.Join(dir, "cpuinfo")
+	cmdlineFile := filepath.Join(dir, "cmdline")
+
+	type testData struct {
+		facilities  string
+		cmdline     string
+		expectError bool
+	}
+
+	data := []testData{
+		{"facilities      : 0 1 2 3", "root=/dev/vda prot_virt=1", true},
+		{"facilities      : 0 1 2 158", "root=/dev/vda", true},
+		{"facilities      : 0 1 2 158", "root=/dev/vda prot_virt=0", true},
+		{"facilities      : 0 1 2 158", "root=/dev/vda prot_virt=1", false},
+		{"facilities      : 0 1 2 158", "prot_virt=yes", false},
+	}
+
+	for i, d := range data {
+		err = ioutil.WriteFile(cpuInfoFile, []byte(d.facilities+"\n"), testFileMode)
+		assert.NoError(err)
+		err = ioutil.WriteFile(cmdlineFile, []byte(d.cmdline+"\n"), testFileMode)
+		assert.NoError(err)
+
+		err = checkSecureExecution(cpuInfoFile, cmdlineFile)
+		if d.expectError {
+			assert.Error(err, "test %d (%+v)", i, d)
+		} else {
+			assert.NoError(err, "test %d (%+v)", i, d)
+		}
+	}
+}
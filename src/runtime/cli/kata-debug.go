@@ -0,0 +1,176 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/urfave/cli"
+)
+
+// minDumpKeySize is the minimum size of guest memory dump encryption keys
+const minDumpKeySize = 16
+
+// guestDiagnosticsTimeout bounds the time taken by the agent to collect the
+// guest diagnostics.
+const guestDiagnosticsTimeout = 30 * time.Second
+
+var debugSubCmds = []cli.Command{
+	dumpMemoryCommand,
+	decryptMemoryDumpCommand,
+	hypervisorLogCommand,
+	guestDiagnosticsCommand,
+}
+
+var kataDebugCLICommand = cli.Command{
+	Name:        "debug",
+	Usage:       "debug a running sandbox",
+	Subcommands: debugSubCmds,
+	Action: func(context *cli.Context) {
+		cli.ShowSubcommandHelp(context)
+	},
+}
+
+var dumpMemoryCommand = cli.Command{
+	Name:      "dump-memory",
+	Usage:     "dump the guest memory of a sandbox to the configured guest memory dump path",
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "key-file",
+			Usage: "encrypt the dump with the key read from `FILE` (mandatory for confidential guests)",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		var req sandboxapi.MemoryDumpRequest
+		if keyFile := context.String("key-file"); keyFile != "" {
+			key, err := readDumpKey(keyFile)
+			if err != nil {
+				return err
+			}
+			req.EncryptionKey = key
+		}
+
+		// dumping the guest memory can take a long time, don't time out
+		dir, err := sandboxapi.NewClient(sandboxID, 0).DumpMemory(req)
+		if err != nil {
+			return fmt.Errorf("failed to dump guest memory: %v", err)
+		}
+
+		fmt.Printf("guest memory dumped to %s\n", dir)
+		return nil
+	},
+}
+
+var decryptMemoryDumpCommand = cli.Command{
+	Name:      "decrypt-memory-dump",
+	Usage:     "decrypt an encrypted guest memory dump",
+	ArgsUsage: "<encrypted dump> <output file>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "key-file",
+			Usage: "read the key used to encrypt the dump from `FILE`",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		if context.NArg() != 2 {
+			return fmt.Errorf("expected encrypted dump and output file arguments")
+		}
+
+		keyFile := context.String("key-file")
+		if keyFile == "" {
+			return fmt.Errorf("missing key file")
+		}
+
+		key, err := readDumpKey(keyFile)
+		if err != nil {
+			return err
+		}
+
+		return vc.DecryptGuestMemoryDump(context.Args().Get(0), context.Args().Get(1), key)
+	},
+}
+
+var hypervisorLogCommand = cli.Command{
+	Name:      "hypervisor-log",
+	Usage:     "show the last output of the hypervisor and of the guest console of a sandbox",
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.UintFlag{
+			Name:  "kb",
+			Usage: "show the last `KB` kilobytes only, all that the shim keeps by default",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		log, err := sandboxapi.NewClient(sandboxID, defaultTimeout).HypervisorLog(context.Uint("kb"))
+		if err != nil {
+			return fmt.Errorf("failed to get the hypervisor log: %v", err)
+		}
+
+		fmt.Print(log)
+		return nil
+	},
+}
+
+var guestDiagnosticsCommand = cli.Command{
+	Name:      "guest-diagnostics",
+	Usage:     "show the kernel log, the mounts, the memory and the modules of the guest of a sandbox, in JSON",
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.UintFlag{
+			Name:  "dmesg-lines",
+			Usage: "show the last `LINES` lines of the kernel log, the agent default otherwise",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		diagnostics, err := sandboxapi.NewClient(sandboxID, guestDiagnosticsTimeout).GuestDiagnostics(context.Uint("dmesg-lines"))
+		if err != nil {
+			return fmt.Errorf("failed to get the guest diagnostics: %v", err)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diagnostics)
+	},
+}
+
+func readDumpKey(keyFile string) ([]byte, error) {
+	key, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key) < minDumpKeySize {
+		return nil, fmt.Errorf("key in %s is too short, at least %d bytes are required", keyFile, minDumpKeySize)
+	}
+
+	return key, nil
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
@@ -27,7 +28,7 @@ import (
 //
 // XXX: Increment for every change to the output format
 // (meaning any change to the EnvInfo type).
-const formatVersion = "1.0.25"
+const formatVersion = "1.0.27"
 
 // MetaInfo stores information on the format of the output itself
 type MetaInfo struct {
@@ -67,7 +68,11 @@ type MemoryInfo struct {
 
 // RuntimeConfigInfo stores runtime config details.
 type RuntimeConfigInfo struct {
-	Path string
+	Path        string
+	Hash        string
+	DefaultPath string
+	DefaultHash string
+	Overridden  []string
 }
 
 // RuntimeInfo stores runtime details.
@@ -106,6 +111,7 @@ type HypervisorInfo struct {
 	EntropySource        string
 	SharedFS             string
 	VirtioFSDaemon       string
+	VMMCgroupMechanism   string
 	Msize9p              uint32
 	MemorySlots          uint32
 	PCIeRootPort         uint32
@@ -181,6 +187,13 @@ func getRuntimeInfo(configFile string, config oci.RuntimeConfig) RuntimeInfo {
 		Path: configFile,
 	}
 
+	// Best effort, the packaged default config may not be installed
+	drift, _ := katautils.GetConfigDrift(configFile)
+	runtimeConfig.Hash = drift.Hash
+	runtimeConfig.DefaultPath = drift.DefaultPath
+	runtimeConfig.DefaultHash = drift.DefaultHash
+	runtimeConfig.Overridden = drift.Overridden
+
 	runtimePath, _ := os.Executable()
 
 	return RuntimeInfo{
@@ -327,6 +340,7 @@ func getHypervisorInfo(config oci.RuntimeConfig) HypervisorInfo {
 		SharedFS:          config.HypervisorConfig.SharedFS,
 		VirtioFSDaemon:    config.HypervisorConfig.VirtioFSDaemon,
 
+		VMMCgroupMechanism:   vc.VMMCgroupMechanism(config.HypervisorType, config.SandboxCgroupOnly),
 		HotplugVFIOOnRootBus: config.HypervisorConfig.HotplugVFIOOnRootBus,
 		PCIeRootPort:         config.HypervisorConfig.PCIeRootPort,
 	}
@@ -293,6 +293,7 @@ func getExpectedHypervisor(config oci.RuntimeConfig) HypervisorInfo {
 		SharedFS:          config.HypervisorConfig.SharedFS,
 		VirtioFSDaemon:    config.HypervisorConfig.VirtioFSDaemon,
 
+		VMMCgroupMechanism:   vc.VMMCgroupMechanism(config.HypervisorType, config.SandboxCgroupOnly),
 		HotplugVFIOOnRootBus: config.HypervisorConfig.HotplugVFIOOnRootBus,
 		PCIeRootPort:         config.HypervisorConfig.PCIeRootPort,
 	}
@@ -316,13 +317,20 @@ func getExpectedRuntimeDetails(config oci.RuntimeConfig, configFile string) Runt
 
 	runtimeVersionInfo := constructVersionInfo(version)
 	runtimeVersionInfo.Commit = commit
+
+	drift, _ := katautils.GetConfigDrift(configFile)
+
 	return RuntimeInfo{
 		Version: RuntimeVersionInfo{
 			Version: runtimeVersionInfo,
 			OCI:     specs.Version,
 		},
 		Config: RuntimeConfigInfo{
-			Path: configFile,
+			Path:        configFile,
+			Hash:        drift.Hash,
+			DefaultPath: drift.DefaultPath,
+			DefaultHash: drift.DefaultHash,
+			Overridden:  drift.Overridden,
 		},
 		Path:            runtimePath,
 		Debug:           config.Debug,
@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/urfave/cli"
+)
+
+var kataEventsCLICommand = cli.Command{
+	Name:      "events",
+	Usage:     "show the event journal of a sandbox",
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "output the events as JSON",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		// The journal is read from the persist directory, so that it
+		// is available even if the shim is gone.
+		events, err := vc.ReadSandboxJournal(sandboxID)
+		if err != nil {
+			return err
+		}
+
+		if context.Bool("json") {
+			return json.NewEncoder(os.Stdout).Encode(events)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+		fmt.Fprintln(w, "TIME\tTYPE\tCONTAINER\tMESSAGE")
+		for _, e := range events {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.Time.Format(time.RFC3339Nano), e.Type, e.Container, e.Message)
+		}
+
+		return w.Flush()
+	},
+}
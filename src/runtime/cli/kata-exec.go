@@ -7,15 +7,16 @@
 package main
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
-	"net/url"
 	"os"
+	"os/signal"
 	"strings"
-
 	"sync"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	clientUtils "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/client"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
+	"golang.org/x/sys/unix"
 )
 
 const (
@@ -37,7 +39,19 @@ const (
 	subCommandName = "exec"
 	// command-line parameters name
 	paramDebugConsolePort                    = "kata-debug-port"
+	paramCommand                             = "command"
 	defaultKernelParamDebugConsoleVPortValue = 1026
+
+	// The debug console session header, followed by the JSON encoded
+	// debugConsoleSession and a newline. The agent then talks in frames
+	// of a type byte and a big endian uint16 payload length.
+	debugConsoleSessionMagic = "KATA-DEBUG-CONSOLE/1 "
+
+	frameData   byte = 0
+	frameResize byte = 1
+	frameExit   byte = 2
+
+	frameHeaderSize = 3
 )
 
 var (
@@ -50,13 +64,18 @@ var (
 )
 
 var kataExecCLICommand = cli.Command{
-	Name:  subCommandName,
-	Usage: "Enter into guest by debug console",
+	Name:      subCommandName,
+	Usage:     "Enter into guest by debug console",
+	ArgsUsage: "<sandbox id>",
 	Flags: []cli.Flag{
 		cli.Uint64Flag{
 			Name:  paramDebugConsolePort,
 			Usage: "Port that debug console is listening on. (Default: 1026)",
 		},
+		cli.StringFlag{
+			Name:  paramCommand,
+			Usage: "Run the command with the guest shell instead of an interactive shell, and exit with its exit code",
+		},
 	},
 	Action: func(context *cli.Context) error {
 		port := context.Uint64(paramDebugConsolePort)
@@ -77,81 +96,165 @@ var kataExecCLICommand = cli.Command{
 		}
 		defer conn.Close()
 
-		con := console.Current()
-		defer con.Reset()
-
-		if err := con.SetRaw(); err != nil {
+		code, err := runDebugConsoleSession(conn, context.String(paramCommand))
+		if err != nil {
 			return err
 		}
 
-		iostream := &iostream{
-			conn:   conn,
-			exitch: make(chan struct{}),
-			closed: false,
+		if code != 0 {
+			exit(code)
 		}
 
-		ioCopy(iostream, con)
-
-		<-iostream.exitch
 		return nil
 	},
 }
 
-func ioCopy(stream *iostream, con console.Console) {
-	var wg sync.WaitGroup
+// debugConsoleSession is the debug console session requested to the agent.
+// Several sessions can be opened on the same sandbox at the same time.
+type debugConsoleSession struct {
+	Rows    uint16 `json:"rows,omitempty"`
+	Cols    uint16 `json:"cols,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
+// runDebugConsoleSession runs a debug console session on conn, forwarding
+// the standard input and the terminal window size changes. It returns the
+// exit code of the guest shell.
+func runDebugConsoleSession(conn net.Conn, command string) (int, error) {
+	var session debugConsoleSession
+
+	session.Command = command
+
+	// The standard input is not a terminal with --command, e.g. in scripts
+	con, err := console.ConsoleFromFile(os.Stdin)
+	if err == nil {
+		if size, err := con.Size(); err == nil {
+			session.Rows, session.Cols = size.Height, size.Width
+		}
+
+		if err := con.SetRaw(); err != nil {
+			return 0, err
+		}
+		defer con.Reset()
+	}
+
+	stream := &iostream{
+		conn: conn,
+	}
+
+	if err := stream.writeSession(session); err != nil {
+		return 0, err
+	}
+
+	if con != nil {
+		winch := make(chan os.Signal, 1)
+		signal.Notify(winch, unix.SIGWINCH)
+		defer signal.Stop(winch)
+
+		go func() {
+			for range winch {
+				size, err := con.Size()
+				if err != nil {
+					continue
+				}
+
+				if err := stream.writeResize(size.Height, size.Width); err != nil {
+					return
+				}
+			}
+		}()
+	}
 
 	// stdin
 	go func() {
 		p := bufPool.Get().(*[]byte)
 		defer bufPool.Put(p)
-		io.CopyBuffer(stream, con, *p)
+		io.CopyBuffer(stream, os.Stdin, *p)
 	}()
 
 	// stdout
-	wg.Add(1)
-	go func() {
-		p := bufPool.Get().(*[]byte)
-		defer bufPool.Put(p)
-		io.CopyBuffer(os.Stdout, stream, *p)
-		wg.Done()
-	}()
-
-	wg.Wait()
-	close(stream.exitch)
+	return stream.copyOutput(os.Stdout)
 }
 
+// iostream is the framed stream of a debug console session.
 type iostream struct {
-	conn   net.Conn
-	exitch chan struct{}
-	closed bool
+	sync.Mutex
+	conn net.Conn
 }
 
-func (s *iostream) Write(data []byte) (n int, err error) {
-	if s.closed {
-		return 0, errors.New("stream closed")
+func (s *iostream) writeFrame(frameType byte, payload []byte) error {
+	if len(payload) > 0xffff {
+		return fmt.Errorf("debug console frame payload too large: %d bytes", len(payload))
 	}
-	return s.conn.Write(data)
+
+	frame := make([]byte, frameHeaderSize+len(payload))
+	frame[0] = frameType
+	binary.BigEndian.PutUint16(frame[1:], uint16(len(payload)))
+	copy(frame[frameHeaderSize:], payload)
+
+	s.Lock()
+	defer s.Unlock()
+
+	_, err := s.conn.Write(frame)
+	return err
 }
 
-func (s *iostream) Close() error {
-	if s.closed {
-		return errors.New("stream closed")
+func (s *iostream) writeSession(session debugConsoleSession) error {
+	params, err := json.Marshal(session)
+	if err != nil {
+		return err
 	}
 
-	err := s.conn.Close()
-	if err == nil {
-		s.closed = true
-	}
+	s.Lock()
+	defer s.Unlock()
 
+	_, err = s.conn.Write([]byte(debugConsoleSessionMagic + string(params) + "\n"))
 	return err
 }
 
-func (s *iostream) Read(data []byte) (n int, err error) {
-	if s.closed {
-		return 0, errors.New("stream closed")
+func (s *iostream) writeResize(rows, cols uint16) error {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint16(payload, rows)
+	binary.BigEndian.PutUint16(payload[2:], cols)
+
+	return s.writeFrame(frameResize, payload)
+}
+
+// Write sends data as a data frame.
+func (s *iostream) Write(data []byte) (n int, err error) {
+	if err := s.writeFrame(frameData, data); err != nil {
+		return 0, err
 	}
 
-	return s.conn.Read(data)
+	return len(data), nil
+}
+
+// copyOutput copies the data frames to w, until the exit frame.
+func (s *iostream) copyOutput(w io.Writer) (int, error) {
+	header := make([]byte, frameHeaderSize)
+	payload := make([]byte, 0xffff)
+
+	for {
+		if _, err := io.ReadFull(s.conn, header); err != nil {
+			return 0, errors.Wrap(err, "debug console connection closed")
+		}
+
+		size := int(binary.BigEndian.Uint16(header[1:]))
+		if _, err := io.ReadFull(s.conn, payload[:size]); err != nil {
+			return 0, errors.Wrap(err, "debug console connection closed")
+		}
+
+		switch {
+		case header[0] == frameData:
+			if _, err := w.Write(payload[:size]); err != nil {
+				return 0, err
+			}
+		case header[0] == frameExit && size == 4:
+			return int(int32(binary.BigEndian.Uint32(payload))), nil
+		default:
+			return 0, fmt.Errorf("unexpected debug console frame type %d of %d bytes", header[0], size)
+		}
+	}
 }
 
 func getConn(sandboxID string, port uint64) (net.Conn, error) {
@@ -176,33 +279,5 @@ func getConn(sandboxID string, port uint64) (net.Conn, error) {
 	}
 
 	sock := strings.TrimSuffix(string(data), "\n")
-	addr, err := url.Parse(sock)
-	if err != nil {
-		return nil, err
-	}
-
-	// validate more
-	switch addr.Scheme {
-	case clientUtils.VSockSocketScheme:
-		// vsock://31513974:1024
-		cidAndPort := strings.Split(addr.Host, ":")
-		if len(cidAndPort) != 2 {
-			return nil, fmt.Errorf("Invalid vsock scheme: %s", sock)
-		}
-		shimAddr := fmt.Sprintf("%s:%s:%d", clientUtils.VSockSocketScheme, cidAndPort[0], port)
-		return clientUtils.VsockDialer(shimAddr, defaultTimeout)
-
-	case clientUtils.HybridVSockScheme:
-		// addr: hvsock:///run/vc/firecracker/340b412c97bf1375cdda56bfa8f18c8a/root/kata.hvsock:1024
-		hvsocket := strings.Split(addr.Path, ":")
-		if len(hvsocket) != 2 {
-			return nil, fmt.Errorf("Invalid hybrid vsock scheme: %s", sock)
-		}
-
-		// hvsock:///run/vc/firecracker/340b412c97bf1375cdda56bfa8f18c8a/root/kata.hvsock
-		shimAddr := fmt.Sprintf("%s:%s:%d", clientUtils.HybridVSockScheme, hvsocket[0], port)
-		return clientUtils.HybridVSockDialer(shimAddr, defaultTimeout)
-	}
-
-	return nil, fmt.Errorf("schema %s not found", addr.Scheme)
+	return clientUtils.AgentPortDialer(sock, uint32(port), defaultTimeout)
 }
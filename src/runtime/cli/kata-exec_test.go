@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugConsoleSessionFrames(t *testing.T) {
+	assert := assert.New(t)
+
+	conn, agent := net.Pipe()
+	defer conn.Close()
+	defer agent.Close()
+
+	stream := &iostream{conn: conn}
+
+	go func() {
+		stream.writeSession(debugConsoleSession{Rows: 24, Cols: 80, Command: "ls"})
+		stream.Write([]byte("a"))
+		stream.writeResize(25, 81)
+	}()
+
+	reader := bufio.NewReader(agent)
+	header, err := reader.ReadString('\n')
+	assert.NoError(err)
+	assert.Equal(debugConsoleSessionMagic+`{"rows":24,"cols":80,"command":"ls"}`+"\n", header)
+
+	frames := make([]byte, 4+7)
+	_, err = io.ReadFull(reader, frames)
+	assert.NoError(err)
+	assert.Equal([]byte{frameData, 0, 1, 'a', frameResize, 0, 4, 0, 25, 0, 81}, frames)
+}
+
+func TestDebugConsoleSessionOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	conn, agent := net.Pipe()
+	defer conn.Close()
+	defer agent.Close()
+
+	stream := &iostream{conn: conn}
+
+	go func() {
+		agent.Write([]byte{frameData, 0, 5, 'h', 'e', 'l', 'l', 'o'})
+		agent.Write([]byte{frameExit, 0, 4, 0, 0, 0, 42})
+	}()
+
+	var out bytes.Buffer
+	code, err := stream.copyOutput(&out)
+	assert.NoError(err)
+	assert.Equal(42, code)
+	assert.Equal("hello", out.String())
+
+	go func() {
+		agent.Write([]byte{7, 0, 0})
+	}()
+
+	_, err = stream.copyOutput(&out)
+	assert.Error(err)
+
+	go agent.Close()
+
+	_, err = stream.copyOutput(&out)
+	assert.Error(err)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	kataMonitor "github.com/kata-containers/kata-containers/src/runtime/pkg/kata-monitor"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/urfave/cli"
+)
+
+var kataGCCLICommand = cli.Command{
+	Name:  "gc",
+	Usage: "clean up host resources of sandboxes whose shim is gone",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "remove",
+			Usage: "kill the processes and remove the resources of the orphan sandboxes, instead of only listing them",
+		},
+		cli.DurationFlag{
+			Name:  "min-age",
+			Value: time.Minute,
+			Usage: "ignore sandboxes whose storage is younger than this",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		runtimeConfig, ok := context.App.Metadata["runtimeConfig"].(oci.RuntimeConfig)
+		if !ok {
+			return errors.New("invalid runtime config")
+		}
+
+		gc, err := katautils.NewSandboxGC(kataMonitor.IsSandboxAlive, context.Duration("min-age"), katautils.SandboxBinaries(runtimeConfig))
+		if err != nil {
+			return err
+		}
+
+		orphans, gcErr := gc.Run(context.Bool("remove"))
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+		fmt.Fprintln(w, "SANDBOX\tPROCESSES\tMOUNTS\tNETNS\tDIRECTORIES")
+		for _, o := range orphans {
+			fmt.Fprintf(w, "%s\t%v\t%d\t%s\t%d\n", o.ID, o.Pids, len(o.Mounts), o.NetNsPath, len(o.Dirs))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		return gcErr
+	},
+}
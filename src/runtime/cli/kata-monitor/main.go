@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"strings"
 	"text/template"
 	"time"
 
@@ -20,7 +21,35 @@ import (
 var monitorListenAddr = flag.String("listen-address", ":8090", "The address to listen on for HTTP requests.")
 var containerdAddr = flag.String("containerd-address", "/run/containerd/containerd.sock", "Containerd address to accept client requests.")
 var containerdConfig = flag.String("containerd-conf", "/etc/containerd/config.toml", "Containerd config file.")
+var containerdNamespaces = flag.String("containerd-namespaces", "", "Comma separated containerd namespaces to discover the sandboxes in (default: all).")
+var criEndpoints stringList
 var logLevel = flag.String("log-level", "info", "Log level of logrus(trace/debug/info/warn/error/fatal/panic).")
+var gcInterval = flag.Duration("gc-interval", 0, "Interval between garbage collections of orphan sandbox resources (0 disables it).")
+var gcMinAge = flag.Duration("gc-min-age", time.Minute, "Minimum age of orphan sandbox resources before they are garbage collected.")
+var gcRuntimeConfig = flag.String("gc-runtime-config", "", "Runtime configuration file of the sandboxes, whose hypervisor, virtiofsd and netmon processes are garbage collected (default: the runtime default).")
+var gcRemove = flag.Bool("gc-remove", false, "Remove the orphan sandbox resources found by the garbage collector, instead of only reporting them.")
+var statsInterval = flag.Duration("stats-interval", 0, "Interval between samples of the sandboxes resource usage kept in the stats history (0 disables it).")
+var statsRetention = flag.Duration("stats-retention", 30*time.Minute, "Period the samples of the stats history are kept for.")
+var tlsCertFile = flag.String("tls-cert-file", "", "Certificate file to serve HTTPS with, along with -tls-key-file.")
+var tlsKeyFile = flag.String("tls-key-file", "", "Private key file of the -tls-cert-file certificate.")
+var tlsClientCAFile = flag.String("tls-client-ca-file", "", "CA certificates file the client certificates must be signed by (default: no client certificate required).")
+var authTokenFile = flag.String("auth-token-file", "", "File holding the bearer token the requests must carry in their Authorization header (default: no token required).")
+
+func init() {
+	flag.Var(&criEndpoints, "cri-endpoint", "CRI endpoint of another runtime to discover the sandboxes of, e.g. /var/run/crio/crio.sock. May be repeated. Set -containerd-address to \"\" when containerd is not running.")
+}
+
+// stringList is a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
 
 // These values are overridden via ldflags
 var (
@@ -85,24 +114,50 @@ func main() {
 		"git-commit": ver.GitCommit,
 
 		// properties from command-line options
-		"listen-address":     *monitorListenAddr,
-		"containerd-address": *containerdAddr,
-		"containerd-conf":    *containerdConfig,
-		"log-level":          *logLevel,
+		"listen-address":        *monitorListenAddr,
+		"containerd-address":    *containerdAddr,
+		"containerd-conf":       *containerdConfig,
+		"containerd-namespaces": *containerdNamespaces,
+		"cri-endpoints":         criEndpoints.String(),
+		"log-level":             *logLevel,
+		"gc-interval":           *gcInterval,
+		"gc-remove":             *gcRemove,
+		"stats-interval":        *statsInterval,
+		"stats-retention":       *statsRetention,
+		"tls":                   *tlsCertFile != "",
+		"tls-client-ca-file":    *tlsClientCAFile,
+		"auth-token-file":       *authTokenFile,
 	}
 
 	logrus.WithFields(announceFields).Info("announce")
 
 	// create new kataMonitor
-	km, err := kataMonitor.NewKataMonitor(*containerdAddr, *containerdConfig)
+	var namespaces []string
+	if *containerdNamespaces != "" {
+		namespaces = strings.Split(*containerdNamespaces, ",")
+	}
+
+	km, err := kataMonitor.NewKataMonitor(*containerdAddr, *containerdConfig, namespaces, criEndpoints)
 	if err != nil {
 		panic(err)
 	}
 
+	if *gcInterval > 0 {
+		if err := km.StartSandboxGC(*gcInterval, *gcMinAge, *gcRemove, *gcRuntimeConfig); err != nil {
+			panic(err)
+		}
+	}
+
+	if *statsInterval > 0 {
+		km.StartStatsHistory(*statsInterval, *statsRetention)
+	}
+
 	// setup handlers, now only metrics is supported
 	m := http.NewServeMux()
 	m.Handle("/metrics", http.HandlerFunc(km.ProcessMetricsRequest))
+	m.Handle("/metrics/runtime", http.HandlerFunc(km.ProcessRuntimeMetricsRequest))
 	m.Handle("/sandboxes", http.HandlerFunc(km.ListSandboxes))
+	m.Handle("/sandboxes/", http.HandlerFunc(km.ServeStatsHistory))
 	m.Handle("/agent-url", http.HandlerFunc(km.GetAgentURL))
 
 	// for debug shim process
@@ -113,12 +168,30 @@ func main() {
 	m.Handle("/debug/pprof/symbol", http.HandlerFunc(km.PprofSymbol))
 	m.Handle("/debug/pprof/trace", http.HandlerFunc(km.PprofTrace))
 
+	var handler http.Handler = m
+	if *authTokenFile != "" {
+		if handler, err = kataMonitor.TokenAuth(m, *authTokenFile); err != nil {
+			panic(err)
+		}
+	}
+
 	// listening on the server
 	svr := &http.Server{
-		Handler: m,
+		Handler: handler,
 		Addr:    *monitorListenAddr,
 	}
-	logrus.Fatal(svr.ListenAndServe())
+
+	if *tlsCertFile == "" && *tlsKeyFile == "" {
+		if *tlsClientCAFile != "" {
+			panic("-tls-client-ca-file requires -tls-cert-file and -tls-key-file")
+		}
+		logrus.Fatal(svr.ListenAndServe())
+	}
+
+	if svr.TLSConfig, err = kataMonitor.TLSConfig(*tlsCertFile, *tlsKeyFile, *tlsClientCAFile); err != nil {
+		panic(err)
+	}
+	logrus.Fatal(svr.ListenAndServeTLS("", ""))
 }
 
 // initLog setup logger
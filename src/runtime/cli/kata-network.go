@@ -0,0 +1,208 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	"github.com/urfave/cli"
+)
+
+// networkInspectTimeout bounds the time taken by the shim to gather the
+// network configuration, the guest side going through the agent.
+const networkInspectTimeout = 30 * time.Second
+
+// defaultNetworkCaptureDuration is the duration of the captures when the
+// user doesn't provide one.
+const defaultNetworkCaptureDuration = 10 * time.Second
+
+var networkSubCmds = []cli.Command{
+	networkInspectCommand,
+	networkCaptureCommand,
+}
+
+var kataNetworkCLICommand = cli.Command{
+	Name:        "network",
+	Usage:       "diagnose the network of a running sandbox",
+	Subcommands: networkSubCmds,
+	Action: func(context *cli.Context) {
+		cli.ShowSubcommandHelp(context)
+	},
+}
+
+var networkInspectCommand = cli.Command{
+	Name:      "inspect",
+	Usage:     "show the network configuration of a sandbox on the host and in the guest, and their differences",
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "Format output as JSON",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		inspection, err := sandboxapi.NewClient(sandboxID, networkInspectTimeout).InspectNetwork()
+		if err != nil {
+			return fmt.Errorf("failed to inspect the sandbox network: %v", err)
+		}
+
+		if context.Bool("json") {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(inspection)
+		}
+
+		return writeNetworkInspection(os.Stdout, inspection)
+	},
+}
+
+var networkCaptureCommand = cli.Command{
+	Name:  "capture",
+	Usage: "capture the traffic of a sandbox in the pcap format",
+	Description: `The traffic is captured on the tap interface of the sandbox on the host,
+   or on its interface in the guest with --guest, which requires the agent
+   "enable_network_capture" option. The capture can be read with tcpdump or
+   wireshark, e.g.:
+
+   kata-runtime network capture <sandbox id> | tcpdump -n -r - port 53`,
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "device",
+			Usage: "interface to capture, the tap interface of the first sandbox interface, or this interface in the guest, by default",
+		},
+		cli.DurationFlag{
+			Name:  "duration",
+			Value: defaultNetworkCaptureDuration,
+			Usage: "duration of the capture",
+		},
+		cli.UintFlag{
+			Name:  "snaplen",
+			Usage: "length captured of each packet, the whole packets by default",
+		},
+		cli.BoolFlag{
+			Name:  "guest",
+			Usage: "capture in the guest, through the agent",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Value: "-",
+			Usage: "file to write the capture to, the standard output by default",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		out := os.Stdout
+		if path := context.String("output"); path != "-" {
+			f, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+
+		req := sandboxapi.NetworkCaptureRequest{
+			Device:   context.String("device"),
+			Duration: context.Duration("duration"),
+			SnapLen:  uint32(context.Uint("snaplen")),
+			Guest:    context.Bool("guest"),
+		}
+
+		if err := sandboxapi.NewClient(sandboxID, 0).CaptureNetwork(gocontext.Background(), out, req); err != nil {
+			return fmt.Errorf("failed to capture the sandbox network: %v", err)
+		}
+
+		return nil
+	},
+}
+
+// writeNetworkInspection writes the network inspection in tables, the
+// differences last.
+func writeNetworkInspection(w io.Writer, inspection sandboxapi.NetworkInspection) error {
+	sides := []struct {
+		title    string
+		snapshot sandboxapi.NetworkSnapshot
+	}{
+		{"Host (sandbox network namespace)", inspection.Host},
+		{"Guest (as reported by the agent)", inspection.Guest},
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	for _, side := range sides {
+		fmt.Fprintf(tw, "%s\n\n", side.title)
+
+		fmt.Fprintln(tw, "INTERFACE\tMAC\tMTU\tADDRESSES")
+		for _, i := range side.snapshot.Interfaces {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", i.Name, i.HwAddr, i.MTU, strings.Join(i.Addresses, ","))
+		}
+		fmt.Fprintln(tw)
+
+		fmt.Fprintln(tw, "DESTINATION\tGATEWAY\tSOURCE\tDEVICE")
+		for _, r := range side.snapshot.Routes {
+			dest := r.Dest
+			if dest == "" {
+				dest = "default"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", dest, r.Gateway, r.Source, r.Device)
+		}
+		fmt.Fprintln(tw)
+
+		if len(side.snapshot.Neighbors) > 0 {
+			fmt.Fprintln(tw, "NEIGHBOR\tMAC\tDEVICE\tPERMANENT")
+			for _, n := range side.snapshot.Neighbors {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", n.IP, n.HwAddr, n.Device, n.Permanent)
+			}
+			fmt.Fprintln(tw)
+		}
+
+		if len(side.snapshot.Filters) > 0 {
+			fmt.Fprintln(tw, "TC FILTER\tREDIRECT")
+			for _, f := range side.snapshot.Filters {
+				fmt.Fprintf(tw, "%s\t%s\n", f.Device, f.Redirect)
+			}
+			fmt.Fprintln(tw)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(inspection.Differences) == 0 {
+		_, err := fmt.Fprintln(w, "No differences between the host and the guest")
+		return err
+	}
+
+	fmt.Fprintln(w, "Differences")
+	fmt.Fprintln(w)
+	for _, d := range inspection.Differences {
+		fmt.Fprintf(w, "  - %s\n", d)
+	}
+
+	return nil
+}
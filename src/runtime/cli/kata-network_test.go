@@ -0,0 +1,52 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteNetworkInspection(t *testing.T) {
+	assert := assert.New(t)
+
+	inspection := sandboxapi.NetworkInspection{
+		Host: sandboxapi.NetworkSnapshot{
+			Interfaces: []sandboxapi.NetworkInterface{
+				{Name: "eth0", HwAddr: "02:42:ac:11:00:02", MTU: 1500, Addresses: []string{"10.0.0.2/24"}},
+			},
+			Routes: []sandboxapi.NetworkRoute{
+				{Gateway: "10.0.0.1", Device: "eth0"},
+			},
+			Filters: []sandboxapi.NetworkFilter{
+				{Device: "eth0", Redirect: "tap0_kata"},
+			},
+		},
+		Guest: sandboxapi.NetworkSnapshot{
+			Interfaces: []sandboxapi.NetworkInterface{
+				{Name: "eth0", HwAddr: "02:42:ac:11:00:02", MTU: 1500},
+			},
+		},
+		Differences: []string{"interface eth0: address 10.0.0.2/24 is missing in the guest"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(writeNetworkInspection(&buf, inspection))
+
+	out := buf.String()
+	assert.Contains(out, "Host (sandbox network namespace)")
+	assert.Contains(out, "Guest (as reported by the agent)")
+	assert.Regexp(`default\s+10\.0\.0\.1\s+eth0`, out)
+	assert.Regexp(`eth0\s+tap0_kata`, out)
+	assert.Contains(out, "  - interface eth0: address 10.0.0.2/24 is missing in the guest")
+
+	buf.Reset()
+	assert.NoError(writeNetworkInspection(&buf, sandboxapi.NetworkInspection{}))
+	assert.Contains(buf.String(), "No differences between the host and the guest")
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	kataMonitor "github.com/kata-containers/kata-containers/src/runtime/pkg/kata-monitor"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
+	"github.com/urfave/cli"
+)
+
+const defaultPortForwardAddress = "127.0.0.1"
+
+var kataPortForwardCLICommand = cli.Command{
+	Name:      "port-forward",
+	Usage:     "forward a local port to a TCP port of a sandbox through the agent",
+	ArgsUsage: "<sandbox id> <local port>:<port>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "address",
+			Value: defaultPortForwardAddress,
+			Usage: "address to listen on",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		localPort, port, err := parsePortForwardPorts(context.Args().Get(1))
+		if err != nil {
+			return err
+		}
+
+		l, err := net.Listen("tcp", net.JoinHostPort(context.String("address"), strconv.Itoa(int(localPort))))
+		if err != nil {
+			return err
+		}
+		defer l.Close()
+
+		fmt.Printf("Forwarding from %s -> %d\n", l.Addr(), port)
+
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return err
+			}
+
+			go func() {
+				defer conn.Close()
+
+				guestConn, err := kataMonitor.DialPortForward(sandboxID, port)
+				if err != nil {
+					kataLog.WithError(err).Error("failed to forward connection")
+					return
+				}
+				defer guestConn.Close()
+
+				mutils.Tunnel(conn, guestConn)
+			}()
+		}
+	},
+}
+
+// parsePortForwardPorts parses "<local port>:<port>", or "<port>" when both
+// ports are the same.
+func parsePortForwardPorts(ports string) (uint16, uint16, error) {
+	fields := strings.Split(ports, ":")
+	if len(fields) > 2 {
+		return 0, 0, fmt.Errorf("invalid ports %q", ports)
+	}
+
+	var parsed []uint16
+	for _, f := range fields {
+		p, err := strconv.ParseUint(f, 10, 16)
+		if err != nil || p == 0 {
+			return 0, 0, fmt.Errorf("invalid port %q", f)
+		}
+		parsed = append(parsed, uint16(p))
+	}
+
+	return parsed[0], parsed[len(parsed)-1], nil
+}
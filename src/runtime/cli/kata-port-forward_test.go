@@ -0,0 +1,45 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePortForwardPorts(t *testing.T) {
+	assert := assert.New(t)
+
+	type testData struct {
+		ports     string
+		localPort uint16
+		port      uint16
+		expectErr bool
+	}
+
+	data := []testData{
+		{"8080", 8080, 8080, false},
+		{"9090:80", 9090, 80, false},
+		{"", 0, 0, true},
+		{"0", 0, 0, true},
+		{"foo:80", 0, 0, true},
+		{"8080:65536", 0, 0, true},
+		{"1:2:3", 0, 0, true},
+	}
+
+	for _, d := range data {
+		localPort, port, err := parsePortForwardPorts(d.ports)
+		if d.expectErr {
+			assert.Error(err, "ports %q", d.ports)
+			continue
+		}
+
+		assert.NoError(err, "ports %q", d.ports)
+		assert.Equal(d.localPort, localPort)
+		assert.Equal(d.port, port)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/urfave/cli"
+)
+
+var stateSubCmds = []cli.Command{
+	dumpStateCommand,
+	restoreStateCommand,
+}
+
+var kataStateCLICommand = cli.Command{
+	Name:        "state",
+	Usage:       "back up and restore sandbox persist data",
+	Subcommands: stateSubCmds,
+	Action: func(context *cli.Context) {
+		cli.ShowSubcommandHelp(context)
+	},
+}
+
+var dumpStateCommand = cli.Command{
+	Name:      "dump",
+	Usage:     "dump the persist data of a sandbox",
+	ArgsUsage: "<sandbox id>",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "write the dump to `FILE` instead of stdout",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		sandboxID := context.Args().Get(0)
+
+		if err := katautils.VerifyContainerID(sandboxID); err != nil {
+			return err
+		}
+
+		driver, err := persist.GetDriver()
+		if err != nil {
+			return err
+		}
+
+		data, err := persist.DumpSandbox(driver, sandboxID)
+		if err != nil {
+			return err
+		}
+
+		if output := context.String("output"); output != "" {
+			return ioutil.WriteFile(output, data, 0600)
+		}
+
+		_, err = fmt.Fprintf(os.Stdout, "%s\n", data)
+		return err
+	},
+}
+
+var restoreStateCommand = cli.Command{
+	Name:      "restore",
+	Usage:     "restore the persist data of a sandbox from a dump",
+	ArgsUsage: "<dump file>",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "overwrite existing persist data of the sandbox",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		file := context.Args().Get(0)
+		if file == "" {
+			return fmt.Errorf("missing dump file")
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		driver, err := persist.GetDriver()
+		if err != nil {
+			return err
+		}
+
+		sandboxID, err := persist.RestoreSandbox(driver, data, context.Bool("force"), katautils.VerifyContainerID)
+		if err != nil {
+			return err
+		}
+
+		kataLog.WithField("sandbox", sandboxID).Info("sandbox persist data restored")
+		return nil
+	},
+}
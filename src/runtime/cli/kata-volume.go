@@ -0,0 +1,168 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	volume "github.com/kata-containers/kata-containers/src/runtime/pkg/direct-volume"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	"github.com/urfave/cli"
+)
+
+var volumeSubCmds = []cli.Command{
+	addVolumeCommand,
+	removeVolumeCommand,
+	volumeStatsCommand,
+	resizeVolumeCommand,
+}
+
+var kataVolumeCLICommand = cli.Command{
+	Name:        "direct-volume",
+	Usage:       "manage the mount info of the direct assigned volumes",
+	Subcommands: volumeSubCmds,
+	Action: func(context *cli.Context) {
+		cli.ShowSubcommandHelp(context)
+	},
+}
+
+var volumePathFlag = cli.StringFlag{
+	Name:  "volume-path",
+	Usage: "the target `PATH` of the volume",
+}
+
+var addVolumeCommand = cli.Command{
+	Name:  "add",
+	Usage: "add the mount info of a direct assigned volume, replacing any previous one",
+	Flags: []cli.Flag{
+		volumePathFlag,
+		cli.StringFlag{
+			Name:  "mount-info",
+			Usage: "the mount info of the volume in `JSON`, with volume_type (block, file or spdk), device, fstype, and optional metadata and options",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		volumePath, err := requiredFlag(context, "volume-path")
+		if err != nil {
+			return err
+		}
+		data, err := requiredFlag(context, "mount-info")
+		if err != nil {
+			return err
+		}
+
+		mountInfo, err := volume.ParseMountInfo([]byte(data))
+		if err != nil {
+			return err
+		}
+
+		return volume.Add(volumePath, mountInfo)
+	},
+}
+
+var removeVolumeCommand = cli.Command{
+	Name:  "remove",
+	Usage: "remove the mount info of a direct assigned volume",
+	Flags: []cli.Flag{
+		volumePathFlag,
+	},
+	Action: func(context *cli.Context) error {
+		volumePath, err := requiredFlag(context, "volume-path")
+		if err != nil {
+			return err
+		}
+
+		return volume.Remove(volumePath)
+	},
+}
+
+var volumeStatsCommand = cli.Command{
+	Name:  "stats",
+	Usage: "show the mount info of a direct assigned volume and the size of its device",
+	Flags: []cli.Flag{
+		volumePathFlag,
+	},
+	Action: func(context *cli.Context) error {
+		volumePath, err := requiredFlag(context, "volume-path")
+		if err != nil {
+			return err
+		}
+
+		stats, err := volume.Stats(volumePath)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	},
+}
+
+var resizeVolumeCommand = cli.Command{
+	Name:  "resize",
+	Usage: "grow a direct assigned volume, and its filesystem in the sandbox using it",
+	Flags: []cli.Flag{
+		volumePathFlag,
+		cli.Int64Flag{
+			Name:  "size",
+			Usage: "the new `SIZE` of the volume in bytes",
+		},
+	},
+	Action: func(context *cli.Context) error {
+		volumePath, err := requiredFlag(context, "volume-path")
+		if err != nil {
+			return err
+		}
+
+		size := context.Int64("size")
+		if size <= 0 {
+			return fmt.Errorf("missing or invalid size")
+		}
+
+		mountInfo, err := volume.VolumeMountInfo(volumePath)
+		if err != nil {
+			return err
+		}
+
+		// The devices of the other volumes are grown by their storage
+		// backend, before the command is run.
+		if mountInfo.VolumeType == volume.FileVolumeType {
+			if err := volume.Resize(volumePath, size); err != nil {
+				return err
+			}
+		}
+
+		// The volume is mounted with its new size by the next sandbox
+		// when none is running with it.
+		sandboxID, err := volume.SandboxID(volumePath)
+		if errors.Is(err, volume.ErrNoSandbox) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		client := sandboxapi.NewClient(sandboxID, 0)
+		if !client.IsAlive() {
+			return nil
+		}
+
+		return client.ResizeVolume(volumePath, uint64(size))
+	},
+}
+
+func requiredFlag(context *cli.Context, name string) (string, error) {
+	value := context.String(name)
+	if value == "" {
+		return "", fmt.Errorf("missing --%s", name)
+	}
+
+	return value, nil
+}
@@ -120,10 +120,18 @@ var runtimeCommands = []cli.Command{
 	versionCLICommand,
 
 	// Kata Containers specific extensions
+	kataBootTimesCLICommand,
 	kataCheckCLICommand,
+	kataDebugCLICommand,
 	kataEnvCLICommand,
+	kataEventsCLICommand,
 	kataExecCLICommand,
+	kataGCCLICommand,
 	kataMetricsCLICommand,
+	kataNetworkCLICommand,
+	kataPortForwardCLICommand,
+	kataStateCLICommand,
+	kataVolumeCLICommand,
 	factoryCLICommand,
 }
 
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var logFile = flag.String("log-file", "", "File to write the agent logs to as JSON lines (default: send them to the journal).")
+var logMaxSize = flag.Int64("log-max-size", 100, "Size in MiB the log file is rotated at (0 disables the rotation).")
+var logMaxFiles = flag.Int("log-max-files", 5, "Number of rotated log files kept.")
+var journalAddr = flag.String("journal-socket", journalSocket, "Socket of journald, when -log-file is not set.")
+var scanInterval = flag.Duration("interval", 5*time.Second, "Interval between the lookups of new sandboxes and the connection attempts to their agent.")
+var dialTimeout = flag.Duration("dial-timeout", 5*time.Second, "Timeout of the connections to the agent log port.")
+var logLevel = flag.String("log-level", "info", "Log level of logrus(trace/debug/info/warn/error/fatal/panic).")
+
+// These values are overridden via ldflags
+var (
+	appName = "kata-agent-proxy"
+	// version is the kata-agent-proxy version.
+	version = "0.1.0"
+
+	GitCommit = "unknown-commit"
+)
+
+func newSink() (logSink, error) {
+	if *logFile != "" {
+		return newFileSink(*logFile, *logMaxSize*1024*1024, *logMaxFiles)
+	}
+
+	return newJournalSink(*journalAddr)
+}
+
+func main() {
+	if len(os.Args) == 2 && (os.Args[1] == "--version" || os.Args[1] == "version") {
+		fmt.Printf("%s\n Version:\t%s\n Go version:\t%s\n Git commit:\t%s\n OS/Arch:\t%s/%s\n",
+			appName, version, runtime.Version(), GitCommit, runtime.GOOS, runtime.GOARCH)
+		return
+	}
+
+	flag.Parse()
+
+	level, err := logrus.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: invalid log level %q\n", appName, *logLevel)
+		os.Exit(1)
+	}
+	logrus.SetLevel(level)
+	logrus.SetFormatter(&logrus.TextFormatter{TimestampFormat: time.RFC3339Nano})
+
+	logrus.WithFields(logrus.Fields{
+		"app":           appName,
+		"version":       version,
+		"git-commit":    GitCommit,
+		"log-file":      *logFile,
+		"log-max-size":  *logMaxSize,
+		"log-max-files": *logMaxFiles,
+		"interval":      *scanInterval,
+	}).Info("announce")
+
+	sink, err := newSink()
+	if err != nil {
+		logrus.WithError(err).Fatal("failed to open the agent logs output")
+	}
+	defer sink.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigCh
+		logrus.WithField("signal", sig).Info("stopping")
+		cancel()
+	}()
+
+	newAgentLogProxy(sink, *scanInterval, *dialTimeout).run(ctx)
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	kataclient "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/client"
+	"github.com/sirupsen/logrus"
+)
+
+// agentLogPort is the vsock port the agent writes its logs to, set by the
+// runtime in the agent.log_vport kernel parameter when enable_log_proxy is
+// on.
+const agentLogPort = 1025
+
+// Annotations holding the name and namespace of the pod of a sandbox
+var (
+	podNameAnnotations      = []string{"io.kubernetes.cri.sandbox-name", "io.kubernetes.pod.name"}
+	podNamespaceAnnotations = []string{"io.kubernetes.cri.sandbox-namespace", "io.kubernetes.pod.namespace"}
+)
+
+// sandboxInfo is what the proxy needs to know of a sandbox to collect and
+// decorate the logs of its agent.
+type sandboxInfo struct {
+	id           string
+	agentURL     string
+	podName      string
+	podNamespace string
+}
+
+func firstAnnotation(annotations map[string]string, keys []string) string {
+	for _, k := range keys {
+		if v := annotations[k]; v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// getSandboxInfo reads the details of a sandbox from its persisted state.
+func getSandboxInfo(sandboxID string) (sandboxInfo, error) {
+	state, _, err := sandboxapi.SandboxState(sandboxID)
+	if err != nil {
+		return sandboxInfo{}, err
+	}
+
+	info := sandboxInfo{
+		id:       sandboxID,
+		agentURL: state.AgentState.URL,
+	}
+
+	// The pod annotations are the ones of the sandbox container
+	for _, c := range state.Config.ContainerConfigs {
+		if c.ID == sandboxID {
+			info.podName = firstAnnotation(c.Annotations, podNameAnnotations)
+			info.podNamespace = firstAnnotation(c.Annotations, podNamespaceAnnotations)
+		}
+	}
+
+	return info, nil
+}
+
+// logRecord is a structured log line of an agent.
+type logRecord map[string]interface{}
+
+// decorate parses a log line of the agent of a sandbox and adds the sandbox
+// and pod details to it. The lines which are not JSON objects, e.g. printed
+// before the agent logger is set up, are kept as the message of the record.
+func decorate(line []byte, info sandboxInfo) logRecord {
+	var rec logRecord
+	if err := json.Unmarshal(line, &rec); err != nil || rec == nil {
+		rec = logRecord{
+			"msg":    string(bytes.TrimRight(line, "\r\n")),
+			"source": "agent",
+		}
+	}
+
+	rec["sandbox"] = info.id
+	if info.podName != "" {
+		rec["pod"] = info.podName
+	}
+	if info.podNamespace != "" {
+		rec["namespace"] = info.podNamespace
+	}
+
+	return rec
+}
+
+// agentLogProxy collects the logs of the agents of all the sandboxes of the
+// node into a single sink.
+type agentLogProxy struct {
+	sync.Mutex
+	wg sync.WaitGroup
+
+	sink        logSink
+	interval    time.Duration
+	dialTimeout time.Duration
+
+	// followers are the cancel functions of the sandboxes being followed
+	followers map[string]context.CancelFunc
+
+	// Overridden by the tests
+	listSandboxes  func() ([]string, error)
+	getSandboxInfo func(string) (sandboxInfo, error)
+	dial           func(string, uint32, time.Duration) (net.Conn, error)
+}
+
+func newAgentLogProxy(sink logSink, interval, dialTimeout time.Duration) *agentLogProxy {
+	return &agentLogProxy{
+		sink:           sink,
+		interval:       interval,
+		dialTimeout:    dialTimeout,
+		followers:      make(map[string]context.CancelFunc),
+		listSandboxes:  sandboxapi.ListSandboxes,
+		getSandboxInfo: getSandboxInfo,
+		dial:           kataclient.AgentPortDialer,
+	}
+}
+
+// run looks for new sandboxes every interval until ctx is done, then waits
+// for the sandboxes being followed to be released.
+func (p *agentLogProxy) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.scan(ctx)
+
+		select {
+		case <-ctx.Done():
+			p.wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan starts following the new sandboxes and stops following the ones
+// which no longer exist.
+func (p *agentLogProxy) scan(ctx context.Context) {
+	ids, err := p.listSandboxes()
+	if err != nil {
+		logrus.WithError(err).Warn("failed to list the sandboxes")
+		return
+	}
+
+	found := make(map[string]bool, len(ids))
+
+	p.Lock()
+	defer p.Unlock()
+
+	for _, id := range ids {
+		found[id] = true
+		if _, ok := p.followers[id]; ok {
+			continue
+		}
+
+		fctx, cancel := context.WithCancel(ctx)
+		p.followers[id] = cancel
+		p.wg.Add(1)
+		go p.follow(fctx, id)
+	}
+
+	for id, cancel := range p.followers {
+		if !found[id] {
+			cancel()
+		}
+	}
+}
+
+func (p *agentLogProxy) release(id string) {
+	p.Lock()
+	defer p.Unlock()
+
+	if cancel, ok := p.followers[id]; ok {
+		cancel()
+		delete(p.followers, id)
+	}
+}
+
+// follow collects the logs of the agent of a sandbox until ctx is done or
+// the state of the sandbox is gone, connecting again every interval while
+// the agent cannot be reached.
+func (p *agentLogProxy) follow(ctx context.Context, id string) {
+	defer p.wg.Done()
+	defer p.release(id)
+
+	log := logrus.WithField("sandbox", id)
+
+	for {
+		info, err := p.getSandboxInfo(id)
+		if err != nil {
+			log.WithError(err).Debug("sandbox state not available, releasing it")
+			return
+		}
+
+		if info.agentURL != "" {
+			if conn, err := p.dial(info.agentURL, agentLogPort, p.dialTimeout); err != nil {
+				log.WithError(err).Debug("failed to connect to the agent log port")
+			} else {
+				log.WithField("agent-url", info.agentURL).Info("collecting the agent logs")
+				p.copyLogs(ctx, conn, info)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// copyLogs writes the decorated log lines read from conn to the sink, until
+// the connection is closed or ctx is done.
+func (p *agentLogProxy) copyLogs(ctx context.Context, conn net.Conn, info sandboxInfo) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if werr := p.sink.write(decorate(line, info)); werr != nil {
+				logrus.WithError(werr).WithField("sandbox", info.id).Warn("failed to write an agent log")
+			}
+		}
+
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				logrus.WithError(err).WithField("sandbox", info.id).Debug("agent log connection closed")
+			}
+			return
+		}
+	}
+}
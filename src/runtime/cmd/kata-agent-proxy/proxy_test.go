@@ -0,0 +1,222 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memorySink struct {
+	sync.Mutex
+	records []logRecord
+}
+
+func (s *memorySink) write(rec logRecord) error {
+	s.Lock()
+	defer s.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func (s *memorySink) close() error {
+	return nil
+}
+
+func (s *memorySink) get() []logRecord {
+	s.Lock()
+	defer s.Unlock()
+	return append([]logRecord{}, s.records...)
+}
+
+func TestDecorate(t *testing.T) {
+	assert := assert.New(t)
+
+	info := sandboxInfo{id: "foo", podName: "nginx", podNamespace: "default"}
+
+	rec := decorate([]byte(`{"msg":"hello","level":"INFO","subsystem":"rpc","sandbox":"bar"}`+"\n"), info)
+	assert.Equal(logRecord{
+		"msg":       "hello",
+		"level":     "INFO",
+		"subsystem": "rpc",
+		"sandbox":   "foo",
+		"pod":       "nginx",
+		"namespace": "default",
+	}, rec)
+
+	for _, line := range []string{"not json\r\n", "null\n", "[1]\n"} {
+		rec = decorate([]byte(line), sandboxInfo{id: "foo"})
+		assert.Equal(logRecord{
+			"msg":     strings.TrimRight(line, "\r\n"),
+			"source":  "agent",
+			"sandbox": "foo",
+		}, rec)
+	}
+
+	assert.Equal("nginx", firstAnnotation(map[string]string{"io.kubernetes.pod.name": "nginx"}, podNameAnnotations))
+	assert.Equal("", firstAnnotation(nil, podNameAnnotations))
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-agent-proxy")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "agent.log")
+	sink, err := newFileSink(path, 40, 2)
+	assert.NoError(err)
+
+	// each record is 34 bytes long, one per file
+	for _, msg := range []string{"aaaaaaaaaaaaaaaaa", "bbbbbbbbbbbbbbbbb", "ccccccccccccccccc", "ddddddddddddddddd"} {
+		assert.NoError(sink.write(logRecord{"msg": msg, "a": 1}))
+	}
+	assert.NoError(sink.close())
+
+	for file, msg := range map[string]string{
+		path:        "ddddddddddddddddd",
+		path + ".1": "ccccccccccccccccc",
+		path + ".2": "bbbbbbbbbbbbbbbbb",
+	} {
+		data, err := ioutil.ReadFile(file)
+		assert.NoError(err)
+		assert.Equal(`{"a":1,"msg":"`+msg+`"}`+"\n", string(data))
+	}
+
+	_, err = os.Stat(path + ".3")
+	assert.True(os.IsNotExist(err))
+
+	// The size of an existing file is accounted for
+	sink, err = newFileSink(path, 40, 0)
+	assert.NoError(err)
+	assert.NoError(sink.write(logRecord{"msg": "e"}))
+	assert.NoError(sink.close())
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(err)
+	assert.Equal(`{"msg":"e"}`+"\n", string(data))
+}
+
+func TestJournalEntry(t *testing.T) {
+	assert := assert.New(t)
+
+	entry := journalEntry(logRecord{
+		"msg":       "hello",
+		"level":     "WARN",
+		"sandbox":   "foo",
+		"pid":       float64(1),
+		"pod-name":  "multi\nline",
+		"subsystem": "rpc",
+	})
+
+	var expected bytes.Buffer
+	expected.WriteString("MESSAGE=hello\nPRIORITY=4\nSYSLOG_IDENTIFIER=kata-agent\nKATA_LEVEL=WARN\nKATA_PID=1\nKATA_POD_NAME\n")
+	binary.Write(&expected, binary.LittleEndian, uint64(10))
+	expected.WriteString("multi\nline\nKATA_SANDBOX=foo\nKATA_SUBSYSTEM=rpc\n")
+	assert.Equal(expected.String(), string(entry))
+
+	assert.Contains(string(journalEntry(logRecord{"level": "unknown"})), "PRIORITY=6\n")
+	assert.Equal(64, len(journalFieldName(strings.Repeat("a", 100))))
+}
+
+func TestAgentLogProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-agent-proxy")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	sock := filepath.Join(dir, "agent.sock")
+	l, err := net.Listen("unix", sock)
+	assert.NoError(err)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte(`{"msg":"agent started","level":"INFO"}` + "\nboot message\n"))
+		conn.Close()
+	}()
+
+	var lock sync.Mutex
+	sandboxes := map[string]sandboxInfo{
+		"foo": {id: "foo", agentURL: "mock://" + sock, podName: "nginx"},
+		"bar": {id: "bar"},
+	}
+
+	sink := &memorySink{}
+	p := newAgentLogProxy(sink, 10*time.Millisecond, time.Second)
+	p.listSandboxes = func() ([]string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		ids := []string{}
+		for id := range sandboxes {
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+	p.getSandboxInfo = func(id string) (sandboxInfo, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		info, ok := sandboxes[id]
+		if !ok {
+			return sandboxInfo{}, errors.New("no such sandbox")
+		}
+		return info, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(func() bool { return len(sink.get()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal([]logRecord{
+		{"msg": "agent started", "level": "INFO", "sandbox": "foo", "pod": "nginx"},
+		{"msg": "boot message", "source": "agent", "sandbox": "foo", "pod": "nginx"},
+	}, sink.get())
+
+	// The sandboxes which are gone are released
+	lock.Lock()
+	delete(sandboxes, "foo")
+	lock.Unlock()
+
+	assert.Eventually(func() bool {
+		p.Lock()
+		defer p.Unlock()
+		_, ok := p.followers["foo"]
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	p.Lock()
+	_, ok := p.followers["bar"]
+	p.Unlock()
+	assert.True(ok)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the proxy did not stop")
+	}
+}
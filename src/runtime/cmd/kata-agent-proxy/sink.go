@@ -0,0 +1,232 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// logSink is where the proxy writes the agent logs.
+type logSink interface {
+	write(rec logRecord) error
+	close() error
+}
+
+// fileSink writes the records as JSON lines to a file, rotated once it
+// reaches maxSize bytes: the file is renamed with the ".1" suffix, the
+// previous ".1" becoming ".2" and so on up to maxFiles rotated files.
+type fileSink struct {
+	sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+func newFileSink(path string, maxSize int64, maxFiles int) (*fileSink, error) {
+	s := &fileSink{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *fileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	if s.maxFiles > 0 {
+		for i := s.maxFiles - 1; i > 0; i-- {
+			if err := os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(s.path, s.path+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(s.path); err != nil {
+		return err
+	}
+
+	return s.open()
+}
+
+func (s *fileSink) write(rec logRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileSink) close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.file.Close()
+}
+
+const journalSocket = "/run/systemd/journal/socket"
+
+// journalSink sends the records to journald with its native protocol, the
+// message and level of the agent records becoming the MESSAGE and PRIORITY
+// of the journal entries and their other fields the KATA_* fields.
+type journalSink struct {
+	conn *net.UnixConn
+}
+
+func newJournalSink(socket string) (*journalSink, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &journalSink{conn}, nil
+}
+
+// journalPriority maps the agent log levels, in their short and long forms,
+// to the syslog priorities.
+var journalPriority = map[string]string{
+	"crit":     "2",
+	"critical": "2",
+	"erro":     "3",
+	"error":    "3",
+	"warn":     "4",
+	"warning":  "4",
+	"info":     "6",
+	"debg":     "7",
+	"debug":    "7",
+	"trce":     "7",
+	"trace":    "7",
+}
+
+// journalFieldName turns a record key into a valid journal field name,
+// made of upper case letters, digits and underscores.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, key)
+
+	name = "KATA_" + name
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+func appendJournalField(buf *bytes.Buffer, name, value string) {
+	if !strings.Contains(value, "\n") {
+		fmt.Fprintf(buf, "%s=%s\n", name, value)
+		return
+	}
+
+	// Multi-line values are sent with their size
+	buf.WriteString(name)
+	buf.WriteByte('\n')
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalEntry encodes a record in the journal native protocol.
+func journalEntry(rec logRecord) []byte {
+	var buf bytes.Buffer
+
+	priority := "6"
+	if level, ok := rec["level"].(string); ok {
+		if p, ok := journalPriority[strings.ToLower(level)]; ok {
+			priority = p
+		}
+	}
+
+	msg, _ := rec["msg"].(string)
+	appendJournalField(&buf, "MESSAGE", msg)
+	appendJournalField(&buf, "PRIORITY", priority)
+	appendJournalField(&buf, "SYSLOG_IDENTIFIER", "kata-agent")
+
+	keys := make([]string, 0, len(rec))
+	for k := range rec {
+		if k != "msg" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		var value string
+		switch v := rec[k].(type) {
+		case string:
+			value = v
+		default:
+			data, _ := json.Marshal(v)
+			value = string(data)
+		}
+		appendJournalField(&buf, journalFieldName(k), value)
+	}
+
+	return buf.Bytes()
+}
+
+func (s *journalSink) write(rec logRecord) error {
+	_, err := s.conn.Write(journalEntry(rec))
+	return err
+}
+
+func (s *journalSink) close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+)
+
+func init() {
+	registerPlugin(cli.Command{
+		Name:      "labels",
+		Usage:     "show or update the labels of a sandbox, or list the sandboxes having some labels",
+		ArgsUsage: "<sandbox id> [<key>=<value> | <key>-]...",
+		Description: `Sets the <key>=<value> labels and removes the <key>- ones, then
+   shows the labels of the sandbox. With --selector, lists the sandboxes of
+   the host having all the labels of the selector.`,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "selector, l",
+				Usage: "comma separated <key>=<value> labels the listed sandboxes have",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.IsSet("selector") {
+				selector, err := parseLabels(strings.Split(c.String("selector"), ","))
+				if err != nil {
+					return err
+				}
+
+				ids, err := sandboxapi.ListSandboxesByLabels(selector)
+				if err != nil {
+					return err
+				}
+				for _, id := range ids {
+					fmt.Println(id)
+				}
+				return nil
+			}
+
+			client, err := sandboxClient(c)
+			if err != nil {
+				return err
+			}
+
+			update, err := parseLabelsUpdate(c.Args().Tail())
+			if err != nil {
+				return err
+			}
+
+			var labels map[string]string
+			switch {
+			case len(update.Set) > 0 || len(update.Remove) > 0:
+				labels, err = client.UpdateLabels(update)
+			case client.IsAlive():
+				labels, err = client.Labels()
+			default:
+				// The labels of a sandbox whose shim is gone are
+				// read from its persisted state.
+				labels, err = persistedLabels(client.SandboxID())
+			}
+			if err != nil {
+				return err
+			}
+
+			printLabels(labels)
+			return nil
+		},
+	})
+}
+
+// persistedLabels returns the labels of the persisted state of a sandbox.
+func persistedLabels(sandboxID string) (map[string]string, error) {
+	ss, _, err := sandboxapi.SandboxState(sandboxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.Labels, nil
+}
+
+// parseLabels parses <key>=<value> labels.
+func parseLabels(args []string) (map[string]string, error) {
+	labels := make(map[string]string, len(args))
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid label %q, expected <key>=<value>", arg)
+		}
+		labels[kv[0]] = kv[1]
+	}
+
+	return labels, nil
+}
+
+// parseLabelsUpdate parses the labels to set, <key>=<value>, and the ones to
+// remove, <key>-.
+func parseLabelsUpdate(args []string) (sandboxapi.LabelsUpdate, error) {
+	var update sandboxapi.LabelsUpdate
+	var set []string
+
+	for _, arg := range args {
+		if !strings.Contains(arg, "=") && strings.HasSuffix(arg, "-") && len(arg) > 1 {
+			update.Remove = append(update.Remove, strings.TrimSuffix(arg, "-"))
+			continue
+		}
+		set = append(set, arg)
+	}
+
+	if len(set) > 0 {
+		labels, err := parseLabels(set)
+		if err != nil {
+			return update, err
+		}
+		update.Set = labels
+	}
+
+	return update, nil
+}
+
+func printLabels(labels map[string]string) {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, labels[key])
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+)
+
+func TestParseLabelsUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	update, err := parseLabelsUpdate(nil)
+	assert.NoError(err)
+	assert.Equal(sandboxapi.LabelsUpdate{}, update)
+
+	update, err = parseLabelsUpdate([]string{"tenant=acme", "team-", "example.com/billing-code=4-2", "empty="})
+	assert.NoError(err)
+	assert.Equal(sandboxapi.LabelsUpdate{
+		Set:    map[string]string{"tenant": "acme", "example.com/billing-code": "4-2", "empty": ""},
+		Remove: []string{"team"},
+	}, update)
+
+	for _, arg := range []string{"tenant", "-", "=acme"} {
+		_, err = parseLabelsUpdate([]string{arg})
+		assert.Error(err, arg)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/urfave/cli"
+)
+
+// These values are overridden via ldflags
+var (
+	appName = "kata-ctl"
+	// version is the kata-ctl version.
+	version = "0.1.0"
+
+	// runtimeName is the name of the runtime binary implementing the
+	// subcommands not yet moved to kata-ctl.
+	runtimeName = "kata-runtime"
+
+	GitCommit = "unknown-commit"
+)
+
+const configFlag = "config"
+
+var ctlFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "config, kata-config",
+		Usage: "Kata Containers config file path",
+	},
+}
+
+// plugins are the kata-ctl subcommands, registered by the files
+// implementing them.
+var plugins []cli.Command
+
+// registerPlugin adds cmd to the kata-ctl subcommands.
+func registerPlugin(cmd cli.Command) {
+	plugins = append(plugins, cmd)
+}
+
+func newApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = appName
+	app.Usage = "Kata Containers management tool"
+	app.Version = fmt.Sprintf("%s (commit %s)", version, GitCommit)
+	app.Flags = ctlFlags
+
+	app.Commands = append([]cli.Command{}, plugins...)
+	sort.Slice(app.Commands, func(i, j int) bool {
+		return app.Commands[i].Name < app.Commands[j].Name
+	})
+
+	// The subcommands not built into kata-ctl are external plugins.
+	app.Action = func(c *cli.Context) error {
+		if !c.Args().Present() {
+			return cli.ShowAppHelp(c)
+		}
+		return runExternalPlugin(c, c.Args().First(), c.Args().Tail())
+	}
+
+	return app
+}
+
+func main() {
+	if err := newApp().Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", appName, err)
+		os.Exit(1)
+	}
+}
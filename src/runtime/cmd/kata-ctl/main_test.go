@@ -0,0 +1,31 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAppCommands(t *testing.T) {
+	assert := assert.New(t)
+
+	var names []string
+	for _, cmd := range newApp().Commands {
+		names = append(names, cmd.Name)
+	}
+
+	assert.Equal([]string{"check", "env", "exec", "factory", "labels", "metrics", "reload-config"}, names)
+}
+
+func TestRuntimeArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{runtimeName, "env", "--json"}, runtimeArgs("", "env", []string{"--json"}))
+	assert.Equal([]string{runtimeName, "--config", "/etc/kata.toml", "exec", "sandbox"},
+		runtimeArgs("/etc/kata.toml", "exec", []string{"sandbox"}))
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+)
+
+func init() {
+	registerPlugin(cli.Command{
+		Name:      "metrics",
+		Usage:     "gather metrics associated with infrastructure used to run a sandbox",
+		ArgsUsage: "<sandbox id>",
+		Action: func(c *cli.Context) error {
+			client, err := sandboxClient(c)
+			if err != nil {
+				return err
+			}
+
+			metrics, err := client.Metrics()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%s\n", metrics)
+			return nil
+		},
+	})
+}
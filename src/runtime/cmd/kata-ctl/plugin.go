@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	"github.com/urfave/cli"
+)
+
+const (
+	// externalPluginPrefix is the prefix of the binaries implementing the
+	// external kata-ctl subcommands, e.g. kata-ctl-foo for "kata-ctl foo".
+	externalPluginPrefix = "kata-ctl-"
+
+	// configFileEnv is the environment variable set to the resolved
+	// configuration file path for the external plugins.
+	configFileEnv = "KATA_CONF_FILE"
+
+	defaultTimeout = 3 * time.Second
+)
+
+// loadConfiguration loads the configuration file selected with the global
+// --config option, or the default one, and returns its resolved path.
+func loadConfiguration(c *cli.Context) (string, error) {
+	path, _, err := katautils.LoadConfiguration(c.GlobalString(configFlag), true)
+	return path, err
+}
+
+// sandboxClient returns the client of the shim management socket of the
+// sandbox given as the first argument of the subcommand.
+func sandboxClient(c *cli.Context) (*sandboxapi.Client, error) {
+	sandboxID := c.Args().First()
+	if err := katautils.VerifyContainerID(sandboxID); err != nil {
+		return nil, err
+	}
+
+	return sandboxapi.NewClient(sandboxID, defaultTimeout), nil
+}
+
+// lookPath returns the path of the binary name, preferably the one
+// installed next to kata-ctl.
+func lookPath(name string) (string, error) {
+	if self, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(self), name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return exec.LookPath(name)
+}
+
+// runExternalPlugin replaces kata-ctl with the external plugin implementing
+// the subcommand name, passing it args and the resolved configuration file
+// path in KATA_CONF_FILE.
+func runExternalPlugin(c *cli.Context, name string, args []string) error {
+	path, err := lookPath(externalPluginPrefix + name)
+	if err != nil {
+		return fmt.Errorf("unknown command %q", name)
+	}
+
+	env := os.Environ()
+	if config, err := loadConfiguration(c); err == nil {
+		env = append(env, configFileEnv+"="+config)
+	}
+
+	return syscall.Exec(path, append([]string{filepath.Base(path)}, args...), env)
+}
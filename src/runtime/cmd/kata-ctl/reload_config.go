@@ -0,0 +1,40 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+func init() {
+	registerPlugin(cli.Command{
+		Name:      "reload-config",
+		Usage:     "reload the settings of the configuration file that can change while a sandbox runs",
+		ArgsUsage: "<sandbox id>",
+		Action: func(c *cli.Context) error {
+			client, err := sandboxClient(c)
+			if err != nil {
+				return err
+			}
+
+			reload, err := client.ReloadConfig()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("config: %s\n", reload.ConfigPath)
+			fmt.Printf("log level: %s\n", reload.LogLevel)
+			fmt.Printf("pprof: %t\n", reload.Pprof)
+			if len(reload.RestartRequired) > 0 {
+				fmt.Printf("restart required: %s\n", strings.Join(reload.RestartRequired, ", "))
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/urfave/cli"
+)
+
+// runtimeCommands are the subcommands still implemented by the runtime,
+// kata-ctl runs them with its configuration file.
+var runtimeCommands = []cli.Command{
+	{
+		Name:  "check",
+		Usage: "tests if the system can run Kata Containers",
+	},
+	{
+		Name:  "env",
+		Usage: "display settings, the default output format being TOML",
+	},
+	{
+		Name:      "exec",
+		Usage:     "enter into the guest by the debug console",
+		ArgsUsage: "<sandbox id>",
+	},
+	{
+		Name:  "factory",
+		Usage: "manage the vm factory",
+	},
+}
+
+func init() {
+	for _, cmd := range runtimeCommands {
+		name := cmd.Name
+		cmd.SkipFlagParsing = true
+		cmd.Action = func(c *cli.Context) error {
+			return runRuntimeCommand(c, name)
+		}
+		registerPlugin(cmd)
+	}
+}
+
+// runtimeArgs returns the runtime command line running the subcommand name
+// with args and the configuration file config, if any.
+func runtimeArgs(config, name string, args []string) []string {
+	argv := []string{runtimeName}
+	if config != "" {
+		argv = append(argv, "--config", config)
+	}
+
+	return append(append(argv, name), args...)
+}
+
+// runRuntimeCommand replaces kata-ctl with the runtime running the
+// subcommand name.
+func runRuntimeCommand(c *cli.Context, name string) error {
+	path, err := lookPath(runtimeName)
+	if err != nil {
+		return err
+	}
+
+	argv := runtimeArgs(c.GlobalString(configFlag), name, c.Args())
+	argv[0] = filepath.Base(path)
+
+	return syscall.Exec(path, argv, os.Environ())
+}
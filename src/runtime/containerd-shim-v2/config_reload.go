@@ -0,0 +1,132 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+)
+
+// shimLogLevel is the log level requested by containerd, the runtime debug
+// only raises it.
+var shimLogLevel = logrus.InfoLevel
+
+// ConfigReload is the body of /config/reload responses
+type ConfigReload = sandboxapi.ConfigReload
+
+// setLogLevel sets the shim log level, debug when the runtime debug is
+// enabled or the one requested by containerd otherwise.
+func setLogLevel(debug bool) logrus.Level {
+	level := shimLogLevel
+	if debug && level < logrus.DebugLevel {
+		level = logrus.DebugLevel
+	}
+	logrus.SetLevel(level)
+
+	return level
+}
+
+// reloadConfig reads the configuration file of the sandbox again and
+// applies the settings that can change while the sandbox runs, the runtime
+// debug and the pprof endpoints. The other settings the sandbox was
+// started with are kept, the changes of those the guest was booted with
+// are reported.
+func (s *service) reloadConfig() (ConfigReload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config == nil {
+		return ConfigReload{}, fmt.Errorf("the sandbox is not created yet")
+	}
+
+	// The system logger is already set up, it's not added again.
+	configPath, config, err := katautils.LoadConfiguration(s.configPath, true)
+	// Loading the configuration also switches the tracing, which cannot
+	// change once the tracer is created.
+	katatrace.SetTracing(s.config.Trace)
+	if err != nil {
+		return ConfigReload{}, err
+	}
+
+	s.config.Debug = config.Debug
+	s.config.EnablePprof = config.EnablePprof
+
+	reload := ConfigReload{
+		ConfigPath: configPath,
+		LogLevel:   setLogLevel(config.Debug).String(),
+		Pprof:      s.config.EnablePprof || s.pprofAnnotation,
+	}
+
+	if config.AgentConfig.EnableDebugConsole != s.config.AgentConfig.EnableDebugConsole {
+		reload.RestartRequired = append(reload.RestartRequired, "agent.debug_console_enabled")
+	}
+	if config.AgentConfig.Debug != s.config.AgentConfig.Debug {
+		reload.RestartRequired = append(reload.RestartRequired, "agent.enable_debug")
+	}
+	if config.HypervisorConfig.Debug != s.config.HypervisorConfig.Debug {
+		reload.RestartRequired = append(reload.RestartRequired, "hypervisor.enable_debug")
+	}
+
+	shimLog.WithFields(logrus.Fields{
+		"config":           reload.ConfigPath,
+		"log-level":        reload.LogLevel,
+		"pprof":            reload.Pprof,
+		"restart-required": reload.RestartRequired,
+	}).Info("configuration reloaded")
+
+	return reload, nil
+}
+
+// handleReloadSignals reloads the configuration when the shim receives
+// SIGHUP, until ctx is done.
+func (s *service) handleReloadSignals(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			if _, err := s.reloadConfig(); err != nil {
+				shimLog.WithError(err).Error("failed to reload the configuration")
+			}
+		}
+	}
+}
+
+// serveConfigReload handles /config/reload requests, the shim reads its
+// configuration file again and returns the settings in effect.
+func (s *service) serveConfigReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	reload, err := s.reloadConfig()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(reload); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode the configuration reload")
+	}
+}
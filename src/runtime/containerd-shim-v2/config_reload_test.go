@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+)
+
+func TestReloadConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	savedLevel, savedShimLevel := logrus.GetLevel(), shimLogLevel
+	defer func() {
+		logrus.SetLevel(savedLevel)
+		shimLogLevel = savedShimLevel
+	}()
+	shimLogLevel = logrus.WarnLevel
+
+	orgVHostVSockDevicePath := utils.VHostVSockDevicePath
+	defer func() {
+		utils.VHostVSockDevicePath = orgVHostVSockDevicePath
+	}()
+	utils.VHostVSockDevicePath = "/dev/null"
+
+	s := &service{
+		id:         testSandboxID,
+		containers: make(map[string]*container),
+	}
+
+	// No configuration is loaded before the sandbox is created
+	rr := httptest.NewRecorder()
+	s.serveConfigReload(rr, httptest.NewRequest(http.MethodPost, "/config/reload", nil))
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+
+	tmpdir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	configPath, err := createAllRuntimeConfigFiles(tmpdir, "qemu")
+	assert.NoError(err)
+
+	_, config, err := katautils.LoadConfiguration(configPath, true)
+	assert.NoError(err)
+	s.config = &config
+	s.configPath = configPath
+
+	m := http.NewServeMux()
+	s.mountPprofHandle(m, &specs.Spec{})
+	pprofCode := func() int {
+		rr := httptest.NewRecorder()
+		m.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+		return rr.Code
+	}
+	assert.Equal(http.StatusNotFound, pprofCode())
+
+	// Enable the runtime and agent debug and pprof
+	data, err := ioutil.ReadFile(configPath)
+	assert.NoError(err)
+	content := strings.NewReplacer(
+		"[runtime]\n\tenable_debug = false", "[runtime]\n\tenable_debug = true",
+		"[agent.kata]\n\tenable_debug = false", "[agent.kata]\n\tenable_debug = true",
+		"enable_pprof= false", "enable_pprof= true",
+	).Replace(string(data))
+	assert.NoError(ioutil.WriteFile(configPath, []byte(content), 0640))
+
+	rr = httptest.NewRecorder()
+	s.serveConfigReload(rr, httptest.NewRequest(http.MethodPost, "/config/reload", nil))
+	assert.Equal(http.StatusOK, rr.Code)
+
+	var reload ConfigReload
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &reload))
+	assert.Equal(ConfigReload{
+		ConfigPath:      configPath,
+		LogLevel:        "debug",
+		Pprof:           true,
+		RestartRequired: []string{"agent.enable_debug"},
+	}, reload)
+	assert.Equal(logrus.DebugLevel, logrus.GetLevel())
+	assert.Equal(http.StatusOK, pprofCode())
+
+	// The agent debug is kept until the sandbox restarts
+	assert.False(s.config.AgentConfig.Debug)
+
+	// Disabling the runtime debug restores the containerd log level
+	assert.NoError(ioutil.WriteFile(configPath, data, 0640))
+	reload, err = s.reloadConfig()
+	assert.NoError(err)
+	assert.Equal("warning", reload.LogLevel)
+	assert.False(reload.Pprof)
+	assert.Empty(reload.RestartRequired)
+	assert.Equal(http.StatusNotFound, pprofCode())
+
+	rr = httptest.NewRecorder()
+	s.serveConfigReload(rr, httptest.NewRequest(http.MethodGet, "/config/reload", nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+}
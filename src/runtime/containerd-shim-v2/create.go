@@ -52,6 +52,18 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 		return nil, err
 	}
 
+	// CRI-O passes the pod annotations in a single annotation, resolve the
+	// Kata ones before they are read.
+	var sandboxSpec *specs.Spec
+	if s.sandbox != nil {
+		if c, ok := s.containers[s.sandbox.ID()]; ok {
+			sandboxSpec = c.spec
+		}
+	}
+	if err := oci.AddCRIOAnnotations(ociSpec, sandboxSpec); err != nil {
+		return nil, err
+	}
+
 	disableOutput := noNeedForOutput(detach, ociSpec.Process.Terminal)
 	rootfs := filepath.Join(r.Bundle, "rootfs")
 
@@ -66,6 +78,14 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 			return nil, err
 		}
 
+		// the runtime debug raises the shim log level, as it does when
+		// the configuration is reloaded.
+		setLogLevel(s.config.Debug)
+
+		// the histograms are replaced by the configuration, which must be
+		// done before the sandbox observes them.
+		vc.ConfigureMetrics(s.config.MetricsConfig)
+
 		// create tracer
 		// This is the earliest location we can create the tracer because we must wait
 		// until the runtime config is loaded
@@ -89,6 +109,10 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 		s.ctx = newCtx
 		defer span.End()
 
+		if err = nriCreate(s.ctx, s, r.ID, containerType, ociSpec); err != nil {
+			return nil, err
+		}
+
 		if rootFs.Mounted, err = checkAndMount(s, r); err != nil {
 			return nil, err
 		}
@@ -128,6 +152,10 @@ func create(ctx context.Context, s *service, r *taskAPI.CreateTaskRequest) (*con
 			return nil, fmt.Errorf("BUG: Cannot start the container, since the sandbox hasn't been created")
 		}
 
+		if err = nriCreate(ctx, s, r.ID, containerType, ociSpec); err != nil {
+			return nil, err
+		}
+
 		if rootFs.Mounted, err = checkAndMount(s, r); err != nil {
 			return nil, err
 		}
@@ -196,7 +224,13 @@ func loadRuntimeConfig(s *service, r *taskAPI.CreateTaskRequest, anno map[string
 		configPath = os.Getenv("KATA_CONF_FILE")
 	}
 
-	_, runtimeConfig, err := katautils.LoadConfiguration(configPath, false)
+	// Then from the name of the shim binary, e.g. configuration-qemu.toml
+	// for containerd-shim-kata-qemu-v2
+	if configPath == "" {
+		configPath = katautils.GetShimConfigFile(os.Args[0])
+	}
+
+	resolvedConfigPath, runtimeConfig, err := katautils.LoadConfiguration(configPath, false)
 	if err != nil {
 		return nil, err
 	}
@@ -204,6 +238,7 @@ func loadRuntimeConfig(s *service, r *taskAPI.CreateTaskRequest, anno map[string
 	// For the unit test, the config will be predefined
 	if s.config == nil {
 		s.config = &runtimeConfig
+		s.configPath = resolvedConfigPath
 	}
 
 	return &runtimeConfig, nil
@@ -217,6 +252,11 @@ func checkAndMount(s *service, r *taskAPI.CreateTaskRequest) (bool, error) {
 		if katautils.IsBlockDevice(m.Source) && !s.config.HypervisorConfig.DisableBlockDeviceUse {
 			return false, nil
 		}
+
+		// The layers of the overlay rootfs are plugged as EROFS images.
+		if m.Type == "overlay" && s.config.HypervisorConfig.ErofsLayers {
+			return false, nil
+		}
 	}
 	rootfs := filepath.Join(r.Bundle, "rootfs")
 	if err := doMount(r.Rootfs, rootfs); err != nil {
@@ -27,6 +27,8 @@ func deleteContainer(ctx context.Context, s *service, c *container) error {
 		}
 	}
 
+	nriDelete(ctx, s, c)
+
 	// Run post-stop OCI hooks.
 	if err := katautils.PostStopHooks(ctx, *c.spec, s.sandbox.ID(), c.bundle); err != nil {
 		// log warning and continue, as defined in oci runtime spec
@@ -0,0 +1,55 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// serveGuestDiagnostics handles /guest/diagnostics requests, it returns a
+// snapshot of the state of the guest OS collected by the agent, for the bug
+// reports of the sandboxes without a shell in the guest. The dmesg_lines
+// query sets the length of the kernel log tail.
+func (s *service) serveGuestDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.sandbox == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("the sandbox is not created yet"))
+		return
+	}
+
+	var opts vc.GuestDiagnosticsOptions
+	if value := r.URL.Query().Get("dmesg_lines"); value != "" {
+		lines, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || lines > vc.MaxGuestDiagnosticsDmesgLines {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("invalid dmesg_lines %q, expected up to %d", value, vc.MaxGuestDiagnosticsDmesgLines)))
+			return
+		}
+		opts.DmesgLines = uint32(lines)
+	}
+
+	diagnostics, err := s.sandbox.GuestDiagnostics(r.Context(), opts)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(diagnostics); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode guest diagnostics")
+	}
+}
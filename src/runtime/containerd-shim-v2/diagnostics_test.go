@@ -0,0 +1,73 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestServeGuestDiagnostics(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		id:  testSandboxID,
+		ctx: context.Background(),
+	}
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		s.serveGuestDiagnostics(rr, httptest.NewRequest(method, target, nil))
+		return rr
+	}
+
+	// No sandbox is created yet
+	assert.Equal(http.StatusInternalServerError, serve(http.MethodGet, "/guest/diagnostics").Code)
+
+	var requested []uint32
+	s.sandbox = &vcmock.Sandbox{
+		MockID: testSandboxID,
+		GuestDiagnosticsFunc: func(opts vc.GuestDiagnosticsOptions) (vc.GuestDiagnostics, error) {
+			requested = append(requested, opts.DmesgLines)
+			return vc.GuestDiagnostics{
+				Dmesg:   "[    0.000000] Linux version 5.10.25",
+				MemInfo: "MemTotal: 2048000 kB",
+				Filesystems: []vc.GuestFilesystemUsage{
+					{MountPoint: "/run/kata-containers/shared/containers", FsType: "virtiofs", Size: 4096, Used: 1024},
+				},
+				Errors: map[string]string{"modules": "No such file or directory"},
+			}, nil
+		},
+	}
+
+	assert.Equal(http.StatusMethodNotAllowed, serve(http.MethodPost, "/guest/diagnostics").Code)
+	assert.Equal(http.StatusBadRequest, serve(http.MethodGet, "/guest/diagnostics?dmesg_lines=some").Code)
+	assert.Equal(http.StatusBadRequest, serve(http.MethodGet, "/guest/diagnostics?dmesg_lines=100000").Code)
+	assert.Empty(requested)
+
+	rr := serve(http.MethodGet, "/guest/diagnostics?dmesg_lines=50")
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal([]uint32{50}, requested)
+
+	// The response is decoded by the sandbox API clients
+	var diagnostics sandboxapi.GuestDiagnostics
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &diagnostics))
+	assert.Equal("MemTotal: 2048000 kB", diagnostics.MemInfo)
+	assert.Equal(uint64(1024), diagnostics.Filesystems[0].Used)
+	assert.Equal("No such file or directory", diagnostics.Errors["modules"])
+
+	assert.Equal(http.StatusOK, serve(http.MethodGet, "/guest/diagnostics").Code)
+	assert.Equal([]uint32{50, 0}, requested)
+}
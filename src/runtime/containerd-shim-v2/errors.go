@@ -16,8 +16,19 @@ import (
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
 )
 
+// errorCodes maps the codes of the classified virtcontainers errors
+// into grpc error codes.
+var errorCodes = map[vc.ErrorCode]codes.Code{
+	vc.ErrCodeHypervisorLaunch: codes.Unavailable,
+	vc.ErrCodeAgentTimeout:     codes.DeadlineExceeded,
+	vc.ErrCodeDeviceHotplug:    codes.Internal,
+	vc.ErrCodeFsShare:          codes.Internal,
+	vc.ErrCodeNetworkSetup:     codes.Internal,
+}
+
 // toGRPC maps the virtcontainers error into a grpc error,
 // using the original error message as a description.
+// The classified errors are prefixed with their code and counted.
 func toGRPC(err error) error {
 	if err == nil {
 		return nil
@@ -28,6 +39,12 @@ func toGRPC(err error) error {
 		return err
 	}
 
+	code := vc.ErrorCodeOf(err)
+	katashimErrors.WithLabelValues(string(code)).Inc()
+	if grpcCode, ok := errorCodes[code]; ok {
+		return status.Errorf(grpcCode, "%s: %s", code, err.Error())
+	}
+
 	err = errors.Cause(err)
 	switch {
 	case isInvalidArgument(err):
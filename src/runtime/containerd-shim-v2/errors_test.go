@@ -31,6 +31,21 @@ func TestToGRPC(t *testing.T) {
 	}
 }
 
+func TestToGRPCErrorCode(t *testing.T) {
+	assert := assert.New(t)
+
+	err := toGRPC(vc.NewError(vc.ErrCodeAgentTimeout, errors.New("foobar")))
+	assert.True(isGRPCErrorCode(codes.DeadlineExceeded, err))
+	assert.Contains(err.Error(), "agent-timeout: foobar")
+
+	err = toGRPCf(vc.NewError(vc.ErrCodeHypervisorLaunch, errors.New("foobar")), "appending")
+	assert.True(isGRPCErrorCode(codes.Unavailable, err))
+	assert.Contains(err.Error(), "hypervisor-launch-failure: appending: foobar")
+
+	err = toGRPC(errors.New("foobar"))
+	assert.False(isGRPCError(err))
+}
+
 func TestIsGRPCErrorCode(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+)
+
+// LabelsUpdate is the body of /labels POST requests
+type LabelsUpdate = sandboxapi.LabelsUpdate
+
+// serveLabels handles /labels requests, the labels of the sandbox for the
+// fleet tools to tag it. A POST updates the labels, which are persisted
+// with the sandbox state. Both POST and GET return the labels.
+func (s *service) serveLabels(w http.ResponseWriter, r *http.Request) {
+	if s.sandbox == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("the sandbox is not created yet"))
+		return
+	}
+
+	var labels map[string]string
+
+	switch r.Method {
+	case http.MethodGet:
+		labels = s.sandbox.Labels()
+	case http.MethodPost:
+		var update LabelsUpdate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		var err error
+		labels, err = s.sandbox.UpdateLabels(s.ctx, update.Set, update.Remove)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		shimMgtLog.WithField("labels", labels).Info("sandbox labels updated")
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(labels); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode sandbox labels")
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestServeLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		id:  testSandboxID,
+		ctx: context.Background(),
+	}
+
+	serve := func(method, body string) (int, map[string]string) {
+		rr := httptest.NewRecorder()
+		s.serveLabels(rr, httptest.NewRequest(method, "/labels", strings.NewReader(body)))
+
+		var labels map[string]string
+		if rr.Code == http.StatusOK {
+			assert.NoError(json.Unmarshal(rr.Body.Bytes(), &labels))
+		}
+		return rr.Code, labels
+	}
+
+	// No sandbox is created yet
+	code, _ := serve(http.MethodGet, "")
+	assert.Equal(http.StatusInternalServerError, code)
+
+	current := map[string]string{"tenant": "acme"}
+	s.sandbox = &vcmock.Sandbox{
+		MockID: testSandboxID,
+		LabelsFunc: func() map[string]string {
+			return current
+		},
+		UpdateLabelsFunc: func(set map[string]string, remove []string) (map[string]string, error) {
+			if _, ok := set["invalid"]; ok {
+				return current, fmt.Errorf("invalid sandbox label")
+			}
+			for _, key := range remove {
+				delete(current, key)
+			}
+			for key, value := range set {
+				current[key] = value
+			}
+			return current, nil
+		},
+	}
+
+	code, labels := serve(http.MethodGet, "")
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(map[string]string{"tenant": "acme"}, labels)
+
+	code, labels = serve(http.MethodPost, `{"set":{"billing-code":"42"},"remove":["tenant"]}`)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(map[string]string{"billing-code": "42"}, labels)
+
+	code, _ = serve(http.MethodPost, `{"set":{"invalid":""}}`)
+	assert.Equal(http.StatusInternalServerError, code)
+	code, _ = serve(http.MethodPost, `{"set":`)
+	assert.Equal(http.StatusBadRequest, code)
+	code, _ = serve(http.MethodPut, "")
+	assert.Equal(http.StatusMethodNotAllowed, code)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/nri"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// nriCreate invokes the NRI plugins for the pod or the container being
+// created, and applies their adjustments to its spec before the VM or the
+// container is created. The VM adjustments, only valid for the pod, also
+// change the runtime configuration the VM is created from.
+func nriCreate(ctx context.Context, s *service, id string, containerType vc.ContainerType, spec *specs.Spec) error {
+	if !s.config.NRIConfig.Enabled {
+		return nil
+	}
+
+	client, err := nri.New(s.config.NRIConfig)
+	if err != nil || client == nil {
+		return err
+	}
+
+	sandboxID := id
+	if !containerType.IsSandbox() {
+		sandboxID = s.sandbox.ID()
+	}
+
+	results, err := client.Invoke(ctx, id, sandboxID, 0, nri.Create, spec)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Adjust == nil {
+			continue
+		}
+
+		if err := r.Adjust.Apply(spec); err != nil {
+			return fmt.Errorf("NRI plugin %s: %v", r.Plugin, err)
+		}
+
+		vm := r.Adjust.VM
+		if vm == nil {
+			continue
+		}
+
+		if !containerType.IsSandbox() {
+			return fmt.Errorf("NRI plugin %s: the VM can only be adjusted for the pod", r.Plugin)
+		}
+
+		if err := vm.Apply(spec); err != nil {
+			return fmt.Errorf("NRI plugin %s: %v", r.Plugin, err)
+		}
+
+		if vm.VCPUs > 0 {
+			if maxVCPUs := s.config.HypervisorConfig.DefaultMaxVCPUs; maxVCPUs > 0 && vm.VCPUs > maxVCPUs {
+				return fmt.Errorf("NRI plugin %s: %d vCPUs are above the maximum of %d", r.Plugin, vm.VCPUs, maxVCPUs)
+			}
+			s.config.HypervisorConfig.NumVCPUs = vm.VCPUs
+		}
+
+		shimLog.WithField("plugin", r.Plugin).WithField("container", id).Info("applied NRI adjustments")
+	}
+
+	return nil
+}
+
+// nriDelete invokes the NRI plugins for the pod or the container being
+// deleted, so that they release what they set up. Their failures are only
+// logged.
+func nriDelete(ctx context.Context, s *service, c *container) {
+	if s.config == nil || !s.config.NRIConfig.Enabled {
+		return
+	}
+
+	client, err := nri.New(s.config.NRIConfig)
+	if err == nil && client != nil {
+		var pid int
+		if c.cType.IsSandbox() {
+			pid = int(s.hpid)
+		}
+		_, err = client.Invoke(ctx, c.id, s.sandbox.ID(), pid, nri.Delete, c.spec)
+	}
+
+	if err != nil {
+		shimLog.WithError(err).WithField("container", c.id).Warn("NRI delete failed")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/nri"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestNRICreate(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "resources.json")
+
+	assert.NoError(ioutil.WriteFile(configPath, []byte(`{"version": "0.1", "plugins": [{"type": "plugin"}]}`), 0644))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, "plugin"), []byte(`#!/bin/sh
+cat > /dev/null
+echo '{"version": "0.1", "adjust": {"env": ["FOO=bar"], "vm": {"vcpus": 4, "hypervisorAnnotations": {"io.katacontainers.config.hypervisor.default_memory": "4096"}}}}'
+`), 0755))
+
+	s := &service{
+		sandbox: &vcmock.Sandbox{MockID: testSandboxID},
+		config: &oci.RuntimeConfig{
+			HypervisorConfig: vc.HypervisorConfig{
+				NumVCPUs:        1,
+				DefaultMaxVCPUs: 8,
+			},
+		},
+	}
+
+	// NRI is disabled
+	spec := &specs.Spec{Process: &specs.Process{}}
+	assert.NoError(nriCreate(context.Background(), s, testSandboxID, vc.PodSandbox, spec))
+	assert.Empty(spec.Process.Env)
+
+	s.config.NRIConfig = nri.Config{
+		Enabled:    true,
+		ConfigPath: configPath,
+		PluginPath: dir,
+	}
+
+	assert.NoError(nriCreate(context.Background(), s, testSandboxID, vc.PodSandbox, spec))
+	assert.Equal([]string{"FOO=bar"}, spec.Process.Env)
+	assert.Equal("4096", spec.Annotations["io.katacontainers.config.hypervisor.default_memory"])
+	assert.Equal(uint32(4), s.config.HypervisorConfig.NumVCPUs)
+
+	// The VM is only adjusted for the pod
+	spec = &specs.Spec{Process: &specs.Process{}}
+	assert.Error(nriCreate(context.Background(), s, testContainerID, vc.PodContainer, spec))
+
+	// Above the maximum vCPUs
+	s.config.HypervisorConfig.DefaultMaxVCPUs = 2
+	assert.Error(nriCreate(context.Background(), s, testSandboxID, vc.PodSandbox, spec))
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+)
+
+// The VM is resized toward its target by steps of at most these many vCPUs
+// and MB of memory, for the guest to online the hotplugged resources before
+// more are added, and for a failure to be caught early.
+const (
+	resizeVCPUsStep    = 2
+	resizeMemoryStepMB = 1024
+)
+
+// SandboxResources is the body of /resources requests
+type SandboxResources = sandboxapi.SandboxResources
+
+// ResourcesStatus is the body of /resources responses
+type ResourcesStatus = sandboxapi.ResourcesStatus
+
+// resizer is the state of the reconciliation of the VM resources toward
+// the target requested through the management endpoint.
+type resizer struct {
+	sync.Mutex
+
+	target      vc.VMResources
+	reconciling bool
+	err         error
+	rolledBack  bool
+}
+
+// resizeStep returns the resources of the next step from current toward
+// target.
+func resizeStep(current, target vc.VMResources) vc.VMResources {
+	step := func(current, target, max uint32) uint32 {
+		switch {
+		case target > current && target-current > max:
+			return current + max
+		case current > target && current-target > max:
+			return current - max
+		}
+		return target
+	}
+
+	return vc.VMResources{
+		VCPUs:    step(current.VCPUs, target.VCPUs, resizeVCPUsStep),
+		MemoryMB: step(current.MemoryMB, target.MemoryMB, resizeMemoryStepMB),
+	}
+}
+
+// resizeVM resizes the VM to target, under the service lock the container
+// updates resize it under as well.
+func (s *service) resizeVM(ctx context.Context, target vc.VMResources) (vc.VMResources, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sandbox.ResizeVM(ctx, target)
+}
+
+func (s *service) vmResources() vc.VMResources {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sandbox.VMResources()
+}
+
+// reconcileResources resizes the VM step by step until it reaches the
+// target, which may change meanwhile. When a step fails, the VM is rolled
+// back to its resources before the reconciliation.
+func (s *service) reconcileResources(ctx context.Context) {
+	initial := s.vmResources()
+
+	var err error
+	for {
+		s.resizer.Lock()
+		target := s.resizer.target
+		s.resizer.Unlock()
+
+		current := s.vmResources()
+		if current == target {
+			break
+		}
+
+		next := resizeStep(current, target)
+		shimLog.WithFields(logrus.Fields{
+			"vcpus":     next.VCPUs,
+			"memory-mb": next.MemoryMB,
+		}).Debug("resizing the VM")
+
+		var resized vc.VMResources
+		resized, err = s.resizeVM(ctx, next)
+		if err != nil {
+			break
+		}
+
+		// The hypervisor aligns the memory to its hotplug block size, the
+		// target may not be reached exactly.
+		if next == target {
+			s.resizer.Lock()
+			done := s.resizer.target == target
+			s.resizer.Unlock()
+			if done {
+				break
+			}
+		} else if resized == current {
+			err = fmt.Errorf("the VM cannot be resized from %+v to %+v", current, next)
+			break
+		}
+	}
+
+	rolledBack := false
+	if err != nil {
+		shimLog.WithError(err).Error("failed to resize the VM, rolling back")
+		if _, rollbackErr := s.resizeVM(ctx, initial); rollbackErr != nil {
+			shimLog.WithError(rollbackErr).Error("failed to roll back the VM resources")
+		} else {
+			rolledBack = true
+		}
+	}
+
+	s.resizer.Lock()
+	defer s.resizer.Unlock()
+
+	s.resizer.reconciling = false
+	s.resizer.err = err
+	s.resizer.rolledBack = rolledBack
+}
+
+func (s *service) resourcesStatus() ResourcesStatus {
+	current := s.vmResources()
+
+	s.resizer.Lock()
+	defer s.resizer.Unlock()
+
+	target := s.resizer.target
+	if target.VCPUs == 0 {
+		target = current
+	}
+
+	status := ResourcesStatus{
+		Target:      SandboxResources{VCPUs: target.VCPUs, MemoryMB: target.MemoryMB},
+		Current:     SandboxResources{VCPUs: current.VCPUs, MemoryMB: current.MemoryMB},
+		Reconciling: s.resizer.reconciling,
+		RolledBack:  s.resizer.rolledBack,
+	}
+	if s.resizer.err != nil {
+		status.Error = s.resizer.err.Error()
+	}
+
+	return status
+}
+
+// serveResources handles /resources requests, the integration point of the
+// in-place pod resize. A POST sets the target resources of the VM, which is
+// resized toward them in the background. Both POST and GET return the
+// resources status.
+func (s *service) serveResources(w http.ResponseWriter, r *http.Request) {
+	if s.sandbox == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("the sandbox is not created yet"))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var target SandboxResources
+		if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if target.VCPUs == 0 || target.MemoryMB == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("both the vcpus and the memory_mb are required"))
+			return
+		}
+
+		s.resizer.Lock()
+		s.resizer.target = vc.VMResources{VCPUs: target.VCPUs, MemoryMB: target.MemoryMB}
+		if !s.resizer.reconciling {
+			s.resizer.reconciling = true
+			s.resizer.err = nil
+			s.resizer.rolledBack = false
+			go s.reconcileResources(s.ctx)
+		}
+		s.resizer.Unlock()
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.resourcesStatus()); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode resources status")
+	}
+}
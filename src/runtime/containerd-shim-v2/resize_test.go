@@ -0,0 +1,123 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestResizeStep(t *testing.T) {
+	assert := assert.New(t)
+
+	current := vc.VMResources{VCPUs: 1, MemoryMB: 2048}
+
+	assert.Equal(vc.VMResources{VCPUs: 3, MemoryMB: 3072}, resizeStep(current, vc.VMResources{VCPUs: 8, MemoryMB: 8192}))
+	assert.Equal(vc.VMResources{VCPUs: 2, MemoryMB: 2560}, resizeStep(current, vc.VMResources{VCPUs: 2, MemoryMB: 2560}))
+	assert.Equal(vc.VMResources{VCPUs: 1, MemoryMB: 1024}, resizeStep(current, vc.VMResources{VCPUs: 1, MemoryMB: 512}))
+}
+
+// newResizeSandbox returns a mock sandbox whose VM is resized to the
+// requested resources, unless failAbove vCPUs are requested.
+func newResizeSandbox(failAbove uint32) (*vcmock.Sandbox, *[]vc.VMResources) {
+	current := vc.VMResources{VCPUs: 1, MemoryMB: 2048}
+	var resizes []vc.VMResources
+
+	return &vcmock.Sandbox{
+		MockID: testSandboxID,
+		VMResourcesFunc: func() vc.VMResources {
+			return current
+		},
+		ResizeVMFunc: func(target vc.VMResources) (vc.VMResources, error) {
+			resizes = append(resizes, target)
+			if failAbove != 0 && target.VCPUs > failAbove {
+				return current, fmt.Errorf("vCPU hotplug failed")
+			}
+			current = target
+			return current, nil
+		},
+	}, &resizes
+}
+
+func TestServeResources(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		id:  testSandboxID,
+		ctx: context.Background(),
+	}
+
+	serve := func(method, body string) (int, ResourcesStatus) {
+		rr := httptest.NewRecorder()
+		s.serveResources(rr, httptest.NewRequest(method, "/resources", strings.NewReader(body)))
+
+		var status ResourcesStatus
+		if rr.Code == http.StatusOK {
+			assert.NoError(json.Unmarshal(rr.Body.Bytes(), &status))
+		}
+		return rr.Code, status
+	}
+
+	waitReconciled := func() ResourcesStatus {
+		var status ResourcesStatus
+		assert.Eventually(func() bool {
+			_, status = serve(http.MethodGet, "")
+			return !status.Reconciling
+		}, 5*time.Second, 10*time.Millisecond)
+		return status
+	}
+
+	// No sandbox is created yet
+	code, _ := serve(http.MethodGet, "")
+	assert.Equal(http.StatusInternalServerError, code)
+
+	sandbox, resizes := newResizeSandbox(0)
+	s.sandbox = sandbox
+
+	code, status := serve(http.MethodGet, "")
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(ResourcesStatus{
+		Target:  SandboxResources{VCPUs: 1, MemoryMB: 2048},
+		Current: SandboxResources{VCPUs: 1, MemoryMB: 2048},
+	}, status)
+
+	code, _ = serve(http.MethodPost, `{"vcpus":0,"memory_mb":1024}`)
+	assert.Equal(http.StatusBadRequest, code)
+	code, _ = serve(http.MethodPut, "")
+	assert.Equal(http.StatusMethodNotAllowed, code)
+
+	// The VM is resized step by step
+	code, status = serve(http.MethodPost, `{"vcpus":4,"memory_mb":3584}`)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(SandboxResources{VCPUs: 4, MemoryMB: 3584}, status.Target)
+
+	status = waitReconciled()
+	assert.Equal(SandboxResources{VCPUs: 4, MemoryMB: 3584}, status.Current)
+	assert.Empty(status.Error)
+	assert.Equal([]vc.VMResources{{VCPUs: 3, MemoryMB: 3072}, {VCPUs: 4, MemoryMB: 3584}}, *resizes)
+
+	// A failed step rolls the VM back
+	sandbox, resizes = newResizeSandbox(2)
+	s.sandbox = sandbox
+
+	serve(http.MethodPost, `{"vcpus":6,"memory_mb":2048}`)
+	status = waitReconciled()
+	assert.Equal(SandboxResources{VCPUs: 1, MemoryMB: 2048}, status.Current)
+	assert.Contains(status.Error, "vCPU hotplug failed")
+	assert.True(status.RolledBack)
+	assert.Equal([]vc.VMResources{{VCPUs: 3, MemoryMB: 2048}, {VCPUs: 1, MemoryMB: 2048}}, *resizes)
+}
@@ -85,6 +85,7 @@ func New(ctx context.Context, id string, publisher cdshim.Publisher, shutdown fu
 	if !opts.Debug {
 		logrus.SetLevel(logrus.WarnLevel)
 	}
+	shimLogLevel = logrus.GetLevel()
 	vci.SetLogger(ctx, shimLog)
 	katautils.SetLogger(ctx, shimLog, shimLog.Logger.Level)
 
@@ -102,6 +103,8 @@ func New(ctx context.Context, id string, publisher cdshim.Publisher, shutdown fu
 
 	go s.forward(ctx, publisher)
 
+	go s.handleReloadSignals(ctx)
+
 	return s, nil
 }
 
@@ -131,6 +134,7 @@ type service struct {
 	sandbox    vc.VCSandbox
 	containers map[string]*container
 	config     *oci.RuntimeConfig
+	configPath string
 	events     chan interface{}
 	monitor    chan error
 
@@ -138,6 +142,20 @@ type service struct {
 
 	ec chan exit
 	id string
+
+	// quiesced is set once the sandbox is drained for a node maintenance,
+	// no new exec is accepted then.
+	quiesced    bool
+	guestSynced bool
+	syncError   string
+
+	// pprofAnnotation is set when pprof is enabled by the sandbox
+	// annotation rather than the configuration
+	pprofAnnotation bool
+
+	// resizer reconciles the VM resources toward the target requested
+	// through the management endpoint
+	resizer resizer
 }
 
 func newCommand(ctx context.Context, id, containerdBinary, containerdAddress string) (*sysexec.Cmd, error) {
@@ -299,6 +317,12 @@ func getTopic(e interface{}) string {
 		return cdruntime.TaskResumedEventTopic
 	case *eventstypes.TaskCheckpointed:
 		return cdruntime.TaskCheckpointedEventTopic
+	case *GuestPanic:
+		return guestPanicEventTopic
+	case *GuestWatchdog:
+		return guestWatchdogEventTopic
+	case *VirtiofsdCrash:
+		return virtiofsdCrashEventTopic
 	default:
 		shimLog.WithField("event-type", e).Warn("no topic for event type")
 	}
@@ -536,6 +560,10 @@ func (s *service) Exec(ctx context.Context, r *taskAPI.ExecProcessRequest) (_ *p
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.quiesced {
+		return nil, errdefs.ToGRPCf(errdefs.ErrFailedPrecondition, "sandbox %s is quiesced", s.id)
+	}
+
 	c, err := s.getContainer(r.ID)
 	if err != nil {
 		return nil, err
@@ -6,16 +6,19 @@
 package containerdshim
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"expvar"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/pprof"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/containerd/containerd/api/types/task"
 	cdshim "github.com/containerd/containerd/runtime/v2/shim"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
@@ -26,6 +29,7 @@ import (
 
 	"google.golang.org/grpc/codes"
 
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 )
 
@@ -34,6 +38,10 @@ var (
 	shimMgtLog               = shimLog.WithField("subsystem", "shim-management")
 )
 
+// PortForwardUpgrade is the protocol the /port-forward requests upgrade
+// the connection to, the connection then carries the forwarded TCP stream.
+const PortForwardUpgrade = sandboxapi.PortForwardUpgrade
+
 // agentURL returns URL for agent
 func (s *service) agentURL(w http.ResponseWriter, r *http.Request) {
 	url, err := s.sandbox.GetAgentURL()
@@ -46,8 +54,499 @@ func (s *service) agentURL(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, url)
 }
 
+// MemoryDumpRequest is the body of /debug/dump-memory requests
+type MemoryDumpRequest = sandboxapi.MemoryDumpRequest
+
+// dumpMemory handles /debug/dump-memory requests, the dump is saved under
+// the configured guest memory dump path and its directory is returned.
+func (s *service) dumpMemory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req MemoryDumpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	dir, err := s.sandbox.DumpGuestMemory(r.Context(), vc.GuestMemoryDumpOptions{
+		EncryptionKey: req.EncryptionKey,
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	fmt.Fprint(w, dir)
+}
+
+// serveEvents handles /events requests, it returns the sandbox event journal
+// as JSON.
+func (s *service) serveEvents(w http.ResponseWriter, r *http.Request) {
+	events, err := s.sandbox.Journal()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode sandbox events")
+	}
+}
+
+// serveHypervisorLog handles /hypervisor-log requests, it returns the last
+// kb KB of the hypervisor output and of the guest console, all that is kept
+// when kb is not given.
+func (s *service) serveHypervisorLog(w http.ResponseWriter, r *http.Request) {
+	if s.sandbox == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("the sandbox is not created yet"))
+		return
+	}
+
+	size := 0
+	if value := r.URL.Query().Get("kb"); value != "" {
+		kb, err := strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("invalid kb %q", value)))
+			return
+		}
+		size = int(kb) * 1024
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write(s.sandbox.HypervisorLog(size))
+}
+
+// serveNetwork returns the network configuration of the sandbox on the host
+// and in the guest, along with their differences.
+func (s *service) serveNetwork(w http.ResponseWriter, r *http.Request) {
+	inspection, err := s.sandbox.InspectNetwork(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(inspection); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode sandbox network")
+	}
+}
+
+// serveNetworkCapture handles /network/capture requests, it streams a pcap
+// capture of the sandbox traffic, described by the query of the request, see
+// sandboxapi.NetworkCaptureRequest.
+func (s *service) serveNetworkCapture(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	config := vc.NetworkCaptureConfig{
+		Device: query.Get("device"),
+	}
+
+	var err error
+	if config.Duration, err = time.ParseDuration(query.Get("duration")); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("invalid duration %q", query.Get("duration"))))
+		return
+	}
+
+	if value := query.Get("snaplen"); value != "" {
+		snapLen, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("invalid snaplen %q", value)))
+			return
+		}
+		config.SnapLen = uint32(snapLen)
+	}
+
+	if value := query.Get("guest"); value != "" {
+		if config.Guest, err = strconv.ParseBool(value); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("invalid guest %q", value)))
+			return
+		}
+	}
+
+	stream := &captureStream{w: w, contentType: sandboxapi.PcapContentType}
+	if err := s.sandbox.CaptureNetwork(r.Context(), stream, config); err != nil {
+		if !stream.started {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		shimMgtLog.WithError(err).Error("network capture failed")
+	}
+}
+
+// captureStream sends the response header with the first bytes of the
+// capture, so that a capture failing to start is reported with an error
+// status, and flushes the packets as they are captured.
+type captureStream struct {
+	w           http.ResponseWriter
+	contentType string
+	started     bool
+}
+
+func (c *captureStream) Write(p []byte) (int, error) {
+	if !c.started {
+		c.w.Header().Set("Content-Type", c.contentType)
+		c.w.WriteHeader(http.StatusOK)
+		c.started = true
+	}
+
+	n, err := c.w.Write(p)
+	if flusher, ok := c.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return n, err
+}
+
+// CheckpointRequest is the query of /containers/checkpoint requests
+type CheckpointRequest = sandboxapi.CheckpointRequest
+
+// checkpointContainer handles /containers/checkpoint requests, it dumps the
+// processes of a container with CRIU in the guest and streams the tar
+// archive of the images, see sandboxapi.CheckpointRequest.
+func (s *service) checkpointContainer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	req := CheckpointRequest{ContainerID: query.Get("id")}
+	if req.ContainerID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("the container id is required"))
+		return
+	}
+
+	if value := query.Get("leave-running"); value != "" {
+		var err error
+		if req.LeaveRunning, err = strconv.ParseBool(value); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("invalid leave-running %q", value)))
+			return
+		}
+	}
+
+	stream := &captureStream{w: w, contentType: sandboxapi.TarContentType}
+	if err := s.sandbox.CheckpointContainer(r.Context(), req.ContainerID, stream, req.LeaveRunning); err != nil {
+		if !stream.started {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		shimMgtLog.WithError(err).WithField("container", req.ContainerID).Error("container checkpoint failed")
+	}
+}
+
+// restoreContainer handles /containers/restore requests, it restores the
+// processes of a checkpoint in the root file system of a container, the
+// body of the request being the tar archive of the images. Its length is
+// required, for the agent to know where the images end.
+func (s *service) restoreContainer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	containerID := r.URL.Query().Get("id")
+	if containerID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("the container id is required"))
+		return
+	}
+
+	if r.ContentLength <= 0 {
+		w.WriteHeader(http.StatusLengthRequired)
+		w.Write([]byte("the checkpoint images length is required"))
+		return
+	}
+
+	if err := s.sandbox.RestoreContainer(r.Context(), containerID, r.Body, r.ContentLength); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+	}
+}
+
+// servePolicyDecisions streams the decisions of the agent policy, a JSON
+// object per line, until the client goes away. This allows trying a policy
+// in audit mode before enforcing it.
+func (s *service) servePolicyDecisions(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("streaming is not supported"))
+		return
+	}
+
+	guestConn, err := s.sandbox.PolicyDecisions(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	defer guestConn.Close()
+
+	// Unblock the reads below once the client is gone
+	go func() {
+		<-r.Context().Done()
+		guestConn.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	scanner := bufio.NewScanner(guestConn)
+	for scanner.Scan() {
+		if _, err := w.Write(append(scanner.Bytes(), '\n')); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// portForward handles /port-forward?port=<port> requests, it tunnels the
+// connection to a TCP port of the sandbox through the agent, so that it
+// doesn't depend on the sandbox network. The request must upgrade the
+// connection to PortForwardUpgrade.
+func (s *service) portForward(w http.ResponseWriter, r *http.Request) {
+	port, err := strconv.ParseUint(r.URL.Query().Get("port"), 10, 16)
+	if err != nil || port == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("invalid port %q", r.URL.Query().Get("port"))))
+		return
+	}
+
+	if !strings.EqualFold(r.Header.Get("Upgrade"), PortForwardUpgrade) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("connection upgrade to %s required", PortForwardUpgrade)))
+		return
+	}
+
+	s.mu.Lock()
+	quiesced := s.quiesced
+	s.mu.Unlock()
+	if quiesced {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(fmt.Sprintf("sandbox %s is quiesced", s.id)))
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("connection cannot be hijacked"))
+		return
+	}
+
+	guestConn, err := s.sandbox.PortForward(r.Context(), uint32(port))
+	if err != nil {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(err.Error()))
+		return
+	}
+	defer guestConn.Close()
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		shimMgtLog.WithError(err).Error("failed to hijack port forward connection")
+		return
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(rw, "HTTP/1.1 %d %s\r\nConnection: Upgrade\r\nUpgrade: %s\r\n\r\n",
+		http.StatusSwitchingProtocols, http.StatusText(http.StatusSwitchingProtocols), PortForwardUpgrade)
+	if err := rw.Flush(); err != nil {
+		shimMgtLog.WithError(err).Error("failed to upgrade port forward connection")
+		return
+	}
+
+	shimMgtLog.WithField("port", port).Debug("port forward started")
+	mutils.Tunnel(mutils.NewBufferedConn(conn, rw.Reader), guestConn)
+	shimMgtLog.WithField("port", port).Debug("port forward done")
+}
+
+// MigrationRequest is the body of /migration/prepare-receive and
+// /migration/start requests
+type MigrationRequest = sandboxapi.MigrationRequest
+
+// MigrationStatus is the body of /migration/status responses
+type MigrationStatus = sandboxapi.MigrationStatus
+
+// decodeMigrationRequest decodes the body of POST migration requests, it
+// writes the error response when it fails.
+func decodeMigrationRequest(w http.ResponseWriter, r *http.Request) (MigrationRequest, bool) {
+	var req MigrationRequest
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return req, false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return req, false
+	}
+
+	if req.URI == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("missing migration uri"))
+		return req, false
+	}
+
+	return req, true
+}
+
+// migrationPrepareReceive handles /migration/prepare-receive requests on the
+// destination sandbox, which must be created with the migration_incoming
+// annotation.
+func (s *service) migrationPrepareReceive(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMigrationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.sandbox.MigrationPrepareReceive(r.Context(), req.URI); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+	}
+}
+
+// migrationStart handles /migration/start requests on the source sandbox.
+func (s *service) migrationStart(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeMigrationRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.sandbox.MigrationStart(r.Context(), req.URI); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+	}
+}
+
+// migrationStatus handles /migration/status requests.
+func (s *service) migrationStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.sandbox.MigrationStatus(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(MigrationStatus{Status: status}); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode migration status")
+	}
+}
+
+// migrationSwitchover handles /migration/switchover requests: on the source
+// sandbox once the migration is "pre-switchover", then on the destination one.
+func (s *service) migrationSwitchover(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := s.sandbox.MigrationSwitchover(r.Context()); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+	}
+}
+
+// QuiesceStatus is the body of /quiesce responses
+type QuiesceStatus = sandboxapi.QuiesceStatus
+
+// quiesce handles /quiesce requests, for the node drain integrations. A
+// POST stops accepting new exec and port forward requests, refreshes the
+// sandbox metrics for a last scrape and has the agent sync the guest file
+// systems. Both POST and GET return the quiesce status, telling when the
+// sandbox is safe to stop.
+func (s *service) quiesce(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		s.mu.Lock()
+		s.quiesced = true
+		s.mu.Unlock()
+
+		if err := s.sandbox.UpdateRuntimeMetrics(); err != nil {
+			shimMgtLog.WithError(err).Warn("failed to update the sandbox metrics")
+		}
+		updateShimMetrics()
+
+		err := s.sandbox.SyncGuestFilesystems(r.Context())
+		if err != nil {
+			shimMgtLog.WithError(err).Warn("failed to sync the guest file systems")
+		}
+
+		s.mu.Lock()
+		s.guestSynced = err == nil
+		s.syncError = ""
+		if err != nil {
+			s.syncError = err.Error()
+		}
+		s.mu.Unlock()
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.quiesceStatus()); err != nil {
+		shimMgtLog.WithError(err).Error("failed to encode quiesce status")
+	}
+}
+
+func (s *service) quiesceStatus() QuiesceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := QuiesceStatus{
+		Quiesced:    s.quiesced,
+		GuestSynced: s.guestSynced,
+		SyncError:   s.syncError,
+	}
+
+	for _, c := range s.containers {
+		for _, e := range c.execs {
+			if e.status != task.StatusStopped {
+				status.RunningExecs++
+			}
+		}
+	}
+
+	status.SafeToStop = status.Quiesced && status.RunningExecs == 0
+
+	return status
+}
+
 // serveMetrics handle /metrics requests
 func (s *service) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	// the guest metrics are skipped with guest=false, sparing the agent
+	guest := true
+	if value := r.URL.Query().Get("guest"); value != "" {
+		var err error
+		if guest, err = strconv.ParseBool(value); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("invalid guest %q", value)))
+			return
+		}
+	}
 
 	// update metrics from sandbox
 	s.sandbox.UpdateRuntimeMetrics()
@@ -55,20 +554,42 @@ func (s *service) serveMetrics(w http.ResponseWriter, r *http.Request) {
 	// update metrics for shim process
 	updateShimMetrics()
 
+	if guest {
+		// update guest network metrics, as gathered by the agent
+		if err := s.updateGuestNetdevMetrics(context.Background()); err != nil {
+			shimMgtLog.WithError(err).Warn("failed to get guest network stats")
+		}
+
+		// update guest memory metrics, as polled from the balloon device
+		if err := s.updateGuestMemoryMetrics(context.Background()); err != nil {
+			shimMgtLog.WithError(err).Warn("failed to get guest memory stats")
+		}
+	}
+
 	// metrics gathered by shim
 	mfs, err := prometheus.DefaultGatherer.Gather()
 	if err != nil {
 		return
 	}
 
-	// encode the metrics
-	encoder := expfmt.NewEncoder(w, expfmt.FmtText)
+	// encode the metrics, exemplars are only supported by the OpenMetrics
+	// format
+	format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+	w.Header().Set("Content-Type", string(format))
+	encoder := expfmt.NewEncoder(w, format)
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		defer closer.Close()
+	}
+
 	for _, mf := range mfs {
+		if !guest && strings.HasPrefix(mf.GetName(), namespaceKatashim+"_guest_") {
+			continue
+		}
 		encoder.Encode(mf)
 	}
 
 	// if using an old agent, only collect shim/sandbox metrics.
-	if !ifSupportAgentMetricsAPI {
+	if !guest || !ifSupportAgentMetricsAPI {
 		return
 	}
 
@@ -148,6 +669,25 @@ func (s *service) startManagementServer(ctx context.Context, ociSpec *specs.Spec
 	m := http.NewServeMux()
 	m.Handle("/metrics", http.HandlerFunc(s.serveMetrics))
 	m.Handle("/agent-url", http.HandlerFunc(s.agentURL))
+	m.Handle("/debug/dump-memory", http.HandlerFunc(s.dumpMemory))
+	m.Handle("/events", http.HandlerFunc(s.serveEvents))
+	m.Handle("/hypervisor-log", http.HandlerFunc(s.serveHypervisorLog))
+	m.Handle("/network", http.HandlerFunc(s.serveNetwork))
+	m.Handle("/network/capture", http.HandlerFunc(s.serveNetworkCapture))
+	m.Handle("/port-forward", http.HandlerFunc(s.portForward))
+	m.Handle("/policy-decisions", http.HandlerFunc(s.servePolicyDecisions))
+	m.Handle("/containers/checkpoint", http.HandlerFunc(s.checkpointContainer))
+	m.Handle("/containers/restore", http.HandlerFunc(s.restoreContainer))
+	m.Handle("/migration/prepare-receive", http.HandlerFunc(s.migrationPrepareReceive))
+	m.Handle("/migration/start", http.HandlerFunc(s.migrationStart))
+	m.Handle("/migration/status", http.HandlerFunc(s.migrationStatus))
+	m.Handle("/migration/switchover", http.HandlerFunc(s.migrationSwitchover))
+	m.Handle("/quiesce", http.HandlerFunc(s.quiesce))
+	m.Handle("/config/reload", http.HandlerFunc(s.serveConfigReload))
+	m.Handle("/resources", http.HandlerFunc(s.serveResources))
+	m.Handle("/labels", http.HandlerFunc(s.serveLabels))
+	m.Handle("/volumes/resize", http.HandlerFunc(s.resizeVolume))
+	m.Handle("/guest/diagnostics", http.HandlerFunc(s.serveGuestDiagnostics))
 	s.mountPprofHandle(m, ociSpec)
 
 	// register shim metrics
@@ -161,30 +701,39 @@ func (s *service) startManagementServer(ctx context.Context, ociSpec *specs.Spec
 	svr.Serve(listener)
 }
 
-// mountPprofHandle provides a debug endpoint
+// mountPprofHandle provides a debug endpoint, served when pprof is enabled
+// by the configuration, which can be reloaded, or by the sandbox annotation
 func (s *service) mountPprofHandle(m *http.ServeMux, ociSpec *specs.Spec) {
-
-	// return if not enabled
-	if !s.config.EnablePprof {
-		value, ok := ociSpec.Annotations[vcAnnotations.EnablePprof]
-		if !ok {
-			return
-		}
+	if value, ok := ociSpec.Annotations[vcAnnotations.EnablePprof]; ok {
 		enabled, err := strconv.ParseBool(value)
-		if err != nil || !enabled {
+		s.pprofAnnotation = err == nil && enabled
+	}
+
+	m.Handle("/debug/vars", s.pprofHandler(expvar.Handler()))
+	m.Handle("/debug/pprof/", s.pprofHandler(http.HandlerFunc(pprof.Index)))
+	m.Handle("/debug/pprof/cmdline", s.pprofHandler(http.HandlerFunc(pprof.Cmdline)))
+	m.Handle("/debug/pprof/profile", s.pprofHandler(http.HandlerFunc(pprof.Profile)))
+	m.Handle("/debug/pprof/symbol", s.pprofHandler(http.HandlerFunc(pprof.Symbol)))
+	m.Handle("/debug/pprof/trace", s.pprofHandler(http.HandlerFunc(pprof.Trace)))
+}
+
+// pprofHandler serves the requests with h while pprof is enabled
+func (s *service) pprofHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		enabled := s.config.EnablePprof || s.pprofAnnotation
+		s.mu.Unlock()
+
+		if !enabled {
+			http.NotFound(w, r)
 			return
 		}
-	}
-	m.Handle("/debug/vars", expvar.Handler())
-	m.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
-	m.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
-	m.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
-	m.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
-	m.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+		h.ServeHTTP(w, r)
+	})
 }
 
 // SocketAddress returns the address of the abstract domain socket for communicating with the
 // shim management endpoint
 func SocketAddress(id string) string {
-	return filepath.Join(string(filepath.Separator), "run", "vc", id, "shim-monitor")
+	return sandboxapi.SocketAddress(id)
 }
@@ -6,12 +6,25 @@
 package containerdshim
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/containerd/namespaces"
+	taskAPI "github.com/containerd/containerd/runtime/v2/task"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
 
 	"github.com/stretchr/testify/assert"
@@ -31,7 +44,7 @@ func TestServeMetrics(t *testing.T) {
 	}
 
 	rr := httptest.NewRecorder()
-	r := &http.Request{}
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
 
 	// case 1: normal
 	sandbox.GetAgentMetricsFunc = func() (string, error) {
@@ -60,4 +73,532 @@ go_threads 23
 	assert.Equal(200, rr.Code, "response code should be 200")
 	body = rr.Body.String()
 	assert.Equal(true, len(strings.Split(body, "\n")) > 0)
+
+	// case 3: the agent is not queried without the guest metrics
+	sandbox.GetAgentMetricsFunc = func() (string, error) {
+		t.Error("unexpected agent metrics request")
+		return "", nil
+	}
+
+	rr = httptest.NewRecorder()
+	s.serveMetrics(rr, httptest.NewRequest(http.MethodGet, "/metrics?guest=false", nil))
+	assert.Equal(200, rr.Code, "response code should be 200")
+	assert.NotContains(rr.Body.String(), "kata_agent_")
+	assert.NotContains(rr.Body.String(), "kata_shim_guest_")
+
+	rr = httptest.NewRecorder()
+	s.serveMetrics(rr, httptest.NewRequest(http.MethodGet, "/metrics?guest=maybe", nil))
+	assert.Equal(http.StatusBadRequest, rr.Code)
+}
+
+func TestDumpMemory(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	var key []byte
+	sandbox.DumpGuestMemoryFunc = func(opts vc.GuestMemoryDumpOptions) (string, error) {
+		key = opts.EncryptionKey
+		return "/tmp/dump", nil
+	}
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/debug/dump-memory", nil)
+	s.dumpMemory(rr, r)
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/debug/dump-memory", bytes.NewBufferString(`{"encryption_key":"MDEyMzQ1Njc4OWFiY2RlZg=="}`))
+	s.dumpMemory(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("/tmp/dump", rr.Body.String())
+	assert.Equal([]byte("0123456789abcdef"), key)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/debug/dump-memory", bytes.NewBufferString(`invalid`))
+	s.dumpMemory(rr, r)
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	sandbox.DumpGuestMemoryFunc = func(opts vc.GuestMemoryDumpOptions) (string, error) {
+		return "", fmt.Errorf("dump failed")
+	}
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/debug/dump-memory", nil)
+	s.dumpMemory(rr, r)
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+}
+
+func TestServeEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	sandbox.JournalFunc = func() ([]vc.JournalEvent, error) {
+		return []vc.JournalEvent{{Type: vc.JournalOOM, Container: "foo"}}, nil
+	}
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+	s.serveEvents(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+
+	var events []vc.JournalEvent
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &events))
+	assert.Len(events, 1)
+	assert.Equal(vc.JournalOOM, events[0].Type)
+	assert.Equal("foo", events[0].Container)
+
+	sandbox.JournalFunc = func() ([]vc.JournalEvent, error) {
+		return nil, fmt.Errorf("journal error")
+	}
+	rr = httptest.NewRecorder()
+	s.serveEvents(rr, r)
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+}
+
+func TestServeNetwork(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	sandbox.InspectNetworkFunc = func() (vc.NetworkInspection, error) {
+		return vc.NetworkInspection{
+			Host: vc.NetworkSnapshot{
+				Interfaces: []vc.InspectedInterface{{Name: "eth0", HwAddr: "02:42:ac:11:00:02", Addresses: []string{"10.0.0.2/24"}}},
+				Filters:    []vc.InspectedFilter{{Device: "eth0", Redirect: "tap0_kata"}},
+			},
+			Differences: []string{"interface eth0 (02:42:ac:11:00:02) is missing in the guest"},
+		}, nil
+	}
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/network", nil)
+	s.serveNetwork(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+
+	// The client decodes the shim response
+	var inspection sandboxapi.NetworkInspection
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &inspection))
+	assert.Equal([]sandboxapi.NetworkInterface{{Name: "eth0", HwAddr: "02:42:ac:11:00:02", Addresses: []string{"10.0.0.2/24"}}}, inspection.Host.Interfaces)
+	assert.Equal([]sandboxapi.NetworkFilter{{Device: "eth0", Redirect: "tap0_kata"}}, inspection.Host.Filters)
+	assert.Len(inspection.Differences, 1)
+
+	sandbox.InspectNetworkFunc = func() (vc.NetworkInspection, error) {
+		return vc.NetworkInspection{}, fmt.Errorf("agent error")
+	}
+	rr = httptest.NewRecorder()
+	s.serveNetwork(rr, r)
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+}
+
+func TestServeNetworkCapture(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	var config vc.NetworkCaptureConfig
+	sandbox.CaptureNetworkFunc = func(w io.Writer, c vc.NetworkCaptureConfig) error {
+		config = c
+		w.Write([]byte("header"))
+		w.Write([]byte("packet"))
+		return nil
+	}
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/network/capture?duration=30s&device=eth0&snaplen=96&guest=true", nil)
+	s.serveNetworkCapture(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal(sandboxapi.PcapContentType, rr.Header().Get("Content-Type"))
+	assert.Equal("headerpacket", rr.Body.String())
+	assert.Equal(vc.NetworkCaptureConfig{Device: "eth0", Duration: 30 * time.Second, SnapLen: 96, Guest: true}, config)
+
+	// The client builds the same query
+	query := sandboxapi.NetworkCaptureRequest{Device: "eth0", Duration: 30 * time.Second, SnapLen: 96, Guest: true}.Query()
+	assert.Equal(r.URL.Query(), query)
+
+	for _, query := range []string{"", "duration=forever", "duration=1s&snaplen=-1", "duration=1s&guest=maybe"} {
+		rr = httptest.NewRecorder()
+		s.serveNetworkCapture(rr, httptest.NewRequest(http.MethodGet, "/network/capture?"+query, nil))
+		assert.Equal(http.StatusBadRequest, rr.Code, query)
+	}
+
+	// A capture failing to start is reported with an error status
+	sandbox.CaptureNetworkFunc = func(w io.Writer, c vc.NetworkCaptureConfig) error {
+		return fmt.Errorf("no such device")
+	}
+	rr = httptest.NewRecorder()
+	s.serveNetworkCapture(rr, httptest.NewRequest(http.MethodGet, "/network/capture?duration=1s", nil))
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+	assert.Equal("no such device", rr.Body.String())
+}
+
+func TestServeContainerCheckpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	var leftRunning bool
+	sandbox.CheckpointContainerFunc = func(containerID string, w io.Writer, leaveRunning bool) error {
+		if containerID != "foo" {
+			return fmt.Errorf("container %s not found", containerID)
+		}
+		leftRunning = leaveRunning
+		w.Write([]byte("images"))
+		return nil
+	}
+
+	var restored string
+	sandbox.RestoreContainerFunc = func(containerID string, images io.Reader, size int64) error {
+		data, err := ioutil.ReadAll(images)
+		assert.NoError(err)
+		assert.Equal(int64(len(data)), size)
+		restored = string(data)
+		return nil
+	}
+
+	// The client builds the same query
+	query := sandboxapi.CheckpointRequest{ContainerID: "foo", LeaveRunning: true}.Query()
+	rr := httptest.NewRecorder()
+	s.checkpointContainer(rr, httptest.NewRequest(http.MethodPost, "/containers/checkpoint?"+query.Encode(), nil))
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal(sandboxapi.TarContentType, rr.Header().Get("Content-Type"))
+	assert.Equal("images", rr.Body.String())
+	assert.True(leftRunning)
+
+	for _, query := range []string{"", "id=foo&leave-running=maybe"} {
+		rr = httptest.NewRecorder()
+		s.checkpointContainer(rr, httptest.NewRequest(http.MethodPost, "/containers/checkpoint?"+query, nil))
+		assert.Equal(http.StatusBadRequest, rr.Code, query)
+	}
+
+	rr = httptest.NewRecorder()
+	s.checkpointContainer(rr, httptest.NewRequest(http.MethodGet, "/containers/checkpoint?id=foo", nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+
+	// A checkpoint failing to start is reported with an error status
+	rr = httptest.NewRecorder()
+	s.checkpointContainer(rr, httptest.NewRequest(http.MethodPost, "/containers/checkpoint?id=bar", nil))
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+	assert.Equal("container bar not found", rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	s.restoreContainer(rr, httptest.NewRequest(http.MethodPost, "/containers/restore?id=foo", strings.NewReader("images")))
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("images", restored)
+
+	rr = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/containers/restore?id=foo", strings.NewReader("images"))
+	r.ContentLength = -1
+	s.restoreContainer(rr, r)
+	assert.Equal(http.StatusLengthRequired, rr.Code)
+
+	rr = httptest.NewRecorder()
+	s.restoreContainer(rr, httptest.NewRequest(http.MethodPost, "/containers/restore", strings.NewReader("images")))
+	assert.Equal(http.StatusBadRequest, rr.Code)
+}
+
+func TestServePolicyDecisions(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	sandbox.PolicyDecisionsFunc = func() (net.Conn, error) {
+		return nil, fmt.Errorf("no agent policy")
+	}
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/policy-decisions", nil)
+	s.servePolicyDecisions(rr, r)
+	assert.Equal(http.StatusBadGateway, rr.Code)
+
+	decisions := `{"allowed":false,"enforced":false,"request":"ExecProcessRequest","rule":"no-exec"}
+{"allowed":true,"enforced":false,"request":"CopyFileRequest","rule":"default"}
+`
+	sandbox.PolicyDecisionsFunc = func() (net.Conn, error) {
+		guest, shim := net.Pipe()
+		go func() {
+			defer guest.Close()
+			guest.Write([]byte(decisions))
+		}()
+		return shim, nil
+	}
+	rr = httptest.NewRecorder()
+	s.servePolicyDecisions(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("application/x-ndjson", rr.Header().Get("Content-Type"))
+	assert.Equal(decisions, rr.Body.String())
+}
+
+func TestPortForward(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	for _, port := range []string{"", "0", "foo", "65536"} {
+		rr := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/port-forward?port="+port, nil)
+		r.Header.Set("Upgrade", PortForwardUpgrade)
+		s.portForward(rr, r)
+		assert.Equal(http.StatusBadRequest, rr.Code, "port %q", port)
+	}
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/port-forward?port=8080", nil)
+	s.portForward(rr, r)
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	server := httptest.NewServer(http.HandlerFunc(s.portForward))
+	defer server.Close()
+
+	sandbox.PortForwardFunc = func(port uint32) (net.Conn, error) {
+		return nil, fmt.Errorf("port forward error")
+	}
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/port-forward?port=8080", nil)
+	assert.NoError(err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", PortForwardUpgrade)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusBadGateway, resp.StatusCode)
+
+	// the guest side echoes what it receives
+	sandbox.PortForwardFunc = func(port uint32) (net.Conn, error) {
+		assert.Equal(uint32(8080), port)
+		guest, shim := net.Pipe()
+		go func() {
+			defer guest.Close()
+			io.Copy(guest, guest)
+		}()
+		return shim, nil
+	}
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	assert.NoError(err)
+	defer conn.Close()
+
+	assert.NoError(req.Write(conn))
+	br := bufio.NewReader(conn)
+	resp, err = http.ReadResponse(br, req)
+	assert.NoError(err)
+	assert.Equal(http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(PortForwardUpgrade, resp.Header.Get("Upgrade"))
+
+	_, err = conn.Write([]byte("ping"))
+	assert.NoError(err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(br, buf)
+	assert.NoError(err)
+	assert.Equal("ping", string(buf))
+}
+
+func TestMigration(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	var receiveURI, startURI string
+	switchedOver := false
+	sandbox.MigrationPrepareReceiveFunc = func(uri string) error {
+		receiveURI = uri
+		return nil
+	}
+	sandbox.MigrationStartFunc = func(uri string) error {
+		startURI = uri
+		return nil
+	}
+	sandbox.MigrationStatusFunc = func() (string, error) {
+		return "pre-switchover", nil
+	}
+	sandbox.MigrationSwitchoverFunc = func() error {
+		switchedOver = true
+		return nil
+	}
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/migration/prepare-receive", strings.NewReader(`{"uri": "tcp:0:4444"}`))
+	s.migrationPrepareReceive(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("tcp:0:4444", receiveURI)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/migration/start", strings.NewReader(`{"uri": "tcp:dest:4444"}`))
+	s.migrationStart(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.Equal("tcp:dest:4444", startURI)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/migration/status", nil)
+	s.migrationStatus(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+	var status MigrationStatus
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &status))
+	assert.Equal("pre-switchover", status.Status)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/migration/switchover", nil)
+	s.migrationSwitchover(rr, r)
+	assert.Equal(http.StatusOK, rr.Code)
+	assert.True(switchedOver)
+
+	// invalid requests
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/migration/start", nil)
+	s.migrationStart(rr, r)
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/migration/start", strings.NewReader(`{}`))
+	s.migrationStart(rr, r)
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/migration/prepare-receive", strings.NewReader(`foo`))
+	s.migrationPrepareReceive(rr, r)
+	assert.Equal(http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/migration/switchover", nil)
+	s.migrationSwitchover(rr, r)
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
+
+	sandbox.MigrationSwitchoverFunc = func() error {
+		return fmt.Errorf("switchover error")
+	}
+	rr = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/migration/switchover", nil)
+	s.migrationSwitchover(rr, r)
+	assert.Equal(http.StatusInternalServerError, rr.Code)
+}
+
+func TestQuiesce(t *testing.T) {
+	assert := assert.New(t)
+
+	synced := false
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		SyncGuestFilesystemsFunc: func() error {
+			synced = true
+			return nil
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	var err error
+	s.containers[testContainerID], err = newContainer(s, &taskAPI.CreateTaskRequest{ID: testContainerID}, "", nil, false)
+	assert.NoError(err)
+	running := &exec{status: task.StatusRunning}
+	s.containers[testContainerID].execs["exec"] = running
+
+	quiesce := func(method string) QuiesceStatus {
+		rr := httptest.NewRecorder()
+		s.quiesce(rr, httptest.NewRequest(method, "/quiesce", nil))
+		assert.Equal(http.StatusOK, rr.Code)
+
+		var status QuiesceStatus
+		assert.NoError(json.Unmarshal(rr.Body.Bytes(), &status))
+		return status
+	}
+
+	assert.Equal(QuiesceStatus{RunningExecs: 1}, quiesce(http.MethodGet))
+	assert.False(synced)
+
+	assert.Equal(QuiesceStatus{Quiesced: true, GuestSynced: true, RunningExecs: 1}, quiesce(http.MethodPost))
+	assert.True(synced)
+
+	// No new exec is accepted
+	ctx := namespaces.WithNamespace(context.Background(), "UnitTest")
+	_, err = s.Exec(ctx, &taskAPI.ExecProcessRequest{ID: testContainerID, ExecID: "new-exec"})
+	assert.Error(err)
+	assert.Contains(err.Error(), "quiesced")
+
+	rr := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/port-forward?port=80", nil)
+	r.Header.Set("Upgrade", PortForwardUpgrade)
+	s.portForward(rr, r)
+	assert.Equal(http.StatusServiceUnavailable, rr.Code)
+
+	// Safe to stop once the running exec is over
+	running.status = task.StatusStopped
+	assert.Equal(QuiesceStatus{Quiesced: true, GuestSynced: true, SafeToStop: true}, quiesce(http.MethodGet))
+
+	// The guest sync failures are reported
+	sandbox.SyncGuestFilesystemsFunc = func() error {
+		return fmt.Errorf("guest sync is not enabled")
+	}
+	status := quiesce(http.MethodPost)
+	assert.False(status.GuestSynced)
+	assert.Equal("guest sync is not enabled", status.SyncError)
+
+	rr = httptest.NewRecorder()
+	s.quiesce(rr, httptest.NewRequest(http.MethodPut, "/quiesce", nil))
+	assert.Equal(http.StatusMethodNotAllowed, rr.Code)
 }
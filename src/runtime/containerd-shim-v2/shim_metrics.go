@@ -82,6 +82,30 @@ var (
 		Name:      "pod_overhead_memory_in_bytes",
 		Help:      "Kata Pod overhead for memory resources(bytes).",
 	})
+
+	katashimGuestNetdev = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceKatashim,
+		Name:      "guest_netdev",
+		Help:      "Guest network devices statistics, as reported by the agent.",
+	},
+		[]string{"interface", "item"},
+	)
+
+	katashimGuestMemory = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceKatashim,
+		Name:      "guest_memory",
+		Help:      "Guest memory statistics, as reported by the virtio-balloon device.",
+	},
+		[]string{"item"},
+	)
+
+	katashimErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespaceKatashim,
+		Name:      "errors_total",
+		Help:      "Errors returned to containerd, by error code.",
+	},
+		[]string{"code"},
+	)
 )
 
 func registerMetrics() {
@@ -94,6 +118,9 @@ func registerMetrics() {
 	prometheus.MustRegister(katashimOpenFDs)
 	prometheus.MustRegister(katashimPodOverheadCPU)
 	prometheus.MustRegister(katashimPodOverheadMemory)
+	prometheus.MustRegister(katashimGuestNetdev)
+	prometheus.MustRegister(katashimGuestMemory)
+	prometheus.MustRegister(katashimErrors)
 }
 
 // updateShimMetrics will update metrics for kata shim process itself
@@ -209,3 +236,58 @@ func (s *service) setPodOverheadMetrics(ctx context.Context) error {
 	katashimPodOverheadCPU.Set(cpu)
 	return nil
 }
+
+func setGuestNetdevMetrics(stats []*vc.NetworkStats) {
+	// Drop the devices which have been removed from the guest.
+	katashimGuestNetdev.Reset()
+
+	for _, n := range stats {
+		katashimGuestNetdev.WithLabelValues(n.Name, "rx_bytes").Set(float64(n.RxBytes))
+		katashimGuestNetdev.WithLabelValues(n.Name, "rx_packets").Set(float64(n.RxPackets))
+		katashimGuestNetdev.WithLabelValues(n.Name, "rx_errors").Set(float64(n.RxErrors))
+		katashimGuestNetdev.WithLabelValues(n.Name, "rx_dropped").Set(float64(n.RxDropped))
+		katashimGuestNetdev.WithLabelValues(n.Name, "tx_bytes").Set(float64(n.TxBytes))
+		katashimGuestNetdev.WithLabelValues(n.Name, "tx_packets").Set(float64(n.TxPackets))
+		katashimGuestNetdev.WithLabelValues(n.Name, "tx_errors").Set(float64(n.TxErrors))
+		katashimGuestNetdev.WithLabelValues(n.Name, "tx_dropped").Set(float64(n.TxDropped))
+	}
+}
+
+// updateGuestNetdevMetrics updates the guest network devices metrics. The
+// guest network is shared by all the containers of the sandbox, so the stats
+// of any container are enough.
+func (s *service) updateGuestNetdevMetrics(ctx context.Context) error {
+	containers := s.sandbox.GetAllContainers()
+	if len(containers) == 0 {
+		return nil
+	}
+
+	cstats, err := s.sandbox.StatsContainer(ctx, containers[0].ID())
+	if err != nil {
+		return err
+	}
+
+	setGuestNetdevMetrics(cstats.NetworkStats)
+	return nil
+}
+
+func setGuestMemoryMetrics(stats vc.GuestMemoryStats) {
+	// Drop the statistics the guest stopped reporting.
+	katashimGuestMemory.Reset()
+
+	for item, value := range stats.Stats {
+		katashimGuestMemory.WithLabelValues(item).Set(float64(value))
+	}
+}
+
+// updateGuestMemoryMetrics updates the guest memory metrics, when the
+// sandbox VM has a virtio-balloon device polling them.
+func (s *service) updateGuestMemoryMetrics(ctx context.Context) error {
+	stats, err := s.sandbox.GuestMemoryStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	setGuestMemoryMetrics(stats)
+	return nil
+}
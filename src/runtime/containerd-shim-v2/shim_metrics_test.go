@@ -12,6 +12,8 @@ import (
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -113,3 +115,111 @@ func TestStatsSandbox(t *testing.T) {
 	//       = 50000
 	assert.Equal(float64(50000), mem)
 }
+
+func TestUpdateGuestNetdevMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		StatsContainerFunc: func(contID string) (vc.ContainerStats, error) {
+			return vc.ContainerStats{
+				NetworkStats: []*vc.NetworkStats{
+					{
+						Name:      "eth0",
+						RxBytes:   1000,
+						RxDropped: 3,
+						TxBytes:   2000,
+					},
+				},
+			}, nil
+		},
+		MockContainers: []*vcmock.Container{
+			{
+				MockID: "foo",
+			},
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	err := s.updateGuestNetdevMetrics(context.Background())
+	assert.NoError(err)
+
+	for item, expected := range map[string]float64{
+		"rx_bytes":   1000,
+		"rx_dropped": 3,
+		"tx_bytes":   2000,
+		"tx_dropped": 0,
+	} {
+		m := &dto.Metric{}
+		err = katashimGuestNetdev.WithLabelValues("eth0", item).Write(m)
+		assert.NoError(err)
+		assert.Equal(expected, m.GetGauge().GetValue(), item)
+	}
+
+	// eth0 was removed from the guest
+	setGuestNetdevMetrics([]*vc.NetworkStats{{Name: "eth1"}})
+
+	ch := make(chan prometheus.Metric, 32)
+	katashimGuestNetdev.Collect(ch)
+	close(ch)
+	assert.Len(ch, 8)
+	for metric := range ch {
+		m := &dto.Metric{}
+		assert.NoError(metric.Write(m))
+		for _, l := range m.GetLabel() {
+			if l.GetName() == "interface" {
+				assert.Equal("eth1", l.GetValue())
+			}
+		}
+	}
+}
+
+func TestUpdateGuestMemoryMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := &vcmock.Sandbox{
+		MockID: testSandboxID,
+		GuestMemoryStatsFunc: func() (vc.GuestMemoryStats, error) {
+			return vc.GuestMemoryStats{
+				Stats: map[string]uint64{
+					"actual":           2048 << 20,
+					"available_memory": 1024 << 20,
+					"major_faults":     42,
+				},
+			}, nil
+		},
+	}
+
+	s := &service{
+		id:         testSandboxID,
+		sandbox:    sandbox,
+		containers: make(map[string]*container),
+	}
+
+	err := s.updateGuestMemoryMetrics(context.Background())
+	assert.NoError(err)
+
+	for item, expected := range map[string]float64{
+		"actual":           2048 << 20,
+		"available_memory": 1024 << 20,
+		"major_faults":     42,
+	} {
+		m := &dto.Metric{}
+		err = katashimGuestMemory.WithLabelValues(item).Write(m)
+		assert.NoError(err)
+		assert.Equal(expected, m.GetGauge().GetValue(), item)
+	}
+
+	// No statistics without a balloon device
+	setGuestMemoryMetrics(vc.GuestMemoryStats{})
+
+	ch := make(chan prometheus.Metric, 8)
+	katashimGuestMemory.Collect(ch)
+	close(ch)
+	assert.Len(ch, 0)
+}
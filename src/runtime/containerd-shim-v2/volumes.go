@@ -0,0 +1,60 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+)
+
+// VolumeResizeRequest is the body of /volumes/resize requests
+type VolumeResizeRequest = sandboxapi.VolumeResizeRequest
+
+// resizeVolume handles /volumes/resize requests, sent once the device of a
+// block volume has been grown on the host, e.g. by kata-runtime
+// direct-volume resize or by a CSI driver expanding the volume. The guest is
+// told of the new capacity of the device, and the agent grows the volume
+// filesystem, without restarting the pod.
+func (s *service) resizeVolume(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.sandbox == nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("the sandbox is not created yet"))
+		return
+	}
+
+	var req VolumeResizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	if req.VolumePath == "" || req.Size == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("missing volume path or size"))
+		return
+	}
+
+	if err := s.sandbox.ResizeVolume(r.Context(), req.VolumePath, req.Size); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	shimMgtLog.WithFields(logrus.Fields{
+		"volume": req.VolumePath,
+		"size":   req.Size,
+	}).Info("volume resized")
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package containerdshim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/vcmock"
+)
+
+func TestResizeVolume(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &service{
+		id:  testSandboxID,
+		ctx: context.Background(),
+	}
+
+	serve := func(method, body string) int {
+		rr := httptest.NewRecorder()
+		s.resizeVolume(rr, httptest.NewRequest(method, "/volumes/resize", strings.NewReader(body)))
+		return rr.Code
+	}
+
+	// No sandbox is created yet
+	assert.Equal(http.StatusInternalServerError, serve(http.MethodPost, `{"volume_path":"/dev/sdb","size":1024}`))
+
+	resized := map[string]uint64{}
+	s.sandbox = &vcmock.Sandbox{
+		MockID: testSandboxID,
+		ResizeVolumeFunc: func(volumePath string, size uint64) error {
+			if volumePath != "/dev/sdb" {
+				return fmt.Errorf("no block volume %s", volumePath)
+			}
+			resized[volumePath] = size
+			return nil
+		},
+	}
+
+	assert.Equal(http.StatusMethodNotAllowed, serve(http.MethodGet, ""))
+	assert.Equal(http.StatusBadRequest, serve(http.MethodPost, "{"))
+	assert.Equal(http.StatusBadRequest, serve(http.MethodPost, `{"volume_path":"/dev/sdb"}`))
+	assert.Equal(http.StatusInternalServerError, serve(http.MethodPost, `{"volume_path":"/dev/sdc","size":1024}`))
+	assert.Empty(resized)
+
+	assert.Equal(http.StatusOK, serve(http.MethodPost, `{"volume_path":"/dev/sdb","size":1024}`))
+	assert.Equal(map[string]uint64{"/dev/sdb": 1024}, resized)
+}
@@ -14,13 +14,75 @@ import (
 	"github.com/containerd/containerd/api/events"
 	"github.com/containerd/containerd/api/types/task"
 	"github.com/containerd/containerd/mount"
+	"github.com/containerd/typeurl"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
 )
 
-const defaultCheckInterval = 1 * time.Second
+const (
+	defaultCheckInterval = 1 * time.Second
+
+	// guestPanicEventTopic is the containerd event topic of GuestPanic
+	guestPanicEventTopic = "/kata/guest-panic"
+
+	// guestWatchdogEventTopic is the containerd event topic of GuestWatchdog
+	guestWatchdogEventTopic = "/kata/guest-watchdog"
+
+	// virtiofsdCrashEventTopic is the containerd event topic of
+	// VirtiofsdCrash
+	virtiofsdCrashEventTopic = "/kata/virtiofsd-crash"
+)
+
+// GuestPanic is the containerd event published when the guest kernel of the
+// sandbox panicked, right before the sandbox is torn down.
+type GuestPanic struct {
+	SandboxID string `json:"sandbox_id"`
+
+	// Console holds the last lines of the guest console, if debug is
+	// enabled.
+	Console []string `json:"console,omitempty"`
+
+	// DumpDir is the directory holding the guest memory dump, if
+	// guest_memory_dump_path is configured.
+	DumpDir string `json:"dump_dir,omitempty"`
+}
+
+// GuestWatchdog is the containerd event published when the guest watchdog
+// of the sandbox expired.
+type GuestWatchdog struct {
+	SandboxID string `json:"sandbox_id"`
+
+	// Action is the configured watchdog recovery action.
+	Action string `json:"action"`
+}
+
+// VirtiofsdCrash is the containerd event published when virtiofsd quit
+// while the sandbox was running. If it could not be recovered, the
+// containers are failed and the sandbox is torn down.
+type VirtiofsdCrash struct {
+	SandboxID string `json:"sandbox_id"`
+
+	// Containers are the containers whose file systems were served by
+	// virtiofsd.
+	Containers []string `json:"containers"`
+
+	// Recovered is set when virtiofsd was restarted.
+	Recovered bool `json:"recovered"`
+
+	// Error is the error of the last recovery attempt.
+	Error string `json:"error,omitempty"`
+}
+
+func init() {
+	// Kata events aren't protobuf messages, register them so that they
+	// are marshaled to JSON when published.
+	typeurl.Register(&GuestPanic{}, "io.katacontainers.shim.v2.events", "GuestPanic")
+	typeurl.Register(&GuestWatchdog{}, "io.katacontainers.shim.v2.events", "GuestWatchdog")
+	typeurl.Register(&VirtiofsdCrash{}, "io.katacontainers.shim.v2.events", "VirtiofsdCrash")
+}
 
 func wait(ctx context.Context, s *service, c *container, execID string) (int32, error) {
 	var execs *exec
@@ -101,12 +163,54 @@ func watchSandbox(ctx context.Context, s *service) {
 	if s.monitor == nil {
 		return
 	}
-	err := <-s.monitor
+	var err error
+	for {
+		err = <-s.monitor
+
+		// non fatal guest watchdog expirations and recovered virtiofsd
+		// crashes are only reported
+		if wdErr, ok := err.(*vc.GuestWatchdogError); ok {
+			s.send(&GuestWatchdog{
+				SandboxID: wdErr.SandboxID,
+				Action:    wdErr.Action,
+			})
+			if wdErr.Fatal() {
+				break
+			}
+			continue
+		}
+
+		if crashErr, ok := err.(*vc.VirtiofsdCrashError); ok {
+			event := &VirtiofsdCrash{
+				SandboxID:  crashErr.SandboxID,
+				Containers: crashErr.Containers,
+				Recovered:  crashErr.Recovered,
+			}
+			if crashErr.Err != nil && !crashErr.Recovered {
+				event.Error = crashErr.Err.Error()
+			}
+			s.send(event)
+			if crashErr.Fatal() {
+				break
+			}
+			continue
+		}
+
+		break
+	}
 	if err == nil {
 		return
 	}
 	s.monitor = nil
 
+	if panicErr, ok := err.(*vc.GuestPanicError); ok {
+		s.send(&GuestPanic{
+			SandboxID: panicErr.SandboxID,
+			Console:   panicErr.Console,
+			DumpDir:   panicErr.DumpDir,
+		})
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	// sandbox malfunctioning, cleanup as much as we can
@@ -0,0 +1,387 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package volume manages the mount information of the direct assigned
+// volumes, the volumes whose device is passed to the VM and mounted by the
+// guest instead of being shared from the host. The mount information of a
+// volume is written by its CSI driver, or by an admin, under the direct
+// volumes directory, keyed by the volume path.
+package volume
+
+import (
+	"bytes"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+const (
+	mountInfoFileName = "mountInfo.json"
+	sandboxIDFileName = "sandboxId"
+	lockFileName      = ".lock"
+
+	// The volume types of the mount information
+	BlockVolumeType = "block"
+	FileVolumeType  = "file"
+	// The device of the SPDK volumes is the vhost-user socket of the
+	// vhost-user-blk controller exposed by the SPDK vhost target.
+	SPDKVolumeType = "spdk"
+
+	// The metadata of the SPDK volumes: the number and the size of the
+	// virtqueues of the vhost-user-blk device, and the interval in seconds
+	// at which the hypervisor reconnects to the SPDK vhost target once it
+	// went away, e.g. to be upgraded.
+	NumQueuesMetadata = "num_queues"
+	QueueSizeMetadata = "queue_size"
+	ReconnectMetadata = "reconnect"
+
+	// maxQueueSize is the maximum size of a virtqueue
+	maxQueueSize = 1024
+)
+
+var kataDirectVolumeRootPath = "/run/kata-containers/shared/direct-volumes"
+
+// ErrNoMountInfo is returned for the volumes with no mount information, the
+// ones not directly assigned.
+var ErrNoMountInfo = errors.New("no mount info")
+
+// ErrNoSandbox is returned for the direct assigned volumes used by no
+// sandbox.
+var ErrNoSandbox = errors.New("no sandbox")
+
+// MountInfo contains the information to mount a direct assigned volume in
+// the guest.
+type MountInfo struct {
+	// VolumeType is the type of the device, block or file
+	VolumeType string `json:"volume_type"`
+	// Device is the path of the device on the host
+	Device string `json:"device"`
+	// FsType is the type of the filesystem of the device
+	FsType string `json:"fstype"`
+	// Metadata is passed to the guest along with the device
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Options are the mount options of the filesystem
+	Options []string `json:"options,omitempty"`
+}
+
+// Validate checks that the mount information is complete.
+func (m *MountInfo) Validate() error {
+	switch m.VolumeType {
+	case BlockVolumeType, FileVolumeType, SPDKVolumeType:
+	case "":
+		return errors.New("missing volume_type")
+	default:
+		return fmt.Errorf("unknown volume_type %q, expected %q, %q or %q", m.VolumeType, BlockVolumeType, FileVolumeType, SPDKVolumeType)
+	}
+
+	if m.Device == "" {
+		return errors.New("missing device")
+	}
+	if !filepath.IsAbs(m.Device) {
+		return fmt.Errorf("device %q is not an absolute path", m.Device)
+	}
+	if m.FsType == "" {
+		return errors.New("missing fstype")
+	}
+
+	if m.VolumeType == SPDKVolumeType {
+		if _, err := m.VhostUserBlkOptions(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VhostUserBlkOptions are the options of the vhost-user-blk device of an
+// SPDK volume, the hypervisor defaults applying when 0.
+type VhostUserBlkOptions struct {
+	NumQueues uint32
+	QueueSize uint32
+	Reconnect uint32
+}
+
+// VhostUserBlkOptions returns the options of the vhost-user-blk device of an
+// SPDK volume, from its metadata.
+func (m *MountInfo) VhostUserBlkOptions() (VhostUserBlkOptions, error) {
+	var options VhostUserBlkOptions
+
+	for key, value := range map[string]*uint32{
+		NumQueuesMetadata: &options.NumQueues,
+		QueueSizeMetadata: &options.QueueSize,
+		ReconnectMetadata: &options.Reconnect,
+	} {
+		s, ok := m.Metadata[key]
+		if !ok {
+			continue
+		}
+
+		v, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return options, fmt.Errorf("invalid %s metadata %q: %v", key, s, err)
+		}
+		*value = uint32(v)
+	}
+
+	// The virtqueue sizes are powers of 2
+	if options.QueueSize&(options.QueueSize-1) != 0 || options.QueueSize > maxQueueSize {
+		return options, fmt.Errorf("invalid %s metadata %d: expected a power of 2 up to %d", QueueSizeMetadata, options.QueueSize, maxQueueSize)
+	}
+
+	return options, nil
+}
+
+// ParseMountInfo parses and validates mount information in JSON, unknown
+// fields are rejected.
+func ParseMountInfo(data []byte) (*MountInfo, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var mountInfo MountInfo
+	if err := decoder.Decode(&mountInfo); err != nil {
+		return nil, fmt.Errorf("invalid mount info: %v", err)
+	}
+	if err := mountInfo.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid mount info: %v", err)
+	}
+
+	return &mountInfo, nil
+}
+
+func volumeDir(volumePath string) string {
+	return filepath.Join(kataDirectVolumeRootPath, b64.URLEncoding.EncodeToString([]byte(volumePath)))
+}
+
+// lock takes the lock of the direct volumes directory, serializing the
+// updates of the mount information by the CSI drivers and the admins.
+func lock(exclusive bool) (func(), error) {
+	if err := os.MkdirAll(kataDirectVolumeRootPath, 0700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(kataDirectVolumeRootPath, lockFileName), os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	lockType := syscall.LOCK_SH
+	if exclusive {
+		lockType = syscall.LOCK_EX
+	}
+
+	if err := syscall.Flock(int(f.Fd()), lockType); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// Add writes the mount information of the volume at volumePath, replacing
+// any previous one.
+func Add(volumePath string, mountInfo *MountInfo) error {
+	if err := mountInfo.Validate(); err != nil {
+		return fmt.Errorf("invalid mount info: %v", err)
+	}
+
+	data, err := json.Marshal(mountInfo)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	dir := volumeDir(volumePath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// Readers never see a partially written file.
+	tmp, err := ioutil.TempFile(dir, mountInfoFileName)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(dir, mountInfoFileName))
+}
+
+// Remove removes the mount information of the volume at volumePath.
+func Remove(volumePath string) error {
+	unlock, err := lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	dir := volumeDir(volumePath)
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w for volume %s", ErrNoMountInfo, volumePath)
+		}
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// VolumeMountInfo returns the mount information of the volume at
+// volumePath.
+func VolumeMountInfo(volumePath string) (*MountInfo, error) {
+	unlock, err := lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	data, err := ioutil.ReadFile(filepath.Join(volumeDir(volumePath), mountInfoFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w for volume %s", ErrNoMountInfo, volumePath)
+		}
+		return nil, err
+	}
+
+	return ParseMountInfo(data)
+}
+
+// RecordSandboxID records that the sandbox sandboxID uses the volume at
+// volumePath, for the volume to be resized while the sandbox runs.
+func RecordSandboxID(sandboxID, volumePath string) error {
+	unlock, err := lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	dir := volumeDir(volumePath)
+	if _, err := os.Stat(filepath.Join(dir, mountInfoFileName)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w for volume %s", ErrNoMountInfo, volumePath)
+		}
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, sandboxIDFileName), []byte(sandboxID), 0600)
+}
+
+// SandboxID returns the ID of the sandbox which last used the volume at
+// volumePath.
+func SandboxID(volumePath string) (string, error) {
+	unlock, err := lock(false)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	data, err := ioutil.ReadFile(filepath.Join(volumeDir(volumePath), sandboxIDFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w uses volume %s", ErrNoSandbox, volumePath)
+		}
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// VolumeStats are the statistics of a direct assigned volume on the host
+type VolumeStats struct {
+	MountInfo
+	// Size is the size of the device in bytes
+	Size int64 `json:"size"`
+}
+
+// Stats returns the mount information of the volume at volumePath along
+// with the size of its device. The size of the SPDK volumes is only known by
+// their vhost target, it is not reported.
+func Stats(volumePath string) (*VolumeStats, error) {
+	mountInfo, err := VolumeMountInfo(volumePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if mountInfo.VolumeType == SPDKVolumeType {
+		return &VolumeStats{MountInfo: *mountInfo}, nil
+	}
+
+	size, err := deviceSize(mountInfo.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VolumeStats{MountInfo: *mountInfo, Size: size}, nil
+}
+
+// Resize grows the file backing the volume at volumePath to size bytes. The
+// block devices are resized by their storage backend, and the filesystem of
+// the volume by the agent of the sandbox using it.
+func Resize(volumePath string, size int64) error {
+	unlock, err := lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	data, err := ioutil.ReadFile(filepath.Join(volumeDir(volumePath), mountInfoFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w for volume %s", ErrNoMountInfo, volumePath)
+		}
+		return err
+	}
+
+	mountInfo, err := ParseMountInfo(data)
+	if err != nil {
+		return err
+	}
+
+	if mountInfo.VolumeType != FileVolumeType {
+		return fmt.Errorf("volume %s is a %s volume, only %s volumes can be resized", volumePath, mountInfo.VolumeType, FileVolumeType)
+	}
+
+	current, err := deviceSize(mountInfo.Device)
+	if err != nil {
+		return err
+	}
+	if size < current {
+		return fmt.Errorf("cannot shrink volume %s from %d to %d bytes", volumePath, current, size)
+	}
+
+	return os.Truncate(mountInfo.Device, size)
+}
+
+// deviceSize returns the size of a block device or of a regular file.
+func deviceSize(device string) (int64, error) {
+	f, err := os.Open(device)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return f.Seek(0, io.SeekEnd)
+}
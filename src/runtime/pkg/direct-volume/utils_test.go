@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package volume
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMountInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	mountInfo, err := ParseMountInfo([]byte(`{"volume_type":"block","device":"/dev/sdb","fstype":"ext4","options":["ro"]}`))
+	assert.NoError(err)
+	assert.Equal(&MountInfo{VolumeType: "block", Device: "/dev/sdb", FsType: "ext4", Options: []string{"ro"}}, mountInfo)
+
+	for _, data := range []string{
+		`{"volume_type":"block","device":"/dev/sdb"`,
+		`{"volume_type":"block","device":"/dev/sdb","fstype":"ext4","fs_type":"ext4"}`,
+		`{"device":"/dev/sdb","fstype":"ext4"}`,
+		`{"volume_type":"nfs","device":"/dev/sdb","fstype":"ext4"}`,
+		`{"volume_type":"block","fstype":"ext4"}`,
+		`{"volume_type":"block","device":"sdb","fstype":"ext4"}`,
+		`{"volume_type":"block","device":"/dev/sdb"}`,
+		`{"volume_type":"spdk","device":"/var/tmp/vhost.0","fstype":"ext4","metadata":{"num_queues":"four"}}`,
+		`{"volume_type":"spdk","device":"/var/tmp/vhost.0","fstype":"ext4","metadata":{"queue_size":"100"}}`,
+		`{"volume_type":"spdk","device":"/var/tmp/vhost.0","fstype":"ext4","metadata":{"queue_size":"2048"}}`,
+		`{"volume_type":"spdk","device":"/var/tmp/vhost.0","fstype":"ext4","metadata":{"reconnect":"-1"}}`,
+	} {
+		_, err := ParseMountInfo([]byte(data))
+		assert.Error(err, data)
+	}
+}
+
+func TestVhostUserBlkOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	mountInfo, err := ParseMountInfo([]byte(`{"volume_type":"spdk","device":"/var/tmp/vhost.0","fstype":"xfs","metadata":{"num_queues":"4","queue_size":"256","reconnect":"1"}}`))
+	assert.NoError(err)
+
+	options, err := mountInfo.VhostUserBlkOptions()
+	assert.NoError(err)
+	assert.Equal(VhostUserBlkOptions{NumQueues: 4, QueueSize: 256, Reconnect: 1}, options)
+
+	// The hypervisor defaults apply
+	options, err = (&MountInfo{VolumeType: SPDKVolumeType}).VhostUserBlkOptions()
+	assert.NoError(err)
+	assert.Equal(VhostUserBlkOptions{}, options)
+}
+
+func TestDirectVolume(t *testing.T) {
+	assert := assert.New(t)
+
+	root, err := ioutil.TempDir("", "direct-volumes")
+	assert.NoError(err)
+	defer os.RemoveAll(root)
+
+	savedRootPath := kataDirectVolumeRootPath
+	kataDirectVolumeRootPath = filepath.Join(root, "direct-volumes")
+	defer func() {
+		kataDirectVolumeRootPath = savedRootPath
+	}()
+
+	device := filepath.Join(root, "disk.img")
+	assert.NoError(ioutil.WriteFile(device, make([]byte, 4096), 0600))
+
+	volumePath := "/var/lib/kubelet/pods/uid/volumes/kubernetes.io~csi/pvc/mount"
+	mountInfo := &MountInfo{VolumeType: FileVolumeType, Device: device, FsType: "ext4"}
+
+	_, err = VolumeMountInfo(volumePath)
+	assert.Error(err)
+	assert.Error(Add(volumePath, &MountInfo{VolumeType: FileVolumeType, Device: device}))
+
+	assert.NoError(Add(volumePath, mountInfo))
+	got, err := VolumeMountInfo(volumePath)
+	assert.NoError(err)
+	assert.Equal(mountInfo, got)
+
+	stats, err := Stats(volumePath)
+	assert.NoError(err)
+	assert.Equal(int64(4096), stats.Size)
+	assert.Equal(*mountInfo, stats.MountInfo)
+
+	assert.Error(Resize(volumePath, 1024))
+	assert.NoError(Resize(volumePath, 8192))
+	stats, err = Stats(volumePath)
+	assert.NoError(err)
+	assert.Equal(int64(8192), stats.Size)
+
+	_, err = SandboxID(volumePath)
+	assert.True(errors.Is(err, ErrNoSandbox))
+	assert.True(errors.Is(RecordSandboxID("sandbox", "/no/mount/info"), ErrNoMountInfo))
+	assert.NoError(RecordSandboxID("sandbox", volumePath))
+	sandboxID, err := SandboxID(volumePath)
+	assert.NoError(err)
+	assert.Equal("sandbox", sandboxID)
+
+	// The sandbox stays recorded when the mount info is replaced
+	assert.NoError(Add(volumePath, mountInfo))
+	sandboxID, err = SandboxID(volumePath)
+	assert.NoError(err)
+	assert.Equal("sandbox", sandboxID)
+
+	assert.NoError(Remove(volumePath))
+	assert.Error(Remove(volumePath))
+	_, err = VolumeMountInfo(volumePath)
+	assert.True(errors.Is(err, ErrNoMountInfo))
+
+	// The size of the SPDK volumes is not reported
+	spdkInfo := &MountInfo{VolumeType: SPDKVolumeType, Device: "/var/tmp/vhost.0", FsType: "ext4"}
+	assert.NoError(Add(volumePath, spdkInfo))
+	stats, err = Stats(volumePath)
+	assert.NoError(err)
+	assert.Equal(&VolumeStats{MountInfo: *spdkInfo}, stats)
+	assert.NoError(Remove(volumePath))
+
+	// Only the file backed volumes are resized
+	mountInfo.VolumeType = BlockVolumeType
+	assert.NoError(Add(volumePath, mountInfo))
+	assert.Error(Resize(volumePath, 16384))
+}
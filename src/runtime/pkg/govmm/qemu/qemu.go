@@ -111,6 +111,12 @@ const (
 	//VhostUserFS represents a virtio-fs vhostuser device type
 	VhostUserFS DeviceDriver = "vhost-user-fs"
 
+	// VhostUserGPU represents a virtio-gpu vhostuser device type.
+	VhostUserGPU DeviceDriver = "vhost-user-gpu"
+
+	// VirtioSound is the virtio-sound device driver.
+	VirtioSound DeviceDriver = "virtio-sound"
+
 	// PCIBridgeDriver represents a PCI bridge device type.
 	PCIBridgeDriver DeviceDriver = "pci-bridge"
 
@@ -132,6 +138,9 @@ const (
 	// PCIeRootPort is a PCIe Root Port, the PCIe device should be hotplugged to this port.
 	PCIeRootPort DeviceDriver = "pcie-root-port"
 
+	// PCIeExpanderBus is a PCIe Expander Bridge, i.e. an extra PCIe root complex.
+	PCIeExpanderBus DeviceDriver = "pxb-pcie"
+
 	// Loader is the Loader device driver.
 	Loader DeviceDriver = "loader"
 
@@ -264,6 +273,11 @@ type Object struct {
 	// Size is the object size in bytes
 	Size uint64
 
+	// ReadOnly opens the memory backend file in read-only mode, the
+	// nvdimm device is then unarmed.
+	// This is only relevant for memory objects
+	ReadOnly bool
+
 	// Debug this is a debug object
 	Debug bool
 
@@ -311,10 +325,16 @@ func (object Object) QemuParams(config *Config) []string {
 		objectParams = append(objectParams, fmt.Sprintf(",id=%s", object.ID))
 		objectParams = append(objectParams, fmt.Sprintf(",mem-path=%s", object.MemPath))
 		objectParams = append(objectParams, fmt.Sprintf(",size=%d", object.Size))
+		if object.ReadOnly {
+			objectParams = append(objectParams, ",readonly=on")
+		}
 
 		deviceParams = append(deviceParams, string(object.Driver))
 		deviceParams = append(deviceParams, fmt.Sprintf(",id=%s", object.DeviceID))
 		deviceParams = append(deviceParams, fmt.Sprintf(",memdev=%s", object.ID))
+		if object.ReadOnly && object.Driver == NVDIMM {
+			deviceParams = append(deviceParams, ",unarmed=on")
+		}
 	case TDXGuest:
 		objectParams = append(objectParams, string(object.Type))
 		objectParams = append(objectParams, fmt.Sprintf(",id=%s", object.ID))
@@ -798,6 +818,10 @@ type NetDevice struct {
 
 	// Transport is the virtio transport for this device.
 	Transport VirtioTransport
+
+	// HostMTU is the MTU advertised to the guest driver, the default
+	// one is kept when it is 0.
+	HostMTU int
 }
 
 // VirtioNetTransport is a map of the virtio-net device name that corresponds
@@ -861,6 +885,10 @@ func (netdev NetDevice) QemuDeviceParams(config *Config) []string {
 	deviceParams = append(deviceParams, fmt.Sprintf(",netdev=%s", netdev.ID))
 	deviceParams = append(deviceParams, fmt.Sprintf(",mac=%s", netdev.MACAddress))
 
+	if netdev.HostMTU > 0 {
+		deviceParams = append(deviceParams, fmt.Sprintf(",host_mtu=%d", netdev.HostMTU))
+	}
+
 	if netdev.Bus != "" {
 		deviceParams = append(deviceParams, fmt.Sprintf(",bus=%s", netdev.Bus))
 	}
@@ -1104,6 +1132,11 @@ type BlockDevice struct {
 	// ReadOnly sets the block device in readonly mode
 	ReadOnly bool
 
+	// Bus is the bus the device is plugged on, e.g. a PCI bridge.
+	// Addr is the device address on this bus. Both are optional.
+	Bus  string
+	Addr string
+
 	// Transport is the virtio transport for this device.
 	Transport VirtioTransport
 }
@@ -1152,6 +1185,14 @@ func (blkdev BlockDevice) QemuParams(config *Config) []string {
 		deviceParams = append(deviceParams, fmt.Sprintf(",devno=%s", blkdev.DevNo))
 	}
 
+	if blkdev.Bus != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf(",bus=%s", blkdev.Bus))
+	}
+
+	if blkdev.Addr != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf(",addr=%s", blkdev.Addr))
+	}
+
 	if blkdev.ShareRW {
 		deviceParams = append(deviceParams, fmt.Sprintf(",share-rw=on"))
 	}
@@ -1210,6 +1251,95 @@ func (dev PVPanicDevice) QemuParams(config *Config) []string {
 	return []string{"-device", "pvpanic"}
 }
 
+// WatchdogModel is the emulated watchdog device model.
+type WatchdogModel string
+
+const (
+	// I6300ESB is the Intel 6300ESB PCI watchdog.
+	I6300ESB WatchdogModel = "i6300esb"
+
+	// IB700 is the iBASE 700 ISA watchdog.
+	IB700 WatchdogModel = "ib700"
+
+	// Diag288 is the s390x diag 288 watchdog.
+	Diag288 WatchdogModel = "diag288"
+)
+
+// WatchdogAction is the action qemu takes when the guest watchdog expires.
+type WatchdogAction string
+
+const (
+	// WatchdogReset resets the guest.
+	WatchdogReset WatchdogAction = "reset"
+
+	// WatchdogShutdown shuts the guest down gracefully.
+	WatchdogShutdown WatchdogAction = "shutdown"
+
+	// WatchdogPoweroff powers the guest off.
+	WatchdogPoweroff WatchdogAction = "poweroff"
+
+	// WatchdogPause pauses the guest.
+	WatchdogPause WatchdogAction = "pause"
+
+	// WatchdogDebug prints a debug message and leaves the guest running.
+	WatchdogDebug WatchdogAction = "debug"
+
+	// WatchdogNone leaves the guest running, only the WATCHDOG QMP
+	// event is emitted.
+	WatchdogNone WatchdogAction = "none"
+
+	// WatchdogInjectNMI injects a NMI into the guest.
+	WatchdogInjectNMI WatchdogAction = "inject-nmi"
+)
+
+// WatchdogDevice represents a qemu watchdog device.
+type WatchdogDevice struct {
+	// Model is the watchdog device model.
+	Model WatchdogModel
+
+	// ID is the device ID.
+	ID string
+
+	// Action is the action taken when the watchdog expires, qemu
+	// resets the guest by default. Setting it requires qemu 6.0 or
+	// later, which replaced -watchdog-action with -action.
+	Action WatchdogAction
+}
+
+// Valid returns true if the WatchdogDevice structure is valid and complete.
+func (dev WatchdogDevice) Valid() bool {
+	switch dev.Model {
+	case I6300ESB, IB700, Diag288:
+	default:
+		return false
+	}
+
+	switch dev.Action {
+	case "", WatchdogReset, WatchdogShutdown, WatchdogPoweroff, WatchdogPause,
+		WatchdogDebug, WatchdogNone, WatchdogInjectNMI:
+		return true
+	}
+
+	return false
+}
+
+// QemuParams returns the qemu parameters built out of this watchdog device.
+func (dev WatchdogDevice) QemuParams(config *Config) []string {
+	var qemuParams []string
+
+	deviceParam := string(dev.Model)
+	if dev.ID != "" {
+		deviceParam += fmt.Sprintf(",id=%s", dev.ID)
+	}
+	qemuParams = append(qemuParams, "-device", deviceParam)
+
+	if dev.Action != "" {
+		qemuParams = append(qemuParams, "-action", fmt.Sprintf("watchdog=%s", dev.Action))
+	}
+
+	return qemuParams
+}
+
 // LoaderDevice represents a qemu loader device.
 type LoaderDevice struct {
 	File string
@@ -1256,6 +1386,16 @@ type VhostUserDevice struct {
 	SharedVersions bool   //enable virtio-fs shared version metadata
 	VhostUserType  DeviceDriver
 
+	// Reconnect is the interval in seconds at which QEMU reconnects to
+	// the socket once the backend disconnected, QEMU doesn't when it's 0.
+	Reconnect uint32
+
+	// NumQueues and QueueSize are the number and the size of the
+	// virtqueues of a vhost-user-blk device, the QEMU defaults applying
+	// when 0.
+	NumQueues uint32
+	QueueSize uint32
+
 	// ROMFile specifies the ROM file being used for this device.
 	ROMFile string
 
@@ -1298,6 +1438,13 @@ var VhostUserFSTransport = map[VirtioTransport]string{
 	TransportMMIO: "vhost-user-fs-device",
 }
 
+// VhostUserGPUTransport is a map of the vhost-user-gpu device name that
+// corresponds to each transport.
+var VhostUserGPUTransport = map[VirtioTransport]string{
+	TransportPCI:  "vhost-user-gpu-pci",
+	TransportMMIO: "vhost-user-gpu",
+}
+
 // Valid returns true if there is a valid structure defined for VhostUserDevice
 func (vhostuserDev VhostUserDevice) Valid() bool {
 
@@ -1319,6 +1466,7 @@ func (vhostuserDev VhostUserDevice) Valid() bool {
 		if vhostuserDev.Tag == "" {
 			return false
 		}
+	case VhostUserGPU:
 	default:
 		return false
 	}
@@ -1396,6 +1544,12 @@ func (vhostuserDev VhostUserDevice) QemuBlkParams(config *Config) []string {
 	devParams = append(devParams, "logical_block_size=4096")
 	devParams = append(devParams, "size=512M")
 	devParams = append(devParams, fmt.Sprintf("chardev=%s", vhostuserDev.CharDevID))
+	if vhostuserDev.NumQueues != 0 {
+		devParams = append(devParams, fmt.Sprintf("num-queues=%d", vhostuserDev.NumQueues))
+	}
+	if vhostuserDev.QueueSize != 0 {
+		devParams = append(devParams, fmt.Sprintf("queue-size=%d", vhostuserDev.QueueSize))
+	}
 
 	if vhostuserDev.Transport.isVirtioPCI(config) && vhostuserDev.ROMFile != "" {
 		devParams = append(devParams, fmt.Sprintf("romfile=%s", vhostuserDev.ROMFile))
@@ -1439,6 +1593,28 @@ func (vhostuserDev VhostUserDevice) QemuFSParams(config *Config) []string {
 	return qemuParams
 }
 
+// QemuGPUParams builds QEMU device parameters for a VhostUserGPU device
+func (vhostuserDev VhostUserDevice) QemuGPUParams(config *Config) []string {
+	var qemuParams []string
+	var devParams []string
+
+	driver := vhostuserDev.deviceName(config)
+	if driver == "" {
+		return nil
+	}
+
+	devParams = append(devParams, driver)
+	devParams = append(devParams, fmt.Sprintf("chardev=%s", vhostuserDev.CharDevID))
+	if vhostuserDev.Transport.isVirtioPCI(config) && vhostuserDev.ROMFile != "" {
+		devParams = append(devParams, fmt.Sprintf("romfile=%s", vhostuserDev.ROMFile))
+	}
+
+	qemuParams = append(qemuParams, "-device")
+	qemuParams = append(qemuParams, strings.Join(devParams, ","))
+
+	return qemuParams
+}
+
 // QemuParams returns the qemu parameters built out of this vhostuser device.
 func (vhostuserDev VhostUserDevice) QemuParams(config *Config) []string {
 	var qemuParams []string
@@ -1448,6 +1624,9 @@ func (vhostuserDev VhostUserDevice) QemuParams(config *Config) []string {
 	charParams = append(charParams, "socket")
 	charParams = append(charParams, fmt.Sprintf("id=%s", vhostuserDev.CharDevID))
 	charParams = append(charParams, fmt.Sprintf("path=%s", vhostuserDev.SocketPath))
+	if vhostuserDev.Reconnect != 0 {
+		charParams = append(charParams, fmt.Sprintf("reconnect=%d", vhostuserDev.Reconnect))
+	}
 
 	qemuParams = append(qemuParams, "-chardev")
 	qemuParams = append(qemuParams, strings.Join(charParams, ","))
@@ -1461,6 +1640,8 @@ func (vhostuserDev VhostUserDevice) QemuParams(config *Config) []string {
 		devParams = vhostuserDev.QemuBlkParams(config)
 	case VhostUserFS:
 		devParams = vhostuserDev.QemuFSParams(config)
+	case VhostUserGPU:
+		devParams = vhostuserDev.QemuGPUParams(config)
 	default:
 		return nil
 	}
@@ -1488,11 +1669,70 @@ func (vhostuserDev VhostUserDevice) deviceName(config *Config) string {
 		return VhostUserBlkTransport[vhostuserDev.Transport]
 	case VhostUserFS:
 		return VhostUserFSTransport[vhostuserDev.Transport]
+	case VhostUserGPU:
+		return VhostUserGPUTransport[vhostuserDev.Transport]
 	default:
 		return ""
 	}
 }
 
+// VirtioSoundDevice represents a virtio-sound device, its audio being
+// played and recorded through a QEMU audio backend.
+type VirtioSoundDevice struct {
+	// ID is the ID of the device, the audio backend being <ID>-audiodev.
+	ID string
+
+	// AudioDriver is the driver of the audio backend, e.g. none or wav.
+	AudioDriver string
+
+	// ROMFile specifies the ROM file being used for this device.
+	ROMFile string
+
+	// Transport is the virtio transport for this device.
+	Transport VirtioTransport
+}
+
+// VirtioSoundTransport is a map of the virtio-sound device name that
+// corresponds to each transport.
+var VirtioSoundTransport = map[VirtioTransport]string{
+	TransportPCI:  "virtio-sound-pci",
+	TransportMMIO: "virtio-sound-device",
+}
+
+// Valid returns true if the VirtioSoundDevice structure is valid and complete.
+func (s VirtioSoundDevice) Valid() bool {
+	return s.ID != "" && s.AudioDriver != ""
+}
+
+// QemuParams returns the qemu parameters built out of the VirtioSoundDevice.
+func (s VirtioSoundDevice) QemuParams(config *Config) []string {
+	driver := s.deviceName(config)
+	if driver == "" {
+		return nil
+	}
+
+	audiodev := fmt.Sprintf("%s-audiodev", s.ID)
+	devParams := []string{driver, fmt.Sprintf("id=%s", s.ID), fmt.Sprintf("audiodev=%s", audiodev)}
+	if s.Transport.isVirtioPCI(config) && s.ROMFile != "" {
+		devParams = append(devParams, fmt.Sprintf("romfile=%s", s.ROMFile))
+	}
+
+	return []string{
+		"-audiodev", fmt.Sprintf("%s,id=%s", s.AudioDriver, audiodev),
+		"-device", strings.Join(devParams, ","),
+	}
+}
+
+// deviceName returns the QEMU device name for the current combination of
+// driver and transport.
+func (s VirtioSoundDevice) deviceName(config *Config) string {
+	if s.Transport == "" {
+		s.Transport = s.Transport.defaultTransport(config)
+	}
+
+	return VirtioSoundTransport[s.Transport]
+}
+
 // PCIeRootPortDevice represents a memory balloon device.
 type PCIeRootPortDevice struct {
 	ID string // format: rp{n}, n>=0
@@ -1592,11 +1832,47 @@ func (b PCIeRootPortDevice) Valid() bool {
 	return true
 }
 
+// PCIeExpanderBusDevice represents a PCIe expander bridge, an extra PCIe root
+// complex of the guest the PCIe root ports can be plugged on.
+type PCIeExpanderBusDevice struct {
+	ID string
+
+	Bus string // default is pcie.0
+
+	// BusNr is the bus number of the root complex, the buses below it are
+	// numbered from BusNr+1, up to the bus number of the next root complex.
+	BusNr uint32
+}
+
+// QemuParams returns the qemu parameters built out of the PCIeExpanderBusDevice.
+func (b PCIeExpanderBusDevice) QemuParams(config *Config) []string {
+	var deviceParams []string
+
+	deviceParams = append(deviceParams, fmt.Sprintf("%s,id=%s", PCIeExpanderBus, b.ID))
+
+	if b.Bus == "" {
+		b.Bus = "pcie.0"
+	}
+	deviceParams = append(deviceParams, fmt.Sprintf("bus=%s", b.Bus))
+	deviceParams = append(deviceParams, fmt.Sprintf("bus_nr=%d", b.BusNr))
+
+	return []string{"-device", strings.Join(deviceParams, ",")}
+}
+
+// Valid returns true if the PCIeExpanderBusDevice structure is valid and complete.
+func (b PCIeExpanderBusDevice) Valid() bool {
+	return b.ID != "" && b.BusNr > 0 && b.BusNr < 256
+}
+
 // VFIODevice represents a qemu vfio device meant for direct access by guest OS.
 type VFIODevice struct {
 	// Bus-Device-Function of device
 	BDF string
 
+	// SysfsDev is the sysfs path of the device, used instead of BDF for the
+	// mediated devices, e.g. /sys/bus/mdev/devices/<uuid>.
+	SysfsDev string
+
 	// ROMFile specifies the ROM file being used for this device.
 	ROMFile string
 
@@ -1626,7 +1902,7 @@ var VFIODeviceTransport = map[VirtioTransport]string{
 
 // Valid returns true if the VFIODevice structure is valid and complete.
 func (vfioDev VFIODevice) Valid() bool {
-	return vfioDev.BDF != ""
+	return vfioDev.BDF != "" || vfioDev.SysfsDev != ""
 }
 
 // QemuParams returns the qemu parameters built out of this vfio device.
@@ -1636,7 +1912,11 @@ func (vfioDev VFIODevice) QemuParams(config *Config) []string {
 
 	driver := vfioDev.deviceName(config)
 
-	deviceParams = append(deviceParams, fmt.Sprintf("%s,host=%s", driver, vfioDev.BDF))
+	if vfioDev.SysfsDev != "" {
+		deviceParams = append(deviceParams, fmt.Sprintf("%s,sysfsdev=%s", driver, vfioDev.SysfsDev))
+	} else {
+		deviceParams = append(deviceParams, fmt.Sprintf("%s,host=%s", driver, vfioDev.BDF))
+	}
 	if vfioDev.Transport.isVirtioPCI(config) {
 		if vfioDev.VendorID != "" {
 			deviceParams = append(deviceParams, fmt.Sprintf(",x-pci-vendor-id=%s", vfioDev.VendorID))
@@ -1951,7 +2231,7 @@ type RngDevice struct {
 	Filename string
 	// MaxBytes is the bytes allowed to guest to get from the host’s entropy per period
 	MaxBytes uint
-	// Period is duration of a read period in seconds
+	// Period is duration of a read period in milliseconds
 	Period uint
 	// ROMFile specifies the ROM file being used for this device.
 	ROMFile string
@@ -2282,6 +2562,10 @@ type Memory struct {
 	// Path is the file path of the memory device. It points to a local
 	// file path used by FileBackedMem.
 	Path string
+
+	// HostNodes are the host NUMA nodes the memory is bound to, e.g. "0"
+	// or "0-1".
+	HostNodes string
 }
 
 // Kernel is the guest kernel configuration structure.
@@ -2493,6 +2777,10 @@ type Config struct {
 	// LogFile is the -D parameter
 	LogFile string
 
+	// SysProcAttr holds the attributes the qemu process is started with,
+	// e.g. the cgroup it is cloned into.
+	SysProcAttr *syscall.SysProcAttr
+
 	qemuParams []string
 }
 
@@ -2720,6 +3008,9 @@ func (config *Config) appendMemoryKnobs() {
 	if config.Knobs.MemPrealloc {
 		objMemParam += ",prealloc=on"
 	}
+	if config.Memory.HostNodes != "" {
+		objMemParam += ",host-nodes=" + config.Memory.HostNodes + ",policy=bind"
+	}
 	config.qemuParams = append(config.qemuParams, "-object")
 	config.qemuParams = append(config.qemuParams, objMemParam)
 
@@ -2881,7 +3172,7 @@ func LaunchQemu(config Config, logger QMPLog) (string, error) {
 	}
 
 	return LaunchCustomQemu(ctx, config.Path, config.qemuParams,
-		config.fds, nil, logger)
+		config.fds, config.SysProcAttr, logger)
 }
 
 // LaunchCustomQemu can be used to launch a new qemu instance.
@@ -183,6 +183,12 @@ type MemoryDevices struct {
 	Type string            `json:"type"`
 }
 
+// IOThreadInfo represents information about an iothread of the VM
+type IOThreadInfo struct {
+	ID       string `json:"id"`
+	ThreadID int    `json:"thread-id"`
+}
+
 // CPUInfo represents information about each virtual CPU
 type CPUInfo struct {
 	CPU      int           `json:"CPU"`
@@ -826,6 +832,63 @@ func (q *QMP) ExecuteBlockdevAddWithCache(ctx context.Context, device, blockdevI
 	return q.executeCommand(ctx, "blockdev-add", args, nil)
 }
 
+// ExecuteBlockdevAddWithDiscard has the same parameters as
+// ExecuteBlockdevAdd, the discard requests of the guest being passed to
+// device so that the blocks it frees are freed on the host too, e.g. the
+// blocks of a sparse file.
+func (q *QMP) ExecuteBlockdevAddWithDiscard(ctx context.Context, device, blockdevID string, ro bool) error {
+	args, blockdevArgs := q.blockdevAddBaseArgs(device, blockdevID, ro)
+
+	blockdevArgs["discard"] = "unmap"
+
+	return q.executeCommand(ctx, "blockdev-add", args, nil)
+}
+
+// BlockdevOptions are the options of a block device added with
+// ExecuteBlockdevAddWithOptions, the zero value leaving the QEMU defaults.
+type BlockdevOptions struct {
+	// CacheSet sets the cache options CacheDirect and CacheNoFlush, as
+	// ExecuteBlockdevAddWithCache does.
+	CacheSet     bool
+	CacheDirect  bool
+	CacheNoFlush bool
+
+	// Discard passes the discard requests of the guest to the device.
+	Discard bool
+
+	// DetectZeroes is the detect-zeroes mode of the device, "off", "on"
+	// or "unmap", the latter requiring Discard.
+	DetectZeroes string
+}
+
+// ExecuteBlockdevAddWithOptions has the same parameters as
+// ExecuteBlockdevAdd, along with the options of the device.
+func (q *QMP) ExecuteBlockdevAddWithOptions(ctx context.Context, device, blockdevID string, ro bool, options BlockdevOptions) error {
+	args, blockdevArgs := q.blockdevAddBaseArgs(device, blockdevID, ro)
+
+	if options.CacheSet {
+		if q.version.Major < 2 || (q.version.Major == 2 && q.version.Minor < 9) {
+			return fmt.Errorf("versions of qemu (%d.%d) older than 2.9 do not support set cache-related options for block devices",
+				q.version.Major, q.version.Minor)
+		}
+
+		blockdevArgs["cache"] = map[string]interface{}{
+			"direct":   options.CacheDirect,
+			"no-flush": options.CacheNoFlush,
+		}
+	}
+
+	if options.Discard {
+		blockdevArgs["discard"] = "unmap"
+	}
+
+	if options.DetectZeroes != "" {
+		blockdevArgs["detect-zeroes"] = options.DetectZeroes
+	}
+
+	return q.executeCommand(ctx, "blockdev-add", args, nil)
+}
+
 // ExecuteDeviceAdd adds the guest portion of a device to a QEMU instance
 // using the device_add command.  blockdevID should match the blockdevID passed
 // to a previous call to ExecuteBlockdevAdd.  devID is the id of the device to
@@ -876,6 +939,14 @@ func (q *QMP) ExecuteDeviceAdd(ctx context.Context, blockdevID, devID, driver, b
 // former version 0.9, as there is a KVM bug that occurs when using virtio
 // 1.0 in nested environments.
 func (q *QMP) ExecuteSCSIDeviceAdd(ctx context.Context, blockdevID, devID, driver, bus, romfile string, scsiID, lun int, shared, disableModern bool) error {
+	return q.ExecuteSCSIDeviceAddWithSerial(ctx, blockdevID, devID, driver, bus, romfile, "", scsiID, lun, shared, disableModern)
+}
+
+// ExecuteSCSIDeviceAddWithSerial has one more parameter serial than
+// ExecuteSCSIDeviceAdd, the serial number of the SCSI disk, which is
+// reported in its vital product data. The default serial number is used if
+// serial is empty.
+func (q *QMP) ExecuteSCSIDeviceAddWithSerial(ctx context.Context, blockdevID, devID, driver, bus, romfile, serial string, scsiID, lun int, shared, disableModern bool) error {
 	// TBD: Add drivers for scsi passthrough like scsi-generic and scsi-block
 	drivers := []string{"scsi-hd", "scsi-cd", "scsi-disk"}
 
@@ -907,6 +978,9 @@ func (q *QMP) ExecuteSCSIDeviceAdd(ctx context.Context, blockdevID, devID, drive
 	if shared && (q.version.Major > 2 || (q.version.Major == 2 && q.version.Minor >= 10)) {
 		args["share-rw"] = "on"
 	}
+	if serial != "" {
+		args["serial"] = serial
+	}
 
 	return q.executeCommand(ctx, "device_add", args, nil)
 }
@@ -932,6 +1006,24 @@ func (q *QMP) ExecuteBlockdevDel(ctx context.Context, blockdevID string) error {
 	return q.executeCommand(ctx, "x-blockdev-del", args, nil)
 }
 
+// ExecuteBlockResize resizes a block device to size bytes by sending a
+// block_resize command, the guest is notified of its new capacity.
+// blockdevID is the id of the block device to be resized.  Typically, this
+// will match the id passed to ExecuteBlockdevAdd.  It must be a valid QMP id.
+func (q *QMP) ExecuteBlockResize(ctx context.Context, blockdevID string, size uint64) error {
+	args := map[string]interface{}{
+		"size": size,
+	}
+
+	if q.version.Major > 2 || (q.version.Major == 2 && q.version.Minor >= 8) {
+		args["node-name"] = blockdevID
+	} else {
+		args["device"] = blockdevID
+	}
+
+	return q.executeCommand(ctx, "block_resize", args, nil)
+}
+
 // ExecuteChardevDel deletes a char device by sending a chardev-remove command.
 // chardevID is the id of the char device to be deleted. Typically, this will
 // match the id passed to ExecuteCharDevUnixSocketAdd. It must be a valid QMP id.
@@ -1013,6 +1105,12 @@ func (q *QMP) ExecuteNetdevDel(ctx context.Context, netdevID string) error {
 // former version 0.9, as there is a KVM bug that occurs when using virtio
 // 1.0 in nested environments.
 func (q *QMP) ExecuteNetPCIDeviceAdd(ctx context.Context, netdevID, devID, macAddr, addr, bus, romfile string, queues int, disableModern bool) error {
+	return q.ExecuteNetPCIDeviceAddWithMTU(ctx, netdevID, devID, macAddr, addr, bus, romfile, queues, disableModern, 0)
+}
+
+// ExecuteNetPCIDeviceAddWithMTU is the same as ExecuteNetPCIDeviceAdd but
+// also advertises hostMTU to the guest driver, unless it is 0.
+func (q *QMP) ExecuteNetPCIDeviceAddWithMTU(ctx context.Context, netdevID, devID, macAddr, addr, bus, romfile string, queues int, disableModern bool, hostMTU int) error {
 	args := map[string]interface{}{
 		"id":      devID,
 		"driver":  VirtioNetPCI,
@@ -1046,6 +1144,10 @@ func (q *QMP) ExecuteNetPCIDeviceAdd(ctx context.Context, netdevID, devID, macAd
 		args["vectors"] = 2*queues + 2
 	}
 
+	if hostMTU > 0 {
+		args["host_mtu"] = hostMTU
+	}
+
 	return q.executeCommand(ctx, "device_add", args, nil)
 }
 
@@ -1054,6 +1156,12 @@ func (q *QMP) ExecuteNetPCIDeviceAdd(ctx context.Context, netdevID, devID, macAd
 // Must be valid QMP identifier. netdevID is the id of nic added by previous netdev_add.
 // queues is the number of queues of a nic.
 func (q *QMP) ExecuteNetCCWDeviceAdd(ctx context.Context, netdevID, devID, macAddr, bus string, queues int) error {
+	return q.ExecuteNetCCWDeviceAddWithMTU(ctx, netdevID, devID, macAddr, bus, queues, 0)
+}
+
+// ExecuteNetCCWDeviceAddWithMTU is the same as ExecuteNetCCWDeviceAdd but
+// also advertises hostMTU to the guest driver, unless it is 0.
+func (q *QMP) ExecuteNetCCWDeviceAddWithMTU(ctx context.Context, netdevID, devID, macAddr, bus string, queues, hostMTU int) error {
 	args := map[string]interface{}{
 		"id":     devID,
 		"driver": VirtioNetCCW,
@@ -1066,6 +1174,10 @@ func (q *QMP) ExecuteNetCCWDeviceAdd(ctx context.Context, netdevID, devID, macAd
 		args["mq"] = "on"
 	}
 
+	if hostMTU > 0 {
+		args["host_mtu"] = hostMTU
+	}
+
 	return q.executeCommand(ctx, "device_add", args, nil)
 }
 
@@ -1095,6 +1207,14 @@ func (q *QMP) ExecuteDeviceDel(ctx context.Context, devID string) error {
 // former version 0.9, as there is a KVM bug that occurs when using virtio
 // 1.0 in nested environments.
 func (q *QMP) ExecutePCIDeviceAdd(ctx context.Context, blockdevID, devID, driver, addr, bus, romfile string, queues int, shared, disableModern bool) error {
+	return q.ExecutePCIDeviceAddWithIOThread(ctx, blockdevID, devID, driver, addr, bus, romfile, "", queues, shared, disableModern)
+}
+
+// ExecutePCIDeviceAddWithIOThread is the same as ExecutePCIDeviceAdd but
+// the device IO is processed by the iothread ioThread, e.g. one added through
+// ExecuteIOThreadAdd. ioThread is optional, it is only supported by virtio-blk
+// and virtio-scsi devices.
+func (q *QMP) ExecutePCIDeviceAddWithIOThread(ctx context.Context, blockdevID, devID, driver, addr, bus, romfile, ioThread string, queues int, shared, disableModern bool) error {
 	args := map[string]interface{}{
 		"id":     devID,
 		"driver": driver,
@@ -1104,6 +1224,9 @@ func (q *QMP) ExecutePCIDeviceAdd(ctx context.Context, blockdevID, devID, driver
 	if bus != "" {
 		args["bus"] = bus
 	}
+	if ioThread != "" {
+		args["iothread"] = ioThread
+	}
 	if shared && (q.version.Major > 2 || (q.version.Major == 2 && q.version.Minor >= 10)) {
 		args["share-rw"] = "on"
 	}
@@ -1145,6 +1268,31 @@ func (q *QMP) ExecutePCIVhostUserDevAdd(ctx context.Context, driver, devID, char
 	return q.executeCommand(ctx, "device_add", args, nil)
 }
 
+// ExecutePCIVhostUserBlkDevAdd adds a vhost-user-blk device to a QEMU instance
+// using the device_add command, like ExecutePCIVhostUserDevAdd. numQueues and
+// queueSize are the number and the size of the virtqueues of the device, the
+// QEMU defaults applying when 0.
+func (q *QMP) ExecutePCIVhostUserBlkDevAdd(ctx context.Context, driver, devID, chardevID, addr, bus string, numQueues, queueSize uint32) error {
+	args := map[string]interface{}{
+		"driver":  driver,
+		"id":      devID,
+		"chardev": chardevID,
+		"addr":    addr,
+	}
+
+	if bus != "" {
+		args["bus"] = bus
+	}
+	if numQueues != 0 {
+		args["num-queues"] = numQueues
+	}
+	if queueSize != 0 {
+		args["queue-size"] = queueSize
+	}
+
+	return q.executeCommand(ctx, "device_add", args, nil)
+}
+
 // ExecuteVFIODeviceAdd adds a VFIO device to a QEMU instance using the device_add command.
 // devID is the id of the device to add. Must be valid QMP identifier.
 // bdf is the PCI bus-device-function of the pci device.
@@ -1214,8 +1362,10 @@ func (q *QMP) ExecutePCIVFIOMediatedDeviceAdd(ctx context.Context, devID, sysfsd
 }
 
 // ExecuteAPVFIOMediatedDeviceAdd adds a VFIO mediated AP device to a QEMU instance using the device_add command.
-func (q *QMP) ExecuteAPVFIOMediatedDeviceAdd(ctx context.Context, sysfsdev string) error {
+// devID is the id of the device to add. Must be valid QMP identifier. sysfsdev is the VFIO mediated device.
+func (q *QMP) ExecuteAPVFIOMediatedDeviceAdd(ctx context.Context, devID, sysfsdev string) error {
 	args := map[string]interface{}{
+		"id":       devID,
 		"driver":   VfioAP,
 		"sysfsdev": sysfsdev,
 	}
@@ -1385,6 +1535,51 @@ func (q *QMP) ExecQueryCpusFast(ctx context.Context) ([]CPUInfoFast, error) {
 	return cpuInfoFast, nil
 }
 
+// ExecQueryIOThreads returns a slice with the list of iothreads of the VM
+func (q *QMP) ExecQueryIOThreads(ctx context.Context) ([]IOThreadInfo, error) {
+	response, err := q.executeCommandWithResponse(ctx, "query-iothreads", nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert response to json
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract iothreads information: %v", err)
+	}
+
+	var ioThreads []IOThreadInfo
+	// convert json to []IOThreadInfo
+	if err = json.Unmarshal(data, &ioThreads); err != nil {
+		return nil, fmt.Errorf("unable to convert json to IOThreadInfo: %v", err)
+	}
+
+	return ioThreads, nil
+}
+
+// ExecuteIOThreadAdd adds an iothread object to the VM using the object-add
+// command. id is the id of the iothread, it must be a valid QMP identifier.
+// Devices can then be attached to the iothread, e.g. through
+// ExecutePCIDeviceAddWithIOThread.
+func (q *QMP) ExecuteIOThreadAdd(ctx context.Context, id string) error {
+	args := map[string]interface{}{
+		"qom-type": "iothread",
+		"id":       id,
+	}
+
+	return q.executeCommand(ctx, "object-add", args, nil)
+}
+
+// ExecuteIOThreadDel removes an iothread object from the VM using the
+// object-del command. No device must be attached to the iothread.
+func (q *QMP) ExecuteIOThreadDel(ctx context.Context, id string) error {
+	args := map[string]interface{}{
+		"id": id,
+	}
+
+	return q.executeCommand(ctx, "object-del", args, nil)
+}
+
 // ExecMemdevAdd adds size of MiB memory device to the guest
 func (q *QMP) ExecMemdevAdd(ctx context.Context, qomtype, id, mempath string, size int, share bool, driver, driverID, addr, bus string) error {
 	props := map[string]interface{}{"size": uint64(size) << 20}
@@ -1491,6 +1686,67 @@ func (q *QMP) ExecuteBalloon(ctx context.Context, bytes uint64) error {
 	return q.executeCommand(ctx, "balloon", args, nil)
 }
 
+// BalloonInfo is the balloon information returned by query-balloon.
+type BalloonInfo struct {
+	// Actual is the memory size of the guest, in bytes, i.e. its RAM
+	// size minus the size of the balloon.
+	Actual int64 `json:"actual"`
+}
+
+// BalloonGuestStats are the guest memory statistics of a virtio-balloon
+// device, polled by QEMU once its guest-stats-polling-interval property is
+// set.
+type BalloonGuestStats struct {
+	// LastUpdate is the time, in seconds since the Epoch, the statistics
+	// were last updated, 0 if they never were.
+	LastUpdate int64 `json:"last-update"`
+
+	// Stats are the statistics by name, e.g. "stat-available-memory",
+	// -1 when the guest doesn't report them.
+	Stats map[string]int64 `json:"stats"`
+}
+
+// ExecuteQueryBalloon returns the balloon information of the VM.
+func (q *QMP) ExecuteQueryBalloon(ctx context.Context) (BalloonInfo, error) {
+	response, err := q.executeCommandWithResponse(ctx, "query-balloon", nil, nil, nil)
+	if err != nil {
+		return BalloonInfo{}, err
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return BalloonInfo{}, fmt.Errorf("unable to extract balloon information: %v", err)
+	}
+
+	var info BalloonInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		return BalloonInfo{}, fmt.Errorf("unable to convert balloon information: %v", err)
+	}
+
+	return info, nil
+}
+
+// ExecuteQueryBalloonGuestStats returns the guest memory statistics of the
+// virtio-balloon device at the QOM path, e.g. its ID.
+func (q *QMP) ExecuteQueryBalloonGuestStats(ctx context.Context, path string) (BalloonGuestStats, error) {
+	response, err := q.ExecQomGet(ctx, path, "guest-stats")
+	if err != nil {
+		return BalloonGuestStats{}, err
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return BalloonGuestStats{}, fmt.Errorf("unable to extract balloon guest stats: %v", err)
+	}
+
+	var stats BalloonGuestStats
+	if err = json.Unmarshal(data, &stats); err != nil {
+		return BalloonGuestStats{}, fmt.Errorf("unable to convert balloon guest stats: %v", err)
+	}
+
+	return stats, nil
+}
+
 // ExecutePCIVSockAdd adds a vhost-vsock-pci bus
 // disableModern indicates if virtio version 1.0 should be replaced by the
 // former version 0.9, as there is a KVM bug that occurs when using virtio
@@ -1550,6 +1806,36 @@ func (q *QMP) ExecuteCharDevUnixSocketAdd(ctx context.Context, id, path string,
 	return q.executeCommand(ctx, "chardev-add", args, nil)
 }
 
+// ExecuteCharDevUnixSocketReconnectAdd adds a character device using as
+// backend a unix socket QEMU connects to, like ExecuteCharDevUnixSocketAdd
+// without server. reconnect is the interval in seconds at which QEMU
+// reconnects to the socket once it got disconnected, it does not when 0.
+func (q *QMP) ExecuteCharDevUnixSocketReconnectAdd(ctx context.Context, id, path string, reconnect uint32) error {
+	data := map[string]interface{}{
+		"wait":   false,
+		"server": false,
+		"addr": map[string]interface{}{
+			"type": "unix",
+			"data": map[string]interface{}{
+				"path": path,
+			},
+		},
+	}
+
+	if reconnect != 0 {
+		data["reconnect"] = reconnect
+	}
+
+	args := map[string]interface{}{
+		"id": id,
+		"backend": map[string]interface{}{
+			"type": "socket",
+			"data": data,
+		},
+	}
+	return q.executeCommand(ctx, "chardev-add", args, nil)
+}
+
 // ExecuteVirtSerialPortAdd adds a virtserialport.
 // id is an identifier for the virtserialport, name is a name for the virtserialport and
 // it will be visible in the VM, chardev is the character device id previously added.
@@ -1592,6 +1878,15 @@ func (q *QMP) ExecuteMigrationIncoming(ctx context.Context, uri string) error {
 	return q.executeCommand(ctx, "migrate-incoming", args, nil)
 }
 
+// ExecuteMigrateContinue continues a migration paused in the given state,
+// e.g. "pre-switchover" when the pause-before-switchover capability is set.
+func (q *QMP) ExecuteMigrateContinue(ctx context.Context, state string) error {
+	args := map[string]interface{}{
+		"state": state,
+	}
+	return q.executeCommand(ctx, "migrate-continue", args, nil)
+}
+
 // ExecQueryQmpSchema query all QMP wire ABI and returns a slice
 func (q *QMP) ExecQueryQmpSchema(ctx context.Context) ([]SchemaInfo, error) {
 	response, err := q.executeCommandWithResponse(ctx, "query-qmp-schema", nil, nil, nil)
@@ -0,0 +1,227 @@
+/*
+// Copyright contributors to the Virtual Machine Manager for Go project
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+*/
+
+package qemu
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+const (
+	// EventBlockIOError is emitted when a disk I/O error occurs.
+	EventBlockIOError = "BLOCK_IO_ERROR"
+
+	// EventDeviceDeleted is emitted when the guest has released a
+	// device, i.e. when a device_del has completed.
+	EventDeviceDeleted = "DEVICE_DELETED"
+
+	// EventGuestPanicked is emitted when the guest kernel panics.
+	EventGuestPanicked = "GUEST_PANICKED"
+
+	// EventRTCChange is emitted when the guest changes the RTC time.
+	EventRTCChange = "RTC_CHANGE"
+
+	// EventWatchdog is emitted when the guest watchdog expires.
+	EventWatchdog = "WATCHDOG"
+)
+
+// BlockIOErrorEvent is the data of a BLOCK_IO_ERROR event.
+type BlockIOErrorEvent struct {
+	// Device is the block device backend name, it may be empty when
+	// the device has no backend name.
+	Device string `json:"device"`
+
+	// NodeName is the node name of the block driver state.
+	NodeName string `json:"node-name"`
+
+	// Operation is the I/O operation, read or write.
+	Operation string `json:"operation"`
+
+	// Action is the action taken by QEMU, ignore, report or stop.
+	Action string `json:"action"`
+
+	// NoSpace is true if the error is caused by a lack of space.
+	NoSpace bool `json:"nospace"`
+
+	// Reason is the human readable description of the error.
+	Reason string `json:"reason"`
+}
+
+// DeviceDeletedEvent is the data of a DEVICE_DELETED event.
+type DeviceDeletedEvent struct {
+	// Device is the device ID, it is empty for devices without ID.
+	Device string `json:"device"`
+
+	// Path is the QOM path of the device.
+	Path string `json:"path"`
+}
+
+// GuestPanickedEvent is the data of a GUEST_PANICKED event.
+type GuestPanickedEvent struct {
+	// Action is the action taken by QEMU, pause, poweroff or run.
+	Action string `json:"action"`
+
+	// Info is the optional hypervisor specific information about the
+	// panic.
+	Info map[string]interface{} `json:"info"`
+}
+
+// RTCChangeEvent is the data of a RTC_CHANGE event.
+type RTCChangeEvent struct {
+	// Offset is the offset, in seconds, between the base of the RTC
+	// clock and the new guest RTC time.
+	Offset int64 `json:"offset"`
+}
+
+// WatchdogEvent is the data of a WATCHDOG event.
+type WatchdogEvent struct {
+	// Action is the action taken by QEMU, e.g. reset or none.
+	Action string `json:"action"`
+}
+
+// QMPEventHandler is a function called with the QMP events it has been
+// registered for.
+type QMPEventHandler func(QMPEvent)
+
+// QMPEventRouter dispatches the QMP events read from the QMPConfig.EventCh
+// channel to the handlers registered for them, so that several components
+// can be notified of the events of a single QMP connection.
+//
+// The handlers are called one after the other from the goroutine running
+// Run and must not block it, a handler issuing QMP commands or doing a
+// lengthy processing of an event must do so from another goroutine.
+type QMPEventRouter struct {
+	sync.Mutex
+	logger   QMPLog
+	handlers map[string]map[int]QMPEventHandler
+	nextID   int
+}
+
+// NewQMPEventRouter returns a QMPEventRouter with no handlers, logging the
+// events it fails to decode to logger, which may be nil.
+func NewQMPEventRouter(logger QMPLog) *QMPEventRouter {
+	if logger == nil {
+		logger = qmpNullLogger{}
+	}
+
+	return &QMPEventRouter{
+		logger:   logger,
+		handlers: make(map[string]map[int]QMPEventHandler),
+	}
+}
+
+// Subscribe registers handler for the events named name, or for all the
+// events if name is empty. The returned function unregisters the handler.
+func (r *QMPEventRouter) Subscribe(name string, handler QMPEventHandler) func() {
+	r.Lock()
+	defer r.Unlock()
+
+	id := r.nextID
+	r.nextID++
+
+	if r.handlers[name] == nil {
+		r.handlers[name] = make(map[int]QMPEventHandler)
+	}
+	r.handlers[name][id] = handler
+
+	return func() {
+		r.Lock()
+		defer r.Unlock()
+		delete(r.handlers[name], id)
+	}
+}
+
+// Run dispatches the events read from events to their handlers until the
+// channel is closed, i.e. until the QMP connection is closed.
+func (r *QMPEventRouter) Run(events <-chan QMPEvent) {
+	for ev := range events {
+		r.dispatch(ev)
+	}
+}
+
+func (r *QMPEventRouter) dispatch(ev QMPEvent) {
+	r.Lock()
+	handlers := make([]QMPEventHandler, 0, len(r.handlers[""])+len(r.handlers[ev.Name]))
+	for _, name := range []string{"", ev.Name} {
+		for _, h := range r.handlers[name] {
+			handlers = append(handlers, h)
+		}
+	}
+	r.Unlock()
+
+	for _, h := range handlers {
+		h(ev)
+	}
+}
+
+// subscribeTyped registers a handler decoding the data of the events named
+// name into a new value returned by newData.
+func (r *QMPEventRouter) subscribeTyped(name string, newData func() interface{}, handler func(interface{})) func() {
+	return r.Subscribe(name, func(ev QMPEvent) {
+		data := newData()
+		if err := decodeQMPEventData(ev, data); err != nil {
+			r.logger.Errorf("Unable to decode %s event data %v: %v", ev.Name, ev.Data, err)
+			return
+		}
+		handler(data)
+	})
+}
+
+// OnBlockIOError registers handler for the BLOCK_IO_ERROR events.
+func (r *QMPEventRouter) OnBlockIOError(handler func(BlockIOErrorEvent)) func() {
+	return r.subscribeTyped(EventBlockIOError, func() interface{} { return &BlockIOErrorEvent{} },
+		func(data interface{}) { handler(*data.(*BlockIOErrorEvent)) })
+}
+
+// OnDeviceDeleted registers handler for the DEVICE_DELETED events.
+func (r *QMPEventRouter) OnDeviceDeleted(handler func(DeviceDeletedEvent)) func() {
+	return r.subscribeTyped(EventDeviceDeleted, func() interface{} { return &DeviceDeletedEvent{} },
+		func(data interface{}) { handler(*data.(*DeviceDeletedEvent)) })
+}
+
+// OnGuestPanicked registers handler for the GUEST_PANICKED events.
+func (r *QMPEventRouter) OnGuestPanicked(handler func(GuestPanickedEvent)) func() {
+	return r.subscribeTyped(EventGuestPanicked, func() interface{} { return &GuestPanickedEvent{} },
+		func(data interface{}) { handler(*data.(*GuestPanickedEvent)) })
+}
+
+// OnRTCChange registers handler for the RTC_CHANGE events.
+func (r *QMPEventRouter) OnRTCChange(handler func(RTCChangeEvent)) func() {
+	return r.subscribeTyped(EventRTCChange, func() interface{} { return &RTCChangeEvent{} },
+		func(data interface{}) { handler(*data.(*RTCChangeEvent)) })
+}
+
+// OnWatchdog registers handler for the WATCHDOG events.
+func (r *QMPEventRouter) OnWatchdog(handler func(WatchdogEvent)) func() {
+	return r.subscribeTyped(EventWatchdog, func() interface{} { return &WatchdogEvent{} },
+		func(data interface{}) { handler(*data.(*WatchdogEvent)) })
+}
+
+// decodeQMPEventData unmarshals the data of ev into v. The event data is
+// kept unprocessed by the QMP loop, as a generic JSON map.
+func decodeQMPEventData(ev QMPEvent, v interface{}) error {
+	if ev.Data == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(ev.Data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
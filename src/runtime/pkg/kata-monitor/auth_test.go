@@ -0,0 +1,151 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCert writes a certificate for 127.0.0.1 and its key in dir, signed by
+// the parent certificate or self-signed when parent is nil.
+func writeCert(t *testing.T, dir, name string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	assert := assert.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(err)
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(err)
+	return cert, key
+}
+
+func TestTLSConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-monitor-tls")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ca, caKey := writeCert(t, dir, "ca", true, nil, nil)
+	writeCert(t, dir, "server", false, ca, caKey)
+	writeCert(t, dir, "client", false, ca, caKey)
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	_, err = TLSConfig(path("server.crt"), "", "")
+	assert.Error(err)
+	_, err = TLSConfig(path("server.crt"), path("client.key"), "")
+	assert.Error(err)
+	_, err = TLSConfig(path("server.crt"), path("server.key"), path("server.key"))
+	assert.Error(err)
+
+	config, err := TLSConfig(path("server.crt"), path("server.key"), "")
+	assert.NoError(err)
+	assert.Equal(tls.NoClientCert, config.ClientAuth)
+
+	config, err = TLSConfig(path("server.crt"), path("server.key"), path("ca.crt"))
+	assert.NoError(err)
+	assert.Equal(tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = config
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+
+	// The client certificate is required
+	_, err = client.Get(srv.URL)
+	assert.Error(err)
+
+	clientCert, err := tls.LoadX509KeyPair(path("client.crt"), path("client.key"))
+	assert.NoError(err)
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{clientCert}
+
+	resp, err := client.Get(srv.URL)
+	assert.NoError(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusOK, resp.StatusCode)
+}
+
+func TestTokenAuth(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-monitor-token")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, "token")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err = TokenAuth(handler, tokenFile)
+	assert.Error(err)
+
+	assert.NoError(ioutil.WriteFile(tokenFile, []byte("\n"), 0600))
+	_, err = TokenAuth(handler, tokenFile)
+	assert.Error(err)
+
+	assert.NoError(ioutil.WriteFile(tokenFile, []byte("s3cr3t\n"), 0600))
+	auth, err := TokenAuth(handler, tokenFile)
+	assert.NoError(err)
+
+	for header, status := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"s3cr3t":        http.StatusUnauthorized,
+		"Basic s3cr3t":  http.StatusUnauthorized,
+		"Bearer s3cr3":  http.StatusUnauthorized,
+		"Bearer s3cr3t": http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rr := httptest.NewRecorder()
+		auth.ServeHTTP(rr, req)
+		assert.Equal(status, rr.Code, header)
+	}
+}
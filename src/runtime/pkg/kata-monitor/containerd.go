@@ -76,6 +76,9 @@ func (ka *KataMonitor) getSandboxes() (map[string]string, error) {
 	sandboxMap := make(map[string]string)
 
 	for _, namespace := range namespaceList {
+		if !ka.isWatchedNamespace(namespace) {
+			continue
+		}
 
 		initSandboxByNamespaceFunc := func(namespace string) error {
 			ctx := context.Background()
@@ -0,0 +1,232 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const (
+	// criPollInterval is the interval between two listings of the
+	// sandboxes of a CRI endpoint, the CRI has no events.
+	criPollInterval = 10 * time.Second
+
+	criListPodSandboxMethod = "/runtime.v1alpha2.RuntimeService/ListPodSandbox"
+
+	// criSandboxReady is the PodSandboxState of the running sandboxes.
+	criSandboxReady = 0
+)
+
+// The CRI messages used to list the sandboxes, limited to the fields
+// kata-monitor needs. They are marshalled like the messages generated from
+// k8s.io/cri-api/pkg/apis/runtime/v1alpha2/api.proto, through their field
+// tags.
+
+type criPodSandboxStateValue struct {
+	State int32 `protobuf:"varint,1,opt,name=state,proto3"`
+}
+
+func (m *criPodSandboxStateValue) Reset()         { *m = criPodSandboxStateValue{} }
+func (m *criPodSandboxStateValue) String() string { return fmt.Sprintf("%+v", *m) }
+func (*criPodSandboxStateValue) ProtoMessage()    {}
+
+type criPodSandboxFilter struct {
+	State *criPodSandboxStateValue `protobuf:"bytes,2,opt,name=state,proto3"`
+}
+
+func (m *criPodSandboxFilter) Reset()         { *m = criPodSandboxFilter{} }
+func (m *criPodSandboxFilter) String() string { return fmt.Sprintf("%+v", *m) }
+func (*criPodSandboxFilter) ProtoMessage()    {}
+
+type criListPodSandboxRequest struct {
+	Filter *criPodSandboxFilter `protobuf:"bytes,1,opt,name=filter,proto3"`
+}
+
+func (m *criListPodSandboxRequest) Reset()         { *m = criListPodSandboxRequest{} }
+func (m *criListPodSandboxRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*criListPodSandboxRequest) ProtoMessage()    {}
+
+type criPodSandboxMetadata struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3"`
+	UID       string `protobuf:"bytes,2,opt,name=uid,proto3"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3"`
+}
+
+func (m *criPodSandboxMetadata) Reset()         { *m = criPodSandboxMetadata{} }
+func (m *criPodSandboxMetadata) String() string { return fmt.Sprintf("%+v", *m) }
+func (*criPodSandboxMetadata) ProtoMessage()    {}
+
+type criPodSandbox struct {
+	ID             string                 `protobuf:"bytes,1,opt,name=id,proto3"`
+	Metadata       *criPodSandboxMetadata `protobuf:"bytes,2,opt,name=metadata,proto3"`
+	State          int32                  `protobuf:"varint,3,opt,name=state,proto3"`
+	RuntimeHandler string                 `protobuf:"bytes,7,opt,name=runtime_handler,proto3"`
+}
+
+func (m *criPodSandbox) Reset()         { *m = criPodSandbox{} }
+func (m *criPodSandbox) String() string { return fmt.Sprintf("%+v", *m) }
+func (*criPodSandbox) ProtoMessage()    {}
+
+type criListPodSandboxResponse struct {
+	Items []*criPodSandbox `protobuf:"bytes,1,rep,name=items,proto3"`
+}
+
+func (m *criListPodSandboxResponse) Reset()         { *m = criListPodSandboxResponse{} }
+func (m *criListPodSandboxResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*criListPodSandboxResponse) ProtoMessage()    {}
+
+// criEndpoint discovers the Kata sandboxes of a CRI runtime, e.g. CRI-O, by
+// polling its ready sandboxes.
+type criEndpoint struct {
+	address string
+
+	// isKataSandbox tells the Kata sandboxes, the CRI doesn't give the
+	// runtime of a sandbox but its handler, whose name is up to the
+	// runtime configuration.
+	isKataSandbox func(sandboxID string) bool
+
+	// sandboxes are the Kata sandboxes of the endpoint in the cache, others
+	// the sandboxes of other runtimes, not to check them at every poll.
+	sync.Mutex
+	sandboxes map[string]struct{}
+	others    map[string]struct{}
+}
+
+func newCRIEndpoint(address string) *criEndpoint {
+	return &criEndpoint{
+		address:       strings.TrimPrefix(address, "unix://"),
+		isKataSandbox: IsSandboxAlive,
+		sandboxes:     make(map[string]struct{}),
+		others:        make(map[string]struct{}),
+	}
+}
+
+// listReadySandboxes returns the ready sandboxes of the endpoint.
+func (ce *criEndpoint) listReadySandboxes(ctx context.Context) ([]*criPodSandbox, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, ce.address, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", addr)
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to CRI endpoint %s: %v", ce.address, err)
+	}
+	defer conn.Close()
+
+	req := &criListPodSandboxRequest{
+		Filter: &criPodSandboxFilter{
+			State: &criPodSandboxStateValue{State: criSandboxReady},
+		},
+	}
+	resp := &criListPodSandboxResponse{}
+	if err := conn.Invoke(ctx, criListPodSandboxMethod, req, resp); err != nil {
+		return nil, fmt.Errorf("failed to list the sandboxes of CRI endpoint %s: %v", ce.address, err)
+	}
+
+	return resp.Items, nil
+}
+
+// refresh updates the cache with the Kata sandboxes of the endpoint, the
+// sandboxes gone since the previous refresh are removed.
+func (ce *criEndpoint) refresh(ctx context.Context, sc *sandboxCache) error {
+	items, err := ce.listReadySandboxes(ctx)
+	if err != nil {
+		return err
+	}
+
+	ready := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		ready[item.ID] = struct{}{}
+
+		if ce.hasSandbox(item.ID) {
+			continue
+		}
+		if _, found := ce.others[item.ID]; found {
+			continue
+		}
+
+		if !ce.isKataSandbox(item.ID) {
+			ce.others[item.ID] = struct{}{}
+			continue
+		}
+
+		// There are no namespaces in the CRI, the pod namespace is
+		// only informative.
+		namespace := ""
+		if item.Metadata != nil {
+			namespace = item.Metadata.Namespace
+		}
+
+		ce.Lock()
+		ce.sandboxes[item.ID] = struct{}{}
+		ce.Unlock()
+		sc.putIfNotExists(item.ID, namespace)
+		monitorLog.WithFields(logrus.Fields{"sandbox": item.ID, "endpoint": ce.address}).Info("add sandbox to cache")
+	}
+
+	ce.Lock()
+	for id := range ce.sandboxes {
+		if _, found := ready[id]; !found {
+			delete(ce.sandboxes, id)
+			_, deleted := sc.deleteIfExists(id)
+			monitorLog.WithFields(logrus.Fields{"sandbox": id, "endpoint": ce.address, "result": deleted}).Info("delete sandbox from cache")
+		}
+	}
+	ce.Unlock()
+
+	for id := range ce.others {
+		if _, found := ready[id]; !found {
+			delete(ce.others, id)
+		}
+	}
+
+	return nil
+}
+
+// hasSandbox returns true if the sandbox is a Kata sandbox of the endpoint.
+func (ce *criEndpoint) hasSandbox(sandboxID string) bool {
+	ce.Lock()
+	defer ce.Unlock()
+
+	_, found := ce.sandboxes[sandboxID]
+	return found
+}
+
+// startPolling refreshes the cache with the sandboxes of the endpoint every
+// criPollInterval.
+func (ce *criEndpoint) startPolling(sc *sandboxCache) {
+	ticker := time.NewTicker(criPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := ce.refresh(context.Background(), sc); err != nil {
+			monitorLog.WithError(err).Warn("failed to refresh the sandboxes of CRI endpoint")
+		}
+	}
+}
+
+// isCRISandbox returns true if the sandbox was discovered through a CRI
+// endpoint.
+func (km *KataMonitor) isCRISandbox(sandboxID string) bool {
+	for _, ce := range km.criEndpoints {
+		if ce.hasSandbox(sandboxID) {
+			return true
+		}
+	}
+
+	return false
+}
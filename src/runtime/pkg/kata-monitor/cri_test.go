@@ -0,0 +1,153 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeCRIServer serves ListPodSandbox with its sandboxes.
+type fakeCRIServer struct {
+	sync.Mutex
+	sandboxes []*criPodSandbox
+}
+
+func (f *fakeCRIServer) listPodSandbox(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := &criListPodSandboxRequest{}
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	resp := &criListPodSandboxResponse{}
+	for _, s := range f.sandboxes {
+		if req.Filter == nil || req.Filter.State == nil || req.Filter.State.State == s.State {
+			resp.Items = append(resp.Items, s)
+		}
+	}
+
+	return resp, nil
+}
+
+func startFakeCRIServer(t *testing.T, f *fakeCRIServer) (string, func()) {
+	dir, err := ioutil.TempDir("", "cri")
+	assert.NoError(t, err)
+
+	address := filepath.Join(dir, "cri.sock")
+	l, err := net.Listen("unix", address)
+	assert.NoError(t, err)
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "runtime.v1alpha2.RuntimeService",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "ListPodSandbox",
+				Handler:    f.listPodSandbox,
+			},
+		},
+	}, f)
+	go server.Serve(l)
+
+	return "unix://" + address, func() {
+		server.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCRIEndpointRefresh(t *testing.T) {
+	assert := assert.New(t)
+
+	f := &fakeCRIServer{
+		sandboxes: []*criPodSandbox{
+			{ID: "kata", Metadata: &criPodSandboxMetadata{Namespace: "default"}, State: criSandboxReady},
+			{ID: "runc", State: criSandboxReady},
+			{ID: "stopped", State: criSandboxReady + 1},
+		},
+	}
+	address, stop := startFakeCRIServer(t, f)
+	defer stop()
+
+	sc := &sandboxCache{
+		Mutex:     &sync.Mutex{},
+		sandboxes: map[string]string{"containerd": "k8s.io"},
+	}
+
+	checked := make(map[string]int)
+	ce := newCRIEndpoint(address)
+	ce.isKataSandbox = func(sandboxID string) bool {
+		checked[sandboxID]++
+		return sandboxID != "runc"
+	}
+
+	assert.NoError(ce.refresh(context.Background(), sc))
+	assert.Equal(map[string]string{"containerd": "k8s.io", "kata": "default"}, sc.getAllSandboxes())
+	assert.True(ce.hasSandbox("kata"))
+	assert.False(ce.hasSandbox("runc"))
+
+	// The known sandboxes are not checked again
+	assert.NoError(ce.refresh(context.Background(), sc))
+	assert.Equal(map[string]int{"kata": 1, "runc": 1}, checked)
+
+	f.Lock()
+	f.sandboxes = f.sandboxes[1:]
+	f.Unlock()
+
+	assert.NoError(ce.refresh(context.Background(), sc))
+	assert.Equal(map[string]string{"containerd": "k8s.io"}, sc.getAllSandboxes())
+	assert.False(ce.hasSandbox("kata"))
+
+	stop()
+	assert.Error(ce.refresh(context.Background(), sc))
+}
+
+func TestGetMonitorAddressCRISandbox(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "state")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ce := newCRIEndpoint("/cri.sock")
+	ce.sandboxes["crio"] = struct{}{}
+
+	km := &KataMonitor{
+		containerdStatePath: dir,
+		criEndpoints:        []*criEndpoint{ce},
+	}
+
+	address, err := km.getMonitorAddress("crio", "default")
+	assert.NoError(err)
+	assert.Equal(sandboxapi.SocketAddress("crio"), address)
+
+	_, err = km.getMonitorAddress("containerd", "k8s.io")
+	assert.Error(err)
+}
+
+func TestIsWatchedNamespace(t *testing.T) {
+	assert := assert.New(t)
+
+	km := &KataMonitor{}
+	assert.True(km.isWatchedNamespace("k8s.io"))
+
+	km.containerdNamespaces = []string{"k8s.io", "moby"}
+	assert.True(km.isWatchedNamespace("k8s.io"))
+	assert.True(km.isWatchedNamespace("moby"))
+	assert.False(km.isWatchedNamespace("default"))
+}
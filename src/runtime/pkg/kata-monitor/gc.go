@@ -0,0 +1,50 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils"
+)
+
+// StartSandboxGC periodically looks for the resources of sandboxes whose
+// shim is gone, and cleans them up if remove is set. Sandbox storage
+// younger than minAge is left alone. The binaries run for the sandboxes are
+// those of the runtime configuration file configPath, or of the default one
+// if it is empty.
+func (km *KataMonitor) StartSandboxGC(interval, minAge time.Duration, remove bool, configPath string) error {
+	_, runtimeConfig, err := katautils.LoadConfiguration(configPath, true)
+	if err != nil {
+		return err
+	}
+
+	gc, err := katautils.NewSandboxGC(IsSandboxAlive, minAge, katautils.SandboxBinaries(runtimeConfig))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			orphans, err := gc.Run(remove)
+			if err != nil {
+				monitorLog.WithError(err).Error("sandbox garbage collection failed")
+			}
+			for _, o := range orphans {
+				if remove {
+					monitorLog.WithField("sandbox", o.ID).Info("collected orphan sandbox")
+				} else {
+					monitorLog.WithField("sandbox", o.ID).Warn("found orphan sandbox")
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -8,15 +8,19 @@ package katamonitor
 import (
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/types"
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 
@@ -74,19 +78,98 @@ func registerMetrics() {
 
 // getMonitorAddress get metrics address for a sandbox, the abstract unix socket address is saved
 // in `metrics_address` with the same place of `address`.
+// The shims of the sandboxes of the CRI endpoints save it elsewhere, it is
+// the well known address of their sandbox then.
 func (km *KataMonitor) getMonitorAddress(sandboxID, namespace string) (string, error) {
 	path := filepath.Join(km.containerdStatePath, types.ContainerdRuntimeTaskPath, namespace, sandboxID, "monitor_address")
 	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && km.isCRISandbox(sandboxID) {
+		return sandboxapi.SocketAddress(sandboxID), nil
+	}
 	if err != nil {
 		return "", err
 	}
 	return string(data), nil
 }
 
+// scrapeOptions select the sandboxes and the metrics of a scrape.
+type scrapeOptions struct {
+	// sandboxes to scrape, all of them when empty
+	sandboxes map[string]bool
+	// namespaces of the sandboxes to scrape, all of them when empty
+	namespaces map[string]bool
+	// runtimeOnly skips the guest metrics, i.e. the agent is not queried
+	runtimeOnly bool
+	// aggregate sums the metrics of all the sandboxes, dropping their
+	// sandbox_id label
+	aggregate bool
+}
+
+// parseScrapeOptions parses the sandbox and namespace query parameters, which
+// may be repeated, and the aggregate one.
+func parseScrapeOptions(r *http.Request, runtimeOnly bool) (scrapeOptions, error) {
+	query := r.URL.Query()
+	opts := scrapeOptions{
+		sandboxes:   make(map[string]bool),
+		namespaces:  make(map[string]bool),
+		runtimeOnly: runtimeOnly,
+	}
+
+	for _, sandbox := range query["sandbox"] {
+		opts.sandboxes[sandbox] = true
+	}
+	for _, namespace := range query["namespace"] {
+		opts.namespaces[namespace] = true
+	}
+
+	if value := query.Get("aggregate"); value != "" {
+		aggregate, err := strconv.ParseBool(value)
+		if err != nil {
+			return opts, fmt.Errorf("invalid aggregate %q", value)
+		}
+		opts.aggregate = aggregate
+	}
+
+	return opts, nil
+}
+
+// selected returns true if the sandbox of the namespace is scraped.
+func (opts scrapeOptions) selected(sandboxID, namespace string) bool {
+	if len(opts.sandboxes) > 0 && !opts.sandboxes[sandboxID] {
+		return false
+	}
+
+	return len(opts.namespaces) == 0 || opts.namespaces[namespace]
+}
+
+// isGuestMetric returns true for the metrics gathered from the guest, by the
+// agent or by the shim through the agent.
+func isGuestMetric(name string) bool {
+	return strings.HasPrefix(name, "kata_agent_") ||
+		strings.HasPrefix(name, "kata_guest_") ||
+		strings.HasPrefix(name, "kata_shim_guest_")
+}
+
 // ProcessMetricsRequest get metrics from shim/hypervisor/vm/agent and return metrics to client.
 func (km *KataMonitor) ProcessMetricsRequest(w http.ResponseWriter, r *http.Request) {
+	km.serveMetrics(w, r, false)
+}
+
+// ProcessRuntimeMetricsRequest get the metrics of the shim, the hypervisor and
+// virtiofsd, without the guest ones, and return metrics to client.
+func (km *KataMonitor) ProcessRuntimeMetricsRequest(w http.ResponseWriter, r *http.Request) {
+	km.serveMetrics(w, r, true)
+}
+
+func (km *KataMonitor) serveMetrics(w http.ResponseWriter, r *http.Request, runtimeOnly bool) {
 	start := time.Now()
 
+	opts, err := parseScrapeOptions(r, runtimeOnly)
+	if err != nil {
+		commonServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	scrapeCount.Inc()
 	defer func() {
 		scrapeDurationsHistogram.Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
@@ -130,7 +213,7 @@ func (km *KataMonitor) ProcessMetricsRequest(w http.ResponseWriter, r *http.Requ
 	}
 
 	// aggregate sandboxes metrics and write to response by encoder
-	if err := km.aggregateSandboxMetrics(encoder); err != nil {
+	if err := km.aggregateSandboxMetrics(encoder, opts); err != nil {
 		monitorLog.WithError(err).Errorf("failed aggregateSandboxMetrics")
 		scrapeFailedCount.Inc()
 	}
@@ -153,11 +236,18 @@ func encodeMetricFamily(mfs []*dto.MetricFamily, encoder expfmt.Encoder) error {
 }
 
 // aggregateSandboxMetrics will get metrics from one sandbox and do some process
-func (km *KataMonitor) aggregateSandboxMetrics(encoder expfmt.Encoder) error {
+func (km *KataMonitor) aggregateSandboxMetrics(encoder expfmt.Encoder, opts scrapeOptions) error {
 	// get all sandboxes from cache
-	sandboxes := km.sandboxCache.getAllSandboxes()
+	allSandboxes := km.sandboxCache.getAllSandboxes()
 	// save running kata pods as a metrics.
-	runningShimCount.Set(float64(len(sandboxes)))
+	runningShimCount.Set(float64(len(allSandboxes)))
+
+	sandboxes := make(map[string]string)
+	for sandboxID, namespace := range allSandboxes {
+		if opts.selected(sandboxID, namespace) {
+			sandboxes[sandboxID] = namespace
+		}
+	}
 
 	if len(sandboxes) == 0 {
 		return nil
@@ -176,7 +266,7 @@ func (km *KataMonitor) aggregateSandboxMetrics(encoder expfmt.Encoder) error {
 	for sandboxID, namespace := range sandboxes {
 		wg.Add(1)
 		go func(sandboxID, namespace string, results chan<- []*dto.MetricFamily) {
-			sandboxMetrics, err := getParsedMetrics(sandboxID)
+			sandboxMetrics, err := getParsedMetrics(sandboxID, opts.runtimeOnly)
 			if err != nil {
 				monitorLog.WithError(err).WithField("sandbox_id", sandboxID).Errorf("failed to get metrics for sandbox")
 			}
@@ -214,6 +304,12 @@ func (km *KataMonitor) aggregateSandboxMetrics(encoder expfmt.Encoder) error {
 			mf := sandboxMetrics[j]
 			key := *mf.Name
 
+			// the shims not supporting the runtime only metrics return
+			// the guest ones as well
+			if opts.runtimeOnly && isGuestMetric(key) {
+				continue
+			}
+
 			// add MetricFamily.Metric to the exists MetricFamily instance
 			if oldmf, found := metricsMap[key]; found {
 				oldmf.Metric = append(oldmf.Metric, mf.Metric...)
@@ -225,6 +321,9 @@ func (km *KataMonitor) aggregateSandboxMetrics(encoder expfmt.Encoder) error {
 
 	// write metrics to response.
 	for _, mf := range metricsMap {
+		if opts.aggregate {
+			aggregateMetricFamily(mf)
+		}
 		if err := encoder.Encode(mf); err != nil {
 			return err
 		}
@@ -233,8 +332,13 @@ func (km *KataMonitor) aggregateSandboxMetrics(encoder expfmt.Encoder) error {
 
 }
 
-func getParsedMetrics(sandboxID string) ([]*dto.MetricFamily, error) {
-	body, err := doGet(sandboxID, defaultTimeout, "metrics")
+func getParsedMetrics(sandboxID string, runtimeOnly bool) ([]*dto.MetricFamily, error) {
+	urlPath := "metrics"
+	if runtimeOnly {
+		urlPath = "metrics?guest=false"
+	}
+
+	body, err := doGet(sandboxID, defaultTimeout, urlPath)
 	if err != nil {
 		return nil, err
 	}
@@ -242,6 +346,96 @@ func getParsedMetrics(sandboxID string) ([]*dto.MetricFamily, error) {
 	return parsePrometheusMetrics(sandboxID, body)
 }
 
+// aggregateMetricFamily sums the metrics of the sandboxes having the same
+// labels but the sandbox_id one. The quantiles of the summaries cannot be
+// summed, only their counts and sums are kept.
+func aggregateMetricFamily(mf *dto.MetricFamily) {
+	merged := make(map[string]*dto.Metric)
+	metrics := make([]*dto.Metric, 0, len(mf.Metric))
+
+	for _, m := range mf.Metric {
+		labels := make([]*dto.LabelPair, 0, len(m.Label))
+		keys := make([]string, 0, len(m.Label))
+		for _, l := range m.Label {
+			if l.GetName() == "sandbox_id" {
+				continue
+			}
+			labels = append(labels, l)
+			keys = append(keys, l.GetName()+"="+l.GetValue())
+		}
+		sort.Strings(keys)
+		key := strings.Join(keys, ",")
+
+		if old, found := merged[key]; found {
+			mergeMetric(old, m)
+			continue
+		}
+
+		m.Label = labels
+		m.TimestampMs = nil
+		if m.Counter != nil {
+			m.Counter.Exemplar = nil
+		}
+		if m.Summary != nil {
+			m.Summary.Quantile = nil
+		}
+		if m.Histogram != nil {
+			for _, b := range m.Histogram.Bucket {
+				b.Exemplar = nil
+			}
+		}
+
+		merged[key] = m
+		metrics = append(metrics, m)
+	}
+
+	mf.Metric = metrics
+}
+
+func addFloat(a *float64, b float64) *float64 {
+	sum := b
+	if a != nil {
+		sum += *a
+	}
+	return &sum
+}
+
+func addUint(a *uint64, b uint64) *uint64 {
+	sum := b
+	if a != nil {
+		sum += *a
+	}
+	return &sum
+}
+
+// mergeMetric adds the values of m to the ones of dst.
+func mergeMetric(dst, m *dto.Metric) {
+	switch {
+	case dst.Counter != nil && m.Counter != nil:
+		dst.Counter.Value = addFloat(dst.Counter.Value, m.Counter.GetValue())
+	case dst.Gauge != nil && m.Gauge != nil:
+		dst.Gauge.Value = addFloat(dst.Gauge.Value, m.Gauge.GetValue())
+	case dst.Untyped != nil && m.Untyped != nil:
+		dst.Untyped.Value = addFloat(dst.Untyped.Value, m.Untyped.GetValue())
+	case dst.Summary != nil && m.Summary != nil:
+		dst.Summary.SampleCount = addUint(dst.Summary.SampleCount, m.Summary.GetSampleCount())
+		dst.Summary.SampleSum = addFloat(dst.Summary.SampleSum, m.Summary.GetSampleSum())
+	case dst.Histogram != nil && m.Histogram != nil:
+		dst.Histogram.SampleCount = addUint(dst.Histogram.SampleCount, m.Histogram.GetSampleCount())
+		dst.Histogram.SampleSum = addFloat(dst.Histogram.SampleSum, m.Histogram.GetSampleSum())
+		// the sandboxes are expected to share the buckets boundaries,
+		// the buckets of the others are dropped
+		for _, b := range dst.Histogram.Bucket {
+			for _, mb := range m.Histogram.Bucket {
+				if mb.GetUpperBound() == b.GetUpperBound() {
+					b.CumulativeCount = addUint(b.CumulativeCount, mb.GetCumulativeCount())
+					break
+				}
+			}
+		}
+	}
+}
+
 // GetSandboxMetrics will get sandbox's metrics from shim
 func GetSandboxMetrics(sandboxID string) (string, error) {
 	body, err := doGet(sandboxID, defaultTimeout, "metrics")
@@ -7,10 +7,13 @@ package katamonitor
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 )
@@ -137,3 +140,91 @@ func TestEncodeMetricFamily(t *testing.T) {
 		}
 	}
 }
+
+func TestParseScrapeOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/metrics?sandbox=a&sandbox=b&namespace=k8s.io&aggregate=true", nil)
+	opts, err := parseScrapeOptions(r, true)
+	assert.NoError(err)
+	assert.True(opts.runtimeOnly)
+	assert.True(opts.aggregate)
+	assert.True(opts.selected("a", "k8s.io"))
+	assert.True(opts.selected("b", "k8s.io"))
+	assert.False(opts.selected("c", "k8s.io"))
+	assert.False(opts.selected("a", "moby"))
+
+	r = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	opts, err = parseScrapeOptions(r, false)
+	assert.NoError(err)
+	assert.False(opts.aggregate)
+	assert.True(opts.selected("c", "moby"))
+
+	r = httptest.NewRequest(http.MethodGet, "/metrics?aggregate=sure", nil)
+	_, err = parseScrapeOptions(r, false)
+	assert.Error(err)
+
+	assert.True(isGuestMetric("kata_agent_total_rss"))
+	assert.True(isGuestMetric("kata_guest_meminfo"))
+	assert.True(isGuestMetric("kata_shim_guest_netdev"))
+	assert.False(isGuestMetric("kata_shim_netdev"))
+	assert.False(isGuestMetric("kata_hypervisor_proc_status"))
+}
+
+func TestAggregateMetricFamily(t *testing.T) {
+	assert := assert.New(t)
+
+	body := `# HELP kata_hypervisor_fds Open FDs for hypervisor.
+# TYPE kata_hypervisor_fds gauge
+kata_hypervisor_fds 11
+# HELP kata_shim_netdev Kata containerd shim network devices statistics.
+# TYPE kata_shim_netdev gauge
+kata_shim_netdev{interface="lo",item="recv_bytes"} 100
+kata_shim_netdev{interface="eth0",item="recv_bytes"} 1000
+# HELP kata_shim_rpc_durations_histogram_milliseconds RPC latency distributions.
+# TYPE kata_shim_rpc_durations_histogram_milliseconds histogram
+kata_shim_rpc_durations_histogram_milliseconds_bucket{action="create",le="1"} 0
+kata_shim_rpc_durations_histogram_milliseconds_bucket{action="create",le="2"} 1
+kata_shim_rpc_durations_histogram_milliseconds_bucket{action="create",le="+Inf"} 1
+kata_shim_rpc_durations_histogram_milliseconds_sum{action="create"} 1.5
+kata_shim_rpc_durations_histogram_milliseconds_count{action="create"} 1
+`
+
+	var families []*dto.MetricFamily
+	for _, sandboxID := range []string{"sandbox-a", "sandbox-b", "sandbox-c"} {
+		list, err := parsePrometheusMetrics(sandboxID, []byte(body))
+		assert.NoError(err)
+		if families == nil {
+			families = list
+			continue
+		}
+		for i := range list {
+			families[i].Metric = append(families[i].Metric, list[i].Metric...)
+		}
+	}
+
+	for _, mf := range families {
+		aggregateMetricFamily(mf)
+		for _, m := range mf.Metric {
+			for _, l := range m.Label {
+				assert.NotEqual("sandbox_id", l.GetName())
+			}
+		}
+	}
+
+	assert.Equal("kata_hypervisor_fds", families[0].GetName())
+	assert.Len(families[0].Metric, 1)
+	assert.Equal(33.0, families[0].Metric[0].GetGauge().GetValue())
+
+	assert.Equal("kata_shim_netdev", families[1].GetName())
+	assert.Len(families[1].Metric, 2)
+	assert.Equal(300.0, families[1].Metric[0].GetGauge().GetValue())
+	assert.Equal(3000.0, families[1].Metric[1].GetGauge().GetValue())
+
+	histogram := families[2].Metric[0].GetHistogram()
+	assert.Len(families[2].Metric, 1)
+	assert.Equal(uint64(3), histogram.GetSampleCount())
+	assert.Equal(4.5, histogram.GetSampleSum())
+	assert.Equal(uint64(0), histogram.Bucket[0].GetCumulativeCount())
+	assert.Equal(uint64(3), histogram.Bucket[1].GetCumulativeCount())
+}
@@ -6,6 +6,7 @@
 package katamonitor
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -32,13 +33,19 @@ type KataMonitor struct {
 	containerdAddr       string
 	containerdConfigFile string
 	containerdStatePath  string
+	containerdNamespaces []string
+	criEndpoints         []*criEndpoint
 	sandboxCache         *sandboxCache
+	statsHistory         *statsHistory
 }
 
-// NewKataMonitor create and return a new KataMonitor instance
-func NewKataMonitor(containerdAddr, containerdConfigFile string) (*KataMonitor, error) {
-	if containerdAddr == "" {
-		return nil, fmt.Errorf("containerd serve address missing")
+// NewKataMonitor create and return a new KataMonitor instance. The sandboxes
+// are discovered in the containerdNamespaces of containerd, all of them when
+// empty, and through the CRI endpoints of the other runtimes, e.g. CRI-O on
+// the same node.
+func NewKataMonitor(containerdAddr, containerdConfigFile string, containerdNamespaces, criEndpoints []string) (*KataMonitor, error) {
+	if containerdAddr == "" && len(criEndpoints) == 0 {
+		return nil, fmt.Errorf("containerd serve address and CRI endpoints missing")
 	}
 
 	containerdConf := &srvconfig.Config{
@@ -53,33 +60,71 @@ func NewKataMonitor(containerdAddr, containerdConfigFile string) (*KataMonitor,
 		containerdAddr:       containerdAddr,
 		containerdConfigFile: containerdConfigFile,
 		containerdStatePath:  containerdConf.State,
+		containerdNamespaces: containerdNamespaces,
 		sandboxCache: &sandboxCache{
 			Mutex:     &sync.Mutex{},
 			sandboxes: make(map[string]string),
 		},
 	}
 
-	if err := km.initSandboxCache(); err != nil {
+	if err := km.initSandboxCache(criEndpoints); err != nil {
 		return nil, err
 	}
 
 	// register metrics
 	registerMetrics()
 
-	go km.sandboxCache.startEventsListener(km.containerdAddr)
+	if km.containerdAddr != "" {
+		go km.sandboxCache.startEventsListener(km.containerdAddr, km.isWatchedNamespace)
+	}
+
+	for _, ce := range km.criEndpoints {
+		go ce.startPolling(km.sandboxCache)
+	}
 
 	return km, nil
 }
 
-func (km *KataMonitor) initSandboxCache() error {
-	sandboxes, err := km.getSandboxes()
-	if err != nil {
-		return err
+func (km *KataMonitor) initSandboxCache(criEndpoints []string) error {
+	sandboxes := make(map[string]string)
+
+	if km.containerdAddr != "" {
+		var err error
+		if sandboxes, err = km.getSandboxes(); err != nil {
+			return err
+		}
 	}
 	km.sandboxCache.init(sandboxes)
+
+	// The sandboxes of the CRI endpoints are merged with the containerd
+	// ones, they have unique IDs.
+	for _, address := range criEndpoints {
+		ce := newCRIEndpoint(address)
+		if err := ce.refresh(context.Background(), km.sandboxCache); err != nil {
+			return err
+		}
+		km.criEndpoints = append(km.criEndpoints, ce)
+	}
+
 	return nil
 }
 
+// isWatchedNamespace returns true if the sandboxes of the containerd
+// namespace are monitored.
+func (km *KataMonitor) isWatchedNamespace(namespace string) bool {
+	if len(km.containerdNamespaces) == 0 {
+		return true
+	}
+
+	for _, ns := range km.containerdNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetAgentURL returns agent URL
 func (km *KataMonitor) GetAgentURL(w http.ResponseWriter, r *http.Request) {
 	sandboxID, err := getSandboxIDFromReq(r)
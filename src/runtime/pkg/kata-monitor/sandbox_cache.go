@@ -79,8 +79,9 @@ func (sc *sandboxCache) init(sandboxes map[string]string) {
 	sc.sandboxes = sandboxes
 }
 
-// startEventsListener will boot a thread to listen container events to manage sandbox cache
-func (sc *sandboxCache) startEventsListener(addr string) error {
+// startEventsListener will boot a thread to listen container events to manage sandbox cache,
+// the events of the namespaces not watched are ignored.
+func (sc *sandboxCache) startEventsListener(addr string, isWatchedNamespace func(string) bool) error {
 	client, err := containerd.New(addr)
 	if err != nil {
 		return err
@@ -113,6 +114,10 @@ func (sc *sandboxCache) startEventsListener(addr string) error {
 			return err
 		}
 
+		if e != nil && !isWatchedNamespace(e.Namespace) {
+			continue
+		}
+
 		if e != nil {
 			var eventBody []byte
 			if e.Event != nil {
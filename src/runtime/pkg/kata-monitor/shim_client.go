@@ -12,7 +12,7 @@ import (
 	"net/http"
 	"time"
 
-	shim "github.com/kata-containers/kata-containers/src/runtime/containerd-shim-v2"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/sandboxapi"
 )
 
 const (
@@ -37,27 +37,13 @@ func getSandboxIDFromReq(r *http.Request) (string, error) {
 
 // BuildShimClient builds and returns an http client for communicating with the provided sandbox
 func BuildShimClient(sandboxID string, timeout time.Duration) (*http.Client, error) {
-	return buildUnixSocketClient(shim.SocketAddress(sandboxID), timeout)
+	return sandboxapi.NewClient(sandboxID, timeout).HTTPClient(), nil
 }
 
-// buildUnixSocketClient build http client for Unix socket
-func buildUnixSocketClient(socketAddr string, timeout time.Duration) (*http.Client, error) {
-	transport := &http.Transport{
-		DisableKeepAlives: true,
-		Dial: func(proto, addr string) (conn net.Conn, err error) {
-			return net.Dial("unix", "\x00"+socketAddr)
-		},
-	}
-
-	client := &http.Client{
-		Transport: transport,
-	}
-
-	if timeout > 0 {
-		client.Timeout = timeout
-	}
-
-	return client, nil
+// IsSandboxAlive returns true if the shim of the provided sandbox is serving
+// its management endpoint
+func IsSandboxAlive(sandboxID string) bool {
+	return sandboxapi.NewClient(sandboxID, defaultTimeout).IsAlive()
 }
 
 func doGet(sandboxID string, timeoutInSeconds time.Duration, urlPath string) ([]byte, error) {
@@ -82,3 +68,9 @@ func doGet(sandboxID string, timeoutInSeconds time.Duration, urlPath string) ([]
 
 	return body, nil
 }
+
+// DialPortForward returns a connection to the TCP port of the provided
+// sandbox, tunnelled by its shim through the agent
+func DialPortForward(sandboxID string, port uint16) (net.Conn, error) {
+	return sandboxapi.NewClient(sandboxID, defaultTimeout).DialPortForward(port)
+}
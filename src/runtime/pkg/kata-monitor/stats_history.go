@@ -0,0 +1,388 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// clockTicks is USER_HZ, the unit of the process CPU times reported by
+	// the shims. procfs assumes it is 100 as well.
+	clockTicks = 100
+
+	// maxStatsPoints bounds the number of points of the history responses
+	// when the step is not requested, the samples being downsampled.
+	maxStatsPoints = 60
+
+	defaultStatsWindow = 5 * time.Minute
+)
+
+// StatsSample is a sample of the resource usage of a sandbox. The rates are
+// averaged over the sampling interval.
+type StatsSample struct {
+	Time time.Time `json:"time"`
+	// CPU is the CPU usage of the hypervisor, in cores
+	CPU float64 `json:"cpu"`
+	// MemoryRSS is the resident memory of the hypervisor, in bytes
+	MemoryRSS float64 `json:"memory_rss"`
+	// NetRx and NetTx are the bytes per second received and sent on the
+	// network interfaces of the sandbox network namespace
+	NetRx float64 `json:"net_rx"`
+	NetTx float64 `json:"net_tx"`
+	// DiskRead and DiskWrite are the bytes per second read and written by
+	// the hypervisor
+	DiskRead  float64 `json:"disk_read"`
+	DiskWrite float64 `json:"disk_write"`
+}
+
+// StatsRange is the average and the maximum of a statistic over a step of
+// the history, the maximum showing the spikes the average smooths out.
+type StatsRange struct {
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+// StatsPoint is a step of the history of a sandbox, starting at Time.
+type StatsPoint struct {
+	Time      time.Time  `json:"time"`
+	Samples   int        `json:"samples"`
+	CPU       StatsRange `json:"cpu"`
+	MemoryRSS StatsRange `json:"memory_rss"`
+	NetRx     StatsRange `json:"net_rx"`
+	NetTx     StatsRange `json:"net_tx"`
+	DiskRead  StatsRange `json:"disk_read"`
+	DiskWrite StatsRange `json:"disk_write"`
+}
+
+// StatsHistory is the body of /sandboxes/{id}/stats/history responses.
+type StatsHistory struct {
+	Sandbox string       `json:"sandbox"`
+	Step    string       `json:"step"`
+	Points  []StatsPoint `json:"points"`
+}
+
+// statsCounters are the statistics of a sandbox as reported by its shim,
+// the cumulative ones are turned into rates between two samples.
+type statsCounters struct {
+	cpuTicks  float64
+	memoryRSS float64
+	netRx     float64
+	netTx     float64
+	diskRead  float64
+	diskWrite float64
+}
+
+// statsRing is the history of a sandbox, the oldest samples are overwritten
+// once it is full.
+type statsRing struct {
+	samples []StatsSample
+	next    int
+	full    bool
+
+	last     statsCounters
+	lastTime time.Time
+}
+
+func (r *statsRing) add(s StatsSample) {
+	r.samples[r.next] = s
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// since returns the samples taken after start, oldest first.
+func (r *statsRing) since(start time.Time) []StatsSample {
+	var ordered []StatsSample
+	if r.full {
+		ordered = append(ordered, r.samples[r.next:]...)
+	}
+	ordered = append(ordered, r.samples[:r.next]...)
+
+	for i, s := range ordered {
+		if s.Time.After(start) {
+			return ordered[i:]
+		}
+	}
+	return nil
+}
+
+// statsHistory keeps the recent samples of each monitored sandbox.
+type statsHistory struct {
+	sync.Mutex
+	interval  time.Duration
+	size      int
+	sandboxes map[string]*statsRing
+}
+
+func newStatsHistory(interval, retention time.Duration) *statsHistory {
+	size := int(retention / interval)
+	if size < 1 {
+		size = 1
+	}
+
+	return &statsHistory{
+		interval:  interval,
+		size:      size,
+		sandboxes: make(map[string]*statsRing),
+	}
+}
+
+// record adds the sample of the counters of a sandbox taken at now. The
+// first counters of a sandbox only serve to compute the rates of the next
+// sample.
+func (h *statsHistory) record(sandboxID string, now time.Time, c statsCounters) {
+	h.Lock()
+	defer h.Unlock()
+
+	ring, ok := h.sandboxes[sandboxID]
+	if !ok {
+		ring = &statsRing{samples: make([]StatsSample, h.size)}
+		h.sandboxes[sandboxID] = ring
+	}
+
+	if !ring.lastTime.IsZero() {
+		elapsed := now.Sub(ring.lastTime).Seconds()
+		rate := func(current, last float64) float64 {
+			// The counters are reset when the hypervisor or an
+			// interface is replaced.
+			return math.Max(current-last, 0) / elapsed
+		}
+
+		ring.add(StatsSample{
+			Time:      now,
+			CPU:       rate(c.cpuTicks, ring.last.cpuTicks) / clockTicks,
+			MemoryRSS: c.memoryRSS,
+			NetRx:     rate(c.netRx, ring.last.netRx),
+			NetTx:     rate(c.netTx, ring.last.netTx),
+			DiskRead:  rate(c.diskRead, ring.last.diskRead),
+			DiskWrite: rate(c.diskWrite, ring.last.diskWrite),
+		})
+	}
+
+	ring.last = c
+	ring.lastTime = now
+}
+
+// prune drops the history of the sandboxes that are not monitored anymore.
+func (h *statsHistory) prune(sandboxes map[string]string) {
+	h.Lock()
+	defer h.Unlock()
+
+	for id := range h.sandboxes {
+		if _, ok := sandboxes[id]; !ok {
+			delete(h.sandboxes, id)
+		}
+	}
+}
+
+// history returns the samples of a sandbox taken after start.
+func (h *statsHistory) history(sandboxID string, start time.Time) ([]StatsSample, bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	ring, ok := h.sandboxes[sandboxID]
+	if !ok {
+		return nil, false
+	}
+
+	return ring.since(start), true
+}
+
+// downsample groups the samples in steps starting at start.
+func downsample(samples []StatsSample, start time.Time, step time.Duration) []StatsPoint {
+	points := []StatsPoint{}
+
+	var sums StatsSample
+	for _, s := range samples {
+		pointTime := start.Add(s.Time.Sub(start).Truncate(step))
+		if len(points) == 0 || !points[len(points)-1].Time.Equal(pointTime) {
+			points = append(points, StatsPoint{Time: pointTime})
+			sums = StatsSample{}
+		}
+
+		p := &points[len(points)-1]
+		p.Samples++
+		for _, stat := range []struct {
+			r     *StatsRange
+			sum   *float64
+			value float64
+		}{
+			{&p.CPU, &sums.CPU, s.CPU},
+			{&p.MemoryRSS, &sums.MemoryRSS, s.MemoryRSS},
+			{&p.NetRx, &sums.NetRx, s.NetRx},
+			{&p.NetTx, &sums.NetTx, s.NetTx},
+			{&p.DiskRead, &sums.DiskRead, s.DiskRead},
+			{&p.DiskWrite, &sums.DiskWrite, s.DiskWrite},
+		} {
+			*stat.sum += stat.value
+			stat.r.Avg = *stat.sum / float64(p.Samples)
+			stat.r.Max = math.Max(stat.r.Max, stat.value)
+		}
+	}
+
+	return points
+}
+
+// parseStatsCounters extracts the statistics of the history from the
+// metrics of a sandbox.
+func parseStatsCounters(mfs []*dto.MetricFamily) statsCounters {
+	var c statsCounters
+
+	for _, mf := range mfs {
+		for _, m := range mf.Metric {
+			value := m.GetGauge().GetValue()
+			item := labelValue(m, "item")
+
+			switch mf.GetName() {
+			case "kata_hypervisor_proc_stat":
+				if item == "utime" || item == "stime" {
+					c.cpuTicks += value
+				}
+			case "kata_hypervisor_proc_status":
+				if item == "vmrss" {
+					c.memoryRSS = value
+				}
+			case "kata_hypervisor_netdev":
+				if labelValue(m, "interface") == "lo" {
+					continue
+				}
+				switch item {
+				case "recv_bytes":
+					c.netRx += value
+				case "sent_bytes":
+					c.netTx += value
+				}
+			case "kata_hypervisor_io_stat":
+				switch item {
+				case "readbytes":
+					c.diskRead = value
+				case "writebytes":
+					c.diskWrite = value
+				}
+			}
+		}
+	}
+
+	return c
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.Label {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+// StartStatsHistory samples the resource usage of the sandboxes every
+// interval, keeping the samples of the last retention period to be served
+// by ServeStatsHistory.
+func (km *KataMonitor) StartStatsHistory(interval, retention time.Duration) {
+	km.statsHistory = newStatsHistory(interval, retention)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			sandboxes := km.sandboxCache.getAllSandboxes()
+			km.statsHistory.prune(sandboxes)
+
+			wg := &sync.WaitGroup{}
+			for sandboxID := range sandboxes {
+				wg.Add(1)
+				go func(sandboxID string) {
+					defer wg.Done()
+
+					// only the hypervisor metrics are sampled
+					mfs, err := getParsedMetrics(sandboxID, true)
+					if err != nil {
+						monitorLog.WithError(err).WithField("sandbox_id", sandboxID).Debug("failed to sample sandbox stats")
+						return
+					}
+					km.statsHistory.record(sandboxID, now, parseStatsCounters(mfs))
+				}(sandboxID)
+			}
+			wg.Wait()
+		}
+	}()
+}
+
+// ServeStatsHistory serves /sandboxes/{id}/stats/history requests, the
+// recent resource usage of a sandbox over the window query parameter, 5m
+// by default, downsampled to the step query parameter.
+func (km *KataMonitor) ServeStatsHistory(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/sandboxes/")
+	if !strings.HasSuffix(path, "/stats/history") {
+		http.NotFound(w, r)
+		return
+	}
+	sandboxID := strings.TrimSuffix(path, "/stats/history")
+
+	if km.statsHistory == nil {
+		commonServeError(w, http.StatusNotFound, fmt.Errorf("stats history is disabled"))
+		return
+	}
+
+	window, err := durationParam(r, "window", defaultStatsWindow)
+	if err != nil {
+		commonServeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// The steps default to a bounded number of points, they cannot be
+	// shorter than the sampling interval.
+	step, err := durationParam(r, "step", window/maxStatsPoints)
+	if err != nil {
+		commonServeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if step < km.statsHistory.interval {
+		step = km.statsHistory.interval
+	}
+
+	start := time.Now().Add(-window)
+	samples, ok := km.statsHistory.history(sandboxID, start)
+	if !ok {
+		commonServeError(w, http.StatusNotFound, fmt.Errorf("no stats history for sandbox %s", sandboxID))
+		return
+	}
+
+	history := StatsHistory{
+		Sandbox: sandboxID,
+		Step:    step.String(),
+		Points:  downsample(samples, start, step),
+	}
+
+	w.Header().Set(contentTypeHeader, "application/json")
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		monitorLog.WithError(err).Error("failed to encode stats history")
+	}
+}
+
+func durationParam(r *http.Request, name string, defaultValue time.Duration) (time.Duration, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return d, nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katamonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const hypervisorMetricBody = `# HELP kata_hypervisor_proc_stat Hypervisor process statistics.
+# TYPE kata_hypervisor_proc_stat gauge
+kata_hypervisor_proc_stat{item="cstime"} 7
+kata_hypervisor_proc_stat{item="stime"} 150
+kata_hypervisor_proc_stat{item="utime"} 250
+# HELP kata_hypervisor_proc_status Hypervisor process status.
+# TYPE kata_hypervisor_proc_status gauge
+kata_hypervisor_proc_status{item="vmrss"} 2.097152e+08
+# HELP kata_hypervisor_netdev Net devices statistics.
+# TYPE kata_hypervisor_netdev gauge
+kata_hypervisor_netdev{interface="lo",item="recv_bytes"} 1000
+kata_hypervisor_netdev{interface="eth0",item="recv_bytes"} 4000
+kata_hypervisor_netdev{interface="tap0_kata",item="recv_bytes"} 6000
+kata_hypervisor_netdev{interface="eth0",item="sent_bytes"} 3000
+# HELP kata_hypervisor_io_stat Process IO statistics.
+# TYPE kata_hypervisor_io_stat gauge
+kata_hypervisor_io_stat{item="readbytes"} 8192
+kata_hypervisor_io_stat{item="writebytes"} 4096
+`
+
+func TestParseStatsCounters(t *testing.T) {
+	assert := assert.New(t)
+
+	mfs, err := parsePrometheusMetrics("sandbox", []byte(hypervisorMetricBody))
+	assert.NoError(err)
+
+	assert.Equal(statsCounters{
+		cpuTicks:  400,
+		memoryRSS: 209715200,
+		netRx:     10000,
+		netTx:     3000,
+		diskRead:  8192,
+		diskWrite: 4096,
+	}, parseStatsCounters(mfs))
+}
+
+func TestStatsHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	h := newStatsHistory(time.Second, 3*time.Second)
+	start := time.Now()
+
+	// The first counters only serve to compute the rates
+	h.record("sandbox", start, statsCounters{cpuTicks: 100, netRx: 1000})
+	samples, ok := h.history("sandbox", start.Add(-time.Minute))
+	assert.True(ok)
+	assert.Empty(samples)
+
+	h.record("sandbox", start.Add(time.Second), statsCounters{cpuTicks: 150, netRx: 3000, memoryRSS: 42})
+	samples, _ = h.history("sandbox", start)
+	assert.Equal([]StatsSample{{Time: start.Add(time.Second), CPU: 0.5, NetRx: 2000, MemoryRSS: 42}}, samples)
+
+	// The counters going backwards are not negative rates
+	h.record("sandbox", start.Add(2*time.Second), statsCounters{cpuTicks: 250})
+	samples, _ = h.history("sandbox", start)
+	assert.Len(samples, 2)
+	assert.Equal(1.0, samples[1].CPU)
+	assert.Equal(0.0, samples[1].NetRx)
+
+	// The oldest samples are overwritten
+	h.record("sandbox", start.Add(3*time.Second), statsCounters{cpuTicks: 250})
+	h.record("sandbox", start.Add(4*time.Second), statsCounters{cpuTicks: 250})
+	samples, _ = h.history("sandbox", start)
+	assert.Len(samples, 3)
+	assert.Equal(start.Add(2*time.Second), samples[0].Time)
+	assert.Equal(start.Add(4*time.Second), samples[2].Time)
+
+	samples, _ = h.history("sandbox", start.Add(3*time.Second))
+	assert.Len(samples, 1)
+
+	h.prune(map[string]string{"other": "k8s.io"})
+	_, ok = h.history("sandbox", start)
+	assert.False(ok)
+}
+
+func TestDownsample(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Now()
+	var samples []StatsSample
+	for i, cpu := range []float64{0.5, 3.5, 1, 1, 1} {
+		samples = append(samples, StatsSample{Time: start.Add(time.Duration(i+1) * time.Second), CPU: cpu})
+	}
+
+	points := downsample(samples, start, 2*time.Second)
+	assert.Len(points, 3)
+	assert.Equal(start, points[0].Time)
+	assert.Equal(1, points[0].Samples)
+	assert.Equal(start.Add(2*time.Second), points[1].Time)
+	assert.Equal(2, points[1].Samples)
+	assert.Equal(StatsRange{Avg: 2.25, Max: 3.5}, points[1].CPU)
+	assert.Equal(StatsRange{Avg: 1, Max: 1}, points[2].CPU)
+
+	assert.Empty(downsample(nil, start, time.Second))
+}
+
+func TestServeStatsHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	km := &KataMonitor{}
+
+	serve := func(url string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		km.ServeStatsHistory(rr, httptest.NewRequest(http.MethodGet, url, nil))
+		return rr
+	}
+
+	assert.Equal(http.StatusNotFound, serve("/sandboxes/sandbox/stats/history").Code)
+
+	km.statsHistory = newStatsHistory(time.Second, time.Minute)
+	now := time.Now()
+	km.statsHistory.record("sandbox", now.Add(-3*time.Second), statsCounters{})
+	km.statsHistory.record("sandbox", now.Add(-2*time.Second), statsCounters{cpuTicks: 100})
+	km.statsHistory.record("sandbox", now.Add(-time.Second), statsCounters{cpuTicks: 300})
+
+	assert.Equal(http.StatusNotFound, serve("/sandboxes/other/stats/history").Code)
+	assert.Equal(http.StatusNotFound, serve("/sandboxes/sandbox/stats").Code)
+	assert.Equal(http.StatusBadRequest, serve("/sandboxes/sandbox/stats/history?window=forever").Code)
+
+	rr := serve("/sandboxes/sandbox/stats/history?window=1m&step=1ms")
+	assert.Equal(http.StatusOK, rr.Code)
+
+	var history StatsHistory
+	assert.NoError(json.Unmarshal(rr.Body.Bytes(), &history))
+	assert.Equal("sandbox", history.Sandbox)
+	// The step cannot be shorter than the sampling interval
+	assert.Equal("1s", history.Step)
+	assert.Len(history.Points, 2)
+	assert.Equal(StatsRange{Avg: 1, Max: 1}, history.Points[0].CPU)
+	assert.Equal(StatsRange{Avg: 2, Max: 2}, history.Points[1].CPU)
+}
@@ -9,17 +9,18 @@ package katautils
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 	goruntime "runtime"
 	"strings"
+	"time"
 
-	"github.com/BurntSushi/toml"
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/nri"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/sirupsen/logrus"
@@ -46,9 +47,13 @@ const (
 	clhHypervisorTableType         = "clh"
 	qemuHypervisorTableType        = "qemu"
 	acrnHypervisorTableType        = "acrn"
+	mockHypervisorTableType        = "mock"
 
 	// the maximum amount of PCI bridges that can be cold plugged in a VM
 	maxPCIBridges uint32 = 5
+
+	// the guest kernel module providing the KVM PTP clock
+	ptpKVMKernelModule = "ptp_kvm"
 )
 
 type tomlConfig struct {
@@ -58,11 +63,22 @@ type tomlConfig struct {
 	Factory    factory
 	Netmon     netmon
 	Image      image
+	Hooks      hooks
+}
+
+type hooks struct {
+	PreVMStart     []string `toml:"pre_vm_start"`
+	PostVMStart    []string `toml:"post_vm_start"`
+	PostAgentReady []string `toml:"post_agent_ready"`
+	PreVMStop      []string `toml:"pre_vm_stop"`
+	Timeout        uint32   `toml:"timeout"`
 }
 
 type image struct {
-	ServiceOffload bool   `toml:"service_offload"`
-	Provision      string `toml:"provision"`
+	ServiceOffload    bool   `toml:"service_offload"`
+	Provision         string `toml:"provision"`
+	GuestStoreSize    uint32 `toml:"guest_store_size"`
+	GuestStoreDiscard bool   `toml:"guest_store_discard"`
 }
 
 type factory struct {
@@ -73,66 +89,114 @@ type factory struct {
 }
 
 type hypervisor struct {
-	Path                    string   `toml:"path"`
-	JailerPath              string   `toml:"jailer_path"`
-	Kernel                  string   `toml:"kernel"`
-	CtlPath                 string   `toml:"ctlpath"`
-	Initrd                  string   `toml:"initrd"`
-	Image                   string   `toml:"image"`
-	Firmware                string   `toml:"firmware"`
-	MachineAccelerators     string   `toml:"machine_accelerators"`
-	CPUFeatures             string   `toml:"cpu_features"`
-	KernelParams            string   `toml:"kernel_params"`
-	MachineType             string   `toml:"machine_type"`
-	BlockDeviceDriver       string   `toml:"block_device_driver"`
-	EntropySource           string   `toml:"entropy_source"`
-	SharedFS                string   `toml:"shared_fs"`
-	VirtioFSDaemon          string   `toml:"virtio_fs_daemon"`
-	VirtioFSCache           string   `toml:"virtio_fs_cache"`
-	VhostUserStorePath      string   `toml:"vhost_user_store_path"`
-	FileBackedMemRootDir    string   `toml:"file_mem_backend"`
-	GuestHookPath           string   `toml:"guest_hook_path"`
-	GuestMemoryDumpPath     string   `toml:"guest_memory_dump_path"`
-	HypervisorPathList      []string `toml:"valid_hypervisor_paths"`
-	JailerPathList          []string `toml:"valid_jailer_paths"`
-	CtlPathList             []string `toml:"valid_ctlpaths"`
-	VirtioFSDaemonList      []string `toml:"valid_virtio_fs_daemon_paths"`
-	VirtioFSExtraArgs       []string `toml:"virtio_fs_extra_args"`
-	PFlashList              []string `toml:"pflashes"`
-	VhostUserStorePathList  []string `toml:"valid_vhost_user_store_paths"`
-	FileBackedMemRootList   []string `toml:"valid_file_mem_backends"`
-	EntropySourceList       []string `toml:"valid_entropy_sources"`
-	EnableAnnotations       []string `toml:"enable_annotations"`
-	RxRateLimiterMaxRate    uint64   `toml:"rx_rate_limiter_max_rate"`
-	TxRateLimiterMaxRate    uint64   `toml:"tx_rate_limiter_max_rate"`
-	VirtioFSCacheSize       uint32   `toml:"virtio_fs_cache_size"`
-	NumVCPUs                int32    `toml:"default_vcpus"`
-	DefaultMaxVCPUs         uint32   `toml:"default_maxvcpus"`
-	MemorySize              uint32   `toml:"default_memory"`
-	MemSlots                uint32   `toml:"memory_slots"`
-	MemOffset               uint64   `toml:"memory_offset"`
-	DefaultBridges          uint32   `toml:"default_bridges"`
-	Msize9p                 uint32   `toml:"msize_9p"`
-	PCIeRootPort            uint32   `toml:"pcie_root_port"`
-	BlockDeviceCacheSet     bool     `toml:"block_device_cache_set"`
-	BlockDeviceCacheDirect  bool     `toml:"block_device_cache_direct"`
-	BlockDeviceCacheNoflush bool     `toml:"block_device_cache_noflush"`
-	EnableVhostUserStore    bool     `toml:"enable_vhost_user_store"`
-	DisableBlockDeviceUse   bool     `toml:"disable_block_device_use"`
-	MemPrealloc             bool     `toml:"enable_mem_prealloc"`
-	HugePages               bool     `toml:"enable_hugepages"`
-	VirtioMem               bool     `toml:"enable_virtio_mem"`
-	IOMMU                   bool     `toml:"enable_iommu"`
-	IOMMUPlatform           bool     `toml:"enable_iommu_platform"`
-	Swap                    bool     `toml:"enable_swap"`
-	Debug                   bool     `toml:"enable_debug"`
-	DisableNestingChecks    bool     `toml:"disable_nesting_checks"`
-	EnableIOThreads         bool     `toml:"enable_iothreads"`
-	DisableImageNvdimm      bool     `toml:"disable_image_nvdimm"`
-	HotplugVFIOOnRootBus    bool     `toml:"hotplug_vfio_on_root_bus"`
-	DisableVhostNet         bool     `toml:"disable_vhost_net"`
-	GuestMemoryDumpPaging   bool     `toml:"guest_memory_dump_paging"`
-	ConfidentialGuest       bool     `toml:"confidential_guest"`
+	Path                       string   `toml:"path"`
+	JailerPath                 string   `toml:"jailer_path"`
+	Kernel                     string   `toml:"kernel"`
+	CtlPath                    string   `toml:"ctlpath"`
+	Initrd                     string   `toml:"initrd"`
+	Image                      string   `toml:"image"`
+	Firmware                   string   `toml:"firmware"`
+	MachineAccelerators        string   `toml:"machine_accelerators"`
+	CPUFeatures                string   `toml:"cpu_features"`
+	CPUModel                   string   `toml:"cpu_model"`
+	KernelParams               string   `toml:"kernel_params"`
+	MachineType                string   `toml:"machine_type"`
+	BlockDeviceDriver          string   `toml:"block_device_driver"`
+	EntropySource              string   `toml:"entropy_source"`
+	EntropyMaxBytes            uint32   `toml:"entropy_max_bytes"`
+	EntropyPeriod              uint32   `toml:"entropy_period"`
+	SharedFS                   string   `toml:"shared_fs"`
+	VirtioFSDaemon             string   `toml:"virtio_fs_daemon"`
+	VirtioFSCache              string   `toml:"virtio_fs_cache"`
+	VirtioFSSandbox            string   `toml:"virtio_fs_sandbox"`
+	VirtioFSSeccomp            string   `toml:"virtio_fs_seccomp"`
+	VhostUserStorePath         string   `toml:"vhost_user_store_path"`
+	FileBackedMemRootDir       string   `toml:"file_mem_backend"`
+	GuestHookPath              string   `toml:"guest_hook_path"`
+	GuestMemoryDumpPath        string   `toml:"guest_memory_dump_path"`
+	Watchdog                   string   `toml:"watchdog"`
+	WatchdogAction             string   `toml:"watchdog_action"`
+	HypervisorPathList         []string `toml:"valid_hypervisor_paths"`
+	JailerPathList             []string `toml:"valid_jailer_paths"`
+	CtlPathList                []string `toml:"valid_ctlpaths"`
+	VirtioFSDaemonList         []string `toml:"valid_virtio_fs_daemon_paths"`
+	VirtioFSExtraArgs          []string `toml:"virtio_fs_extra_args"`
+	PFlashList                 []string `toml:"pflashes"`
+	VhostUserStorePathList     []string `toml:"valid_vhost_user_store_paths"`
+	FileBackedMemRootList      []string `toml:"valid_file_mem_backends"`
+	EntropySourceList          []string `toml:"valid_entropy_sources"`
+	EnableAnnotations          []string `toml:"enable_annotations"`
+	RxRateLimiterMaxRate       uint64   `toml:"rx_rate_limiter_max_rate"`
+	TxRateLimiterMaxRate       uint64   `toml:"tx_rate_limiter_max_rate"`
+	VirtioFSCacheSize          uint32   `toml:"virtio_fs_cache_size"`
+	VirtioFSRestarts           uint32   `toml:"virtio_fs_restarts"`
+	LaunchAttempts             uint32   `toml:"launch_attempts"`
+	LaunchBackoff              uint32   `toml:"launch_backoff"`
+	NumVCPUs                   int32    `toml:"default_vcpus"`
+	DefaultMaxVCPUs            uint32   `toml:"default_maxvcpus"`
+	MemorySize                 uint32   `toml:"default_memory"`
+	MemSlots                   uint32   `toml:"memory_slots"`
+	MemOffset                  uint64   `toml:"memory_offset"`
+	DefaultBridges             uint32   `toml:"default_bridges"`
+	Msize9p                    uint32   `toml:"msize_9p"`
+	PCIeRootPort               uint32   `toml:"pcie_root_port"`
+	WatchdogTimeout            uint32   `toml:"watchdog_timeout"`
+	BlockDeviceCacheSet        bool     `toml:"block_device_cache_set"`
+	BlockDeviceCacheDirect     bool     `toml:"block_device_cache_direct"`
+	BlockDeviceCacheNoflush    bool     `toml:"block_device_cache_noflush"`
+	EnableVhostUserStore       bool     `toml:"enable_vhost_user_store"`
+	EnableMultipath            bool     `toml:"enable_multipath"`
+	DisableBlockDeviceUse      bool     `toml:"disable_block_device_use"`
+	ErofsLayers                bool     `toml:"erofs_layers"`
+	MemPrealloc                bool     `toml:"enable_mem_prealloc"`
+	HugePages                  bool     `toml:"enable_hugepages"`
+	VirtioMem                  bool     `toml:"enable_virtio_mem"`
+	IOMMU                      bool     `toml:"enable_iommu"`
+	IOMMUPlatform              bool     `toml:"enable_iommu_platform"`
+	Swap                       bool     `toml:"enable_swap"`
+	Debug                      bool     `toml:"enable_debug"`
+	DisableNestingChecks       bool     `toml:"disable_nesting_checks"`
+	EnableIOThreads            bool     `toml:"enable_iothreads"`
+	HotplugIOThreads           uint32   `toml:"hotplug_iothreads"`
+	HotplugConcurrency         uint32   `toml:"hotplug_concurrency"`
+	BalloonStatsInterval       uint32   `toml:"balloon_stats_interval"`
+	VhostUserGPUDaemon         string   `toml:"vhost_user_gpu_daemon"`
+	VhostUserGPUExtraArgs      []string `toml:"vhost_user_gpu_extra_args"`
+	VirtioSound                string   `toml:"virtio_sound"`
+	EmulatorThreadsWeight      uint64   `toml:"emulator_threads_weight"`
+	VCPUThreadsWeight          uint64   `toml:"vcpu_threads_weight"`
+	IOThreadsWeight            uint64   `toml:"iothreads_weight"`
+	EnableNUMAPinning          bool     `toml:"enable_numa_pinning"`
+	NUMANode                   uint32   `toml:"numa_node"`
+	DisableImageNvdimm         bool     `toml:"disable_image_nvdimm"`
+	ReadOnlyImage              bool     `toml:"read_only_image"`
+	MockFaults                 []string `toml:"faults"`
+	GuestRNGSeed               bool     `toml:"guest_rng_seed"`
+	RNGTrustCPU                bool     `toml:"rng_trust_cpu"`
+	ArtifactsManifest          string   `toml:"artifacts_manifest"`
+	ArtifactsManifestPublicKey string   `toml:"artifacts_manifest_public_key"`
+	HotplugVFIOOnRootBus       bool     `toml:"hotplug_vfio_on_root_bus"`
+	PreserveVFIOTopology       bool     `toml:"preserve_vfio_topology"`
+	ColdPlugDevices            bool     `toml:"cold_plug_devices"`
+	ColdPlugDevicePathList     []string `toml:"valid_cold_plug_device_paths"`
+	VFIOAPDevices              []string `toml:"vfio_ap_devices"`
+	VFIOAPDevicePathList       []string `toml:"valid_vfio_ap_devices"`
+	VFIOBindDevices            []string `toml:"vfio_bind_devices"`
+	VFIOBindDevicePathList     []string `toml:"valid_vfio_bind_devices"`
+	VFIOMdevDevices            []string `toml:"vfio_mdev_devices"`
+	VFIOMdevDevicePathList     []string `toml:"valid_vfio_mdev_devices"`
+	VhostUserNetSocketPathList []string `toml:"valid_vhost_user_net_socket_paths"`
+	DisableVhostNet            bool     `toml:"disable_vhost_net"`
+	GuestMemoryDumpPaging      bool     `toml:"guest_memory_dump_paging"`
+	ConfidentialGuest          bool     `toml:"confidential_guest"`
+
+	BlockVolumeClasses map[string]blockVolumeClass `toml:"block_volume_classes"`
+}
+
+type blockVolumeClass struct {
+	Cache        string `toml:"cache"`
+	Discard      bool   `toml:"discard"`
+	DetectZeroes string `toml:"detect_zeroes"`
 }
 
 type runtime struct {
@@ -140,6 +204,8 @@ type runtime struct {
 	JaegerEndpoint      string   `toml:"jaeger_endpoint"`
 	JaegerUser          string   `toml:"jaeger_user"`
 	JaegerPassword      string   `toml:"jaeger_password"`
+	NRIConfigPath       string   `toml:"nri_config_path"`
+	NRIPluginPath       string   `toml:"nri_plugin_path"`
 	SandboxBindMounts   []string `toml:"sandbox_bind_mounts"`
 	Experimental        []string `toml:"experimental"`
 	Debug               bool     `toml:"enable_debug"`
@@ -148,16 +214,32 @@ type runtime struct {
 	DisableGuestSeccomp bool     `toml:"disable_guest_seccomp"`
 	SandboxCgroupOnly   bool     `toml:"sandbox_cgroup_only"`
 	EnablePprof         bool     `toml:"enable_pprof"`
+	EnableNRI           bool     `toml:"enable_nri"`
+
+	AgentRPCDurationBuckets         []float64 `toml:"agent_rpc_duration_buckets"`
+	HypervisorLaunchDurationBuckets []float64 `toml:"hypervisor_launch_duration_buckets"`
 }
 
 type agent struct {
 	TraceMode           string   `toml:"trace_mode"`
 	TraceType           string   `toml:"trace_type"`
+	PolicyFile          string   `toml:"policy_file"`
 	KernelModules       []string `toml:"kernel_modules"`
+	KernelModulesList   []string `toml:"valid_kernel_modules"`
+	GuestSysctls        []string `toml:"guest_sysctls"`
+	GuestSysctlsList    []string `toml:"valid_guest_sysctls"`
 	Debug               bool     `toml:"enable_debug"`
 	Tracing             bool     `toml:"enable_tracing"`
 	DebugConsoleEnabled bool     `toml:"debug_console_enabled"`
+	PortForwardEnabled  bool     `toml:"enable_port_forward"`
+	NetworkCapture      bool     `toml:"enable_network_capture"`
+	GuestSync           bool     `toml:"enable_guest_sync"`
+	Checkpoint          bool     `toml:"enable_checkpoint"`
+	LogProxy            bool     `toml:"enable_log_proxy"`
+	PTPKVM              bool     `toml:"enable_ptp_kvm"`
+	PolicyAudit         bool     `toml:"policy_audit"`
 	DialTimeout         uint32   `toml:"dial_timeout"`
+	TimeSyncInterval    uint32   `toml:"time_sync_interval"`
 }
 
 type netmon struct {
@@ -176,6 +258,26 @@ func (h hypervisor) path() (string, error) {
 	return ResolvePath(p)
 }
 
+func (h hypervisor) vhostUserGPUDaemon() (string, error) {
+	if h.VhostUserGPUDaemon == "" {
+		return "", nil
+	}
+
+	return ResolvePath(h.VhostUserGPUDaemon)
+}
+
+// virtioSound returns the QEMU audio backend driver of the virtio-sound
+// device, the ones needing a host side configuration, e.g. the path of a
+// wav file, are not supported.
+func (h hypervisor) virtioSound() (string, error) {
+	switch h.VirtioSound {
+	case "", "none", "alsa", "pa", "pipewire":
+		return h.VirtioSound, nil
+	}
+
+	return "", fmt.Errorf("invalid virtio_sound audio backend %q (supported backends: none, alsa, pa, pipewire)", h.VirtioSound)
+}
+
 func (h hypervisor) ctlpath() (string, error) {
 	p := h.CtlPath
 
@@ -269,6 +371,10 @@ func (h hypervisor) machineAccelerators() string {
 	return machineAccelerators
 }
 
+func (h hypervisor) cpuModel() string {
+	return strings.TrimSpace(h.CPUModel)
+}
+
 func (h hypervisor) cpuFeatures() string {
 	var cpuFeatures string
 	for _, feature := range strings.Split(h.CPUFeatures, ",") {
@@ -400,6 +506,92 @@ func (h hypervisor) blockDeviceDriver() (string, error) {
 	return "", fmt.Errorf("Invalid hypervisor block storage driver %v specified (supported drivers: %v)", h.BlockDeviceDriver, supportedBlockDrivers)
 }
 
+// applyMicrovmProfile adapts the configuration to the "microvm" machine
+// type. microvm has no PCI bus: the virtio devices use the MMIO transport
+// and no device can be hot plugged. The settings that cannot work are either
+// turned off, when they are only an optimisation, or rejected.
+func (h *hypervisor) applyMicrovmProfile() error {
+	// Don't require the user to add 'disable_image_nvdimm = true' in the
+	// .toml file.
+	if !h.DisableImageNvdimm {
+		h.DisableImageNvdimm = true
+		kataUtilsLogger.Info("Setting 'disable_image_nvdimm = true' as microvm does not support NVDIMM")
+	}
+
+	if h.HotplugVFIOOnRootBus {
+		h.HotplugVFIOOnRootBus = false
+		kataUtilsLogger.Info("Setting 'hotplug_vfio_on_root_bus = false' as microvm does not support PCI hotplug")
+	}
+
+	if h.PCIeRootPort > 0 {
+		h.PCIeRootPort = 0
+		kataUtilsLogger.Info("Setting 'pcie_root_port = 0' as microvm does not support PCI hotplug")
+	}
+
+	if h.PreserveVFIOTopology {
+		return errors.New("preserve_vfio_topology is not supported by the microvm machine type")
+	}
+
+	if h.IOMMU {
+		return errors.New("enable_iommu is not supported by the microvm machine type")
+	}
+
+	if h.VirtioMem {
+		return errors.New("enable_virtio_mem is not supported by the microvm machine type")
+	}
+
+	switch h.BlockDeviceDriver {
+	case "", config.VirtioSCSI, config.VirtioBlock:
+	default:
+		return fmt.Errorf("block_device_driver %q is not supported by the microvm machine type (supported drivers: %v)",
+			h.BlockDeviceDriver, []string{config.VirtioSCSI, config.VirtioBlock})
+	}
+
+	// The guest memory cannot be hot plugged either, see
+	// qemuAmd64.supportGuestMemoryHotplug.
+	return h.disableCPUHotplug("the microvm machine type")
+}
+
+// applySecureExecutionProfile adapts the configuration to the s390x Secure
+// Execution (protected virtualization) guests, whose memory is not accessible
+// by the host. The virtio devices must go through the guest bounce buffers,
+// and neither memory nor vCPUs can be hot plugged.
+func (h *hypervisor) applySecureExecutionProfile() error {
+	if !h.IOMMUPlatform {
+		h.IOMMUPlatform = true
+		kataUtilsLogger.Info("Setting 'enable_iommu_platform = true' as Secure Execution guests require it")
+	}
+
+	if h.VirtioMem {
+		return errors.New("enable_virtio_mem is not supported by Secure Execution guests: memory cannot be hot plugged")
+	}
+
+	if h.HugePages {
+		return errors.New("enable_hugepages is not supported by Secure Execution guests")
+	}
+
+	return h.disableCPUHotplug("Secure Execution guests")
+}
+
+// disableCPUHotplug caps default_maxvcpus to default_vcpus, so that the
+// runtime never tries to hot plug vCPUs. An explicit default_maxvcpus larger
+// than default_vcpus is rejected rather than silently lowered.
+func (h *hypervisor) disableCPUHotplug(reason string) error {
+	vcpus := h.defaultVCPUs()
+
+	if h.DefaultMaxVCPUs > vcpus {
+		return fmt.Errorf("default_maxvcpus (%d) larger than default_vcpus (%d) is not supported by %s: vCPUs cannot be hot plugged",
+			h.DefaultMaxVCPUs, vcpus, reason)
+	}
+
+	if h.DefaultMaxVCPUs != vcpus {
+		h.DefaultMaxVCPUs = vcpus
+		kataUtilsLogger.Infof("Setting 'default_maxvcpus = %d' as vCPUs cannot be hot plugged in %s", vcpus, reason)
+	}
+
+	return nil
+}
+
 func (h hypervisor) sharedFS() (string, error) {
 	supportedSharedFS := []string{config.Virtio9P, config.VirtioFS}
 
@@ -424,6 +616,23 @@ func (h hypervisor) msize9p() uint32 {
 	return h.Msize9p
 }
 
+func (h hypervisor) blockVolumeClasses() map[string]vc.BlockVolumeClass {
+	if len(h.BlockVolumeClasses) == 0 {
+		return nil
+	}
+
+	classes := make(map[string]vc.BlockVolumeClass, len(h.BlockVolumeClasses))
+	for name, class := range h.BlockVolumeClasses {
+		classes[name] = vc.BlockVolumeClass{
+			Cache:        class.Cache,
+			Discard:      class.Discard,
+			DetectZeroes: class.DetectZeroes,
+		}
+	}
+
+	return classes
+}
+
 func (h hypervisor) guestHookPath() string {
 	if h.GuestHookPath == "" {
 		return defaultGuestHookPath
@@ -475,10 +684,18 @@ func (a agent) debugConsoleEnabled() bool {
 	return a.DebugConsoleEnabled
 }
 
+func (a agent) portForwardEnabled() bool {
+	return a.PortForwardEnabled
+}
+
 func (a agent) dialTimout() uint32 {
 	return a.DialTimeout
 }
 
+func (a agent) timeSyncInterval() uint32 {
+	return a.TimeSyncInterval
+}
+
 func (a agent) debug() bool {
 	return a.Debug
 }
@@ -496,7 +713,17 @@ func (a agent) traceType() string {
 }
 
 func (a agent) kernelModules() []string {
-	return a.KernelModules
+	if !a.PTPKVM {
+		return a.KernelModules
+	}
+
+	for _, m := range a.KernelModules {
+		if fields := strings.Fields(m); len(fields) > 0 && fields[0] == ptpKVMKernelModule {
+			return a.KernelModules
+		}
+	}
+
+	return append(a.KernelModules, ptpKVMKernelModule)
 }
 
 func (n netmon) enable() bool {
@@ -552,34 +779,38 @@ func newFirecrackerHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 	txRateLimiterMaxRate := h.getTxRateLimiterCfg()
 
 	return vc.HypervisorConfig{
-		HypervisorPath:        hypervisor,
-		HypervisorPathList:    h.HypervisorPathList,
-		JailerPath:            jailer,
-		JailerPathList:        h.JailerPathList,
-		KernelPath:            kernel,
-		InitrdPath:            initrd,
-		ImagePath:             image,
-		FirmwarePath:          firmware,
-		KernelParams:          vc.DeserializeParams(strings.Fields(kernelParams)),
-		NumVCPUs:              h.defaultVCPUs(),
-		DefaultMaxVCPUs:       h.defaultMaxVCPUs(),
-		MemorySize:            h.defaultMemSz(),
-		MemSlots:              h.defaultMemSlots(),
-		EntropySource:         h.GetEntropySource(),
-		EntropySourceList:     h.EntropySourceList,
-		DefaultBridges:        h.defaultBridges(),
-		DisableBlockDeviceUse: h.DisableBlockDeviceUse,
-		HugePages:             h.HugePages,
-		Mlock:                 !h.Swap,
-		Debug:                 h.Debug,
-		DisableNestingChecks:  h.DisableNestingChecks,
-		BlockDeviceDriver:     blockDriver,
-		EnableIOThreads:       h.EnableIOThreads,
-		DisableVhostNet:       true, // vhost-net backend is not supported in Firecracker
-		GuestHookPath:         h.guestHookPath(),
-		RxRateLimiterMaxRate:  rxRateLimiterMaxRate,
-		TxRateLimiterMaxRate:  txRateLimiterMaxRate,
-		EnableAnnotations:     h.EnableAnnotations,
+		HypervisorPath:             hypervisor,
+		HypervisorPathList:         h.HypervisorPathList,
+		JailerPath:                 jailer,
+		JailerPathList:             h.JailerPathList,
+		KernelPath:                 kernel,
+		InitrdPath:                 initrd,
+		ImagePath:                  image,
+		FirmwarePath:               firmware,
+		KernelParams:               vc.DeserializeParams(strings.Fields(kernelParams)),
+		NumVCPUs:                   h.defaultVCPUs(),
+		DefaultMaxVCPUs:            h.defaultMaxVCPUs(),
+		MemorySize:                 h.defaultMemSz(),
+		MemSlots:                   h.defaultMemSlots(),
+		EntropySource:              h.GetEntropySource(),
+		EntropySourceList:          h.EntropySourceList,
+		GuestRNGSeed:               h.GuestRNGSeed,
+		RNGTrustCPU:                h.RNGTrustCPU,
+		ArtifactsManifest:          h.ArtifactsManifest,
+		ArtifactsManifestPublicKey: h.ArtifactsManifestPublicKey,
+		DefaultBridges:             h.defaultBridges(),
+		DisableBlockDeviceUse:      h.DisableBlockDeviceUse,
+		HugePages:                  h.HugePages,
+		Mlock:                      !h.Swap,
+		Debug:                      h.Debug,
+		DisableNestingChecks:       h.DisableNestingChecks,
+		BlockDeviceDriver:          blockDriver,
+		EnableIOThreads:            h.EnableIOThreads,
+		DisableVhostNet:            true, // vhost-net backend is not supported in Firecracker
+		GuestHookPath:              h.guestHookPath(),
+		RxRateLimiterMaxRate:       rxRateLimiterMaxRate,
+		TxRateLimiterMaxRate:       txRateLimiterMaxRate,
+		EnableAnnotations:          h.EnableAnnotations,
 	}, nil
 }
 
@@ -624,12 +855,16 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 	kernelParams := h.kernelParams()
 	machineType := h.machineType()
 
-	// The "microvm" machine type doesn't support NVDIMM so override the
-	// config setting to explicitly disable it (i.e. don't require the
-	// user to add 'disable_image_nvdimm = true' in the .toml file).
-	if machineType == govmmQemu.MachineTypeMicrovm && !h.DisableImageNvdimm {
-		h.DisableImageNvdimm = true
-		kataUtilsLogger.Info("Setting 'disable_image_nvdimm = true' as microvm does not support NVDIMM")
+	if machineType == govmmQemu.MachineTypeMicrovm {
+		if err := h.applyMicrovmProfile(); err != nil {
+			return vc.HypervisorConfig{}, err
+		}
+	}
+
+	if machineType == vc.QemuCCWVirtio && h.ConfidentialGuest {
+		if err := h.applySecureExecutionProfile(); err != nil {
+			return vc.HypervisorConfig{}, err
+		}
 	}
 
 	blockDriver, err := h.blockDeviceDriver()
@@ -651,66 +886,116 @@ func newQemuHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 		return vc.HypervisorConfig{}, err
 	}
 
+	vhostUserGPUDaemon, err := h.vhostUserGPUDaemon()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	virtioSound, err := h.virtioSound()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
 	rxRateLimiterMaxRate := h.getRxRateLimiterCfg()
 	txRateLimiterMaxRate := h.getTxRateLimiterCfg()
 
 	return vc.HypervisorConfig{
-		HypervisorPath:          hypervisor,
-		HypervisorPathList:      h.HypervisorPathList,
-		KernelPath:              kernel,
-		InitrdPath:              initrd,
-		ImagePath:               image,
-		FirmwarePath:            firmware,
-		PFlash:                  pflashes,
-		MachineAccelerators:     machineAccelerators,
-		CPUFeatures:             cpuFeatures,
-		KernelParams:            vc.DeserializeParams(strings.Fields(kernelParams)),
-		HypervisorMachineType:   machineType,
-		NumVCPUs:                h.defaultVCPUs(),
-		DefaultMaxVCPUs:         h.defaultMaxVCPUs(),
-		MemorySize:              h.defaultMemSz(),
-		MemSlots:                h.defaultMemSlots(),
-		MemOffset:               h.defaultMemOffset(),
-		VirtioMem:               h.VirtioMem,
-		EntropySource:           h.GetEntropySource(),
-		EntropySourceList:       h.EntropySourceList,
-		DefaultBridges:          h.defaultBridges(),
-		DisableBlockDeviceUse:   h.DisableBlockDeviceUse,
-		SharedFS:                sharedFS,
-		VirtioFSDaemon:          h.VirtioFSDaemon,
-		VirtioFSDaemonList:      h.VirtioFSDaemonList,
-		VirtioFSCacheSize:       h.VirtioFSCacheSize,
-		VirtioFSCache:           h.defaultVirtioFSCache(),
-		VirtioFSExtraArgs:       h.VirtioFSExtraArgs,
-		MemPrealloc:             h.MemPrealloc,
-		HugePages:               h.HugePages,
-		IOMMU:                   h.IOMMU,
-		IOMMUPlatform:           h.getIOMMUPlatform(),
-		FileBackedMemRootDir:    h.FileBackedMemRootDir,
-		FileBackedMemRootList:   h.FileBackedMemRootList,
-		Mlock:                   !h.Swap,
-		Debug:                   h.Debug,
-		DisableNestingChecks:    h.DisableNestingChecks,
-		BlockDeviceDriver:       blockDriver,
-		BlockDeviceCacheSet:     h.BlockDeviceCacheSet,
-		BlockDeviceCacheDirect:  h.BlockDeviceCacheDirect,
-		BlockDeviceCacheNoflush: h.BlockDeviceCacheNoflush,
-		EnableIOThreads:         h.EnableIOThreads,
-		Msize9p:                 h.msize9p(),
-		DisableImageNvdimm:      h.DisableImageNvdimm,
-		HotplugVFIOOnRootBus:    h.HotplugVFIOOnRootBus,
-		PCIeRootPort:            h.PCIeRootPort,
-		DisableVhostNet:         h.DisableVhostNet,
-		EnableVhostUserStore:    h.EnableVhostUserStore,
-		VhostUserStorePath:      h.vhostUserStorePath(),
-		VhostUserStorePathList:  h.VhostUserStorePathList,
-		GuestHookPath:           h.guestHookPath(),
-		RxRateLimiterMaxRate:    rxRateLimiterMaxRate,
-		TxRateLimiterMaxRate:    txRateLimiterMaxRate,
-		EnableAnnotations:       h.EnableAnnotations,
-		GuestMemoryDumpPath:     h.GuestMemoryDumpPath,
-		GuestMemoryDumpPaging:   h.GuestMemoryDumpPaging,
-		ConfidentialGuest:       h.ConfidentialGuest,
+		HypervisorPath:             hypervisor,
+		HypervisorPathList:         h.HypervisorPathList,
+		KernelPath:                 kernel,
+		InitrdPath:                 initrd,
+		ImagePath:                  image,
+		FirmwarePath:               firmware,
+		PFlash:                     pflashes,
+		MachineAccelerators:        machineAccelerators,
+		CPUFeatures:                cpuFeatures,
+		CPUModel:                   h.cpuModel(),
+		KernelParams:               vc.DeserializeParams(strings.Fields(kernelParams)),
+		HypervisorMachineType:      machineType,
+		NumVCPUs:                   h.defaultVCPUs(),
+		DefaultMaxVCPUs:            h.defaultMaxVCPUs(),
+		MemorySize:                 h.defaultMemSz(),
+		MemSlots:                   h.defaultMemSlots(),
+		MemOffset:                  h.defaultMemOffset(),
+		VirtioMem:                  h.VirtioMem,
+		EntropySource:              h.GetEntropySource(),
+		EntropyMaxBytes:            h.EntropyMaxBytes,
+		EntropyPeriod:              h.EntropyPeriod,
+		EntropySourceList:          h.EntropySourceList,
+		GuestRNGSeed:               h.GuestRNGSeed,
+		RNGTrustCPU:                h.RNGTrustCPU,
+		ArtifactsManifest:          h.ArtifactsManifest,
+		ArtifactsManifestPublicKey: h.ArtifactsManifestPublicKey,
+		DefaultBridges:             h.defaultBridges(),
+		DisableBlockDeviceUse:      h.DisableBlockDeviceUse,
+		SharedFS:                   sharedFS,
+		VirtioFSDaemon:             h.VirtioFSDaemon,
+		VirtioFSDaemonList:         h.VirtioFSDaemonList,
+		VirtioFSCacheSize:          h.VirtioFSCacheSize,
+		VirtioFSCache:              h.defaultVirtioFSCache(),
+		VirtioFSExtraArgs:          h.VirtioFSExtraArgs,
+		VirtioFSSandbox:            h.VirtioFSSandbox,
+		VirtioFSSeccomp:            h.VirtioFSSeccomp,
+		VirtioFSRestarts:           h.VirtioFSRestarts,
+		LaunchAttempts:             h.LaunchAttempts,
+		LaunchBackoff:              h.LaunchBackoff,
+		MemPrealloc:                h.MemPrealloc,
+		HugePages:                  h.HugePages,
+		IOMMU:                      h.IOMMU,
+		IOMMUPlatform:              h.getIOMMUPlatform(),
+		FileBackedMemRootDir:       h.FileBackedMemRootDir,
+		FileBackedMemRootList:      h.FileBackedMemRootList,
+		Mlock:                      !h.Swap,
+		Debug:                      h.Debug,
+		DisableNestingChecks:       h.DisableNestingChecks,
+		BlockDeviceDriver:          blockDriver,
+		BlockDeviceCacheSet:        h.BlockDeviceCacheSet,
+		BlockDeviceCacheDirect:     h.BlockDeviceCacheDirect,
+		BlockDeviceCacheNoflush:    h.BlockDeviceCacheNoflush,
+		EnableIOThreads:            h.EnableIOThreads,
+		HotplugIOThreads:           h.HotplugIOThreads,
+		HotplugConcurrency:         h.HotplugConcurrency,
+		BalloonStatsInterval:       h.BalloonStatsInterval,
+		VhostUserGPUDaemon:         vhostUserGPUDaemon,
+		VhostUserGPUExtraArgs:      h.VhostUserGPUExtraArgs,
+		VirtioSound:                virtioSound,
+		BlockVolumeClasses:         h.blockVolumeClasses(),
+		EmulatorThreadsWeight:      h.EmulatorThreadsWeight,
+		VCPUThreadsWeight:          h.VCPUThreadsWeight,
+		IOThreadsWeight:            h.IOThreadsWeight,
+		EnableNUMAPinning:          h.EnableNUMAPinning,
+		NUMANode:                   h.NUMANode,
+		Msize9p:                    h.msize9p(),
+		DisableImageNvdimm:         h.DisableImageNvdimm,
+		ReadOnlyImage:              h.ReadOnlyImage,
+		HotplugVFIOOnRootBus:       h.HotplugVFIOOnRootBus,
+		PreserveVFIOTopology:       h.PreserveVFIOTopology,
+		ErofsLayers:                h.ErofsLayers,
+		ColdPlugDevices:            h.ColdPlugDevices,
+		ColdPlugDevicePathList:     h.ColdPlugDevicePathList,
+		VFIOAPDevices:              h.VFIOAPDevices,
+		VFIOAPDevicePathList:       h.VFIOAPDevicePathList,
+		VFIOBindDevices:            h.VFIOBindDevices,
+		VFIOBindDevicePathList:     h.VFIOBindDevicePathList,
+		VFIOMdevDevices:            h.VFIOMdevDevices,
+		VFIOMdevDevicePathList:     h.VFIOMdevDevicePathList,
+		VhostUserNetSocketPathList: h.VhostUserNetSocketPathList,
+		PCIeRootPort:               h.PCIeRootPort,
+		DisableVhostNet:            h.DisableVhostNet,
+		EnableVhostUserStore:       h.EnableVhostUserStore,
+		VhostUserStorePath:         h.vhostUserStorePath(),
+		EnableMultipath:            h.EnableMultipath,
+		VhostUserStorePathList:     h.VhostUserStorePathList,
+		GuestHookPath:              h.guestHookPath(),
+		RxRateLimiterMaxRate:       rxRateLimiterMaxRate,
+		TxRateLimiterMaxRate:       txRateLimiterMaxRate,
+		EnableAnnotations:          h.EnableAnnotations,
+		GuestMemoryDumpPath:        h.GuestMemoryDumpPath,
+		GuestMemoryDumpPaging:      h.GuestMemoryDumpPaging,
+		ConfidentialGuest:          h.ConfidentialGuest,
+		Watchdog:                   h.Watchdog,
+		WatchdogAction:             h.WatchdogAction,
+		WatchdogTimeout:            h.WatchdogTimeout,
 	}, nil
 }
 
@@ -753,29 +1038,77 @@ func newAcrnHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 	}
 
 	return vc.HypervisorConfig{
-		HypervisorPath:        hypervisor,
-		HypervisorPathList:    h.HypervisorPathList,
-		KernelPath:            kernel,
-		ImagePath:             image,
-		HypervisorCtlPath:     hypervisorctl,
-		HypervisorCtlPathList: h.CtlPathList,
-		FirmwarePath:          firmware,
-		KernelParams:          vc.DeserializeParams(strings.Fields(kernelParams)),
-		NumVCPUs:              h.defaultVCPUs(),
-		DefaultMaxVCPUs:       h.defaultMaxVCPUs(),
-		MemorySize:            h.defaultMemSz(),
-		MemSlots:              h.defaultMemSlots(),
-		EntropySource:         h.GetEntropySource(),
-		EntropySourceList:     h.EntropySourceList,
-		DefaultBridges:        h.defaultBridges(),
-		HugePages:             h.HugePages,
-		Mlock:                 !h.Swap,
-		Debug:                 h.Debug,
-		DisableNestingChecks:  h.DisableNestingChecks,
-		BlockDeviceDriver:     blockDriver,
-		DisableVhostNet:       h.DisableVhostNet,
-		GuestHookPath:         h.guestHookPath(),
-		EnableAnnotations:     h.EnableAnnotations,
+		HypervisorPath:             hypervisor,
+		HypervisorPathList:         h.HypervisorPathList,
+		KernelPath:                 kernel,
+		ImagePath:                  image,
+		HypervisorCtlPath:          hypervisorctl,
+		HypervisorCtlPathList:      h.CtlPathList,
+		FirmwarePath:               firmware,
+		KernelParams:               vc.DeserializeParams(strings.Fields(kernelParams)),
+		NumVCPUs:                   h.defaultVCPUs(),
+		DefaultMaxVCPUs:            h.defaultMaxVCPUs(),
+		MemorySize:                 h.defaultMemSz(),
+		MemSlots:                   h.defaultMemSlots(),
+		EntropySource:              h.GetEntropySource(),
+		EntropySourceList:          h.EntropySourceList,
+		GuestRNGSeed:               h.GuestRNGSeed,
+		RNGTrustCPU:                h.RNGTrustCPU,
+		ArtifactsManifest:          h.ArtifactsManifest,
+		ArtifactsManifestPublicKey: h.ArtifactsManifestPublicKey,
+		DefaultBridges:             h.defaultBridges(),
+		HugePages:                  h.HugePages,
+		Mlock:                      !h.Swap,
+		Debug:                      h.Debug,
+		DisableNestingChecks:       h.DisableNestingChecks,
+		BlockDeviceDriver:          blockDriver,
+		DisableVhostNet:            h.DisableVhostNet,
+		GuestHookPath:              h.guestHookPath(),
+		EnableAnnotations:          h.EnableAnnotations,
+	}, nil
+}
+
+// newMockHypervisorConfig returns the configuration of the mock hypervisor,
+// which boots no guest, its kernel and image are thus not checked.
+func newMockHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
+	kernel := h.Kernel
+	if kernel == "" {
+		kernel = defaultKernelPath
+	}
+
+	image := h.Image
+	if image == "" && h.Initrd == "" {
+		image = defaultImagePath
+	}
+
+	var faults []vc.MockFault
+	for _, f := range h.MockFaults {
+		fault, err := vc.ParseMockFault(f)
+		if err != nil {
+			return vc.HypervisorConfig{}, err
+		}
+		faults = append(faults, fault)
+	}
+
+	blockDriver, err := h.blockDeviceDriver()
+	if err != nil {
+		return vc.HypervisorConfig{}, err
+	}
+
+	return vc.HypervisorConfig{
+		KernelPath:        kernel,
+		ImagePath:         image,
+		InitrdPath:        h.Initrd,
+		KernelParams:      vc.DeserializeParams(strings.Fields(h.kernelParams())),
+		NumVCPUs:          h.defaultVCPUs(),
+		DefaultMaxVCPUs:   h.defaultMaxVCPUs(),
+		MemorySize:        h.defaultMemSz(),
+		MemSlots:          h.defaultMemSlots(),
+		DefaultBridges:    h.defaultBridges(),
+		BlockDeviceDriver: blockDriver,
+		Debug:             h.Debug,
+		EnableAnnotations: h.EnableAnnotations,
+		MockFaults:        faults,
 	}, nil
 }
 
@@ -827,50 +1160,59 @@ func newClhHypervisorConfig(h hypervisor) (vc.HypervisorConfig, error) {
 	}
 
 	return vc.HypervisorConfig{
-		HypervisorPath:          hypervisor,
-		HypervisorPathList:      h.HypervisorPathList,
-		KernelPath:              kernel,
-		InitrdPath:              initrd,
-		ImagePath:               image,
-		FirmwarePath:            firmware,
-		MachineAccelerators:     machineAccelerators,
-		KernelParams:            vc.DeserializeParams(strings.Fields(kernelParams)),
-		HypervisorMachineType:   machineType,
-		NumVCPUs:                h.defaultVCPUs(),
-		DefaultMaxVCPUs:         h.defaultMaxVCPUs(),
-		MemorySize:              h.defaultMemSz(),
-		MemSlots:                h.defaultMemSlots(),
-		MemOffset:               h.defaultMemOffset(),
-		VirtioMem:               h.VirtioMem,
-		EntropySource:           h.GetEntropySource(),
-		EntropySourceList:       h.EntropySourceList,
-		DefaultBridges:          h.defaultBridges(),
-		DisableBlockDeviceUse:   h.DisableBlockDeviceUse,
-		SharedFS:                sharedFS,
-		VirtioFSDaemon:          h.VirtioFSDaemon,
-		VirtioFSDaemonList:      h.VirtioFSDaemonList,
-		VirtioFSCacheSize:       h.VirtioFSCacheSize,
-		VirtioFSCache:           h.VirtioFSCache,
-		MemPrealloc:             h.MemPrealloc,
-		HugePages:               h.HugePages,
-		FileBackedMemRootDir:    h.FileBackedMemRootDir,
-		FileBackedMemRootList:   h.FileBackedMemRootList,
-		Mlock:                   !h.Swap,
-		Debug:                   h.Debug,
-		DisableNestingChecks:    h.DisableNestingChecks,
-		BlockDeviceDriver:       blockDriver,
-		BlockDeviceCacheSet:     h.BlockDeviceCacheSet,
-		BlockDeviceCacheDirect:  h.BlockDeviceCacheDirect,
-		BlockDeviceCacheNoflush: h.BlockDeviceCacheNoflush,
-		EnableIOThreads:         h.EnableIOThreads,
-		Msize9p:                 h.msize9p(),
-		HotplugVFIOOnRootBus:    h.HotplugVFIOOnRootBus,
-		PCIeRootPort:            h.PCIeRootPort,
-		DisableVhostNet:         true,
-		GuestHookPath:           h.guestHookPath(),
-		VirtioFSExtraArgs:       h.VirtioFSExtraArgs,
-		SGXEPCSize:              defaultSGXEPCSize,
-		EnableAnnotations:       h.EnableAnnotations,
+		HypervisorPath:             hypervisor,
+		HypervisorPathList:         h.HypervisorPathList,
+		KernelPath:                 kernel,
+		InitrdPath:                 initrd,
+		ImagePath:                  image,
+		FirmwarePath:               firmware,
+		MachineAccelerators:        machineAccelerators,
+		KernelParams:               vc.DeserializeParams(strings.Fields(kernelParams)),
+		HypervisorMachineType:      machineType,
+		NumVCPUs:                   h.defaultVCPUs(),
+		DefaultMaxVCPUs:            h.defaultMaxVCPUs(),
+		MemorySize:                 h.defaultMemSz(),
+		MemSlots:                   h.defaultMemSlots(),
+		MemOffset:                  h.defaultMemOffset(),
+		VirtioMem:                  h.VirtioMem,
+		EntropySource:              h.GetEntropySource(),
+		EntropySourceList:          h.EntropySourceList,
+		GuestRNGSeed:               h.GuestRNGSeed,
+		RNGTrustCPU:                h.RNGTrustCPU,
+		ArtifactsManifest:          h.ArtifactsManifest,
+		ArtifactsManifestPublicKey: h.ArtifactsManifestPublicKey,
+		DefaultBridges:             h.defaultBridges(),
+		DisableBlockDeviceUse:      h.DisableBlockDeviceUse,
+		SharedFS:                   sharedFS,
+		VirtioFSDaemon:             h.VirtioFSDaemon,
+		VirtioFSDaemonList:         h.VirtioFSDaemonList,
+		VirtioFSCacheSize:          h.VirtioFSCacheSize,
+		VirtioFSCache:              h.VirtioFSCache,
+		MemPrealloc:                h.MemPrealloc,
+		HugePages:                  h.HugePages,
+		FileBackedMemRootDir:       h.FileBackedMemRootDir,
+		FileBackedMemRootList:      h.FileBackedMemRootList,
+		Mlock:                      !h.Swap,
+		Debug:                      h.Debug,
+		DisableNestingChecks:       h.DisableNestingChecks,
+		BlockDeviceDriver:          blockDriver,
+		BlockDeviceCacheSet:        h.BlockDeviceCacheSet,
+		BlockDeviceCacheDirect:     h.BlockDeviceCacheDirect,
+		BlockDeviceCacheNoflush:    h.BlockDeviceCacheNoflush,
+		EnableIOThreads:            h.EnableIOThreads,
+		HotplugConcurrency:         h.HotplugConcurrency,
+		Msize9p:                    h.msize9p(),
+		HotplugVFIOOnRootBus:       h.HotplugVFIOOnRootBus,
+		PCIeRootPort:               h.PCIeRootPort,
+		DisableVhostNet:            true,
+		GuestHookPath:              h.guestHookPath(),
+		VirtioFSExtraArgs:          h.VirtioFSExtraArgs,
+		VirtioFSSandbox:            h.VirtioFSSandbox,
+		VirtioFSSeccomp:            h.VirtioFSSeccomp,
+		LaunchAttempts:             h.LaunchAttempts,
+		LaunchBackoff:              h.LaunchBackoff,
+		SGXEPCSize:                 defaultSGXEPCSize,
+		EnableAnnotations:          h.EnableAnnotations,
 	}, nil
 }
 
@@ -907,6 +1249,9 @@ func updateRuntimeConfigHypervisor(configPath string, tomlConf tomlConfig, confi
 		case clhHypervisorTableType:
 			config.HypervisorType = vc.ClhHypervisor
 			hConfig, err = newClhHypervisorConfig(hypervisor)
+		case mockHypervisorTableType:
+			config.HypervisorType = vc.MockHypervisor
+			hConfig, err = newMockHypervisorConfig(hypervisor)
 		}
 
 		if err != nil {
@@ -919,17 +1264,72 @@ func updateRuntimeConfigHypervisor(configPath string, tomlConf tomlConfig, confi
 	return nil
 }
 
+// updateRuntimeConfigImage sets up the guest image store disk, which is only
+// meant for the images pulled in the guest.
+func updateRuntimeConfigImage(configPath string, tomlConf tomlConfig, config *oci.RuntimeConfig) error {
+	if tomlConf.Image.GuestStoreSize == 0 {
+		if tomlConf.Image.GuestStoreDiscard {
+			return fmt.Errorf("%v: guest_store_discard requires guest_store_size", configPath)
+		}
+		return nil
+	}
+
+	if !tomlConf.Image.ServiceOffload {
+		return fmt.Errorf("%v: guest_store_size requires service_offload", configPath)
+	}
+
+	config.HypervisorConfig.GuestImageStoreSize = tomlConf.Image.GuestStoreSize
+	config.HypervisorConfig.GuestImageStoreDiscard = tomlConf.Image.GuestStoreDiscard
+
+	return nil
+}
+
+// updateRuntimeConfigHooks sets up the sandbox hooks, which are run by the
+// runtime, from the host.
+func updateRuntimeConfigHooks(configPath string, tomlConf tomlConfig, config *oci.RuntimeConfig) error {
+	h := tomlConf.Hooks
+
+	for _, hooks := range [][]string{h.PreVMStart, h.PostVMStart, h.PostAgentReady, h.PreVMStop} {
+		for _, hook := range hooks {
+			if !filepath.IsAbs(hook) {
+				return fmt.Errorf("%v: sandbox hook %q must be an absolute path", configPath, hook)
+			}
+		}
+	}
+
+	config.SandboxHooks = vc.SandboxHooks{
+		PreVMStart:     h.PreVMStart,
+		PostVMStart:    h.PostVMStart,
+		PostAgentReady: h.PostAgentReady,
+		PreVMStop:      h.PreVMStop,
+		Timeout:        time.Duration(h.Timeout) * time.Second,
+	}
+
+	return nil
+}
+
 func updateRuntimeConfigAgent(configPath string, tomlConf tomlConfig, config *oci.RuntimeConfig) error {
 	for _, agent := range tomlConf.Agent {
 		config.AgentConfig = vc.KataAgentConfig{
-			LongLiveConn:       true,
-			Debug:              agent.debug(),
-			Trace:              agent.trace(),
-			TraceMode:          agent.traceMode(),
-			TraceType:          agent.traceType(),
-			KernelModules:      agent.kernelModules(),
-			EnableDebugConsole: agent.debugConsoleEnabled(),
-			DialTimeout:        agent.dialTimout(),
+			LongLiveConn:         true,
+			Debug:                agent.debug(),
+			Trace:                agent.trace(),
+			TraceMode:            agent.traceMode(),
+			TraceType:            agent.traceType(),
+			KernelModules:        agent.kernelModules(),
+			KernelModulesList:    agent.KernelModulesList,
+			GuestSysctls:         agent.GuestSysctls,
+			GuestSysctlsList:     agent.GuestSysctlsList,
+			EnableDebugConsole:   agent.debugConsoleEnabled(),
+			EnablePortForward:    agent.portForwardEnabled(),
+			EnableNetworkCapture: agent.NetworkCapture,
+			EnableGuestSync:      agent.GuestSync,
+			EnableCheckpoint:     agent.Checkpoint,
+			EnableLogProxy:       agent.LogProxy,
+			DialTimeout:          agent.dialTimout(),
+			TimeSyncInterval:     agent.timeSyncInterval(),
+			PolicyFile:           agent.PolicyFile,
+			PolicyAudit:          agent.PolicyAudit,
 		}
 	}
 
@@ -971,6 +1371,24 @@ func SetKernelParams(runtimeConfig *oci.RuntimeConfig) error {
 		}
 	}
 
+	// make the guest image strictly read-only
+	if runtimeConfig.HypervisorConfig.ReadOnlyImage && runtimeConfig.HypervisorConfig.ImagePath != "" {
+		for _, p := range vc.ReadOnlyImageKernelParams {
+			if err := runtimeConfig.AddKernelParam(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	// initialize the guest random number generator from the CPU one
+	if runtimeConfig.HypervisorConfig.RNGTrustCPU {
+		for _, p := range vc.RNGTrustCPUKernelParams {
+			if err := runtimeConfig.AddKernelParam(p); err != nil {
+				return err
+			}
+		}
+	}
+
 	// next, check for agent specific kernel params
 	err := vc.KataAgentSetDefaultTraceConfigOptions(&runtimeConfig.AgentConfig)
 	if err != nil {
@@ -1004,6 +1422,14 @@ func updateRuntimeConfig(configPath string, tomlConf tomlConfig, config *oci.Run
 		return err
 	}
 
+	if err := updateRuntimeConfigImage(configPath, tomlConf, config); err != nil {
+		return err
+	}
+
+	if err := updateRuntimeConfigHooks(configPath, tomlConf, config); err != nil {
+		return err
+	}
+
 	fConfig, err := newFactoryConfig(tomlConf.Factory)
 	if err != nil {
 		return fmt.Errorf("%v: %v", configPath, err)
@@ -1132,6 +1558,8 @@ func LoadConfiguration(configPath string, ignoreLogging bool) (resolvedConfigPat
 				"format": "TOML",
 				"file":   resolved,
 			}).Info("loaded configuration")
+
+		logConfigDrift(resolved)
 	}
 
 	if err := updateRuntimeConfig(resolved, tomlConf, &config); err != nil {
@@ -1146,6 +1574,11 @@ func LoadConfiguration(configPath string, ignoreLogging bool) (resolvedConfigPat
 	config.JaegerEndpoint = tomlConf.Runtime.JaegerEndpoint
 	config.JaegerUser = tomlConf.Runtime.JaegerUser
 	config.JaegerPassword = tomlConf.Runtime.JaegerPassword
+	config.NRIConfig = nri.Config{
+		Enabled:    tomlConf.Runtime.EnableNRI,
+		ConfigPath: tomlConf.Runtime.NRIConfigPath,
+		PluginPath: tomlConf.Runtime.NRIPluginPath,
+	}
 	for _, f := range tomlConf.Runtime.Experimental {
 		feature := exp.Get(f)
 		if feature == nil {
@@ -1159,6 +1592,17 @@ func LoadConfiguration(configPath string, ignoreLogging bool) (resolvedConfigPat
 	}
 	config.SandboxBindMounts = tomlConf.Runtime.SandboxBindMounts
 
+	if err = validateHistogramBuckets("agent_rpc_duration_buckets", tomlConf.Runtime.AgentRPCDurationBuckets); err != nil {
+		return "", config, err
+	}
+	if err = validateHistogramBuckets("hypervisor_launch_duration_buckets", tomlConf.Runtime.HypervisorLaunchDurationBuckets); err != nil {
+		return "", config, err
+	}
+	config.MetricsConfig = vc.MetricsConfig{
+		AgentRPCBuckets:         tomlConf.Runtime.AgentRPCDurationBuckets,
+		HypervisorLaunchBuckets: tomlConf.Runtime.HypervisorLaunchDurationBuckets,
+	}
+
 	if err := checkConfig(config); err != nil {
 		return "", config, err
 	}
@@ -1191,6 +1635,19 @@ func validateBindMounts(mounts []string) error {
 	return nil
 }
 
+// Verify that histogram bucket boundaries are positive and strictly increasing
+func validateHistogramBuckets(name string, buckets []float64) error {
+	for i, b := range buckets {
+		if b <= 0 {
+			return fmt.Errorf("%s: bucket boundary %v must be positive", name, b)
+		}
+		if i > 0 && b <= buckets[i-1] {
+			return fmt.Errorf("%s: bucket boundaries must be strictly increasing, got %v after %v", name, b, buckets[i-1])
+		}
+	}
+	return nil
+}
+
 func decodeConfig(configPath string) (tomlConfig, string, error) {
 	var (
 		resolved string
@@ -1208,13 +1665,7 @@ func decodeConfig(configPath string) (tomlConfig, string, error) {
 		return tomlConf, "", fmt.Errorf("Cannot find usable config file (%v)", err)
 	}
 
-	configData, err := ioutil.ReadFile(resolved)
-	if err != nil {
-		return tomlConf, resolved, err
-	}
-
-	_, err = toml.Decode(string(configData), &tomlConf)
-	if err != nil {
+	if err := decodeConfigFile(resolved, &tomlConf); err != nil {
 		return tomlConf, resolved, err
 	}
 
@@ -1227,14 +1678,61 @@ func checkConfig(config oci.RuntimeConfig) error {
 		return err
 	}
 
-	if err := checkHypervisorConfig(config.HypervisorConfig); err != nil {
-		return err
+	// The mock hypervisor boots no guest, there is no image to check.
+	if config.HypervisorType != vc.MockHypervisor {
+		if err := checkHypervisorConfig(config.HypervisorConfig); err != nil {
+			return err
+		}
 	}
 
 	if err := checkFactoryConfig(config); err != nil {
 		return err
 	}
 
+	if err := checkThreadsWeightConfig(config); err != nil {
+		return err
+	}
+
+	if err := checkAgentConfig(config); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkAgentConfig ensures the agent features are compatible with the
+// rest of the configuration.
+func checkAgentConfig(config oci.RuntimeConfig) error {
+	// Anyone able to reach the shim management socket could reach
+	// the guest loopback ports.
+	if config.AgentConfig.EnablePortForward && config.HypervisorConfig.ConfidentialGuest {
+		return errors.New("enable_port_forward is not supported with confidential_guest")
+	}
+
+	// Nor capture the guest traffic
+	if config.AgentConfig.EnableNetworkCapture && config.HypervisorConfig.ConfidentialGuest {
+		return errors.New("enable_network_capture is not supported with confidential_guest")
+	}
+
+	// Nor dump the memory of the containers, or restore processes in them
+	if config.AgentConfig.EnableCheckpoint && config.HypervisorConfig.ConfidentialGuest {
+		return errors.New("enable_checkpoint is not supported with confidential_guest")
+	}
+
+	if config.AgentConfig.PolicyFile != "" && !filepath.IsAbs(config.AgentConfig.PolicyFile) {
+		return fmt.Errorf("policy_file %q must be an absolute guest path", config.AgentConfig.PolicyFile)
+	}
+
+	if config.AgentConfig.PolicyAudit && config.AgentConfig.PolicyFile == "" {
+		return errors.New("policy_audit requires a policy_file")
+	}
+
+	for _, sysctl := range config.AgentConfig.GuestSysctls {
+		if _, _, err := vc.ParseGuestSysctl(sysctl); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1253,12 +1751,47 @@ func checkNetNsConfig(config oci.RuntimeConfig) error {
 	return nil
 }
 
+// checkThreadsWeightConfig ensures the hypervisor threads cpu weights are
+// valid cgroup cpu.weight values, and only set when the hypervisor runs in
+// the sandbox cgroup.
+func checkThreadsWeightConfig(config oci.RuntimeConfig) error {
+	weights := []struct {
+		name   string
+		weight uint64
+	}{
+		{"emulator_threads_weight", config.HypervisorConfig.EmulatorThreadsWeight},
+		{"vcpu_threads_weight", config.HypervisorConfig.VCPUThreadsWeight},
+		{"iothreads_weight", config.HypervisorConfig.IOThreadsWeight},
+	}
+
+	for _, w := range weights {
+		if w.weight == 0 {
+			continue
+		}
+
+		if w.weight > cgroups.MaxCPUWeight {
+			return fmt.Errorf("%s %d is greater than %d", w.name, w.weight, cgroups.MaxCPUWeight)
+		}
+
+		if !config.SandboxCgroupOnly {
+			return fmt.Errorf("%s requires sandbox_cgroup_only", w.name)
+		}
+	}
+
+	return nil
+}
+
 // checkFactoryConfig ensures the VM factory configuration is valid.
 func checkFactoryConfig(config oci.RuntimeConfig) error {
 	if config.FactoryConfig.Template {
 		if config.HypervisorConfig.InitrdPath == "" {
 			return errors.New("Factory option enable_template requires an initrd image")
 		}
+
+		// The memory of a confidential guest cannot be cloned.
+		if config.HypervisorConfig.ConfidentialGuest {
+			return errors.New("Factory option enable_template is not supported with confidential_guest")
+		}
 	}
 
 	if config.FactoryConfig.VMCacheNumber > 0 {
@@ -1295,6 +1828,20 @@ func checkHypervisorConfig(config vc.HypervisorConfig) error {
 		return errors.New("VM memory cannot be zero")
 	}
 
+	// The EROFS layers are hot plugged block devices.
+	if config.ErofsLayers && (config.DisableBlockDeviceUse || config.ColdPlugDevices) {
+		return errors.New("erofs_layers is not supported with disable_block_device_use or cold_plug_devices")
+	}
+
+	// The guest image store disk is a hot plugged block device.
+	if config.GuestImageStoreSize != 0 && config.DisableBlockDeviceUse {
+		return errors.New("guest_store_size is not supported with disable_block_device_use")
+	}
+
+	if err := checkBlockVolumeClasses(config.BlockVolumeClasses); err != nil {
+		return err
+	}
+
 	mb := int64(1024 * 1024)
 
 	for _, image := range images {
@@ -1335,6 +1882,30 @@ func checkHypervisorConfig(config vc.HypervisorConfig) error {
 	return nil
 }
 
+// checkBlockVolumeClasses checks the cache and detect-zeroes modes of the
+// block volume classes.
+func checkBlockVolumeClasses(classes map[string]vc.BlockVolumeClass) error {
+	for name, class := range classes {
+		switch class.Cache {
+		case "", "none", "writeback":
+		default:
+			return fmt.Errorf("block volume class %s: invalid cache mode %q, must be none or writeback", name, class.Cache)
+		}
+
+		switch class.DetectZeroes {
+		case "", "off", "on":
+		case "unmap":
+			if !class.Discard {
+				return fmt.Errorf("block volume class %s: detect_zeroes unmap requires discard", name)
+			}
+		default:
+			return fmt.Errorf("block volume class %s: invalid detect_zeroes mode %q, must be off, on or unmap", name, class.DetectZeroes)
+		}
+	}
+
+	return nil
+}
+
 // GetDefaultConfigFilePaths returns a list of paths that will be
 // considered as configuration files in priority order.
 func GetDefaultConfigFilePaths() []string {
@@ -1347,6 +1918,56 @@ func GetDefaultConfigFilePaths() []string {
 	}
 }
 
+// shimConfigSuffix returns the runtime class suffix of the shim binary name,
+// e.g. "qemu" for containerd-shim-kata-qemu-v2, or "" for the default shim.
+func shimConfigSuffix(shimBinary string) string {
+	name := filepath.Base(shimBinary)
+
+	if !strings.HasPrefix(name, shimBinaryPrefix) || !strings.HasSuffix(name, shimBinarySuffix) {
+		return ""
+	}
+
+	name = strings.TrimSuffix(strings.TrimPrefix(name, shimBinaryPrefix), shimBinarySuffix)
+	if !strings.HasPrefix(name, "-") {
+		return ""
+	}
+
+	return strings.TrimPrefix(name, "-")
+}
+
+// GetShimConfigFilePaths returns the configuration files of the runtime
+// class served by the shim binary, named after it, e.g.
+// configuration-qemu.toml for containerd-shim-kata-qemu-v2, in priority
+// order, or nil for the default shim.
+func GetShimConfigFilePaths(shimBinary string) []string {
+	suffix := shimConfigSuffix(shimBinary)
+	if suffix == "" {
+		return nil
+	}
+
+	file := fmt.Sprintf("configuration-%s.toml", suffix)
+
+	var paths []string
+	for _, path := range GetDefaultConfigFilePaths() {
+		paths = append(paths, filepath.Join(filepath.Dir(path), file))
+	}
+
+	return paths
+}
+
+// GetShimConfigFile returns the resolved path of the first configuration file
+// of the runtime class served by the shim binary found, or "" if none is
+// found, the default configuration files being used then.
+func GetShimConfigFile(shimBinary string) string {
+	for _, file := range GetShimConfigFilePaths(shimBinary) {
+		if resolved, err := ResolvePath(file); err == nil {
+			return resolved
+		}
+	}
+
+	return ""
+}
+
 // getDefaultConfigFile looks in multiple default locations for a
 // configuration file and returns the resolved path for the first file
 // found, or an error if no config files can be found.
@@ -0,0 +1,146 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ConfigDrift describes how a configuration file differs from the packaged
+// default configuration file.
+type ConfigDrift struct {
+	// DefaultPath is the resolved path of the packaged default
+	// configuration file.
+	DefaultPath string
+
+	// DefaultHash and Hash are the SHA-256 of the packaged default
+	// configuration file and of the configuration file, telling whether
+	// the packaged default file was itself modified, by comparing
+	// DefaultHash with the one of the release.
+	DefaultHash string
+	Hash        string
+
+	// Overridden are the fields set, changed or removed by the
+	// configuration file, e.g. "hypervisor.qemu.default_memory".
+	Overridden []string
+}
+
+// fileHash returns the SHA-256 of the file, as a hex string.
+func fileHash(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// flattenConfig adds to fields the leaves of the decoded TOML tables, keyed
+// by their dotted path.
+func flattenConfig(prefix string, table map[string]interface{}, fields map[string]interface{}) {
+	for key, value := range table {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if sub, ok := value.(map[string]interface{}); ok {
+			flattenConfig(key, sub, fields)
+			continue
+		}
+
+		fields[key] = value
+	}
+}
+
+// decodeConfigFields returns the hash and the fields of the configuration
+// file, merged with the files it includes.
+func decodeConfigFields(path string) (string, map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	table, err := loadConfigTable(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fields := make(map[string]interface{})
+	flattenConfig("", table, fields)
+
+	return fileHash(data), fields, nil
+}
+
+// GetConfigDrift compares the configuration file with the packaged default
+// configuration file. The hash of the configuration file is returned even
+// when the packaged default configuration file cannot be read.
+func GetConfigDrift(configPath string) (ConfigDrift, error) {
+	var drift ConfigDrift
+
+	resolved, err := ResolvePath(configPath)
+	if err != nil {
+		return drift, err
+	}
+
+	hash, fields, err := decodeConfigFields(resolved)
+	if err != nil {
+		return drift, err
+	}
+	drift.Hash = hash
+
+	drift.DefaultPath, err = ResolvePath(defaultRuntimeConfiguration)
+	if err != nil {
+		return drift, fmt.Errorf("Cannot find packaged default config file (%v)", err)
+	}
+
+	defaultHash, defaultFields, err := decodeConfigFields(drift.DefaultPath)
+	if err != nil {
+		return drift, err
+	}
+	drift.DefaultHash = defaultHash
+
+	if resolved == drift.DefaultPath {
+		return drift, nil
+	}
+
+	for key, value := range fields {
+		if defaultValue, ok := defaultFields[key]; !ok || !reflect.DeepEqual(value, defaultValue) {
+			drift.Overridden = append(drift.Overridden, key)
+		}
+	}
+
+	for key := range defaultFields {
+		if _, ok := fields[key]; !ok {
+			drift.Overridden = append(drift.Overridden, key)
+		}
+	}
+
+	sort.Strings(drift.Overridden)
+
+	return drift, nil
+}
+
+// logConfigDrift warns about the fields of the configuration file which
+// differ from the packaged default configuration file.
+func logConfigDrift(configPath string) {
+	drift, err := GetConfigDrift(configPath)
+	if err != nil {
+		kataUtilsLogger.WithError(err).Debug("cannot compare the configuration with the packaged default")
+		return
+	}
+
+	if len(drift.Overridden) == 0 {
+		return
+	}
+
+	kataUtilsLogger.WithFields(logrus.Fields{
+		"file":       configPath,
+		"default":    drift.DefaultPath,
+		"overridden": strings.Join(drift.Overridden, ","),
+	}).Warn("configuration differs from the packaged default")
+}
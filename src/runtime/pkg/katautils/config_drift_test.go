@@ -0,0 +1,86 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfigDrift(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "config-drift")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	savedConf := defaultRuntimeConfiguration
+	defer func() {
+		defaultRuntimeConfiguration = savedConf
+	}()
+
+	defaultConfig := `
+[hypervisor.qemu]
+path = "/usr/bin/qemu"
+default_memory = 2048
+kernel_params = ""
+
+[agent.kata]
+debug = false
+
+[runtime]
+internetworking_model = "tcfilter"
+`
+	config := `
+# a comment
+[hypervisor.qemu]
+path = "/usr/bin/qemu"
+default_memory = 4096
+enable_iommu = true
+
+[agent.kata]
+debug = false
+
+[runtime]
+internetworking_model = "tcfilter"
+`
+
+	defaultRuntimeConfiguration = filepath.Join(dir, "default.toml")
+	configFile := filepath.Join(dir, "configuration.toml")
+
+	// No packaged default config
+	assert.NoError(ioutil.WriteFile(configFile, []byte(config), testFileMode))
+	drift, err := GetConfigDrift(configFile)
+	assert.Error(err)
+	assert.Equal(fmt.Sprintf("%x", sha256.Sum256([]byte(config))), drift.Hash)
+
+	assert.NoError(ioutil.WriteFile(defaultRuntimeConfiguration, []byte(defaultConfig), testFileMode))
+	drift, err = GetConfigDrift(configFile)
+	assert.NoError(err)
+	assert.Equal(defaultRuntimeConfiguration, drift.DefaultPath)
+	assert.Equal(fmt.Sprintf("%x", sha256.Sum256([]byte(defaultConfig))), drift.DefaultHash)
+	assert.Equal([]string{
+		"hypervisor.qemu.default_memory",
+		"hypervisor.qemu.enable_iommu",
+		"hypervisor.qemu.kernel_params",
+	}, drift.Overridden)
+
+	// The packaged default config itself
+	drift, err = GetConfigDrift(defaultRuntimeConfiguration)
+	assert.NoError(err)
+	assert.Equal(drift.DefaultHash, drift.Hash)
+	assert.Empty(drift.Overridden)
+
+	assert.NoError(ioutil.WriteFile(configFile, []byte("[hypervisor"), testFileMode))
+	_, err = GetConfigDrift(configFile)
+	assert.Error(err)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	"github.com/sirupsen/logrus"
+)
+
+// includeKey is the top level key of the configuration file listing the
+// files merged over it, e.g. include = ["/etc/kata-containers/conf.d/*.toml"].
+const includeKey = "include"
+
+// decodeConfigTable decodes the configuration file in a generic table.
+func decodeConfigTable(path string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	table := make(map[string]interface{})
+	if _, err := toml.Decode(string(data), &table); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return table, nil
+}
+
+// configIncludes returns the files included by the configuration file, in
+// their merge order: the patterns in their order, the files matching a
+// pattern in lexical order. The relative patterns are relative to the
+// directory of the configuration file.
+func configIncludes(configPath string, table map[string]interface{}) ([]string, error) {
+	value, ok := table[includeKey]
+	if !ok {
+		return nil, nil
+	}
+
+	patterns, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: %q must be an array of paths", configPath, includeKey)
+	}
+
+	var includes []string
+	seen := make(map[string]struct{})
+
+	for _, p := range patterns {
+		pattern, ok := p.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: %q must be an array of paths", configPath, includeKey)
+		}
+
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(configPath), pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid include pattern %q: %v", configPath, pattern, err)
+		}
+		sort.Strings(matches)
+
+		for _, m := range matches {
+			if _, found := seen[m]; found {
+				continue
+			}
+			seen[m] = struct{}{}
+			includes = append(includes, m)
+		}
+	}
+
+	return includes, nil
+}
+
+// mergeConfigTable merges the overrides table into the table, the tables
+// are merged key by key, other values are replaced.
+func mergeConfigTable(table, overrides map[string]interface{}) {
+	for key, value := range overrides {
+		sub, ok := value.(map[string]interface{})
+		if !ok {
+			table[key] = value
+			continue
+		}
+
+		current, ok := table[key].(map[string]interface{})
+		if !ok {
+			current = make(map[string]interface{})
+			table[key] = current
+		}
+		mergeConfigTable(current, sub)
+	}
+}
+
+// loadConfigTable decodes the configuration file and merges the files it
+// includes over it. The included files cannot include other files.
+func loadConfigTable(configPath string) (map[string]interface{}, error) {
+	table, err := decodeConfigTable(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	includes, err := configIncludes(configPath, table)
+	if err != nil {
+		return nil, err
+	}
+	delete(table, includeKey)
+
+	for _, include := range includes {
+		overrides, err := decodeConfigTable(include)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := overrides[includeKey]; ok {
+			return nil, fmt.Errorf("%s: included configuration files cannot include other files", include)
+		}
+
+		mergeConfigTable(table, overrides)
+
+		kataUtilsLogger.WithFields(logrus.Fields{
+			"file":    include,
+			"include": configPath,
+		}).Debug("merged configuration file")
+	}
+
+	return table, nil
+}
+
+// decodeConfigFile decodes the configuration file and the files it includes
+// in the TOML configuration.
+func decodeConfigFile(configPath string, tomlConf *tomlConfig) error {
+	table, err := loadConfigTable(configPath)
+	if err != nil {
+		return err
+	}
+
+	// The configuration is decoded from the merged table, not file by
+	// file, which would replace the hypervisor and agent sections
+	// partially set by the included files.
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(table); err != nil {
+		return err
+	}
+
+	_, err = toml.Decode(buf.String(), tomlConf)
+	return err
+}
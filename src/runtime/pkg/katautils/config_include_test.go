@@ -0,0 +1,92 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeConfigIncludes(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "config-include")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	confDir := filepath.Join(dir, "conf.d")
+	assert.NoError(os.MkdirAll(confDir, testDirMode))
+
+	configFile := filepath.Join(dir, "configuration.toml")
+	config := `
+include = ["conf.d/*.toml", "` + filepath.Join(dir, "last.toml") + `", "missing/*.toml"]
+
+[hypervisor.qemu]
+path = "/usr/bin/qemu"
+kernel = "/usr/share/kata-containers/vmlinuz"
+default_memory = 2048
+
+[agent.kata]
+enable_debug = false
+
+[runtime]
+enable_debug = false
+`
+	files := map[string]string{
+		configFile: config,
+		// merged in lexical order
+		filepath.Join(confDir, "20-memory.toml"): `
+[hypervisor.qemu]
+default_memory = 4096
+`,
+		filepath.Join(confDir, "10-memory.toml"): `
+[hypervisor.qemu]
+default_memory = 1024
+default_vcpus = 2
+`,
+		filepath.Join(confDir, "ignored.conf"): `
+[hypervisor.qemu]
+default_memory = 512
+`,
+		filepath.Join(dir, "last.toml"): `
+[agent.kata]
+enable_debug = true
+`,
+	}
+	for path, content := range files {
+		assert.NoError(ioutil.WriteFile(path, []byte(content), testFileMode))
+	}
+
+	tomlConf, resolved, err := decodeConfig(configFile)
+	assert.NoError(err)
+	assert.Equal(configFile, resolved)
+
+	qemu := tomlConf.Hypervisor["qemu"]
+	assert.Equal("/usr/bin/qemu", qemu.Path)
+	assert.Equal("/usr/share/kata-containers/vmlinuz", qemu.Kernel)
+	assert.Equal(uint32(4096), qemu.MemorySize)
+	assert.Equal(int32(2), qemu.NumVCPUs)
+	assert.True(tomlConf.Agent["kata"].Debug)
+	assert.False(tomlConf.Runtime.Debug)
+
+	// The included files cannot include other files
+	assert.NoError(ioutil.WriteFile(filepath.Join(confDir, "30-include.toml"), []byte(`include = ["/etc/foo.toml"]`), testFileMode))
+	_, _, err = decodeConfig(configFile)
+	assert.Error(err)
+	assert.NoError(os.Remove(filepath.Join(confDir, "30-include.toml")))
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(confDir, "30-invalid.toml"), []byte("[hypervisor"), testFileMode))
+	_, _, err = decodeConfig(configFile)
+	assert.Error(err)
+
+	assert.NoError(ioutil.WriteFile(configFile, []byte(`include = "conf.d/*.toml"`), testFileMode))
+	_, _, err = decodeConfig(configFile)
+	assert.Error(err)
+}
@@ -18,9 +18,13 @@ import (
 	"strings"
 	"syscall"
 	"testing"
+	"time"
 
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	vcconfig "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/stretchr/testify/assert"
@@ -535,6 +539,7 @@ func TestMinimalRuntimeConfig(t *testing.T) {
 	[agent.kata]
 	debug_console_enabled=true
 	kernel_modules=["a", "b", "c"]
+	valid_kernel_modules=["nbd", "sctp"]
 	[netmon]
 	path = "` + netmonPath + `"
 `
@@ -595,6 +600,7 @@ func TestMinimalRuntimeConfig(t *testing.T) {
 		LongLiveConn:       true,
 		EnableDebugConsole: true,
 		KernelModules:      []string{"a", "b", "c"},
+		KernelModulesList:  []string{"nbd", "sctp"},
 	}
 
 	expectedNetmonConfig := vc.NetmonConfig{
@@ -859,6 +865,187 @@ func TestNewQemuHypervisorConfigImageAndInitrd(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestNewQemuHypervisorConfigGPUAndSound(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir(testDir, "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	imagePath := filepath.Join(tmpdir, "image")
+	hypervisorPath := path.Join(tmpdir, "hypervisor")
+	kernelPath := path.Join(tmpdir, "kernel")
+	gpuDaemonPath := path.Join(tmpdir, "vhost-user-gpu")
+
+	for _, file := range []string{imagePath, hypervisorPath, kernelPath} {
+		err = createEmptyFile(file)
+		assert.NoError(err)
+	}
+
+	orgVHostVSockDevicePath := utils.VHostVSockDevicePath
+	defer func() {
+		utils.VHostVSockDevicePath = orgVHostVSockDevicePath
+	}()
+	utils.VHostVSockDevicePath = "/dev/null"
+
+	hypervisor := hypervisor{
+		Path:                  hypervisorPath,
+		Kernel:                kernelPath,
+		Image:                 imagePath,
+		VhostUserGPUDaemon:    gpuDaemonPath,
+		VhostUserGPUExtraArgs: []string{"--virgl"},
+		VirtioSound:           "wav",
+	}
+
+	// The vhost-user-gpu backend does not exist
+	_, err = newQemuHypervisorConfig(hypervisor)
+	assert.Error(err)
+
+	// The wav audio backend is not supported
+	assert.NoError(createEmptyFile(gpuDaemonPath))
+	_, err = newQemuHypervisorConfig(hypervisor)
+	assert.Error(err)
+
+	hypervisor.VirtioSound = "none"
+	config, err := newQemuHypervisorConfig(hypervisor)
+	assert.NoError(err)
+	assert.Equal(gpuDaemonPath, config.VhostUserGPUDaemon)
+	assert.Equal([]string{"--virgl"}, config.VhostUserGPUExtraArgs)
+	assert.Equal("none", config.VirtioSound)
+}
+
+func TestNewQemuHypervisorConfigMicrovm(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir(testDir, "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	imagePath := filepath.Join(tmpdir, "image")
+	hypervisorPath := path.Join(tmpdir, "hypervisor")
+	kernelPath := path.Join(tmpdir, "kernel")
+
+	for _, file := range []string{imagePath, hypervisorPath, kernelPath} {
+		err = createEmptyFile(file)
+		assert.NoError(err)
+	}
+
+	newMicrovm := func() hypervisor {
+		return hypervisor{
+			Path:                 hypervisorPath,
+			Kernel:               kernelPath,
+			Image:                imagePath,
+			MachineType:          govmmQemu.MachineTypeMicrovm,
+			HotplugVFIOOnRootBus: true,
+			PCIeRootPort:         2,
+		}
+	}
+
+	orgVHostVSockDevicePath := utils.VHostVSockDevicePath
+	defer func() {
+		utils.VHostVSockDevicePath = orgVHostVSockDevicePath
+	}()
+	utils.VHostVSockDevicePath = "/dev/null"
+
+	config, err := newQemuHypervisorConfig(newMicrovm())
+	assert.NoError(err)
+	assert.Equal(govmmQemu.MachineTypeMicrovm, config.HypervisorMachineType)
+	assert.True(config.DisableImageNvdimm)
+	assert.False(config.HotplugVFIOOnRootBus)
+	assert.Zero(config.PCIeRootPort)
+
+	h := newMicrovm()
+	h.IOMMU = true
+	_, err = newQemuHypervisorConfig(h)
+	assert.Error(err)
+
+	h = newMicrovm()
+	h.VirtioMem = true
+	_, err = newQemuHypervisorConfig(h)
+	assert.Error(err)
+
+	h = newMicrovm()
+	h.BlockDeviceDriver = vcconfig.Nvdimm
+	_, err = newQemuHypervisorConfig(h)
+	assert.Error(err)
+
+	h = newMicrovm()
+	h.BlockDeviceDriver = vcconfig.VirtioBlock
+	_, err = newQemuHypervisorConfig(h)
+	assert.NoError(err)
+
+	// vCPUs cannot be hot plugged
+	h = newMicrovm()
+	h.NumVCPUs = 1
+	config, err = newQemuHypervisorConfig(h)
+	assert.NoError(err)
+	assert.Equal(uint32(1), config.DefaultMaxVCPUs)
+
+	h.DefaultMaxVCPUs = 2
+	_, err = newQemuHypervisorConfig(h)
+	assert.Error(err)
+}
+
+func TestNewQemuHypervisorConfigSecureExecution(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir(testDir, "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	imagePath := filepath.Join(tmpdir, "image")
+	hypervisorPath := path.Join(tmpdir, "hypervisor")
+	kernelPath := path.Join(tmpdir, "kernel")
+
+	for _, file := range []string{imagePath, hypervisorPath, kernelPath} {
+		err = createEmptyFile(file)
+		assert.NoError(err)
+	}
+
+	newSecureExecution := func() hypervisor {
+		return hypervisor{
+			Path:              hypervisorPath,
+			Kernel:            kernelPath,
+			Image:             imagePath,
+			MachineType:       vc.QemuCCWVirtio,
+			ConfidentialGuest: true,
+		}
+	}
+
+	orgVHostVSockDevicePath := utils.VHostVSockDevicePath
+	defer func() {
+		utils.VHostVSockDevicePath = orgVHostVSockDevicePath
+	}()
+	utils.VHostVSockDevicePath = "/dev/null"
+
+	config, err := newQemuHypervisorConfig(newSecureExecution())
+	assert.NoError(err)
+	assert.True(config.IOMMUPlatform)
+	assert.Equal(config.NumVCPUs, config.DefaultMaxVCPUs)
+
+	h := newSecureExecution()
+	h.NumVCPUs = 1
+	h.DefaultMaxVCPUs = 2
+	_, err = newQemuHypervisorConfig(h)
+	assert.Error(err)
+
+	h = newSecureExecution()
+	h.VirtioMem = true
+	_, err = newQemuHypervisorConfig(h)
+	assert.Error(err)
+
+	h = newSecureExecution()
+	h.HugePages = true
+	_, err = newQemuHypervisorConfig(h)
+	assert.Error(err)
+
+	h = newSecureExecution()
+	h.ConfidentialGuest = false
+	config, err = newQemuHypervisorConfig(h)
+	assert.NoError(err)
+	assert.False(config.IOMMUPlatform)
+}
+
 func TestNewClhHypervisorConfig(t *testing.T) {
 
 	assert := assert.New(t)
@@ -1154,6 +1341,27 @@ func TestAgentDefaults(t *testing.T) {
 
 	assert.Equal(a.traceMode(), a.TraceMode)
 	assert.Equal(a.traceType(), a.TraceType)
+
+	assert.Equal(a.timeSyncInterval(), a.TimeSyncInterval)
+
+	a.TimeSyncInterval = 60
+	assert.Equal(a.timeSyncInterval(), a.TimeSyncInterval)
+}
+
+func TestAgentKernelModulesPTPKVM(t *testing.T) {
+	assert := assert.New(t)
+
+	a := agent{
+		KernelModules: []string{"e1000e InterruptThrottleRate=3000"},
+	}
+	assert.Equal([]string{"e1000e InterruptThrottleRate=3000"}, a.kernelModules())
+
+	a.PTPKVM = true
+	assert.Equal([]string{"e1000e InterruptThrottleRate=3000", "ptp_kvm"}, a.kernelModules())
+
+	// ptp_kvm is not loaded twice
+	a.KernelModules = []string{"ptp_kvm", "e1000e"}
+	assert.Equal([]string{"ptp_kvm", "e1000e"}, a.kernelModules())
 }
 
 func TestGetDefaultConfigFilePaths(t *testing.T) {
@@ -1222,6 +1430,54 @@ func TestGetDefaultConfigFile(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestGetShimConfigFile(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir(testDir, "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	confDir := filepath.Join(tmpdir, "conf")
+	sysConfDir := filepath.Join(tmpdir, "sysconf")
+	for _, dir := range []string{confDir, sysConfDir} {
+		assert.NoError(os.MkdirAll(dir, testDirMode))
+	}
+
+	savedConf := defaultRuntimeConfiguration
+	savedSysConf := defaultSysConfRuntimeConfiguration
+
+	defaultRuntimeConfiguration = filepath.Join(confDir, "configuration.toml")
+	defaultSysConfRuntimeConfiguration = filepath.Join(sysConfDir, "configuration.toml")
+
+	defer func() {
+		defaultRuntimeConfiguration = savedConf
+		defaultSysConfRuntimeConfiguration = savedSysConf
+	}()
+
+	for _, name := range []string{"containerd-shim-kata-v2", "kata-runtime", "containerd-shim-kata-qemu", "containerd-shim-runc-v2"} {
+		assert.Nil(GetShimConfigFilePaths(name), name)
+		assert.Empty(GetShimConfigFile(name), name)
+	}
+
+	shim := "/usr/local/bin/containerd-shim-kata-qemu-snp-v2"
+	assert.Equal([]string{
+		filepath.Join(sysConfDir, "configuration-qemu-snp.toml"),
+		filepath.Join(confDir, "configuration-qemu-snp.toml"),
+	}, GetShimConfigFilePaths(shim))
+
+	// None found
+	assert.Empty(GetShimConfigFile(shim))
+
+	conf := filepath.Join(confDir, "configuration-qemu-snp.toml")
+	assert.NoError(ioutil.WriteFile(conf, nil, testFileMode))
+	assert.Equal(conf, GetShimConfigFile(shim))
+
+	// The one below sysconf has priority
+	sysConf := filepath.Join(sysConfDir, "configuration-qemu-snp.toml")
+	assert.NoError(ioutil.WriteFile(sysConf, nil, testFileMode))
+	assert.Equal(sysConf, GetShimConfigFile(shim))
+}
+
 func TestDefaultBridges(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1422,6 +1678,80 @@ func TestUpdateRuntimeConfigurationFactoryConfig(t *testing.T) {
 	assert.Equal(expectedFactoryConfig, config.FactoryConfig)
 }
 
+func TestUpdateRuntimeConfigurationImageConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+	tomlConf := tomlConfig{Image: image{GuestStoreSize: 2048, GuestStoreDiscard: true}}
+
+	err := updateRuntimeConfig("", tomlConf, &config)
+	assert.Error(err, "the guest image store is only used with service_offload")
+
+	tomlConf.Image.ServiceOffload = true
+	err = updateRuntimeConfig("", tomlConf, &config)
+	assert.NoError(err)
+	assert.Equal(uint32(2048), config.HypervisorConfig.GuestImageStoreSize)
+	assert.True(config.HypervisorConfig.GuestImageStoreDiscard)
+
+	config = oci.RuntimeConfig{}
+	tomlConf.Image.GuestStoreSize = 0
+	err = updateRuntimeConfig("", tomlConf, &config)
+	assert.Error(err, "guest_store_discard without a guest image store")
+}
+
+func TestUpdateRuntimeConfigurationHooksConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+	tomlConf := tomlConfig{Hooks: hooks{
+		PreVMStart: []string{"/usr/libexec/ids-register"},
+		PreVMStop:  []string{"/usr/libexec/ids-unregister"},
+		Timeout:    5,
+	}}
+
+	err := updateRuntimeConfig("", tomlConf, &config)
+	assert.NoError(err)
+	assert.Equal(vc.SandboxHooks{
+		PreVMStart: []string{"/usr/libexec/ids-register"},
+		PreVMStop:  []string{"/usr/libexec/ids-unregister"},
+		Timeout:    5 * time.Second,
+	}, config.SandboxHooks)
+
+	tomlConf.Hooks.PostAgentReady = []string{"numa-tuner"}
+	err = updateRuntimeConfig("", tomlConf, &config)
+	assert.Error(err, "relative hook path")
+}
+
+func TestUpdateRuntimeConfigurationMockHypervisor(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+	tomlConf := tomlConfig{Hypervisor: map[string]hypervisor{
+		mockHypervisorTableType: {
+			MockFaults: []string{"boot:delay=2s", "hotplug:fail=3"},
+		},
+	}}
+
+	err := updateRuntimeConfig("", tomlConf, &config)
+	assert.NoError(err)
+	assert.Equal(vc.MockHypervisor, config.HypervisorType)
+	assert.Equal(defaultKernelPath, config.HypervisorConfig.KernelPath)
+	assert.Equal(defaultImagePath, config.HypervisorConfig.ImagePath)
+	assert.Equal([]vc.MockFault{
+		{Operation: vc.MockFaultBoot, Delay: 2 * time.Second},
+		{Operation: vc.MockFaultHotplug, FailNth: 3},
+	}, config.HypervisorConfig.MockFaults)
+
+	// The image is not checked
+	assert.NoError(checkConfig(config))
+
+	tomlConf.Hypervisor[mockHypervisorTableType] = hypervisor{
+		MockFaults: []string{"reboot:fail=1"},
+	}
+	err = updateRuntimeConfig("", tomlConf, &config)
+	assert.Error(err, "unknown operation")
+}
+
 func TestUpdateRuntimeConfigurationInvalidKernelParams(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1551,6 +1881,31 @@ func TestCheckHypervisorConfig(t *testing.T) {
 	}
 }
 
+func TestCheckBlockVolumeClasses(t *testing.T) {
+	assert := assert.New(t)
+
+	h := hypervisor{
+		BlockVolumeClasses: map[string]blockVolumeClass{
+			"database": {Cache: "none"},
+			"scratch":  {Cache: "writeback", Discard: true, DetectZeroes: "unmap"},
+		},
+	}
+
+	classes := h.blockVolumeClasses()
+	assert.Equal(vc.BlockVolumeClass{Cache: "none"}, classes["database"])
+	assert.Equal(vc.BlockVolumeClass{Cache: "writeback", Discard: true, DetectZeroes: "unmap"}, classes["scratch"])
+	assert.NoError(checkBlockVolumeClasses(classes))
+	assert.Nil(hypervisor{}.blockVolumeClasses())
+
+	for _, class := range []vc.BlockVolumeClass{
+		{Cache: "writethrough"},
+		{DetectZeroes: "yes"},
+		{DetectZeroes: "unmap"},
+	} {
+		assert.Error(checkBlockVolumeClasses(map[string]vc.BlockVolumeClass{"invalid": class}), "%+v", class)
+	}
+}
+
 func TestCheckNetNsConfig(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1571,6 +1926,104 @@ func TestCheckNetNsConfig(t *testing.T) {
 	assert.Error(err)
 }
 
+func TestCheckThreadsWeightConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	savedUnifiedMode := cgroups.UnifiedMode
+	defer func() {
+		cgroups.UnifiedMode = savedUnifiedMode
+	}()
+	cgroups.UnifiedMode = func() bool { return false }
+
+	config := oci.RuntimeConfig{}
+	assert.NoError(checkThreadsWeightConfig(config))
+
+	config.HypervisorConfig.VCPUThreadsWeight = 400
+	assert.Error(checkThreadsWeightConfig(config))
+
+	config.SandboxCgroupOnly = true
+	assert.NoError(checkThreadsWeightConfig(config))
+
+	config.HypervisorConfig.IOThreadsWeight = 10001
+	assert.Error(checkThreadsWeightConfig(config))
+
+	// cgroup v2
+	config.HypervisorConfig.IOThreadsWeight = 0
+	cgroups.UnifiedMode = func() bool { return true }
+	assert.NoError(checkThreadsWeightConfig(config))
+}
+
+func TestCheckHypervisorConfigErofsLayers(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.HypervisorConfig{
+		MemorySize:  defaultMemSize,
+		ErofsLayers: true,
+	}
+	assert.NoError(checkHypervisorConfig(config))
+
+	config.DisableBlockDeviceUse = true
+	assert.Error(checkHypervisorConfig(config))
+
+	config.DisableBlockDeviceUse = false
+	config.ColdPlugDevices = true
+	assert.Error(checkHypervisorConfig(config))
+}
+
+func TestCheckHypervisorConfigGuestImageStore(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.HypervisorConfig{
+		MemorySize:          defaultMemSize,
+		GuestImageStoreSize: 1024,
+	}
+	assert.NoError(checkHypervisorConfig(config))
+
+	config.DisableBlockDeviceUse = true
+	assert.Error(checkHypervisorConfig(config))
+}
+
+func TestCheckAgentConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+	assert.NoError(checkAgentConfig(config))
+
+	config.AgentConfig.EnablePortForward = true
+	assert.NoError(checkAgentConfig(config))
+
+	config.HypervisorConfig.ConfidentialGuest = true
+	assert.Error(checkAgentConfig(config))
+
+	config.AgentConfig.EnablePortForward = false
+	assert.NoError(checkAgentConfig(config))
+
+	config.AgentConfig.EnableNetworkCapture = true
+	assert.Error(checkAgentConfig(config))
+
+	config.AgentConfig.EnableNetworkCapture = false
+
+	config.AgentConfig.EnableCheckpoint = true
+	assert.Error(checkAgentConfig(config))
+
+	config.AgentConfig.EnableCheckpoint = false
+
+	config.AgentConfig.PolicyAudit = true
+	assert.Error(checkAgentConfig(config))
+
+	config.AgentConfig.PolicyFile = "kata-policy.json"
+	assert.Error(checkAgentConfig(config))
+
+	config.AgentConfig.PolicyFile = "/etc/kata-policy.json"
+	assert.NoError(checkAgentConfig(config))
+
+	config.AgentConfig.GuestSysctls = []string{"vm.max_map_count=262144"}
+	assert.NoError(checkAgentConfig(config))
+
+	config.AgentConfig.GuestSysctls = []string{"vm.max_map_count"}
+	assert.Error(checkAgentConfig(config))
+}
+
 func TestCheckFactoryConfig(t *testing.T) {
 	assert := assert.New(t)
 
@@ -1660,3 +2113,14 @@ func TestValidateBindMounts(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateHistogramBuckets(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validateHistogramBuckets("buckets", nil))
+	assert.NoError(validateHistogramBuckets("buckets", []float64{0.5, 1, 10}))
+	assert.Error(validateHistogramBuckets("buckets", []float64{0, 1}))
+	assert.Error(validateHistogramBuckets("buckets", []float64{-1}))
+	assert.Error(validateHistogramBuckets("buckets", []float64{1, 10, 10}))
+	assert.Error(validateHistogramBuckets("buckets", []float64{10, 1}))
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -96,13 +97,22 @@ func HandleFactory(ctx context.Context, vci vc.VC, runtimeConfig *oci.RuntimeCon
 // For the given pod ephemeral volume is created only once
 // backed by tmpfs inside the VM. For successive containers
 // of the same pod the already existing volume is reused.
+// The host emptyDirs marked as confidential are backed by an
+// encrypted scratch disk instead.
 func SetEphemeralStorageType(ociSpec specs.Spec) specs.Spec {
+	// The annotation is validated by oci.ContainerConfig().
+	confidentialSizes, _ := vc.ConfidentialEmptyDirSizes(ociSpec.Annotations)
+
 	for idx, mnt := range ociSpec.Mounts {
 		if vc.IsEphemeralStorage(mnt.Source) {
 			ociSpec.Mounts[idx].Type = vc.KataEphemeralDevType
 		}
 		if vc.Isk8sHostEmptyDir(mnt.Source) {
-			ociSpec.Mounts[idx].Type = vc.KataLocalDevType
+			if _, ok := confidentialSizes[filepath.Base(mnt.Source)]; ok {
+				ociSpec.Mounts[idx].Type = vc.KataEncryptedScratchDevType
+			} else {
+				ociSpec.Mounts[idx].Type = vc.KataLocalDevType
+			}
 		}
 	}
 	return ociSpec
@@ -219,6 +219,49 @@ func TestSetKernelParams(t *testing.T) {
 	}
 }
 
+func TestSetKernelParamsReadOnlyImage(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{
+		HypervisorConfig: vc.HypervisorConfig{
+			ReadOnlyImage: true,
+		},
+	}
+
+	// no image, the initrd is read-only already
+	err := SetKernelParams(&config)
+	assert.NoError(err)
+	for _, p := range vc.ReadOnlyImageKernelParams {
+		assert.NotContains(config.HypervisorConfig.KernelParams, p)
+	}
+
+	config.HypervisorConfig.ImagePath = "/path/to/image"
+	err = SetKernelParams(&config)
+	assert.NoError(err)
+	for _, p := range vc.ReadOnlyImageKernelParams {
+		assert.Contains(config.HypervisorConfig.KernelParams, p)
+	}
+}
+
+func TestSetKernelParamsRNGTrustCPU(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+
+	err := SetKernelParams(&config)
+	assert.NoError(err)
+	for _, p := range vc.RNGTrustCPUKernelParams {
+		assert.NotContains(config.HypervisorConfig.KernelParams, p)
+	}
+
+	config.HypervisorConfig.RNGTrustCPU = true
+	err = SetKernelParams(&config)
+	assert.NoError(err)
+	for _, p := range vc.RNGTrustCPUKernelParams {
+		assert.Contains(config.HypervisorConfig.KernelParams, p)
+	}
+}
+
 func TestSetKernelParamsUserOptionTakesPriority(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,534 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// gcProcessExitTimeout is how long the garbage collector waits for
+	// killed orphan processes to exit.
+	gcProcessExitTimeout = 5 * time.Second
+
+	// gcAliveProbeAttempts is how many times a shim is probed before its
+	// sandbox is considered orphan, the delay between two attempts being
+	// doubled each time.
+	gcAliveProbeAttempts = 4
+	gcAliveProbeBackoff  = 500 * time.Millisecond
+
+	// shimBinaryPrefix is the prefix of the shim binary names, which are
+	// started by containerd with "-id <sandbox ID>".
+	shimBinaryPrefix = "containerd-shim-kata"
+
+	// shimBinarySuffix is the suffix of the shim binary names, the
+	// runtime class being in between, e.g. containerd-shim-kata-qemu-v2.
+	shimBinarySuffix = "-v2"
+)
+
+// OrphanSandbox describes the host resources left behind by a sandbox
+// which is not managed by a running shim anymore.
+type OrphanSandbox struct {
+	// ID of the sandbox
+	ID string
+
+	// Pids of the processes (hypervisor, virtiofsd, netmon...) still
+	// running for the sandbox.
+	Pids []int
+
+	// Mounts still present under the sandbox shared directory.
+	Mounts []string
+
+	// NetNsPath is the network namespace created by the runtime for the
+	// sandbox, if any.
+	NetNsPath string
+
+	// Dirs are the sandbox storage directories.
+	Dirs []string
+}
+
+// SandboxGC finds and removes the resources of orphan sandboxes.
+type SandboxGC struct {
+	// IsAlive reports whether a shim still manages the sandbox.
+	IsAlive func(sandboxID string) bool
+
+	// MinAge is the minimum age of a sandbox storage directory before
+	// it is considered, so that sandboxes being created are left alone.
+	MinAge time.Duration
+
+	// ProbeBackoff is the delay before the first retry of a failed
+	// liveness probe: a busy shim may not answer immediately.
+	ProbeBackoff time.Duration
+
+	// Binaries are the paths of the hypervisor, virtiofsd and netmon
+	// binaries run for the sandboxes, see SandboxBinaries. Only their
+	// processes are killed.
+	Binaries []string
+
+	runStoragePath   string
+	runVMStoragePath string
+	sharedPath       string
+	procPath         string
+	mountInfoPath    string
+	driver           persistapi.PersistDriver
+}
+
+// NewSandboxGC returns a garbage collector working on the default runtime
+// storage paths, for the sandboxes running binaries.
+func NewSandboxGC(isAlive func(sandboxID string) bool, minAge time.Duration, binaries []string) (*SandboxGC, error) {
+	if isAlive == nil {
+		return nil, fmt.Errorf("sandbox liveness check required")
+	}
+
+	driver, err := persist.GetDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SandboxGC{
+		IsAlive:          isAlive,
+		MinAge:           minAge,
+		ProbeBackoff:     gcAliveProbeBackoff,
+		Binaries:         binaries,
+		runStoragePath:   driver.RunStoragePath(),
+		runVMStoragePath: driver.RunVMStoragePath(),
+		sharedPath:       vc.KataHostSharedDir(),
+		procPath:         "/proc",
+		mountInfoPath:    procMountInfoFile,
+		driver:           driver,
+	}, nil
+}
+
+// SandboxBinaries returns the paths of the hypervisor, virtiofsd and netmon
+// binaries of the runtime configuration.
+func SandboxBinaries(config oci.RuntimeConfig) []string {
+	var binaries []string
+
+	for _, path := range append([]string{
+		config.HypervisorConfig.HypervisorPath,
+		config.HypervisorConfig.VirtioFSDaemon,
+		config.NetmonConfig.Path,
+	}, config.HypervisorConfig.VirtioFSDaemonList...) {
+		if path != "" {
+			binaries = append(binaries, filepath.Clean(path))
+		}
+	}
+
+	return binaries
+}
+
+func (gc *SandboxGC) logger() *logrus.Entry {
+	return kataUtilsLogger.WithField("subsystem", "gc")
+}
+
+// candidates returns the IDs of all sandboxes with runtime storage old
+// enough to be collected. Only the persist and shared directories are
+// looked at: the VM storage directory is also used by factory VMs, which
+// don't belong to any sandbox.
+func (gc *SandboxGC) candidates() ([]string, error) {
+	ids := make(map[string]struct{})
+
+	for _, dir := range []string{gc.runStoragePath, gc.sharedPath} {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, e := range entries {
+			if !e.IsDir() || time.Since(e.ModTime()) < gc.MinAge {
+				continue
+			}
+			if VerifyContainerID(e.Name()) != nil {
+				continue
+			}
+			ids[e.Name()] = struct{}{}
+		}
+	}
+
+	var result []string
+	for id := range ids {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// FindOrphans returns all sandboxes which have resources on the host but
+// are not alive anymore. A sandbox is orphan only when its shim fails all
+// the liveness probes and its process is gone.
+func (gc *SandboxGC) FindOrphans() ([]OrphanSandbox, error) {
+	ids, err := gc.candidates()
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []OrphanSandbox
+	for _, id := range ids {
+		if gc.probeAlive(id) {
+			continue
+		}
+
+		shimPids, err := gc.shimPids(id)
+		if err != nil {
+			return nil, err
+		}
+		if len(shimPids) > 0 {
+			gc.logger().WithFields(logrus.Fields{
+				"sandbox": id,
+				"pids":    shimPids,
+			}).Warn("shim not answering but still running, leaving sandbox alone")
+			continue
+		}
+
+		o, err := gc.inspect(id)
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, o)
+	}
+
+	return orphans, nil
+}
+
+// probeAlive checks whether a shim manages sandbox id, retrying with an
+// exponential backoff.
+func (gc *SandboxGC) probeAlive(id string) bool {
+	backoff := gc.ProbeBackoff
+
+	for i := 0; i < gcAliveProbeAttempts; i++ {
+		if i > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if gc.IsAlive(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (gc *SandboxGC) inspect(id string) (OrphanSandbox, error) {
+	o := OrphanSandbox{ID: id}
+
+	for _, dir := range []string{
+		filepath.Join(gc.runStoragePath, id),
+		filepath.Join(gc.runVMStoragePath, id),
+		filepath.Join(gc.sharedPath, id),
+	} {
+		if FileExists(dir) {
+			o.Dirs = append(o.Dirs, dir)
+		}
+	}
+
+	var netmonPid int
+	if gc.driver != nil {
+		if ss, _, err := gc.driver.FromDisk(id); err == nil {
+			if ss.Network.NetNsCreated {
+				o.NetNsPath = ss.Network.NetNsPath
+			}
+			netmonPid = ss.Network.NetmonPID
+		} else if !os.IsNotExist(err) {
+			gc.logger().WithError(err).WithField("sandbox", id).Warn("failed to read sandbox persist data")
+		}
+	}
+
+	pids, err := gc.sandboxPids(id)
+	if err != nil {
+		return o, err
+	}
+	if netmonPid > 0 && gc.isNetmon(netmonPid, id) {
+		pids = append(pids, netmonPid)
+	}
+	o.Pids = uniquePids(pids)
+
+	mounts, err := gc.sandboxMounts(id)
+	if err != nil {
+		return o, err
+	}
+	o.Mounts = mounts
+
+	return o, nil
+}
+
+// shimPids returns the shim processes started for sandbox id.
+func (gc *SandboxGC) shimPids(id string) ([]int, error) {
+	entries, err := ioutil.ReadDir(gc.procPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+
+		cmdline, err := ioutil.ReadFile(filepath.Join(gc.procPath, e.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+
+		args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+		if !strings.HasPrefix(filepath.Base(args[0]), shimBinaryPrefix) {
+			continue
+		}
+
+		for i, a := range args[1:] {
+			if (a == "-id" && i+2 < len(args) && args[i+2] == id) || a == "-id="+id {
+				pids = append(pids, pid)
+				break
+			}
+		}
+	}
+
+	return pids, nil
+}
+
+// sandboxPids returns the processes of the sandbox binaries referencing
+// the storage of sandbox id on their command line: the hypervisor and
+// virtiofsd use sockets or directories from there.
+func (gc *SandboxGC) sandboxPids(id string) ([]int, error) {
+	refs := []string{
+		filepath.Join(gc.runVMStoragePath, id) + "/",
+		filepath.Join(gc.sharedPath, id) + "/",
+	}
+	qemuName := fmt.Sprintf("sandbox-%s", id)
+
+	entries, err := ioutil.ReadDir(gc.procPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pids []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == os.Getpid() {
+			continue
+		}
+
+		args, err := gc.sandboxBinaryArgs(pid)
+		if err != nil || args == nil {
+			// process exited in the meantime, or not a sandbox binary
+			continue
+		}
+
+	args:
+		for _, a := range args[1:] {
+			if a == qemuName {
+				pids = append(pids, pid)
+				break
+			}
+			for _, ref := range refs {
+				if strings.Contains(a, ref) || a == strings.TrimSuffix(ref, "/") {
+					pids = append(pids, pid)
+					break args
+				}
+			}
+		}
+	}
+
+	return pids, nil
+}
+
+// sandboxBinaryArgs returns the command line of process pid, or nil if it
+// does not run one of the sandbox binaries.
+func (gc *SandboxGC) sandboxBinaryArgs(pid int) ([]string, error) {
+	cmdline, err := ioutil.ReadFile(filepath.Join(gc.procPath, strconv.Itoa(pid), "cmdline"))
+	if err != nil {
+		return nil, err
+	}
+
+	args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+	for _, binary := range gc.Binaries {
+		if filepath.Clean(args[0]) == binary {
+			return args, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// isNetmon checks that the netmon pid persisted for sandbox id is still
+// the netmon of the sandbox, and not another process it was reused for.
+func (gc *SandboxGC) isNetmon(pid int, id string) bool {
+	args, err := gc.sandboxBinaryArgs(pid)
+	if err != nil || args == nil {
+		return false
+	}
+
+	for i, a := range args[1:] {
+		if a == "-s" && i+2 < len(args) && args[i+2] == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sandboxMounts returns the mount points under the shared directory of
+// sandbox id, deepest first.
+func (gc *SandboxGC) sandboxMounts(id string) ([]string, error) {
+	f, err := os.Open(gc.mountInfoPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dir := filepath.Join(gc.sharedPath, id)
+
+	var mounts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		mountPoint := fields[4]
+		if mountPoint == dir || strings.HasPrefix(mountPoint, dir+"/") {
+			mounts = append(mounts, mountPoint)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(mounts, func(i, j int) bool {
+		return len(mounts[i]) > len(mounts[j])
+	})
+
+	return mounts, nil
+}
+
+func (gc *SandboxGC) processExists(pid int) bool {
+	_, err := os.Stat(filepath.Join(gc.procPath, strconv.Itoa(pid)))
+	return err == nil
+}
+
+// Cleanup kills the processes of an orphan sandbox and removes its mounts,
+// network namespace and storage directories. Storage directories are kept
+// if any mount could not be removed, so that no host data is deleted
+// through a leftover bind mount.
+func (gc *SandboxGC) Cleanup(o OrphanSandbox) error {
+	logger := gc.logger().WithField("sandbox", o.ID)
+
+	for _, pid := range o.Pids {
+		logger.WithField("pid", pid).Info("killing orphan process")
+		if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			return fmt.Errorf("failed to kill process %d of sandbox %s: %v", pid, o.ID, err)
+		}
+	}
+
+	if err := gc.waitProcesses(o.Pids, gcProcessExitTimeout); err != nil {
+		return fmt.Errorf("sandbox %s: %v", o.ID, err)
+	}
+
+	var umountErr error
+	for _, m := range o.Mounts {
+		logger.WithField("mount", m).Info("removing orphan mount")
+		if err := unix.Unmount(m, unix.MNT_DETACH); err != nil && err != unix.EINVAL && err != unix.ENOENT {
+			logger.WithError(err).WithField("mount", m).Error("failed to remove orphan mount")
+			umountErr = err
+		}
+	}
+	if umountErr != nil {
+		return fmt.Errorf("failed to remove mounts of sandbox %s: %v", o.ID, umountErr)
+	}
+
+	if o.NetNsPath != "" && FileExists(o.NetNsPath) {
+		logger.WithField("netns", o.NetNsPath).Info("removing orphan network namespace")
+		if err := cleanupNetNS(o.NetNsPath); err != nil {
+			return err
+		}
+	}
+
+	for _, dir := range o.Dirs {
+		logger.WithField("dir", dir).Info("removing orphan directory")
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (gc *SandboxGC) waitProcesses(pids []int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for _, pid := range pids {
+		for gc.processExists(pid) {
+			if time.Now().After(deadline) {
+				return fmt.Errorf("process %d still running after %v", pid, timeout)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	return nil
+}
+
+// Run finds all orphan sandboxes and, only if remove is set, cleans them
+// up. It returns the orphans found, and the first cleanup error.
+func (gc *SandboxGC) Run(remove bool) ([]OrphanSandbox, error) {
+	orphans, err := gc.FindOrphans()
+	if err != nil {
+		return nil, err
+	}
+
+	if !remove {
+		return orphans, nil
+	}
+
+	var firstErr error
+	for _, o := range orphans {
+		if err := gc.Cleanup(o); err != nil {
+			gc.logger().WithError(err).WithField("sandbox", o.ID).Error("failed to clean up orphan sandbox")
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return orphans, firstErr
+}
+
+func uniquePids(pids []int) []int {
+	seen := make(map[int]struct{})
+	var result []int
+
+	for _, pid := range pids {
+		if _, ok := seen[pid]; ok {
+			continue
+		}
+		seen[pid] = struct{}{}
+		result = append(result, pid)
+	}
+
+	sort.Ints(result)
+	return result
+}
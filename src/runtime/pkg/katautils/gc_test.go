@@ -0,0 +1,224 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package katautils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/oci"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSandboxGC(t *testing.T, alive map[string]bool) *SandboxGC {
+	root, err := ioutil.TempDir("", "kata-gc")
+	assert.NoError(t, err)
+
+	gc := &SandboxGC{
+		IsAlive: func(id string) bool {
+			return alive[id]
+		},
+		Binaries:         []string{"/usr/bin/qemu", "/usr/libexec/virtiofsd", "/usr/libexec/kata-netmon"},
+		runStoragePath:   filepath.Join(root, "sbs"),
+		runVMStoragePath: filepath.Join(root, "vm"),
+		sharedPath:       filepath.Join(root, "shared"),
+		procPath:         filepath.Join(root, "proc"),
+		mountInfoPath:    filepath.Join(root, "mountinfo"),
+	}
+
+	for _, dir := range []string{gc.runStoragePath, gc.runVMStoragePath, gc.sharedPath, gc.procPath} {
+		assert.NoError(t, os.MkdirAll(dir, testDirMode))
+	}
+	assert.NoError(t, ioutil.WriteFile(gc.mountInfoPath, nil, testFileMode))
+
+	return gc
+}
+
+func addTestProcess(t *testing.T, gc *SandboxGC, pid string, args ...string) {
+	dir := filepath.Join(gc.procPath, pid)
+	assert.NoError(t, os.MkdirAll(dir, testDirMode))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cmdline"), []byte(strings.Join(args, "\x00")+"\x00"), testFileMode))
+}
+
+func TestSandboxGCFindOrphans(t *testing.T) {
+	assert := assert.New(t)
+
+	gc := newTestSandboxGC(t, map[string]bool{"alive": true})
+	defer os.RemoveAll(filepath.Dir(gc.procPath))
+
+	for _, dir := range []string{
+		filepath.Join(gc.runStoragePath, "alive"),
+		filepath.Join(gc.runStoragePath, "orphan"),
+		filepath.Join(gc.runVMStoragePath, "orphan"),
+		filepath.Join(gc.sharedPath, "orphan"),
+		filepath.Join(gc.sharedPath, "shared-only"),
+		// factory VMs only have a VM storage directory
+		filepath.Join(gc.runVMStoragePath, "factory-vm"),
+	} {
+		assert.NoError(os.MkdirAll(dir, testDirMode))
+	}
+
+	addTestProcess(t, gc, "100", "/usr/bin/qemu", "-name", "sandbox-orphan")
+	addTestProcess(t, gc, "101", "/usr/libexec/virtiofsd", "--socket-path="+filepath.Join(gc.runVMStoragePath, "orphan", "vhost-fs.sock"))
+	addTestProcess(t, gc, "102", "/usr/bin/qemu", "-name", "sandbox-alive")
+	addTestProcess(t, gc, "103", "/usr/libexec/virtiofsd", "--socket-path="+filepath.Join(gc.runVMStoragePath, "orphan-other", "vhost-fs.sock"))
+	// only the processes of the sandbox binaries are collected
+	addTestProcess(t, gc, "104", "/usr/bin/tail", "-f", filepath.Join(gc.runVMStoragePath, "orphan", "console.log"))
+	addTestProcess(t, gc, "105", "qemu", "-name", "sandbox-orphan")
+
+	mountInfo := strings.Join([]string{
+		"1 0 0:1 / " + filepath.Join(gc.sharedPath, "orphan", "shared") + " ro - none none ro",
+		"2 0 0:1 / " + filepath.Join(gc.sharedPath, "orphan", "mounts", "rootfs") + " rw - none none rw",
+		"3 0 0:1 / " + filepath.Join(gc.sharedPath, "orphan-other") + " rw - none none rw",
+	}, "\n")
+	assert.NoError(ioutil.WriteFile(gc.mountInfoPath, []byte(mountInfo), testFileMode))
+
+	orphans, err := gc.FindOrphans()
+	assert.NoError(err)
+	assert.Len(orphans, 2)
+
+	o := orphans[0]
+	assert.Equal("orphan", o.ID)
+	assert.Equal([]int{100, 101}, o.Pids)
+	assert.Equal([]string{
+		filepath.Join(gc.sharedPath, "orphan", "mounts", "rootfs"),
+		filepath.Join(gc.sharedPath, "orphan", "shared"),
+	}, o.Mounts)
+	assert.Len(o.Dirs, 3)
+
+	o = orphans[1]
+	assert.Equal("shared-only", o.ID)
+	assert.Empty(o.Pids)
+	assert.Empty(o.Mounts)
+	assert.Equal([]string{filepath.Join(gc.sharedPath, "shared-only")}, o.Dirs)
+
+	// recently created sandboxes are ignored
+	gc.MinAge = time.Hour
+	orphans, err = gc.FindOrphans()
+	assert.NoError(err)
+	assert.Empty(orphans)
+}
+
+func TestSandboxGCRun(t *testing.T) {
+	assert := assert.New(t)
+
+	gc := newTestSandboxGC(t, nil)
+	defer os.RemoveAll(filepath.Dir(gc.procPath))
+
+	dirs := []string{
+		filepath.Join(gc.runStoragePath, "orphan"),
+		filepath.Join(gc.runVMStoragePath, "orphan"),
+		filepath.Join(gc.sharedPath, "orphan"),
+	}
+	for _, dir := range dirs {
+		assert.NoError(os.MkdirAll(dir, testDirMode))
+	}
+
+	orphans, err := gc.Run(false)
+	assert.NoError(err)
+	assert.Len(orphans, 1)
+	for _, dir := range dirs {
+		assert.True(FileExists(dir))
+	}
+
+	orphans, err = gc.Run(true)
+	assert.NoError(err)
+	assert.Len(orphans, 1)
+	for _, dir := range dirs {
+		assert.False(FileExists(dir))
+	}
+
+	orphans, err = gc.Run(true)
+	assert.NoError(err)
+	assert.Empty(orphans)
+}
+
+func TestSandboxGCShimStillRunning(t *testing.T) {
+	assert := assert.New(t)
+
+	probes := 0
+	gc := newTestSandboxGC(t, nil)
+	defer os.RemoveAll(filepath.Dir(gc.procPath))
+
+	gc.IsAlive = func(id string) bool {
+		probes++
+		return false
+	}
+
+	for _, id := range []string{"stalled", "orphan"} {
+		assert.NoError(os.MkdirAll(filepath.Join(gc.runStoragePath, id), testDirMode))
+	}
+
+	// a shim which doesn't answer, but whose process is still there
+	addTestProcess(t, gc, "200", "/usr/bin/containerd-shim-kata-v2", "-namespace", "k8s.io", "-id", "stalled")
+	addTestProcess(t, gc, "201", "/usr/bin/containerd-shim-runc-v2", "-id", "orphan")
+
+	orphans, err := gc.FindOrphans()
+	assert.NoError(err)
+	assert.Len(orphans, 1)
+	assert.Equal("orphan", orphans[0].ID)
+	assert.Equal(2*gcAliveProbeAttempts, probes)
+}
+
+func TestSandboxGCProbeRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	gc := newTestSandboxGC(t, nil)
+	defer os.RemoveAll(filepath.Dir(gc.procPath))
+
+	probes := 0
+	gc.IsAlive = func(id string) bool {
+		probes++
+		return probes == gcAliveProbeAttempts
+	}
+
+	assert.NoError(os.MkdirAll(filepath.Join(gc.runStoragePath, "busy"), testDirMode))
+
+	orphans, err := gc.FindOrphans()
+	assert.NoError(err)
+	assert.Empty(orphans)
+	assert.Equal(gcAliveProbeAttempts, probes)
+}
+
+func TestSandboxGCIsNetmon(t *testing.T) {
+	assert := assert.New(t)
+
+	gc := newTestSandboxGC(t, nil)
+	defer os.RemoveAll(filepath.Dir(gc.procPath))
+
+	addTestProcess(t, gc, "300", "/usr/libexec/kata-netmon", "-r", "kata-runtime", "-s", "orphan")
+	addTestProcess(t, gc, "301", "/usr/libexec/kata-netmon", "-r", "kata-runtime", "-s", "other")
+	// the netmon pid was reused
+	addTestProcess(t, gc, "302", "/usr/sbin/sshd", "-s", "orphan")
+
+	assert.True(gc.isNetmon(300, "orphan"))
+	assert.False(gc.isNetmon(301, "orphan"))
+	assert.False(gc.isNetmon(302, "orphan"))
+	assert.False(gc.isNetmon(303, "orphan"))
+}
+
+func TestSandboxBinaries(t *testing.T) {
+	assert := assert.New(t)
+
+	config := oci.RuntimeConfig{}
+	assert.Empty(SandboxBinaries(config))
+
+	config.HypervisorConfig.HypervisorPath = "/usr/bin/qemu"
+	config.HypervisorConfig.VirtioFSDaemon = "/usr/libexec/virtiofsd"
+	config.HypervisorConfig.VirtioFSDaemonList = []string{"/usr/libexec/virtiofsd", "/opt/kata/libexec/virtiofsd/"}
+	config.NetmonConfig.Path = "/usr/libexec/kata-netmon"
+	assert.Equal([]string{
+		"/usr/bin/qemu",
+		"/usr/libexec/virtiofsd",
+		"/usr/libexec/kata-netmon",
+		"/usr/libexec/virtiofsd",
+		"/opt/kata/libexec/virtiofsd",
+	}, SandboxBinaries(config))
+}
@@ -0,0 +1,386 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package nri runs the plugins of the containerd Node Resource Interface
+// (NRI) from the shim, before the VM and the containers are created.
+//
+// The plugins are configured and invoked as with NRI 0.1: the configuration
+// file lists the plugins, each plugin is a binary of the plugin directory run
+// with the "invoke" argument, the request in JSON on its stdin, and writes its
+// result in JSON on its stdout. Kata extends the result with the adjustments
+// of the container configuration the plugin asks for.
+package nri
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// Version is the NRI version of the requests.
+	Version = "0.1"
+
+	// DefaultConfigPath is the default NRI configuration file.
+	DefaultConfigPath = "/etc/nri/resources.json"
+
+	// DefaultPluginPath is the default directory of the NRI plugins.
+	DefaultPluginPath = "/opt/nri/bin"
+
+	// HypervisorAnnotationPrefix is the prefix of the annotations the
+	// Kata adjustments may set.
+	HypervisorAnnotationPrefix = "io.katacontainers.config.hypervisor."
+
+	pluginTimeout = 10 * time.Second
+)
+
+// State is the lifecycle point a plugin is invoked at.
+type State string
+
+const (
+	// Create is the state of a pod or a container being created, before its
+	// VM or the container itself is.
+	Create State = "create"
+
+	// Delete is the state of a pod or a container being deleted.
+	Delete State = "delete"
+)
+
+// Config is the NRI configuration of the runtime.
+type Config struct {
+	// Enabled tells if the NRI plugins are invoked
+	Enabled bool
+
+	// ConfigPath is the configuration file listing the plugins,
+	// DefaultConfigPath when empty
+	ConfigPath string
+
+	// PluginPath is the directory of the plugin binaries,
+	// DefaultPluginPath when empty
+	PluginPath string
+}
+
+// ConfigList is the NRI configuration file.
+type ConfigList struct {
+	Version string    `json:"version"`
+	Plugins []*Plugin `json:"plugins"`
+}
+
+// Plugin is a plugin of the configuration file.
+type Plugin struct {
+	Type string          `json:"type"`
+	Conf json.RawMessage `json:"conf,omitempty"`
+}
+
+// Spec is the part of the OCI spec of the container the plugins get.
+type Spec struct {
+	Resources   json.RawMessage   `json:"resources"`
+	Namespaces  map[string]string `json:"namespaces"`
+	CgroupsPath string            `json:"cgroupsPath"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// Request is the request a plugin gets on its stdin.
+type Request struct {
+	Version   string          `json:"version"`
+	ID        string          `json:"id"`
+	SandboxID string          `json:"sandboxID,omitempty"`
+	Pid       int             `json:"pid"`
+	State     State           `json:"state"`
+	Spec      *Spec           `json:"spec"`
+	Conf      json.RawMessage `json:"conf,omitempty"`
+	Results   []*Result       `json:"results,omitempty"`
+}
+
+// IsSandbox tells if the request is the one of the pod rather than of a
+// container.
+func (r *Request) IsSandbox() bool {
+	return r.ID == r.SandboxID
+}
+
+// Result is the result a plugin writes on its stdout.
+type Result struct {
+	Version  string            `json:"version"`
+	Plugin   string            `json:"plugin"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Adjust is the Kata extension of the result, the adjustments of the
+	// container configuration
+	Adjust *Adjustment `json:"adjust,omitempty"`
+}
+
+// Adjustment is a change of the container configuration a plugin asks for.
+type Adjustment struct {
+	// Mounts are added to the mounts of the container
+	Mounts []specs.Mount `json:"mounts,omitempty"`
+
+	// Env are the KEY=value environment variables set in the container
+	Env []string `json:"env,omitempty"`
+
+	// Resources replace the resources of the container they set
+	Resources *specs.LinuxResources `json:"resources,omitempty"`
+
+	// Annotations are set on the container
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// VM adjusts the VM of the pod, it is only valid for the pod
+	VM *VMAdjustment `json:"vm,omitempty"`
+}
+
+// VMAdjustment is a change of the VM of the pod a plugin asks for.
+type VMAdjustment struct {
+	// VCPUs is the number of vCPUs the VM boots with
+	VCPUs uint32 `json:"vcpus,omitempty"`
+
+	// HypervisorAnnotations are the io.katacontainers.config.hypervisor.
+	// annotations set on the pod, they are checked against the
+	// enable_annotations of the configuration as the other ones
+	HypervisorAnnotations map[string]string `json:"hypervisorAnnotations,omitempty"`
+}
+
+// Client invokes the NRI plugins.
+type Client struct {
+	conf       *ConfigList
+	pluginPath string
+}
+
+// New returns a client invoking the plugins of the configuration file of
+// config. NRI is disabled, and the client nil, when the file does not exist.
+func New(config Config) (*Client, error) {
+	configPath := config.ConfigPath
+	if configPath == "" {
+		configPath = DefaultConfigPath
+	}
+
+	pluginPath := config.PluginPath
+	if pluginPath == "" {
+		pluginPath = DefaultPluginPath
+	}
+
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var conf ConfigList
+	if err := json.Unmarshal(data, &conf); err != nil {
+		return nil, fmt.Errorf("invalid NRI configuration %s: %v", configPath, err)
+	}
+
+	for _, p := range conf.Plugins {
+		if p.Type == "" || strings.Contains(p.Type, "/") {
+			return nil, fmt.Errorf("invalid NRI configuration %s: invalid plugin type %q", configPath, p.Type)
+		}
+	}
+
+	return &Client{
+		conf:       &conf,
+		pluginPath: pluginPath,
+	}, nil
+}
+
+// Invoke invokes the plugins in order for the container id of the pod
+// sandboxID, each plugin getting the results of the previous ones, and
+// returns their results.
+func (c *Client) Invoke(ctx context.Context, id, sandboxID string, pid int, state State, spec *specs.Spec) ([]*Result, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	nriSpec, err := newSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*Result
+	for _, p := range c.conf.Plugins {
+		r := &Request{
+			Version:   c.conf.Version,
+			ID:        id,
+			SandboxID: sandboxID,
+			Pid:       pid,
+			State:     state,
+			Spec:      nriSpec,
+			Conf:      p.Conf,
+			Results:   results,
+		}
+
+		result, err := c.invokePlugin(ctx, p.Type, r)
+		if err != nil {
+			return nil, fmt.Errorf("NRI plugin %s: %v", p.Type, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (c *Client) invokePlugin(ctx context.Context, name string, r *Request) (*Result, error) {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pluginTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, filepath.Join(c.pluginPath, name), "invoke")
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %v", pluginTimeout)
+		}
+		return nil, fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var result Result
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("invalid result: %v", err)
+	}
+	if result.Plugin == "" {
+		result.Plugin = name
+	}
+
+	return &result, nil
+}
+
+func newSpec(spec *specs.Spec) (*Spec, error) {
+	s := &Spec{
+		Namespaces:  make(map[string]string),
+		Annotations: spec.Annotations,
+	}
+
+	if spec.Linux != nil {
+		for _, ns := range spec.Linux.Namespaces {
+			s.Namespaces[string(ns.Type)] = ns.Path
+		}
+		s.CgroupsPath = spec.Linux.CgroupsPath
+
+		if spec.Linux.Resources != nil {
+			resources, err := json.Marshal(spec.Linux.Resources)
+			if err != nil {
+				return nil, err
+			}
+			s.Resources = resources
+		}
+	}
+
+	return s, nil
+}
+
+// Apply applies the adjustment to the spec of the container, but the one of
+// the VM.
+func (a *Adjustment) Apply(spec *specs.Spec) error {
+	spec.Mounts = append(spec.Mounts, a.Mounts...)
+
+	for _, env := range a.Env {
+		if err := setEnv(spec, env); err != nil {
+			return err
+		}
+	}
+
+	if a.Resources != nil {
+		if spec.Linux == nil {
+			spec.Linux = &specs.Linux{}
+		}
+		if spec.Linux.Resources == nil {
+			spec.Linux.Resources = &specs.LinuxResources{}
+		}
+		mergeResources(spec.Linux.Resources, a.Resources)
+	}
+
+	if len(a.Annotations) > 0 && spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+	for k, v := range a.Annotations {
+		spec.Annotations[k] = v
+	}
+
+	return nil
+}
+
+// Apply sets the hypervisor annotations of the adjustment on the spec of the
+// pod.
+func (a *VMAdjustment) Apply(spec *specs.Spec) error {
+	for k := range a.HypervisorAnnotations {
+		if !strings.HasPrefix(k, HypervisorAnnotationPrefix) {
+			return fmt.Errorf("%s is not a hypervisor annotation", k)
+		}
+	}
+
+	if len(a.HypervisorAnnotations) > 0 && spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+	for k, v := range a.HypervisorAnnotations {
+		spec.Annotations[k] = v
+	}
+
+	return nil
+}
+
+func setEnv(spec *specs.Spec, env string) error {
+	kv := strings.SplitN(env, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("invalid environment variable %q", env)
+	}
+
+	if spec.Process == nil {
+		spec.Process = &specs.Process{}
+	}
+
+	for i, e := range spec.Process.Env {
+		if strings.SplitN(e, "=", 2)[0] == kv[0] {
+			spec.Process.Env[i] = env
+			return nil
+		}
+	}
+	spec.Process.Env = append(spec.Process.Env, env)
+
+	return nil
+}
+
+// mergeResources replaces the resources of r the adjustment a sets.
+func mergeResources(r, a *specs.LinuxResources) {
+	if a.Memory != nil {
+		r.Memory = a.Memory
+	}
+	if a.CPU != nil {
+		r.CPU = a.CPU
+	}
+	if a.Pids != nil {
+		r.Pids = a.Pids
+	}
+	if a.BlockIO != nil {
+		r.BlockIO = a.BlockIO
+	}
+	if a.HugepageLimits != nil {
+		r.HugepageLimits = a.HugepageLimits
+	}
+	if a.Devices != nil {
+		r.Devices = append(r.Devices, a.Devices...)
+	}
+	for k, v := range a.Unified {
+		if r.Unified == nil {
+			r.Unified = make(map[string]string)
+		}
+		r.Unified[k] = v
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package nri
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// writePlugin writes a plugin saving its request to <name>.request and
+// writing result on its stdout.
+func writePlugin(t *testing.T, dir, name, result string) {
+	script := "#!/bin/sh\n" +
+		"[ \"$1\" = invoke ] || exit 1\n" +
+		"cat > " + filepath.Join(dir, name+".request") + "\n" +
+		"echo '" + result + "'\n"
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(script), 0755))
+}
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "resources.json")
+
+	// NRI is disabled without configuration
+	client, err := New(Config{Enabled: true, ConfigPath: configPath})
+	assert.NoError(err)
+	assert.Nil(client)
+
+	results, err := client.Invoke(context.Background(), "id", "id", 0, Create, &specs.Spec{})
+	assert.NoError(err)
+	assert.Empty(results)
+
+	assert.NoError(ioutil.WriteFile(configPath, []byte(`{"version": "0.1", "plugins": [{"type": "../plugin"}]}`), 0644))
+	_, err = New(Config{Enabled: true, ConfigPath: configPath})
+	assert.Error(err)
+
+	assert.NoError(ioutil.WriteFile(configPath, []byte(`{"version": "0.1", "plugins": [{"type": "plugin"}]}`), 0644))
+	client, err = New(Config{Enabled: true, ConfigPath: configPath})
+	assert.NoError(err)
+	assert.Equal(DefaultPluginPath, client.pluginPath)
+}
+
+func TestInvoke(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "resources.json")
+
+	assert.NoError(ioutil.WriteFile(configPath, []byte(`{
+		"version": "0.1",
+		"plugins": [
+			{"type": "first", "conf": {"key": "value"}},
+			{"type": "second"}
+		]
+	}`), 0644))
+	writePlugin(t, dir, "first", `{"version": "0.1", "metadata": {"first": "done"}}`)
+	writePlugin(t, dir, "second", `{"version": "0.1", "plugin": "second", "adjust": {"env": ["FOO=bar"]}}`)
+
+	client, err := New(Config{Enabled: true, ConfigPath: configPath, PluginPath: dir})
+	assert.NoError(err)
+
+	spec := &specs.Spec{
+		Annotations: map[string]string{"a": "b"},
+		Linux: &specs.Linux{
+			CgroupsPath: "/kubepods/pod",
+			Namespaces:  []specs.LinuxNamespace{{Type: specs.NetworkNamespace, Path: "/var/run/netns/pod"}},
+		},
+	}
+
+	results, err := client.Invoke(context.Background(), "ctr", "pod", 0, Create, spec)
+	assert.NoError(err)
+	assert.Len(results, 2)
+	assert.Equal("first", results[0].Plugin)
+	assert.Equal(map[string]string{"first": "done"}, results[0].Metadata)
+	assert.Equal([]string{"FOO=bar"}, results[1].Adjust.Env)
+
+	// The second plugin gets the result of the first one
+	data, err := ioutil.ReadFile(filepath.Join(dir, "second.request"))
+	assert.NoError(err)
+	var r Request
+	assert.NoError(json.Unmarshal(data, &r))
+	assert.Equal("ctr", r.ID)
+	assert.Equal("pod", r.SandboxID)
+	assert.False(r.IsSandbox())
+	assert.Equal(Create, r.State)
+	assert.Equal("/kubepods/pod", r.Spec.CgroupsPath)
+	assert.Equal("/var/run/netns/pod", r.Spec.Namespaces["network"])
+	assert.Equal(map[string]string{"a": "b"}, r.Spec.Annotations)
+	assert.Len(r.Results, 1)
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "first.request"))
+	assert.NoError(err)
+	assert.NoError(json.Unmarshal(data, &r))
+	assert.JSONEq(`{"key": "value"}`, string(r.Conf))
+
+	// A failing plugin fails the invocation
+	writePlugin(t, dir, "second", `not json`)
+	_, err = client.Invoke(context.Background(), "ctr", "pod", 0, Create, spec)
+	assert.Error(err)
+}
+
+func TestAdjustmentApply(t *testing.T) {
+	assert := assert.New(t)
+
+	limit := int64(1024)
+	shares := uint64(512)
+	spec := &specs.Spec{
+		Process: &specs.Process{Env: []string{"PATH=/bin", "FOO=foo"}},
+		Linux: &specs.Linux{
+			Resources: &specs.LinuxResources{
+				CPU: &specs.LinuxCPU{Shares: &shares},
+			},
+		},
+	}
+
+	a := &Adjustment{
+		Mounts:      []specs.Mount{{Destination: "/data", Source: "/srv/data", Type: "bind"}},
+		Env:         []string{"FOO=bar", "BAZ=qux"},
+		Resources:   &specs.LinuxResources{Memory: &specs.LinuxMemory{Limit: &limit}},
+		Annotations: map[string]string{"a": "b"},
+	}
+	assert.NoError(a.Apply(spec))
+	assert.Len(spec.Mounts, 1)
+	assert.Equal([]string{"PATH=/bin", "FOO=bar", "BAZ=qux"}, spec.Process.Env)
+	assert.Equal(&shares, spec.Linux.Resources.CPU.Shares)
+	assert.Equal(&limit, spec.Linux.Resources.Memory.Limit)
+	assert.Equal("b", spec.Annotations["a"])
+
+	a = &Adjustment{Env: []string{"=bar"}}
+	assert.Error(a.Apply(spec))
+
+	vm := &VMAdjustment{HypervisorAnnotations: map[string]string{"io.katacontainers.config.hypervisor.default_memory": "4096"}}
+	assert.NoError(vm.Apply(spec))
+	assert.Equal("4096", spec.Annotations["io.katacontainers.config.hypervisor.default_memory"])
+
+	vm = &VMAdjustment{HypervisorAnnotations: map[string]string{"io.katacontainers.config.agent.debug": "true"}}
+	assert.Error(vm.Apply(spec))
+}
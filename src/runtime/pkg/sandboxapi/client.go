@@ -0,0 +1,514 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package sandboxapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
+)
+
+const (
+	defaultDialTimeout = 3 * time.Second
+
+	shimURL = "http://shim"
+)
+
+// Client talks to the shim management endpoint of a sandbox.
+type Client struct {
+	sandboxID string
+	timeout   time.Duration
+}
+
+// NewClient returns a client for the sandbox. Requests time out after
+// timeout, or never when it is zero.
+func NewClient(sandboxID string, timeout time.Duration) *Client {
+	return &Client{
+		sandboxID: sandboxID,
+		timeout:   timeout,
+	}
+}
+
+// SandboxID returns the ID of the sandbox of the client.
+func (c *Client) SandboxID() string {
+	return c.sandboxID
+}
+
+func (c *Client) dial() (net.Conn, error) {
+	return net.DialTimeout("unix", "\x00"+SocketAddress(c.sandboxID), defaultDialTimeout)
+}
+
+// HTTPClient returns an http client connected to the shim management
+// endpoint, for the requests that are not wrapped by Client.
+func (c *Client) HTTPClient() *http.Client {
+	transport := &http.Transport{
+		DisableKeepAlives: true,
+		Dial: func(proto, addr string) (conn net.Conn, err error) {
+			return c.dial()
+		},
+	}
+
+	client := &http.Client{
+		Transport: transport,
+	}
+
+	if c.timeout > 0 {
+		client.Timeout = c.timeout
+	}
+
+	return client
+}
+
+// IsAlive returns true if the shim of the sandbox is serving its management
+// endpoint.
+func (c *Client) IsAlive() bool {
+	conn, err := c.dial()
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// do sends a request to the shim and returns the response body, it fails
+// when the response status is not 200.
+func (c *Client) do(method, path string, in interface{}) ([]byte, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, shimURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s failed for sandbox %s: %d %s", method, path, c.sandboxID, resp.StatusCode, data)
+	}
+
+	return data, nil
+}
+
+// AgentURL returns the URL of the agent of the sandbox.
+func (c *Client) AgentURL() (string, error) {
+	data, err := c.do(http.MethodGet, "/agent-url", nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// Metrics returns the sandbox metrics, in the Prometheus text format.
+func (c *Client) Metrics() (string, error) {
+	data, err := c.do(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// Events returns the event journal of the sandbox.
+func (c *Client) Events() ([]Event, error) {
+	data, err := c.do(http.MethodGet, "/events", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// HypervisorLog returns the last kb KB of the hypervisor output and of the
+// guest console of the sandbox, all that the shim keeps when kb is 0.
+func (c *Client) HypervisorLog(kb uint) (string, error) {
+	path := "/hypervisor-log"
+	if kb != 0 {
+		path = fmt.Sprintf("%s?kb=%d", path, kb)
+	}
+
+	data, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// InspectNetwork returns the network configuration of the sandbox on the
+// host and in the guest, along with their differences.
+func (c *Client) InspectNetwork() (NetworkInspection, error) {
+	data, err := c.do(http.MethodGet, "/network", nil)
+	if err != nil {
+		return NetworkInspection{}, err
+	}
+
+	var inspection NetworkInspection
+	if err := json.Unmarshal(data, &inspection); err != nil {
+		return NetworkInspection{}, err
+	}
+
+	return inspection, nil
+}
+
+// CaptureNetwork captures the traffic of the sandbox and writes it to w in
+// the pcap format, until the end of the capture or until ctx is done.
+func (c *Client) CaptureNetwork(ctx context.Context, w io.Writer, req NetworkCaptureRequest) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, shimURL+"/network/capture?"+req.Query().Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	// The capture lasts as long as requested
+	client := c.HTTPClient()
+	client.Timeout = 0
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("GET /network/capture failed for sandbox %s: %d %s", c.sandboxID, resp.StatusCode, data)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// CheckpointContainer dumps the processes of a container of the sandbox and
+// writes the tar archive of their CRIU images to w.
+func (c *Client) CheckpointContainer(ctx context.Context, w io.Writer, req CheckpointRequest) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, shimURL+"/containers/checkpoint?"+req.Query().Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	// The images are as large as the memory of the processes
+	client := c.HTTPClient()
+	client.Timeout = 0
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("POST /containers/checkpoint failed for sandbox %s: %d %s", c.sandboxID, resp.StatusCode, data)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// RestoreContainer restores the processes of a checkpoint in the root file
+// system of a container of the sandbox, images being the tar archive of
+// their CRIU images, size bytes long.
+func (c *Client) RestoreContainer(ctx context.Context, containerID string, images io.Reader, size int64) error {
+	query := url.Values{}
+	query.Set("id", containerID)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, shimURL+"/containers/restore?"+query.Encode(), images)
+	if err != nil {
+		return err
+	}
+	httpReq.ContentLength = size
+	httpReq.Header.Set("Content-Type", TarContentType)
+
+	client := c.HTTPClient()
+	client.Timeout = 0
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("POST /containers/restore failed for sandbox %s: %d %s", c.sandboxID, resp.StatusCode, data)
+	}
+
+	return nil
+}
+
+// WatchPolicyDecisions calls fn with the agent policy decisions of the
+// sandbox as they are made, until ctx is done or fn fails.
+func (c *Client) WatchPolicyDecisions(ctx context.Context, fn func(PolicyDecision) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, shimURL+"/policy-decisions", nil)
+	if err != nil {
+		return err
+	}
+
+	// The decisions are streamed for as long as the client wants them
+	client := c.HTTPClient()
+	client.Timeout = 0
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("GET /policy-decisions failed for sandbox %s: %d %s", c.sandboxID, resp.StatusCode, data)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var decision PolicyDecision
+		if err := decoder.Decode(&decision); err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		if err := fn(decision); err != nil {
+			return err
+		}
+	}
+}
+
+// DumpMemory dumps the guest memory of the sandbox and returns the directory
+// of the dump.
+func (c *Client) DumpMemory(req MemoryDumpRequest) (string, error) {
+	data, err := c.do(http.MethodPost, "/debug/dump-memory", req)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// MigrationPrepareReceive prepares the destination sandbox to receive a live
+// migration on uri.
+func (c *Client) MigrationPrepareReceive(uri string) error {
+	_, err := c.do(http.MethodPost, "/migration/prepare-receive", MigrationRequest{URI: uri})
+	return err
+}
+
+// MigrationStart starts the live migration of the source sandbox to uri.
+func (c *Client) MigrationStart(uri string) error {
+	_, err := c.do(http.MethodPost, "/migration/start", MigrationRequest{URI: uri})
+	return err
+}
+
+// MigrationStatus returns the live migration status of the sandbox.
+func (c *Client) MigrationStatus() (string, error) {
+	data, err := c.do(http.MethodGet, "/migration/status", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var status MigrationStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return "", err
+	}
+
+	return status.Status, nil
+}
+
+// MigrationSwitchover switches the live migration over, on the source
+// sandbox then on the destination one.
+func (c *Client) MigrationSwitchover() error {
+	_, err := c.do(http.MethodPost, "/migration/switchover", nil)
+	return err
+}
+
+// Quiesce stops the shim from accepting new exec and port forward requests,
+// has the agent sync the guest file systems and returns the quiesce status.
+func (c *Client) Quiesce() (QuiesceStatus, error) {
+	return c.quiesce(http.MethodPost)
+}
+
+// QuiesceStatus returns the quiesce status of the sandbox, e.g. to wait for
+// it to be safe to stop.
+func (c *Client) QuiesceStatus() (QuiesceStatus, error) {
+	return c.quiesce(http.MethodGet)
+}
+
+func (c *Client) quiesce(method string) (QuiesceStatus, error) {
+	var status QuiesceStatus
+
+	data, err := c.do(method, "/quiesce", nil)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(data, &status)
+	return status, err
+}
+
+// ReloadConfig has the shim read its configuration file again and apply
+// the settings that can change while the sandbox runs.
+func (c *Client) ReloadConfig() (ConfigReload, error) {
+	var reload ConfigReload
+
+	data, err := c.do(http.MethodPost, "/config/reload", nil)
+	if err != nil {
+		return reload, err
+	}
+
+	err = json.Unmarshal(data, &reload)
+	return reload, err
+}
+
+// Resources returns the resources status of the sandbox VM.
+func (c *Client) Resources() (ResourcesStatus, error) {
+	return c.resources(http.MethodGet, nil)
+}
+
+// ResizeResources has the shim resize the sandbox VM to target, e.g. for an
+// in-place pod resize. The VM is resized asynchronously, Resources tells
+// when it's done.
+func (c *Client) ResizeResources(target SandboxResources) (ResourcesStatus, error) {
+	return c.resources(http.MethodPost, target)
+}
+
+func (c *Client) resources(method string, in interface{}) (ResourcesStatus, error) {
+	var status ResourcesStatus
+
+	data, err := c.do(method, "/resources", in)
+	if err != nil {
+		return status, err
+	}
+
+	err = json.Unmarshal(data, &status)
+	return status, err
+}
+
+// Labels returns the labels of the sandbox, its key/value metadata.
+func (c *Client) Labels() (map[string]string, error) {
+	return c.labels(http.MethodGet, nil)
+}
+
+// UpdateLabels has the shim update the labels of the sandbox, which are
+// persisted with its state, and returns them once updated.
+func (c *Client) UpdateLabels(update LabelsUpdate) (map[string]string, error) {
+	return c.labels(http.MethodPost, update)
+}
+
+func (c *Client) labels(method string, in interface{}) (map[string]string, error) {
+	data, err := c.do(method, "/labels", in)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{}
+	err = json.Unmarshal(data, &labels)
+	return labels, err
+}
+
+// ResizeVolume has the sandbox resize the block volume at volumePath once
+// its device has been grown to size bytes on the host: the guest is told of
+// the new device capacity, and the volume filesystem is grown.
+func (c *Client) ResizeVolume(volumePath string, size uint64) error {
+	_, err := c.do(http.MethodPost, "/volumes/resize", VolumeResizeRequest{VolumePath: volumePath, Size: size})
+	return err
+}
+
+// GuestDiagnostics returns a snapshot of the state of the guest OS of the
+// sandbox, with the last dmesgLines lines of its kernel log, the agent
+// default when it is 0.
+func (c *Client) GuestDiagnostics(dmesgLines uint) (GuestDiagnostics, error) {
+	path := "/guest/diagnostics"
+	if dmesgLines != 0 {
+		path = fmt.Sprintf("%s?dmesg_lines=%d", path, dmesgLines)
+	}
+
+	data, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		return GuestDiagnostics{}, err
+	}
+
+	var diagnostics GuestDiagnostics
+	if err := json.Unmarshal(data, &diagnostics); err != nil {
+		return GuestDiagnostics{}, err
+	}
+
+	return diagnostics, nil
+}
+
+// DialPortForward returns a connection to the TCP port of the sandbox,
+// tunnelled by its shim through the agent.
+func (c *Client) DialPortForward(port uint16) (net.Conn, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/port-forward?port=%d", shimURL, port), nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", PortForwardUpgrade)
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		conn.Close()
+		return nil, fmt.Errorf("failed to forward port %d of sandbox %s: %d %s", port, c.sandboxID, resp.StatusCode, body)
+	}
+
+	return mutils.NewBufferedConn(conn, br), nil
+}
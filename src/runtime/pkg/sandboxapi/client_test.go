@@ -0,0 +1,257 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package sandboxapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveShim serves a fake shim management endpoint for the sandbox.
+func serveShim(t *testing.T, sandboxID string, m *http.ServeMux) func() {
+	l, err := net.Listen("unix", "\x00"+SocketAddress(sandboxID))
+	assert.NoError(t, err)
+
+	server := &http.Server{Handler: m}
+	go server.Serve(l)
+
+	return func() {
+		server.Close()
+	}
+}
+
+func TestClient(t *testing.T) {
+	assert := assert.New(t)
+
+	sandboxID := fmt.Sprintf("sandboxapi-test-%d", time.Now().UnixNano())
+	client := NewClient(sandboxID, time.Second)
+	assert.Equal(sandboxID, client.SandboxID())
+	assert.False(client.IsAlive())
+
+	var migrationURI string
+	m := http.NewServeMux()
+	m.HandleFunc("/agent-url", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "vsock://3:1024")
+	})
+	m.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]Event{{Type: "sandbox-start"}})
+	})
+	m.HandleFunc("/hypervisor-log", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "[console] kb=%s\n", r.URL.Query().Get("kb"))
+	})
+	m.HandleFunc("/network", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"host":{"interfaces":[{"name":"eth0","hw_addr":"02:42:ac:11:00:02","addresses":["10.0.0.2/24"]}]},"guest":{},"differences":["interface eth0 (02:42:ac:11:00:02) is missing in the guest"]}`)
+	})
+	m.HandleFunc("/network/capture", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal("10s", query.Get("duration"))
+		assert.Equal("eth0", query.Get("device"))
+		assert.Equal("true", query.Get("guest"))
+		assert.Empty(query.Get("snaplen"))
+		w.Header().Set("Content-Type", PcapContentType)
+		w.Write([]byte("pcap"))
+	})
+	m.HandleFunc("/containers/checkpoint", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal("foo", r.URL.Query().Get("id"))
+		assert.Equal("true", r.URL.Query().Get("leave-running"))
+		w.Header().Set("Content-Type", TarContentType)
+		w.Write([]byte("images"))
+	})
+	m.HandleFunc("/containers/restore", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		if id := r.URL.Query().Get("id"); id != "foo" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "container %s not found", id)
+			return
+		}
+		assert.Equal(int64(6), r.ContentLength)
+		data, err := ioutil.ReadAll(r.Body)
+		assert.NoError(err)
+		assert.Equal("images", string(data))
+	})
+	m.HandleFunc("/migration/start", func(w http.ResponseWriter, r *http.Request) {
+		var req MigrationRequest
+		assert.Equal(http.MethodPost, r.Method)
+		assert.NoError(json.NewDecoder(r.Body).Decode(&req))
+		migrationURI = req.URI
+	})
+	m.HandleFunc("/migration/status", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(MigrationStatus{Status: "completed"})
+	})
+	m.HandleFunc("/policy-decisions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"time":1,"request":"ExecProcessRequest","rule":"no-exec","digest":"af63dc4c8601ec8c","allowed":false,"enforced":false}`)
+		fmt.Fprintln(w, `{"time":2,"request":"CopyFileRequest","rule":"default","digest":"cbf29ce484222325","allowed":true,"enforced":false}`)
+	})
+	m.HandleFunc("/quiesce", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(QuiesceStatus{Quiesced: r.Method == http.MethodPost, RunningExecs: 1})
+	})
+	m.HandleFunc("/config/reload", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		json.NewEncoder(w).Encode(ConfigReload{ConfigPath: "/etc/kata-containers/configuration.toml", LogLevel: "debug"})
+	})
+	m.HandleFunc("/resources", func(w http.ResponseWriter, r *http.Request) {
+		status := ResourcesStatus{Current: SandboxResources{VCPUs: 1, MemoryMB: 2048}}
+		if r.Method == http.MethodPost {
+			assert.NoError(json.NewDecoder(r.Body).Decode(&status.Target))
+			status.Reconciling = true
+		}
+		json.NewEncoder(w).Encode(status)
+	})
+	m.HandleFunc("/labels", func(w http.ResponseWriter, r *http.Request) {
+		labels := map[string]string{"tenant": "acme"}
+		if r.Method == http.MethodPost {
+			var update LabelsUpdate
+			assert.NoError(json.NewDecoder(r.Body).Decode(&update))
+			for _, key := range update.Remove {
+				delete(labels, key)
+			}
+			for key, value := range update.Set {
+				labels[key] = value
+			}
+		}
+		json.NewEncoder(w).Encode(labels)
+	})
+	m.HandleFunc("/volumes/resize", func(w http.ResponseWriter, r *http.Request) {
+		var req VolumeResizeRequest
+		assert.NoError(json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(VolumeResizeRequest{VolumePath: "/dev/sdb", Size: 1 << 30}, req)
+	})
+	m.HandleFunc("/guest/diagnostics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"dmesg":"dmesg_lines=%s","filesystems":[{"mount_point":"/","fstype":"ext4","size":4096,"used":1024}],"errors":{"modules":"not found"}}`, r.URL.Query().Get("dmesg_lines"))
+	})
+	m.HandleFunc("/migration/switchover", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("no migration"))
+	})
+
+	stop := serveShim(t, sandboxID, m)
+	defer stop()
+
+	assert.True(client.IsAlive())
+
+	url, err := client.AgentURL()
+	assert.NoError(err)
+	assert.Equal("vsock://3:1024", url)
+
+	events, err := client.Events()
+	assert.NoError(err)
+	assert.Len(events, 1)
+	assert.Equal("sandbox-start", events[0].Type)
+
+	log, err := client.HypervisorLog(0)
+	assert.NoError(err)
+	assert.Equal("[console] kb=\n", log)
+	log, err = client.HypervisorLog(8)
+	assert.NoError(err)
+	assert.Equal("[console] kb=8\n", log)
+
+	inspection, err := client.InspectNetwork()
+	assert.NoError(err)
+	assert.Len(inspection.Host.Interfaces, 1)
+	assert.Equal([]string{"10.0.0.2/24"}, inspection.Host.Interfaces[0].Addresses)
+	assert.Empty(inspection.Guest.Interfaces)
+	assert.Len(inspection.Differences, 1)
+
+	var capture bytes.Buffer
+	err = client.CaptureNetwork(context.Background(), &capture, NetworkCaptureRequest{Device: "eth0", Duration: 10 * time.Second, Guest: true})
+	assert.NoError(err)
+	assert.Equal("pcap", capture.String())
+
+	var images bytes.Buffer
+	err = client.CheckpointContainer(context.Background(), &images, CheckpointRequest{ContainerID: "foo", LeaveRunning: true})
+	assert.NoError(err)
+	assert.Equal("images", images.String())
+
+	err = client.RestoreContainer(context.Background(), "foo", strings.NewReader("images"), 6)
+	assert.NoError(err)
+
+	err = client.RestoreContainer(context.Background(), "bar", strings.NewReader("images"), 6)
+	assert.Error(err)
+
+	err = client.MigrationStart("tcp:dest:4444")
+	assert.NoError(err)
+	assert.Equal("tcp:dest:4444", migrationURI)
+
+	status, err := client.MigrationStatus()
+	assert.NoError(err)
+	assert.Equal("completed", status)
+
+	err = client.MigrationSwitchover()
+	assert.Error(err)
+	assert.Contains(err.Error(), "no migration")
+
+	quiesce, err := client.QuiesceStatus()
+	assert.NoError(err)
+	assert.Equal(QuiesceStatus{RunningExecs: 1}, quiesce)
+
+	quiesce, err = client.Quiesce()
+	assert.NoError(err)
+	assert.Equal(QuiesceStatus{Quiesced: true, RunningExecs: 1}, quiesce)
+
+	reload, err := client.ReloadConfig()
+	assert.NoError(err)
+	assert.Equal(ConfigReload{ConfigPath: "/etc/kata-containers/configuration.toml", LogLevel: "debug"}, reload)
+
+	resources, err := client.Resources()
+	assert.NoError(err)
+	assert.Equal(ResourcesStatus{Current: SandboxResources{VCPUs: 1, MemoryMB: 2048}}, resources)
+
+	resources, err = client.ResizeResources(SandboxResources{VCPUs: 4, MemoryMB: 4096})
+	assert.NoError(err)
+	assert.True(resources.Reconciling)
+	assert.Equal(SandboxResources{VCPUs: 4, MemoryMB: 4096}, resources.Target)
+
+	labels, err := client.Labels()
+	assert.NoError(err)
+	assert.Equal(map[string]string{"tenant": "acme"}, labels)
+
+	labels, err = client.UpdateLabels(LabelsUpdate{Set: map[string]string{"billing-code": "42"}, Remove: []string{"tenant"}})
+	assert.NoError(err)
+	assert.Equal(map[string]string{"billing-code": "42"}, labels)
+
+	assert.NoError(client.ResizeVolume("/dev/sdb", 1<<30))
+
+	diagnostics, err := client.GuestDiagnostics(20)
+	assert.NoError(err)
+	assert.Equal("dmesg_lines=20", diagnostics.Dmesg)
+	assert.Equal([]GuestFilesystemUsage{{MountPoint: "/", FsType: "ext4", Size: 4096, Used: 1024}}, diagnostics.Filesystems)
+	assert.Equal(map[string]string{"modules": "not found"}, diagnostics.Errors)
+
+	diagnostics, err = client.GuestDiagnostics(0)
+	assert.NoError(err)
+	assert.Equal("dmesg_lines=", diagnostics.Dmesg)
+
+	var decisions []PolicyDecision
+	err = client.WatchPolicyDecisions(context.Background(), func(d PolicyDecision) error {
+		decisions = append(decisions, d)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Len(decisions, 2)
+	assert.Equal("no-exec", decisions[0].Rule)
+	assert.False(decisions[0].Allowed)
+	assert.True(decisions[1].Allowed)
+
+	err = client.WatchPolicyDecisions(context.Background(), func(d PolicyDecision) error {
+		return fmt.Errorf("stop")
+	})
+	assert.Error(err)
+
+	// not served by the fake shim
+	_, err = client.Metrics()
+	assert.Error(err)
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package sandboxapi is the client API to manage Kata sandboxes from Go
+// programs. It talks to the management socket of the sandbox shims and
+// reads the persisted sandbox state, without depending on virtcontainers.
+//
+// The package is part of the runtime module and is released with it, the
+// shim management endpoints it talks to are those of the shims of the same
+// release.
+package sandboxapi
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// PortForwardUpgrade is the protocol of the connection upgrade required by
+// the port forward endpoint.
+const PortForwardUpgrade = "tcp"
+
+// PcapContentType is the content type of the /network/capture responses.
+const PcapContentType = "application/vnd.tcpdump.pcap"
+
+// TarContentType is the content type of the checkpoint images, streamed by
+// /containers/checkpoint and sent to /containers/restore.
+const TarContentType = "application/x-tar"
+
+// SocketAddress returns the address of the abstract domain socket for
+// communicating with the shim management endpoint of a sandbox.
+func SocketAddress(sandboxID string) string {
+	return filepath.Join(string(filepath.Separator), "run", "vc", sandboxID, "shim-monitor")
+}
+
+// MemoryDumpRequest is the body of /debug/dump-memory requests
+type MemoryDumpRequest struct {
+	// EncryptionKey is used to encrypt the dump when it's set
+	EncryptionKey []byte `json:"encryption_key,omitempty"`
+}
+
+// NetworkCaptureRequest describes a packet capture of the sandbox traffic,
+// it is sent as the query of /network/capture requests.
+type NetworkCaptureRequest struct {
+	// Device is the captured interface, the tap interface of the first
+	// sandbox interface on the host, or its interface in the guest, when
+	// it is empty
+	Device string
+	// Duration of the capture, the shim bounds it
+	Duration time.Duration
+	// SnapLen is the length captured of each packet, the whole packets
+	// are captured when it is 0
+	SnapLen uint32
+	// Guest captures in the guest, through the agent, rather than on the
+	// host side of the sandbox network
+	Guest bool
+}
+
+// Query returns the query of the /network/capture request.
+func (r NetworkCaptureRequest) Query() url.Values {
+	query := url.Values{}
+	query.Set("duration", r.Duration.String())
+	if r.Device != "" {
+		query.Set("device", r.Device)
+	}
+	if r.SnapLen > 0 {
+		query.Set("snaplen", strconv.FormatUint(uint64(r.SnapLen), 10))
+	}
+	if r.Guest {
+		query.Set("guest", "true")
+	}
+	return query
+}
+
+// CheckpointRequest describes the checkpoint of a container of the sandbox,
+// it is sent as the query of /containers/checkpoint requests.
+type CheckpointRequest struct {
+	// ContainerID is the checkpointed container
+	ContainerID string
+	// LeaveRunning keeps the container running once its processes are
+	// dumped, it is stopped by the checkpoint otherwise
+	LeaveRunning bool
+}
+
+// Query returns the query of the /containers/checkpoint request.
+func (r CheckpointRequest) Query() url.Values {
+	query := url.Values{}
+	query.Set("id", r.ContainerID)
+	if r.LeaveRunning {
+		query.Set("leave-running", "true")
+	}
+	return query
+}
+
+// MigrationRequest is the body of /migration/prepare-receive and
+// /migration/start requests
+type MigrationRequest struct {
+	// URI is the migration URI, e.g. "tcp:0:4444" to receive the migration
+	// or "tcp:<destination>:4444" to start it
+	URI string `json:"uri"`
+}
+
+// MigrationStatus is the body of /migration/status responses
+type MigrationStatus struct {
+	// Status is the hypervisor migration status, e.g. "active",
+	// "pre-switchover" or "completed"
+	Status string `json:"status"`
+}
+
+// QuiesceStatus is the body of /quiesce responses
+type QuiesceStatus struct {
+	// Quiesced is true once the shim stopped accepting the new exec and
+	// port forward requests
+	Quiesced bool `json:"quiesced"`
+
+	// GuestSynced is true once the guest file systems have been synced,
+	// SyncError tells why they could not be otherwise
+	GuestSynced bool   `json:"guest_synced"`
+	SyncError   string `json:"sync_error,omitempty"`
+
+	// RunningExecs is the number of exec processes still running
+	RunningExecs int `json:"running_execs"`
+
+	// SafeToStop is true once the sandbox is quiesced and no exec process
+	// is running anymore
+	SafeToStop bool `json:"safe_to_stop"`
+}
+
+// ConfigReload is the body of /config/reload responses, the settings in
+// effect once the shim has read its configuration file again.
+type ConfigReload struct {
+	// ConfigPath is the configuration file read again
+	ConfigPath string `json:"config_path"`
+
+	// LogLevel is the shim log level, e.g. "debug" when the runtime debug
+	// is enabled
+	LogLevel string `json:"log_level"`
+
+	// Pprof is true when the pprof endpoints are served
+	Pprof bool `json:"pprof"`
+
+	// RestartRequired lists the changed settings the running sandbox
+	// cannot apply, e.g. "agent.debug_console_enabled", they apply to
+	// the sandboxes started afterwards
+	RestartRequired []string `json:"restart_required,omitempty"`
+}
+
+// SandboxResources are the vCPUs and the memory of a sandbox VM, the body
+// of /resources requests.
+type SandboxResources struct {
+	VCPUs    uint32 `json:"vcpus"`
+	MemoryMB uint32 `json:"memory_mb"`
+}
+
+// ResourcesStatus is the body of /resources responses. The shim resizes the
+// VM toward Target step by step, Current are the resources of the VM.
+type ResourcesStatus struct {
+	Target  SandboxResources `json:"target"`
+	Current SandboxResources `json:"current"`
+
+	// Reconciling is true while the VM is being resized
+	Reconciling bool `json:"reconciling"`
+
+	// Error tells why the last resize failed, the VM being rolled back to
+	// its resources before the resize when RolledBack is true
+	Error      string `json:"error,omitempty"`
+	RolledBack bool   `json:"rolled_back,omitempty"`
+}
+
+// LabelsUpdate is the body of the /labels POST requests, the labels of Set
+// are set and the ones of Remove removed. /labels responses are the labels
+// of the sandbox, as a JSON object.
+type LabelsUpdate struct {
+	Set    map[string]string `json:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
+// VolumeResizeRequest is the body of /volumes/resize requests
+type VolumeResizeRequest struct {
+	// VolumePath is the mount source of the block volume on the host,
+	// e.g. the path of a CSI volume or of its device
+	VolumePath string `json:"volume_path"`
+	// Size is the new size of the volume device in bytes
+	Size uint64 `json:"size"`
+}
+
+// GuestDiagnostics is a snapshot of the state of the guest OS of a sandbox,
+// as returned by /guest/diagnostics. The text sections are bounded, their
+// start is cut when they are too large. Errors are the failures to collect
+// the sections, by section name.
+type GuestDiagnostics struct {
+	Time        time.Time              `json:"time"`
+	Dmesg       string                 `json:"dmesg"`
+	Mounts      string                 `json:"mounts"`
+	Filesystems []GuestFilesystemUsage `json:"filesystems"`
+	MemInfo     string                 `json:"meminfo"`
+	Modules     string                 `json:"modules"`
+	Errors      map[string]string      `json:"errors,omitempty"`
+}
+
+// GuestFilesystemUsage is the usage of a filesystem mounted in the guest,
+// the sizes are in bytes.
+type GuestFilesystemUsage struct {
+	MountPoint string `json:"mount_point"`
+	FsType     string `json:"fstype"`
+	Source     string `json:"source"`
+	Size       uint64 `json:"size"`
+	Used       uint64 `json:"used"`
+	Available  uint64 `json:"available"`
+	Inodes     uint64 `json:"inodes"`
+	InodesFree uint64 `json:"inodes_free"`
+}
+
+// Event is an entry of the sandbox event journal, as returned by /events.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	Container string    `json:"container,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// PolicyDecision is a decision of the agent policy, as streamed by
+// /policy-decisions, a JSON object per line.
+type PolicyDecision struct {
+	// Time is the Unix time of the decision, in the guest
+	Time    int64  `json:"time"`
+	Request string `json:"request"`
+	Rule    string `json:"rule"`
+	// Digest identifies the request, it's not collision resistant
+	Digest  string `json:"digest"`
+	Allowed bool   `json:"allowed"`
+	// Enforced is false when the policy is in audit mode, the denied
+	// requests being served anyway
+	Enforced bool `json:"enforced"`
+}
+
+// NetworkInspection is the network configuration of a sandbox on the host
+// side, in its network namespace, and on the guest side, as returned by
+// /network. Differences describe the host configuration missing in the
+// guest, or the other way around.
+type NetworkInspection struct {
+	Host        NetworkSnapshot `json:"host"`
+	Guest       NetworkSnapshot `json:"guest"`
+	Differences []string        `json:"differences,omitempty"`
+}
+
+// NetworkSnapshot is the network configuration of one side of a sandbox.
+// The neighbors and the tc filters are only reported on the host side.
+type NetworkSnapshot struct {
+	Interfaces []NetworkInterface `json:"interfaces,omitempty"`
+	Routes     []NetworkRoute     `json:"routes,omitempty"`
+	Neighbors  []NetworkNeighbor  `json:"neighbors,omitempty"`
+	Filters    []NetworkFilter    `json:"filters,omitempty"`
+}
+
+// NetworkInterface is a network interface of a NetworkSnapshot.
+type NetworkInterface struct {
+	Name   string `json:"name"`
+	HwAddr string `json:"hw_addr,omitempty"`
+	MTU    uint64 `json:"mtu,omitempty"`
+	// Addresses are in CIDR notation, e.g. "10.0.0.2/24"
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// NetworkRoute is a route of a NetworkSnapshot.
+type NetworkRoute struct {
+	Dest    string `json:"dest,omitempty"`
+	Gateway string `json:"gateway,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Device  string `json:"device"`
+}
+
+// NetworkNeighbor is a neighbor table entry of a NetworkSnapshot.
+type NetworkNeighbor struct {
+	IP        string `json:"ip"`
+	HwAddr    string `json:"hw_addr,omitempty"`
+	Device    string `json:"device"`
+	Permanent bool   `json:"permanent,omitempty"`
+}
+
+// NetworkFilter is a tc filter redirecting the traffic received by Device
+// to Redirect.
+type NetworkFilter struct {
+	Device   string `json:"device"`
+	Redirect string `json:"redirect"`
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package sandboxapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+)
+
+func getStore() (persistapi.PersistDriver, error) {
+	store, err := persist.GetDriver()
+	if err != nil || store == nil {
+		return nil, errors.New("failed to get fs persist driver")
+	}
+
+	return store, nil
+}
+
+// ListSandboxes returns the sorted IDs of the sandboxes persisted on the
+// host, whether their shim is running or not.
+func ListSandboxes() ([]string, error) {
+	store, err := getStore()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(store.RunStoragePath())
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, e := range entries {
+		if e.IsDir() {
+			ids = append(ids, e.Name())
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// ListSandboxesByLabels returns the sorted IDs of the sandboxes persisted on
+// the host having all the labels of selector, whether their shim is running
+// or not. The sandboxes whose state cannot be read are skipped.
+func ListSandboxesByLabels(selector map[string]string) ([]string, error) {
+	ids, err := ListSandboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := []string{}
+	for _, id := range ids {
+		ss, _, err := SandboxState(id)
+		if err != nil {
+			continue
+		}
+
+		if matchLabels(ss.Labels, selector) {
+			matching = append(matching, id)
+		}
+	}
+
+	return matching, nil
+}
+
+func matchLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// SandboxState returns the persisted state of a sandbox and of its
+// containers, indexed by container ID.
+func SandboxState(sandboxID string) (persistapi.SandboxState, map[string]persistapi.ContainerState, error) {
+	store, err := getStore()
+	if err != nil {
+		return persistapi.SandboxState{}, nil, err
+	}
+
+	return store.FromDisk(sandboxID)
+}
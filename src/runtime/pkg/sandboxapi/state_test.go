@@ -0,0 +1,69 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package sandboxapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxState(t *testing.T) {
+	assert := assert.New(t)
+
+	persist.EnableMockTesting()
+	defer fs.MockStorageDestroy()
+
+	ids, err := ListSandboxes()
+	assert.NoError(err)
+	assert.Empty(ids)
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+
+	for _, id := range []string{"sandbox-b", "sandbox-a"} {
+		err = store.ToDisk(persistapi.SandboxState{
+			SandboxContainer: id,
+			State:            "running",
+			Labels:           map[string]string{"tenant": "acme", "name": id},
+		}, nil)
+		assert.NoError(err)
+	}
+
+	// not a sandbox directory
+	f, err := os.Create(filepath.Join(fs.MockRunStoragePath(), "file"))
+	assert.NoError(err)
+	f.Close()
+
+	ids, err = ListSandboxes()
+	assert.NoError(err)
+	assert.Equal([]string{"sandbox-a", "sandbox-b"}, ids)
+
+	ids, err = ListSandboxesByLabels(map[string]string{"tenant": "acme"})
+	assert.NoError(err)
+	assert.Equal([]string{"sandbox-a", "sandbox-b"}, ids)
+
+	ids, err = ListSandboxesByLabels(map[string]string{"tenant": "acme", "name": "sandbox-b"})
+	assert.NoError(err)
+	assert.Equal([]string{"sandbox-b"}, ids)
+
+	ids, err = ListSandboxesByLabels(map[string]string{"tenant": "initech"})
+	assert.NoError(err)
+	assert.Empty(ids)
+
+	ss, _, err := SandboxState("sandbox-a")
+	assert.NoError(err)
+	assert.Equal("sandbox-a", ss.SandboxContainer)
+	assert.Equal("running", ss.State)
+
+	_, _, err = SandboxState("sandbox-c")
+	assert.Error(err)
+}
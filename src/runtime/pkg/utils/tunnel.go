@@ -0,0 +1,63 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package utils
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"sync"
+)
+
+type closeWriter interface {
+	CloseWrite() error
+}
+
+// Tunnel copies the data between a and b, in both directions, until both
+// directions are done. Once a side stops sending, the write side of its peer
+// is closed when supported, so that half closed connections keep working.
+func Tunnel(a, b io.ReadWriter) {
+	var wg sync.WaitGroup
+
+	copyAndClose := func(dst, src io.ReadWriter) {
+		defer wg.Done()
+
+		io.Copy(dst, src)
+		if cw, ok := dst.(closeWriter); ok {
+			cw.CloseWrite()
+		}
+	}
+
+	wg.Add(2)
+	go copyAndClose(a, b)
+	go copyAndClose(b, a)
+	wg.Wait()
+}
+
+// BufferedConn is a connection whose reads go through a buffered reader,
+// e.g. one which already consumed an HTTP response from the connection.
+type BufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// NewBufferedConn returns a connection reading from r, which must read from
+// conn.
+func NewBufferedConn(conn net.Conn, r *bufio.Reader) *BufferedConn {
+	return &BufferedConn{Conn: conn, r: r}
+}
+
+func (c *BufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// CloseWrite closes the write side of the connection, when supported.
+func (c *BufferedConn) CloseWrite() error {
+	if cw, ok := c.Conn.(closeWriter); ok {
+		return cw.CloseWrite()
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package utils
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// echoServer replies to each connection with what it received, once the
+// client closed its write side.
+func echoServer(t *testing.T) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				data, _ := ioutil.ReadAll(conn)
+				conn.Write(data)
+			}()
+		}
+	}()
+
+	return l
+}
+
+func TestTunnel(t *testing.T) {
+	assert := assert.New(t)
+
+	echo := echoServer(t)
+	defer echo.Close()
+
+	front, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer front.Close()
+
+	go func() {
+		client, err := front.Accept()
+		if err != nil {
+			return
+		}
+		defer client.Close()
+
+		backend, err := net.Dial("tcp", echo.Addr().String())
+		if err != nil {
+			return
+		}
+		defer backend.Close()
+
+		Tunnel(client, NewBufferedConn(backend, bufio.NewReader(backend)))
+	}()
+
+	conn, err := net.Dial("tcp", front.Addr().String())
+	assert.NoError(err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hello"))
+	assert.NoError(err)
+	assert.NoError(conn.(*net.TCPConn).CloseWrite())
+
+	// The half closed connection must still get the reply
+	data, err := ioutil.ReadAll(conn)
+	assert.NoError(err)
+	assert.Equal("hello", string(data))
+}
@@ -798,10 +798,44 @@ func (a *Acrn) loadInfo() error {
 	return nil
 }
 
+func (a *Acrn) setVMMCgroup(path string) bool {
+	return false
+}
+
 func (a *Acrn) isRateLimiterBuiltin() bool {
 	return false
 }
 
+func (a *Acrn) dumpGuestMemory(dumpSavePath string, encryptionKey []byte) (string, string, error) {
+	return "", "", errors.New("acrn does not support guest memory dump")
+}
+
+func (a *Acrn) prepareMigrationIncoming(ctx context.Context, uri string) error {
+	return errors.New("acrn does not support live migration")
+}
+
+func (a *Acrn) startMigration(ctx context.Context, uri string) error {
+	return errors.New("acrn does not support live migration")
+}
+
+func (a *Acrn) migrationStatus(ctx context.Context) (string, error) {
+	return "", errors.New("acrn does not support live migration")
+}
+
+// guestMemoryStats returns no statistics, the VM has no virtio-balloon
+// device.
+func (a *Acrn) guestMemoryStats(ctx context.Context) (GuestMemoryStats, error) {
+	return GuestMemoryStats{}, nil
+}
+
+func (a *Acrn) migrationSwitchover(ctx context.Context) error {
+	return errors.New("acrn does not support live migration")
+}
+
+func (a *Acrn) resizeBlockDevice(ctx context.Context, drive *config.BlockDrive, size uint64) error {
+	return errors.New("acrn does not support resizing block devices")
+}
+
 func (a *Acrn) setSandbox(sandbox *Sandbox) {
 	a.sandbox = sandbox
 }
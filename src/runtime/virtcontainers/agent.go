@@ -6,6 +6,8 @@
 package virtcontainers
 
 import (
+	"io"
+	"net"
 	"syscall"
 	"time"
 
@@ -207,4 +209,31 @@ type agent interface {
 
 	// getAgentMetrics get metrics of agent and guest through agent
 	getAgentMetrics(context.Context, *grpc.GetMetricsRequest) (*grpc.Metrics, error)
+
+	// resizeGuestVolume grows the filesystem of a block volume in the guest
+	resizeGuestVolume(ctx context.Context, guestPath string, size uint64) error
+
+	// getGuestDiagnostics returns a snapshot of the state of the guest OS
+	getGuestDiagnostics(context.Context, *grpc.GuestDiagnosticsRequest) (*grpc.GuestDiagnostics, error)
+
+	// portForward connects to a TCP port of the guest through the agent
+	portForward(ctx context.Context, port uint32) (net.Conn, error)
+
+	// policyDecisions streams the agent policy decisions
+	policyDecisions(ctx context.Context) (net.Conn, error)
+
+	// captureNetwork streams a pcap capture of a guest network device
+	captureNetwork(ctx context.Context, device string, duration time.Duration, snapLen uint32) (net.Conn, error)
+
+	// remountSharedFS mounts the shared directory in the guest again
+	remountSharedFS(ctx context.Context, options []string) error
+
+	// syncFilesystems writes the dirty data of the guest file systems
+	syncFilesystems(ctx context.Context) error
+
+	// checkpointContainer streams the CRIU images of the container processes
+	checkpointContainer(ctx context.Context, containerID string, leaveRunning bool) (net.Conn, error)
+
+	// restoreContainer restores the container processes from CRIU images
+	restoreContainer(ctx context.Context, containerID string, images io.Reader, size int64) error
 }
@@ -0,0 +1,193 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/sirupsen/logrus"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+)
+
+// The agent API versions introducing the features the runtime negotiates,
+// the agents with an older API version are used without them.
+var (
+	// agentPolicyAPIVersion introduces the policies allowing or denying
+	// the agent requests.
+	agentPolicyAPIVersion = semver.MustParse("0.1.0")
+
+	// agentImagePullAPIVersion introduces the formatting of the guest
+	// image store the images are pulled to.
+	agentImagePullAPIVersion = semver.MustParse("0.1.0")
+
+	// agentUnifiedCgroupAPIVersion introduces the unified resources, the
+	// cgroup v2 interface files of the containers.
+	agentUnifiedCgroupAPIVersion = semver.MustParse("0.2.0")
+
+	// agentDeviceCgroupAPIVersion introduces the device cgroup rules of the
+	// containers, enforced with cgroup v1 and v2.
+	agentDeviceCgroupAPIVersion = semver.MustParse("0.3.0")
+)
+
+// agentCapabilities are the features of the guest agent, negotiated when
+// the sandbox starts and kept in the sandbox persisted state.
+type agentCapabilities struct {
+	// APIVersion is the API version reported by the agent, empty when
+	// it could not be found.
+	APIVersion string
+
+	// Seccomp is set when the agent applies the seccomp profile of the
+	// containers.
+	Seccomp bool
+
+	// Policy is set when the agent enforces or audits a policy.
+	Policy bool
+
+	// ImagePull is set when the agent formats the guest image store.
+	ImagePull bool
+
+	// UnifiedCgroup is set when the agent applies the unified resources
+	// of the containers.
+	UnifiedCgroup bool
+
+	// DeviceCgroup is set when the agent enforces the device cgroup rules
+	// of the containers.
+	DeviceCgroup bool
+}
+
+// newAgentCapabilities returns the capabilities of an agent with the API
+// version apiVersion and the details details, either of which may be
+// missing with older agents.
+func newAgentCapabilities(apiVersion string, details *grpc.AgentDetails) (agentCapabilities, error) {
+	caps := agentCapabilities{
+		APIVersion: apiVersion,
+	}
+
+	if details != nil {
+		caps.Seccomp = details.SupportsSeccomp
+	}
+
+	if apiVersion == "" {
+		return caps, nil
+	}
+
+	version, err := semver.ParseTolerant(apiVersion)
+	if err != nil {
+		return caps, fmt.Errorf("malformed agent API version %q: %v", apiVersion, err)
+	}
+
+	runtimeVersion := semver.MustParse(grpc.APIVersion)
+	if version.Major > runtimeVersion.Major {
+		return caps, fmt.Errorf("agent API version %s is not supported, the runtime API version is %s", version, runtimeVersion)
+	}
+
+	caps.Policy = version.GTE(agentPolicyAPIVersion)
+	caps.ImagePull = version.GTE(agentImagePullAPIVersion)
+	caps.UnifiedCgroup = version.GTE(agentUnifiedCgroupAPIVersion)
+	caps.DeviceCgroup = version.GTE(agentDeviceCgroupAPIVersion)
+
+	return caps, nil
+}
+
+// agentAPIVersion returns the API version of the agent.
+// The version request is special, it has the same type as the check one.
+func (k *kataAgent) agentAPIVersion(ctx context.Context) (string, error) {
+	if err := k.connect(ctx); err != nil {
+		return "", err
+	}
+	if !k.keepConn {
+		defer k.disconnect(ctx)
+	}
+
+	ctx, cancel := k.getReqContext(ctx, grpcCheckRequest)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	resp, err := k.client.HealthClient.Version(ctx, &grpc.CheckRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GrpcVersion, nil
+}
+
+// negotiateCapabilities finds the capabilities of the agent and disables
+// the features of the sandbox the agent lacks. It fails when a feature
+// cannot be done without, i.e. a policy to enforce.
+func (k *kataAgent) negotiateCapabilities(ctx context.Context, sandbox *Sandbox) error {
+	apiVersion, err := k.agentAPIVersion(ctx)
+	if err != nil {
+		k.Logger().WithError(err).Warn("failed to get the agent API version, assuming an old agent")
+	}
+
+	var details *grpc.AgentDetails
+	if resp, err := k.getGuestDetails(ctx, &grpc.GuestDetailsRequest{}); err != nil {
+		k.Logger().WithError(err).Warn("failed to get the agent details, assuming an old agent")
+	} else {
+		details = resp.AgentDetails
+	}
+
+	caps, err := newAgentCapabilities(apiVersion, details)
+	if err != nil {
+		return err
+	}
+
+	return k.setCapabilities(sandbox, caps)
+}
+
+// setCapabilities sets the capabilities of the agent and disables the
+// features of the sandbox the agent lacks.
+func (k *kataAgent) setCapabilities(sandbox *Sandbox, caps agentCapabilities) error {
+	k.caps = caps
+
+	k.Logger().WithFields(logrus.Fields{
+		"api-version": caps.APIVersion,
+		"seccomp":     caps.Seccomp,
+		"policy":      caps.Policy,
+		"image-pull":  caps.ImagePull,
+		"unified":     caps.UnifiedCgroup,
+		"devices":     caps.DeviceCgroup,
+	}).Info("negotiated agent capabilities")
+
+	if k.policyEnabled && !caps.Policy {
+		if !sandbox.config.AgentConfig.PolicyAudit {
+			return fmt.Errorf("the agent does not support policies, refusing to run the sandbox without its policy")
+		}
+		k.Logger().Warn("the agent does not support policies, the policy is not audited")
+		k.policyEnabled = false
+	}
+
+	if sandbox.config.HypervisorConfig.GuestImageStoreSize != 0 && !caps.ImagePull {
+		k.Logger().Warn("the agent does not support the guest image store, the images are pulled to the guest memory")
+	}
+
+	return nil
+}
+
+func (caps agentCapabilities) save() persistapi.AgentCapabilities {
+	return persistapi.AgentCapabilities{
+		APIVersion:    caps.APIVersion,
+		Seccomp:       caps.Seccomp,
+		Policy:        caps.Policy,
+		ImagePull:     caps.ImagePull,
+		UnifiedCgroup: caps.UnifiedCgroup,
+		DeviceCgroup:  caps.DeviceCgroup,
+	}
+}
+
+func (caps *agentCapabilities) load(s persistapi.AgentCapabilities) {
+	caps.APIVersion = s.APIVersion
+	caps.Seccomp = s.Seccomp
+	caps.Policy = s.Policy
+	caps.ImagePull = s.ImagePull
+	caps.UnifiedCgroup = s.UnifiedCgroup
+	caps.DeviceCgroup = s.DeviceCgroup
+}
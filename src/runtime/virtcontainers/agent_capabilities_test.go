@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/mock"
+)
+
+func TestNewAgentCapabilities(t *testing.T) {
+	assert := assert.New(t)
+
+	// Old agents without API version nor details
+	caps, err := newAgentCapabilities("", nil)
+	assert.NoError(err)
+	assert.Equal(agentCapabilities{}, caps)
+
+	caps, err = newAgentCapabilities("", &grpc.AgentDetails{SupportsSeccomp: true})
+	assert.NoError(err)
+	assert.Equal(agentCapabilities{Seccomp: true}, caps)
+
+	caps, err = newAgentCapabilities("0.0.1", &grpc.AgentDetails{})
+	assert.NoError(err)
+	assert.Equal(agentCapabilities{APIVersion: "0.0.1"}, caps)
+
+	caps, err = newAgentCapabilities(grpc.APIVersion, &grpc.AgentDetails{})
+	assert.NoError(err)
+	assert.True(caps.Policy)
+	assert.True(caps.ImagePull)
+	assert.True(caps.UnifiedCgroup)
+	assert.True(caps.DeviceCgroup)
+	assert.False(caps.Seccomp)
+
+	caps, err = newAgentCapabilities("0.1.0", nil)
+	assert.NoError(err)
+	assert.True(caps.Policy)
+	assert.False(caps.UnifiedCgroup)
+
+	caps, err = newAgentCapabilities("0.2.0", nil)
+	assert.NoError(err)
+	assert.True(caps.UnifiedCgroup)
+	assert.False(caps.DeviceCgroup)
+
+	// Newer minor versions keep the features
+	caps, err = newAgentCapabilities("0.42.0", nil)
+	assert.NoError(err)
+	assert.True(caps.Policy)
+	assert.True(caps.ImagePull)
+
+	_, err = newAgentCapabilities("foo", nil)
+	assert.Error(err)
+
+	_, err = newAgentCapabilities("1.0.0", nil)
+	assert.Error(err)
+}
+
+func TestKataAgentNegotiateCapabilities(t *testing.T) {
+	assert := assert.New(t)
+
+	url, err := mock.GenerateKataMockHybridVSock()
+	assert.NoError(err)
+
+	hybridVSockTTRPCMock := mock.HybridVSockTTRPCMock{}
+	err = hybridVSockTTRPCMock.Start(url)
+	assert.NoError(err)
+	defer hybridVSockTTRPCMock.Stop()
+
+	k := &kataAgent{
+		ctx: context.Background(),
+		state: KataAgentState{
+			URL: url,
+		},
+		policyEnabled: true,
+	}
+
+	sandbox := &Sandbox{
+		config: &SandboxConfig{},
+	}
+
+	err = k.negotiateCapabilities(context.Background(), sandbox)
+	assert.NoError(err)
+	assert.Equal(grpc.APIVersion, k.caps.APIVersion)
+	assert.True(k.caps.Policy)
+	assert.True(k.policyEnabled)
+
+	// The capabilities are kept in the persisted state
+	k2 := &kataAgent{}
+	k2.load(k.save())
+	assert.Equal(k.caps, k2.caps)
+
+	// Without policy support, an enforced policy is refused while an
+	// audited one is dropped.
+	caps, err := newAgentCapabilities("0.0.1", nil)
+	assert.NoError(err)
+
+	err = k.setCapabilities(sandbox, caps)
+	assert.Error(err)
+
+	sandbox.config.AgentConfig.PolicyAudit = true
+	err = k.setCapabilities(sandbox, caps)
+	assert.NoError(err)
+	assert.False(k.policyEnabled)
+	assert.Equal(caps, k.caps)
+}
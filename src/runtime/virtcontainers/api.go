@@ -15,6 +15,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/compatoci"
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -53,6 +54,43 @@ func CreateSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Fac
 	return s, err
 }
 
+// ProbeSandbox boots a throwaway sandbox from sandboxConfig, to check that
+// the node can run sandboxes end to end, and returns its boot times. The
+// sandbox has no container, network nor sandbox cgroup, and is stopped and
+// deleted before returning.
+func ProbeSandbox(ctx context.Context, sandboxConfig SandboxConfig) (_ BootTimes, err error) {
+	span, ctx := katatrace.Trace(ctx, virtLog, "ProbeSandbox", apiTracingTags)
+	defer span.End()
+
+	if sandboxConfig.ID == "" {
+		sandboxConfig.ID = uuid.Generate().String()
+	}
+	sandboxConfig.Containers = nil
+	sandboxConfig.NetworkConfig = NetworkConfig{}
+	sandboxConfig.SandboxCgroupOnly = false
+
+	s, err := createSandboxFromConfig(ctx, sandboxConfig, nil)
+	if err != nil {
+		return BootTimes{}, err
+	}
+
+	bootTimes := s.BootTimes()
+
+	defer func() {
+		if deleteErr := s.Delete(ctx); deleteErr != nil && err == nil {
+			err = deleteErr
+		}
+	}()
+
+	if err := s.Stop(ctx, false); err != nil {
+		// Do not leave the VM behind.
+		s.Stop(ctx, true)
+		return bootTimes, err
+	}
+
+	return bootTimes, nil
+}
+
 func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, factory Factory) (_ *Sandbox, err error) {
 	span, ctx := katatrace.Trace(ctx, virtLog, "createSandboxFromConfig", apiTracingTags)
 	defer span.End()
@@ -93,6 +131,11 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 		}
 	}
 
+	// Cold plug the devices, if they cannot be hot plugged
+	if err = s.coldPlugDevices(ctx); err != nil {
+		return nil, err
+	}
+
 	// Start the VM
 	if err = s.startVM(ctx); err != nil {
 		return nil, err
@@ -107,10 +150,29 @@ func createSandboxFromConfig(ctx context.Context, sandboxConfig SandboxConfig, f
 
 	s.postCreatedNetwork(ctx)
 
+	// The guest, including the containers, comes with the incoming live
+	// migration, the containers are restored by MigrationSwitchover.
+	if s.config.HypervisorConfig.MigrationIncoming {
+		s.setAgentAway(true)
+		return s, nil
+	}
+
 	if err = s.getAndStoreGuestDetails(ctx); err != nil {
 		return nil, err
 	}
 
+	if err = s.attachVFIOAPDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = s.attachVFIOBindDevices(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = s.attachVFIOMdevDevices(ctx); err != nil {
+		return nil, err
+	}
+
 	// Create Containers
 	if err = s.createContainers(ctx); err != nil {
 		return nil, err
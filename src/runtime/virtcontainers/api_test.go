@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
 	vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/mock"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/rootless"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -180,6 +182,38 @@ func TestCreateSandboxKataAgentSuccessful(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestCreateSandboxMigrationIncoming(t *testing.T) {
+	defer cleanUp()
+	assert := assert.New(t)
+
+	config := newTestSandboxConfigNoop()
+	config.HypervisorConfig.MigrationIncoming = true
+
+	ctx := WithNewAgentFunc(context.Background(), newMockAgent)
+	p, err := CreateSandbox(ctx, config, nil)
+	assert.NoError(err)
+	s := p.(*Sandbox)
+
+	// the containers come with the migration
+	assert.Empty(s.containers)
+	assert.True(s.isAgentAway())
+	assert.Error(s.Start(ctx))
+
+	assert.Error(s.MigrationPrepareReceive(ctx, ""))
+	assert.NoError(s.MigrationPrepareReceive(ctx, "tcp:0:4444"))
+	assert.NoError(s.MigrationSwitchover(ctx))
+	assert.False(s.isAgentAway())
+
+	c, ok := s.containers[containerID]
+	assert.True(ok)
+	assert.Equal(types.StateRunning, c.state.State)
+	assert.Equal(containerID, c.process.Token)
+
+	// the migrated containers are not started again
+	assert.NoError(s.Start(ctx))
+	assert.Equal(types.StateRunning, s.state.State)
+}
+
 func TestCreateSandboxFailing(t *testing.T) {
 	defer cleanUp()
 	assert := assert.New(t)
@@ -253,6 +287,30 @@ func TestReleaseSandbox(t *testing.T) {
 	assert.Nil(t, err, "sandbox release failed: %v", err)
 }
 
+func TestProbeSandbox(t *testing.T) {
+	defer cleanUp()
+	assert := assert.New(t)
+
+	config := newTestSandboxConfigNoop()
+	config.ID = ""
+
+	ctx := WithNewAgentFunc(context.Background(), newMockAgent)
+	_, err := ProbeSandbox(ctx, config)
+	assert.NoError(err)
+
+	// The probe sandbox is deleted
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+	entries, err := ioutil.ReadDir(store.RunStoragePath())
+	if err == nil {
+		assert.Empty(entries)
+	}
+
+	config.HypervisorConfig.KernelPath = ""
+	_, err = ProbeSandbox(ctx, config)
+	assert.Error(err)
+}
+
 func TestCleanupContainer(t *testing.T) {
 	config := newTestSandboxConfigNoop()
 	assert := assert.New(t)
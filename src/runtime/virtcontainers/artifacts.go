@@ -0,0 +1,207 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// artifactsManifestSignatureSuffix is appended to the path of the artifacts
+// manifest to get the one of its detached signature.
+const artifactsManifestSignatureSuffix = ".sig"
+
+// artifactsManifest maps the paths of the guest artifacts to their sha256
+// digests.
+type artifactsManifest map[string]string
+
+// parseArtifactsManifest parses a sha256sum(1) file, the relative paths
+// being relative to the directory of the manifest.
+func parseArtifactsManifest(path string, data []byte) (artifactsManifest, error) {
+	manifest := make(artifactsManifest)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line %q in artifacts manifest %s", line, path)
+		}
+
+		digest := strings.ToLower(fields[0])
+		if b, err := hex.DecodeString(digest); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("invalid sha256 digest %q in artifacts manifest %s", fields[0], path)
+		}
+
+		// The binary mode marker of sha256sum(1)
+		name := strings.TrimPrefix(fields[1], "*")
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(filepath.Dir(path), name)
+		}
+		manifest[filepath.Clean(name)] = digest
+	}
+
+	return manifest, scanner.Err()
+}
+
+// verifyArtifactsManifestSignature verifies the detached ed25519 signature
+// of the artifacts manifest with the PEM encoded public key of keyPath.
+func verifyArtifactsManifestSignature(path string, data []byte, keyPath string) error {
+	keyData, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+
+	block, _ := pem.Decode(keyData)
+	if block == nil {
+		return fmt.Errorf("no PEM encoded public key in %s", keyPath)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid public key %s: %v", keyPath, err)
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("public key %s is a %T, not an ed25519 one", keyPath, key)
+	}
+
+	signature, err := ioutil.ReadFile(path + artifactsManifestSignatureSuffix)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(publicKey, data, signature) {
+		return fmt.Errorf("invalid signature of artifacts manifest %s", path)
+	}
+
+	return nil
+}
+
+// sha256File returns the hex encoded sha256 digest of a file.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verify checks that the artifact of path, or the file it links to, is
+// listed by the manifest with its sha256 digest.
+func (m artifactsManifest) verify(path string) error {
+	digest, ok := m[filepath.Clean(path)]
+	if !ok {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return err
+		}
+		if digest, ok = m[resolved]; !ok {
+			return fmt.Errorf("artifact %s is not listed in the manifest", path)
+		}
+	}
+
+	actual, err := sha256File(path)
+	if err != nil {
+		return err
+	}
+
+	if actual != digest {
+		return fmt.Errorf("artifact %s has sha256 digest %s, the manifest expects %s", path, actual, digest)
+	}
+
+	return nil
+}
+
+// verifyArtifacts verifies the guest kernel, initrd, image and firmware
+// against the artifacts manifest, after verifying the signature of the
+// manifest when a public key is configured. The confidential guests require
+// a signed manifest.
+func (conf *HypervisorConfig) verifyArtifacts() error {
+	if conf.ArtifactsManifest == "" {
+		return nil
+	}
+
+	if conf.ArtifactsManifestPublicKey == "" && conf.ConfidentialGuest {
+		return errors.New("the artifacts manifest of a confidential guest must be signed: no public key configured")
+	}
+
+	data, err := ioutil.ReadFile(conf.ArtifactsManifest)
+	if err != nil {
+		return err
+	}
+
+	if conf.ArtifactsManifestPublicKey != "" {
+		if err := verifyArtifactsManifestSignature(conf.ArtifactsManifest, data, conf.ArtifactsManifestPublicKey); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := parseArtifactsManifest(conf.ArtifactsManifest, data)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range []string{conf.KernelPath, conf.InitrdPath, conf.ImagePath, conf.FirmwarePath} {
+		if path == "" {
+			continue
+		}
+
+		if err := manifest.verify(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkGuestArtifacts verifies the guest artifacts before launching a VM. A
+// failure only gets logged, unless the guest is a confidential one: it then
+// fails closed, the guest could otherwise be booted with tampered artifacts.
+func checkGuestArtifacts(conf *HypervisorConfig, logger *logrus.Entry) error {
+	if conf.ArtifactsManifest == "" {
+		return nil
+	}
+
+	logger = logger.WithField("manifest", conf.ArtifactsManifest)
+	err := conf.verifyArtifacts()
+	if err == nil {
+		logger.Info("Guest artifacts verified")
+		return nil
+	}
+
+	if conf.ConfidentialGuest {
+		return fmt.Errorf("failed to verify the guest artifacts: %v", err)
+	}
+
+	logger.WithError(err).Warn("Failed to verify the guest artifacts")
+	return nil
+}
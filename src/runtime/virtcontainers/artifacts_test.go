@@ -0,0 +1,112 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArtifactsManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := hex.EncodeToString(make([]byte, sha256.Size))
+	manifest, err := parseArtifactsManifest("/opt/kata/share/kata-containers/artifacts.sha256", []byte(fmt.Sprintf(
+		"# kata-deploy artifacts\n%s  vmlinux.container\n%s *./images/kata-containers.img\n\n%s  /usr/share/ovmf/OVMF.fd\n",
+		digest, digest, digest)))
+	assert.NoError(err)
+	assert.Equal(artifactsManifest{
+		"/opt/kata/share/kata-containers/vmlinux.container":          digest,
+		"/opt/kata/share/kata-containers/images/kata-containers.img": digest,
+		"/usr/share/ovmf/OVMF.fd":                                    digest,
+	}, manifest)
+
+	for _, data := range []string{
+		"vmlinux.container",
+		digest + "  vmlinux container",
+		"0123  vmlinux.container",
+		digest[1:] + "g  vmlinux.container",
+	} {
+		_, err := parseArtifactsManifest("artifacts.sha256", []byte(data))
+		assert.Error(err, data)
+	}
+}
+
+func TestVerifyArtifacts(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "artifacts")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// The kernel and the image link, the image is listed with its target.
+	var manifest string
+	for _, name := range []string{"vmlinux", "kata-containers-image.img"} {
+		data := []byte("the " + name + " artifact")
+		assert.NoError(ioutil.WriteFile(filepath.Join(dir, name), data, 0644))
+		manifest += fmt.Sprintf("%x  %s\n", sha256.Sum256(data), name)
+	}
+	assert.NoError(os.Symlink("kata-containers-image.img", filepath.Join(dir, "kata-containers.img")))
+
+	manifestPath := filepath.Join(dir, "artifacts.sha256")
+	assert.NoError(ioutil.WriteFile(manifestPath, []byte(manifest), 0644))
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(err)
+	keyPath := filepath.Join(dir, "artifacts.sha256.pub")
+	assert.NoError(ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+
+	config := &HypervisorConfig{
+		KernelPath:        filepath.Join(dir, "vmlinux"),
+		ImagePath:         filepath.Join(dir, "kata-containers.img"),
+		ArtifactsManifest: manifestPath,
+	}
+	assert.NoError(config.verifyArtifacts())
+
+	// The confidential guests require a signed manifest
+	config.ConfidentialGuest = true
+	assert.Error(config.verifyArtifacts())
+
+	config.ArtifactsManifestPublicKey = keyPath
+	assert.Error(config.verifyArtifacts())
+
+	signaturePath := manifestPath + artifactsManifestSignatureSuffix
+	assert.NoError(ioutil.WriteFile(signaturePath, ed25519.Sign(privateKey, []byte(manifest)), 0644))
+	assert.NoError(config.verifyArtifacts())
+
+	// A tampered manifest
+	assert.NoError(ioutil.WriteFile(manifestPath, []byte(manifest+manifest), 0644))
+	assert.Error(config.verifyArtifacts())
+	assert.NoError(ioutil.WriteFile(manifestPath, []byte(manifest), 0644))
+
+	// An artifact missing from the manifest
+	config.FirmwarePath = keyPath
+	assert.Error(config.verifyArtifacts())
+	config.FirmwarePath = ""
+
+	// A tampered artifact
+	assert.NoError(ioutil.WriteFile(config.KernelPath, []byte("a tampered kernel"), 0644))
+	assert.Error(config.verifyArtifacts())
+
+	// Only the confidential guests fail closed
+	logger := virtLog.WithField("test", t.Name())
+	assert.Error(checkGuestArtifacts(config, logger))
+	config.ConfidentialGuest = false
+	assert.NoError(checkGuestArtifacts(config, logger))
+}
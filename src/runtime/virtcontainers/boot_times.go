@@ -0,0 +1,172 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+)
+
+// Sandbox boot phases
+const (
+	BootPhaseVMCreate      = "vm_create"
+	BootPhaseKernelBoot    = "kernel_boot"
+	BootPhaseAgentReady    = "agent_ready"
+	BootPhaseWorkloadStart = "workload_start"
+)
+
+// BootTimes is the breakdown of the time it took to boot a sandbox.
+type BootTimes struct {
+	// VMCreate is the time spent creating and launching the VM.
+	VMCreate time.Duration `json:"vm_create"`
+
+	// KernelBoot is the time from the VM launch to the agent answering.
+	KernelBoot time.Duration `json:"kernel_boot"`
+
+	// AgentReady is the time spent by the agent setting up the sandbox.
+	AgentReady time.Duration `json:"agent_ready"`
+
+	// WorkloadStart is the time from the agent being ready to the first
+	// container being started.
+	WorkloadStart time.Duration `json:"workload_start"`
+}
+
+// Phases returns the duration of each boot phase, indexed by phase name.
+func (b BootTimes) Phases() map[string]time.Duration {
+	return map[string]time.Duration{
+		BootPhaseVMCreate:      b.VMCreate,
+		BootPhaseKernelBoot:    b.KernelBoot,
+		BootPhaseAgentReady:    b.AgentReady,
+		BootPhaseWorkloadStart: b.WorkloadStart,
+	}
+}
+
+// Total returns the sum of all the boot phases.
+func (b BootTimes) Total() time.Duration {
+	return b.VMCreate + b.KernelBoot + b.AgentReady + b.WorkloadStart
+}
+
+// bootTimer measures the sandbox boot phases with the monotonic clock. A
+// phase lasts from the previous reset or mark to its own mark. Once the
+// workload is started the timer is done and further marks are ignored, so
+// that restarting a container does not change the boot times. All methods
+// are no-ops on a nil timer.
+type bootTimer struct {
+	sync.Mutex
+	times BootTimes
+	last  time.Time
+	done  bool
+}
+
+// reset starts measuring a new interval.
+func (t *bootTimer) reset() {
+	if t == nil {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.last = time.Now()
+}
+
+// mark adds the time elapsed since the last reset or mark to the phase.
+func (t *bootTimer) mark(phase string) {
+	if t == nil {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if t.done || t.last.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(t.last)
+	t.last = now
+
+	switch phase {
+	case BootPhaseVMCreate:
+		t.times.VMCreate += elapsed
+	case BootPhaseKernelBoot:
+		t.times.KernelBoot += elapsed
+	case BootPhaseAgentReady:
+		t.times.AgentReady += elapsed
+	case BootPhaseWorkloadStart:
+		t.times.WorkloadStart += elapsed
+		t.done = true
+	}
+}
+
+func (t *bootTimer) get() BootTimes {
+	if t == nil {
+		return BootTimes{}
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	return t.times
+}
+
+func (t *bootTimer) load(bt persistapi.BootTimes) {
+	if t == nil {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	t.times = BootTimes{
+		VMCreate:      bt.VMCreate,
+		KernelBoot:    bt.KernelBoot,
+		AgentReady:    bt.AgentReady,
+		WorkloadStart: bt.WorkloadStart,
+	}
+	// The boot of a restored sandbox is over.
+	t.done = true
+}
+
+func (t *bootTimer) dump() persistapi.BootTimes {
+	times := t.get()
+
+	return persistapi.BootTimes{
+		VMCreate:      times.VMCreate,
+		KernelBoot:    times.KernelBoot,
+		AgentReady:    times.AgentReady,
+		WorkloadStart: times.WorkloadStart,
+	}
+}
+
+// BootTimes returns the boot time breakdown of the sandbox.
+func (s *Sandbox) BootTimes() BootTimes {
+	return s.boot.get()
+}
+
+// ReadSandboxBootTimes returns the boot time breakdown of a sandbox, as
+// saved in its persist directory.
+func ReadSandboxBootTimes(sandboxID string) (BootTimes, error) {
+	store, err := persist.GetDriver()
+	if err != nil || store == nil {
+		return BootTimes{}, errors.New("failed to get fs persist driver")
+	}
+
+	ss, _, err := store.FromDisk(sandboxID)
+	if err != nil {
+		return BootTimes{}, err
+	}
+
+	t := &bootTimer{}
+	t.load(ss.BootTimes)
+
+	return t.get(), nil
+}
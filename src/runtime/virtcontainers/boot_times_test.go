@@ -0,0 +1,75 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+	"time"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootTimer(t *testing.T) {
+	assert := assert.New(t)
+
+	var nilTimer *bootTimer
+	nilTimer.reset()
+	nilTimer.mark(BootPhaseVMCreate)
+	assert.Equal(BootTimes{}, nilTimer.get())
+
+	timer := &bootTimer{}
+
+	// No mark is taken before the first reset
+	timer.mark(BootPhaseVMCreate)
+	assert.Equal(BootTimes{}, timer.get())
+
+	timer.reset()
+	time.Sleep(time.Millisecond)
+	timer.mark(BootPhaseVMCreate)
+	vmCreate := timer.get().VMCreate
+	assert.True(vmCreate >= time.Millisecond)
+
+	// VM create is measured over several intervals
+	timer.reset()
+	time.Sleep(time.Millisecond)
+	timer.mark(BootPhaseVMCreate)
+	assert.True(timer.get().VMCreate >= vmCreate+time.Millisecond)
+
+	timer.mark(BootPhaseKernelBoot)
+	timer.mark(BootPhaseAgentReady)
+	timer.mark(BootPhaseWorkloadStart)
+
+	times := timer.get()
+	assert.Equal(times.VMCreate+times.KernelBoot+times.AgentReady+times.WorkloadStart, times.Total())
+	assert.Len(times.Phases(), 4)
+
+	// The timer is done once the workload is started
+	time.Sleep(time.Millisecond)
+	timer.mark(BootPhaseWorkloadStart)
+	assert.Equal(times, timer.get())
+}
+
+func TestBootTimerPersist(t *testing.T) {
+	assert := assert.New(t)
+
+	bt := persistapi.BootTimes{
+		VMCreate:      time.Second,
+		KernelBoot:    2 * time.Second,
+		AgentReady:    3 * time.Second,
+		WorkloadStart: 4 * time.Second,
+	}
+
+	timer := &bootTimer{}
+	timer.load(bt)
+	assert.Equal(bt, timer.dump())
+	assert.Equal(10*time.Second, timer.get().Total())
+
+	// A restored sandbox does not measure its boot again
+	timer.reset()
+	timer.mark(BootPhaseVMCreate)
+	assert.Equal(bt, timer.dump())
+}
@@ -9,12 +9,16 @@ package virtcontainers
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/containerd/cgroups"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 )
 
 type cgroupPather interface {
@@ -30,6 +34,43 @@ const cgroupKataPath = "/kata/"
 var cgroupsLoadFunc = cgroups.Load
 var cgroupsNewFunc = cgroups.New
 
+// child cgroups of the sandbox cgroup for the hypervisor threads
+const (
+	threadGroupEmulator = "emulator"
+	threadGroupVCPU     = "vcpu"
+	threadGroupIOThread = "iothread"
+)
+
+var procTaskPath = "/proc/%d/task"
+
+// The mechanisms placing the VMM in its cgroup
+const (
+	// VMMCgroupClone starts the VMM directly in the sandbox cgroup, with
+	// clone3(CLONE_INTO_CGROUP).
+	VMMCgroupClone = "clone-into-cgroup"
+	// VMMCgroupInherit starts the VMM in the sandbox cgroup the runtime
+	// joined beforehand.
+	VMMCgroupInherit = "inherit-runtime-cgroup"
+	// VMMCgroupMigration moves the VMM, found through its pid file, into
+	// its cgroup once started.
+	VMMCgroupMigration = "cgroup-migration"
+)
+
+// VMMCgroupMechanism returns the mechanism placing the VMM of a sandbox in
+// its cgroup on this host. The systemd cgroup paths fall back from
+// VMMCgroupClone to VMMCgroupInherit.
+func VMMCgroupMechanism(hType HypervisorType, sandboxCgroupOnly bool) string {
+	if !sandboxCgroupOnly {
+		return VMMCgroupMigration
+	}
+
+	if hType == QemuHypervisor && vccgroups.CloneIntoCgroupSupported() {
+		return VMMCgroupClone
+	}
+
+	return VMMCgroupInherit
+}
+
 // V1Constraints returns the cgroups that are compatible with the VC architecture
 // and hypervisor, constraints can be applied to these cgroups.
 func V1Constraints() ([]cgroups.Subsystem, error) {
@@ -165,3 +206,40 @@ func validCPUResources(cpuSpec *specs.LinuxCPU) *specs.LinuxCPU {
 
 	return &cpu
 }
+
+// hypervisorThreadGroups returns the threads of the hypervisor process pid
+// indexed by thread group. The emulator group holds all the threads that are
+// neither vCPU nor iothreads.
+func hypervisorThreadGroups(pid int, tids vcpuThreadIDs) (map[string][]int, error) {
+	entries, err := ioutil.ReadDir(fmt.Sprintf(procTaskPath, pid))
+	if err != nil {
+		return nil, fmt.Errorf("Could not list threads of hypervisor PID %d: %v", pid, err)
+	}
+
+	groups := map[string][]int{
+		threadGroupEmulator: {},
+		threadGroupVCPU:     {},
+		threadGroupIOThread: {},
+	}
+	known := make(map[int]bool)
+
+	for _, tid := range tids.vcpus {
+		groups[threadGroupVCPU] = append(groups[threadGroupVCPU], tid)
+		known[tid] = true
+	}
+
+	for _, tid := range tids.ioThreads {
+		groups[threadGroupIOThread] = append(groups[threadGroupIOThread], tid)
+		known[tid] = true
+	}
+
+	for _, e := range entries {
+		tid, err := strconv.Atoi(e.Name())
+		if err != nil || known[tid] {
+			continue
+		}
+		groups[threadGroupEmulator] = append(groups[threadGroupEmulator], tid)
+	}
+
+	return groups, nil
+}
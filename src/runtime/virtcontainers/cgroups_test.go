@@ -8,6 +8,7 @@ package virtcontainers
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/containerd/cgroups"
 	cgroupsstatsv1 "github.com/containerd/cgroups/stats/v1"
+	vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
@@ -120,6 +122,24 @@ func TestCgroupNoConstraintsPath(t *testing.T) {
 	assert.Equal(expectedPath, path)
 }
 
+func TestVMMCgroupMechanism(t *testing.T) {
+	assert := assert.New(t)
+
+	savedCloneIntoCgroupSupported := vccgroups.CloneIntoCgroupSupported
+	defer func() {
+		vccgroups.CloneIntoCgroupSupported = savedCloneIntoCgroupSupported
+	}()
+
+	vccgroups.CloneIntoCgroupSupported = func() bool { return true }
+	assert.Equal(VMMCgroupClone, VMMCgroupMechanism(QemuHypervisor, true))
+	assert.Equal(VMMCgroupInherit, VMMCgroupMechanism(ClhHypervisor, true))
+	assert.Equal(VMMCgroupMigration, VMMCgroupMechanism(QemuHypervisor, false))
+
+	vccgroups.CloneIntoCgroupSupported = func() bool { return false }
+	assert.Equal(VMMCgroupInherit, VMMCgroupMechanism(QemuHypervisor, true))
+	assert.Equal(VMMCgroupMigration, VMMCgroupMechanism(QemuHypervisor, false))
+}
+
 func TestUpdateCgroups(t *testing.T) {
 	assert := assert.New(t)
 
@@ -205,3 +225,35 @@ func TestUpdateCgroups(t *testing.T) {
 	err = s.cgroupsDelete()
 	assert.NoError(err)
 }
+
+func TestHypervisorThreadGroups(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "proc")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	savedProcTaskPath := procTaskPath
+	defer func() {
+		procTaskPath = savedProcTaskPath
+	}()
+	procTaskPath = filepath.Join(dir, "%d", "task")
+
+	for _, tid := range []string{"100", "101", "102", "103", "104"} {
+		assert.NoError(os.MkdirAll(filepath.Join(dir, "100", "task", tid), 0755))
+	}
+
+	tids := vcpuThreadIDs{
+		vcpus:     map[int]int{0: 102, 1: 103},
+		ioThreads: []int{104},
+	}
+
+	groups, err := hypervisorThreadGroups(100, tids)
+	assert.NoError(err)
+	assert.Equal([]int{100, 101}, groups[threadGroupEmulator])
+	assert.ElementsMatch([]int{102, 103}, groups[threadGroupVCPU])
+	assert.Equal([]int{104}, groups[threadGroupIOThread])
+
+	_, err = hypervisorThreadGroups(200, tids)
+	assert.Error(err)
+}
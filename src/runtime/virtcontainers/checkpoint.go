@@ -0,0 +1,83 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// CheckpointContainer dumps the processes of a running container with CRIU
+// in the guest, and writes the tar archive of the images to w. The container
+// is stopped by the checkpoint unless leaveRunning is set, e.g. for a
+// forensic dump.
+func (s *Sandbox) CheckpointContainer(ctx context.Context, containerID string, w io.Writer, leaveRunning bool) error {
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+
+	if c.state.State != types.StateRunning {
+		return fmt.Errorf("container %s is not running", containerID)
+	}
+
+	conn, err := s.agent.checkpointContainer(ctx, containerID, leaveRunning)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock the copy below if the caller gives up
+	checkpointCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-checkpointCtx.Done()
+		conn.Close()
+	}()
+
+	if _, err := io.Copy(w, conn); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	s.journal.record(JournalCheckpointed, containerID, "leave running: %v", leaveRunning)
+
+	return nil
+}
+
+// RestoreContainer restores the processes of a checkpoint, the tar archive
+// of its CRIU images being read from images, size bytes long. They are
+// restored in the root file system of a container of the sandbox whose
+// processes are gone, e.g. stopped by the checkpoint. The restored process
+// tree becomes the init process of the container in the agent, but its
+// output is not forwarded.
+func (s *Sandbox) RestoreContainer(ctx context.Context, containerID string, images io.Reader, size int64) error {
+	c, err := s.findContainer(containerID)
+	if err != nil {
+		return err
+	}
+
+	if c.state.State == types.StateRunning {
+		return fmt.Errorf("container %s is running", containerID)
+	}
+
+	if size <= 0 {
+		return fmt.Errorf("invalid checkpoint images size %d", size)
+	}
+
+	if err := s.agent.restoreContainer(ctx, containerID, images, size); err != nil {
+		return err
+	}
+
+	s.journal.record(JournalRestored, containerID, "%d bytes of images", size)
+
+	return nil
+}
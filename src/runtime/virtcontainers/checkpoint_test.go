@@ -0,0 +1,49 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+func TestSandboxCheckpointContainer(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:    "sandbox",
+		agent: &mockAgent{},
+		containers: map[string]*Container{
+			"foo": {id: "foo", state: types.ContainerState{State: types.StateStopped}},
+		},
+	}
+
+	var images bytes.Buffer
+	err := s.CheckpointContainer(context.Background(), "bar", &images, false)
+	assert.Error(err)
+
+	err = s.CheckpointContainer(context.Background(), "foo", &images, false)
+	assert.EqualError(err, "container foo is not running")
+
+	err = s.RestoreContainer(context.Background(), "bar", strings.NewReader("images"), 6)
+	assert.Error(err)
+
+	err = s.RestoreContainer(context.Background(), "foo", strings.NewReader(""), 0)
+	assert.Error(err)
+
+	err = s.RestoreContainer(context.Background(), "foo", strings.NewReader("images"), 6)
+	assert.NoError(err)
+
+	s.containers["foo"].state.State = types.StateRunning
+	err = s.RestoreContainer(context.Background(), "foo", strings.NewReader("images"), 6)
+	assert.EqualError(err, "container foo is running")
+}
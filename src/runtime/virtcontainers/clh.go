@@ -128,6 +128,7 @@ type cloudHypervisor struct {
 	virtiofsd Virtiofsd
 	store     persistapi.PersistDriver
 	console   console.Console
+	sandbox   *Sandbox
 }
 
 var clhKernelParams = []Param{
@@ -286,6 +287,8 @@ func (clh *cloudHypervisor) createSandbox(ctx context.Context, id string, networ
 		sourcePath: filepath.Join(getSharePath(clh.id)),
 		socketPath: virtiofsdSocketPath,
 		extraArgs:  clh.config.VirtioFSExtraArgs,
+		sandbox:    clh.config.VirtioFSSandbox,
+		seccomp:    clh.config.VirtioFSSeccomp,
 		debug:      clh.config.Debug,
 		cache:      clh.config.VirtioFSCache,
 	}
@@ -337,14 +340,21 @@ func (clh *cloudHypervisor) startSandbox(ctx context.Context, timeout int) error
 			clh.stopSandbox(ctx, false)
 		})
 		if err != nil {
-			return err
+			return vcTypes.NewError(vcTypes.ErrCodeFsShare, err)
 		}
 		clh.state.VirtiofsdPID = pid
 	} else {
 		return errors.New("cloud-hypervisor only supports virtio based file sharing")
 	}
 
-	pid, err := clh.launchClh()
+	// Only the failures to start the process are retried, the errors
+	// waiting for the VMM API are never transient.
+	var pid int
+	_, err = clh.config.launchWithRetry(ctx, clh.Logger(), func() (string, error) {
+		var err error
+		pid, err = clh.launchClh()
+		return "", err
+	})
 	if err != nil {
 		if shutdownErr := clh.virtiofsd.Stop(ctx); shutdownErr != nil {
 			clh.Logger().WithError(shutdownErr).Warn("error shutting down Virtiofsd")
@@ -731,6 +741,8 @@ func (clh *cloudHypervisor) capabilities(ctx context.Context) types.Capabilities
 	var caps types.Capabilities
 	caps.SetFsSharingSupport()
 	caps.SetBlockDeviceHotplugSupport()
+	caps.SetDeviceHotplugSupport()
+	caps.SetRNGDeviceSupport()
 	return caps
 }
 
@@ -882,6 +894,17 @@ func (clh *cloudHypervisor) launchClh() (int, error) {
 		}
 	}
 
+	// Without a console watcher reading it, the output is kept in the
+	// sandbox hypervisor log.
+	if cmdHypervisor.Stdout == nil && clh.sandbox != nil {
+		output, err := clh.sandbox.hypervisorLog.pipe(hypervisorLogHypervisor, clh.Logger())
+		if err != nil {
+			return -1, err
+		}
+		defer output.Close()
+		cmdHypervisor.Stdout = output
+	}
+
 	cmdHypervisor.Stderr = cmdHypervisor.Stdout
 
 	err = utils.StartCmd(cmdHypervisor)
@@ -1192,9 +1215,44 @@ func (clh *cloudHypervisor) vmInfo() (chclient.VmInfo, error) {
 	return info, openAPIClientError(err)
 }
 
+func (clh *cloudHypervisor) setVMMCgroup(path string) bool {
+	return false
+}
+
 func (clh *cloudHypervisor) isRateLimiterBuiltin() bool {
 	return false
 }
 
+func (clh *cloudHypervisor) dumpGuestMemory(dumpSavePath string, encryptionKey []byte) (string, string, error) {
+	return "", "", errors.New("cloudHypervisor does not support guest memory dump")
+}
+
+func (clh *cloudHypervisor) prepareMigrationIncoming(ctx context.Context, uri string) error {
+	return errors.New("cloudHypervisor does not support live migration")
+}
+
+func (clh *cloudHypervisor) startMigration(ctx context.Context, uri string) error {
+	return errors.New("cloudHypervisor does not support live migration")
+}
+
+func (clh *cloudHypervisor) migrationStatus(ctx context.Context) (string, error) {
+	return "", errors.New("cloudHypervisor does not support live migration")
+}
+
+// guestMemoryStats returns no statistics, the VM has no virtio-balloon
+// device.
+func (clh *cloudHypervisor) guestMemoryStats(ctx context.Context) (GuestMemoryStats, error) {
+	return GuestMemoryStats{}, nil
+}
+
+func (clh *cloudHypervisor) migrationSwitchover(ctx context.Context) error {
+	return errors.New("cloudHypervisor does not support live migration")
+}
+
+func (clh *cloudHypervisor) resizeBlockDevice(ctx context.Context, drive *config.BlockDrive, size uint64) error {
+	return errors.New("cloudHypervisor does not support resizing block devices")
+}
+
 func (clh *cloudHypervisor) setSandbox(sandbox *Sandbox) {
+	clh.sandbox = sandbox
 }
@@ -0,0 +1,241 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"golang.org/x/sys/unix"
+)
+
+// coldPlugDevices attaches the devices of the containers known at sandbox
+// creation, and the ColdPlugDevicePaths, VFIOAPDevices and VFIOBindDevices
+// ones, to the VM before it boots.
+// It must be called before the VM is started.
+func (s *Sandbox) coldPlugDevices(ctx context.Context) error {
+	if !s.config.HypervisorConfig.ColdPlugDevices {
+		return nil
+	}
+
+	if s.config.HypervisorType != QemuHypervisor {
+		return fmt.Errorf("cold plugging devices is not supported by the %s hypervisor", s.config.HypervisorType)
+	}
+
+	if s.factory != nil {
+		return fmt.Errorf("cold plugging devices is not supported with the VM factory")
+	}
+
+	infos, err := s.coldPlugDeviceInfos()
+	if err != nil {
+		return err
+	}
+
+	s.coldPlugging = true
+	defer func() {
+		s.coldPlugging = false
+	}()
+
+	for _, info := range infos {
+		// The device is kept attached for the sandbox lifetime, the
+		// containers using it only take a reference on it.
+		dev, err := s.AddDevice(ctx, info)
+		if err != nil {
+			return fmt.Errorf("failed to cold plug device %s: %v", info.HostPath, err)
+		}
+
+		s.Logger().WithField("device", dev.GetHostPath()).Info("device cold plugged")
+	}
+
+	return nil
+}
+
+// coldPlugDeviceInfos returns the devices to cold plug.
+func (s *Sandbox) coldPlugDeviceInfos() ([]config.DeviceInfo, error) {
+	var infos []config.DeviceInfo
+
+	for _, c := range s.config.Containers {
+		infos = append(infos, c.DeviceInfos...)
+
+		// Block device bind mounts are passed as block devices, see
+		// Container.createBlockDevices().
+		for _, m := range c.Mounts {
+			if m.Type != "bind" || m.BlockDeviceID != "" {
+				continue
+			}
+
+			info, err := hostDeviceInfo(m.Source, m.Destination, m.ReadOnly)
+			if err != nil || info.DevType != "b" {
+				continue
+			}
+			infos = append(infos, *info)
+		}
+	}
+
+	for _, path := range s.config.HypervisorConfig.ColdPlugDevicePaths {
+		info, err := hostDeviceInfo(path, path, false)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, *info)
+	}
+
+	for _, sysfsDev := range s.config.HypervisorConfig.VFIOAPDevices {
+		info, err := vfioAPDeviceInfo(sysfsDev)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, *info)
+	}
+
+	for _, sysfsDev := range s.config.HypervisorConfig.VFIOBindDevices {
+		info, err := vfioBindDeviceInfo(sysfsDev)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, *info)
+	}
+
+	for _, sysfsDev := range s.config.HypervisorConfig.VFIOMdevDevices {
+		info, err := vfioMdevDeviceInfo(sysfsDev)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, *info)
+	}
+
+	return infos, nil
+}
+
+// vfioAPDeviceInfo returns the device information of the VFIO group of the
+// VFIO-AP mediated device sysfsDev, e.g. /sys/devices/vfio_ap/matrix/<uuid>.
+func vfioAPDeviceInfo(sysfsDev string) (*config.DeviceInfo, error) {
+	if !utils.IsAPVFIOMediatedDevice(sysfsDev) {
+		return nil, fmt.Errorf("%s is not a VFIO-AP mediated device", sysfsDev)
+	}
+
+	group, err := os.Readlink(filepath.Join(sysfsDev, "iommu_group"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the VFIO group of %s: %v", sysfsDev, err)
+	}
+
+	groupPath := filepath.Join(vfioPath, filepath.Base(group))
+	return hostDeviceInfo(groupPath, groupPath, false)
+}
+
+// vfioBindDeviceInfo returns the device information of the PCI device
+// sysfsDev, e.g. /sys/bus/pci/devices/0000:3b:00.0, which the device manager
+// binds to vfio-pci and passes as its VFIO group.
+func vfioBindDeviceInfo(sysfsDev string) (*config.DeviceInfo, error) {
+	if filepath.Dir(filepath.Clean(sysfsDev)) != filepath.Clean(config.SysBusPciDevicesPath) {
+		return nil, fmt.Errorf("%s is not a PCI device", sysfsDev)
+	}
+
+	return &config.DeviceInfo{
+		HostPath:      sysfsDev,
+		ContainerPath: sysfsDev,
+		DevType:       "c",
+	}, nil
+}
+
+// hostDeviceInfo returns the device information of a host block or
+// character device.
+func hostDeviceInfo(hostPath, containerPath string, readOnly bool) (*config.DeviceInfo, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(hostPath, &stat); err != nil {
+		return nil, fmt.Errorf("stat %q failed: %v", hostPath, err)
+	}
+
+	var devType string
+	switch stat.Mode & unix.S_IFMT {
+	case unix.S_IFBLK:
+		devType = "b"
+	case unix.S_IFCHR:
+		devType = "c"
+	default:
+		return nil, fmt.Errorf("%s is not a device", hostPath)
+	}
+
+	return &config.DeviceInfo{
+		HostPath:      hostPath,
+		ContainerPath: containerPath,
+		DevType:       devType,
+		Major:         int64(unix.Major(stat.Rdev)),
+		Minor:         int64(unix.Minor(stat.Rdev)),
+		ReadOnly:      readOnly,
+	}, nil
+}
+
+// coldPlugDevice adds a device to the VM before it boots.
+func (s *Sandbox) coldPlugDevice(ctx context.Context, device api.Device, devType config.DeviceType) error {
+	switch devType {
+	case config.DeviceVFIO:
+		vfioDevices, ok := device.GetDeviceInfo().([]*config.VFIODev)
+		if !ok {
+			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
+		}
+
+		for _, dev := range vfioDevices {
+			if err := s.hypervisor.addDevice(ctx, *dev, vfioDev); err != nil {
+				return err
+			}
+		}
+		return nil
+	case config.DeviceBlock:
+		blockDevice, ok := device.(*drivers.BlockDevice)
+		if !ok {
+			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
+		}
+		// The drive is passed by reference, so that the hypervisor can
+		// set its guest address.
+		return s.hypervisor.addDevice(ctx, blockDevice.BlockDrive, blockDev)
+	case config.VhostUserBlk:
+		vhostUserBlkDevice, ok := device.(*drivers.VhostUserBlkDevice)
+		if !ok {
+			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
+		}
+		return s.hypervisor.addDevice(ctx, *vhostUserBlkDevice.VhostUserDeviceAttrs, vhostuserDev)
+	case config.DeviceGeneric:
+		return nil
+	}
+
+	return fmt.Errorf("cannot cold plug %s device %s", devType, device.GetHostPath())
+}
+
+// errColdPlugOnly is returned when a device is hot plugged in a sandbox
+// which only supports cold plugged devices.
+func (s *Sandbox) errColdPlugOnly(device api.Device, devType config.DeviceType) error {
+	return fmt.Errorf("cannot hot plug %s device %s: sandbox %s only supports devices cold plugged at creation, list the device in the %s annotation",
+		devType, device.GetHostPath(), s.id, vcAnnotations.ColdPlugDevicePaths)
+}
+
+// vfioMdevDeviceInfo returns the device information of the mediated device
+// sysfsDev, e.g. /sys/bus/mdev/devices/<uuid>, or of the mediated device type
+// sysfsDev, e.g. /sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63,
+// which the device manager creates a device of, passed as its VFIO group.
+func vfioMdevDeviceInfo(sysfsDev string) (*config.DeviceInfo, error) {
+	path := filepath.Clean(sysfsDev)
+	typesDir := filepath.Dir(path)
+	isDevice := typesDir == filepath.Clean(config.SysBusMdevDevicesPath)
+	isType := filepath.Base(typesDir) == "mdev_supported_types" &&
+		filepath.Dir(filepath.Dir(typesDir)) == filepath.Clean(config.SysClassMdevBusPath)
+	if !isDevice && !isType {
+		return nil, fmt.Errorf("%s is neither a mediated device nor a mediated device type", sysfsDev)
+	}
+
+	return &config.DeviceInfo{
+		HostPath:      sysfsDev,
+		ContainerPath: sysfsDev,
+		DevType:       "c",
+	}, nil
+}
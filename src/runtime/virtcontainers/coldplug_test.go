@@ -0,0 +1,140 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostDeviceInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	info, err := hostDeviceInfo("/dev/null", "/dev/foo", true)
+	assert.NoError(err)
+	assert.Equal("c", info.DevType)
+	assert.Equal(int64(1), info.Major)
+	assert.Equal(int64(3), info.Minor)
+	assert.Equal("/dev/foo", info.ContainerPath)
+	assert.True(info.ReadOnly)
+
+	_, err = hostDeviceInfo("/tmp", "/tmp", false)
+	assert.Error(err)
+
+	_, err = hostDeviceInfo("/does/not/exist", "/foo", false)
+	assert.Error(err)
+}
+
+func TestVFIOAPDeviceInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := vfioAPDeviceInfo("/sys/bus/pci/devices/0000:00:02.0")
+	assert.Error(err)
+
+	// No VFIO group
+	sysfsDev := filepath.Join(t.TempDir(), "vfio_ap", "matrix", "a297db4a-f4c2-11e6-90f6-d3b88d6c9525")
+	assert.NoError(os.MkdirAll(sysfsDev, 0755))
+	_, err = vfioAPDeviceInfo(sysfsDev)
+	assert.Error(err)
+
+	// The VFIO group device does not exist
+	assert.NoError(os.Symlink("../../../kernel/iommu_groups/4242", filepath.Join(sysfsDev, "iommu_group")))
+	_, err = vfioAPDeviceInfo(sysfsDev)
+	assert.Error(err)
+	assert.Contains(err.Error(), "/dev/vfio/4242")
+}
+
+func TestSandboxColdPlugDevices(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id: testSandboxID,
+		config: &SandboxConfig{
+			HypervisorType: MockHypervisor,
+		},
+	}
+
+	// Nothing to do when devices can be hot plugged
+	assert.NoError(s.coldPlugDevices(context.Background()))
+
+	s.config.HypervisorConfig.ColdPlugDevices = true
+	assert.Error(s.coldPlugDevices(context.Background()))
+
+	s.config.HypervisorType = QemuHypervisor
+	s.config.HypervisorConfig.ColdPlugDevicePaths = []string{"/tmp"}
+	assert.Error(s.coldPlugDevices(context.Background()))
+}
+
+func TestSandboxHotplugColdPlugOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:         testSandboxID,
+		hypervisor: &mockHypervisor{},
+		config: &SandboxConfig{
+			HypervisorConfig: HypervisorConfig{
+				ColdPlugDevices: true,
+			},
+		},
+	}
+
+	dev := drivers.NewBlockDevice(&config.DeviceInfo{HostPath: "/dev/foo"})
+	dev.BlockDrive = &config.BlockDrive{File: "/dev/foo"}
+
+	err := s.HotplugAddDevice(context.Background(), dev, config.DeviceBlock)
+	assert.Error(err)
+	assert.Contains(err.Error(), "cold plugged")
+
+	// Generic devices are not plugged in the VM
+	generic := drivers.NewGenericDevice(&config.DeviceInfo{HostPath: "/dev/null"})
+	assert.NoError(s.HotplugAddDevice(context.Background(), generic, config.DeviceGeneric))
+
+	s.coldPlugging = true
+	assert.NoError(s.HotplugAddDevice(context.Background(), dev, config.DeviceBlock))
+}
+
+func TestVFIOBindDeviceInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := vfioBindDeviceInfo("/dev/vfio/1")
+	assert.Error(err)
+
+	_, err = vfioBindDeviceInfo("/sys/bus/pci/devices/0000:3b:00.0/driver")
+	assert.Error(err)
+
+	info, err := vfioBindDeviceInfo("/sys/bus/pci/devices/0000:3b:00.0")
+	assert.NoError(err)
+	assert.Equal("/sys/bus/pci/devices/0000:3b:00.0", info.HostPath)
+	assert.Equal("c", info.DevType)
+}
+
+func TestVFIOMdevDeviceInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := vfioMdevDeviceInfo("/dev/vfio/1")
+	assert.Error(err)
+
+	_, err = vfioMdevDeviceInfo("/sys/bus/pci/devices/0000:3b:00.0")
+	assert.Error(err)
+
+	_, err = vfioMdevDeviceInfo("/sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types")
+	assert.Error(err)
+
+	info, err := vfioMdevDeviceInfo("/sys/bus/mdev/devices/f79944e4-5a3d-11e8-99ce-479cbab002e4")
+	assert.NoError(err)
+	assert.Equal("/sys/bus/mdev/devices/f79944e4-5a3d-11e8-99ce-479cbab002e4", info.HostPath)
+	assert.Equal("c", info.DevType)
+
+	info, err = vfioMdevDeviceInfo("/sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63")
+	assert.NoError(err)
+	assert.Equal("/sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63", info.HostPath)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+
+	vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
+)
+
+var (
+	confidentialMiscResourceFunc = confidentialMiscResource
+	miscCapacityFunc             = vccgroups.MiscCapacity
+	miscUsageFunc                = vccgroups.MiscUsage
+)
+
+// checkConfidentialIDs refuses to start a confidential guest when the host
+// has no hardware ID left for it, the SEV ASIDs or the TDX key IDs being
+// accounted by the cgroup v2 misc controller, rather than having the
+// hypervisor fail obscurely. The IDs are not checked when the host does not
+// account them.
+func (s *Sandbox) checkConfidentialIDs() error {
+	if !s.config.HypervisorConfig.ConfidentialGuest {
+		return nil
+	}
+
+	resource, err := confidentialMiscResourceFunc()
+	if err != nil || resource == "" {
+		return err
+	}
+
+	capacity, err := miscCapacityFunc()
+	if err != nil {
+		return err
+	}
+
+	total, ok := capacity[resource]
+	if !ok {
+		s.Logger().WithField("resource", resource).Debug("The confidential guest IDs are not accounted by the misc cgroup controller")
+		return nil
+	}
+
+	usage, err := miscUsageFunc()
+	if err != nil {
+		return err
+	}
+
+	if usage[resource] >= total {
+		return fmt.Errorf("no %s ID left on the host for the confidential guest: %d of %d are used", resource, usage[resource], total)
+	}
+
+	return nil
+}
+
+// updateConfidentialIDsMetrics exports the hardware IDs of the confidential
+// guests of the host, and the ones of the sandbox.
+func (s *Sandbox) updateConfidentialIDsMetrics() error {
+	if !s.config.HypervisorConfig.ConfidentialGuest {
+		return nil
+	}
+
+	capacity, err := miscCapacityFunc()
+	if err != nil {
+		return err
+	}
+
+	usage, err := miscUsageFunc()
+	if err != nil {
+		return err
+	}
+
+	for resource, total := range capacity {
+		confidentialIDs.WithLabelValues(resource, "capacity").Set(float64(total))
+		confidentialIDs.WithLabelValues(resource, "used").Set(float64(usage[resource]))
+	}
+
+	if s.cgroupMgr == nil || !vccgroups.UnifiedMode() {
+		return nil
+	}
+
+	current, err := s.cgroupMgr.MiscCurrent()
+	if err != nil {
+		return err
+	}
+
+	for resource := range capacity {
+		confidentialIDs.WithLabelValues(resource, "sandbox").Set(float64(current[resource]))
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
+
+// confidentialMiscResource returns the cgroup misc resource of the hardware
+// ID a confidential guest takes on the host, the SEV ASIDs or the TDX key
+// IDs.
+func confidentialMiscResource() (string, error) {
+	protection, err := availableGuestProtection()
+	if err != nil {
+		return "", err
+	}
+
+	switch protection {
+	case sevProtection:
+		return vccgroups.MiscSEV, nil
+	case tdxProtection:
+		return vccgroups.MiscTDX, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,15 @@
+// +build !amd64
+
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+// confidentialMiscResource returns the cgroup misc resource of the hardware
+// ID a confidential guest takes on the host: the protected guests of the
+// other architectures take none.
+func confidentialMiscResource() (string, error) {
+	return "", nil
+}
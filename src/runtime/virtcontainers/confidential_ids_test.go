@@ -0,0 +1,61 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+
+	vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
+)
+
+func TestCheckConfidentialIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	savedResourceFunc, savedCapacityFunc, savedUsageFunc := confidentialMiscResourceFunc, miscCapacityFunc, miscUsageFunc
+	defer func() {
+		confidentialMiscResourceFunc, miscCapacityFunc, miscUsageFunc = savedResourceFunc, savedCapacityFunc, savedUsageFunc
+	}()
+
+	resource := vccgroups.MiscSEV
+	capacity := map[string]uint64{}
+	usage := map[string]uint64{}
+	confidentialMiscResourceFunc = func() (string, error) { return resource, nil }
+	miscCapacityFunc = func() (map[string]uint64, error) { return capacity, nil }
+	miscUsageFunc = func() (map[string]uint64, error) { return usage, nil }
+
+	s := &Sandbox{
+		config: &SandboxConfig{},
+	}
+
+	// Not a confidential guest
+	usage[vccgroups.MiscSEV] = 1
+	assert.NoError(s.checkConfidentialIDs())
+
+	s.config.HypervisorConfig.ConfidentialGuest = true
+
+	// The IDs are not accounted
+	assert.NoError(s.checkConfidentialIDs())
+
+	capacity[vccgroups.MiscSEV] = 2
+	assert.NoError(s.checkConfidentialIDs())
+
+	usage[vccgroups.MiscSEV] = 2
+	assert.Error(s.checkConfidentialIDs())
+
+	// No ID is taken by the guest
+	resource = ""
+	assert.NoError(s.checkConfidentialIDs())
+
+	assert.NoError(s.updateConfidentialIDsMetrics())
+	for _, item := range []string{"capacity", "used"} {
+		m := &dto.Metric{}
+		assert.NoError(confidentialIDs.WithLabelValues(vccgroups.MiscSEV, item).Write(m))
+		assert.Equal(float64(2), m.GetGauge().GetValue(), item)
+	}
+}
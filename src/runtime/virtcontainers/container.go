@@ -13,9 +13,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"syscall"
 	"time"
 
+	volume "github.com/kata-containers/kata-containers/src/runtime/pkg/direct-volume"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
@@ -344,6 +346,10 @@ type Container struct {
 
 	devices []ContainerDevice
 
+	// networkNS is the network of the container, when it has its own
+	// rather than the sandbox one.
+	networkNS NetworkNamespace
+
 	systemMountsInfo SystemMountsInfo
 
 	ctx context.Context
@@ -517,6 +523,40 @@ func (c *Container) mountSharedDirMounts(ctx context.Context, sharedDirMounts, i
 			}
 		}
 	}()
+
+	// The block devices are attached at once before the other mounts are
+	// shared, all other devices passed in the config have been attached
+	// at this point.
+	if concurrency := c.sandbox.hotplugConcurrency(); concurrency > 1 {
+		var (
+			lock  sync.Mutex
+			tasks []hotplugTask
+		)
+		for idx, m := range c.mounts {
+			if isSystemMount(m.Source) || len(m.BlockDeviceID) == 0 {
+				continue
+			}
+
+			id := m.BlockDeviceID
+			tasks = append(tasks, hotplugTask{
+				id: fmt.Sprintf("%d-%s", idx, id),
+				run: func(ctx context.Context) error {
+					if err := c.sandbox.devManager.AttachDevice(ctx, id, c.sandbox); err != nil {
+						return err
+					}
+					lock.Lock()
+					devicesToDetach = append(devicesToDetach, id)
+					lock.Unlock()
+					return nil
+				},
+			})
+		}
+
+		if err = runHotplugTasks(ctx, concurrency, tasks); err != nil {
+			return storages, err
+		}
+	}
+
 	for idx, m := range c.mounts {
 		// Skip mounting certain system paths from the source on the host side
 		// into the container as it does not make sense to do so.
@@ -528,6 +568,10 @@ func (c *Container) mountSharedDirMounts(ctx context.Context, sharedDirMounts, i
 		// Check if mount is a block device file. If it is, the block device will be attached to the host
 		// instead of passing this as a shared mount:
 		if len(m.BlockDeviceID) > 0 {
+			if c.sandbox.hotplugConcurrency() > 1 {
+				continue
+			}
+
 			// Attach this block device, all other devices passed in the config have been attached at this point
 			if err = c.sandbox.devManager.AttachDevice(ctx, m.BlockDeviceID, c.sandbox); err != nil {
 				return storages, err
@@ -700,13 +744,35 @@ func (c *Container) createBlockDevices(ctx context.Context) error {
 			continue
 		}
 
+		// The device of the direct assigned volumes is passed to the VM,
+		// and their filesystem mounted by the guest.
+		directInfo, err := c.directVolumeDeviceInfo(m)
+		if err != nil {
+			return err
+		}
+		if directInfo != nil {
+			b, err := c.sandbox.devManager.NewDevice(directInfo.DeviceInfo)
+			if err != nil {
+				return err
+			}
+
+			// kata-runtime direct-volume resize looks the sandbox up
+			if err := volume.RecordSandboxID(c.sandboxID, m.Source); err != nil {
+				return err
+			}
+
+			c.mounts[i].BlockDeviceID = b.DeviceID()
+			c.mounts[i].Type = directInfo.FsType
+			c.mounts[i].Options = directInfo.Options
+			continue
+		}
+
 		var stat unix.Stat_t
 		if err := unix.Stat(m.Source, &stat); err != nil {
 			return fmt.Errorf("stat %q failed: %v", m.Source, err)
 		}
 
 		var di *config.DeviceInfo
-		var err error
 
 		// Check if mount is a block device file. If it is, the block device will be attached to the host
 		// instead of passing this as a shared mount.
@@ -719,6 +785,10 @@ func (c *Container) createBlockDevices(ctx context.Context) error {
 				Minor:         int64(unix.Minor(stat.Rdev)),
 				ReadOnly:      m.ReadOnly,
 			}
+
+			if err := c.setBlockVolumeClass(di, m.BlockVolumeClass); err != nil {
+				return err
+			}
 			// check whether source can be used as a pmem device
 		} else if di, err = config.PmemDeviceInfo(m.Source, m.Destination); err != nil {
 			c.Logger().WithError(err).
@@ -744,6 +814,99 @@ func (c *Container) createBlockDevices(ctx context.Context) error {
 	return nil
 }
 
+// directVolume is the device of a direct assigned volume, and the
+// filesystem the guest mounts from it.
+type directVolume struct {
+	config.DeviceInfo
+	FsType  string
+	Options []string
+}
+
+// directVolumeDeviceInfo returns the device of the mount when it is a direct
+// assigned volume, nil otherwise. The SPDK volumes are passed as
+// vhost-user-blk devices. Their vhost target accesses the guest memory, it
+// must be shared: the vhost-user storage, which backs the guest memory with
+// shared huge pages, must be enabled.
+func (c *Container) directVolumeDeviceInfo(m Mount) (*directVolume, error) {
+	mountInfo, err := volume.VolumeMountInfo(m.Source)
+	if errors.Is(err, volume.ErrNoMountInfo) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	di := config.DeviceInfo{
+		HostPath:      mountInfo.Device,
+		ContainerPath: m.Destination,
+		DevType:       "b",
+		ReadOnly:      m.ReadOnly,
+	}
+
+	switch mountInfo.VolumeType {
+	case volume.BlockVolumeType:
+		var stat unix.Stat_t
+		if err := unix.Stat(mountInfo.Device, &stat); err != nil {
+			return nil, fmt.Errorf("stat %q failed: %v", mountInfo.Device, err)
+		}
+		if stat.Mode&unix.S_IFMT != unix.S_IFBLK {
+			return nil, fmt.Errorf("device %s of volume %s is not a block device", mountInfo.Device, m.Destination)
+		}
+		di.Major = int64(unix.Major(stat.Rdev))
+		di.Minor = int64(unix.Minor(stat.Rdev))
+	case volume.FileVolumeType:
+		// not a host block device
+		di.Major = -1
+	case volume.SPDKVolumeType:
+		if !c.sandbox.config.HypervisorConfig.EnableVhostUserStore {
+			return nil, fmt.Errorf("SPDK volume %s requires enable_vhost_user_store, the SPDK vhost target accessing the guest memory", m.Destination)
+		}
+
+		options, err := mountInfo.VhostUserBlkOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		di.Major = config.VhostUserBlkMajor
+		di.Minor = -1
+		di.NumQueues = options.NumQueues
+		di.QueueSize = options.QueueSize
+		di.Reconnect = options.Reconnect
+	default:
+		return nil, fmt.Errorf("unknown type %q of volume %s", mountInfo.VolumeType, m.Destination)
+	}
+
+	mountOptions := mountInfo.Options
+	if m.ReadOnly {
+		mountOptions = append([]string{"ro"}, mountOptions...)
+	}
+
+	return &directVolume{
+		DeviceInfo: di,
+		FsType:     mountInfo.FsType,
+		Options:    mountOptions,
+	}, nil
+}
+
+// setBlockVolumeClass sets the cache, discard and detect-zeroes modes of the
+// block volume class on the device.
+func (c *Container) setBlockVolumeClass(di *config.DeviceInfo, class string) error {
+	if class == "" {
+		return nil
+	}
+
+	options, ok := c.sandbox.config.HypervisorConfig.BlockVolumeClasses[class]
+	if !ok {
+		return fmt.Errorf("unknown block volume class %q of the volume %s", class, di.ContainerPath)
+	}
+
+	di.Cache = options.Cache
+	di.Discard = options.Discard
+	di.DetectZeroes = options.DetectZeroes
+
+	return nil
+}
+
 // newContainer creates a Container structure from a sandbox and a container configuration.
 func newContainer(ctx context.Context, sandbox *Sandbox, contConfig *ContainerConfig) (*Container, error) {
 	span, ctx := katatrace.Trace(ctx, sandbox.Logger(), "newContainer", sandbox.tracingTags())
@@ -824,6 +987,9 @@ func (c *Container) createDevices(contConfig *ContainerConfig) error {
 // - Unplug CPU and memory resources from the VM.
 // - Unplug devices from the VM.
 func (c *Container) rollbackFailingContainerCreation(ctx context.Context) {
+	if err := c.detachNetwork(ctx); err != nil {
+		c.Logger().WithError(err).Error("rollback failed detachNetwork()")
+	}
 	if err := c.detachDevices(ctx); err != nil {
 		c.Logger().WithError(err).Error("rollback failed detachDevices()")
 	}
@@ -908,6 +1074,10 @@ func (c *Container) create(ctx context.Context) (err error) {
 	// inside the VM
 	c.getSystemMountInfo()
 
+	if err = c.attachNetwork(ctx); err != nil {
+		return
+	}
+
 	process, err := c.sandbox.agent.createContainer(ctx, c.sandbox, c)
 	if err != nil {
 		return err
@@ -934,6 +1104,7 @@ func (c *Container) create(ctx context.Context) (err error) {
 	if err = c.setContainerState(types.StateReady); err != nil {
 		return
 	}
+	c.sandbox.journal.record(JournalContainerCreated, c.id, "container created")
 
 	return nil
 }
@@ -1012,7 +1183,12 @@ func (c *Container) start(ctx context.Context) error {
 		return err
 	}
 
-	return c.setContainerState(types.StateRunning)
+	if err := c.setContainerState(types.StateRunning); err != nil {
+		return err
+	}
+	c.sandbox.journal.record(JournalContainerStarted, c.id, "container started")
+
+	return nil
 }
 
 func (c *Container) stop(ctx context.Context, force bool) error {
@@ -1058,6 +1234,10 @@ func (c *Container) stop(ctx context.Context, force bool) error {
 		return err
 	}
 
+	if err := c.detachNetwork(ctx); err != nil && !force {
+		return err
+	}
+
 	if err := c.unmountHostMounts(ctx); err != nil && !force {
 		return err
 	}
@@ -1085,6 +1265,7 @@ func (c *Container) stop(ctx context.Context, force bool) error {
 	if err := c.setContainerState(types.StateStopped); err != nil {
 		return err
 	}
+	c.sandbox.journal.record(JournalContainerStopped, c.id, "container stopped")
 
 	return nil
 }
@@ -1254,6 +1435,16 @@ func (c *Container) hotplugDrive(ctx context.Context) error {
 	var dev device
 	var err error
 
+	// The rootfs is passed through the shared filesystem when devices
+	// cannot be hot plugged.
+	if c.sandbox.config.HypervisorConfig.ColdPlugDevices {
+		return nil
+	}
+
+	if c.useErofsLayers() {
+		return c.plugErofsLayers(ctx)
+	}
+
 	// Check to see if the rootfs is an umounted block device (source) or if the
 	// mount (target) is backed by a block device:
 	if !c.rootFs.Mounted {
@@ -1352,6 +1543,10 @@ func (c *Container) isDriveUsed() bool {
 }
 
 func (c *Container) removeDrive(ctx context.Context) (err error) {
+	if err := c.removeErofsLayers(ctx); err != nil {
+		return err
+	}
+
 	if c.isDriveUsed() {
 		c.Logger().Info("unplugging block device")
 
@@ -1385,6 +1580,14 @@ func (c *Container) attachDevices(ctx context.Context, devices []ContainerDevice
 	// since devices with large bar space require delayed attachment,
 	// the devices need to be split into two lists, normalAttachedDevs and delayAttachedDevs.
 	// so c.device is not used here. See issue https://github.com/kata-containers/runtime/issues/2460.
+	if concurrency := c.sandbox.hotplugConcurrency(); concurrency > 1 {
+		var ids []string
+		for _, dev := range devices {
+			ids = append(ids, dev.ID)
+		}
+		return runHotplugTasks(ctx, concurrency, c.attachDeviceTasks(ids))
+	}
+
 	for _, dev := range devices {
 		if err := c.sandbox.devManager.AttachDevice(ctx, dev.ID, c.sandbox); err != nil {
 			return err
@@ -1516,8 +1719,8 @@ func (c *Container) cgroupsUpdate(resources specs.LinuxResources) error {
 		return fmt.Errorf("Could not update container cgroup path='%v': error='%v'", c.state.CgroupPath, err)
 	}
 
-	// store new resources
-	c.config.Resources = r
+	// The resources of the container are already updated, r only holds
+	// the CPU ones.
 	if err := c.storeContainer(); err != nil {
 		return err
 	}
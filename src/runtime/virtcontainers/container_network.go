@@ -0,0 +1,156 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+)
+
+var (
+	// ContainerNetworkFeature is an experimental feature
+	ContainerNetworkFeature = exp.Feature{
+		Name:        "container_network",
+		Description: "This gives the containers their own network interfaces and network namespaces in the VM, from the host network namespaces of the containers, it has to be an experimental feature since the containers of a sandbox no longer share its network.",
+		ExpRelease:  "3.0",
+	}
+	containerNetworkExpErr error
+)
+
+func init() {
+	containerNetworkExpErr = exp.Register(ContainerNetworkFeature)
+}
+
+// ContainerNetworkNSPath returns the path of the host network namespace of a
+// container, set by the ContainerNetworkNS annotation. The path is empty when
+// the container shares the sandbox network.
+func ContainerNetworkNSPath(annotations map[string]string) (string, error) {
+	path, ok := annotations[vcAnnotations.ContainerNetworkNS]
+	if !ok {
+		return "", nil
+	}
+
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("Invalid network namespace %q specified in annotation %s, it must be an absolute path", path, vcAnnotations.ContainerNetworkNS)
+	}
+
+	return path, nil
+}
+
+// containerNetworkEnabled returns whether the containers of the sandbox can
+// have their own network.
+func (s *Sandbox) containerNetworkEnabled() bool {
+	for _, f := range s.config.Experimental {
+		if f.Name == ContainerNetworkFeature.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nextNetworkIndex returns the index of the next endpoint of the sandbox,
+// above the indexes of the sandbox and container endpoints. The index names
+// the host interfaces of the endpoint and its hypervisor network device.
+func (s *Sandbox) nextNetworkIndex() int {
+	next := len(s.networkNS.Endpoints)
+
+	endpoints := append([]Endpoint{}, s.networkNS.Endpoints...)
+	for _, c := range s.containers {
+		endpoints = append(endpoints, c.networkNS.Endpoints...)
+	}
+
+	for _, endpoint := range endpoints {
+		netPair := endpoint.NetworkPair()
+		if netPair == nil {
+			continue
+		}
+
+		var idx int
+		if _, err := fmt.Sscanf(netPair.TapInterface.Name, "br%d_kata", &idx); err == nil && idx >= next {
+			next = idx + 1
+		}
+	}
+
+	return next
+}
+
+// attachNetwork hotplugs in the VM the interfaces of the host network
+// namespace of the container, if it has one. The agent moves them to the
+// network namespace of the container in the VM.
+func (c *Container) attachNetwork(ctx context.Context) (err error) {
+	ociSpec := c.GetPatchedOCISpec()
+	if ociSpec == nil {
+		return nil
+	}
+
+	netNsPath, err := ContainerNetworkNSPath(ociSpec.Annotations)
+	if err != nil || netNsPath == "" {
+		return err
+	}
+
+	if !c.sandbox.containerNetworkEnabled() {
+		return fmt.Errorf("annotation %s requires the experimental feature %q", vcAnnotations.ContainerNetworkNS, ContainerNetworkFeature.Name)
+	}
+	if containerNetworkExpErr != nil {
+		return containerNetworkExpErr
+	}
+
+	span, ctx := katatrace.Trace(ctx, c.Logger(), "attachNetwork", c.tracingTags())
+	defer span.End()
+
+	endpoints, err := createEndpointsFromScan(netNsPath, &c.sandbox.config.NetworkConfig, c.sandbox.nextNetworkIndex())
+	if err != nil {
+		return err
+	}
+
+	c.networkNS = NetworkNamespace{NetNsPath: netNsPath}
+
+	defer func() {
+		if err != nil {
+			if detachErr := c.detachNetwork(ctx); detachErr != nil {
+				c.Logger().WithError(detachErr).Warn("Could not detach the container network")
+			}
+		}
+	}()
+
+	for _, endpoint := range endpoints {
+		if err = doNetNS(netNsPath, func(_ ns.NetNS) error {
+			c.Logger().WithField("endpoint-type", endpoint.Type()).Info("Hot attaching container endpoint")
+			return endpoint.HotAttach(ctx, c.sandbox.hypervisor)
+		}); err != nil {
+			return err
+		}
+
+		c.networkNS.Endpoints = append(c.networkNS.Endpoints, endpoint)
+	}
+
+	return nil
+}
+
+// detachNetwork hot unplugs from the VM the interfaces of the container. The
+// VM outlives the container, its network devices must be removed even though
+// the host network namespace was not created by the runtime.
+func (c *Container) detachNetwork(ctx context.Context) error {
+	for len(c.networkNS.Endpoints) > 0 {
+		endpoint := c.networkNS.Endpoints[0]
+
+		c.Logger().WithField("endpoint-type", endpoint.Type()).Info("Hot detaching container endpoint")
+		if err := endpoint.HotDetach(ctx, c.sandbox.hypervisor, true, c.networkNS.NetNsPath); err != nil {
+			return err
+		}
+
+		c.networkNS.Endpoints = c.networkNS.Endpoints[1:]
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerNetworkNSPath(t *testing.T) {
+	assert := assert.New(t)
+
+	path, err := ContainerNetworkNSPath(nil)
+	assert.NoError(err)
+	assert.Empty(path)
+
+	path, err = ContainerNetworkNSPath(map[string]string{
+		vcAnnotations.ContainerNetworkNS: "/var/run/netns/cni-5678",
+	})
+	assert.NoError(err)
+	assert.Equal("/var/run/netns/cni-5678", path)
+
+	_, err = ContainerNetworkNSPath(map[string]string{
+		vcAnnotations.ContainerNetworkNS: "cni-5678",
+	})
+	assert.Error(err)
+}
+
+func TestSandboxNextNetworkIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	vethEndpoint := func(idx int) Endpoint {
+		endpoint, err := createVethNetworkEndpoint(idx, "", DefaultNetInterworkingModel)
+		assert.NoError(err)
+		return endpoint
+	}
+
+	s := &Sandbox{
+		containers: map[string]*Container{},
+	}
+	assert.Equal(0, s.nextNetworkIndex())
+
+	s.networkNS.Endpoints = []Endpoint{vethEndpoint(0), &PhysicalEndpoint{}}
+	assert.Equal(2, s.nextNetworkIndex())
+
+	s.containers["foo"] = &Container{
+		networkNS: NetworkNamespace{
+			NetNsPath: "/var/run/netns/cni-5678",
+			Endpoints: []Endpoint{vethEndpoint(2), vethEndpoint(4)},
+		},
+	}
+	s.containers["bar"] = &Container{}
+	assert.Equal(5, s.nextNetworkIndex())
+
+	// The sandbox endpoints are left untouched
+	assert.Len(s.networkNS.Endpoints, 2)
+}
+
+func TestContainerAttachNetwork(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	s := &Sandbox{
+		config: &SandboxConfig{},
+	}
+	c := &Container{
+		sandbox: s,
+		config: &ContainerConfig{
+			CustomSpec: &specs.Spec{},
+		},
+	}
+
+	// The container shares the sandbox network
+	assert.NoError(c.attachNetwork(ctx))
+	assert.Empty(c.networkNS.Endpoints)
+
+	// The container network requires the experimental feature
+	c.config.CustomSpec.Annotations = map[string]string{
+		vcAnnotations.ContainerNetworkNS: "/var/run/netns/cni-5678",
+	}
+	assert.False(s.containerNetworkEnabled())
+	assert.Error(c.attachNetwork(ctx))
+
+	s.config.Experimental = []exp.Feature{ContainerNetworkFeature}
+	assert.True(s.containerNetworkEnabled())
+	assert.NotNil(exp.Get(ContainerNetworkFeature.Name))
+}
+
+func TestContainerNetworkPersist(t *testing.T) {
+	assert := assert.New(t)
+
+	endpoint, err := createVethNetworkEndpoint(3, "eth0", DefaultNetInterworkingModel)
+	assert.NoError(err)
+
+	networkNS := NetworkNamespace{
+		NetNsPath: "/var/run/netns/cni-5678",
+		Endpoints: []Endpoint{endpoint},
+	}
+
+	loaded := loadNetworkNS(saveNetworkNS(networkNS))
+	assert.Equal(networkNS.NetNsPath, loaded.NetNsPath)
+	assert.False(loaded.NetNsCreated)
+	assert.Len(loaded.Endpoints, 1)
+	assert.Equal(VethEndpointType, loaded.Endpoints[0].Type())
+	assert.Equal("br3_kata", loaded.Endpoints[0].NetworkPair().TapInterface.Name)
+}
@@ -16,6 +16,7 @@ import (
 	"testing"
 
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	volume "github.com/kata-containers/kata-containers/src/runtime/pkg/direct-volume"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
@@ -87,7 +88,7 @@ func TestContainerRemoveDrive(t *testing.T) {
 	sandbox := &Sandbox{
 		ctx:        context.Background(),
 		id:         "sandbox",
-		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", false, nil),
 		config:     &SandboxConfig{},
 	}
 
@@ -306,7 +307,7 @@ func TestContainerAddDriveDir(t *testing.T) {
 	sandbox := &Sandbox{
 		ctx:        context.Background(),
 		id:         testSandboxID,
-		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil),
+		devManager: manager.NewDeviceManager(manager.VirtioSCSI, false, "", false, nil),
 		hypervisor: &mockHypervisor{},
 		agent:      &mockAgent{},
 		config: &SandboxConfig{
@@ -636,3 +637,62 @@ func TestMountSharedDirMounts(t *testing.T) {
 	assert.Equal(updatedMounts[mountDestination].Source, expectedStorageDest)
 	assert.Equal(updatedMounts[mountDestination].Destination, mountDestination)
 }
+
+func TestContainerSPDKVolumeDeviceInfo(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("Test disabled as requires root user")
+	}
+
+	assert := assert.New(t)
+
+	volumePath, err := ioutil.TempDir("", "spdk-volume")
+	assert.NoError(err)
+	defer os.RemoveAll(volumePath)
+
+	c := &Container{
+		sandbox: &Sandbox{
+			config: &SandboxConfig{},
+		},
+	}
+	m := Mount{
+		Source:      volumePath,
+		Destination: "/data",
+		Type:        "bind",
+		ReadOnly:    true,
+	}
+
+	// Not a direct assigned volume
+	info, err := c.directVolumeDeviceInfo(m)
+	assert.NoError(err)
+	assert.Nil(info)
+
+	assert.NoError(volume.Add(volumePath, &volume.MountInfo{
+		VolumeType: volume.SPDKVolumeType,
+		Device:     "/var/tmp/vhost.0",
+		FsType:     "xfs",
+		Options:    []string{"noatime"},
+		Metadata:   map[string]string{volume.NumQueuesMetadata: "4", volume.ReconnectMetadata: "1"},
+	}))
+	defer volume.Remove(volumePath)
+
+	// The guest memory must be shared with the SPDK vhost target
+	_, err = c.directVolumeDeviceInfo(m)
+	assert.Error(err)
+
+	c.sandbox.config.HypervisorConfig.EnableVhostUserStore = true
+	info, err = c.directVolumeDeviceInfo(m)
+	assert.NoError(err)
+	assert.Equal("xfs", info.FsType)
+	assert.Equal([]string{"ro", "noatime"}, info.Options)
+	assert.Equal(uint32(4), info.NumQueues)
+	assert.Equal(uint32(0), info.QueueSize)
+	assert.Equal(uint32(1), info.Reconnect)
+
+	// The vhost-user-blk device is given by its socket
+	dm := manager.NewDeviceManager(manager.VirtioBlock, true, "/var/run/kata-containers/vhost-user", false, nil)
+	device, err := dm.NewDevice(info.DeviceInfo)
+	assert.NoError(err)
+	vhostUserBlkDevice, ok := device.(*drivers.VhostUserBlkDevice)
+	assert.True(ok)
+	assert.Equal("/var/tmp/vhost.0", vhostUserBlkDevice.DeviceInfo.HostPath)
+}
@@ -32,6 +32,10 @@ const (
 	// DeviceGeneric is a generic device type
 	DeviceGeneric DeviceType = "generic"
 
+	// DeviceMultipath is the dm-mpath block device type, whose paths are
+	// attached to the guest
+	DeviceMultipath DeviceType = "multipath"
+
 	//VhostUserSCSI - SCSI based vhost-user type
 	VhostUserSCSI = "vhost-user-scsi-pci"
 
@@ -43,6 +47,9 @@ const (
 
 	//VhostUserFS represents a virtio-fs vhostuser device type
 	VhostUserFS = "vhost-user-fs-pci"
+
+	// VhostUserGPU represents a virtio-gpu vhostuser device type
+	VhostUserGPU = "vhost-user-gpu-pci"
 )
 
 const (
@@ -90,6 +97,13 @@ var SysIOMMUPath = "/sys/kernel/iommu_groups"
 // SysBusPciDevicesPath is static string of /sys/bus/pci/devices
 var SysBusPciDevicesPath = "/sys/bus/pci/devices"
 
+// SysBusMdevDevicesPath is static string of /sys/bus/mdev/devices
+var SysBusMdevDevicesPath = "/sys/bus/mdev/devices"
+
+// SysClassMdevBusPath is static string of /sys/class/mdev_bus, holding the
+// parent devices of the mediated devices
+var SysClassMdevBusPath = "/sys/class/mdev_bus"
+
 var getSysDevPath = getSysDevPathImpl
 
 // DeviceInfo is an embedded type that contains device data common to all types of devices.
@@ -118,6 +132,24 @@ type DeviceInfo struct {
 	// If applicable, should this device be considered RO
 	ReadOnly bool
 
+	// Discard passes the discard requests of the guest to the backing
+	// file of a block device.
+	Discard bool
+
+	// Cache and DetectZeroes are the cache ("none" or "writeback") and
+	// detect-zeroes ("off", "on" or "unmap") modes of a block device,
+	// the hypervisor defaults applying when empty.
+	Cache        string
+	DetectZeroes string
+
+	// NumQueues and QueueSize are the number and the size of the
+	// virtqueues of a vhost-user-blk device, and Reconnect the interval in
+	// seconds at which the hypervisor reconnects to its socket, the
+	// hypervisor defaults applying when 0.
+	NumQueues uint32
+	QueueSize uint32
+	Reconnect uint32
+
 	// ColdPlug specifies whether the device must be cold plugged (true)
 	// or hot plugged (false).
 	ColdPlug bool
@@ -182,6 +214,19 @@ type BlockDrive struct {
 	// Pmem enables persistent memory. Use File as backing file
 	// for a nvdimm device in the guest
 	Pmem bool
+
+	// Discard passes the discard requests of the guest to File
+	Discard bool
+
+	// Cache and DetectZeroes are the cache ("none" or "writeback") and
+	// detect-zeroes ("off", "on" or "unmap") modes of the drive, the
+	// hypervisor defaults applying when empty.
+	Cache        string
+	DetectZeroes string
+
+	// Serial is the serial number of the drive. The paths of a multipath
+	// device share the WWID of the device as serial number.
+	Serial string
 }
 
 // VFIODeviceType indicates VFIO device type
@@ -226,6 +271,21 @@ type VFIODev struct {
 
 	// Bus of VFIO PCIe device
 	Bus string
+
+	// APDevices are the AP queues, "<card>.<domain>", of a VFIO-AP
+	// mediated device
+	APDevices []string
+}
+
+// PCIHostBinding is the host driver of a PCI device bound to vfio-pci by
+// the runtime, the device is bound back to it once released.
+type PCIHostBinding struct {
+	// BDF (Bus:Device.Function) of the PCI address
+	BDF string
+
+	// Driver is the host driver, empty if the device was not bound to a
+	// driver
+	Driver string
 }
 
 // RNGDev represents a random number generator device
@@ -234,6 +294,11 @@ type RNGDev struct {
 	ID string
 	// Filename is the file to use as entropy source.
 	Filename string
+	// MaxBytes is the number of bytes the guest can read per Period,
+	// unlimited when it is 0.
+	MaxBytes uint32
+	// Period of the rate limit, in milliseconds.
+	Period uint32
 }
 
 // VhostUserDeviceAttrs represents data shared by most vhost-user devices
@@ -250,6 +315,16 @@ type VhostUserDeviceAttrs struct {
 	CacheSize uint32
 	Cache     string
 
+	// Reconnect is the interval in seconds at which the hypervisor
+	// reconnects to the vhost-user socket once the backend went away, it
+	// never does when 0.
+	Reconnect uint32
+
+	// NumQueues and QueueSize are the number and the size of the
+	// virtqueues, they are only meaningful for vhost user block devices.
+	NumQueues uint32
+	QueueSize uint32
+
 	// PCIPath is the PCI path used to identify the slot at which
 	// the drive is attached.  It is only meaningful for vhost
 	// user block devices
@@ -274,6 +349,18 @@ func GetHostPath(devInfo DeviceInfo, vhostUserStoreEnabled bool, vhostUserStoreP
 		return "", fmt.Errorf("Empty path provided for device")
 	}
 
+	// The block devices backed by a regular file have a -1 major number.
+	if devInfo.DevType == "b" && devInfo.Major == -1 {
+		return devInfo.HostPath, nil
+	}
+
+	// The vhost-user-blk devices given by their socket rather than by a
+	// device node, e.g. the SPDK direct assigned volumes, have a -1 minor
+	// number.
+	if devInfo.DevType == "b" && devInfo.Major == VhostUserBlkMajor && devInfo.Minor == -1 {
+		return devInfo.HostPath, nil
+	}
+
 	// Filter out vhost-user storage devices by device Major numbers.
 	if vhostUserStoreEnabled && devInfo.DevType == "b" &&
 		(devInfo.Major == VhostUserSCSIMajor || devInfo.Major == VhostUserBlkMajor) {
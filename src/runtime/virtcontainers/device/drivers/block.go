@@ -62,18 +62,25 @@ func (device *BlockDevice) Attach(ctx context.Context, devReceiver api.DeviceRec
 	}
 
 	drive := &config.BlockDrive{
-		File:     device.DeviceInfo.HostPath,
-		Format:   "raw",
-		ID:       utils.MakeNameID("drive", device.DeviceInfo.ID, maxDevIDSize),
-		Index:    index,
-		Pmem:     device.DeviceInfo.Pmem,
-		ReadOnly: device.DeviceInfo.ReadOnly,
+		File:         device.DeviceInfo.HostPath,
+		Format:       "raw",
+		ID:           utils.MakeNameID("drive", device.DeviceInfo.ID, maxDevIDSize),
+		Index:        index,
+		Pmem:         device.DeviceInfo.Pmem,
+		ReadOnly:     device.DeviceInfo.ReadOnly,
+		Discard:      device.DeviceInfo.Discard,
+		Cache:        device.DeviceInfo.Cache,
+		DetectZeroes: device.DeviceInfo.DetectZeroes,
 	}
 
 	if fs, ok := device.DeviceInfo.DriverOptions["fstype"]; ok {
 		drive.Format = fs
 	}
 
+	if serial, ok := device.DeviceInfo.DriverOptions["serial"]; ok {
+		drive.Serial = serial
+	}
+
 	customOptions := device.DeviceInfo.DriverOptions
 	if customOptions == nil ||
 		customOptions["block-driver"] == "virtio-scsi" {
@@ -177,6 +184,7 @@ func (device *BlockDevice) Save() persistapi.DeviceState {
 			VirtPath: drive.VirtPath,
 			DevNo:    drive.DevNo,
 			Pmem:     drive.Pmem,
+			Serial:   drive.Serial,
 		}
 	}
 	return ds
@@ -203,6 +211,7 @@ func (device *BlockDevice) Load(ds persistapi.DeviceState) {
 		VirtPath: bd.VirtPath,
 		DevNo:    bd.DevNo,
 		Pmem:     bd.Pmem,
+		Serial:   bd.Serial,
 	}
 }
 
@@ -0,0 +1,176 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package drivers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+)
+
+// maxSerialLen is the maximum length of the serial number of a SCSI disk.
+const maxSerialLen = 36
+
+// MultipathDevice refers to a dm-mpath host device. Instead of the device
+// itself, each of its paths is attached to the guest as a SCSI disk, with
+// the WWID of the device as serial number, so that multipathd assembles the
+// device again in the guest and fails over between the paths.
+type MultipathDevice struct {
+	*GenericDevice
+	WWID  string
+	Paths []*BlockDevice
+}
+
+// NewMultipathDevice creates a new multipath device based on DeviceInfo,
+// with a block device for each of its paths.
+func NewMultipathDevice(devInfo *config.DeviceInfo, wwid string, paths []config.DeviceInfo) *MultipathDevice {
+	device := &MultipathDevice{
+		GenericDevice: &GenericDevice{
+			ID:         devInfo.ID,
+			DeviceInfo: devInfo,
+		},
+		WWID: wwid,
+	}
+
+	serial := wwid
+	if len(serial) > maxSerialLen {
+		// The paths only need to share a serial number unique to the device
+		sum := sha256.Sum256([]byte(wwid))
+		serial = hex.EncodeToString(sum[:])[:maxSerialLen]
+	}
+
+	for i := range paths {
+		path := paths[i]
+		path.ID = fmt.Sprintf("%s-%d", devInfo.ID, i)
+		path.DriverOptions = map[string]string{
+			"block-driver": config.VirtioSCSI,
+			"serial":       serial,
+		}
+		device.Paths = append(device.Paths, NewBlockDevice(&path))
+	}
+
+	return device
+}
+
+// Attach is standard interface of api.Device, it's used to add device to some
+// DeviceReceiver
+func (device *MultipathDevice) Attach(ctx context.Context, devReceiver api.DeviceReceiver) (err error) {
+	skip, err := device.bumpAttachCount(true)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return nil
+	}
+
+	var attached []*BlockDevice
+
+	defer func() {
+		if err != nil {
+			for _, path := range attached {
+				if detachErr := path.Detach(ctx, devReceiver); detachErr != nil {
+					deviceLogger().WithError(detachErr).WithField("device", path.DeviceInfo.HostPath).Warn("Failed to detach multipath device path")
+				}
+			}
+			device.bumpAttachCount(false)
+		}
+	}()
+
+	deviceLogger().WithField("device", device.DeviceInfo.HostPath).WithField("wwid", device.WWID).Infof("Attaching %d multipath device paths", len(device.Paths))
+
+	for _, path := range device.Paths {
+		if err = path.Attach(ctx, devReceiver); err != nil {
+			return err
+		}
+		attached = append(attached, path)
+	}
+
+	return nil
+}
+
+// Detach is standard interface of api.Device, it's used to remove device from some
+// DeviceReceiver
+func (device *MultipathDevice) Detach(ctx context.Context, devReceiver api.DeviceReceiver) error {
+	skip, err := device.bumpAttachCount(false)
+	if err != nil {
+		return err
+	}
+	if skip {
+		return nil
+	}
+
+	deviceLogger().WithField("device", device.DeviceInfo.HostPath).WithField("wwid", device.WWID).Info("Unplugging multipath device paths")
+
+	// Detach all the paths, even if one of them fails
+	for _, path := range device.Paths {
+		if path.GetAttachCount() == 0 {
+			continue
+		}
+
+		if detachErr := path.Detach(ctx, devReceiver); detachErr != nil && err == nil {
+			err = detachErr
+		}
+	}
+
+	return err
+}
+
+// DeviceType is standard interface of api.Device, it returns device type
+func (device *MultipathDevice) DeviceType() config.DeviceType {
+	return config.DeviceMultipath
+}
+
+// GetDeviceInfo returns the drives of the paths of the device
+func (device *MultipathDevice) GetDeviceInfo() interface{} {
+	var drives []*config.BlockDrive
+	for _, path := range device.Paths {
+		if path.BlockDrive != nil {
+			drives = append(drives, path.BlockDrive)
+		}
+	}
+	return drives
+}
+
+// Save converts Device to DeviceState
+func (device *MultipathDevice) Save() persistapi.DeviceState {
+	ds := device.GenericDevice.Save()
+	ds.Type = string(device.DeviceType())
+
+	ds.Multipath = &persistapi.MultipathDevice{
+		WWID: device.WWID,
+	}
+	for _, path := range device.Paths {
+		ds.Multipath.Paths = append(ds.Multipath.Paths, path.Save())
+	}
+
+	return ds
+}
+
+// Load loads DeviceState and converts it to specific device
+func (device *MultipathDevice) Load(ds persistapi.DeviceState) {
+	device.GenericDevice = &GenericDevice{}
+	device.GenericDevice.Load(ds)
+
+	if ds.Multipath == nil {
+		return
+	}
+
+	device.WWID = ds.Multipath.WWID
+	device.Paths = nil
+	for _, pds := range ds.Multipath.Paths {
+		path := &BlockDevice{}
+		path.Load(pds)
+		device.Paths = append(device.Paths, path)
+	}
+}
+
+// It should implement GetAttachCount() and DeviceID() as api.Device implementation
+// here it shares function from *GenericDevice so we don't need duplicate codes
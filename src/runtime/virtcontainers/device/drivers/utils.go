@@ -108,3 +108,22 @@ func GetVFIODeviceType(deviceFileName string) config.VFIODeviceType {
 	}
 	return vfioDeviceType
 }
+
+// GetPCIRootComplex returns the host PCI root complex of the PCI or mediated
+// device sysfsDev, e.g. pci0000:3a for /sys/bus/pci/devices/0000:3b:00.0
+// which links to /sys/devices/pci0000:3a/0000:3a:00.0/0000:3b:00.0.
+func GetPCIRootComplex(sysfsDev string) (string, error) {
+	path, err := filepath.EvalSymlinks(sysfsDev)
+	if err != nil {
+		return "", err
+	}
+
+	for _, elem := range strings.Split(path, string(filepath.Separator)) {
+		var domain, bus uint32
+		if n, _ := fmt.Sscanf(elem, "pci%04x:%02x", &domain, &bus); n == 2 && len(elem) == len("pci0000:00") {
+			return elem, nil
+		}
+	}
+
+	return "", fmt.Errorf("no PCI root complex found for %s", sysfsDev)
+}
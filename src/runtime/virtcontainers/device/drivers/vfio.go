@@ -20,6 +20,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/uuid"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 )
 
@@ -32,6 +33,8 @@ const (
 	iommuGroupPath      = "/sys/bus/pci/devices/%s/iommu_group"
 	vfioDevPath         = "/dev/vfio/%s"
 	pcieRootPortPrefix  = "rp"
+	vfioAPMatrix        = "matrix"
+	vfioPCIDriver       = "vfio-pci"
 )
 
 var (
@@ -43,6 +46,14 @@ var (
 type VFIODevice struct {
 	*GenericDevice
 	VfioDevs []*config.VFIODev
+
+	// HostBindings are the host drivers of the devices of the group bound
+	// to vfio-pci by the runtime, to bind them back once released.
+	HostBindings []config.PCIHostBinding
+
+	// CreatedMdevs are the sysfs paths of the mediated devices of the group
+	// created by the runtime, to remove them once released.
+	CreatedMdevs []string
 }
 
 // NewVFIODevice create a new VFIO device
@@ -95,6 +106,11 @@ func (device *VFIODevice) Attach(ctx context.Context, devReceiver api.DeviceRece
 			IsPCIe:   isPCIeDevice(deviceBDF),
 			Class:    getPCIDeviceProperty(deviceBDF, PCISysFsDevicesClass),
 		}
+		if vfioDeviceType == config.VFIODeviceMediatedType && utils.IsAPVFIOMediatedDevice(deviceSysfsDev) {
+			if vfio.APDevices, err = getAPVFIODevices(deviceSysfsDev); err != nil {
+				return err
+			}
+		}
 		device.VfioDevs = append(device.VfioDevs, vfio)
 		if vfio.IsPCIe {
 			vfio.Bus = fmt.Sprintf("%s%d", pcieRootPortPrefix, len(AllPCIeDevs))
@@ -183,13 +199,22 @@ func (device *VFIODevice) Save() persistapi.DeviceState {
 	for _, dev := range devs {
 		if dev != nil {
 			ds.VFIODevs = append(ds.VFIODevs, &persistapi.VFIODev{
-				ID:       dev.ID,
-				Type:     uint32(dev.Type),
-				BDF:      dev.BDF,
-				SysfsDev: dev.SysfsDev,
+				ID:        dev.ID,
+				Type:      uint32(dev.Type),
+				BDF:       dev.BDF,
+				SysfsDev:  dev.SysfsDev,
+				APDevices: dev.APDevices,
 			})
 		}
 	}
+
+	for _, b := range device.HostBindings {
+		ds.VFIOHostBindings = append(ds.VFIOHostBindings, persistapi.PCIHostBinding{
+			BDF:    b.BDF,
+			Driver: b.Driver,
+		})
+	}
+	ds.VFIOCreatedMdevs = device.CreatedMdevs
 	return ds
 }
 
@@ -200,12 +225,21 @@ func (device *VFIODevice) Load(ds persistapi.DeviceState) {
 
 	for _, dev := range ds.VFIODevs {
 		device.VfioDevs = append(device.VfioDevs, &config.VFIODev{
-			ID:       dev.ID,
-			Type:     config.VFIODeviceType(dev.Type),
-			BDF:      dev.BDF,
-			SysfsDev: dev.SysfsDev,
+			ID:        dev.ID,
+			Type:      config.VFIODeviceType(dev.Type),
+			BDF:       dev.BDF,
+			SysfsDev:  dev.SysfsDev,
+			APDevices: dev.APDevices,
 		})
 	}
+
+	for _, b := range ds.VFIOHostBindings {
+		device.HostBindings = append(device.HostBindings, config.PCIHostBinding{
+			BDF:    b.BDF,
+			Driver: b.Driver,
+		})
+	}
+	device.CreatedMdevs = ds.VFIOCreatedMdevs
 }
 
 // It should implement GetAttachCount() and DeviceID() as api.Device implementation
@@ -244,6 +278,32 @@ func getSysfsDev(sysfsDevStr string) (string, error) {
 	return filepath.EvalSymlinks(sysfsDevStr)
 }
 
+// getAPVFIODevices returns the AP queues assigned to a VFIO-AP mediated device,
+// read from its matrix attribute. Each line of the matrix is an AP queue,
+// "<card>.<domain>", e.g. "05.0032", or a card or domain which is assigned
+// alone, e.g. "05." or ".0032", and cannot be used.
+func getAPVFIODevices(sysfsDev string) ([]string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(sysfsDev, vfioAPMatrix))
+	if err != nil {
+		return nil, err
+	}
+
+	var apDevices []string
+	for _, line := range strings.Split(string(data), "\n") {
+		tokens := strings.Split(strings.TrimSpace(line), ".")
+		if len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+			continue
+		}
+		apDevices = append(apDevices, strings.TrimSpace(line))
+	}
+
+	if len(apDevices) == 0 {
+		return nil, fmt.Errorf("no AP queue assigned to the VFIO-AP device %s", sysfsDev)
+	}
+
+	return apDevices, nil
+}
+
 // BindDevicetoVFIO binds the device to vfio driver after unbinding from host.
 // Will be called by a network interface or a generic pcie device.
 func BindDevicetoVFIO(bdf, hostDriver, vendorDeviceID string) (string, error) {
@@ -315,3 +375,182 @@ func BindDevicetoHost(bdf, hostDriver, vendorDeviceID string) error {
 
 	return utils.WriteToFile(bindDriverPath, []byte(bdf))
 }
+
+// pciDeviceDriver returns the driver the PCI device bdf is bound to, empty if
+// it is not bound to any driver.
+func pciDeviceDriver(bdf string) (string, error) {
+	link, err := os.Readlink(filepath.Join(config.SysBusPciDevicesPath, bdf, "driver"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(link), nil
+}
+
+// BindPCIDeviceToVFIO binds the PCI device bdf to the vfio-pci driver through
+// its driver_override, so that the other devices with the same vendor and
+// device IDs are left to their driver. It returns the path of the VFIO group
+// of the device and the host driver binding to restore when the device is
+// released, nil if the device already was bound to vfio-pci.
+func BindPCIDeviceToVFIO(bdf string) (string, *config.PCIHostBinding, error) {
+	devPath := filepath.Join(config.SysBusPciDevicesPath, bdf)
+	if _, err := os.Stat(devPath); err != nil {
+		return "", nil, fmt.Errorf("PCI device %s not found: %v", bdf, err)
+	}
+
+	hostDriver, err := pciDeviceDriver(bdf)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var binding *config.PCIHostBinding
+	if hostDriver != vfioPCIDriver {
+		deviceLogger().WithFields(logrus.Fields{
+			"device-bdf":  bdf,
+			"host-driver": hostDriver,
+		}).Info("Binding device to vfio-pci")
+
+		binding = &config.PCIHostBinding{
+			BDF:    bdf,
+			Driver: hostDriver,
+		}
+
+		if err := bindPCIDeviceToVFIO(bdf, hostDriver); err != nil {
+			if restoreErr := BindPCIDeviceToHost(*binding); restoreErr != nil {
+				deviceLogger().WithError(restoreErr).WithField("device-bdf", bdf).Warn("Failed to bind device back to host driver")
+			}
+			return "", nil, err
+		}
+	}
+
+	groupPath, err := os.Readlink(filepath.Join(devPath, "iommu_group"))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get the IOMMU group of %s: %v", bdf, err)
+	}
+
+	return fmt.Sprintf(vfioDevPath, filepath.Base(groupPath)), binding, nil
+}
+
+func bindPCIDeviceToVFIO(bdf, hostDriver string) error {
+	devPath := filepath.Join(config.SysBusPciDevicesPath, bdf)
+
+	if err := utils.WriteToFile(filepath.Join(devPath, "driver_override"), []byte(vfioPCIDriver)); err != nil {
+		return fmt.Errorf("failed to override the driver of %s: %v", bdf, err)
+	}
+
+	if hostDriver != "" {
+		if err := utils.WriteToFile(filepath.Join(devPath, "driver", "unbind"), []byte(bdf)); err != nil {
+			return fmt.Errorf("failed to unbind %s from %s: %v", bdf, hostDriver, err)
+		}
+	}
+
+	probePath := filepath.Join(filepath.Dir(config.SysBusPciDevicesPath), "drivers_probe")
+	if err := utils.WriteToFile(probePath, []byte(bdf)); err != nil {
+		return fmt.Errorf("failed to probe the driver of %s: %v", bdf, err)
+	}
+
+	driver, err := pciDeviceDriver(bdf)
+	if err != nil {
+		return err
+	}
+	if driver != vfioPCIDriver {
+		return fmt.Errorf("failed to bind %s to %s, is the vfio-pci module loaded?", bdf, vfioPCIDriver)
+	}
+
+	return nil
+}
+
+// BindPCIDeviceToHost binds the PCI device of binding, bound to vfio-pci by
+// BindPCIDeviceToVFIO, back to its host driver.
+func BindPCIDeviceToHost(binding config.PCIHostBinding) error {
+	devPath := filepath.Join(config.SysBusPciDevicesPath, binding.BDF)
+
+	deviceLogger().WithFields(logrus.Fields{
+		"device-bdf":  binding.BDF,
+		"host-driver": binding.Driver,
+	}).Info("Binding device back to host driver")
+
+	// An empty line clears the driver override
+	if err := utils.WriteToFile(filepath.Join(devPath, "driver_override"), []byte("\n")); err != nil {
+		return fmt.Errorf("failed to clear the driver override of %s: %v", binding.BDF, err)
+	}
+
+	driver, err := pciDeviceDriver(binding.BDF)
+	if err != nil {
+		return err
+	}
+	if driver == binding.Driver {
+		return nil
+	}
+
+	if driver != "" {
+		if err := utils.WriteToFile(filepath.Join(devPath, "driver", "unbind"), []byte(binding.BDF)); err != nil {
+			return fmt.Errorf("failed to unbind %s from %s: %v", binding.BDF, driver, err)
+		}
+	}
+
+	if binding.Driver == "" {
+		return nil
+	}
+
+	bindPath := filepath.Join(filepath.Dir(config.SysBusPciDevicesPath), "drivers", binding.Driver, "bind")
+	return utils.WriteToFile(bindPath, []byte(binding.BDF))
+}
+
+// CreateMediatedDevice creates a mediated device of the type typePath, e.g.
+// /sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63, and
+// returns its sysfs path, e.g. /sys/bus/mdev/devices/<uuid>.
+func CreateMediatedDevice(typePath string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(typePath, "available_instances"))
+	if err != nil {
+		return "", fmt.Errorf("mediated device type %s not found: %v", typePath, err)
+	}
+
+	available, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return "", fmt.Errorf("invalid available instances of mediated device type %s: %v", typePath, err)
+	}
+	if available < 1 {
+		return "", fmt.Errorf("no instance of mediated device type %s available", typePath)
+	}
+
+	id := uuid.Generate().String()
+	if err := utils.WriteToFile(filepath.Join(typePath, "create"), []byte(id)); err != nil {
+		return "", fmt.Errorf("failed to create a mediated device of type %s: %v", typePath, err)
+	}
+
+	sysfsDev := filepath.Join(config.SysBusMdevDevicesPath, id)
+
+	deviceLogger().WithFields(logrus.Fields{
+		"mdev-type":     typePath,
+		"mdev-sysfsdev": sysfsDev,
+	}).Info("Created mediated device")
+
+	return sysfsDev, nil
+}
+
+// RemoveMediatedDevice removes the mediated device sysfsDev created by
+// CreateMediatedDevice.
+func RemoveMediatedDevice(sysfsDev string) error {
+	deviceLogger().WithField("mdev-sysfsdev", sysfsDev).Info("Removing mediated device")
+
+	if err := utils.WriteToFile(filepath.Join(sysfsDev, "remove"), []byte("1")); err != nil {
+		return fmt.Errorf("failed to remove mediated device %s: %v", sysfsDev, err)
+	}
+
+	return nil
+}
+
+// MediatedDeviceVFIOGroup returns the path of the VFIO group of the mediated
+// device sysfsDev, e.g. /dev/vfio/42.
+func MediatedDeviceVFIOGroup(sysfsDev string) (string, error) {
+	groupPath, err := os.Readlink(filepath.Join(sysfsDev, "iommu_group"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the IOMMU group of %s, is the vendor driver bound to vfio_mdev? %v", sysfsDev, err)
+	}
+
+	return fmt.Sprintf(vfioDevPath, filepath.Base(groupPath)), nil
+}
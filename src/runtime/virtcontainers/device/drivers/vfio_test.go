@@ -7,6 +7,9 @@
 package drivers
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
@@ -47,3 +50,207 @@ func TestGetVFIODetails(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAPVFIODevices(t *testing.T) {
+	assert := assert.New(t)
+
+	sysfsDev, err := ioutil.TempDir("", "matrix")
+	assert.NoError(err)
+	defer os.RemoveAll(sysfsDev)
+
+	_, err = getAPVFIODevices(sysfsDev)
+	assert.Error(err)
+
+	matrix := filepath.Join(sysfsDev, vfioAPMatrix)
+	err = ioutil.WriteFile(matrix, []byte("05.0032\n05.0033\n06.\n.0034\n"), 0644)
+	assert.NoError(err)
+
+	apDevices, err := getAPVFIODevices(sysfsDev)
+	assert.NoError(err)
+	assert.Equal([]string{"05.0032", "05.0033"}, apDevices)
+
+	// No AP queue when only cards or domains are assigned
+	err = ioutil.WriteFile(matrix, []byte("06.\n.0034\n"), 0644)
+	assert.NoError(err)
+
+	_, err = getAPVFIODevices(sysfsDev)
+	assert.Error(err)
+}
+
+// fakePCIDevice creates the sysfs tree of the PCI device bdf in IOMMU group
+// 42, bound to driver unless it is empty, and returns its sysfs path.
+func fakePCIDevice(t *testing.T, bdf, driver string) string {
+	assert := assert.New(t)
+
+	bus := filepath.Join(t.TempDir(), "pci")
+	devPath := filepath.Join(bus, "devices", bdf)
+	assert.NoError(os.MkdirAll(devPath, 0755))
+	assert.NoError(ioutil.WriteFile(filepath.Join(devPath, "driver_override"), []byte("(null)\n"), 0644))
+	assert.NoError(ioutil.WriteFile(filepath.Join(bus, "drivers_probe"), nil, 0644))
+	assert.NoError(os.Symlink("../../../../kernel/iommu_groups/42", filepath.Join(devPath, "iommu_group")))
+
+	for _, d := range []string{vfioPCIDriver, "e1000e"} {
+		driverPath := filepath.Join(bus, "drivers", d)
+		assert.NoError(os.MkdirAll(driverPath, 0755))
+		assert.NoError(ioutil.WriteFile(filepath.Join(driverPath, "bind"), nil, 0644))
+		assert.NoError(ioutil.WriteFile(filepath.Join(driverPath, "unbind"), nil, 0644))
+	}
+
+	if driver != "" {
+		assert.NoError(os.Symlink(filepath.Join(bus, "drivers", driver), filepath.Join(devPath, "driver")))
+	}
+
+	return devPath
+}
+
+func TestBindPCIDeviceToVFIO(t *testing.T) {
+	assert := assert.New(t)
+	bdf := "0000:3b:00.0"
+
+	savedSysBusPciDevicesPath := config.SysBusPciDevicesPath
+	defer func() {
+		config.SysBusPciDevicesPath = savedSysBusPciDevicesPath
+	}()
+
+	// The device does not exist
+	devPath := fakePCIDevice(t, bdf, vfioPCIDriver)
+	config.SysBusPciDevicesPath = filepath.Dir(devPath)
+	_, _, err := BindPCIDeviceToVFIO("0000:3b:00.1")
+	assert.Error(err)
+
+	// The device is already bound to vfio-pci
+	groupPath, binding, err := BindPCIDeviceToVFIO(bdf)
+	assert.NoError(err)
+	assert.Equal("/dev/vfio/42", groupPath)
+	assert.Nil(binding)
+
+	// The device stays bound to its host driver, e.g. when vfio-pci is
+	// not loaded, it is bound back to it.
+	devPath = fakePCIDevice(t, bdf, "e1000e")
+	config.SysBusPciDevicesPath = filepath.Dir(devPath)
+	_, _, err = BindPCIDeviceToVFIO(bdf)
+	assert.Error(err)
+
+	bus := filepath.Dir(filepath.Dir(devPath))
+	content, err := ioutil.ReadFile(filepath.Join(bus, "drivers_probe"))
+	assert.NoError(err)
+	assert.Equal(bdf, string(content))
+	content, err = ioutil.ReadFile(filepath.Join(bus, "drivers", "e1000e", "unbind"))
+	assert.NoError(err)
+	assert.Equal(bdf, string(content))
+}
+
+func TestBindPCIDeviceToHost(t *testing.T) {
+	assert := assert.New(t)
+	bdf := "0000:3b:00.0"
+
+	savedSysBusPciDevicesPath := config.SysBusPciDevicesPath
+	defer func() {
+		config.SysBusPciDevicesPath = savedSysBusPciDevicesPath
+	}()
+
+	devPath := fakePCIDevice(t, bdf, vfioPCIDriver)
+	config.SysBusPciDevicesPath = filepath.Dir(devPath)
+	bus := filepath.Dir(filepath.Dir(devPath))
+
+	err := BindPCIDeviceToHost(config.PCIHostBinding{BDF: bdf, Driver: "e1000e"})
+	assert.NoError(err)
+
+	content, err := ioutil.ReadFile(filepath.Join(devPath, "driver_override"))
+	assert.NoError(err)
+	assert.Equal("\n", string(content[:1]))
+	content, err = ioutil.ReadFile(filepath.Join(bus, "drivers", vfioPCIDriver, "unbind"))
+	assert.NoError(err)
+	assert.Equal(bdf, string(content))
+	content, err = ioutil.ReadFile(filepath.Join(bus, "drivers", "e1000e", "bind"))
+	assert.NoError(err)
+	assert.Equal(bdf, string(content))
+
+	// The device was not bound to a driver
+	devPath = fakePCIDevice(t, bdf, vfioPCIDriver)
+	config.SysBusPciDevicesPath = filepath.Dir(devPath)
+	bus = filepath.Dir(filepath.Dir(devPath))
+
+	err = BindPCIDeviceToHost(config.PCIHostBinding{BDF: bdf})
+	assert.NoError(err)
+	content, err = ioutil.ReadFile(filepath.Join(bus, "drivers", "e1000e", "bind"))
+	assert.NoError(err)
+	assert.Empty(content)
+}
+
+func TestMediatedDevice(t *testing.T) {
+	assert := assert.New(t)
+	tmpDir := t.TempDir()
+
+	savedSysBusMdevDevicesPath := config.SysBusMdevDevicesPath
+	config.SysBusMdevDevicesPath = filepath.Join(tmpDir, "bus", "mdev", "devices")
+	defer func() {
+		config.SysBusMdevDevicesPath = savedSysBusMdevDevicesPath
+	}()
+
+	typePath := filepath.Join(tmpDir, "class", "mdev_bus", "0000:3b:00.0", "mdev_supported_types", "nvidia-63")
+	assert.NoError(os.MkdirAll(typePath, 0755))
+
+	// The type does not exist
+	_, err := CreateMediatedDevice(filepath.Join(filepath.Dir(typePath), "nvidia-64"))
+	assert.Error(err)
+
+	// No instance available
+	assert.NoError(ioutil.WriteFile(filepath.Join(typePath, "available_instances"), []byte("0\n"), 0644))
+	_, err = CreateMediatedDevice(typePath)
+	assert.Error(err)
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(typePath, "available_instances"), []byte("4\n"), 0644))
+	assert.NoError(ioutil.WriteFile(filepath.Join(typePath, "create"), nil, 0644))
+	sysfsDev, err := CreateMediatedDevice(typePath)
+	assert.NoError(err)
+	assert.Equal(config.SysBusMdevDevicesPath, filepath.Dir(sysfsDev))
+	content, err := ioutil.ReadFile(filepath.Join(typePath, "create"))
+	assert.NoError(err)
+	assert.Equal(filepath.Base(sysfsDev), string(content))
+	assert.Equal(config.VFIODeviceMediatedType, GetVFIODeviceType(filepath.Base(sysfsDev)))
+
+	// The vendor driver creates the device, bound to vfio_mdev
+	assert.NoError(os.MkdirAll(sysfsDev, 0755))
+	_, err = MediatedDeviceVFIOGroup(sysfsDev)
+	assert.Error(err)
+	assert.NoError(os.Symlink("../../../../kernel/iommu_groups/42", filepath.Join(sysfsDev, "iommu_group")))
+	groupPath, err := MediatedDeviceVFIOGroup(sysfsDev)
+	assert.NoError(err)
+	assert.Equal("/dev/vfio/42", groupPath)
+
+	assert.Error(RemoveMediatedDevice(sysfsDev))
+	assert.NoError(ioutil.WriteFile(filepath.Join(sysfsDev, "remove"), nil, 0644))
+	assert.NoError(RemoveMediatedDevice(sysfsDev))
+	content, err = ioutil.ReadFile(filepath.Join(sysfsDev, "remove"))
+	assert.NoError(err)
+	assert.Equal("1", string(content))
+}
+
+func TestGetPCIRootComplex(t *testing.T) {
+	assert := assert.New(t)
+	tmpDir := t.TempDir()
+
+	deviceDir := filepath.Join(tmpDir, "devices", "pci0000:3a", "0000:3a:00.0", "0000:3b:00.0")
+	assert.NoError(os.MkdirAll(deviceDir, 0755))
+	busDir := filepath.Join(tmpDir, "bus", "pci", "devices")
+	assert.NoError(os.MkdirAll(busDir, 0755))
+	assert.NoError(os.Symlink("../../../devices/pci0000:3a/0000:3a:00.0/0000:3b:00.0", filepath.Join(busDir, "0000:3b:00.0")))
+
+	rootComplex, err := GetPCIRootComplex(filepath.Join(busDir, "0000:3b:00.0"))
+	assert.NoError(err)
+	assert.Equal("pci0000:3a", rootComplex)
+
+	// Mediated devices are below their parent device
+	mdevDir := filepath.Join(deviceDir, "f79944e4-5a3d-11e8-99ce-479cbab002e4")
+	assert.NoError(os.MkdirAll(mdevDir, 0755))
+	rootComplex, err = GetPCIRootComplex(mdevDir)
+	assert.NoError(err)
+	assert.Equal("pci0000:3a", rootComplex)
+
+	_, err = GetPCIRootComplex(filepath.Join(busDir, "0000:3b:00.1"))
+	assert.Error(err)
+
+	_, err = GetPCIRootComplex(filepath.Join(tmpDir, "bus"))
+	assert.Error(err)
+}
@@ -76,6 +76,9 @@ func (device *VhostUserBlkDevice) Attach(ctx context.Context, devReceiver api.De
 		DevID:      utils.MakeNameID("blk", device.DeviceInfo.ID, maxDevIDSize),
 		SocketPath: device.DeviceInfo.HostPath,
 		Type:       config.VhostUserBlk,
+		Reconnect:  device.DeviceInfo.Reconnect,
+		NumQueues:  device.DeviceInfo.NumQueues,
+		QueueSize:  device.DeviceInfo.QueueSize,
 		Index:      index,
 	}
 
@@ -166,6 +169,9 @@ func (device *VhostUserBlkDevice) Save() persistapi.DeviceState {
 			DevID:      vAttr.DevID,
 			SocketPath: vAttr.SocketPath,
 			Type:       string(vAttr.Type),
+			Reconnect:  vAttr.Reconnect,
+			NumQueues:  vAttr.NumQueues,
+			QueueSize:  vAttr.QueueSize,
 			PCIPath:    vAttr.PCIPath,
 			Index:      vAttr.Index,
 		}
@@ -187,6 +193,9 @@ func (device *VhostUserBlkDevice) Load(ds persistapi.DeviceState) {
 		DevID:      dev.DevID,
 		SocketPath: dev.SocketPath,
 		Type:       config.DeviceType(dev.Type),
+		Reconnect:  dev.Reconnect,
+		NumQueues:  dev.NumQueues,
+		QueueSize:  dev.QueueSize,
 		PCIPath:    dev.PCIPath,
 		Index:      dev.Index,
 	}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -52,8 +53,16 @@ type deviceManager struct {
 	vhostUserStoreEnabled bool
 	vhostUserStorePath    string
 
+	// multipathEnabled attaches the paths of the dm-mpath devices
+	// rather than the devices themselves.
+	multipathEnabled bool
+
 	devices map[string]api.Device
 	sync.RWMutex
+
+	// deviceLocks serialize the attachments and detachments of each
+	// device, those of different devices run concurrently.
+	deviceLocks map[string]*sync.Mutex
 }
 
 func deviceLogger() *logrus.Entry {
@@ -61,10 +70,11 @@ func deviceLogger() *logrus.Entry {
 }
 
 // NewDeviceManager creates a deviceManager object behaved as api.DeviceManager
-func NewDeviceManager(blockDriver string, vhostUserStoreEnabled bool, vhostUserStorePath string, devices []api.Device) api.DeviceManager {
+func NewDeviceManager(blockDriver string, vhostUserStoreEnabled bool, vhostUserStorePath string, multipathEnabled bool, devices []api.Device) api.DeviceManager {
 	dm := &deviceManager{
 		vhostUserStoreEnabled: vhostUserStoreEnabled,
 		vhostUserStorePath:    vhostUserStorePath,
+		multipathEnabled:      multipathEnabled,
 		devices:               make(map[string]api.Device),
 	}
 	if blockDriver == VirtioMmio {
@@ -87,10 +97,20 @@ func NewDeviceManager(blockDriver string, vhostUserStoreEnabled bool, vhostUserS
 	return dm
 }
 
-func (dm *deviceManager) findDeviceByMajorMinor(major, minor int64) api.Device {
+// findDevice returns the known device of devInfo. The block devices which
+// are not host devices, e.g. backed by a regular file, have a -1 major
+// number and are found by host path.
+func (dm *deviceManager) findDevice(devInfo config.DeviceInfo) api.Device {
 	for _, dev := range dm.devices {
+		if devInfo.Major == -1 {
+			if dev.GetHostPath() == devInfo.HostPath {
+				return dev
+			}
+			continue
+		}
+
 		dma, dmi := dev.GetMajorMinor()
-		if dma == major && dmi == minor {
+		if dma == devInfo.Major && dmi == devInfo.Minor {
 			return dev
 		}
 	}
@@ -99,6 +119,35 @@ func (dm *deviceManager) findDeviceByMajorMinor(major, minor int64) api.Device {
 
 // createDevice creates one device based on DeviceInfo
 func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device, err error) {
+	// PCI devices are bound to vfio-pci and passed as their vfio group.
+	var binding *config.PCIHostBinding
+	if isPCIDevice(devInfo.HostPath) {
+		if devInfo, binding, err = bindPCIDevice(devInfo); err != nil {
+			return nil, err
+		}
+
+		defer func() {
+			if err != nil && binding != nil {
+				drivers.BindPCIDeviceToHost(*binding)
+			}
+		}()
+	}
+
+	// Mediated devices, created on demand from their type if needed, are
+	// passed as their vfio group.
+	var createdMdev string
+	if isMdevDevice(devInfo.HostPath) || isMdevType(devInfo.HostPath) {
+		if devInfo, createdMdev, err = mdevDevice(devInfo); err != nil {
+			return nil, err
+		}
+
+		defer func() {
+			if err != nil && createdMdev != "" {
+				drivers.RemoveMediatedDevice(createdMdev)
+			}
+		}()
+	}
+
 	// pmem device may points to block devices or raw files,
 	// do not change its HostPath.
 	if !devInfo.Pmem {
@@ -115,7 +164,24 @@ func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device
 		}
 	}()
 
-	if existingDev := dm.findDeviceByMajorMinor(devInfo.Major, devInfo.Minor); existingDev != nil {
+	defer func() {
+		if err != nil || (binding == nil && createdMdev == "") {
+			return
+		}
+		vfioDev, ok := dev.(*drivers.VFIODevice)
+		if !ok {
+			err = fmt.Errorf("device %s is not a vfio group", devInfo.HostPath)
+			return
+		}
+		if binding != nil {
+			vfioDev.HostBindings = append(vfioDev.HostBindings, *binding)
+		}
+		if createdMdev != "" {
+			vfioDev.CreatedMdevs = append(vfioDev.CreatedMdevs, createdMdev)
+		}
+	}()
+
+	if existingDev := dm.findDevice(devInfo); existingDev != nil {
 		return existingDev, nil
 	}
 
@@ -133,6 +199,17 @@ func (dm *deviceManager) createDevice(devInfo config.DeviceInfo) (dev api.Device
 		devInfo.DriverOptions["block-driver"] = dm.blockDriver
 		return drivers.NewVhostUserBlkDevice(&devInfo), nil
 	} else if isBlock(devInfo) {
+		if dm.multipathEnabled && dm.blockDriver == VirtioSCSI && !devInfo.Pmem && devInfo.Major != -1 {
+			var wwid string
+			var paths []config.DeviceInfo
+			if wwid, paths, err = getMultipathInfo(devInfo); err != nil {
+				return nil, err
+			}
+			if wwid != "" {
+				return drivers.NewMultipathDevice(&devInfo, wwid, paths), nil
+			}
+		}
+
 		if devInfo.DriverOptions == nil {
 			devInfo.DriverOptions = make(map[string]string)
 		}
@@ -157,18 +234,21 @@ func (dm *deviceManager) NewDevice(devInfo config.DeviceInfo) (api.Device, error
 
 // RemoveDevice deletes the device from list based on specified device id
 func (dm *deviceManager) RemoveDevice(id string) error {
+	dev, unlock, err := dm.lockDevice(id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	dm.Lock()
 	defer dm.Unlock()
-	dev, ok := dm.devices[id]
-	if !ok {
-		return ErrDeviceNotExist
-	}
 
 	if dev.Dereference() == 0 {
 		if dev.GetAttachCount() > 0 {
 			return ErrRemoveAttachedDevice
 		}
 		delete(dm.devices, id)
+		delete(dm.deviceLocks, id)
 	}
 	return nil
 }
@@ -190,14 +270,37 @@ func (dm *deviceManager) newDeviceID() (string, error) {
 	return "", ErrIDExhausted
 }
 
-func (dm *deviceManager) AttachDevice(ctx context.Context, id string, dr api.DeviceReceiver) error {
+// lockDevice returns the device locked, along with its unlock function. The
+// device manager lock is not held while the device is locked, the devices
+// being attached by the hypervisor.
+func (dm *deviceManager) lockDevice(id string) (api.Device, func(), error) {
 	dm.Lock()
-	defer dm.Unlock()
-
 	d, ok := dm.devices[id]
 	if !ok {
-		return ErrDeviceNotExist
+		dm.Unlock()
+		return nil, nil, ErrDeviceNotExist
+	}
+
+	if dm.deviceLocks == nil {
+		dm.deviceLocks = make(map[string]*sync.Mutex)
+	}
+	l, ok := dm.deviceLocks[id]
+	if !ok {
+		l = &sync.Mutex{}
+		dm.deviceLocks[id] = l
 	}
+	dm.Unlock()
+
+	l.Lock()
+	return d, l.Unlock, nil
+}
+
+func (dm *deviceManager) AttachDevice(ctx context.Context, id string, dr api.DeviceReceiver) error {
+	d, unlock, err := dm.lockDevice(id)
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	if err := d.Attach(ctx, dr); err != nil {
 		return err
@@ -206,13 +309,12 @@ func (dm *deviceManager) AttachDevice(ctx context.Context, id string, dr api.Dev
 }
 
 func (dm *deviceManager) DetachDevice(ctx context.Context, id string, dr api.DeviceReceiver) error {
-	dm.Lock()
-	defer dm.Unlock()
-
-	d, ok := dm.devices[id]
-	if !ok {
-		return ErrDeviceNotExist
+	d, unlock, err := dm.lockDevice(id)
+	if err != nil {
+		return err
 	}
+	defer unlock()
+
 	if d.GetAttachCount() == 0 {
 		return ErrDeviceNotAttached
 	}
@@ -243,12 +345,12 @@ func (dm *deviceManager) GetAllDevices() []api.Device {
 }
 
 func (dm *deviceManager) IsDeviceAttached(id string) bool {
-	dm.RLock()
-	defer dm.RUnlock()
-	d, ok := dm.devices[id]
-	if !ok {
+	d, unlock, err := dm.lockDevice(id)
+	if err != nil {
 		return false
 	}
+	defer unlock()
+
 	return d.GetAttachCount() > 0
 }
 
@@ -265,6 +367,8 @@ func (dm *deviceManager) LoadDevices(devStates []persistapi.DeviceState) {
 			dev = &drivers.GenericDevice{}
 		case config.DeviceBlock:
 			dev = &drivers.BlockDevice{}
+		case config.DeviceMultipath:
+			dev = &drivers.MultipathDevice{}
 		case config.DeviceVFIO:
 			dev = &drivers.VFIODevice{}
 		case config.VhostUserSCSI:
@@ -19,6 +19,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/stretchr/testify/assert"
 
 	"golang.org/x/sys/unix"
@@ -99,6 +100,36 @@ func TestNewDevice(t *testing.T) {
 	assert.Equal(t, vfioDev.DeviceInfo.GID, uint32(2))
 }
 
+func TestNewFileBackedBlockDevice(t *testing.T) {
+	assert := assert.New(t)
+	dm := &deviceManager{
+		blockDriver: VirtioBlock,
+		devices:     make(map[string]api.Device),
+	}
+
+	newDeviceInfo := func(path string) config.DeviceInfo {
+		return config.DeviceInfo{
+			HostPath:      path,
+			ContainerPath: "/scratch",
+			DevType:       "b",
+			Major:         -1,
+		}
+	}
+
+	device, err := dm.NewDevice(newDeviceInfo("/tmp/disk1.img"))
+	assert.NoError(err)
+	assert.Equal("/tmp/disk1.img", device.GetHostPath())
+
+	// found by host path
+	other, err := dm.NewDevice(newDeviceInfo("/tmp/disk2.img"))
+	assert.NoError(err)
+	assert.NotEqual(device.DeviceID(), other.DeviceID())
+
+	same, err := dm.NewDevice(newDeviceInfo("/tmp/disk1.img"))
+	assert.NoError(err)
+	assert.Equal(device.DeviceID(), same.DeviceID())
+}
+
 func TestAttachVFIODevice(t *testing.T) {
 	dm := &deviceManager{
 		blockDriver: VirtioBlock,
@@ -158,6 +189,101 @@ func TestAttachVFIODevice(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestAttachPCIDevice(t *testing.T) {
+	assert := assert.New(t)
+	dm := &deviceManager{
+		blockDriver: VirtioBlock,
+		devices:     make(map[string]api.Device),
+	}
+
+	tmpDir := t.TempDir()
+	devicesDir := filepath.Join(tmpDir, "devices")
+	deviceDir := filepath.Join(devicesDir, "0000:3b:00.0")
+	assert.NoError(os.MkdirAll(deviceDir, dirMode))
+	assert.NoError(os.MkdirAll(filepath.Join(tmpDir, "drivers", "vfio-pci"), dirMode))
+	assert.NoError(os.Symlink(filepath.Join(tmpDir, "drivers", "vfio-pci"), filepath.Join(deviceDir, "driver")))
+	assert.NoError(os.Symlink("../../../../kernel/iommu_groups/4242", filepath.Join(deviceDir, "iommu_group")))
+
+	savedSysBusPciDevicesPath := config.SysBusPciDevicesPath
+	config.SysBusPciDevicesPath = devicesDir
+	defer func() {
+		config.SysBusPciDevicesPath = savedSysBusPciDevicesPath
+	}()
+
+	// The PCI device does not exist
+	path := filepath.Join(devicesDir, "0000:3b:00.1")
+	_, err := dm.NewDevice(config.DeviceInfo{
+		HostPath:      path,
+		ContainerPath: path,
+	})
+	assert.Error(err)
+
+	// The device is bound to vfio-pci but its VFIO group does not exist
+	_, err = dm.NewDevice(config.DeviceInfo{
+		HostPath:      deviceDir,
+		ContainerPath: deviceDir,
+	})
+	assert.Error(err)
+	assert.Contains(err.Error(), "/dev/vfio/4242")
+	assert.Empty(dm.GetAllDevices())
+}
+
+func TestAttachMdevDevice(t *testing.T) {
+	assert := assert.New(t)
+	dm := &deviceManager{
+		blockDriver: VirtioBlock,
+		devices:     make(map[string]api.Device),
+	}
+
+	tmpDir := t.TempDir()
+	devicesDir := filepath.Join(tmpDir, "bus", "mdev", "devices")
+	mdevBusDir := filepath.Join(tmpDir, "class", "mdev_bus")
+	typePath := filepath.Join(mdevBusDir, "0000:3b:00.0", "mdev_supported_types", "nvidia-63")
+	assert.NoError(os.MkdirAll(devicesDir, dirMode))
+	assert.NoError(os.MkdirAll(typePath, dirMode))
+	assert.NoError(ioutil.WriteFile(filepath.Join(typePath, "available_instances"), []byte("1\n"), 0644))
+	assert.NoError(ioutil.WriteFile(filepath.Join(typePath, "create"), nil, 0644))
+
+	savedSysBusMdevDevicesPath := config.SysBusMdevDevicesPath
+	savedSysClassMdevBusPath := config.SysClassMdevBusPath
+	config.SysBusMdevDevicesPath = devicesDir
+	config.SysClassMdevBusPath = mdevBusDir
+	defer func() {
+		config.SysBusMdevDevicesPath = savedSysBusMdevDevicesPath
+		config.SysClassMdevBusPath = savedSysClassMdevBusPath
+	}()
+
+	// The mediated device does not exist
+	path := filepath.Join(devicesDir, "f79944e4-5a3d-11e8-99ce-479cbab002e4")
+	_, err := dm.NewDevice(config.DeviceInfo{
+		HostPath:      path,
+		ContainerPath: path,
+	})
+	assert.Error(err)
+
+	// The mediated device is created but the fake sysfs does not have it
+	_, err = dm.NewDevice(config.DeviceInfo{
+		HostPath:      typePath,
+		ContainerPath: typePath,
+	})
+	assert.Error(err)
+	content, err := ioutil.ReadFile(filepath.Join(typePath, "create"))
+	assert.NoError(err)
+	assert.Len(string(content), 36)
+
+	// The mediated device exists but its VFIO group does not
+	path = filepath.Join(devicesDir, string(content))
+	assert.NoError(os.MkdirAll(path, dirMode))
+	assert.NoError(os.Symlink("../../../../kernel/iommu_groups/4242", filepath.Join(path, "iommu_group")))
+	_, err = dm.NewDevice(config.DeviceInfo{
+		HostPath:      path,
+		ContainerPath: path,
+	})
+	assert.Error(err)
+	assert.Contains(err.Error(), "/dev/vfio/4242")
+	assert.Empty(dm.GetAllDevices())
+}
+
 func TestAttachGenericDevice(t *testing.T) {
 	dm := &deviceManager{
 		blockDriver: VirtioBlock,
@@ -218,6 +344,99 @@ func TestAttachBlockDevice(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestAttachMultipathDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpDir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+
+	savedSysDevPrefix := config.SysDevPrefix
+	config.SysDevPrefix = tmpDir
+	defer func() {
+		os.RemoveAll(tmpDir)
+		config.SysDevPrefix = savedSysDevPrefix
+	}()
+
+	// dm-mpath device 253:2 with the paths sdb (8:16) and sdc (8:32)
+	dmPath := filepath.Join(tmpDir, "block", "253:2")
+	assert.NoError(os.MkdirAll(filepath.Join(dmPath, "dm"), dirMode))
+	assert.NoError(ioutil.WriteFile(filepath.Join(dmPath, "dm", "uuid"), []byte("mpath-36001405a4f8c2d1e\n"), fileMode0640))
+	for _, slave := range []string{"sdb 8:16", "sdc 8:32"} {
+		var name, dev string
+		fmt.Sscan(slave, &name, &dev)
+		slavePath := filepath.Join(dmPath, "slaves", name)
+		assert.NoError(os.MkdirAll(slavePath, dirMode))
+		assert.NoError(ioutil.WriteFile(filepath.Join(slavePath, "dev"), []byte(dev+"\n"), fileMode0640))
+	}
+
+	deviceInfo := config.DeviceInfo{
+		HostPath:      "/dev/dm-2",
+		ContainerPath: "/dev/xvda",
+		DevType:       "b",
+		Major:         253,
+		Minor:         2,
+	}
+
+	// The multipath devices are only attached with virtio-scsi
+	dm := NewDeviceManager(VirtioBlock, false, "", true, nil).(*deviceManager)
+	device, err := dm.createDevice(deviceInfo)
+	assert.NoError(err)
+	assert.Equal(config.DeviceBlock, device.DeviceType())
+
+	dm = NewDeviceManager(VirtioSCSI, false, "", false, nil).(*deviceManager)
+	device, err = dm.createDevice(deviceInfo)
+	assert.NoError(err)
+	assert.Equal(config.DeviceBlock, device.DeviceType())
+
+	dm = NewDeviceManager(VirtioSCSI, false, "", true, nil).(*deviceManager)
+	device, err = dm.NewDevice(deviceInfo)
+	assert.NoError(err)
+
+	mpathDevice, ok := device.(*drivers.MultipathDevice)
+	assert.True(ok)
+	assert.Equal("36001405a4f8c2d1e", mpathDevice.WWID)
+	assert.Len(mpathDevice.Paths, 2)
+	assert.Equal("/dev/sdb", mpathDevice.Paths[0].DeviceInfo.HostPath)
+	assert.Equal(int64(8), mpathDevice.Paths[1].DeviceInfo.Major)
+	assert.Equal(int64(32), mpathDevice.Paths[1].DeviceInfo.Minor)
+
+	devReceiver := &api.MockDeviceReceiver{}
+	assert.NoError(dm.AttachDevice(context.Background(), device.DeviceID(), devReceiver))
+
+	drives, ok := device.GetDeviceInfo().([]*config.BlockDrive)
+	assert.True(ok)
+	assert.Len(drives, 2)
+	for _, drive := range drives {
+		assert.Equal("36001405a4f8c2d1e", drive.Serial)
+		assert.NotEmpty(drive.SCSIAddr)
+	}
+	assert.NotEqual(drives[0].ID, drives[1].ID)
+
+	// The device is found again by its major and minor numbers
+	sameDevice, err := dm.NewDevice(deviceInfo)
+	assert.NoError(err)
+	assert.Equal(device.DeviceID(), sameDevice.DeviceID())
+
+	// The paths are restored along with the device
+	dm.LoadDevices(append([]persistapi.DeviceState{}, device.Save()))
+	restored, ok := dm.GetDeviceByID(device.DeviceID()).(*drivers.MultipathDevice)
+	assert.True(ok)
+	assert.Equal(mpathDevice.WWID, restored.WWID)
+	assert.Equal(drives, restored.GetDeviceInfo())
+
+	assert.NoError(dm.DetachDevice(context.Background(), device.DeviceID(), devReceiver))
+	for _, path := range restored.Paths {
+		assert.Equal(uint(0), path.GetAttachCount())
+	}
+
+	// Another device mapper target is attached as a block device
+	assert.NoError(ioutil.WriteFile(filepath.Join(dmPath, "dm", "uuid"), []byte("CRYPT-LUKS2-foo\n"), fileMode0640))
+	dm = NewDeviceManager(VirtioSCSI, false, "", true, nil).(*deviceManager)
+	device, err = dm.createDevice(deviceInfo)
+	assert.NoError(err)
+	assert.Equal(config.DeviceBlock, device.DeviceType())
+}
+
 func TestAttachVhostUserBlkDevice(t *testing.T) {
 	rootEnabled := true
 	tc := ktu.NewTestConstraint(false)
@@ -296,7 +515,7 @@ func TestAttachVhostUserBlkDevice(t *testing.T) {
 }
 
 func TestAttachDetachDevice(t *testing.T) {
-	dm := NewDeviceManager(VirtioSCSI, false, "", nil)
+	dm := NewDeviceManager(VirtioSCSI, false, "", false, nil)
 
 	path := "/dev/hda"
 	deviceInfo := config.DeviceInfo{
@@ -9,11 +9,13 @@ package manager
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
@@ -21,6 +23,10 @@ import (
 
 const (
 	vfioPath = "/dev/vfio/"
+
+	// multipathUUIDPrefix prefixes the device mapper UUID of the dm-mpath
+	// devices, followed by their WWID.
+	multipathUUIDPrefix = "mpath-"
 )
 
 // isVFIO checks if the device provided is a vfio group.
@@ -37,6 +43,98 @@ func isVFIO(hostPath string) bool {
 	return false
 }
 
+// isPCIDevice checks if the device provided is a PCI device, requested by
+// its sysfs path, e.g. /sys/bus/pci/devices/0000:3b:00.0, to be bound to
+// vfio-pci and passed as a vfio group.
+func isPCIDevice(hostPath string) bool {
+	return filepath.Dir(filepath.Clean(hostPath)) == filepath.Clean(config.SysBusPciDevicesPath)
+}
+
+// bindPCIDevice binds the PCI device of devInfo to vfio-pci and returns the
+// device information of its vfio group, with the host driver binding to
+// restore once the device is released.
+func bindPCIDevice(devInfo config.DeviceInfo) (config.DeviceInfo, *config.PCIHostBinding, error) {
+	bdf := filepath.Base(devInfo.HostPath)
+	groupPath, binding, err := drivers.BindPCIDeviceToVFIO(bdf)
+	if err != nil {
+		return devInfo, nil, err
+	}
+
+	var stat unix.Stat_t
+	if err := unix.Stat(groupPath, &stat); err != nil {
+		if binding != nil {
+			drivers.BindPCIDeviceToHost(*binding)
+		}
+		return devInfo, nil, fmt.Errorf("stat %q failed: %v", groupPath, err)
+	}
+
+	devInfo.HostPath = groupPath
+	devInfo.ContainerPath = groupPath
+	devInfo.DevType = "c"
+	devInfo.Major = int64(unix.Major(uint64(stat.Rdev)))
+	devInfo.Minor = int64(unix.Minor(uint64(stat.Rdev)))
+
+	return devInfo, binding, nil
+}
+
+// isMdevDevice checks if the device provided is a mediated device, requested
+// by its sysfs path, e.g. /sys/bus/mdev/devices/<uuid>, to be passed as its
+// vfio group.
+func isMdevDevice(hostPath string) bool {
+	return filepath.Dir(filepath.Clean(hostPath)) == filepath.Clean(config.SysBusMdevDevicesPath)
+}
+
+// isMdevType checks if the device provided is a mediated device type, e.g.
+// /sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63, a
+// mediated device of this type being created on demand.
+func isMdevType(hostPath string) bool {
+	typesDir := filepath.Dir(filepath.Clean(hostPath))
+	return filepath.Base(typesDir) == "mdev_supported_types" &&
+		filepath.Dir(filepath.Dir(typesDir)) == filepath.Clean(config.SysClassMdevBusPath)
+}
+
+// mdevDevice returns the device information of the vfio group of the
+// mediated device of devInfo, creating it first when devInfo is a mediated
+// device type. The sysfs path of the created mediated device is returned so
+// that it is removed once the device is released.
+func mdevDevice(devInfo config.DeviceInfo) (config.DeviceInfo, string, error) {
+	sysfsDev := devInfo.HostPath
+	created := ""
+	if isMdevType(sysfsDev) {
+		var err error
+		if sysfsDev, err = drivers.CreateMediatedDevice(sysfsDev); err != nil {
+			return devInfo, "", err
+		}
+		created = sysfsDev
+	}
+
+	removeCreated := func() {
+		if created != "" {
+			drivers.RemoveMediatedDevice(created)
+		}
+	}
+
+	groupPath, err := drivers.MediatedDeviceVFIOGroup(sysfsDev)
+	if err != nil {
+		removeCreated()
+		return devInfo, "", err
+	}
+
+	var stat unix.Stat_t
+	if err := unix.Stat(groupPath, &stat); err != nil {
+		removeCreated()
+		return devInfo, "", fmt.Errorf("stat %q failed: %v", groupPath, err)
+	}
+
+	devInfo.HostPath = groupPath
+	devInfo.ContainerPath = groupPath
+	devInfo.DevType = "c"
+	devInfo.Major = int64(unix.Major(uint64(stat.Rdev)))
+	devInfo.Minor = int64(unix.Minor(uint64(stat.Rdev)))
+
+	return devInfo, created, nil
+}
+
 // isBlock checks if the device is a block device.
 func isBlock(devInfo config.DeviceInfo) bool {
 	return devInfo.DevType == "b"
@@ -146,3 +244,57 @@ func isVhostUserBlk(devInfo config.DeviceInfo) bool {
 func isVhostUserSCSI(devInfo config.DeviceInfo) bool {
 	return devInfo.DevType == "b" && devInfo.Major == config.VhostUserSCSIMajor
 }
+
+// getMultipathInfo returns the WWID and the paths of the block device if it
+// is a dm-mpath device, or an empty WWID if it is not.
+func getMultipathInfo(devInfo config.DeviceInfo) (string, []config.DeviceInfo, error) {
+	sysDevPath := filepath.Join(config.SysDevPrefix, "block", fmt.Sprintf("%d:%d", devInfo.Major, devInfo.Minor))
+
+	uuid, err := ioutil.ReadFile(filepath.Join(sysDevPath, "dm", "uuid"))
+	if os.IsNotExist(err) {
+		// Not a device mapper device
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	wwid := strings.TrimSpace(string(uuid))
+	if !strings.HasPrefix(wwid, multipathUUIDPrefix) {
+		return "", nil, nil
+	}
+	wwid = strings.TrimPrefix(wwid, multipathUUIDPrefix)
+
+	slaves, err := ioutil.ReadDir(filepath.Join(sysDevPath, "slaves"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var paths []config.DeviceInfo
+	for _, slave := range slaves {
+		dev, err := ioutil.ReadFile(filepath.Join(sysDevPath, "slaves", slave.Name(), "dev"))
+		if err != nil {
+			return "", nil, err
+		}
+
+		var major, minor int64
+		if _, err := fmt.Sscanf(strings.TrimSpace(string(dev)), "%d:%d", &major, &minor); err != nil {
+			return "", nil, fmt.Errorf("invalid device number of multipath device %s path %s: %v", devInfo.HostPath, slave.Name(), err)
+		}
+
+		paths = append(paths, config.DeviceInfo{
+			HostPath:      filepath.Join("/dev", slave.Name()),
+			ContainerPath: devInfo.ContainerPath,
+			DevType:       devInfo.DevType,
+			Major:         major,
+			Minor:         minor,
+			ReadOnly:      devInfo.ReadOnly,
+		})
+	}
+
+	if len(paths) == 0 {
+		return "", nil, fmt.Errorf("multipath device %s has no path", devInfo.HostPath)
+	}
+
+	return wwid, paths, nil
+}
@@ -38,6 +38,47 @@ func TestIsVFIO(t *testing.T) {
 	}
 }
 
+func TestIsPCIDevice(t *testing.T) {
+	type testData struct {
+		path     string
+		expected bool
+	}
+
+	data := []testData{
+		{"/sys/bus/pci/devices/0000:3b:00.0", true},
+		{"/sys/bus/pci/devices/0000:3b:00.0/", true},
+		{"/sys/bus/pci/devices", false},
+		{"/sys/bus/pci/devices/0000:3b:00.0/driver", false},
+		{"/dev/vfio/1", false},
+	}
+
+	for _, d := range data {
+		isPCIDevice := isPCIDevice(d.path)
+		assert.Equal(t, d.expected, isPCIDevice)
+	}
+}
+
+func TestIsMdevDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(isMdevDevice("/sys/bus/mdev/devices/f79944e4-5a3d-11e8-99ce-479cbab002e4"))
+	assert.True(isMdevDevice("/sys/bus/mdev/devices/f79944e4-5a3d-11e8-99ce-479cbab002e4/"))
+	assert.False(isMdevDevice("/sys/bus/mdev/devices"))
+	assert.False(isMdevDevice("/sys/bus/pci/devices/0000:3b:00.0"))
+	assert.False(isMdevDevice("/dev/vfio/1"))
+}
+
+func TestIsMdevType(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(isMdevType("/sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63"))
+	assert.True(isMdevType("/sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63/"))
+	assert.False(isMdevType("/sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types"))
+	assert.False(isMdevType("/sys/class/mdev_bus/0000:3b:00.0"))
+	assert.False(isMdevType("/sys/devices/pci0000:3a/0000:3b:00.0/mdev_supported_types/nvidia-63"))
+	assert.False(isMdevType("/sys/bus/mdev/devices/f79944e4-5a3d-11e8-99ce-479cbab002e4"))
+}
+
 func TestIsBlock(t *testing.T) {
 	type testData struct {
 		devType  string
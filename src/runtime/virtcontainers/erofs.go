@@ -0,0 +1,218 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// erofsLayerImage is the EROFS image of a layer, saved next to the
+	// layer directory so that it is removed with the snapshot.
+	erofsLayerImage = "layer.erofs"
+
+	erofsFsType = "erofs"
+
+	// kataOverlayDevType is the agent storage driver stacking the layers
+	// of a container rootfs, with a writable layer in the guest.
+	kataOverlayDevType = "overlayfs"
+
+	overlayFsType = "overlay"
+)
+
+// erofsMkfs builds the EROFS layer images, it's a variable for the tests.
+var erofsMkfs = "mkfs.erofs"
+
+// useErofsLayers returns true when the rootfs layers are passed to the
+// guest as EROFS block devices instead of through the shared file system.
+func (c *Container) useErofsLayers() bool {
+	return c.sandbox.config.HypervisorConfig.ErofsLayers && !c.rootFs.Mounted && c.rootFs.Type == overlayFsType
+}
+
+// overlayLowerDirs returns the lower directories of an overlay rootfs, the
+// topmost first.
+func overlayLowerDirs(rootFs RootFs) ([]string, error) {
+	for _, o := range rootFs.Options {
+		if strings.HasPrefix(o, "lowerdir=") {
+			return strings.Split(strings.TrimPrefix(o, "lowerdir="), ":"), nil
+		}
+	}
+
+	return nil, fmt.Errorf("no lowerdir in the rootfs options %v", rootFs.Options)
+}
+
+// erofsLayerImagePath returns the EROFS image of a layer directory, building
+// it the first time the layer is used.
+func erofsLayerImagePath(ctx context.Context, layerDir string) (string, error) {
+	image := filepath.Join(filepath.Dir(layerDir), erofsLayerImage)
+	if _, err := os.Stat(image); err == nil {
+		return image, nil
+	}
+
+	// Build the image aside, several sandboxes may share the layer.
+	tmp, err := ioutil.TempFile(filepath.Dir(layerDir), erofsLayerImage+".")
+	if err != nil {
+		return "", err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	out, err := exec.CommandContext(ctx, erofsMkfs, tmp.Name(), layerDir).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to build the EROFS image of %s: %v: %s", layerDir, err, out)
+	}
+
+	if err := os.Rename(tmp.Name(), image); err != nil {
+		return "", err
+	}
+
+	virtLog.WithFields(logrus.Fields{
+		"layer": layerDir,
+		"image": image,
+	}).Info("EROFS layer image built")
+
+	return image, nil
+}
+
+// plugErofsLayers attaches the EROFS images of the rootfs layers as read-only
+// block devices. The layers shared by several containers are attached once.
+func (c *Container) plugErofsLayers(ctx context.Context) error {
+	layers, err := overlayLowerDirs(c.rootFs)
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range layers {
+		image, err := erofsLayerImagePath(ctx, layer)
+		if err != nil {
+			return err
+		}
+
+		dev, err := c.sandbox.devManager.NewDevice(config.DeviceInfo{
+			HostPath:      image,
+			ContainerPath: layer,
+			DevType:       "b",
+			ReadOnly:      true,
+			// not a host block device
+			Major: -1,
+		})
+		if err != nil {
+			return fmt.Errorf("device manager failed to create the layer device for %q: %v", image, err)
+		}
+
+		c.state.LayerDeviceIDs = append(c.state.LayerDeviceIDs, dev.DeviceID())
+
+		if err := c.sandbox.devManager.AttachDevice(ctx, dev.DeviceID(), c.sandbox); err != nil {
+			return err
+		}
+	}
+
+	// there is no "rootfs" dir on the overlay rootfs
+	c.rootfsSuffix = ""
+
+	return nil
+}
+
+// removeErofsLayers detaches the layers of the container, they are unplugged
+// once no container uses them.
+func (c *Container) removeErofsLayers(ctx context.Context) error {
+	for len(c.state.LayerDeviceIDs) > 0 {
+		devID := c.state.LayerDeviceIDs[0]
+
+		err := c.sandbox.devManager.DetachDevice(ctx, devID, c.sandbox)
+		if err != nil && err != manager.ErrDeviceNotAttached {
+			return err
+		}
+
+		if err := c.sandbox.devManager.RemoveDevice(devID); err != nil && err != manager.ErrDeviceNotExist {
+			return err
+		}
+
+		c.state.LayerDeviceIDs = c.state.LayerDeviceIDs[1:]
+	}
+
+	return nil
+}
+
+// buildErofsRootfs returns the storages mounting the layers of the container
+// in the guest, and stacking them on rootPath with a writable layer in the
+// guest memory.
+func (k *kataAgent) buildErofsRootfs(sandbox *Sandbox, c *Container, rootPath string) ([]*grpc.Storage, error) {
+	var storages []*grpc.Storage
+	var lowerDirs []string
+
+	layersDir := filepath.Join(kataGuestSandboxDir(), "layers", c.id)
+
+	for i, devID := range c.state.LayerDeviceIDs {
+		device := sandbox.devManager.GetDeviceByID(devID)
+		if device == nil {
+			return nil, fmt.Errorf("failed to find device by id %q", devID)
+		}
+
+		blockDrive, ok := device.GetDeviceInfo().(*config.BlockDrive)
+		if !ok || blockDrive == nil {
+			return nil, fmt.Errorf("malformed block drive")
+		}
+
+		layer := &grpc.Storage{
+			Fstype:     erofsFsType,
+			Options:    []string{"ro"},
+			MountPoint: filepath.Join(layersDir, strconv.Itoa(i)),
+		}
+
+		switch sandbox.config.HypervisorConfig.BlockDeviceDriver {
+		case config.VirtioBlockCCW:
+			layer.Driver = kataBlkCCWDevType
+			layer.Source = blockDrive.DevNo
+		case config.VirtioBlock:
+			layer.Driver = kataBlkDevType
+			layer.Source = blockDrive.PCIPath.String()
+		case config.VirtioMmio:
+			layer.Driver = kataMmioBlkDevType
+			layer.Source = blockDrive.VirtPath
+		case config.VirtioSCSI:
+			layer.Driver = kataSCSIDevType
+			layer.Source = blockDrive.SCSIAddr
+		default:
+			return nil, fmt.Errorf("Unknown block device driver: %s", sandbox.config.HypervisorConfig.BlockDeviceDriver)
+		}
+
+		storages = append(storages, layer)
+		lowerDirs = append(lowerDirs, layer.MountPoint)
+	}
+
+	storages = append(storages, &grpc.Storage{
+		Driver: kataOverlayDevType,
+		Source: overlayFsType,
+		Fstype: overlayFsType,
+		Options: []string{
+			"lowerdir=" + strings.Join(lowerDirs, ":"),
+			"upperdir=" + filepath.Join(layersDir, "rw", "upper"),
+			"workdir=" + filepath.Join(layersDir, "rw", "work"),
+		},
+		MountPoint: rootPath,
+	})
+
+	// Ensure the container mount destination exists, like for the block
+	// device rootfs.
+	if err := os.MkdirAll(filepath.Join(getMountPath(c.sandbox.id), c.id), DirMode); err != nil {
+		return nil, err
+	}
+
+	return storages, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlayLowerDirs(t *testing.T) {
+	assert := assert.New(t)
+
+	layers, err := overlayLowerDirs(RootFs{
+		Type:    overlayFsType,
+		Options: []string{"index=off", "workdir=/w", "upperdir=/u", "lowerdir=/l2:/l1"},
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"/l2", "/l1"}, layers)
+
+	_, err = overlayLowerDirs(RootFs{Type: overlayFsType})
+	assert.Error(err)
+}
+
+func TestErofsLayerImagePath(t *testing.T) {
+	assert := assert.New(t)
+
+	savedMkfs := erofsMkfs
+	defer func() {
+		erofsMkfs = savedMkfs
+	}()
+
+	dir, err := ioutil.TempDir("", "erofs")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	layer := filepath.Join(dir, "1", "fs")
+	assert.NoError(os.MkdirAll(layer, DirMode))
+
+	erofsMkfs = "false"
+	_, err = erofsLayerImagePath(context.Background(), layer)
+	assert.Error(err)
+	files, err := ioutil.ReadDir(filepath.Dir(layer))
+	assert.NoError(err)
+	assert.Len(files, 1, "the partial image must be removed")
+
+	erofsMkfs = "true"
+	image, err := erofsLayerImagePath(context.Background(), layer)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(dir, "1", erofsLayerImage), image)
+	assert.FileExists(image)
+
+	// The image is only built once
+	erofsMkfs = "false"
+	image, err = erofsLayerImagePath(context.Background(), layer)
+	assert.NoError(err)
+	assert.Equal(filepath.Join(dir, "1", erofsLayerImage), image)
+}
+
+func TestErofsLayers(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	dir, err := ioutil.TempDir("", "erofs")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	var layers []string
+	for _, id := range []string{"2", "1"} {
+		layer := filepath.Join(dir, id, "fs")
+		assert.NoError(os.MkdirAll(layer, DirMode))
+		assert.NoError(ioutil.WriteFile(filepath.Join(dir, id, erofsLayerImage), nil, 0644))
+		layers = append(layers, layer)
+	}
+
+	sConfig := SandboxConfig{}
+	sConfig.HypervisorConfig.BlockDeviceDriver = config.VirtioSCSI
+	sConfig.HypervisorConfig.ErofsLayers = true
+	sandbox := &Sandbox{
+		id:         "erofs-sandbox",
+		hypervisor: &mockHypervisor{},
+		ctx:        context.Background(),
+		config:     &sConfig,
+		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", false, nil),
+		state:      types.SandboxState{BlockIndexMap: make(map[int]struct{})},
+	}
+	defer os.RemoveAll(getMountPath(sandbox.id))
+
+	newContainer := func(id string) *Container {
+		return &Container{
+			id:           id,
+			sandbox:      sandbox,
+			rootfsSuffix: "rootfs",
+			rootFs: RootFs{
+				Type:    overlayFsType,
+				Options: []string{"lowerdir=" + layers[0] + ":" + layers[1]},
+			},
+		}
+	}
+
+	c := newContainer("foo")
+	assert.True(c.useErofsLayers())
+	assert.NoError(c.plugErofsLayers(context.Background()))
+	assert.Len(c.state.LayerDeviceIDs, 2)
+	assert.Empty(c.rootfsSuffix)
+
+	storages, err := k.buildErofsRootfs(sandbox, c, "/run/rootfs")
+	assert.NoError(err)
+	assert.Len(storages, 3)
+
+	layersDir := filepath.Join(kataGuestSandboxDir(), "layers", "foo")
+	for i, s := range storages[:2] {
+		assert.Equal(kataSCSIDevType, s.Driver)
+		assert.Equal(erofsFsType, s.Fstype)
+		assert.Equal([]string{"ro"}, s.Options)
+		assert.Equal(filepath.Join(layersDir, []string{"0", "1"}[i]), s.MountPoint)
+	}
+
+	overlay := storages[2]
+	assert.Equal(kataOverlayDevType, overlay.Driver)
+	assert.Equal("/run/rootfs", overlay.MountPoint)
+	assert.Equal([]string{
+		"lowerdir=" + filepath.Join(layersDir, "0") + ":" + filepath.Join(layersDir, "1"),
+		"upperdir=" + filepath.Join(layersDir, "rw", "upper"),
+		"workdir=" + filepath.Join(layersDir, "rw", "work"),
+	}, overlay.Options)
+
+	// The layers are shared by the containers
+	other := newContainer("bar")
+	assert.NoError(other.plugErofsLayers(context.Background()))
+	assert.Equal(c.state.LayerDeviceIDs, other.state.LayerDeviceIDs)
+	assert.Len(sandbox.devManager.GetAllDevices(), 2)
+
+	assert.NoError(c.removeErofsLayers(context.Background()))
+	assert.Empty(c.state.LayerDeviceIDs)
+	assert.Len(sandbox.devManager.GetAllDevices(), 2)
+
+	assert.NoError(other.removeErofsLayers(context.Background()))
+	assert.Empty(sandbox.devManager.GetAllDevices())
+
+	// The rootfs is mounted on the host otherwise
+	c.rootFs.Mounted = true
+	assert.False(c.useErofsLayers())
+}
@@ -685,7 +685,7 @@ func (fc *firecracker) fcInitConfiguration(ctx context.Context) error {
 		fcKernelParams = append(fcKernelParams, []Param{
 			{"8250.nr_uarts", "0"},
 			// Tell agent where to send the logs
-			{"agent.log_vport", fmt.Sprintf("%d", vSockLogsPort)},
+			{kernelParamLogVPort, fmt.Sprintf("%d", vSockLogsPort)},
 		}...)
 	}
 
@@ -1216,10 +1216,40 @@ func (fc *firecracker) generateSocket(id string) (interface{}, error) {
 	}, nil
 }
 
+func (fc *firecracker) setVMMCgroup(path string) bool {
+	return false
+}
+
 func (fc *firecracker) isRateLimiterBuiltin() bool {
 	return true
 }
 
+func (fc *firecracker) dumpGuestMemory(dumpSavePath string, encryptionKey []byte) (string, string, error) {
+	return "", "", errors.New("firecracker does not support guest memory dump")
+}
+
+func (fc *firecracker) prepareMigrationIncoming(ctx context.Context, uri string) error {
+	return errors.New("firecracker does not support live migration")
+}
+
+func (fc *firecracker) startMigration(ctx context.Context, uri string) error {
+	return errors.New("firecracker does not support live migration")
+}
+
+func (fc *firecracker) migrationStatus(ctx context.Context) (string, error) {
+	return "", errors.New("firecracker does not support live migration")
+}
+
+// guestMemoryStats returns no statistics, the VM has no virtio-balloon
+// device.
+func (fc *firecracker) guestMemoryStats(ctx context.Context) (GuestMemoryStats, error) {
+	return GuestMemoryStats{}, nil
+}
+
+func (fc *firecracker) migrationSwitchover(ctx context.Context) error {
+	return errors.New("firecracker does not support live migration")
+}
+
 // In firecracker, it accepts the size of rate limiter in scaling factors of 2^10(1024)
 // But in kata-defined rate limiter, for better Human-readability, we prefer scaling factors of 10^3(1000).
 // func revertByte reverts num from scaling factors of 1000 to 1024, e.g. 10000000(10MB) to 10485760.
@@ -1232,5 +1262,9 @@ func revertBytes(num uint64) uint64 {
 	return 1024*revertBytes(a) + b
 }
 
+func (fc *firecracker) resizeBlockDevice(ctx context.Context, drive *config.BlockDrive, size uint64) error {
+	return errors.New("firecracker does not support resizing block devices")
+}
+
 func (fc *firecracker) setSandbox(sandbox *Sandbox) {
 }
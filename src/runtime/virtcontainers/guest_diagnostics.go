@@ -0,0 +1,111 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+)
+
+const (
+	// MaxGuestDiagnosticsDmesgLines bounds the kernel log tail of the guest
+	// diagnostics.
+	MaxGuestDiagnosticsDmesgLines = 10000
+
+	// guestDiagnosticsSectionSize bounds the size in bytes of each text
+	// section of the guest diagnostics.
+	guestDiagnosticsSectionSize = 64 * 1024
+)
+
+// GuestDiagnosticsOptions describe the guest diagnostics to collect.
+type GuestDiagnosticsOptions struct {
+	// DmesgLines is the number of lines of the tail of the guest kernel
+	// log, the agent default applies when it is 0
+	DmesgLines uint32
+}
+
+// GuestDiagnostics is a snapshot of the state of the guest OS, collected by
+// the agent for the bug reports. The text sections are bounded, their start
+// is cut when they are too large.
+type GuestDiagnostics struct {
+	Time  time.Time `json:"time"`
+	Dmesg string    `json:"dmesg"`
+	// Mounts is the content of /proc/self/mountinfo
+	Mounts      string                 `json:"mounts"`
+	Filesystems []GuestFilesystemUsage `json:"filesystems"`
+	// MemInfo is the content of /proc/meminfo
+	MemInfo string `json:"meminfo"`
+	// Modules is the content of /proc/modules
+	Modules string `json:"modules"`
+	// Errors are the failures to collect the sections, by section name
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// GuestFilesystemUsage is the usage of a filesystem mounted in the guest,
+// the sizes are in bytes.
+type GuestFilesystemUsage struct {
+	MountPoint string `json:"mount_point"`
+	FsType     string `json:"fstype"`
+	Source     string `json:"source"`
+	Size       uint64 `json:"size"`
+	Used       uint64 `json:"used"`
+	Available  uint64 `json:"available"`
+	Inodes     uint64 `json:"inodes"`
+	InodesFree uint64 `json:"inodes_free"`
+}
+
+// GuestDiagnostics returns a snapshot of the state of the guest OS: the tail
+// of its kernel log, its mounts and their usage, its memory information and
+// its loaded modules.
+func (s *Sandbox) GuestDiagnostics(ctx context.Context, opts GuestDiagnosticsOptions) (GuestDiagnostics, error) {
+	span, ctx := katatrace.Trace(ctx, s.Logger(), "GuestDiagnostics", s.tracingTags())
+	defer span.End()
+
+	if opts.DmesgLines > MaxGuestDiagnosticsDmesgLines {
+		return GuestDiagnostics{}, fmt.Errorf("too many dmesg lines %d, the maximum is %d", opts.DmesgLines, MaxGuestDiagnosticsDmesgLines)
+	}
+
+	resp, err := s.agent.getGuestDiagnostics(ctx, &grpc.GuestDiagnosticsRequest{
+		DmesgLines:     opts.DmesgLines,
+		MaxSectionSize: guestDiagnosticsSectionSize,
+	})
+	if err != nil {
+		return GuestDiagnostics{}, fmt.Errorf("failed to get the guest diagnostics: %v", err)
+	}
+
+	return newGuestDiagnostics(resp), nil
+}
+
+func newGuestDiagnostics(resp *grpc.GuestDiagnostics) GuestDiagnostics {
+	diagnostics := GuestDiagnostics{
+		Time:        time.Now().UTC(),
+		Dmesg:       resp.Dmesg,
+		Mounts:      resp.Mounts,
+		Filesystems: []GuestFilesystemUsage{},
+		MemInfo:     resp.Meminfo,
+		Modules:     resp.Modules,
+		Errors:      resp.Errors,
+	}
+
+	for _, fs := range resp.Filesystems {
+		diagnostics.Filesystems = append(diagnostics.Filesystems, GuestFilesystemUsage{
+			MountPoint: fs.MountPoint,
+			FsType:     fs.Fstype,
+			Source:     fs.Source,
+			Size:       fs.Size_,
+			Used:       fs.Used,
+			Available:  fs.Available,
+			Inodes:     fs.Inodes,
+			InodesFree: fs.InodesFree,
+		})
+	}
+
+	return diagnostics
+}
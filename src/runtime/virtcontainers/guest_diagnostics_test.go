@@ -0,0 +1,51 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxGuestDiagnostics(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{
+		id:    "diagnostics-sandbox",
+		agent: &mockAgent{},
+	}
+
+	diagnostics, err := s.GuestDiagnostics(context.Background(), GuestDiagnosticsOptions{DmesgLines: 100})
+	assert.NoError(err)
+	assert.NotNil(diagnostics.Filesystems)
+
+	_, err = s.GuestDiagnostics(context.Background(), GuestDiagnosticsOptions{DmesgLines: MaxGuestDiagnosticsDmesgLines + 1})
+	assert.Error(err)
+}
+
+func TestNewGuestDiagnostics(t *testing.T) {
+	assert := assert.New(t)
+
+	diagnostics := newGuestDiagnostics(&grpc.GuestDiagnostics{
+		Dmesg:   "[    0.000000] Linux version 5.10.25",
+		Meminfo: "MemTotal: 2048000 kB",
+		Filesystems: []*grpc.FilesystemUsage{
+			{MountPoint: "/", Fstype: "ext4", Source: "/dev/vda1", Size_: 4096, Used: 1024, Available: 3072, Inodes: 16, InodesFree: 8},
+		},
+		Errors: map[string]string{"modules": "No such file or directory"},
+	})
+
+	assert.False(diagnostics.Time.IsZero())
+	assert.Equal("[    0.000000] Linux version 5.10.25", diagnostics.Dmesg)
+	assert.Equal("MemTotal: 2048000 kB", diagnostics.MemInfo)
+	assert.Equal([]GuestFilesystemUsage{
+		{MountPoint: "/", FsType: "ext4", Source: "/dev/vda1", Size: 4096, Used: 1024, Available: 3072, Inodes: 16, InodesFree: 8},
+	}, diagnostics.Filesystems)
+	assert.Equal(map[string]string{"modules": "No such file or directory"}, diagnostics.Errors)
+}
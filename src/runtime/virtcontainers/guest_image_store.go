@@ -0,0 +1,167 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+)
+
+const (
+	// guestImageStorePath is where the guest image store disk is mounted
+	// in the guest, the images pulled in the guest are stored there.
+	guestImageStorePath = "/run/kata-containers/image"
+
+	guestImageStoreFsType = "ext4"
+
+	// kataFormatDriverOption asks the agent to format the block volume
+	// before mounting it, whatever it contains.
+	kataFormatDriverOption = "format=always"
+)
+
+// guestImageStoreDir is the host directory of the backing files of the guest
+// image store disks. It is not under /run, usually a tmpfs, which would
+// take the memory the disks are meant to save. It's a variable for the tests.
+var guestImageStoreDir = "/var/lib/kata-containers/guest-image-store"
+
+// guestImageStoreImage returns the backing file of the guest image store disk
+// of the sandbox.
+func (s *Sandbox) guestImageStoreImage() string {
+	return filepath.Join(guestImageStoreDir, s.id+".img")
+}
+
+// guestImageStoreDevice attaches the guest image store disk, backed by a
+// sparse file of GuestImageStoreSize MiB.
+func (s *Sandbox) guestImageStoreDevice(ctx context.Context) (api.Device, error) {
+	image := s.guestImageStoreImage()
+
+	if err := os.MkdirAll(guestImageStoreDir, DirMode); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(image, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	err = f.Truncate(int64(s.config.HypervisorConfig.GuestImageStoreSize) << utils.MibToBytesShift)
+	f.Close()
+	if err != nil {
+		os.Remove(image)
+		return nil, err
+	}
+
+	dev, err := s.devManager.NewDevice(config.DeviceInfo{
+		HostPath:      image,
+		ContainerPath: guestImageStorePath,
+		DevType:       "b",
+		Discard:       s.config.HypervisorConfig.GuestImageStoreDiscard,
+		// not a host block device
+		Major: -1,
+	})
+	if err != nil {
+		os.Remove(image)
+		return nil, err
+	}
+
+	if err := s.devManager.AttachDevice(ctx, dev.DeviceID(), s); err != nil {
+		s.devManager.RemoveDevice(dev.DeviceID())
+		os.Remove(image)
+		return nil, fmt.Errorf("failed to attach the guest image store disk: %v", err)
+	}
+
+	return dev, nil
+}
+
+// guestImageStoreStorage returns the storage of the guest image store disk,
+// formatted by the agent when the sandbox starts, or nil when it is disabled
+// or the agent does not support it.
+func (k *kataAgent) guestImageStoreStorage(ctx context.Context, sandbox *Sandbox) (*grpc.Storage, error) {
+	if sandbox.config.HypervisorConfig.GuestImageStoreSize == 0 || !k.caps.ImagePull {
+		return nil, nil
+	}
+
+	device, err := sandbox.guestImageStoreDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockDrive, ok := device.GetDeviceInfo().(*config.BlockDrive)
+	if !ok || blockDrive == nil {
+		return nil, fmt.Errorf("malformed block drive")
+	}
+
+	storage := &grpc.Storage{
+		Fstype:        guestImageStoreFsType,
+		MountPoint:    guestImageStorePath,
+		DriverOptions: []string{kataFormatDriverOption},
+	}
+
+	// The free blocks are discarded as the images are removed.
+	if sandbox.config.HypervisorConfig.GuestImageStoreDiscard {
+		storage.Options = []string{"discard"}
+	}
+
+	switch sandbox.config.HypervisorConfig.BlockDeviceDriver {
+	case config.VirtioBlockCCW:
+		storage.Driver = kataBlkCCWDevType
+		storage.Source = blockDrive.DevNo
+	case config.VirtioBlock:
+		storage.Driver = kataBlkDevType
+		storage.Source = blockDrive.PCIPath.String()
+	case config.VirtioMmio:
+		storage.Driver = kataMmioBlkDevType
+		storage.Source = blockDrive.VirtPath
+	case config.VirtioSCSI:
+		storage.Driver = kataSCSIDevType
+		storage.Source = blockDrive.SCSIAddr
+	default:
+		return nil, fmt.Errorf("Unknown block device driver: %s", sandbox.config.HypervisorConfig.BlockDeviceDriver)
+	}
+
+	return storage, nil
+}
+
+// detachGuestImageStoreDevice detaches the guest image store disk and
+// removes its backing file, the images it contains are lost.
+func (s *Sandbox) detachGuestImageStoreDevice(ctx context.Context) error {
+	if s.devManager == nil {
+		return nil
+	}
+
+	image := s.guestImageStoreImage()
+
+	for _, dev := range s.devManager.GetAllDevices() {
+		if dev.DeviceType() != config.DeviceBlock {
+			continue
+		}
+
+		drive, ok := dev.GetDeviceInfo().(*config.BlockDrive)
+		if !ok || drive == nil || drive.File != image {
+			continue
+		}
+
+		if err := s.devManager.DetachDevice(ctx, dev.DeviceID(), s); err != nil {
+			return err
+		}
+
+		if err := s.devManager.RemoveDevice(dev.DeviceID()); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Remove(image); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestImageStore(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	savedDir := guestImageStoreDir
+	defer func() {
+		guestImageStoreDir = savedDir
+	}()
+
+	dir, err := ioutil.TempDir("", "guest-image-store")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	guestImageStoreDir = dir
+
+	sConfig := SandboxConfig{}
+	sConfig.HypervisorConfig.BlockDeviceDriver = config.VirtioSCSI
+	sandbox := &Sandbox{
+		id:         "image-store-sandbox",
+		hypervisor: &mockHypervisor{},
+		ctx:        context.Background(),
+		config:     &sConfig,
+		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", false, nil),
+		state:      types.SandboxState{BlockIndexMap: make(map[int]struct{})},
+	}
+
+	// Disabled
+	storage, err := k.guestImageStoreStorage(context.Background(), sandbox)
+	assert.NoError(err)
+	assert.Nil(storage)
+	assert.Empty(sandbox.devManager.GetAllDevices())
+
+	sConfig.HypervisorConfig.GuestImageStoreSize = 64
+	sConfig.HypervisorConfig.GuestImageStoreDiscard = true
+
+	// Not supported by the agent
+	storage, err = k.guestImageStoreStorage(context.Background(), sandbox)
+	assert.NoError(err)
+	assert.Nil(storage)
+	assert.Empty(sandbox.devManager.GetAllDevices())
+
+	k.caps.ImagePull = true
+	storage, err = k.guestImageStoreStorage(context.Background(), sandbox)
+	assert.NoError(err)
+	assert.NotNil(storage)
+	assert.Equal(kataSCSIDevType, storage.Driver)
+	assert.Equal(guestImageStoreFsType, storage.Fstype)
+	assert.Equal(guestImageStorePath, storage.MountPoint)
+	assert.Equal([]string{kataFormatDriverOption}, storage.DriverOptions)
+	assert.Equal([]string{"discard"}, storage.Options)
+
+	devices := sandbox.devManager.GetAllDevices()
+	assert.Len(devices, 1)
+	drive := devices[0].GetDeviceInfo().(*config.BlockDrive)
+	assert.True(drive.Discard)
+
+	st, err := os.Stat(sandbox.guestImageStoreImage())
+	assert.NoError(err)
+	assert.Equal(int64(64<<20), st.Size())
+
+	assert.NoError(sandbox.detachGuestImageStoreDevice(context.Background()))
+	assert.Empty(sandbox.devManager.GetAllDevices())
+	assert.NoFileExists(sandbox.guestImageStoreImage())
+
+	// Nothing to detach
+	assert.NoError(sandbox.detachGuestImageStoreDevice(context.Background()))
+}
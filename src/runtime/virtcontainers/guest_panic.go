@@ -0,0 +1,98 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+)
+
+// guestConsoleFile holds the guest console lines saved along with the
+// guest memory dump of a crashed sandbox.
+const guestConsoleFile = "console.log"
+
+// GuestPanicError is sent to the sandbox monitor watchers when the guest
+// kernel of the sandbox panicked.
+type GuestPanicError struct {
+	SandboxID string
+
+	// Console holds the last lines of the guest console. It is only
+	// captured when the console is watched, i.e. when debug is enabled.
+	Console []string
+
+	// DumpDir is the directory holding the guest memory dump, if one was
+	// taken.
+	DumpDir string
+}
+
+func (e *GuestPanicError) Error() string {
+	return fmt.Sprintf("guest kernel of sandbox %s panicked", e.SandboxID)
+}
+
+// handleGuestPanic is called by the hypervisor when the guest kernel
+// panicked. It marks the sandbox as crashed, dumps the guest memory if a
+// dump path is configured and notifies the sandbox monitor watchers so
+// that the sandbox can be torn down.
+func (s *Sandbox) handleGuestPanic(ctx context.Context) {
+	s.Logger().Error("guest kernel panicked")
+
+	panicErr := &GuestPanicError{SandboxID: s.id}
+	if s.cw != nil {
+		panicErr.Console = s.cw.lines()
+	}
+
+	s.markCrashed()
+	s.journal.record(JournalGuestPanic, "", "guest kernel panicked")
+
+	if s.hypervisor.hypervisorConfig().GuestMemoryDumpPath != "" {
+		dumpDir, err := s.DumpGuestMemory(ctx, GuestMemoryDumpOptions{})
+		if err != nil {
+			s.Logger().WithError(err).Error("failed to dump guest memory")
+		} else {
+			panicErr.DumpDir = dumpDir
+			s.saveGuestConsole(dumpDir, panicErr.Console)
+		}
+	}
+
+	if s.monitor != nil {
+		s.monitor.notify(ctx, panicErr)
+	}
+}
+
+// markCrashed moves the sandbox to the crashed state and saves it.
+func (s *Sandbox) markCrashed() {
+	if err := s.state.ValidTransition(s.state.State, types.StateCrashed); err != nil {
+		s.Logger().WithError(err).Warn("failed to mark sandbox as crashed")
+		return
+	}
+
+	if err := s.setSandboxState(types.StateCrashed); err != nil {
+		s.Logger().WithError(err).Warn("failed to mark sandbox as crashed")
+		return
+	}
+
+	if err := s.Save(); err != nil {
+		s.Logger().WithError(err).Warn("failed to save crashed sandbox state")
+	}
+}
+
+// saveGuestConsole writes the captured guest console lines next to a guest
+// memory dump.
+func (s *Sandbox) saveGuestConsole(dumpDir string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	data := []byte(strings.Join(lines, "\n") + "\n")
+	if err := ioutil.WriteFile(filepath.Join(dumpDir, guestConsoleFile), data, defaultFilePerms); err != nil {
+		s.Logger().WithError(err).Warn("failed to save guest console")
+	}
+}
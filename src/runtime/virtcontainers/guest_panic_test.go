@@ -0,0 +1,62 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleWatcherRing(t *testing.T) {
+	assert := assert.New(t)
+
+	cw := &consoleWatcher{}
+	assert.Empty(cw.lines())
+
+	cw.record("first")
+	cw.record("second")
+	assert.Equal([]string{"first", "second"}, cw.lines())
+
+	for i := 0; i < consoleRingSize+10; i++ {
+		cw.record(fmt.Sprintf("line %d", i))
+	}
+
+	lines := cw.lines()
+	assert.Len(lines, consoleRingSize)
+	assert.Equal("line 10", lines[0])
+	assert.Equal(fmt.Sprintf("line %d", consoleRingSize+9), lines[len(lines)-1])
+}
+
+func TestSandboxHandleGuestPanic(t *testing.T) {
+	contConfig := newTestContainerConfigNoop("505")
+	hConfig := newHypervisorConfig(nil, nil)
+	assert := assert.New(t)
+
+	s, err := testCreateSandbox(t, testSandboxID, MockHypervisor, hConfig, NetworkConfig{}, []ContainerConfig{contConfig}, nil)
+	assert.NoError(err)
+	defer cleanUp()
+
+	s.state.State = types.StateRunning
+	s.cw = &consoleWatcher{}
+	s.cw.record("Kernel panic - not syncing: test")
+
+	ch, err := s.Monitor(context.Background())
+	assert.NoError(err)
+	defer s.monitor.stop()
+
+	s.handleGuestPanic(context.Background())
+	assert.Equal(types.StateCrashed, s.state.State)
+
+	panicErr, ok := (<-ch).(*GuestPanicError)
+	assert.True(ok)
+	assert.Equal(testSandboxID, panicErr.SandboxID)
+	assert.Equal([]string{"Kernel panic - not syncing: test"}, panicErr.Console)
+	assert.Empty(panicErr.DumpDir)
+}
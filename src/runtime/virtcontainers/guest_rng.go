@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// guestRNGSeedSize is the number of bytes of host entropy the guest
+	// random number generator is seeded with.
+	guestRNGSeedSize = 512
+
+	// guestRNGSeedSource is the host source of the seed, the host random
+	// number generator is initialized long before the VMs are started, it
+	// does not block.
+	guestRNGSeedSource = "/dev/urandom"
+)
+
+// reseedGuestRNG adds guestRNGSeedSize bytes of host entropy to the guest
+// random number generator and reseeds it.
+func reseedGuestRNG(ctx context.Context, a agent) error {
+	f, err := os.Open(guestRNGSeedSource)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	data := make([]byte, guestRNGSeedSize)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return fmt.Errorf("failed to read %s: %v", guestRNGSeedSource, err)
+	}
+
+	return a.reseedRNG(ctx, data)
+}
+
+// seedGuestRNG seeds the guest random number generator once the agent is up,
+// when GuestRNGSeed is set, or as a fallback when the hypervisor provides no
+// virtio-rng device: the guest then only has the entropy of its own boot
+// and the getrandom(2) calls of the containers may block.
+func (s *Sandbox) seedGuestRNG(ctx context.Context) error {
+	if s.config.HypervisorConfig.GuestRNGSeed {
+		s.Logger().Info("seeding the guest random number generator")
+		return reseedGuestRNG(ctx, s.agent)
+	}
+
+	caps := s.hypervisor.capabilities(ctx)
+	if caps.IsRNGDeviceSupported() {
+		return nil
+	}
+
+	// Only a fallback, e.g. the agent policy may deny the request.
+	s.Logger().Info("no virtio-rng device, seeding the guest random number generator")
+	if err := reseedGuestRNG(ctx, s.agent); err != nil {
+		s.Logger().WithError(err).Warn("seeding the guest random number generator failed")
+	}
+
+	return nil
+}
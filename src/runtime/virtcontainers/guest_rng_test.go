@@ -0,0 +1,66 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type reseedAgent struct {
+	mockAgent
+	seeds [][]byte
+	err   error
+}
+
+func (a *reseedAgent) reseedRNG(ctx context.Context, data []byte) error {
+	a.seeds = append(a.seeds, data)
+	return a.err
+}
+
+type rngHypervisor struct {
+	mockHypervisor
+}
+
+func (h *rngHypervisor) capabilities(ctx context.Context) types.Capabilities {
+	caps := h.mockHypervisor.capabilities(ctx)
+	caps.SetRNGDeviceSupport()
+	return caps
+}
+
+func TestSandboxSeedGuestRNG(t *testing.T) {
+	assert := assert.New(t)
+
+	agent := &reseedAgent{}
+	s := &Sandbox{
+		agent:      agent,
+		hypervisor: &rngHypervisor{},
+		config:     &SandboxConfig{},
+	}
+
+	// The virtio-rng device feeds the guest
+	assert.NoError(s.seedGuestRNG(context.Background()))
+	assert.Empty(agent.seeds)
+
+	s.config.HypervisorConfig.GuestRNGSeed = true
+	assert.NoError(s.seedGuestRNG(context.Background()))
+	assert.Len(agent.seeds, 1)
+	assert.Len(agent.seeds[0], guestRNGSeedSize)
+
+	agent.err = errors.New("denied")
+	assert.Error(s.seedGuestRNG(context.Background()))
+
+	// No virtio-rng device, the seed is a fallback
+	agent.seeds = nil
+	s.config.HypervisorConfig.GuestRNGSeed = false
+	s.hypervisor = &mockHypervisor{}
+	assert.NoError(s.seedGuestRNG(context.Background()))
+	assert.Len(agent.seeds, 1)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+)
+
+// ipcSysctls are the non "fs.mqueue." sysctls of the IPC namespace
+var ipcSysctls = map[string]bool{
+	"kernel.msgmax":          true,
+	"kernel.msgmnb":          true,
+	"kernel.msgmni":          true,
+	"kernel.sem":             true,
+	"kernel.shmall":          true,
+	"kernel.shmmax":          true,
+	"kernel.shmmni":          true,
+	"kernel.shm_rmid_forced": true,
+}
+
+// sysctlNamespace returns the namespace a sysctl is specific to, or "" when
+// it applies to the whole VM.
+func sysctlNamespace(key string) string {
+	switch {
+	case ipcSysctls[key], strings.HasPrefix(key, "fs.mqueue."):
+		return "ipc"
+	case strings.HasPrefix(key, "net."):
+		return "network"
+	case key == "kernel.hostname", key == "kernel.domainname":
+		return "uts"
+	}
+
+	return ""
+}
+
+// validateContainerSysctls checks that the sysctls of a container are
+// specific to its namespaces, the agent refusing to set the others, which
+// would apply to the whole VM, from a container.
+func validateContainerSysctls(linux *grpc.Linux) error {
+	if linux == nil {
+		return nil
+	}
+
+	for key := range linux.Sysctl {
+		if sysctlNamespace(key) == "" {
+			return fmt.Errorf("sysctl %s is not namespaced and cannot be set for a container, set it for the VM with the guest_sysctls option", key)
+		}
+	}
+
+	return nil
+}
+
+// ParseGuestSysctl parses a "key=value" sysctl of the guest_sysctls option,
+// the key being the dot separated names of its /proc/sys entry.
+func ParseGuestSysctl(sysctl string) (string, string, error) {
+	kv := strings.SplitN(sysctl, "=", 2)
+	if len(kv) != 2 {
+		return "", "", fmt.Errorf("invalid guest sysctl %q, expected key=value", sysctl)
+	}
+
+	key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+	for _, name := range strings.Split(key, ".") {
+		if name == "" || strings.IndexFunc(name, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+		}) >= 0 {
+			return "", "", fmt.Errorf("invalid guest sysctl name %q", key)
+		}
+	}
+
+	if value == "" || strings.Contains(value, "\n") {
+		return "", "", fmt.Errorf("invalid value %q of guest sysctl %s", value, key)
+	}
+
+	return key, value, nil
+}
+
+// setupGuestSysctls returns the guest_sysctls the agent sets for the whole
+// VM when the sandbox is created, before the containers are.
+func setupGuestSysctls(sysctls []string) (map[string]string, error) {
+	if len(sysctls) == 0 {
+		return nil, nil
+	}
+
+	guestSysctls := make(map[string]string, len(sysctls))
+	for _, sysctl := range sysctls {
+		key, value, err := ParseGuestSysctl(sysctl)
+		if err != nil {
+			return nil, err
+		}
+		guestSysctls[key] = value
+	}
+
+	return guestSysctls, nil
+}
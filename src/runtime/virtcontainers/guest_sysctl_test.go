@@ -0,0 +1,88 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSysctlNamespace(t *testing.T) {
+	assert := assert.New(t)
+
+	for key, ns := range map[string]string{
+		"kernel.shmmax":               "ipc",
+		"fs.mqueue.msg_max":           "ipc",
+		"net.ipv4.ip_forward":         "network",
+		"net.core.somaxconn":          "network",
+		"kernel.hostname":             "uts",
+		"vm.max_map_count":            "",
+		"kernel.pid_max":              "",
+		"fs.inotify.max_user_watches": "",
+	} {
+		assert.Equal(ns, sysctlNamespace(key), key)
+	}
+}
+
+func TestValidateContainerSysctls(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validateContainerSysctls(nil))
+	assert.NoError(validateContainerSysctls(&grpc.Linux{}))
+	assert.NoError(validateContainerSysctls(&grpc.Linux{
+		Sysctl: map[string]string{"net.ipv4.ip_forward": "1", "kernel.shm_rmid_forced": "1"},
+	}))
+
+	err := validateContainerSysctls(&grpc.Linux{
+		Sysctl: map[string]string{"vm.max_map_count": "262144"},
+	})
+	assert.Error(err)
+	assert.Contains(err.Error(), "guest_sysctls")
+}
+
+func TestParseGuestSysctl(t *testing.T) {
+	assert := assert.New(t)
+
+	key, value, err := ParseGuestSysctl(" net.ipv4.ip_local_port_range = 1024 65000")
+	assert.NoError(err)
+	assert.Equal("net.ipv4.ip_local_port_range", key)
+	assert.Equal("1024 65000", value)
+
+	for _, s := range []string{
+		"",
+		"vm.swappiness",
+		"=1",
+		"vm.swappiness=",
+		"vm..swappiness=1",
+		"vm/swappiness=1",
+		"../../etc/passwd=1",
+		"vm.*=1",
+		"vm.swappiness=1\nkernel.pid_max=1",
+	} {
+		_, _, err := ParseGuestSysctl(s)
+		assert.Error(err, s)
+	}
+}
+
+func TestSetupGuestSysctls(t *testing.T) {
+	assert := assert.New(t)
+
+	sysctls, err := setupGuestSysctls(nil)
+	assert.NoError(err)
+	assert.Nil(sysctls)
+
+	sysctls, err = setupGuestSysctls([]string{"vm.max_map_count=262144", " net.core.rmem_max = 8388608"})
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"vm.max_map_count":  "262144",
+		"net.core.rmem_max": "8388608",
+	}, sysctls)
+
+	_, err = setupGuestSysctls([]string{"vm.max_map_count=262144", "../../etc/passwd=1"})
+	assert.Error(err)
+}
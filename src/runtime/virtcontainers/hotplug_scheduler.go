@@ -0,0 +1,175 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+// hotplugTask is a device attachment run by runHotplugTasks, after the
+// tasks it depends on.
+type hotplugTask struct {
+	id   string
+	deps []string
+	run  func(ctx context.Context) error
+}
+
+// runHotplugTasks runs the tasks, at most concurrency of them at once, each
+// task once all its dependencies succeeded. No task is started anymore after
+// one failed, the error of the first failed task is returned once the
+// running tasks are done. The tasks run in their order when concurrency is
+// 1 or less.
+func runHotplugTasks(ctx context.Context, concurrency int, tasks []hotplugTask) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	pending := make(map[string]int, len(tasks))
+	dependents := make(map[string][]int)
+	for i, t := range tasks {
+		if _, found := pending[t.id]; found {
+			return fmt.Errorf("duplicate hotplug task %s", t.id)
+		}
+		pending[t.id] = i
+	}
+	for i, t := range tasks {
+		for _, dep := range t.deps {
+			if _, found := pending[dep]; !found {
+				return fmt.Errorf("hotplug task %s depends on unknown task %s", t.id, dep)
+			}
+			dependents[dep] = append(dependents[dep], i)
+		}
+	}
+
+	remaining := make([]int, len(tasks))
+	var ready []int
+	for i, t := range tasks {
+		remaining[i] = len(t.deps)
+		if remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	type result struct {
+		index int
+		err   error
+	}
+
+	var (
+		wg       sync.WaitGroup
+		firstErr error
+		running  int
+		done     int
+	)
+	results := make(chan result)
+
+	for done < len(tasks) {
+		for firstErr == nil && running < concurrency && len(ready) > 0 {
+			i := ready[0]
+			ready = ready[1:]
+			running++
+
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results <- result{i, tasks[i].run(ctx)}
+			}(i)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		done++
+
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+
+		for _, d := range dependents[tasks[r.index].id] {
+			remaining[d]--
+			if remaining[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if done < len(tasks) {
+		return fmt.Errorf("hotplug tasks dependency cycle")
+	}
+
+	return nil
+}
+
+// hotplugConcurrency returns the number of devices of a container attached at
+// once.
+func (s *Sandbox) hotplugConcurrency() int {
+	if s.config == nil || s.config.HypervisorConfig.HotplugConcurrency == 0 {
+		return 1
+	}
+
+	return int(s.config.HypervisorConfig.HotplugConcurrency)
+}
+
+// attachDeviceTasks returns the tasks attaching the devices. The VFIO
+// devices take the bridge or root port slots first, in their order, the
+// other devices are attached after them, in any order.
+func (c *Container) attachDeviceTasks(ids []string) []hotplugTask {
+	var (
+		tasks []hotplugTask
+		vfio  []string
+	)
+
+	isVFIO := func(id string) bool {
+		dev := c.sandbox.devManager.GetDeviceByID(id)
+		return dev != nil && dev.DeviceType() == config.DeviceVFIO
+	}
+
+	// A device may be listed twice, the task IDs are unique.
+	for i, id := range ids {
+		if !isVFIO(id) {
+			continue
+		}
+
+		task := hotplugTask{id: fmt.Sprintf("%d-%s", i, id), run: c.attachDeviceFunc(id)}
+		if len(vfio) > 0 {
+			task.deps = []string{vfio[len(vfio)-1]}
+		}
+		vfio = append(vfio, task.id)
+		tasks = append(tasks, task)
+	}
+
+	for i, id := range ids {
+		if isVFIO(id) {
+			continue
+		}
+
+		tasks = append(tasks, hotplugTask{id: fmt.Sprintf("%d-%s", i, id), deps: vfio, run: c.attachDeviceFunc(id)})
+	}
+
+	return tasks
+}
+
+func (c *Container) attachDeviceFunc(id string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return c.sandbox.devManager.AttachDevice(ctx, id, c.sandbox)
+	}
+}
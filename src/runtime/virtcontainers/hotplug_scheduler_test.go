@@ -0,0 +1,142 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunHotplugTasks(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		lock     sync.Mutex
+		order    []string
+		running  int
+		maxAtOne int
+	)
+
+	task := func(id string, deps ...string) hotplugTask {
+		return hotplugTask{
+			id:   id,
+			deps: deps,
+			run: func(ctx context.Context) error {
+				lock.Lock()
+				running++
+				if running > maxAtOne {
+					maxAtOne = running
+				}
+				lock.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				lock.Lock()
+				running--
+				order = append(order, id)
+				lock.Unlock()
+				return nil
+			},
+		}
+	}
+
+	tasks := []hotplugTask{
+		task("bridge"),
+		task("a", "bridge"),
+		task("b", "bridge"),
+		task("c", "bridge"),
+		task("d", "a", "b"),
+	}
+
+	assert.NoError(runHotplugTasks(context.Background(), 2, tasks))
+	assert.Len(order, 5)
+	assert.Equal("bridge", order[0])
+	position := make(map[string]int)
+	for i, id := range order {
+		position[id] = i
+	}
+	assert.True(position["d"] > position["a"] && position["d"] > position["b"])
+	assert.Equal(2, maxAtOne)
+
+	// In their order, one at a time
+	order, maxAtOne = nil, 0
+	assert.NoError(runHotplugTasks(context.Background(), 0, tasks))
+	assert.Equal([]string{"bridge", "a", "b", "c", "d"}, order)
+	assert.Equal(1, maxAtOne)
+
+	// Nothing is started after a failure
+	order = nil
+	failing := task("a", "bridge")
+	failing.run = func(ctx context.Context) error {
+		return errors.New("hotplug failed")
+	}
+	err := runHotplugTasks(context.Background(), 1, []hotplugTask{task("bridge"), failing, task("b", "a")})
+	assert.EqualError(err, "hotplug failed")
+	assert.Equal([]string{"bridge"}, order)
+
+	assert.Error(runHotplugTasks(context.Background(), 2, []hotplugTask{task("a", "b"), task("b", "a")}))
+	assert.Error(runHotplugTasks(context.Background(), 2, []hotplugTask{task("a", "unknown")}))
+	assert.Error(runHotplugTasks(context.Background(), 2, []hotplugTask{task("a"), task("a")}))
+	assert.NoError(runHotplugTasks(context.Background(), 2, nil))
+}
+
+func TestAttachDevicesConcurrently(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "hotplug")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	sConfig := SandboxConfig{}
+	sConfig.HypervisorConfig.BlockDeviceDriver = config.VirtioSCSI
+	sConfig.HypervisorConfig.HotplugConcurrency = 4
+	sandbox := &Sandbox{
+		id:         "hotplug-sandbox",
+		hypervisor: &mockHypervisor{},
+		ctx:        context.Background(),
+		config:     &sConfig,
+		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", false, nil),
+		state:      types.SandboxState{BlockIndexMap: make(map[int]struct{})},
+	}
+	c := &Container{id: "foo", sandbox: sandbox}
+
+	var devices []ContainerDevice
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		image := filepath.Join(dir, name)
+		assert.NoError(ioutil.WriteFile(image, nil, 0600))
+
+		dev, err := sandbox.devManager.NewDevice(config.DeviceInfo{
+			HostPath:      image,
+			ContainerPath: "/" + name,
+			DevType:       "b",
+			Major:         -1,
+		})
+		assert.NoError(err)
+		devices = append(devices, ContainerDevice{ID: dev.DeviceID(), ContainerPath: "/" + name})
+	}
+
+	assert.NoError(c.attachDevices(context.Background(), devices))
+
+	indexes := make(map[int]struct{})
+	for _, d := range devices {
+		assert.True(sandbox.devManager.IsDeviceAttached(d.ID))
+		drive := sandbox.devManager.GetDeviceByID(d.ID).GetDeviceInfo().(*config.BlockDrive)
+		indexes[drive.Index] = struct{}{}
+	}
+	assert.Len(indexes, len(devices), "the block indexes must be unique")
+	assert.Len(sandbox.state.BlockIndexMap, len(devices))
+}
@@ -67,7 +67,8 @@ const (
 	vSockPort = 1024
 
 	// Port where the agent will send the logs. Logs are sent through the vsock in cases
-	// where the hypervisor has no console.sock, i.e firecracker
+	// where the hypervisor has no console.sock, i.e firecracker, or when they are
+	// collected by kata-agent-proxy
 	vSockLogsPort = 1025
 
 	// MinHypervisorMemory is the minimum memory required for a VM.
@@ -98,6 +99,20 @@ var commonVirtioblkKernelRootParams = []Param{ //nolint: unused, deadcode, varch
 	{"rootfstype", "ext4"},
 }
 
+// ReadOnlyImageKernelParams are the kernel parameters making the guest image
+// strictly read-only, the directories which must be writable get tmpfs
+// overlays.
+var ReadOnlyImageKernelParams = []Param{
+	{"ro", ""},
+	{"agent.tmpfs_overlay", "/run,/tmp"},
+}
+
+// RNGTrustCPUKernelParams are the kernel parameters initializing the guest
+// random number generator from the CPU one.
+var RNGTrustCPUKernelParams = []Param{
+	{"random.trust_cpu", "on"},
+}
+
 // deviceType describes a virtualized device type.
 type deviceType int
 
@@ -220,6 +235,31 @@ type Param struct {
 	Value string
 }
 
+// GuestMemoryStats are the guest memory statistics reported by the
+// virtio-balloon device.
+type GuestMemoryStats struct {
+	// Stats are the statistics by name, e.g. "available_memory" or
+	// "major_faults", the memory sizes in bytes. "actual" is the memory
+	// size of the guest minus the size of the balloon.
+	Stats map[string]uint64
+}
+
+// BlockVolumeClass are the options of the block volumes of a class, e.g. the
+// volumes of the databases or the scratch volumes.
+type BlockVolumeClass struct {
+	// Cache is the cache mode of the volumes, "none" bypassing the host
+	// page cache or "writeback". The block device cache options of the
+	// hypervisor apply when empty.
+	Cache string
+
+	// Discard passes the discard requests of the guest to the volumes.
+	Discard bool
+
+	// DetectZeroes is the detect-zeroes mode of the volumes, "off", "on"
+	// or "unmap", the latter requiring Discard.
+	DetectZeroes string
+}
+
 // HypervisorConfig is the hypervisor configuration.
 type HypervisorConfig struct {
 	// PCIeRootPort is used to indicate the number of PCIe Root Port devices
@@ -273,6 +313,10 @@ type HypervisorConfig struct {
 	// CPUFeatures are cpu specific features
 	CPUFeatures string
 
+	// CPUModel is the guest CPU model, the architecture default one is
+	// used when it's empty.
+	CPUModel string
+
 	// HypervisorPath is the hypervisor executable host path.
 	HypervisorPath string
 
@@ -311,6 +355,15 @@ type HypervisorConfig struct {
 	// entropy (/dev/random, /dev/urandom or real hardware RNG device)
 	EntropySource string
 
+	// EntropyMaxBytes is the number of bytes the guest can read from the
+	// entropy source per EntropyPeriod, unlimited when it is 0.
+	EntropyMaxBytes uint32
+
+	// EntropyPeriod is the period of the entropy rate limit, in
+	// milliseconds. The hypervisor default, 60 seconds for QEMU, is used
+	// when it is 0.
+	EntropyPeriod uint32
+
 	// Shared file system type:
 	//   - virtio-9p (default)
 	//   - virtio-fs
@@ -334,6 +387,28 @@ type HypervisorConfig struct {
 	// VirtioFSExtraArgs passes options to virtiofsd daemon
 	VirtioFSExtraArgs []string
 
+	// VirtioFSSandbox is the sandbox mode of virtiofsd, one of namespace,
+	// chroot or none. virtiofsd picks its default when empty.
+	VirtioFSSandbox string
+
+	// VirtioFSSeccomp is the action taken by the seccomp filter of
+	// virtiofsd, one of kill, log, trap or none.
+	VirtioFSSeccomp string
+
+	// VirtioFSRestarts is the number of times a crashed virtiofsd is
+	// restarted, the sandbox is stopped as soon as virtiofsd quits when
+	// it is 0.
+	VirtioFSRestarts uint32
+
+	// LaunchAttempts is the number of times the hypervisor is launched
+	// when it fails to start for a transient reason, e.g. a busy host.
+	// 0 or 1 launches it once.
+	LaunchAttempts uint32
+
+	// LaunchBackoff is the delay, in milliseconds, before the second
+	// launch attempt, doubled after each failed attempt.
+	LaunchBackoff uint32
+
 	// Enable annotations by name
 	EnableAnnotations []string
 
@@ -366,10 +441,82 @@ type HypervisorConfig struct {
 	// DisableBlockDeviceUse disallows a block device from being used.
 	DisableBlockDeviceUse bool
 
+	// ErofsLayers passes the layers of the overlay rootfs as read-only
+	// EROFS block devices, stacked in the guest, instead of through the
+	// shared file system.
+	ErofsLayers bool
+
+	// GuestImageStoreSize is the size in MiB of the scratch disk the
+	// guest pulls the container images to, instead of the guest memory.
+	// 0 disables it.
+	GuestImageStoreSize uint32
+
+	// GuestImageStoreDiscard frees the host blocks of the guest image
+	// store disk as the guest frees them.
+	GuestImageStoreDiscard bool
+
+	// BlockVolumeClasses are the block volume classes by name, the block
+	// volumes of a container are set a class through the
+	// BlockVolumeClasses container annotation.
+	BlockVolumeClasses map[string]BlockVolumeClass
+
 	// EnableIOThreads enables IO to be processed in a separate thread.
 	// Supported currently for virtio-scsi driver.
 	EnableIOThreads bool
 
+	// HotplugIOThreads is the number of iothreads the hot plugged
+	// virtio-blk devices are distributed across, round-robin. The
+	// iothreads are added on demand, 0 disables them.
+	HotplugIOThreads uint32
+
+	// HotplugConcurrency is the number of devices of a container attached
+	// at once, the host side preparation of the devices, e.g. the VFIO
+	// driver binding, running concurrently. 0 or 1 attaches them one
+	// after the other.
+	HotplugConcurrency uint32
+
+	// BalloonStatsInterval is the interval, in seconds, the guest memory
+	// statistics of the virtio-balloon device are polled at, 0 disables
+	// the device.
+	BalloonStatsInterval uint32
+
+	// VhostUserGPUDaemon is the path of the vhost-user-gpu backend, e.g.
+	// the vhost-user-gpu program of QEMU, started for the sandbox to serve
+	// a vhost-user-gpu device. No device is added when it is empty.
+	VhostUserGPUDaemon string
+
+	// VhostUserGPUExtraArgs are the extra arguments of the vhost-user-gpu
+	// backend, e.g. --virgl or --render-node.
+	VhostUserGPUExtraArgs []string
+
+	// VirtioSound is the driver of the QEMU audio backend of a virtio-sound
+	// device, e.g. none. No device is added when it is empty.
+	VirtioSound string
+
+	// EmulatorThreadsWeight is the cgroup cpu weight of the hypervisor
+	// threads that are neither vCPU nor iothreads. When set, these threads
+	// are moved into their own child cgroup of the sandbox cgroup.
+	EmulatorThreadsWeight uint64
+
+	// VCPUThreadsWeight is the cgroup cpu weight of the vCPU threads.
+	// When set, these threads are moved into their own child cgroup of
+	// the sandbox cgroup.
+	VCPUThreadsWeight uint64
+
+	// IOThreadsWeight is the cgroup cpu weight of the iothreads. When
+	// set, these threads are moved into their own child cgroup of the
+	// sandbox cgroup.
+	IOThreadsWeight uint64
+
+	// EnableNUMAPinning binds the guest memory to the host NUMA node
+	// NUMANode, and places the iothreads and the virtiofsd threads on the
+	// CPUs of that node.
+	EnableNUMAPinning bool
+
+	// NUMANode is the host NUMA node the guest memory is bound to, when
+	// EnableNUMAPinning is set.
+	NUMANode uint32
+
 	// Debug changes the default hypervisor and kernel parameters to
 	// enable debug output where available.
 	Debug bool
@@ -397,6 +544,10 @@ type HypervisorConfig struct {
 	// enabling higher density
 	Mlock bool
 
+	// MockFaults are the faults the mock hypervisor injects, it is only
+	// used for testing.
+	MockFaults []MockFault
+
 	// DisableNestingChecks is used to override customizations performed
 	// when running on top of another VMM.
 	DisableNestingChecks bool
@@ -404,10 +555,97 @@ type HypervisorConfig struct {
 	// DisableImageNvdimm is used to disable guest rootfs image nvdimm devices
 	DisableImageNvdimm bool
 
+	// ReadOnlyImage is used to make the guest image strictly read-only:
+	// the hypervisor must not open it read-write, the guest mounts it
+	// read-only and gets tmpfs overlays for the writable directories.
+	ReadOnlyImage bool
+
+	// GuestRNGSeed is used to seed the guest random number generator with
+	// host entropy through the agent once it is up, so that the guest does
+	// not wait for its boot entropy. The guest is seeded anyway when the
+	// hypervisor provides no virtio-rng device.
+	GuestRNGSeed bool
+
+	// RNGTrustCPU is used to initialize the guest random number generator
+	// from the CPU one, e.g. RDRAND, with the random.trust_cpu kernel
+	// parameter.
+	RNGTrustCPU bool
+
+	// ArtifactsManifest is the path of the manifest of the guest
+	// artifacts, a sha256sum(1) file listing the digests of the kernel,
+	// initrd, image and firmware the VM is launched with. They are not
+	// verified if empty.
+	ArtifactsManifest string
+
+	// ArtifactsManifestPublicKey is the path of the PEM encoded ed25519
+	// public key the detached signature of the artifacts manifest is
+	// verified with, the manifest is not authenticated if empty.
+	ArtifactsManifestPublicKey string
+
 	// HotplugVFIOOnRootBus is used to indicate if devices need to be hotplugged on the
 	// root bus instead of a bridge.
 	HotplugVFIOOnRootBus bool
 
+	// PreserveVFIOTopology is used to indicate that the cold plugged VFIO
+	// devices of a same host PCI root complex are plugged on a same guest
+	// root complex, keeping the peer to peer locality of the host, e.g. for
+	// GPUDirect RDMA between GPUs and NICs.
+	PreserveVFIOTopology bool
+
+	// ColdPlugDevices is used to indicate that devices cannot be hot
+	// plugged, e.g. in confidential guests. The devices of the containers
+	// known at sandbox creation and the ColdPlugDevicePaths ones are
+	// cold plugged before the VM boots, hot plugging is then rejected.
+	ColdPlugDevices bool
+
+	// ColdPlugDevicePaths is the list of host devices to cold plug, for
+	// the containers which are not known at sandbox creation.
+	ColdPlugDevicePaths []string
+
+	// ColdPlugDevicePathList is the list of valid values for cold plug
+	// device paths requested through annotations.
+	ColdPlugDevicePathList []string
+
+	// VFIOAPDevices is the list of the sysfs paths of the VFIO-AP mediated
+	// devices, e.g. /sys/devices/vfio_ap/matrix/<uuid>, passing IBM Z crypto
+	// domains to the sandbox. They are attached for the sandbox lifetime.
+	VFIOAPDevices []string
+
+	// VFIOAPDevicePathList is the list of valid values for VFIO-AP
+	// devices requested through annotations.
+	VFIOAPDevicePathList []string
+
+	// VFIOBindDevices is the list of the sysfs paths of the PCI devices,
+	// e.g. /sys/bus/pci/devices/0000:3b:00.0, bound to vfio-pci by the
+	// runtime and attached for the sandbox lifetime. They are bound back
+	// to their host driver when the sandbox is stopped.
+	VFIOBindDevices []string
+
+	// VFIOBindDevicePathList is the list of valid values for the PCI
+	// devices to bind to vfio-pci requested through annotations.
+	VFIOBindDevicePathList []string
+
+	// VFIOMdevDevices is the list of the sysfs paths of the mediated
+	// devices attached for the sandbox lifetime, either existing ones, e.g.
+	// /sys/bus/mdev/devices/<uuid>, or mediated device types, e.g.
+	// /sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63, a
+	// device of the type being created by the runtime and removed when the
+	// sandbox is stopped.
+	VFIOMdevDevices []string
+
+	// VFIOMdevDevicePathList is the list of valid values for the mediated
+	// devices requested through annotations.
+	VFIOMdevDevicePathList []string
+
+	// VhostUserNet is set when the sandbox network is backed by vhost-user
+	// sockets, the guest memory is then shared with the vhost-user
+	// backends.
+	VhostUserNet bool
+
+	// VhostUserNetSocketPathList is the list of valid values for the
+	// vhost-user network socket paths requested through annotations.
+	VhostUserNetSocketPathList []string
+
 	// GuestMemoryDumpPaging is used to indicate if enable paging
 	// for QEMU dump-guest-memory command
 	GuestMemoryDumpPaging bool
@@ -423,6 +661,10 @@ type HypervisorConfig struct {
 	// BootFromTemplate used to indicate if the VM should be created from a template VM
 	BootFromTemplate bool
 
+	// MigrationIncoming is used to launch the VM waiting for an incoming
+	// live migration, the guest state then comes from the source sandbox.
+	MigrationIncoming bool
+
 	// DisableVhostNet is used to indicate if host supports vhost_net
 	DisableVhostNet bool
 
@@ -433,9 +675,27 @@ type HypervisorConfig struct {
 	// related folders, sockets and device nodes should be.
 	VhostUserStorePath string
 
+	// EnableMultipath attaches each path of the dm-mpath host devices
+	// to the guest, which assembles the multipath devices again, rather
+	// than the devices themselves. It requires the virtio-scsi block
+	// device driver.
+	EnableMultipath bool
+
 	// GuestCoredumpPath is the path in host for saving guest memory dump
 	GuestMemoryDumpPath string
 
+	// Watchdog is the model of the emulated guest watchdog device.
+	// The guest watchdog is disabled if empty.
+	Watchdog string
+
+	// WatchdogAction is the recovery action taken when the guest
+	// watchdog expires, one of reset, fail or notify.
+	WatchdogAction string
+
+	// WatchdogTimeout is the number of seconds the guest watchdog can go
+	// without being petted by the agent before it expires.
+	WatchdogTimeout uint32
+
 	// GuestHookPath is the path within the VM that will be used for 'drop-in' hooks
 	GuestHookPath string
 
@@ -463,6 +723,8 @@ type HypervisorConfig struct {
 // vcpu mapping from vcpu number to thread number
 type vcpuThreadIDs struct {
 	vcpus map[int]int
+	// thread numbers of the iothreads, if the hypervisor supports them
+	ioThreads []int
 }
 
 func (conf *HypervisorConfig) checkTemplateConfig() error {
@@ -470,6 +732,10 @@ func (conf *HypervisorConfig) checkTemplateConfig() error {
 		return fmt.Errorf("Cannot set both 'to be' and 'from' vm tempate")
 	}
 
+	if conf.MigrationIncoming && (conf.BootToBeTemplate || conf.BootFromTemplate) {
+		return fmt.Errorf("Cannot boot a vm template waiting for an incoming migration")
+	}
+
 	if conf.BootToBeTemplate || conf.BootFromTemplate {
 		if conf.MemoryPath == "" {
 			return fmt.Errorf("Missing MemoryPath for vm template")
@@ -483,6 +749,44 @@ func (conf *HypervisorConfig) checkTemplateConfig() error {
 	return nil
 }
 
+// checkConfidentialGuestConfig reconciles the configuration with the
+// confidential guests, whose memory the host cannot access. The optional
+// device classes which need it are disabled, and the ones explicitly
+// requested are rejected, rather than failing obscurely in the guest.
+func (conf *HypervisorConfig) checkConfidentialGuestConfig() error {
+	if !conf.ConfidentialGuest {
+		return nil
+	}
+
+	// The vhost-user backends map the guest memory.
+	if conf.VhostUserNet {
+		return fmt.Errorf("vhost-user network interfaces are not supported by confidential guests: the vhost-user backend cannot access the guest memory")
+	}
+
+	if conf.EnableVhostUserStore {
+		conf.EnableVhostUserStore = false
+		virtLog.Info("Disabling the vhost-user devices as confidential guests do not support them")
+	}
+
+	if conf.VhostUserGPUDaemon != "" {
+		conf.VhostUserGPUDaemon = ""
+		virtLog.Info("Disabling the vhost-user-gpu device as confidential guests do not support it")
+	}
+
+	// The DAX window maps the host page cache in the guest memory.
+	if conf.SharedFS == config.VirtioFS && conf.VirtioFSCacheSize > 0 {
+		return fmt.Errorf("virtio-fs DAX (cache size %d MiB) is not supported by confidential guests: the host memory cannot be mapped in the guest", conf.VirtioFSCacheSize)
+	}
+
+	// The guest pages cannot be handed back to the host through the balloon.
+	if conf.BalloonStatsInterval > 0 {
+		conf.BalloonStatsInterval = 0
+		virtLog.Info("Disabling the virtio-balloon device as confidential guests do not support it")
+	}
+
+	return nil
+}
+
 func (conf *HypervisorConfig) valid() error {
 	if conf.KernelPath == "" {
 		return fmt.Errorf("Missing kernel path")
@@ -496,6 +800,10 @@ func (conf *HypervisorConfig) valid() error {
 		return err
 	}
 
+	if err := conf.checkConfidentialGuestConfig(); err != nil {
+		return err
+	}
+
 	if conf.NumVCPUs == 0 {
 		conf.NumVCPUs = defaultVCPUs
 	}
@@ -522,6 +830,18 @@ func (conf *HypervisorConfig) valid() error {
 		conf.Msize9p = defaultMsize9p
 	}
 
+	if err := conf.checkWatchdogConfig(); err != nil {
+		return err
+	}
+
+	if err := conf.checkVirtiofsdConfig(); err != nil {
+		return err
+	}
+
+	if conf.EntropyPeriod > 0 && conf.EntropyMaxBytes == 0 {
+		return fmt.Errorf("The entropy period requires an entropy max bytes limit")
+	}
+
 	return nil
 }
 
@@ -856,6 +1176,9 @@ type hypervisor interface {
 	hotplugRemoveDevice(ctx context.Context, devInfo interface{}, devType deviceType) (interface{}, error)
 	resizeMemory(ctx context.Context, memMB uint32, memoryBlockSizeMB uint32, probe bool) (uint32, memoryDevice, error)
 	resizeVCPUs(ctx context.Context, vcpus uint32) (uint32, uint32, error)
+	// resizeBlockDevice grows a hotplugged block device to size bytes, the
+	// guest being notified of its new capacity.
+	resizeBlockDevice(ctx context.Context, drive *config.BlockDrive, size uint64) error
 	getSandboxConsole(ctx context.Context, sandboxID string) (string, string, error)
 	disconnect(ctx context.Context)
 	capabilities(ctx context.Context) types.Capabilities
@@ -879,5 +1202,32 @@ type hypervisor interface {
 	// check if hypervisor supports built-in rate limiter.
 	isRateLimiterBuiltin() bool
 
+	// setVMMCgroup makes the hypervisor start the VMM directly in the
+	// cgroup v2 directory path, it returns false when the hypervisor
+	// cannot, the VMM then being moved to its cgroup once started.
+	setVMMCgroup(path string) bool
+
+	// dumpGuestMemory saves the guest memory under dumpSavePath, encrypted
+	// with encryptionKey when it's set, and returns the directory holding
+	// the dump and the name of the dump file in it.
+	dumpGuestMemory(dumpSavePath string, encryptionKey []byte) (string, string, error)
+
+	// prepareMigrationIncoming makes the VM, waiting for an incoming
+	// migration, listen on uri.
+	prepareMigrationIncoming(ctx context.Context, uri string) error
+	// startMigration starts migrating the VM to uri, the migration is
+	// paused before switching over to the destination.
+	startMigration(ctx context.Context, uri string) error
+	// migrationStatus returns the status of the VM migration.
+	migrationStatus(ctx context.Context) (string, error)
+	// migrationSwitchover completes the migration, i.e. it switches over
+	// to the destination on the source VM, and waits for the migration to
+	// complete on the destination one.
+	migrationSwitchover(ctx context.Context) error
+
+	// guestMemoryStats returns the guest memory statistics, none when the
+	// hypervisor doesn't report them.
+	guestMemoryStats(ctx context.Context) (GuestMemoryStats, error)
+
 	setSandbox(sandbox *Sandbox)
 }
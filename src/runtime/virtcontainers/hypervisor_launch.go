@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultLaunchBackoff is the default delay, in milliseconds, before the
+// hypervisor is launched again after a transient failure.
+const defaultLaunchBackoff = 100
+
+// transientLaunchErrors are the errors, as reported by the hypervisor or
+// by the kernel when starting it, of a launch failing because of the load
+// of the host rather than because of the VM configuration.
+var transientLaunchErrors = []string{
+	"Resource temporarily unavailable",
+	"Device or resource busy",
+}
+
+// isTransientLaunchError returns true if launching the hypervisor failed
+// with err and output for a reason that may go away when launching it
+// again, e.g. a fork hitting the process limit or a busy vhost device.
+// Any other failure, e.g. an invalid option or a missing file, is
+// deterministic and is not worth retrying.
+func isTransientLaunchError(err error, output string) bool {
+	if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EBUSY) {
+		return true
+	}
+
+	for _, e := range transientLaunchErrors {
+		if strings.Contains(output, e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// launchWithRetry calls launch, which starts the hypervisor and returns
+// its error output, until it succeeds, fails for a deterministic reason or
+// LaunchAttempts attempts have been made. The delay between two attempts
+// starts at LaunchBackoff milliseconds and is doubled after each failure.
+// The output and error of the last attempt are returned.
+func (conf *HypervisorConfig) launchWithRetry(ctx context.Context, logger *logrus.Entry, launch func() (string, error)) (string, error) {
+	backoff := time.Duration(conf.LaunchBackoff) * time.Millisecond
+	if backoff == 0 {
+		backoff = defaultLaunchBackoff * time.Millisecond
+	}
+
+	for attempt := uint32(1); ; attempt++ {
+		output, err := launch()
+		if err == nil || attempt >= conf.LaunchAttempts || !isTransientLaunchError(err, output) {
+			return output, err
+		}
+
+		logger.WithError(err).WithFields(logrus.Fields{
+			"attempt": attempt,
+			"backoff": backoff,
+		}).Warn("hypervisor launch failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return output, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientLaunchError(t *testing.T) {
+	assert := assert.New(t)
+
+	fork := &os.PathError{Op: "fork/exec", Path: "/usr/bin/qemu", Err: syscall.EAGAIN}
+	assert.True(isTransientLaunchError(fork, ""))
+	assert.True(isTransientLaunchError(fmt.Errorf("launch: %w", syscall.EBUSY), ""))
+	assert.True(isTransientLaunchError(errors.New("exit status 1"),
+		"qemu-system-x86_64: -device vhost-vsock-pci: vhost-vsock: unable to open: Device or resource busy"))
+
+	assert.False(isTransientLaunchError(errors.New("exit status 1"),
+		"qemu-system-x86_64: -machine foo: unsupported machine type"))
+	assert.False(isTransientLaunchError(&os.PathError{Op: "fork/exec", Path: "/usr/bin/qemu", Err: syscall.ENOENT}, ""))
+}
+
+func TestLaunchWithRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := &HypervisorConfig{LaunchAttempts: 3, LaunchBackoff: 1}
+	logger := virtLog.WithField("test", "launch")
+
+	// Transient failures are retried up to LaunchAttempts times
+	attempts := 0
+	output, err := conf.launchWithRetry(context.Background(), logger, func() (string, error) {
+		attempts++
+		return "Resource temporarily unavailable", errors.New("exit status 1")
+	})
+	assert.Error(err)
+	assert.Equal("Resource temporarily unavailable", output)
+	assert.Equal(3, attempts)
+
+	// The launch stops as soon as it succeeds
+	attempts = 0
+	_, err = conf.launchWithRetry(context.Background(), logger, func() (string, error) {
+		attempts++
+		if attempts == 1 {
+			return "", syscall.EAGAIN
+		}
+		return "", nil
+	})
+	assert.NoError(err)
+	assert.Equal(2, attempts)
+
+	// Deterministic failures are not retried
+	attempts = 0
+	_, err = conf.launchWithRetry(context.Background(), logger, func() (string, error) {
+		attempts++
+		return "invalid option", errors.New("exit status 1")
+	})
+	assert.Error(err)
+	assert.Equal(1, attempts)
+
+	// No retry once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts = 0
+	_, err = conf.launchWithRetry(ctx, logger, func() (string, error) {
+		attempts++
+		return "", syscall.EAGAIN
+	})
+	assert.Error(err)
+	assert.Equal(1, attempts)
+
+	// The hypervisor is launched once by default
+	attempts = 0
+	_, err = (&HypervisorConfig{}).launchWithRetry(context.Background(), logger, func() (string, error) {
+		attempts++
+		return "", syscall.EAGAIN
+	})
+	assert.Error(err)
+	assert.Equal(1, attempts)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// hypervisorLogSize is the number of bytes of the hypervisor output
+	// and of the guest console kept by a sandbox for diagnostics.
+	hypervisorLogSize = 64 * 1024
+
+	// hypervisorLogErrorSize is the number of bytes of the hypervisor log
+	// attached to the errors of the VM start.
+	hypervisorLogErrorSize = 4 * 1024
+)
+
+// The sources of the hypervisor log lines
+const (
+	hypervisorLogHypervisor = "hypervisor"
+	hypervisorLogConsole    = "console"
+)
+
+// hypervisorLog keeps the last lines written by the hypervisor and by the
+// guest on its console, up to hypervisorLogSize bytes, for them to be
+// served by the shim and attached to the errors of the VM start.
+type hypervisorLog struct {
+	sync.Mutex
+
+	lines []string
+	size  int
+}
+
+func newHypervisorLog() *hypervisorLog {
+	return &hypervisorLog{}
+}
+
+// add records a line of source, dropping the oldest lines once the log is
+// full.
+func (l *hypervisorLog) add(source, line string) {
+	if l == nil {
+		return
+	}
+
+	line = fmt.Sprintf("[%s] %s", source, line)
+
+	l.Lock()
+	defer l.Unlock()
+
+	l.lines = append(l.lines, line)
+	l.size += len(line) + 1
+	for l.size > hypervisorLogSize && len(l.lines) > 1 {
+		l.size -= len(l.lines[0]) + 1
+		l.lines = l.lines[1:]
+	}
+}
+
+// addOutput records the lines of output, as returned by a hypervisor
+// failing to launch.
+func (l *hypervisorLog) addOutput(source, output string) {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		l.add(source, line)
+	}
+}
+
+// tail returns the last lines of the log that fit in size bytes, all of
+// them when size is 0.
+func (l *hypervisorLog) tail(size int) []byte {
+	if l == nil {
+		return nil
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	start, n := len(l.lines), 0
+	for start > 0 {
+		lineSize := len(l.lines[start-1]) + 1
+		if size > 0 && n+lineSize > size {
+			break
+		}
+		n += lineSize
+		start--
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(n)
+	for _, line := range l.lines[start:] {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+// pipe returns the write end of a pipe for the output of the hypervisor
+// process, whose lines are recorded as source and logged. The caller closes
+// it once the process is started.
+func (l *hypervisorLog) pipe(source string, logger *logrus.Entry) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer r.Close()
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			l.add(source, scanner.Text())
+			logger.WithField("vmoutput", scanner.Text()).Debug("reading hypervisor output")
+		}
+
+		// Keep draining the output, the hypervisor must not block on it.
+		if err := scanner.Err(); err != nil {
+			logger.WithError(err).Warn("failed to read the hypervisor output")
+			io.Copy(ioutil.Discard, r)
+		}
+	}()
+
+	return w, nil
+}
+
+// HypervisorLog returns the last lines written by the hypervisor and by the
+// guest on its console that fit in size bytes, all of them when size is 0.
+func (s *Sandbox) HypervisorLog(size int) []byte {
+	return s.hypervisorLog.tail(size)
+}
+
+// withHypervisorLog attaches the end of the hypervisor log to err, so that
+// the errors of the VM start tell why the VM did not come up.
+func (s *Sandbox) withHypervisorLog(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	tail := s.hypervisorLog.tail(hypervisorLogErrorSize)
+	if len(tail) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%w, last hypervisor and guest console output:\n%s", err, tail)
+}
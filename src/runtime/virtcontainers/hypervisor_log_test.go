@@ -0,0 +1,78 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
+)
+
+func TestHypervisorLogTail(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newHypervisorLog()
+	assert.Empty(l.tail(0))
+
+	l.addOutput(hypervisorLogHypervisor, "qemu: could not open disk\nqemu: exiting\n")
+	l.add(hypervisorLogConsole, "Kernel panic")
+
+	assert.Equal("[hypervisor] qemu: could not open disk\n[hypervisor] qemu: exiting\n[console] Kernel panic\n", string(l.tail(0)))
+	assert.Equal("[hypervisor] qemu: exiting\n[console] Kernel panic\n", string(l.tail(50)))
+	assert.Empty(l.tail(10))
+
+	// The oldest lines are dropped once the log is full
+	line := strings.Repeat("x", 1000)
+	for i := 0; i < 2*hypervisorLogSize/len(line); i++ {
+		l.add(hypervisorLogConsole, fmt.Sprintf("%d %s", i, line))
+	}
+	assert.True(l.size <= hypervisorLogSize)
+	assert.Len(l.tail(0), l.size)
+	assert.NotContains(string(l.tail(0)), "Kernel panic")
+	assert.True(strings.HasSuffix(string(l.tail(0)), fmt.Sprintf("%d %s\n", 2*hypervisorLogSize/len(line)-1, line)))
+
+	// A sandbox without a log
+	var nilLog *hypervisorLog
+	nilLog.add(hypervisorLogConsole, "lost")
+	assert.Empty(nilLog.tail(0))
+}
+
+func TestHypervisorLogPipe(t *testing.T) {
+	assert := assert.New(t)
+
+	l := newHypervisorLog()
+	w, err := l.pipe(hypervisorLogHypervisor, virtLog)
+	assert.NoError(err)
+
+	fmt.Fprintln(w, "cloud-hypervisor: Error booting VM")
+	w.Close()
+
+	assert.Eventually(func() bool {
+		return string(l.tail(0)) == "[hypervisor] cloud-hypervisor: Error booting VM\n"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestWithHypervisorLog(t *testing.T) {
+	assert := assert.New(t)
+
+	s := &Sandbox{hypervisorLog: newHypervisorLog()}
+	launchErr := vcTypes.NewError(vcTypes.ErrCodeHypervisorLaunch, errors.New("failed to launch qemu"))
+
+	assert.NoError(s.withHypervisorLog(nil))
+	assert.Equal(launchErr, s.withHypervisorLog(launchErr))
+
+	s.hypervisorLog.add(hypervisorLogHypervisor, "qemu: could not open disk")
+	err := s.withHypervisorLog(launchErr)
+	assert.Contains(err.Error(), "failed to launch qemu")
+	assert.Contains(err.Error(), "[hypervisor] qemu: could not open disk")
+	assert.Equal(vcTypes.ErrCodeHypervisorLaunch, vcTypes.ErrorCodeOf(err))
+}
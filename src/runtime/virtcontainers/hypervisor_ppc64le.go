@@ -4,7 +4,19 @@
 
 package virtcontainers
 
-import "os"
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// MMURadix and MMUHash are the MMU modes of the POWER hosts, as
+	// reported by /proc/cpuinfo.
+	MMURadix = "Radix"
+	MMUHash  = "Hash"
+)
 
 //Returns pefProtection if the firmware directory exists
 func availableGuestProtection() (guestProtection, error) {
@@ -15,3 +27,37 @@ func availableGuestProtection() (guestProtection, error) {
 
 	return noneProtection, nil
 }
+
+// HostMMU returns the MMU mode of the host, MMURadix or MMUHash, read from
+// the "MMU" field of cpuInfoPath (such as /proc/cpuinfo).
+func HostMMU(cpuInfoPath string) (string, error) {
+	mmuField := "MMU"
+
+	f, err := os.Open(cpuInfoPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Expected format: "MMU		: Radix"
+		fields := strings.SplitN(scanner.Text(), ":", 2)
+		if len(fields) != 2 || strings.TrimSpace(fields[0]) != mmuField {
+			continue
+		}
+
+		mmu := strings.TrimSpace(fields[1])
+		if mmu != MMURadix && mmu != MMUHash {
+			return "", fmt.Errorf("unknown MMU mode %q in %q", mmu, cpuInfoPath)
+		}
+
+		return mmu, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("Couldn't find %q from %q output", mmuField, cpuInfoPath)
+}
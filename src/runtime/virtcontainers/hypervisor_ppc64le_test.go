@@ -0,0 +1,47 @@
+// Copyright (c) 2021 IBM
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostMMU(t *testing.T) {
+	assert := assert.New(t)
+
+	cpuInfoFile := filepath.Join(t.TempDir(), "cpuinfo")
+
+	type testData struct {
+		cpuInfo     string
+		expectedMMU string
+		expectError bool
+	}
+
+	data := []testData{
+		{"platform\t: pSeries\nMMU\t\t: Radix\n", MMURadix, false},
+		{"platform\t: PowerNV\nMMU\t\t: Hash\n", MMUHash, false},
+		{"platform\t: PowerNV\nMMU\t\t: Foo\n", "", true},
+		{"platform\t: PowerNV\n", "", true},
+	}
+
+	for i, d := range data {
+		assert.NoError(ioutil.WriteFile(cpuInfoFile, []byte(d.cpuInfo), 0640))
+
+		mmu, err := HostMMU(cpuInfoFile)
+		if d.expectError {
+			assert.Error(err, "test %d (%+v)", i, d)
+		} else {
+			assert.NoError(err, "test %d (%+v)", i, d)
+			assert.Equal(d.expectedMMU, mmu, "test %d (%+v)", i, d)
+		}
+	}
+
+	_, err := HostMMU(filepath.Join(t.TempDir(), "does-not-exist"))
+	assert.Error(err)
+}
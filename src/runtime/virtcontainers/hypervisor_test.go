@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -156,6 +157,59 @@ func TestHypervisorConfigValidTemplateConfig(t *testing.T) {
 	testHypervisorConfigValid(t, hypervisorConfig, true)
 	hypervisorConfig.MemoryPath = ""
 	testHypervisorConfigValid(t, hypervisorConfig, false)
+
+	hypervisorConfig.MemoryPath = "foobar"
+	hypervisorConfig.MigrationIncoming = true
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+	hypervisorConfig.BootToBeTemplate = false
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+}
+
+func TestHypervisorConfigValidEntropyRateLimit(t *testing.T) {
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:     fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:      fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath: fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		EntropyPeriod:  1000,
+	}
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+
+	hypervisorConfig.EntropyMaxBytes = 1024
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+
+	hypervisorConfig.EntropyPeriod = 0
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+}
+
+func TestHypervisorConfigValidConfidentialGuest(t *testing.T) {
+	assert := assert.New(t)
+
+	hypervisorConfig := &HypervisorConfig{
+		KernelPath:           fmt.Sprintf("%s/%s", testDir, testKernel),
+		ImagePath:            fmt.Sprintf("%s/%s", testDir, testImage),
+		HypervisorPath:       fmt.Sprintf("%s/%s", testDir, testHypervisor),
+		EnableVhostUserStore: true,
+		BalloonStatsInterval: 5,
+		SharedFS:             config.VirtioFS,
+	}
+
+	// Not a confidential guest
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+	assert.True(hypervisorConfig.EnableVhostUserStore)
+	assert.Equal(uint32(5), hypervisorConfig.BalloonStatsInterval)
+
+	// The optional devices are disabled
+	hypervisorConfig.ConfidentialGuest = true
+	testHypervisorConfigValid(t, hypervisorConfig, true)
+	assert.False(hypervisorConfig.EnableVhostUserStore)
+	assert.Zero(hypervisorConfig.BalloonStatsInterval)
+
+	// The requested ones are rejected
+	hypervisorConfig.VirtioFSCacheSize = 1024
+	testHypervisorConfigValid(t, hypervisorConfig, false)
+	hypervisorConfig.VirtioFSCacheSize = 0
+	hypervisorConfig.VhostUserNet = true
+	testHypervisorConfigValid(t, hypervisorConfig, false)
 }
 
 func TestHypervisorConfigDefaults(t *testing.T) {
@@ -8,6 +8,7 @@ package virtcontainers
 import (
 	"context"
 	"io"
+	"net"
 	"syscall"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
@@ -69,13 +70,39 @@ type VCSandbox interface {
 	ListInterfaces(ctx context.Context) ([]*pbTypes.Interface, error)
 	UpdateRoutes(ctx context.Context, routes []*pbTypes.Route) ([]*pbTypes.Route, error)
 	ListRoutes(ctx context.Context) ([]*pbTypes.Route, error)
+	InspectNetwork(ctx context.Context) (NetworkInspection, error)
+	CaptureNetwork(ctx context.Context, w io.Writer, config NetworkCaptureConfig) error
 
 	GetOOMEvent(ctx context.Context) (string, error)
 	GetHypervisorPid() (int, error)
 
 	UpdateRuntimeMetrics() error
 	GetAgentMetrics(ctx context.Context) (string, error)
+	GuestMemoryStats(ctx context.Context) (GuestMemoryStats, error)
 	GetAgentURL() (string, error)
+	DumpGuestMemory(ctx context.Context, opts GuestMemoryDumpOptions) (string, error)
+	Journal() ([]JournalEvent, error)
+	HypervisorLog(size int) []byte
+	PortForward(ctx context.Context, port uint32) (net.Conn, error)
+	PolicyDecisions(ctx context.Context) (net.Conn, error)
+	SyncGuestFilesystems(ctx context.Context) error
+	CheckpointContainer(ctx context.Context, containerID string, w io.Writer, leaveRunning bool) error
+	RestoreContainer(ctx context.Context, containerID string, images io.Reader, size int64) error
+
+	MigrationPrepareReceive(ctx context.Context, uri string) error
+	MigrationStart(ctx context.Context, uri string) error
+	MigrationStatus(ctx context.Context) (string, error)
+	MigrationSwitchover(ctx context.Context) error
+
+	VMResources() VMResources
+	ResizeVM(ctx context.Context, target VMResources) (VMResources, error)
+
+	Labels() map[string]string
+	UpdateLabels(ctx context.Context, set map[string]string, remove []string) (map[string]string, error)
+
+	ResizeVolume(ctx context.Context, volumePath string, size uint64) error
+
+	GuestDiagnostics(ctx context.Context, opts GuestDiagnosticsOptions) (GuestDiagnostics, error)
 }
 
 // VCContainer is the Container interface
@@ -0,0 +1,185 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+)
+
+const (
+	// sandboxJournalFile is the file holding the sandbox event journal,
+	// in the sandbox persist directory.
+	sandboxJournalFile = "journal.json"
+
+	// maxJournalSize is the size above which the journal is rotated, only
+	// the previous journal is kept.
+	maxJournalSize = 1024 * 1024
+)
+
+// Sandbox event journal entry types
+const (
+	JournalSandboxCreated   = "sandbox-created"
+	JournalSandboxStarted   = "sandbox-started"
+	JournalSandboxStopped   = "sandbox-stopped"
+	JournalVMStarted        = "vm-started"
+	JournalAgentStarted     = "agent-started"
+	JournalContainerCreated = "container-created"
+	JournalContainerStarted = "container-started"
+	JournalContainerStopped = "container-stopped"
+	JournalDeviceHotplugged = "device-hotplugged"
+	JournalDeviceUnplugged  = "device-unplugged"
+	JournalAgentRPCFailed   = "agent-rpc-failed"
+	JournalOOM              = "oom"
+	JournalGuestPanic       = "guest-panic"
+	JournalGuestWatchdog    = "guest-watchdog"
+	JournalVirtiofsdCrash   = "virtiofsd-crash"
+	JournalMigrationStarted = "migration-started"
+	JournalMigrationDone    = "migration-done"
+	JournalVMResized        = "vm-resized"
+	JournalCheckpointed     = "container-checkpointed"
+	JournalRestored         = "container-restored"
+	JournalLabelsUpdated    = "labels-updated"
+	JournalVolumeResized    = "volume-resized"
+)
+
+// JournalEvent is an entry of the sandbox event journal.
+type JournalEvent struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"`
+	Container string    `json:"container,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// eventJournal records the significant events in the life of a sandbox, so
+// that they can be looked at without debug logs. Events are appended to a
+// JSON lines file.
+type eventJournal struct {
+	sync.Mutex
+	path string
+}
+
+func newEventJournal(sandboxDir string) *eventJournal {
+	return &eventJournal{path: filepath.Join(sandboxDir, sandboxJournalFile)}
+}
+
+// record appends an event to the journal. Failures are only logged, the
+// journal must never get in the way of the sandbox operations. It is a no-op
+// on a nil journal.
+func (j *eventJournal) record(eventType, containerID, format string, args ...interface{}) {
+	if j == nil {
+		return
+	}
+
+	event := JournalEvent{
+		Time:      time.Now().UTC(),
+		Type:      eventType,
+		Container: containerID,
+		Message:   fmt.Sprintf(format, args...),
+	}
+
+	if err := j.append(event); err != nil {
+		virtLog.WithError(err).WithField("journal", j.path).Warn("failed to record sandbox event")
+	}
+}
+
+func (j *eventJournal) append(event JournalEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	j.Lock()
+	defer j.Unlock()
+
+	if st, err := os.Stat(j.path); err == nil && st.Size() > maxJournalSize {
+		if err := os.Rename(j.path, j.path+".1"); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), DirMode); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, defaultFilePerms)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// read returns the journal events, oldest first.
+func (j *eventJournal) read() ([]JournalEvent, error) {
+	j.Lock()
+	defer j.Unlock()
+
+	var events []JournalEvent
+	for _, path := range []string{j.path + ".1", j.path} {
+		e, err := readJournalFile(path)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e...)
+	}
+
+	return events, nil
+}
+
+func readJournalFile(path string) ([]JournalEvent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []JournalEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e JournalEvent
+		// skip lines truncated by a crash
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+
+	return events, scanner.Err()
+}
+
+// Journal returns the event journal of the sandbox, oldest event first.
+func (s *Sandbox) Journal() ([]JournalEvent, error) {
+	return s.journal.read()
+}
+
+// ReadSandboxJournal returns the event journal of a sandbox from its persist
+// directory. It doesn't require the sandbox to be running.
+func ReadSandboxJournal(sandboxID string) ([]JournalEvent, error) {
+	store, err := persist.GetDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(store.RunStoragePath(), sandboxID)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	return newEventJournal(dir).read()
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventJournal(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-journal")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// recording on a nil journal is a no-op
+	var nilJournal *eventJournal
+	nilJournal.record(JournalSandboxCreated, "", "sandbox created")
+
+	j := newEventJournal(filepath.Join(dir, testSandboxID))
+	events, err := j.read()
+	assert.NoError(err)
+	assert.Empty(events)
+
+	j.record(JournalSandboxCreated, "", "sandbox created")
+	j.record(JournalOOM, "foo", "container %s hit its memory limit", "foo")
+
+	events, err = j.read()
+	assert.NoError(err)
+	assert.Len(events, 2)
+	assert.Equal(JournalSandboxCreated, events[0].Type)
+	assert.Equal(JournalOOM, events[1].Type)
+	assert.Equal("foo", events[1].Container)
+	assert.Equal("container foo hit its memory limit", events[1].Message)
+	assert.False(events[1].Time.Before(events[0].Time))
+
+	// truncated entries are skipped
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(err)
+	_, err = f.WriteString(`{"time":"`)
+	assert.NoError(err)
+	f.Close()
+
+	events, err = j.read()
+	assert.NoError(err)
+	assert.Len(events, 2)
+}
+
+func TestEventJournalRotation(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-journal")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	j := newEventJournal(dir)
+	j.record(JournalSandboxCreated, "", "sandbox created")
+
+	// make the journal too big, the next record rotates it
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0)
+	assert.NoError(err)
+	_, err = f.WriteString(strings.Repeat(strings.Repeat(" ", 1023)+"\n", maxJournalSize/1024))
+	assert.NoError(err)
+	f.Close()
+
+	j.record(JournalSandboxStarted, "", "sandbox started")
+
+	_, err = os.Stat(j.path + ".1")
+	assert.NoError(err)
+
+	events, err := j.read()
+	assert.NoError(err)
+	assert.Len(events, 2)
+	assert.Equal(JournalSandboxCreated, events[0].Type)
+	assert.Equal(JournalSandboxStarted, events[1].Type)
+}
@@ -9,7 +9,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -21,6 +23,7 @@ import (
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
 	kataclient "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/client"
@@ -56,6 +59,10 @@ const (
 	// containers.
 	KataLocalDevType = "local"
 
+	// KataEncryptedScratchDevType creates a volume inside the VM backed by a scratch
+	// disk encrypted with an ephemeral key, for sharing files between containers.
+	KataEncryptedScratchDevType = "encrypted-scratch"
+
 	// Allocating an FSGroup that owns the pod's volumes
 	fsGid = "fsgid"
 
@@ -68,6 +75,17 @@ const (
 	kernelParamDebugConsole           = "agent.debug_console"
 	kernelParamDebugConsoleVPort      = "agent.debug_console_vport"
 	kernelParamDebugConsoleVPortValue = "1026"
+	kernelParamPortForwardVPort       = "agent.port_forward_vport"
+	portForwardVPort                  = 1027
+	kernelParamPolicyFile             = "agent.policy_file"
+	kernelParamPolicyAudit            = "agent.policy_audit"
+	kernelParamPolicyVPort            = "agent.policy_vport"
+	policyVPort                       = 1028
+	kernelParamNetworkCaptureVPort    = "agent.capture_vport"
+	networkCaptureVPort               = 1029
+	kernelParamCheckpointVPort        = "agent.checkpoint_vport"
+	checkpointVPort                   = 1032
+	kernelParamLogVPort               = "agent.log_vport"
 )
 
 var (
@@ -86,9 +104,15 @@ var (
 	kataBlkDevType              = "blk"
 	kataBlkCCWDevType           = "blk-ccw"
 	kataSCSIDevType             = "scsi"
+	kataSCSIMpathDevType        = "scsi-mpath"
 	kataNvdimmDevType           = "nvdimm"
+	kataVfioAPDevType           = "vfio-ap"
 	kataVirtioFSDevType         = "virtio-fs"
 	kataWatchableBindDevType    = "watchable-bind"
+	kataLUKSKeyDriverOption     = "luks_key="
+	kataMpathPathDriverOption   = "mpath_path="
+	kataScratchDriverOption     = "encryption=ephemeral"
+	kataScratchFsType           = "ext4"
 	sharedDir9pOptions          = []string{"trans=virtio,version=9p2000.L,cache=mmap", "nodev"}
 	sharedDirVirtioFSOptions    = []string{}
 	sharedDirVirtioFSDaxOptions = "dax"
@@ -112,37 +136,45 @@ const (
 )
 
 const (
-	grpcCheckRequest             = "grpc.CheckRequest"
-	grpcExecProcessRequest       = "grpc.ExecProcessRequest"
-	grpcCreateSandboxRequest     = "grpc.CreateSandboxRequest"
-	grpcDestroySandboxRequest    = "grpc.DestroySandboxRequest"
-	grpcCreateContainerRequest   = "grpc.CreateContainerRequest"
-	grpcStartContainerRequest    = "grpc.StartContainerRequest"
-	grpcRemoveContainerRequest   = "grpc.RemoveContainerRequest"
-	grpcSignalProcessRequest     = "grpc.SignalProcessRequest"
-	grpcUpdateRoutesRequest      = "grpc.UpdateRoutesRequest"
-	grpcUpdateInterfaceRequest   = "grpc.UpdateInterfaceRequest"
-	grpcListInterfacesRequest    = "grpc.ListInterfacesRequest"
-	grpcListRoutesRequest        = "grpc.ListRoutesRequest"
-	grpcAddARPNeighborsRequest   = "grpc.AddARPNeighborsRequest"
-	grpcOnlineCPUMemRequest      = "grpc.OnlineCPUMemRequest"
-	grpcUpdateContainerRequest   = "grpc.UpdateContainerRequest"
-	grpcWaitProcessRequest       = "grpc.WaitProcessRequest"
-	grpcTtyWinResizeRequest      = "grpc.TtyWinResizeRequest"
-	grpcWriteStreamRequest       = "grpc.WriteStreamRequest"
-	grpcCloseStdinRequest        = "grpc.CloseStdinRequest"
-	grpcStatsContainerRequest    = "grpc.StatsContainerRequest"
-	grpcPauseContainerRequest    = "grpc.PauseContainerRequest"
-	grpcResumeContainerRequest   = "grpc.ResumeContainerRequest"
-	grpcReseedRandomDevRequest   = "grpc.ReseedRandomDevRequest"
-	grpcGuestDetailsRequest      = "grpc.GuestDetailsRequest"
-	grpcMemHotplugByProbeRequest = "grpc.MemHotplugByProbeRequest"
-	grpcCopyFileRequest          = "grpc.CopyFileRequest"
-	grpcSetGuestDateTimeRequest  = "grpc.SetGuestDateTimeRequest"
-	grpcStartTracingRequest      = "grpc.StartTracingRequest"
-	grpcStopTracingRequest       = "grpc.StopTracingRequest"
-	grpcGetOOMEventRequest       = "grpc.GetOOMEventRequest"
-	grpcGetMetricsRequest        = "grpc.GetMetricsRequest"
+	grpcCheckRequest               = "grpc.CheckRequest"
+	grpcExecProcessRequest         = "grpc.ExecProcessRequest"
+	grpcCreateSandboxRequest       = "grpc.CreateSandboxRequest"
+	grpcDestroySandboxRequest      = "grpc.DestroySandboxRequest"
+	grpcCreateContainerRequest     = "grpc.CreateContainerRequest"
+	grpcStartContainerRequest      = "grpc.StartContainerRequest"
+	grpcRemoveContainerRequest     = "grpc.RemoveContainerRequest"
+	grpcSignalProcessRequest       = "grpc.SignalProcessRequest"
+	grpcUpdateRoutesRequest        = "grpc.UpdateRoutesRequest"
+	grpcUpdateInterfaceRequest     = "grpc.UpdateInterfaceRequest"
+	grpcListInterfacesRequest      = "grpc.ListInterfacesRequest"
+	grpcListRoutesRequest          = "grpc.ListRoutesRequest"
+	grpcAddARPNeighborsRequest     = "grpc.AddARPNeighborsRequest"
+	grpcOnlineCPUMemRequest        = "grpc.OnlineCPUMemRequest"
+	grpcUpdateContainerRequest     = "grpc.UpdateContainerRequest"
+	grpcWaitProcessRequest         = "grpc.WaitProcessRequest"
+	grpcTtyWinResizeRequest        = "grpc.TtyWinResizeRequest"
+	grpcWriteStreamRequest         = "grpc.WriteStreamRequest"
+	grpcCloseStdinRequest          = "grpc.CloseStdinRequest"
+	grpcStatsContainerRequest      = "grpc.StatsContainerRequest"
+	grpcPauseContainerRequest      = "grpc.PauseContainerRequest"
+	grpcResumeContainerRequest     = "grpc.ResumeContainerRequest"
+	grpcReseedRandomDevRequest     = "grpc.ReseedRandomDevRequest"
+	grpcGuestDetailsRequest        = "grpc.GuestDetailsRequest"
+	grpcMemHotplugByProbeRequest   = "grpc.MemHotplugByProbeRequest"
+	grpcCopyFileRequest            = "grpc.CopyFileRequest"
+	grpcSetGuestDateTimeRequest    = "grpc.SetGuestDateTimeRequest"
+	grpcStartTracingRequest        = "grpc.StartTracingRequest"
+	grpcStopTracingRequest         = "grpc.StopTracingRequest"
+	grpcGetOOMEventRequest         = "grpc.GetOOMEventRequest"
+	grpcGetMetricsRequest          = "grpc.GetMetricsRequest"
+	grpcResizeVolumeRequest        = "grpc.ResizeVolumeRequest"
+	grpcGuestDiagnosticsRequest    = "grpc.GuestDiagnosticsRequest"
+	grpcRemountSharedFSRequest     = "grpc.RemountSharedFSRequest"
+	grpcSyncFilesystemsRequest     = "grpc.SyncFilesystemsRequest"
+	grpcStartNetworkCaptureRequest = "grpc.StartNetworkCaptureRequest"
+	grpcStopNetworkCaptureRequest  = "grpc.StopNetworkCaptureRequest"
+	grpcCheckpointContainerRequest = "grpc.CheckpointContainerRequest"
+	grpcRestoreContainerRequest    = "grpc.RestoreContainerRequest"
 )
 
 // newKataAgent returns an agent from an agent type.
@@ -159,6 +191,12 @@ var kataHostSharedDir = func() string {
 	return defaultKataHostSharedDir
 }
 
+// KataHostSharedDir returns the host directory holding the shared and
+// private mount directories of all sandboxes.
+func KataHostSharedDir() string {
+	return kataHostSharedDir()
+}
+
 // Shared path handling:
 // 1. create three directories for each sandbox:
 // -. /run/kata-containers/shared/sandboxes/$sbx_id/mounts/, a directory to hold all host/guest shared mounts
@@ -216,15 +254,38 @@ func ephemeralPath() string {
 // KataAgentConfig is a structure storing information needed
 // to reach the Kata Containers agent.
 type KataAgentConfig struct {
-	LongLiveConn       bool
-	Debug              bool
-	Trace              bool
-	EnableDebugConsole bool
-	ContainerPipeSize  uint32
-	TraceMode          string
-	TraceType          string
-	DialTimeout        uint32
-	KernelModules      []string
+	LongLiveConn         bool
+	Debug                bool
+	Trace                bool
+	EnableDebugConsole   bool
+	EnablePortForward    bool
+	EnableNetworkCapture bool
+	EnableGuestSync      bool
+	EnableCheckpoint     bool
+	EnableLogProxy       bool
+	ContainerPipeSize    uint32
+	TraceMode            string
+	TraceType            string
+	DialTimeout          uint32
+	TimeSyncInterval     uint32
+	KernelModules        []string
+
+	// KernelModulesList is the allow-list of the kernel modules the
+	// kernel_modules annotation may load, as glob patterns of their names.
+	KernelModulesList []string
+
+	// GuestSysctls are the "key=value" sysctls set for the whole VM before
+	// the containers are created, and GuestSysctlsList the allow-list of
+	// the sysctls the guest_sysctls annotation may add, as glob patterns of
+	// their keys.
+	GuestSysctls     []string
+	GuestSysctlsList []string
+
+	// PolicyFile is the guest path of the policy allowing or denying the
+	// agent requests. With PolicyAudit, the denied requests are served
+	// and the policy decisions only reported.
+	PolicyFile  string
+	PolicyAudit bool
 }
 
 // KataAgentState is the structure describing the data stored from this
@@ -246,8 +307,40 @@ type kataAgent struct {
 	dialTimout     uint32
 	kmodules       []string
 
+	// portForwardEnabled is set when the agent serves the port forward
+	// vsock port.
+	portForwardEnabled bool
+
+	// policyEnabled is set when the agent enforces or audits a policy, and
+	// streams its decisions on the policy vsock port.
+	policyEnabled bool
+
+	// caps are the capabilities of the agent, negotiated when the
+	// sandbox starts.
+	caps agentCapabilities
+
+	// networkCaptureEnabled is set when the agent serves the streams of
+	// the network captures on the network capture vsock port.
+	networkCaptureEnabled bool
+
+	// guestSyncEnabled is set when the agent serves the guest file systems
+	// sync vsock port.
+	guestSyncEnabled bool
+
+	// checkpointEnabled is set when the agent serves the container
+	// checkpoint vsock port.
+	checkpointEnabled bool
+
+	// sysctls are the guest sysctls set by the agent when the sandbox
+	// starts.
+	sysctls []string
+
 	vmSocket interface{}
 	ctx      context.Context
+
+	// journal records the agent RPC failures, it is nil when the agent
+	// isn't bound to a sandbox.
+	journal *eventJournal
 }
 
 func (k *kataAgent) Logger() *logrus.Entry {
@@ -309,6 +402,30 @@ func KataAgentKernelParams(config KataAgentConfig) []Param {
 		params = append(params, Param{Key: kernelParamDebugConsoleVPort, Value: kernelParamDebugConsoleVPortValue})
 	}
 
+	if config.EnablePortForward {
+		params = append(params, Param{Key: kernelParamPortForwardVPort, Value: strconv.Itoa(portForwardVPort)})
+	}
+
+	if config.EnableNetworkCapture {
+		params = append(params, Param{Key: kernelParamNetworkCaptureVPort, Value: strconv.Itoa(networkCaptureVPort)})
+	}
+
+	if config.EnableCheckpoint {
+		params = append(params, Param{Key: kernelParamCheckpointVPort, Value: strconv.Itoa(checkpointVPort)})
+	}
+
+	if config.EnableLogProxy {
+		params = append(params, Param{Key: kernelParamLogVPort, Value: strconv.Itoa(vSockLogsPort)})
+	}
+
+	if config.PolicyFile != "" {
+		params = append(params, Param{Key: kernelParamPolicyFile, Value: config.PolicyFile})
+		params = append(params, Param{Key: kernelParamPolicyVPort, Value: strconv.Itoa(policyVPort)})
+		if config.PolicyAudit {
+			params = append(params, Param{Key: kernelParamPolicyAudit, Value: ""})
+		}
+	}
+
 	return params
 }
 
@@ -332,6 +449,7 @@ func (k *kataAgent) handleTraceSettings(config KataAgentConfig) bool {
 func (k *kataAgent) init(ctx context.Context, sandbox *Sandbox, config KataAgentConfig) (disableVMShutdown bool, err error) {
 	// save
 	k.ctx = sandbox.ctx
+	k.journal = sandbox.journal
 
 	span, _ := katatrace.Trace(ctx, k.Logger(), "init", kataAgentTracingTags)
 	defer span.End()
@@ -340,6 +458,12 @@ func (k *kataAgent) init(ctx context.Context, sandbox *Sandbox, config KataAgent
 	k.keepConn = config.LongLiveConn
 	k.kmodules = config.KernelModules
 	k.dialTimout = config.DialTimeout
+	k.portForwardEnabled = config.EnablePortForward
+	k.policyEnabled = config.PolicyFile != ""
+	k.networkCaptureEnabled = config.EnableNetworkCapture
+	k.guestSyncEnabled = config.EnableGuestSync
+	k.checkpointEnabled = config.EnableCheckpoint
+	k.sysctls = config.GuestSysctls
 
 	return disableVMShutdown, nil
 }
@@ -542,7 +666,7 @@ func (k *kataAgent) createSandbox(ctx context.Context, sandbox *Sandbox) error {
 	defer span.End()
 
 	if err := k.setupSharedPath(ctx, sandbox); err != nil {
-		return err
+		return vcTypes.NewError(vcTypes.ErrCodeFsShare, err)
 	}
 	return k.configure(ctx, sandbox.hypervisor, sandbox.id, getSharePath(sandbox.id), sandbox.config.AgentConfig)
 }
@@ -818,6 +942,11 @@ func (k *kataAgent) startSandbox(ctx context.Context, sandbox *Sandbox) error {
 	if err = k.check(ctx); err != nil {
 		return err
 	}
+	sandbox.boot.mark(BootPhaseKernelBoot)
+
+	if err = k.negotiateCapabilities(ctx, sandbox); err != nil {
+		return err
+	}
 
 	// Setup network interfaces and routes
 	interfaces, routes, neighs, err := generateVCNetworkStructures(ctx, sandbox.networkNS)
@@ -836,8 +965,21 @@ func (k *kataAgent) startSandbox(ctx context.Context, sandbox *Sandbox) error {
 
 	storages := setupStorages(ctx, sandbox)
 
+	imageStore, err := k.guestImageStoreStorage(ctx, sandbox)
+	if err != nil {
+		return err
+	}
+	if imageStore != nil {
+		storages = append(storages, imageStore)
+	}
+
 	kmodules := setupKernelModules(k.kmodules)
 
+	sysctls, err := setupGuestSysctls(k.sysctls)
+	if err != nil {
+		return err
+	}
+
 	req := &grpc.CreateSandboxRequest{
 		Hostname:      hostname,
 		Dns:           dns,
@@ -846,6 +988,7 @@ func (k *kataAgent) startSandbox(ctx context.Context, sandbox *Sandbox) error {
 		SandboxId:     sandbox.id,
 		GuestHookPath: sandbox.config.HypervisorConfig.GuestHookPath,
 		KernelModules: kmodules,
+		Sysctls:       sysctls,
 	}
 
 	_, err = k.sendReq(ctx, req)
@@ -884,6 +1027,24 @@ func setupKernelModules(kmodules []string) []*grpc.KernelModule {
 	return modules
 }
 
+// virtioFSSharedDirOptions returns the mount options of the shared directory
+// in the guest with virtio-fs.
+func virtioFSSharedDirOptions(conf HypervisorConfig) []string {
+	options := append([]string{}, sharedDirVirtioFSOptions...)
+
+	// If virtio-fs uses either of the two cache options 'auto, always',
+	// the guest directory can be mounted with option 'dax' allowing it to
+	// directly map contents from the host. When set to 'none', the mount
+	// options should not contain 'dax' lest the virtio-fs daemon crashing
+	// with an invalid address reference.
+	// If virtio_fs_cache_size = 0, dax should not be used.
+	if conf.VirtioFSCache != typeVirtioFSNoCache && conf.VirtioFSCacheSize != 0 {
+		options = append(options, sharedDirVirtioFSDaxOptions)
+	}
+
+	return options
+}
+
 func setupStorages(ctx context.Context, sandbox *Sandbox) []*grpc.Storage {
 	storages := []*grpc.Storage{}
 	caps := sandbox.hypervisor.capabilities(ctx)
@@ -896,23 +1057,12 @@ func setupStorages(ctx context.Context, sandbox *Sandbox) []*grpc.Storage {
 		// (resolv.conf, etc...) and potentially all container
 		// rootfs will reside.
 		if sandbox.config.HypervisorConfig.SharedFS == config.VirtioFS {
-			// If virtio-fs uses either of the two cache options 'auto, always',
-			// the guest directory can be mounted with option 'dax' allowing it to
-			// directly map contents from the host. When set to 'none', the mount
-			// options should not contain 'dax' lest the virtio-fs daemon crashing
-			// with an invalid address reference.
-			if sandbox.config.HypervisorConfig.VirtioFSCache != typeVirtioFSNoCache {
-				// If virtio_fs_cache_size = 0, dax should not be used.
-				if sandbox.config.HypervisorConfig.VirtioFSCacheSize != 0 {
-					sharedDirVirtioFSOptions = append(sharedDirVirtioFSOptions, sharedDirVirtioFSDaxOptions)
-				}
-			}
 			sharedVolume := &grpc.Storage{
 				Driver:     kataVirtioFSDevType,
 				Source:     mountGuestTag,
 				MountPoint: kataGuestSharedDir(),
 				Fstype:     typeVirtioFS,
-				Options:    sharedDirVirtioFSOptions,
+				Options:    virtioFSSharedDirOptions(sandbox.config.HypervisorConfig),
 			}
 
 			storages = append(storages, sharedVolume)
@@ -999,7 +1149,7 @@ func (k *kataAgent) removeIgnoredOCIMount(spec *specs.Spec, ignoredMounts map[st
 	return nil
 }
 
-func (k *kataAgent) replaceOCIMountsForStorages(spec *specs.Spec, volumeStorages []*grpc.Storage) error {
+func (k *kataAgent) replaceOCIMountsForStorages(c *Container, spec *specs.Spec, volumeStorages []*grpc.Storage) error {
 	ociMounts := spec.Mounts
 	var index int
 	var m specs.Mount
@@ -1019,6 +1169,12 @@ func (k *kataAgent) replaceOCIMountsForStorages(spec *specs.Spec, volumeStorages
 			ociMounts[index].Source = path
 			volumeStorages[i].MountPoint = path
 
+			for j := range c.mounts {
+				if c.mounts[j].Destination == m.Destination && c.mounts[j].BlockDeviceID != "" {
+					c.mounts[j].GuestDeviceMount = path
+				}
+			}
+
 			break
 		}
 		if index == len(ociMounts) {
@@ -1047,7 +1203,8 @@ func (k *kataAgent) constraintGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool) {
 		grpcSpec.Process.SelinuxLabel = ""
 	}
 
-	// By now only CPU constraints are supported
+	// By now only CPU constraints are supported, the device rules being
+	// set by constrainDeviceCgroup once translated.
 	// Issue: https://github.com/kata-containers/runtime/issues/158
 	// Issue: https://github.com/kata-containers/runtime/issues/204
 	grpcSpec.Linux.Resources.Devices = nil
@@ -1104,6 +1261,155 @@ func (k *kataAgent) constraintGRPCSpec(grpcSpec *grpc.Spec, passSeccomp bool) {
 	grpcSpec.Linux.Devices = linuxDevices
 }
 
+// The range of the OOM score adjustment of a process
+const (
+	oomScoreAdjMin = -1000
+	oomScoreAdjMax = 1000
+)
+
+// unifiedCgroupControllers are the cgroup v2 controllers the unified
+// resources of a container may set the interface files of.
+var unifiedCgroupControllers = map[string]bool{
+	"cpu":     true,
+	"cpuset":  true,
+	"hugetlb": true,
+	"io":      true,
+	"memory":  true,
+	"misc":    true,
+	"pids":    true,
+	"rdma":    true,
+}
+
+func validateOOMScoreAdj(process *grpc.Process) error {
+	if process == nil {
+		return nil
+	}
+
+	if process.OOMScoreAdj < oomScoreAdjMin || process.OOMScoreAdj > oomScoreAdjMax {
+		return fmt.Errorf("invalid OOM score adjustment %d, the valid range is %d to %d", process.OOMScoreAdj, oomScoreAdjMin, oomScoreAdjMax)
+	}
+
+	return nil
+}
+
+// validateUnifiedResources checks that the unified resources name the
+// interface files of cgroup v2 controllers, e.g. memory.high or
+// cpu.max.burst.
+func validateUnifiedResources(unified map[string]string) error {
+	for key := range unified {
+		parts := strings.SplitN(key, ".", 2)
+		if len(parts) != 2 || parts[1] == "" || strings.Contains(key, "/") || !unifiedCgroupControllers[parts[0]] {
+			return fmt.Errorf("invalid unified cgroup resource %q", key)
+		}
+	}
+
+	return nil
+}
+
+// constrainResources validates the resources of a container before they
+// are passed to the agent, and drops the unified resources an agent which
+// does not support them would ignore.
+func (k *kataAgent) constrainResources(resources *grpc.LinuxResources) error {
+	if resources == nil || len(resources.Unified) == 0 {
+		return nil
+	}
+
+	if err := validateUnifiedResources(resources.Unified); err != nil {
+		return err
+	}
+
+	if !k.caps.UnifiedCgroup {
+		k.Logger().WithField("unified", resources.Unified).Warn("the agent does not support the unified cgroup resources, ignoring them")
+		resources.Unified = nil
+	}
+
+	return nil
+}
+
+// deviceCgroupWildcard is the major or minor number of the device cgroup
+// rules applying to all the numbers.
+const deviceCgroupWildcard = -1
+
+// deviceCgroupOCItoGRPC translates the device cgroup rules of the OCI
+// specification, whose missing fields mean all the devices and all the
+// accesses, which a plain copy would turn into the device 0:0.
+func deviceCgroupOCItoGRPC(rules []specs.LinuxDeviceCgroup) ([]grpc.LinuxDeviceCgroup, error) {
+	var grpcRules []grpc.LinuxDeviceCgroup
+
+	for _, r := range rules {
+		rule := grpc.LinuxDeviceCgroup{
+			Allow:  r.Allow,
+			Type:   r.Type,
+			Major:  deviceCgroupWildcard,
+			Minor:  deviceCgroupWildcard,
+			Access: r.Access,
+		}
+
+		if rule.Type == "" {
+			rule.Type = "a"
+		}
+		if rule.Type != "a" && rule.Type != "b" && rule.Type != "c" {
+			return nil, fmt.Errorf("invalid device cgroup rule type %q", r.Type)
+		}
+
+		if rule.Access == "" {
+			rule.Access = "rwm"
+		}
+		if strings.Trim(rule.Access, "rwm") != "" {
+			return nil, fmt.Errorf("invalid device cgroup rule access %q", r.Access)
+		}
+
+		if r.Major != nil {
+			rule.Major = *r.Major
+		}
+		if r.Minor != nil {
+			rule.Minor = *r.Minor
+		}
+		if rule.Major < deviceCgroupWildcard || rule.Minor < deviceCgroupWildcard {
+			return nil, fmt.Errorf("invalid device cgroup rule numbers %d:%d", rule.Major, rule.Minor)
+		}
+
+		grpcRules = append(grpcRules, rule)
+	}
+
+	return grpcRules, nil
+}
+
+// constrainDeviceCgroup passes the device cgroup rules of a container to
+// the agent, which replaces the host numbers of the hotplugged devices with
+// their guest ones. The rules are not passed to an agent which would not
+// enforce them, nor for the containers with VFIO devices, whose guest
+// device nodes are only known in the guest and would be denied.
+func (k *kataAgent) constrainDeviceCgroup(grpcSpec *grpc.Spec, ociSpec *specs.Spec) error {
+	if ociSpec.Linux == nil || ociSpec.Linux.Resources == nil || len(ociSpec.Linux.Resources.Devices) == 0 {
+		return nil
+	}
+
+	rules, err := deviceCgroupOCItoGRPC(ociSpec.Linux.Resources.Devices)
+	if err != nil {
+		return err
+	}
+
+	if !k.caps.DeviceCgroup {
+		k.Logger().Warn("the agent does not enforce the device cgroup rules, ignoring them")
+		return nil
+	}
+
+	for _, dev := range ociSpec.Linux.Devices {
+		if dev.Type == "c" && strings.HasPrefix(dev.Path, vfioPath) {
+			k.Logger().WithField("vfio-dev", dev.Path).Warn("the device cgroup rules of the containers with VFIO devices are not enforced")
+			return nil
+		}
+	}
+
+	if grpcSpec.Linux.Resources == nil {
+		grpcSpec.Linux.Resources = &grpc.LinuxResources{}
+	}
+	grpcSpec.Linux.Resources.Devices = rules
+
+	return nil
+}
+
 func (k *kataAgent) handleShm(mounts []specs.Mount, sandbox *Sandbox) {
 	for idx, mnt := range mounts {
 		if mnt.Destination != "/dev/shm" {
@@ -1196,10 +1502,70 @@ func (k *kataAgent) appendVhostUserBlkDevice(dev ContainerDevice, c *Container)
 	return kataDevice
 }
 
-func (k *kataAgent) appendDevices(deviceList []*grpc.Device, c *Container) []*grpc.Device {
-	var kataDevice *grpc.Device
+// appendMultipathDevice returns the SCSI addresses of the paths of a
+// multipath device, the agent assembles the device from them.
+func (k *kataAgent) appendMultipathDevice(dev ContainerDevice, c *Container) *grpc.Device {
+	device := c.sandbox.devManager.GetDeviceByID(dev.ID)
+
+	mpathDevice, ok := device.(*drivers.MultipathDevice)
+	if !ok {
+		k.Logger().WithField("device", device).Error("malformed multipath device")
+		return nil
+	}
+
+	drives, ok := device.GetDeviceInfo().([]*config.BlockDrive)
+	if !ok || len(drives) == 0 {
+		k.Logger().WithField("device", device).Error("malformed multipath device")
+		return nil
+	}
+
+	kataDevice := &grpc.Device{
+		ContainerPath: dev.ContainerPath,
+		Type:          kataSCSIMpathDevType,
+		Id:            mpathDevice.WWID,
+	}
+	for _, d := range drives {
+		kataDevice.Options = append(kataDevice.Options, d.SCSIAddr)
+	}
 
+	return kataDevice
+}
+
+// appendVfioAPDevice returns the AP queues of a VFIO-AP device, which the agent
+// waits for before creating the container. The other VFIO devices are not
+// passed to the agent.
+func (k *kataAgent) appendVfioAPDevice(dev ContainerDevice, c *Container) *grpc.Device {
+	device := c.sandbox.devManager.GetDeviceByID(dev.ID)
+
+	vfioDevs, ok := device.GetDeviceInfo().([]*config.VFIODev)
+	if !ok {
+		k.Logger().WithField("device", device).Error("malformed vfio device")
+		return nil
+	}
+
+	var apDevices []string
+	for _, vfioDev := range vfioDevs {
+		if vfioDev != nil {
+			apDevices = append(apDevices, vfioDev.APDevices...)
+		}
+	}
+
+	if len(apDevices) == 0 {
+		return nil
+	}
+
+	return &grpc.Device{
+		ContainerPath: dev.ContainerPath,
+		Type:          kataVfioAPDevType,
+		Id:            dev.ID,
+		Options:       apDevices,
+	}
+}
+
+func (k *kataAgent) appendDevices(deviceList []*grpc.Device, c *Container) []*grpc.Device {
 	for _, dev := range c.devices {
+		var kataDevice *grpc.Device
+
 		device := c.sandbox.devManager.GetDeviceByID(dev.ID)
 		if device == nil {
 			k.Logger().WithField("device", dev.ID).Error("failed to find device by id")
@@ -1211,6 +1577,10 @@ func (k *kataAgent) appendDevices(deviceList []*grpc.Device, c *Container) []*gr
 			kataDevice = k.appendBlockDevice(dev, c)
 		case config.VhostUserBlk:
 			kataDevice = k.appendVhostUserBlkDevice(dev, c)
+		case config.DeviceMultipath:
+			kataDevice = k.appendMultipathDevice(dev, c)
+		case config.DeviceVFIO:
+			kataDevice = k.appendVfioAPDevice(dev, c)
 		}
 
 		if kataDevice == nil {
@@ -1330,7 +1700,14 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 	// setup rootfs -- if its block based, we'll receive a non-nil storage object representing
 	// the block device for the rootfs, which us utilized for mounting in the guest. This'll be handled
 	// already for non-block based rootfs
-	if rootfs, err = k.buildContainerRootfs(ctx, sandbox, c, rootPathParent); err != nil {
+	if len(c.state.LayerDeviceIDs) > 0 {
+		// The layers are mounted, then stacked, by the agent.
+		layerStorages, err := k.buildErofsRootfs(sandbox, c, rootPath)
+		if err != nil {
+			return nil, err
+		}
+		ctrStorages = append(ctrStorages, layerStorages...)
+	} else if rootfs, err = k.buildContainerRootfs(ctx, sandbox, c, rootPathParent); err != nil {
 		return nil, err
 	}
 
@@ -1353,7 +1730,7 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 
 	shareStorages, err := c.mountSharedDirMounts(ctx, sharedDirMounts, ignoredMounts)
 	if err != nil {
-		return nil, err
+		return nil, vcTypes.NewError(vcTypes.ErrCodeFsShare, err)
 	}
 	ctrStorages = append(ctrStorages, shareStorages...)
 
@@ -1366,6 +1743,13 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 
 	ctrStorages = append(ctrStorages, epheStorages...)
 
+	scratchStorages, err := k.handleEncryptedScratchStorage(ctx, ociSpec.Mounts, ociSpec.Annotations, sandbox)
+	if err != nil {
+		return nil, err
+	}
+
+	ctrStorages = append(ctrStorages, scratchStorages...)
+
 	localStorages, err := k.handleLocalStorage(ociSpec.Mounts, sandbox.id, c.rootfsSuffix)
 	if err != nil {
 		return nil, err
@@ -1396,7 +1780,7 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 		return nil, err
 	}
 
-	if err := k.replaceOCIMountsForStorages(ociSpec, volumeStorages); err != nil {
+	if err := k.replaceOCIMountsForStorages(c, ociSpec, volumeStorages); err != nil {
 		return nil, err
 	}
 
@@ -1412,12 +1796,35 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 
 	sharedPidNs := k.handlePidNamespace(grpcSpec, sandbox)
 
-	passSeccomp := !sandbox.config.DisableGuestSeccomp && sandbox.seccompSupported
+	passSeccomp := !sandbox.config.DisableGuestSeccomp && k.caps.Seccomp
 
 	// We need to constraint the spec to make sure we're not passing
 	// irrelevant information to the agent.
 	k.constraintGRPCSpec(grpcSpec, passSeccomp)
 
+	if err := validateOOMScoreAdj(grpcSpec.Process); err != nil {
+		return nil, err
+	}
+
+	if err := validateContainerSysctls(grpcSpec.Linux); err != nil {
+		return nil, err
+	}
+
+	if err := k.constrainResources(grpcSpec.Linux.Resources); err != nil {
+		return nil, err
+	}
+
+	if err := k.constrainDeviceCgroup(grpcSpec, ociSpec); err != nil {
+		return nil, err
+	}
+
+	// The interfaces and routes of a container with its own network, the
+	// agent creates a network namespace for them.
+	interfaces, routes, _, err := generateVCNetworkStructures(ctx, c.networkNS)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &grpc.CreateContainerRequest{
 		ContainerId:  c.id,
 		ExecId:       c.id,
@@ -1425,6 +1832,8 @@ func (k *kataAgent) createContainer(ctx context.Context, sandbox *Sandbox, c *Co
 		Devices:      ctrDevices,
 		OCI:          grpcSpec,
 		SandboxPidns: sharedPidNs,
+		Interfaces:   interfaces,
+		Routes:       routes,
 	}
 
 	if _, err = k.sendReq(ctx, req); err != nil {
@@ -1485,6 +1894,72 @@ func (k *kataAgent) handleEphemeralStorage(mounts []specs.Mount) ([]*grpc.Storag
 	return epheStorages, nil
 }
 
+// handleEncryptedScratchStorage handles the confidential emptyDirs by attaching
+// their scratch disks, encrypted by the agent with an ephemeral key and mounted
+// in the sandbox directory of the VM.
+func (k *kataAgent) handleEncryptedScratchStorage(ctx context.Context, mounts []specs.Mount, annotations map[string]string, sandbox *Sandbox) ([]*grpc.Storage, error) {
+	sizes, err := ConfidentialEmptyDirSizes(annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	var scratchStorages []*grpc.Storage
+	for idx, mnt := range mounts {
+		if mnt.Type != KataEncryptedScratchDevType {
+			continue
+		}
+
+		name := filepath.Base(mnt.Source)
+		size, ok := sizes[name]
+		if !ok {
+			return nil, fmt.Errorf("no size specified for confidential emptyDir %s", name)
+		}
+
+		device, err := sandbox.encryptedScratchDevice(ctx, mnt.Source, size)
+		if err != nil {
+			return nil, err
+		}
+
+		guestPath := filepath.Join(kataGuestSandboxDir(), KataEncryptedScratchDevType, name)
+
+		blockDrive, ok := device.GetDeviceInfo().(*config.BlockDrive)
+		if !ok || blockDrive == nil {
+			return nil, fmt.Errorf("malformed block drive")
+		}
+
+		scratchStorage := &grpc.Storage{
+			Fstype:        kataScratchFsType,
+			MountPoint:    guestPath,
+			DriverOptions: []string{kataScratchDriverOption},
+		}
+
+		// The agent sets up the dm-crypt mapping of the disk, so that the
+		// encryption key never leaves the VM.
+		switch sandbox.config.HypervisorConfig.BlockDeviceDriver {
+		case config.VirtioBlockCCW:
+			scratchStorage.Driver = kataBlkCCWDevType
+			scratchStorage.Source = blockDrive.DevNo
+		case config.VirtioBlock:
+			scratchStorage.Driver = kataBlkDevType
+			scratchStorage.Source = blockDrive.PCIPath.String()
+		case config.VirtioMmio:
+			scratchStorage.Driver = kataMmioBlkDevType
+			scratchStorage.Source = blockDrive.VirtPath
+		case config.VirtioSCSI:
+			scratchStorage.Driver = kataSCSIDevType
+			scratchStorage.Source = blockDrive.SCSIAddr
+		default:
+			return nil, fmt.Errorf("Unknown block device driver: %s", sandbox.config.HypervisorConfig.BlockDeviceDriver)
+		}
+
+		mounts[idx].Source = guestPath
+		mounts[idx].Type = "bind"
+
+		scratchStorages = append(scratchStorages, scratchStorage)
+	}
+	return scratchStorages, nil
+}
+
 // handleLocalStorage handles local storage within the VM
 // by creating a directory in the VM from the source of the mount point.
 func (k *kataAgent) handleLocalStorage(mounts []specs.Mount, sandboxID string, rootfsSuffix string) ([]*grpc.Storage, error) {
@@ -1593,6 +2068,42 @@ func (k *kataAgent) handleVhostUserBlkVolume(c *Container, m Mount, device api.D
 	vol.Options = []string{"bind"}
 	vol.MountPoint = m.Destination
 
+	// The filesystem of the device is mounted, rather than the device
+	// bind mounted, for the SPDK direct assigned volumes.
+	if m.Type != "" && m.Type != "bind" {
+		vol.Fstype = m.Type
+		vol.Options = m.Options
+	}
+
+	return vol, nil
+}
+
+// handleMultipathVolume handles volume that is a multipath device, passing
+// the SCSI addresses of its paths to the agent.
+func (k *kataAgent) handleMultipathVolume(c *Container, m Mount, device api.Device) (*grpc.Storage, error) {
+	mpathDevice, ok := device.(*drivers.MultipathDevice)
+	if !ok {
+		k.Logger().Error("malformed multipath device")
+		return nil, fmt.Errorf("malformed multipath device")
+	}
+
+	drives, ok := device.GetDeviceInfo().([]*config.BlockDrive)
+	if !ok || len(drives) == 0 {
+		k.Logger().Error("malformed multipath device")
+		return nil, fmt.Errorf("malformed multipath device")
+	}
+
+	vol := &grpc.Storage{
+		Driver:     kataSCSIMpathDevType,
+		Source:     mpathDevice.WWID,
+		Fstype:     m.Type,
+		Options:    m.Options,
+		MountPoint: m.Destination,
+	}
+	for _, d := range drives {
+		vol.DriverOptions = append(vol.DriverOptions, kataMpathPathDriverOption+d.SCSIAddr)
+	}
+
 	return vol, nil
 }
 
@@ -1606,6 +2117,9 @@ func (k *kataAgent) handleBlockVolumes(c *Container) ([]*grpc.Storage, error) {
 		id := m.BlockDeviceID
 
 		if len(id) == 0 {
+			if m.LUKSKeyRef != "" {
+				return nil, fmt.Errorf("LUKS volume %s is not a block device volume", m.Destination)
+			}
 			continue
 		}
 
@@ -1627,6 +2141,8 @@ func (k *kataAgent) handleBlockVolumes(c *Container) ([]*grpc.Storage, error) {
 			vol, err = k.handleDeviceBlockVolume(c, m, device)
 		case config.VhostUserBlk:
 			vol, err = k.handleVhostUserBlkVolume(c, m, device)
+		case config.DeviceMultipath:
+			vol, err = k.handleMultipathVolume(c, m, device)
 		default:
 			k.Logger().Error("Unknown device type")
 			continue
@@ -1636,6 +2152,16 @@ func (k *kataAgent) handleBlockVolumes(c *Container) ([]*grpc.Storage, error) {
 			return nil, err
 		}
 
+		if m.LUKSKeyRef != "" {
+			switch vol.Driver {
+			case kataBlkDevType, kataBlkCCWDevType, kataMmioBlkDevType, kataSCSIDevType, kataSCSIMpathDevType:
+				// The agent unlocks the volume before mounting it.
+				vol.DriverOptions = append(vol.DriverOptions, kataLUKSKeyDriverOption+m.LUKSKeyRef)
+			default:
+				return nil, fmt.Errorf("LUKS volume %s is not supported by the %s storage driver", m.Destination, vol.Driver)
+			}
+		}
+
 		volumeStorages = append(volumeStorages, vol)
 	}
 
@@ -1643,10 +2169,12 @@ func (k *kataAgent) handleBlockVolumes(c *Container) ([]*grpc.Storage, error) {
 }
 
 // handlePidNamespace checks if Pid namespace for a container needs to be shared with its sandbox
-// pid namespace. This function also modifies the grpc spec to remove the pid namespace
-// from the list of namespaces passed to the agent.
+// pid namespace, either because the sandbox shares it between all its containers or because the
+// container joins the one of another container, e.g. with the Kubernetes shareProcessNamespace.
+// This function also modifies the grpc spec to remove the pid namespace from the list of
+// namespaces passed to the agent.
 func (k *kataAgent) handlePidNamespace(grpcSpec *grpc.Spec, sandbox *Sandbox) bool {
-	sharedPidNs := false
+	sharedPidNs := sandbox.sharePidNs
 	pidIndex := -1
 
 	for i, ns := range grpcSpec.Linux.Namespaces {
@@ -1725,6 +2253,14 @@ func (k *kataAgent) updateContainer(ctx context.Context, sandbox *Sandbox, c Con
 		return err
 	}
 
+	if err := k.constrainResources(grpcResources); err != nil {
+		return err
+	}
+
+	// The agent only replaces the host numbers of the devices in the rules
+	// of the created containers, the updates keep them.
+	grpcResources.Devices = nil
+
 	req := &grpc.UpdateContainerRequest{
 		ContainerId: c.id,
 		Resources:   grpcResources,
@@ -1813,8 +2349,21 @@ func (k *kataAgent) statsContainer(ctx context.Context, sandbox *Sandbox, c Cont
 	if err != nil {
 		return nil, err
 	}
+
+	data, err = json.Marshal(stats.NetworkStats)
+	if err != nil {
+		return nil, err
+	}
+
+	var networkStats []*NetworkStats
+	err = json.Unmarshal(data, &networkStats)
+	if err != nil {
+		return nil, err
+	}
+
 	containerStats := &ContainerStats{
-		CgroupStats: &cgroupStats,
+		CgroupStats:  &cgroupStats,
+		NetworkStats: networkStats,
 	}
 	return containerStats, nil
 }
@@ -2024,6 +2573,30 @@ func (k *kataAgent) installReqFunc(c *kataclient.AgentClient) {
 	k.reqHandlers[grpcGetMetricsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
 		return k.client.AgentServiceClient.GetMetrics(ctx, req.(*grpc.GetMetricsRequest))
 	}
+	k.reqHandlers[grpcResizeVolumeRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.ResizeVolume(ctx, req.(*grpc.ResizeVolumeRequest))
+	}
+	k.reqHandlers[grpcGuestDiagnosticsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.GetGuestDiagnostics(ctx, req.(*grpc.GuestDiagnosticsRequest))
+	}
+	k.reqHandlers[grpcRemountSharedFSRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.RemountSharedFS(ctx, req.(*grpc.RemountSharedFSRequest))
+	}
+	k.reqHandlers[grpcSyncFilesystemsRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.SyncFilesystems(ctx, req.(*grpc.SyncFilesystemsRequest))
+	}
+	k.reqHandlers[grpcStartNetworkCaptureRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.StartNetworkCapture(ctx, req.(*grpc.StartNetworkCaptureRequest))
+	}
+	k.reqHandlers[grpcStopNetworkCaptureRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.StopNetworkCapture(ctx, req.(*grpc.StopNetworkCaptureRequest))
+	}
+	k.reqHandlers[grpcCheckpointContainerRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.CheckpointContainer(ctx, req.(*grpc.CheckpointContainerRequest))
+	}
+	k.reqHandlers[grpcRestoreContainerRequest] = func(ctx context.Context, req interface{}) (interface{}, error) {
+		return k.client.AgentServiceClient.RestoreContainer(ctx, req.(*grpc.RestoreContainerRequest))
+	}
 }
 
 func (k *kataAgent) getReqContext(ctx context.Context, reqName string) (newCtx context.Context, cancel context.CancelFunc) {
@@ -2043,14 +2616,16 @@ func (k *kataAgent) getReqContext(ctx context.Context, reqName string) (newCtx c
 func (k *kataAgent) sendReq(spanCtx context.Context, request interface{}) (interface{}, error) {
 	start := time.Now()
 
+	msgName := proto.MessageName(request.(proto.Message))
+
 	if err := k.connect(spanCtx); err != nil {
-		return nil, err
+		k.recordRPCFailure(msgName, err)
+		return nil, agentTimeoutError(err)
 	}
 	if !k.keepConn {
 		defer k.disconnect(spanCtx)
 	}
 
-	msgName := proto.MessageName(request.(proto.Message))
 	handler := k.reqHandlers[msgName]
 	if msgName == "" || handler == nil {
 		return nil, errors.New("Invalid request type")
@@ -2063,9 +2638,41 @@ func (k *kataAgent) sendReq(spanCtx context.Context, request interface{}) (inter
 	k.Logger().WithField("name", msgName).WithField("req", message.String()).Trace("sending request")
 
 	defer func() {
-		agentRPCDurationsHistogram.WithLabelValues(msgName).Observe(float64(time.Since(start).Nanoseconds() / int64(time.Millisecond)))
+		observeDuration(spanCtx, agentRPCDurationsHistogram.WithLabelValues(msgName), time.Since(start))
 	}()
-	return handler(ctx, request)
+
+	resp, err := handler(ctx, request)
+	if err != nil && ctx.Err() != context.Canceled {
+		k.recordRPCFailure(msgName, err)
+	}
+	return resp, agentTimeoutError(err)
+}
+
+// agentTimeoutError classifies the agent request errors caused by a
+// timeout. The other errors are returned as is, their gRPC status is
+// checked by the callers.
+func agentTimeoutError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || grpcStatus.Code(err) == codes.DeadlineExceeded {
+		return vcTypes.NewError(vcTypes.ErrCodeAgentTimeout, err)
+	}
+
+	return err
+}
+
+// recordRPCFailure adds a failed agent request to the sandbox journal.
+// Failures of the long polling requests are expected when the sandbox
+// stops and are not recorded.
+func (k *kataAgent) recordRPCFailure(msgName string, err error) {
+	switch msgName {
+	case grpcWaitProcessRequest, grpcGetOOMEventRequest:
+		return
+	}
+
+	k.journal.record(JournalAgentRPCFailed, "", "%s: %v", msgName, err)
 }
 
 // readStdout and readStderr are special that we cannot differentiate them with the request types...
@@ -2214,12 +2821,14 @@ func (k *kataAgent) cleanup(ctx context.Context, s *Sandbox) {
 
 func (k *kataAgent) save() persistapi.AgentState {
 	return persistapi.AgentState{
-		URL: k.state.URL,
+		URL:          k.state.URL,
+		Capabilities: k.caps.save(),
 	}
 }
 
 func (k *kataAgent) load(s persistapi.AgentState) {
 	k.state.URL = s.URL
+	k.caps.load(s.Capabilities)
 }
 
 func (k *kataAgent) getOOMEvent(ctx context.Context) (string, error) {
@@ -2242,3 +2851,268 @@ func (k *kataAgent) getAgentMetrics(ctx context.Context, req *grpc.GetMetricsReq
 
 	return resp.(*grpc.Metrics), nil
 }
+
+// resizeGuestVolume has the agent grow the filesystem of the block volume
+// mounted at guestPath, once its device has been resized to size bytes.
+func (k *kataAgent) resizeGuestVolume(ctx context.Context, guestPath string, size uint64) error {
+	_, err := k.sendReq(ctx, &grpc.ResizeVolumeRequest{
+		VolumeGuestPath: guestPath,
+		Size_:           size,
+	})
+	return err
+}
+
+func (k *kataAgent) getGuestDiagnostics(ctx context.Context, req *grpc.GuestDiagnosticsRequest) (*grpc.GuestDiagnostics, error) {
+	resp, err := k.sendReq(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.(*grpc.GuestDiagnostics), nil
+}
+
+// portForward connects to the TCP port of the guest through the agent port
+// forward vsock port. The agent replies to the requested port with "OK" once
+// it is connected, the connection then carries the TCP stream.
+func (k *kataAgent) portForward(ctx context.Context, port uint32) (net.Conn, error) {
+	if !k.portForwardEnabled {
+		return nil, fmt.Errorf("port forwarding is not enabled in the agent configuration")
+	}
+
+	if port == 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", port)
+	}
+
+	url, err := k.agentURL()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := kataclient.AgentPortDialer(url, portForwardVPort, time.Duration(k.dialTimout)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := portForwardHandshake(conn, port); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// policyDecisions connects to the agent policy vsock port, the agent writes
+// a JSON object per line for each of the following policy decisions.
+func (k *kataAgent) policyDecisions(ctx context.Context) (net.Conn, error) {
+	if !k.policyEnabled {
+		return nil, fmt.Errorf("no agent policy in the agent configuration")
+	}
+
+	url, err := k.agentURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return kataclient.AgentPortDialer(url, policyVPort, time.Duration(k.dialTimout)*time.Second)
+}
+
+func portForwardHandshake(conn net.Conn, port uint32) error {
+	if err := agentPortHandshake(conn, strconv.FormatUint(uint64(port), 10)); err != nil {
+		return fmt.Errorf("failed to forward port %d: %v", port, err)
+	}
+
+	return nil
+}
+
+// agentPortHandshake sends the request line to an agent vsock port, which
+// replies with "OK", or with "ERR <error>", before the stream it serves.
+func agentPortHandshake(conn net.Conn, request string) error {
+	if _, err := fmt.Fprintf(conn, "%s\n", request); err != nil {
+		return err
+	}
+
+	return readAgentPortReply(conn)
+}
+
+// readAgentPortReply reads the "OK", or "ERR <error>", reply of an agent
+// vsock port.
+func readAgentPortReply(conn net.Conn) error {
+	// Read the reply byte by byte, not to consume the stream that follows.
+	var reply []byte
+	b := make([]byte, 1)
+	for {
+		if _, err := conn.Read(b); err != nil {
+			return fmt.Errorf("failed to read the agent reply: %v", err)
+		}
+		if b[0] == '\n' {
+			break
+		}
+		reply = append(reply, b[0])
+	}
+
+	if string(reply) != "OK" {
+		return errors.New(strings.TrimPrefix(string(reply), "ERR "))
+	}
+
+	return nil
+}
+
+// captureNetwork has the agent start a capture of the guest device, and
+// connects to the agent network capture vsock port, on which the agent
+// streams the capture in the pcap format and closes the connection once the
+// capture is over.
+func (k *kataAgent) captureNetwork(ctx context.Context, device string, duration time.Duration, snapLen uint32) (net.Conn, error) {
+	if !k.networkCaptureEnabled {
+		return nil, fmt.Errorf("network capture is not enabled in the agent configuration")
+	}
+
+	// The duration is rounded up to the second
+	seconds := uint32((duration + time.Second - 1) / time.Second)
+	resp, err := k.sendReq(ctx, &grpc.StartNetworkCaptureRequest{
+		Device:  device,
+		Seconds: seconds,
+		Snaplen: snapLen,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture on %s: %v", device, err)
+	}
+	captureID := resp.(*grpc.StartNetworkCaptureResponse).CaptureId
+
+	conn, err := k.networkCaptureStream(captureID)
+	if err != nil {
+		if _, stopErr := k.sendReq(ctx, &grpc.StopNetworkCaptureRequest{CaptureId: captureID}); stopErr != nil {
+			k.Logger().WithError(stopErr).Warn("failed to stop the network capture")
+		}
+		return nil, fmt.Errorf("failed to capture on %s: %v", device, err)
+	}
+
+	return conn, nil
+}
+
+// networkCaptureStream connects to the agent network capture vsock port for
+// the stream of a started capture.
+func (k *kataAgent) networkCaptureStream(captureID uint32) (net.Conn, error) {
+	url, err := k.agentURL()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := kataclient.AgentPortDialer(url, networkCaptureVPort, time.Duration(k.dialTimout)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := agentPortHandshake(conn, strconv.FormatUint(uint64(captureID), 10)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// remountSharedFS has the agent mount the shared directory again, once
+// virtiofsd has been restarted.
+func (k *kataAgent) remountSharedFS(ctx context.Context, options []string) error {
+	_, err := k.sendReq(ctx, &grpc.RemountSharedFSRequest{
+		MountPoint: kataGuestSharedDir(),
+		Options:    options,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remount the shared file system: %v", err)
+	}
+
+	return nil
+}
+
+// syncFilesystems has the agent write the dirty data of all the guest file
+// systems.
+func (k *kataAgent) syncFilesystems(ctx context.Context) error {
+	if !k.guestSyncEnabled {
+		return fmt.Errorf("guest sync is not enabled in the agent configuration")
+	}
+
+	if _, err := k.sendReq(ctx, &grpc.SyncFilesystemsRequest{}); err != nil {
+		return fmt.Errorf("failed to sync the guest file systems: %v", err)
+	}
+
+	return nil
+}
+
+// checkpointContainer has the agent dump the processes of a container with
+// CRIU, the agent then streams the tar archive of the images and closes the
+// connection once it is sent.
+func (k *kataAgent) checkpointContainer(ctx context.Context, containerID string, leaveRunning bool) (net.Conn, error) {
+	if !k.checkpointEnabled {
+		return nil, fmt.Errorf("checkpoint is not enabled in the agent configuration")
+	}
+
+	resp, err := k.sendReq(ctx, &grpc.CheckpointContainerRequest{
+		ContainerId:  containerID,
+		LeaveRunning: leaveRunning,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to checkpoint container %s: %v", containerID, err)
+	}
+
+	conn, err := k.checkpointStream(resp.(*grpc.CheckpointContainerResponse).CheckpointId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checkpoint container %s: %v", containerID, err)
+	}
+
+	return conn, nil
+}
+
+// restoreContainer sends the tar archive of the CRIU images of a checkpoint,
+// size bytes long, for the agent to restore its processes in the root file
+// system of a container, as its init process. The agent replies with "OK"
+// once they are restored.
+func (k *kataAgent) restoreContainer(ctx context.Context, containerID string, images io.Reader, size int64) error {
+	if !k.checkpointEnabled {
+		return fmt.Errorf("checkpoint is not enabled in the agent configuration")
+	}
+
+	resp, err := k.sendReq(ctx, &grpc.RestoreContainerRequest{
+		ContainerId: containerID,
+		Size_:       uint64(size),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore container %s: %v", containerID, err)
+	}
+
+	conn, err := k.checkpointStream(resp.(*grpc.RestoreContainerResponse).CheckpointId)
+	if err != nil {
+		return fmt.Errorf("failed to restore container %s: %v", containerID, err)
+	}
+	defer conn.Close()
+
+	if _, err := io.CopyN(conn, images, size); err != nil {
+		return fmt.Errorf("failed to send the checkpoint images: %v", err)
+	}
+
+	if err := readAgentPortReply(conn); err != nil {
+		return fmt.Errorf("failed to restore container %s: %v", containerID, err)
+	}
+
+	return nil
+}
+
+// checkpointStream connects to the agent checkpoint vsock port for the
+// stream of the images of a checkpoint or of a restore.
+func (k *kataAgent) checkpointStream(checkpointID uint32) (net.Conn, error) {
+	url, err := k.agentURL()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := kataclient.AgentPortDialer(url, checkpointVPort, time.Duration(k.dialTimout)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := agentPortHandshake(conn, strconv.FormatUint(uint64(checkpointID), 10)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
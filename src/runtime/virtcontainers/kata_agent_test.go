@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path"
 	"path/filepath"
@@ -178,6 +179,17 @@ func TestKataAgentSendReq(t *testing.T) {
 
 	_, err = k.getOOMEvent(ctx)
 	assert.Nil(err)
+
+	diagnostics, err := k.getGuestDiagnostics(ctx, &pb.GuestDiagnosticsRequest{})
+	assert.Nil(err)
+	assert.NotEmpty(diagnostics.Meminfo)
+
+	err = k.remountSharedFS(ctx, []string{"dax"})
+	assert.Nil(err)
+
+	k.guestSyncEnabled = true
+	err = k.syncFilesystems(ctx)
+	assert.Nil(err)
 }
 
 func TestHandleEphemeralStorage(t *testing.T) {
@@ -226,6 +238,81 @@ func TestHandleLocalStorage(t *testing.T) {
 	assert.Equal(t, localMountPoint, expected)
 }
 
+func TestHandleEncryptedScratchStorage(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	dir, err := ioutil.TempDir("", "scratch")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	emptyDir := filepath.Join(dir, K8sEmptyDir, "scratch")
+	err = os.MkdirAll(emptyDir, 0755)
+	assert.NoError(err)
+
+	sConfig := SandboxConfig{}
+	sConfig.HypervisorConfig.BlockDeviceDriver = config.VirtioSCSI
+	sandbox := &Sandbox{
+		id:         "100",
+		hypervisor: &mockHypervisor{},
+		ctx:        context.Background(),
+		config:     &sConfig,
+		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", false, nil),
+		state:      types.SandboxState{BlockIndexMap: make(map[int]struct{})},
+	}
+
+	annotations := map[string]string{
+		vcAnnotations.ConfidentialEmptyDirs: "scratch=16Mi",
+	}
+
+	ociMounts := []specs.Mount{
+		{
+			Type:        KataEncryptedScratchDevType,
+			Source:      emptyDir,
+			Destination: "/scratch",
+		},
+	}
+
+	scratchStorages, err := k.handleEncryptedScratchStorage(context.Background(), ociMounts, annotations, sandbox)
+	assert.NoError(err)
+	assert.Len(scratchStorages, 1)
+
+	expected := filepath.Join(kataGuestSandboxDir(), KataEncryptedScratchDevType, "scratch")
+	assert.Equal(kataSCSIDevType, scratchStorages[0].Driver)
+	assert.Equal(expected, scratchStorages[0].MountPoint)
+	assert.Equal([]string{kataScratchDriverOption}, scratchStorages[0].DriverOptions)
+	assert.Equal(expected, ociMounts[0].Source)
+	assert.Equal("bind", ociMounts[0].Type)
+
+	fi, err := os.Stat(filepath.Join(emptyDir, encryptedScratchImage))
+	assert.NoError(err)
+	assert.Equal(int64(16*1024*1024), fi.Size())
+
+	// The scratch disk is shared by the containers of the sandbox
+	ociMounts[0].Source = emptyDir
+	ociMounts[0].Type = KataEncryptedScratchDevType
+	otherStorages, err := k.handleEncryptedScratchStorage(context.Background(), ociMounts, annotations, sandbox)
+	assert.NoError(err)
+	assert.Len(otherStorages, 1)
+	assert.Equal(scratchStorages[0].Source, otherStorages[0].Source)
+
+	// The size of the scratch disk must be specified
+	ociMounts[0].Source = emptyDir
+	ociMounts[0].Type = KataEncryptedScratchDevType
+	_, err = k.handleEncryptedScratchStorage(context.Background(), ociMounts, nil, sandbox)
+	assert.Error(err)
+
+	// The scratch disk is tracked by the device manager
+	devices := sandbox.encryptedScratchDevices()
+	assert.Len(devices, 1)
+	assert.Len(sandbox.devManager.GetAllDevices(), 1)
+	assert.True(sandbox.devManager.IsDeviceAttached(devices[0].DeviceID()))
+
+	assert.NoError(sandbox.detachEncryptedScratchDevices(context.Background()))
+	assert.Empty(sandbox.encryptedScratchDevices())
+	assert.Empty(sandbox.devManager.GetAllDevices())
+}
+
 func TestHandleDeviceBlockVolume(t *testing.T) {
 	k := kataAgent{}
 
@@ -336,13 +423,18 @@ func TestHandleBlockVolume(t *testing.T) {
 	containers := map[string]*Container{}
 	containers[c.id] = c
 
-	// Create a devices for VhostUserBlk, standard DeviceBlock and direct assigned Block device
+	// Create a devices for VhostUserBlk, standard DeviceBlock, direct assigned Block device
+	// and SPDK volume
 	vDevID := "MockVhostUserBlk"
 	bDevID := "MockDeviceBlock"
 	dDevID := "MockDeviceBlockDirect"
 	vDestination := "/VhostUserBlk/destination"
 	bDestination := "/DeviceBlock/destination"
 	dDestination := "/DeviceDirectBlock/destination"
+	sDevID := "MockSPDKVolume"
+	sDestination := "/SPDKVolume/destination"
+	sPCIPath, err := vcTypes.PciPathFromString("05/06")
+	assert.NoError(t, err)
 	vPCIPath, err := vcTypes.PciPathFromString("01/02")
 	assert.NoError(t, err)
 	bPCIPath, err := vcTypes.PciPathFromString("03/04")
@@ -353,13 +445,15 @@ func TestHandleBlockVolume(t *testing.T) {
 	vDev := drivers.NewVhostUserBlkDevice(&config.DeviceInfo{ID: vDevID})
 	bDev := drivers.NewBlockDevice(&config.DeviceInfo{ID: bDevID})
 	dDev := drivers.NewBlockDevice(&config.DeviceInfo{ID: dDevID})
+	sDev := drivers.NewVhostUserBlkDevice(&config.DeviceInfo{ID: sDevID})
 
 	vDev.VhostUserDeviceAttrs = &config.VhostUserDeviceAttrs{PCIPath: vPCIPath}
 	bDev.BlockDrive = &config.BlockDrive{PCIPath: bPCIPath}
 	dDev.BlockDrive = &config.BlockDrive{PCIPath: dPCIPath}
+	sDev.VhostUserDeviceAttrs = &config.VhostUserDeviceAttrs{PCIPath: sPCIPath}
 
 	var devices []api.Device
-	devices = append(devices, vDev, bDev, dDev)
+	devices = append(devices, vDev, bDev, dDev, sDev)
 
 	// Create a VhostUserBlk mount and a DeviceBlock mount
 	var mounts []Mount
@@ -379,10 +473,16 @@ func TestHandleBlockVolume(t *testing.T) {
 		Type:          "ext4",
 		Options:       []string{"ro"},
 	}
-	mounts = append(mounts, vMount, bMount, dMount)
+	sMount := Mount{
+		BlockDeviceID: sDevID,
+		Destination:   sDestination,
+		Type:          "xfs",
+		Options:       []string{"ro", "noatime"},
+	}
+	mounts = append(mounts, vMount, bMount, dMount, sMount)
 
 	tmpDir := "/vhost/user/dir"
-	dm := manager.NewDeviceManager(manager.VirtioBlock, true, tmpDir, devices)
+	dm := manager.NewDeviceManager(manager.VirtioBlock, true, tmpDir, false, devices)
 
 	sConfig := SandboxConfig{}
 	sConfig.HypervisorConfig.BlockDeviceDriver = manager.VirtioBlock
@@ -421,10 +521,83 @@ func TestHandleBlockVolume(t *testing.T) {
 		Driver:     kataBlkDevType,
 		Source:     dPCIPath.String(),
 	}
+	sStorage := &pb.Storage{
+		MountPoint: sDestination,
+		Fstype:     "xfs",
+		Options:    []string{"ro", "noatime"},
+		Driver:     kataBlkDevType,
+		Source:     sPCIPath.String(),
+	}
 
 	assert.Equal(t, vStorage, volumeStorages[0], "Error while handle VhostUserBlk type block volume")
 	assert.Equal(t, bStorage, volumeStorages[1], "Error while handle BlockDevice type block volume")
 	assert.Equal(t, dStorage, volumeStorages[2], "Error while handle direct BlockDevice type block volume")
+	assert.Equal(t, sStorage, volumeStorages[3], "Error while handle SPDK volume")
+}
+
+func TestHandleBlockVolumeLUKS(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	c := &Container{
+		id: "100",
+	}
+
+	bDevID := "MockDeviceBlock"
+	pDevID := "MockDevicePmem"
+	bPCIPath, err := vcTypes.PciPathFromString("03/04")
+	assert.NoError(err)
+
+	bDev := drivers.NewBlockDevice(&config.DeviceInfo{ID: bDevID})
+	bDev.BlockDrive = &config.BlockDrive{PCIPath: bPCIPath}
+	pDev := drivers.NewBlockDevice(&config.DeviceInfo{ID: pDevID})
+	pDev.BlockDrive = &config.BlockDrive{Pmem: true, NvdimmID: testNvdimmID}
+
+	sConfig := SandboxConfig{}
+	sConfig.HypervisorConfig.BlockDeviceDriver = manager.VirtioBlock
+	c.sandbox = &Sandbox{
+		id:         "100",
+		hypervisor: &mockHypervisor{},
+		devManager: manager.NewDeviceManager(manager.VirtioBlock, false, "", false, []api.Device{bDev, pDev}),
+		ctx:        context.Background(),
+		config:     &sConfig,
+	}
+
+	c.mounts = []Mount{
+		{
+			BlockDeviceID: bDevID,
+			Destination:   "/data",
+			Type:          "ext4",
+			LUKSKeyRef:    "kbs:///default/key/1",
+		},
+	}
+
+	volumeStorages, err := k.handleBlockVolumes(c)
+	assert.NoError(err)
+	assert.Len(volumeStorages, 1)
+	assert.Equal([]string{"luks_key=kbs:///default/key/1"}, volumeStorages[0].DriverOptions)
+
+	// LUKS volumes must be block device volumes
+	c.mounts = []Mount{
+		{
+			Source:      "/host/data",
+			Destination: "/data",
+			LUKSKeyRef:  "kbs:///default/key/1",
+		},
+	}
+	_, err = k.handleBlockVolumes(c)
+	assert.Error(err)
+
+	// NVDIMM volumes cannot be unlocked
+	c.mounts = []Mount{
+		{
+			BlockDeviceID: pDevID,
+			Destination:   "/data",
+			LUKSKeyRef:    "kbs:///default/key/1",
+		},
+	}
+	_, err = k.handleBlockVolumes(c)
+	assert.Error(err)
 }
 
 func TestAppendDevicesEmptyContainerDeviceList(t *testing.T) {
@@ -436,7 +609,7 @@ func TestAppendDevicesEmptyContainerDeviceList(t *testing.T) {
 
 	c := &Container{
 		sandbox: &Sandbox{
-			devManager: manager.NewDeviceManager("virtio-scsi", false, "", nil),
+			devManager: manager.NewDeviceManager("virtio-scsi", false, "", false, nil),
 		},
 		devices: ctrDevices,
 	}
@@ -469,7 +642,7 @@ func TestAppendDevices(t *testing.T) {
 
 	c := &Container{
 		sandbox: &Sandbox{
-			devManager: manager.NewDeviceManager("virtio-blk", false, "", ctrDevices),
+			devManager: manager.NewDeviceManager("virtio-blk", false, "", false, ctrDevices),
 			config:     sandboxConfig,
 		},
 	}
@@ -492,6 +665,143 @@ func TestAppendDevices(t *testing.T) {
 		updatedDevList, expected)
 }
 
+func TestAppendVfioAPDevices(t *testing.T) {
+	k := kataAgent{}
+
+	blkID := "test-append-block"
+	pciID := "test-append-vfio-pci"
+	apID := "test-append-vfio-ap"
+	ctrDevices := []api.Device{
+		&drivers.BlockDevice{
+			GenericDevice: &drivers.GenericDevice{
+				ID: blkID,
+			},
+			BlockDrive: &config.BlockDrive{
+				PCIPath: testPCIPath,
+			},
+		},
+		&drivers.VFIODevice{
+			GenericDevice: &drivers.GenericDevice{
+				ID: pciID,
+			},
+			VfioDevs: []*config.VFIODev{
+				{
+					Type: config.VFIODeviceNormalType,
+					BDF:  "02:10.0",
+				},
+			},
+		},
+		&drivers.VFIODevice{
+			GenericDevice: &drivers.GenericDevice{
+				ID: apID,
+			},
+			VfioDevs: []*config.VFIODev{
+				{
+					Type:      config.VFIODeviceMediatedType,
+					SysfsDev:  "/sys/devices/vfio_ap/matrix/a297db4a-f4c2-11e6-90f6-d3b88d6c9525",
+					APDevices: []string{"05.0032", "05.0033"},
+				},
+			},
+		},
+	}
+
+	sandboxConfig := &SandboxConfig{
+		HypervisorConfig: HypervisorConfig{
+			BlockDeviceDriver: config.VirtioBlock,
+		},
+	}
+
+	c := &Container{
+		sandbox: &Sandbox{
+			devManager: manager.NewDeviceManager("virtio-blk", false, "", false, ctrDevices),
+			config:     sandboxConfig,
+		},
+		devices: []ContainerDevice{
+			{ID: blkID, ContainerPath: testBlockDeviceCtrPath},
+			{ID: pciID, ContainerPath: "/dev/vfio/1"},
+			{ID: apID, ContainerPath: "/dev/vfio/2"},
+		},
+	}
+
+	expected := []*pb.Device{
+		{
+			Type:          kataBlkDevType,
+			ContainerPath: testBlockDeviceCtrPath,
+			Id:            testPCIPath.String(),
+		},
+		{
+			Type:          kataVfioAPDevType,
+			ContainerPath: "/dev/vfio/2",
+			Id:            apID,
+			Options:       []string{"05.0032", "05.0033"},
+		},
+	}
+	updatedDevList := k.appendDevices([]*pb.Device{}, c)
+	assert.Equal(t, expected, updatedDevList)
+}
+
+func TestAppendMultipathDevices(t *testing.T) {
+	assert := assert.New(t)
+	k := kataAgent{}
+
+	id := "test-append-multipath"
+	mpathDev := &drivers.MultipathDevice{
+		GenericDevice: &drivers.GenericDevice{
+			ID: id,
+		},
+		WWID: "36001405a4f8c2d1e",
+		Paths: []*drivers.BlockDevice{
+			{BlockDrive: &config.BlockDrive{SCSIAddr: "0:1"}},
+			{BlockDrive: &config.BlockDrive{SCSIAddr: "0:2"}},
+		},
+	}
+
+	c := &Container{
+		sandbox: &Sandbox{
+			devManager: manager.NewDeviceManager("virtio-scsi", false, "", true, []api.Device{mpathDev}),
+			config:     &SandboxConfig{},
+		},
+		devices: []ContainerDevice{
+			{ID: id, ContainerPath: testBlockDeviceCtrPath},
+		},
+	}
+
+	expected := []*pb.Device{
+		{
+			Type:          kataSCSIMpathDevType,
+			ContainerPath: testBlockDeviceCtrPath,
+			Id:            "36001405a4f8c2d1e",
+			Options:       []string{"0:1", "0:2"},
+		},
+	}
+	assert.Equal(expected, k.appendDevices([]*pb.Device{}, c))
+
+	// Multipath volume
+	c.devices = nil
+	c.mounts = []Mount{
+		{
+			BlockDeviceID: id,
+			Destination:   "/data",
+			Type:          "xfs",
+			Options:       []string{"ro"},
+			LUKSKeyRef:    "file:///run/key",
+		},
+	}
+
+	volumeStorages, err := k.handleBlockVolumes(c)
+	assert.NoError(err)
+	assert.Equal([]*pb.Storage{
+		{
+			Driver:        kataSCSIMpathDevType,
+			Source:        "36001405a4f8c2d1e",
+			Fstype:        "xfs",
+			Options:       []string{"ro"},
+			MountPoint:    "/data",
+			DriverOptions: []string{"mpath_path=0:1", "mpath_path=0:2", "luks_key=file:///run/key"},
+		},
+	}, volumeStorages)
+}
+
 func TestAppendVhostUserBlkDevices(t *testing.T) {
 	k := kataAgent{}
 
@@ -517,7 +827,7 @@ func TestAppendVhostUserBlkDevices(t *testing.T) {
 	testVhostUserStorePath := "/test/vhost/user/store/path"
 	c := &Container{
 		sandbox: &Sandbox{
-			devManager: manager.NewDeviceManager("virtio-blk", true, testVhostUserStorePath, ctrDevices),
+			devManager: manager.NewDeviceManager("virtio-blk", true, testVhostUserStorePath, false, ctrDevices),
 			config:     sandboxConfig,
 		},
 	}
@@ -616,6 +926,108 @@ func TestConstraintGRPCSpec(t *testing.T) {
 	assert.Empty(g.Linux.Devices)
 }
 
+func TestConstrainResources(t *testing.T) {
+	assert := assert.New(t)
+
+	k := kataAgent{caps: agentCapabilities{UnifiedCgroup: true}}
+
+	assert.NoError(k.constrainResources(nil))
+
+	resources := &pb.LinuxResources{
+		Unified: map[string]string{
+			"memory.high":   "1073741824",
+			"cpu.max.burst": "10000",
+		},
+	}
+	assert.NoError(k.constrainResources(resources))
+	assert.Len(resources.Unified, 2)
+
+	for _, key := range []string{"memory", "memory.", "cgroup.procs", "foo.bar", "memory.high/../x"} {
+		resources.Unified = map[string]string{key: "1"}
+		assert.Error(k.constrainResources(resources), key)
+	}
+
+	// The unified resources are dropped for older agents
+	k.caps.UnifiedCgroup = false
+	resources.Unified = map[string]string{"memory.high": "max"}
+	assert.NoError(k.constrainResources(resources))
+	assert.Nil(resources.Unified)
+}
+
+func TestDeviceCgroupOCItoGRPC(t *testing.T) {
+	assert := assert.New(t)
+
+	major := int64(10)
+	minor := int64(229)
+
+	rules, err := deviceCgroupOCItoGRPC([]specs.LinuxDeviceCgroup{
+		{Allow: false, Access: "rwm"},
+		{Allow: true, Type: "c", Major: &major, Minor: &minor, Access: "rw"},
+		{Allow: true, Type: "b", Access: "m"},
+		{Allow: true, Type: "c", Major: &major},
+	})
+	assert.NoError(err)
+	assert.Equal([]pb.LinuxDeviceCgroup{
+		{Allow: false, Type: "a", Major: -1, Minor: -1, Access: "rwm"},
+		{Allow: true, Type: "c", Major: 10, Minor: 229, Access: "rw"},
+		{Allow: true, Type: "b", Major: -1, Minor: -1, Access: "m"},
+		{Allow: true, Type: "c", Major: 10, Minor: -1, Access: "rwm"},
+	}, rules)
+
+	invalid := int64(-2)
+	for _, r := range []specs.LinuxDeviceCgroup{
+		{Type: "p"},
+		{Type: "c", Access: "rx"},
+		{Type: "c", Major: &invalid},
+	} {
+		_, err := deviceCgroupOCItoGRPC([]specs.LinuxDeviceCgroup{r})
+		assert.Error(err, r)
+	}
+}
+
+func TestConstrainDeviceCgroup(t *testing.T) {
+	assert := assert.New(t)
+
+	k := kataAgent{caps: agentCapabilities{DeviceCgroup: true}}
+
+	ociSpec := &specs.Spec{
+		Linux: &specs.Linux{
+			Resources: &specs.LinuxResources{
+				Devices: []specs.LinuxDeviceCgroup{{Allow: false, Access: "rwm"}},
+			},
+		},
+	}
+	grpcSpec := &pb.Spec{Linux: &pb.Linux{Resources: &pb.LinuxResources{}}}
+
+	assert.NoError(k.constrainDeviceCgroup(grpcSpec, ociSpec))
+	assert.Equal([]pb.LinuxDeviceCgroup{{Type: "a", Major: -1, Minor: -1, Access: "rwm"}}, grpcSpec.Linux.Resources.Devices)
+
+	// The rules of the containers with VFIO devices are not passed
+	grpcSpec.Linux.Resources.Devices = nil
+	ociSpec.Linux.Devices = []specs.LinuxDevice{{Path: "/dev/vfio/1", Type: "c"}}
+	assert.NoError(k.constrainDeviceCgroup(grpcSpec, ociSpec))
+	assert.Nil(grpcSpec.Linux.Resources.Devices)
+
+	// Nor to the agents which would not enforce them
+	ociSpec.Linux.Devices = nil
+	k.caps.DeviceCgroup = false
+	assert.NoError(k.constrainDeviceCgroup(grpcSpec, ociSpec))
+	assert.Nil(grpcSpec.Linux.Resources.Devices)
+
+	ociSpec.Linux.Resources.Devices[0].Type = "x"
+	assert.Error(k.constrainDeviceCgroup(grpcSpec, ociSpec))
+}
+
+func TestValidateOOMScoreAdj(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validateOOMScoreAdj(nil))
+	assert.NoError(validateOOMScoreAdj(&pb.Process{OOMScoreAdj: -1000}))
+	assert.NoError(validateOOMScoreAdj(&pb.Process{OOMScoreAdj: 1000}))
+	assert.Error(validateOOMScoreAdj(&pb.Process{OOMScoreAdj: -1001}))
+	assert.Error(validateOOMScoreAdj(&pb.Process{OOMScoreAdj: 1001}))
+}
+
 func TestHandleShm(t *testing.T) {
 	assert := assert.New(t)
 	k := kataAgent{}
@@ -734,6 +1146,14 @@ func TestHandlePidNamespace(t *testing.T) {
 	sharedPid = k.handlePidNamespace(g, sandbox)
 	assert.True(sharedPid)
 	assert.False(testIsPidNamespacePresent(g))
+
+	// All the containers join the pid namespace of the sandbox
+	sandbox.sharePidNs = true
+	g.Linux.Namespaces = append(g.Linux.Namespaces, pb.LinuxNamespace{Type: string(specs.PIDNamespace)})
+
+	sharedPid = k.handlePidNamespace(g, sandbox)
+	assert.True(sharedPid)
+	assert.False(testIsPidNamespacePresent(g))
 }
 
 func TestAgentConfigure(t *testing.T) {
@@ -1084,6 +1504,110 @@ func TestKataAgentKernelParams(t *testing.T) {
 	}
 }
 
+func TestKataAgentPortForwardKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	params := KataAgentKernelParams(KataAgentConfig{EnablePortForward: true})
+	assert.Contains(params, Param{Key: kernelParamPortForwardVPort, Value: "1027"})
+
+	params = KataAgentKernelParams(KataAgentConfig{})
+	assert.Empty(params)
+}
+
+func TestKataAgentPolicyKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	params := KataAgentKernelParams(KataAgentConfig{PolicyFile: "/etc/kata-policy.json"})
+	assert.Equal([]Param{
+		{Key: kernelParamPolicyFile, Value: "/etc/kata-policy.json"},
+		{Key: kernelParamPolicyVPort, Value: "1028"},
+	}, params)
+
+	params = KataAgentKernelParams(KataAgentConfig{PolicyFile: "/etc/kata-policy.json", PolicyAudit: true})
+	assert.Contains(params, Param{Key: kernelParamPolicyAudit, Value: ""})
+
+	params = KataAgentKernelParams(KataAgentConfig{PolicyAudit: true})
+	assert.Empty(params)
+}
+
+func TestKataAgentGuestSyncDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	k := &kataAgent{}
+	err := k.syncFilesystems(context.Background())
+	assert.Error(err)
+}
+
+func TestKataAgentCheckpointKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	params := KataAgentKernelParams(KataAgentConfig{EnableCheckpoint: true})
+	assert.Equal([]Param{{Key: kernelParamCheckpointVPort, Value: "1032"}}, params)
+
+	k := &kataAgent{}
+	_, err := k.checkpointContainer(context.Background(), "foo", false)
+	assert.Error(err)
+	err = k.restoreContainer(context.Background(), "foo", strings.NewReader("images"), 6)
+	assert.Error(err)
+}
+
+func TestKataAgentLogProxyKernelParams(t *testing.T) {
+	assert := assert.New(t)
+
+	params := KataAgentKernelParams(KataAgentConfig{EnableLogProxy: true})
+	assert.Equal([]Param{{Key: kernelParamLogVPort, Value: "1025"}}, params)
+}
+
+func TestKataAgentPolicyDecisions(t *testing.T) {
+	assert := assert.New(t)
+
+	k := &kataAgent{}
+	_, err := k.policyDecisions(context.Background())
+	assert.Error(err)
+}
+
+func TestKataAgentPortForward(t *testing.T) {
+	assert := assert.New(t)
+
+	k := &kataAgent{}
+	_, err := k.portForward(context.Background(), 8080)
+	assert.Error(err)
+
+	k.portForwardEnabled = true
+	_, err = k.portForward(context.Background(), 0)
+	assert.Error(err)
+	_, err = k.portForward(context.Background(), 65536)
+	assert.Error(err)
+}
+
+func TestPortForwardHandshake(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, reply := range []string{"OK\n", "ERR connection refused\n", "OK"} {
+		host, guest := net.Pipe()
+		go func() {
+			defer guest.Close()
+			line, _ := bufio.NewReader(guest).ReadString('\n')
+			assert.Equal("8080\n", line)
+			guest.Write([]byte(reply + "data"))
+		}()
+
+		err := portForwardHandshake(host, 8080)
+		if reply != "OK\n" {
+			assert.Error(err, "reply %q", reply)
+			host.Close()
+			continue
+		}
+
+		assert.NoError(err)
+		// the forwarded stream is left untouched
+		data, err := ioutil.ReadAll(host)
+		assert.NoError(err)
+		assert.Equal("data", string(data))
+		host.Close()
+	}
+}
+
 func TestKataAgentHandleTraceSettings(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,257 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// guestMemoryDumpInfoFile is the file holding the GuestMemoryDumpInfo
+	// of a guest memory dump.
+	guestMemoryDumpInfoFile = "dump.json"
+
+	// encryptedDumpSuffix is appended to the name of encrypted dump files.
+	encryptedDumpSuffix = ".enc"
+)
+
+// encryptedDumpMagic starts every encrypted guest memory dump file.
+var encryptedDumpMagic = []byte("KATADUMP1")
+
+// GuestMemoryDumpOptions describes an on demand guest memory dump.
+type GuestMemoryDumpOptions struct {
+	// Path is the directory the dump is saved under, it defaults to
+	// the configured guest memory dump path.
+	Path string
+
+	// EncryptionKey is used to encrypt the dump files when it's set.
+	// It is mandatory for confidential guests.
+	EncryptionKey []byte
+}
+
+// GuestMemoryDumpInfo describes a guest memory dump, it is saved alongside
+// the dump for offline analysis.
+type GuestMemoryDumpInfo struct {
+	SandboxID  string
+	Hypervisor HypervisorType
+	Time       time.Time
+
+	// Files are the dump files, relative to the dump directory
+	Files []string
+
+	// Encrypted is true if the dump files are encrypted
+	Encrypted bool
+
+	// Redacted is true if secrets were removed from the saved
+	// hypervisor configuration
+	Redacted bool
+}
+
+// DumpGuestMemory saves the guest memory of the sandbox along with some
+// meta information, and returns the directory holding the dump.
+func (s *Sandbox) DumpGuestMemory(ctx context.Context, opts GuestMemoryDumpOptions) (string, error) {
+	hConfig := s.hypervisor.hypervisorConfig()
+
+	path := opts.Path
+	if path == "" {
+		path = hConfig.GuestMemoryDumpPath
+	}
+	if path == "" {
+		return "", errors.New("no guest memory dump path specified")
+	}
+
+	if hConfig.ConfidentialGuest && len(opts.EncryptionKey) == 0 {
+		return "", errors.New("guest memory dump of a confidential guest requires an encryption key")
+	}
+
+	start := time.Now()
+	dumpDir, dumpFile, err := s.hypervisor.dumpGuestMemory(path, opts.EncryptionKey)
+	if err != nil {
+		return "", err
+	}
+
+	info := GuestMemoryDumpInfo{
+		SandboxID:  s.id,
+		Hypervisor: s.config.HypervisorType,
+		Time:       start,
+		Encrypted:  len(opts.EncryptionKey) > 0,
+		Redacted:   hConfig.ConfidentialGuest,
+	}
+	if dumpFile != "" {
+		info.Files = append(info.Files, dumpFile)
+	}
+
+	data, err := json.MarshalIndent(info, "", " ")
+	if err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dumpDir, guestMemoryDumpInfoFile), data, defaultFilePerms); err != nil {
+		return "", err
+	}
+
+	s.Logger().WithField("dump-dir", dumpDir).WithField("encrypted", info.Encrypted).Info("guest memory dumped")
+	return dumpDir, nil
+}
+
+// dumpKeys derives the encryption and authentication keys from the
+// user provided key.
+func dumpKeys(key []byte) ([]byte, []byte) {
+	encKey := sha256.Sum256(append([]byte("enc:"), key...))
+	macKey := sha256.Sum256(append([]byte("mac:"), key...))
+	return encKey[:], macKey[:]
+}
+
+// encryptGuestMemoryDump encrypts the dump read from in into dst with
+// AES-256-CTR and authenticates the result with HMAC-SHA256, so that dumps
+// of any size can be processed as streams, and the plain dump never
+// reaches the disk. The layout of dst is:
+// magic | IV | ciphertext | HMAC(magic | IV | ciphertext)
+func encryptGuestMemoryDump(in io.Reader, dst string, key []byte) (err error) {
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, defaultFilePerms)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(dst)
+		}
+	}()
+
+	encKey, macKey := dumpKeys(key)
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return err
+	}
+
+	iv := make([]byte, block.BlockSize())
+	if _, err := rand.Read(iv); err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, macKey)
+	w := bufio.NewWriter(io.MultiWriter(out, mac))
+
+	if _, err := w.Write(encryptedDumpMagic); err != nil {
+		return err
+	}
+	if _, err := w.Write(iv); err != nil {
+		return err
+	}
+
+	stream := &cipher.StreamWriter{S: cipher.NewCTR(block, iv), W: w}
+	if _, err := io.Copy(stream, in); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err = out.Write(mac.Sum(nil))
+	return err
+}
+
+// DecryptGuestMemoryDump decrypts a guest memory dump file created by
+// DumpGuestMemory with an encryption key. The whole file is authenticated
+// before dst is written.
+func DecryptGuestMemoryDump(src, dst string, key []byte) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	st, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	encKey, macKey := dumpKeys(key)
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return err
+	}
+
+	headerLen := int64(len(encryptedDumpMagic) + block.BlockSize())
+	dataLen := st.Size() - headerLen - sha256.Size
+	if dataLen < 0 {
+		return fmt.Errorf("%s is not an encrypted guest memory dump", src)
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(in, header); err != nil {
+		return err
+	}
+	if !bytes.Equal(header[:len(encryptedDumpMagic)], encryptedDumpMagic) {
+		return fmt.Errorf("%s is not an encrypted guest memory dump", src)
+	}
+	iv := header[len(encryptedDumpMagic):]
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(header)
+	if err := authenticateDump(in, mac, dataLen); err != nil {
+		return fmt.Errorf("failed to authenticate %s: %v", src, err)
+	}
+
+	if _, err := in.Seek(headerLen, io.SeekStart); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, defaultFilePerms)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(dst)
+		}
+	}()
+
+	stream := &cipher.StreamReader{S: cipher.NewCTR(block, iv), R: io.LimitReader(in, dataLen)}
+	_, err = io.Copy(out, stream)
+	return err
+}
+
+// authenticateDump reads dataLen bytes of ciphertext followed by the HMAC
+// from r and checks the HMAC.
+func authenticateDump(r io.Reader, mac hash.Hash, dataLen int64) error {
+	if _, err := io.CopyN(mac, r, dataLen); err != nil {
+		return err
+	}
+
+	expected := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(r, expected); err != nil {
+		return err
+	}
+
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errors.New("invalid key or corrupted dump")
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestMemoryDumpEncryption(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-memory-dump")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	plain := filepath.Join(dir, "vmcore-test.elf")
+	encrypted := plain + encryptedDumpSuffix
+	decrypted := filepath.Join(dir, "decrypted")
+
+	data := make([]byte, 100000)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	assert.NoError(ioutil.WriteFile(plain, data, defaultFilePerms))
+
+	key := []byte("0123456789abcdef")
+	in, err := os.Open(plain)
+	assert.NoError(err)
+	defer in.Close()
+	assert.NoError(encryptGuestMemoryDump(in, encrypted, key))
+
+	content, err := ioutil.ReadFile(encrypted)
+	assert.NoError(err)
+	assert.NotContains(string(content), string(data[:1000]))
+
+	assert.NoError(DecryptGuestMemoryDump(encrypted, decrypted, key))
+	content, err = ioutil.ReadFile(decrypted)
+	assert.NoError(err)
+	assert.Equal(data, content)
+
+	// wrong key
+	os.Remove(decrypted)
+	assert.Error(DecryptGuestMemoryDump(encrypted, decrypted, []byte("fedcba9876543210")))
+	_, err = os.Stat(decrypted)
+	assert.True(os.IsNotExist(err))
+
+	// corrupted dump
+	corrupted := filepath.Join(dir, "corrupted")
+	encContent, err := ioutil.ReadFile(encrypted)
+	assert.NoError(err)
+	encContent[len(encContent)/2] ^= 0xff
+	assert.NoError(ioutil.WriteFile(corrupted, encContent, defaultFilePerms))
+	assert.Error(DecryptGuestMemoryDump(corrupted, decrypted, key))
+
+	// not an encrypted dump
+	assert.Error(DecryptGuestMemoryDump(plain, decrypted, key))
+}
+
+func TestSandboxDumpGuestMemory(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "kata-memory-dump")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	s := &Sandbox{
+		id:         testSandboxID,
+		hypervisor: &mockHypervisor{},
+		config: &SandboxConfig{
+			HypervisorType: MockHypervisor,
+		},
+	}
+
+	_, err = s.DumpGuestMemory(context.Background(), GuestMemoryDumpOptions{})
+	assert.Error(err)
+
+	dumpDir, err := s.DumpGuestMemory(context.Background(), GuestMemoryDumpOptions{Path: dir})
+	assert.NoError(err)
+	assert.Equal(dir, dumpDir)
+
+	data, err := ioutil.ReadFile(filepath.Join(dumpDir, guestMemoryDumpInfoFile))
+	assert.NoError(err)
+
+	var info GuestMemoryDumpInfo
+	assert.NoError(json.Unmarshal(data, &info))
+	assert.Equal(testSandboxID, info.SandboxID)
+	assert.Equal(MockHypervisor, info.Hypervisor)
+	assert.False(info.Encrypted)
+	assert.False(info.Redacted)
+
+	_, err = s.DumpGuestMemory(context.Background(), GuestMemoryDumpOptions{Path: dir, EncryptionKey: []byte("key")})
+	assert.NoError(err)
+
+	data, err = ioutil.ReadFile(filepath.Join(dumpDir, guestMemoryDumpInfoFile))
+	assert.NoError(err)
+	assert.NoError(json.Unmarshal(data, &info))
+	assert.True(info.Encrypted)
+}
@@ -6,6 +6,8 @@
 package virtcontainers
 
 import (
+	"io"
+	"net"
 	"syscall"
 	"time"
 
@@ -236,3 +238,49 @@ func (n *mockAgent) getOOMEvent(ctx context.Context) (string, error) {
 func (n *mockAgent) getAgentMetrics(ctx context.Context, req *grpc.GetMetricsRequest) (*grpc.Metrics, error) {
 	return nil, nil
 }
+
+// resizeGuestVolume is the Noop agent guest volume resizer. It does nothing.
+func (n *mockAgent) resizeGuestVolume(ctx context.Context, guestPath string, size uint64) error {
+	return nil
+}
+
+// getGuestDiagnostics is the Noop agent guest diagnostics getter. It returns
+// empty diagnostics.
+func (n *mockAgent) getGuestDiagnostics(ctx context.Context, req *grpc.GuestDiagnosticsRequest) (*grpc.GuestDiagnostics, error) {
+	return &grpc.GuestDiagnostics{}, nil
+}
+
+// portForward is the Noop agent port forwarder. It does nothing.
+func (n *mockAgent) portForward(ctx context.Context, port uint32) (net.Conn, error) {
+	return nil, nil
+}
+
+// policyDecisions is the Noop agent policy decisions streamer. It does nothing.
+func (n *mockAgent) policyDecisions(ctx context.Context) (net.Conn, error) {
+	return nil, nil
+}
+
+// captureNetwork is the Noop agent network capture. It does nothing.
+func (n *mockAgent) captureNetwork(ctx context.Context, device string, duration time.Duration, snapLen uint32) (net.Conn, error) {
+	return nil, nil
+}
+
+// remountSharedFS is the Noop agent shared file system remount. It does nothing.
+func (n *mockAgent) remountSharedFS(ctx context.Context, options []string) error {
+	return nil
+}
+
+// syncFilesystems is the Noop agent guest file systems sync. It does nothing.
+func (n *mockAgent) syncFilesystems(ctx context.Context) error {
+	return nil
+}
+
+// checkpointContainer is the Noop agent container checkpoint. It does nothing.
+func (n *mockAgent) checkpointContainer(ctx context.Context, containerID string, leaveRunning bool) (net.Conn, error) {
+	return nil, nil
+}
+
+// restoreContainer is the Noop agent container restore. It does nothing.
+func (n *mockAgent) restoreContainer(ctx context.Context, containerID string, images io.Reader, size int64) error {
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operations of the mock hypervisor the faults are injected at
+const (
+	MockFaultCreate  = "create"
+	MockFaultBoot    = "boot"
+	MockFaultStop    = "stop"
+	MockFaultPause   = "pause"
+	MockFaultResume  = "resume"
+	MockFaultHotplug = "hotplug"
+	MockFaultUnplug  = "unplug"
+	MockFaultResize  = "resize"
+)
+
+var mockFaultOperations = []string{
+	MockFaultCreate,
+	MockFaultBoot,
+	MockFaultStop,
+	MockFaultPause,
+	MockFaultResume,
+	MockFaultHotplug,
+	MockFaultUnplug,
+	MockFaultResize,
+}
+
+// MockFault is a fault the mock hypervisor injects at an operation, so that
+// the upper layers can be tested against slow or failing hypervisors
+// without KVM: each call of the operation is delayed by Delay, and the
+// FailNth one, 1 for the first one, fails, or all of them with FailAll.
+type MockFault struct {
+	Operation string
+	Delay     time.Duration
+	FailNth   uint32
+	FailAll   bool
+}
+
+// ParseMockFault parses a fault of the
+// <operation>[:delay=<duration>][:fail=<n>|all] form, e.g. boot:delay=5s to
+// boot slowly or hotplug:fail=3 to fail the third device hotplug.
+func ParseMockFault(s string) (MockFault, error) {
+	fields := strings.Split(s, ":")
+
+	fault := MockFault{Operation: fields[0]}
+
+	valid := false
+	for _, op := range mockFaultOperations {
+		if fault.Operation == op {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return MockFault{}, fmt.Errorf("mock fault %q: unknown operation %q, expected one of %s", s, fault.Operation, strings.Join(mockFaultOperations, ", "))
+	}
+
+	for _, field := range fields[1:] {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return MockFault{}, fmt.Errorf("mock fault %q: invalid %q", s, field)
+		}
+
+		switch kv[0] {
+		case "delay":
+			delay, err := time.ParseDuration(kv[1])
+			if err != nil || delay < 0 {
+				return MockFault{}, fmt.Errorf("mock fault %q: invalid delay %q", s, kv[1])
+			}
+			fault.Delay = delay
+		case "fail":
+			if kv[1] == "all" {
+				fault.FailAll = true
+				continue
+			}
+			n, err := strconv.ParseUint(kv[1], 10, 32)
+			if err != nil || n == 0 {
+				return MockFault{}, fmt.Errorf("mock fault %q: invalid call number %q", s, kv[1])
+			}
+			fault.FailNth = uint32(n)
+		default:
+			return MockFault{}, fmt.Errorf("mock fault %q: unknown %q", s, kv[0])
+		}
+	}
+
+	return fault, nil
+}
+
+// mockFaults injects the faults of the mock hypervisor and counts the calls
+// of its operations.
+type mockFaults struct {
+	sync.Mutex
+	faults map[string]MockFault
+	calls  map[string]uint32
+}
+
+func (f *mockFaults) set(faults []MockFault) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.faults = make(map[string]MockFault, len(faults))
+	for _, fault := range faults {
+		f.faults[fault.Operation] = fault
+	}
+}
+
+// inject counts a call of the operation and injects its fault, if any.
+func (f *mockFaults) inject(ctx context.Context, op string) error {
+	f.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]uint32)
+	}
+	f.calls[op]++
+	n := f.calls[op]
+	fault, ok := f.faults[op]
+	f.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	if fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if fault.FailAll || fault.FailNth == n {
+		return fmt.Errorf("mock hypervisor: injected %s failure at call %d", op, n)
+	}
+
+	return nil
+}
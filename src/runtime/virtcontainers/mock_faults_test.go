@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMockFault(t *testing.T) {
+	assert := assert.New(t)
+
+	for s, expected := range map[string]MockFault{
+		"boot":                     {Operation: MockFaultBoot},
+		"boot:delay=5s":            {Operation: MockFaultBoot, Delay: 5 * time.Second},
+		"hotplug:fail=3":           {Operation: MockFaultHotplug, FailNth: 3},
+		"stop:fail=all":            {Operation: MockFaultStop, FailAll: true},
+		"resize:delay=10ms:fail=1": {Operation: MockFaultResize, Delay: 10 * time.Millisecond, FailNth: 1},
+	} {
+		fault, err := ParseMockFault(s)
+		assert.NoError(err, s)
+		assert.Equal(expected, fault, s)
+	}
+
+	for _, s := range []string{
+		"",
+		"reboot",
+		"boot:delay",
+		"boot:delay=soon",
+		"boot:delay=-1s",
+		"hotplug:fail=0",
+		"hotplug:fail=some",
+		"hotplug:retry=1",
+	} {
+		_, err := ParseMockFault(s)
+		assert.Error(err, s)
+	}
+}
+
+func TestMockHypervisorFaults(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	m := &mockHypervisor{}
+	config := HypervisorConfig{
+		KernelPath: "/path/to/kernel",
+		ImagePath:  "/path/to/image",
+		MockFaults: []MockFault{
+			{Operation: MockFaultBoot, Delay: 50 * time.Millisecond},
+			{Operation: MockFaultHotplug, FailNth: 2},
+			{Operation: MockFaultStop, FailAll: true},
+		},
+	}
+	assert.NoError(m.createSandbox(ctx, "sandbox", NetworkNamespace{}, &config))
+
+	start := time.Now()
+	assert.NoError(m.startSandbox(ctx, 10))
+	assert.True(time.Since(start) >= 50*time.Millisecond)
+
+	// Only the second hotplug fails
+	_, err := m.hotplugAddDevice(ctx, uint32(1), cpuDev)
+	assert.NoError(err)
+	_, err = m.hotplugAddDevice(ctx, uint32(1), cpuDev)
+	assert.Error(err)
+	_, err = m.hotplugAddDevice(ctx, uint32(1), cpuDev)
+	assert.NoError(err)
+
+	assert.Error(m.stopSandbox(ctx, false))
+	assert.Error(m.stopSandbox(ctx, false))
+
+	// The delay is cut short by the context
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(m.startSandbox(ctx, 10))
+
+	// No fault
+	assert.NoError((&mockHypervisor{}).pauseSandbox(context.Background()))
+}
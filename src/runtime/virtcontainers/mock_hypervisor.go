@@ -10,6 +10,7 @@ import (
 	"errors"
 	"os"
 
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 )
@@ -18,6 +19,7 @@ var MockHybridVSockPath = "/tmp/kata-mock-hybrid-vsock.socket"
 
 type mockHypervisor struct {
 	mockPid int
+	faults  mockFaults
 }
 
 func (m *mockHypervisor) capabilities(ctx context.Context) types.Capabilities {
@@ -36,23 +38,36 @@ func (m *mockHypervisor) createSandbox(ctx context.Context, id string, networkNS
 		return err
 	}
 
-	return nil
+	if m != nil {
+		m.faults.set(hypervisorConfig.MockFaults)
+	}
+
+	return m.injectFault(ctx, MockFaultCreate)
+}
+
+// injectFault injects the fault of the operation, set by createSandbox.
+func (m *mockHypervisor) injectFault(ctx context.Context, op string) error {
+	if m == nil {
+		return nil
+	}
+
+	return m.faults.inject(ctx, op)
 }
 
 func (m *mockHypervisor) startSandbox(ctx context.Context, timeout int) error {
-	return nil
+	return m.injectFault(ctx, MockFaultBoot)
 }
 
 func (m *mockHypervisor) stopSandbox(ctx context.Context, waitOnly bool) error {
-	return nil
+	return m.injectFault(ctx, MockFaultStop)
 }
 
 func (m *mockHypervisor) pauseSandbox(ctx context.Context) error {
-	return nil
+	return m.injectFault(ctx, MockFaultPause)
 }
 
 func (m *mockHypervisor) resumeSandbox(ctx context.Context) error {
-	return nil
+	return m.injectFault(ctx, MockFaultResume)
 }
 
 func (m *mockHypervisor) saveSandbox() error {
@@ -64,6 +79,10 @@ func (m *mockHypervisor) addDevice(ctx context.Context, devInfo interface{}, dev
 }
 
 func (m *mockHypervisor) hotplugAddDevice(ctx context.Context, devInfo interface{}, devType deviceType) (interface{}, error) {
+	if err := m.injectFault(ctx, MockFaultHotplug); err != nil {
+		return nil, err
+	}
+
 	switch devType {
 	case cpuDev:
 		return devInfo.(uint32), nil
@@ -75,6 +94,10 @@ func (m *mockHypervisor) hotplugAddDevice(ctx context.Context, devInfo interface
 }
 
 func (m *mockHypervisor) hotplugRemoveDevice(ctx context.Context, devInfo interface{}, devType deviceType) (interface{}, error) {
+	if err := m.injectFault(ctx, MockFaultUnplug); err != nil {
+		return nil, err
+	}
+
 	switch devType {
 	case cpuDev:
 		return devInfo.(uint32), nil
@@ -89,10 +112,10 @@ func (m *mockHypervisor) getSandboxConsole(ctx context.Context, sandboxID string
 }
 
 func (m *mockHypervisor) resizeMemory(ctx context.Context, memMB uint32, memorySectionSizeMB uint32, probe bool) (uint32, memoryDevice, error) {
-	return 0, memoryDevice{}, nil
+	return 0, memoryDevice{}, m.injectFault(ctx, MockFaultResize)
 }
 func (m *mockHypervisor) resizeVCPUs(ctx context.Context, cpus uint32) (uint32, uint32, error) {
-	return 0, 0, nil
+	return 0, 0, m.injectFault(ctx, MockFaultResize)
 }
 
 func (m *mockHypervisor) disconnect(ctx context.Context) {
@@ -100,7 +123,7 @@ func (m *mockHypervisor) disconnect(ctx context.Context) {
 
 func (m *mockHypervisor) getThreadIDs(ctx context.Context) (vcpuThreadIDs, error) {
 	vcpus := map[int]int{0: os.Getpid()}
-	return vcpuThreadIDs{vcpus}, nil
+	return vcpuThreadIDs{vcpus: vcpus}, nil
 }
 
 func (m *mockHypervisor) cleanup(ctx context.Context) error {
@@ -139,9 +162,41 @@ func (m *mockHypervisor) generateSocket(id string) (interface{}, error) {
 	}, nil
 }
 
+func (m *mockHypervisor) setVMMCgroup(path string) bool {
+	return false
+}
+
 func (m *mockHypervisor) isRateLimiterBuiltin() bool {
 	return false
 }
 
+func (m *mockHypervisor) dumpGuestMemory(dumpSavePath string, encryptionKey []byte) (string, string, error) {
+	return dumpSavePath, "", nil
+}
+
+func (m *mockHypervisor) prepareMigrationIncoming(ctx context.Context, uri string) error {
+	return nil
+}
+
+func (m *mockHypervisor) startMigration(ctx context.Context, uri string) error {
+	return nil
+}
+
+func (m *mockHypervisor) migrationStatus(ctx context.Context) (string, error) {
+	return "completed", nil
+}
+
+func (m *mockHypervisor) guestMemoryStats(ctx context.Context) (GuestMemoryStats, error) {
+	return GuestMemoryStats{}, nil
+}
+
+func (m *mockHypervisor) migrationSwitchover(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockHypervisor) resizeBlockDevice(ctx context.Context, drive *config.BlockDrive, size uint64) error {
+	return m.injectFault(ctx, MockFaultResize)
+}
+
 func (m *mockHypervisor) setSandbox(sandbox *Sandbox) {
 }
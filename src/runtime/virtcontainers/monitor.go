@@ -16,6 +16,11 @@ import (
 const (
 	defaultCheckInterval = 1 * time.Second
 	watcherChannelSize   = 128
+
+	// clockJumpThreshold is the gap between the progressions of the host
+	// wall clock and monotonic clock above which the wall clock is
+	// considered to have jumped, e.g. after a host suspend.
+	clockJumpThreshold = 2 * time.Second
 )
 
 type monitor struct {
@@ -27,14 +32,26 @@ type monitor struct {
 	wg            sync.WaitGroup
 	running       bool
 	stopCh        chan bool
+
+	// timeSyncInterval is the guest time synchronization period, zero
+	// when it is disabled.
+	timeSyncInterval time.Duration
+	lastTimeSync     time.Time
+	lastTick         time.Time
 }
 
 func newMonitor(s *Sandbox) *monitor {
-	return &monitor{
+	m := &monitor{
 		sandbox:       s,
 		checkInterval: defaultCheckInterval,
 		stopCh:        make(chan bool, 1),
 	}
+
+	if s.config != nil {
+		m.timeSyncInterval = time.Duration(s.config.AgentConfig.TimeSyncInterval) * time.Second
+	}
+
+	return m
 }
 
 func (m *monitor) newWatcher(ctx context.Context) (chan error, error) {
@@ -60,6 +77,7 @@ func (m *monitor) newWatcher(ctx context.Context) (chan error, error) {
 				case <-tick.C:
 					m.watchHypervisor(ctx)
 					m.watchAgent(ctx)
+					m.watchTime(ctx)
 				}
 			}
 		}()
@@ -70,7 +88,12 @@ func (m *monitor) newWatcher(ctx context.Context) (chan error, error) {
 
 func (m *monitor) notify(ctx context.Context, err error) {
 	m.sandbox.agent.markDead(ctx)
+	m.notifyEvent(err)
+}
 
+// notifyEvent sends a non fatal error to the watchers, the agent is still
+// considered alive.
+func (m *monitor) notifyEvent(err error) {
 	m.Lock()
 	defer m.Unlock()
 
@@ -129,6 +152,11 @@ func (m *monitor) stop() {
 }
 
 func (m *monitor) watchAgent(ctx context.Context) {
+	// The guest is being migrated, or was migrated away.
+	if m.sandbox.isAgentAway() {
+		return
+	}
+
 	err := m.sandbox.agent.check(ctx)
 	if err != nil {
 		// TODO: define and export error types
@@ -143,3 +171,36 @@ func (m *monitor) watchHypervisor(ctx context.Context) error {
 	}
 	return nil
 }
+
+// watchTime sets the guest clock to the host time, every timeSyncInterval
+// and as soon as the host wall clock jumped. The guest clock drifts when the
+// host is suspended, which is only seen by the wall clock: the monotonic
+// clock stops during the suspend.
+func (m *monitor) watchTime(ctx context.Context) {
+	if m.timeSyncInterval == 0 || m.sandbox.isAgentAway() {
+		return
+	}
+
+	now := time.Now()
+
+	jumped := false
+	if !m.lastTick.IsZero() {
+		// Round(0) strips the monotonic clock reading.
+		wall := now.Round(0).Sub(m.lastTick.Round(0))
+		monotonic := now.Sub(m.lastTick)
+		if gap := wall - monotonic; gap > clockJumpThreshold || gap < -clockJumpThreshold {
+			jumped = true
+		}
+	}
+	m.lastTick = now
+
+	if !jumped && now.Sub(m.lastTimeSync) < m.timeSyncInterval {
+		return
+	}
+
+	if err := m.sandbox.agent.setGuestDateTime(ctx, now); err != nil {
+		virtLog.WithError(err).Warn("failed to sync guest time")
+		return
+	}
+	m.lastTimeSync = now
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -59,3 +60,45 @@ func TestMonitorClosedChannel(t *testing.T) {
 
 	m.stop()
 }
+
+type timeSyncAgent struct {
+	mockAgent
+	syncs int
+}
+
+func (a *timeSyncAgent) setGuestDateTime(context.Context, time.Time) error {
+	a.syncs++
+	return nil
+}
+
+func TestMonitorWatchTime(t *testing.T) {
+	assert := assert.New(t)
+
+	agent := &timeSyncAgent{}
+	s := &Sandbox{
+		agent: agent,
+		config: &SandboxConfig{
+			AgentConfig: KataAgentConfig{TimeSyncInterval: 3600},
+		},
+	}
+
+	m := newMonitor(s)
+	assert.Equal(time.Hour, m.timeSyncInterval)
+
+	// The first tick syncs the guest time
+	m.watchTime(context.Background())
+	assert.Equal(1, agent.syncs)
+
+	m.watchTime(context.Background())
+	assert.Equal(1, agent.syncs)
+
+	m.lastTimeSync = m.lastTimeSync.Add(-2 * time.Hour)
+	m.watchTime(context.Background())
+	assert.Equal(2, agent.syncs)
+
+	// Disabled
+	s.config.AgentConfig.TimeSyncInterval = 0
+	m = newMonitor(s)
+	m.watchTime(context.Background())
+	assert.Equal(2, agent.syncs)
+}
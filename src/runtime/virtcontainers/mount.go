@@ -17,9 +17,11 @@ import (
 
 	merr "github.com/hashicorp/go-multierror"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/sirupsen/logrus"
 	otelLabel "go.opentelemetry.io/otel/label"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // DefaultShmSize is the default shm size to be used in case host
@@ -349,6 +351,18 @@ type Mount struct {
 	// VM in case this mount is a block device file or a directory
 	// backed by a block device.
 	BlockDeviceID string
+
+	// LUKSKeyRef is the reference of the key of the mount when it is a
+	// LUKS encrypted block volume, unlocked in the guest.
+	LUKSKeyRef string
+
+	// BlockVolumeClass is the block volume class of the mount when it
+	// is a block volume, one of the hypervisor BlockVolumeClasses.
+	BlockVolumeClass string
+
+	// GuestDeviceMount is the path where the guest mounts the device of
+	// the mount when it is a block volume, for the volume to be resized.
+	GuestDeviceMount string
 }
 
 func isSymlink(path string) bool {
@@ -460,6 +474,43 @@ func Isk8sHostEmptyDir(path string) bool {
 	return false
 }
 
+// ConfidentialEmptyDirSizes returns the sizes in bytes of the scratch disks of
+// the emptyDir volumes listed by the ConfidentialEmptyDirs annotation, indexed
+// by emptyDir name.
+func ConfidentialEmptyDirSizes(annotations map[string]string) (map[string]int64, error) {
+	value, ok := annotations[vcAnnotations.ConfidentialEmptyDirs]
+	if !ok {
+		return nil, nil
+	}
+
+	sizes := make(map[string]int64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("Invalid confidential emptyDir %q specified in annotation %s", pair, vcAnnotations.ConfidentialEmptyDirs)
+		}
+
+		quantity, err := resource.ParseQuantity(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't parse the size of confidential emptyDir %s: %v", kv[0], err)
+		}
+
+		size, ok := quantity.AsInt64()
+		if !ok || size <= 0 {
+			return nil, fmt.Errorf("Invalid size %s of confidential emptyDir %s", kv[1], kv[0])
+		}
+
+		sizes[kv[0]] = size
+	}
+
+	return sizes, nil
+}
+
 func checkKubernetesVolume(path, volumeType string) bool {
 	splitSourceSlice := strings.Split(path, "/")
 	if len(splitSourceSlice) > 1 {
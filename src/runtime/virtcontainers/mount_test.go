@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -507,3 +508,24 @@ func TestBindUnmountContainerRootfsRemoveRootfsDest(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestConfidentialEmptyDirSizes(t *testing.T) {
+	assert := assert.New(t)
+
+	sizes, err := ConfidentialEmptyDirSizes(nil)
+	assert.NoError(err)
+	assert.Empty(sizes)
+
+	sizes, err = ConfidentialEmptyDirSizes(map[string]string{
+		vcAnnotations.ConfidentialEmptyDirs: "cache=1Gi, scratch=512Mi,",
+	})
+	assert.NoError(err)
+	assert.Equal(map[string]int64{"cache": 1 << 30, "scratch": 512 << 20}, sizes)
+
+	for _, value := range []string{"cache", "=1Gi", "cache=", "cache=big", "cache=0"} {
+		_, err = ConfidentialEmptyDirSizes(map[string]string{
+			vcAnnotations.ConfidentialEmptyDirs: value,
+		})
+		assert.Error(err, value)
+	}
+}
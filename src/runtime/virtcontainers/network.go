@@ -183,6 +183,10 @@ type NetworkConfig struct {
 	DisableNewNetNs   bool
 	NetmonConfig      NetmonConfig
 	InterworkingModel NetInterworkingModel
+
+	// VhostUserNetSockets maps the names of the network interfaces to
+	// the vhost-user sockets backing them, e.g. provided by OVS-DPDK.
+	VhostUserNetSockets map[string]string
 }
 
 func networkLogger() *logrus.Entry {
@@ -1072,7 +1076,7 @@ func generateVCNetworkStructures(ctx context.Context, networkNS NetworkNamespace
 				Address: neigh.IP.String(),
 			}
 			if neigh.IP.To4() == nil {
-				n.ToIPAddress.Family = netlink.FAMILY_V6
+				n.ToIPAddress.Family = utils.ConvertNetlinkFamily(netlink.FAMILY_V6)
 			}
 
 			neighs = append(neighs, &n)
@@ -1155,7 +1159,9 @@ func networkInfoFromLink(handle *netlink.Handle, link netlink.Link) (NetworkInfo
 	}, nil
 }
 
-func createEndpointsFromScan(networkNSPath string, config *NetworkConfig) ([]Endpoint, error) {
+// createEndpointsFromScan creates the endpoints of the interfaces of the
+// network namespace, indexed from firstIdx.
+func createEndpointsFromScan(networkNSPath string, config *NetworkConfig, firstIdx int) ([]Endpoint, error) {
 	var endpoints []Endpoint
 
 	netnsHandle, err := netns.GetFromPath(networkNSPath)
@@ -1175,7 +1181,7 @@ func createEndpointsFromScan(networkNSPath string, config *NetworkConfig) ([]End
 		return []Endpoint{}, err
 	}
 
-	idx := 0
+	idx := firstIdx
 	for _, link := range linkList {
 		var (
 			endpoint  Endpoint
@@ -1201,7 +1207,7 @@ func createEndpointsFromScan(networkNSPath string, config *NetworkConfig) ([]End
 		}
 
 		if err := doNetNS(networkNSPath, func(_ ns.NetNS) error {
-			endpoint, errCreate = createEndpoint(netInfo, idx, config.InterworkingModel, link)
+			endpoint, errCreate = createEndpoint(netInfo, idx, config.InterworkingModel, link, config.VhostUserNetSockets)
 			return errCreate
 		}); err != nil {
 			return []Endpoint{}, err
@@ -1222,7 +1228,7 @@ func createEndpointsFromScan(networkNSPath string, config *NetworkConfig) ([]End
 	return endpoints, nil
 }
 
-func createEndpoint(netInfo NetworkInfo, idx int, model NetInterworkingModel, link netlink.Link) (Endpoint, error) {
+func createEndpoint(netInfo NetworkInfo, idx int, model NetInterworkingModel, link netlink.Link, vhostUserSockets map[string]string) (Endpoint, error) {
 	var endpoint Endpoint
 	// TODO: This is the incoming interface
 	// based on the incoming interface we should create
@@ -1243,7 +1249,7 @@ func createEndpoint(netInfo NetworkInfo, idx int, model NetInterworkingModel, li
 		var socketPath string
 
 		// Check if this is a dummy interface which has a vhost-user socket associated with it
-		socketPath, err = vhostUserSocketPath(netInfo)
+		socketPath, err = vhostUserNetSocketPath(netInfo, vhostUserSockets)
 		if err != nil {
 			return nil, err
 		}
@@ -1335,7 +1341,7 @@ func (n *Network) Add(ctx context.Context, config *NetworkConfig, s *Sandbox, ho
 	katatrace.AddTag(span, "type", config.InterworkingModel.GetModel())
 	defer span.End()
 
-	endpoints, err := createEndpointsFromScan(config.NetNSPath, config)
+	endpoints, err := createEndpointsFromScan(config.NetNSPath, config, 0)
 	if err != nil {
 		return endpoints, err
 	}
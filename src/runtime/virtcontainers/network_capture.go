@@ -0,0 +1,231 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// MaxNetworkCaptureDuration bounds the duration of a network capture,
+	// it is not meant to record the traffic of a sandbox for good.
+	MaxNetworkCaptureDuration = 5 * time.Minute
+
+	// DefaultNetworkCaptureSnapLen captures the whole packets.
+	DefaultNetworkCaptureSnapLen = 262144
+
+	// pcapLinkTypeEthernet is the pcap link type of the captured frames.
+	pcapLinkTypeEthernet = 1
+
+	// captureReadTimeout is how often the host capture checks whether it
+	// is over while no packet is received.
+	captureReadTimeout = 200 * time.Millisecond
+)
+
+// NetworkCaptureConfig describes a packet capture of the sandbox traffic.
+type NetworkCaptureConfig struct {
+	// Device is the captured interface, the tap interface of the first
+	// endpoint on the host, or its interface in the guest, by default.
+	Device string
+
+	// Duration of the capture, up to MaxNetworkCaptureDuration.
+	Duration time.Duration
+
+	// SnapLen is the length captured of each packet,
+	// DefaultNetworkCaptureSnapLen when it is 0.
+	SnapLen uint32
+
+	// Guest captures in the guest, through the agent, rather than on the
+	// host side of the sandbox network.
+	Guest bool
+}
+
+func (c *NetworkCaptureConfig) validate() error {
+	if c.Duration <= 0 || c.Duration > MaxNetworkCaptureDuration {
+		return fmt.Errorf("invalid capture duration %v, it must be positive and at most %v", c.Duration, MaxNetworkCaptureDuration)
+	}
+
+	if c.SnapLen == 0 {
+		c.SnapLen = DefaultNetworkCaptureSnapLen
+	}
+
+	return nil
+}
+
+// CaptureNetwork captures the traffic of the sandbox for the duration of
+// the capture, or until ctx is done, and writes it to w in the pcap format.
+func (s *Sandbox) CaptureNetwork(ctx context.Context, w io.Writer, config NetworkCaptureConfig) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	if config.Device == "" {
+		if len(s.networkNS.Endpoints) == 0 {
+			return errors.New("the sandbox has no network interface")
+		}
+		config.Device = captureDevice(s.networkNS.Endpoints[0], config.Guest)
+	}
+
+	if config.Guest {
+		return s.captureGuestNetwork(ctx, w, config)
+	}
+
+	return captureHostNetwork(ctx, s.networkNS.NetNsPath, w, config)
+}
+
+// captureDevice returns the interface of the endpoint carrying the traffic of
+// the VM on the host, or the endpoint interface in the guest.
+func captureDevice(endpoint Endpoint, guest bool) string {
+	if !guest {
+		if pair := endpoint.NetworkPair(); pair != nil && pair.TAPIface.Name != "" {
+			return pair.TAPIface.Name
+		}
+	}
+
+	return endpoint.Name()
+}
+
+// captureGuestNetwork copies the capture made by the agent, which ends the
+// stream once the capture is over.
+func (s *Sandbox) captureGuestNetwork(ctx context.Context, w io.Writer, config NetworkCaptureConfig) error {
+	conn, err := s.agent.captureNetwork(ctx, config.Device, config.Duration, config.SnapLen)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock the copy below if the caller gives up
+	captureCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-captureCtx.Done()
+		conn.Close()
+	}()
+
+	if _, err := io.Copy(w, conn); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// captureHostNetwork captures the traffic of device, in the network
+// namespace netNSPath, with a packet socket.
+func captureHostNetwork(ctx context.Context, netNSPath string, w io.Writer, config NetworkCaptureConfig) error {
+	var fd int
+
+	// The socket stays bound to the interface of the network namespace
+	// once it is created
+	err := doNetNS(netNSPath, func(_ ns.NetNS) error {
+		var err error
+		fd, err = openPacketSocket(config.Device)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to capture on %s: %v", config.Device, err)
+	}
+	defer unix.Close(fd)
+
+	if err := writePcapHeader(w, config.SnapLen); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(config.Duration)
+	buf := make([]byte, config.SnapLen)
+
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		// MSG_TRUNC returns the length of the whole packet
+		n, _, err := unix.Recvfrom(fd, buf, unix.MSG_TRUNC)
+		if err != nil {
+			if err == unix.EAGAIN || err == unix.EINTR {
+				continue
+			}
+			return fmt.Errorf("failed to capture on %s: %v", config.Device, err)
+		}
+
+		captured := n
+		if captured > len(buf) {
+			captured = len(buf)
+		}
+
+		if err := writePcapRecord(w, time.Now(), buf[:captured], n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openPacketSocket returns a packet socket receiving the frames sent and
+// received by device, which times out regularly.
+func openPacketSocket(device string) (int, error) {
+	iface, err := net.InterfaceByName(device)
+	if err != nil {
+		return -1, err
+	}
+
+	protocol := htons(unix.ETH_P_ALL)
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(protocol))
+	if err != nil {
+		return -1, err
+	}
+
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: protocol, Ifindex: iface.Index}); err != nil {
+		unix.Close(fd)
+		return -1, err
+	}
+
+	timeout := unix.NsecToTimeval(captureReadTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &timeout); err != nil {
+		unix.Close(fd)
+		return -1, err
+	}
+
+	return fd, nil
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+// writePcapHeader writes the global header of a pcap capture of ethernet
+// frames, in the little endian byte order.
+func writePcapHeader(w io.Writer, snapLen uint32) error {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(header[4:], 2)
+	binary.LittleEndian.PutUint16(header[6:], 4)
+	// The time zone offset and the timestamps accuracy are left to 0
+	binary.LittleEndian.PutUint32(header[16:], snapLen)
+	binary.LittleEndian.PutUint32(header[20:], pcapLinkTypeEthernet)
+
+	_, err := w.Write(header)
+	return err
+}
+
+// writePcapRecord writes a captured packet, data being its first bytes out
+// of length.
+func writePcapRecord(w io.Writer, ts time.Time, data []byte, length int) error {
+	record := make([]byte, 16, 16+len(data))
+	binary.LittleEndian.PutUint32(record[0:], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(record[4:], uint32(ts.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(record[8:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(record[12:], uint32(length))
+	record = append(record, data...)
+
+	_, err := w.Write(record)
+	return err
+}
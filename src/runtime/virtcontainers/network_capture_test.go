@@ -0,0 +1,110 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	ktu "github.com/kata-containers/kata-containers/src/runtime/pkg/katatestutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkCaptureConfigValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	config := NetworkCaptureConfig{Duration: time.Minute}
+	assert.NoError(config.validate())
+	assert.Equal(uint32(DefaultNetworkCaptureSnapLen), config.SnapLen)
+
+	config = NetworkCaptureConfig{Duration: time.Minute, SnapLen: 96}
+	assert.NoError(config.validate())
+	assert.Equal(uint32(96), config.SnapLen)
+
+	for _, d := range []time.Duration{0, -time.Second, MaxNetworkCaptureDuration + time.Second} {
+		config = NetworkCaptureConfig{Duration: d}
+		assert.Error(config.validate(), "duration %v", d)
+	}
+}
+
+func TestCaptureDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	veth := &VethEndpoint{
+		NetPair: NetworkInterfacePair{
+			TapInterface: TapInterface{TAPIface: NetworkInterface{Name: "tap0_kata"}},
+			VirtIface:    NetworkInterface{Name: "eth0"},
+		},
+	}
+	assert.Equal("tap0_kata", captureDevice(veth, false))
+	assert.Equal("eth0", captureDevice(veth, true))
+
+	macvtap := &MacvtapEndpoint{EndpointProperties: NetworkInfo{Iface: NetlinkIface{}}}
+	macvtap.EndpointProperties.Iface.Name = "eth1"
+	assert.Equal("eth1", captureDevice(macvtap, false))
+}
+
+func TestWritePcap(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	assert.NoError(writePcapHeader(&buf, 96))
+
+	header := buf.Bytes()
+	assert.Len(header, 24)
+	assert.Equal(uint32(0xa1b2c3d4), binary.LittleEndian.Uint32(header[0:]))
+	assert.Equal(uint16(2), binary.LittleEndian.Uint16(header[4:]))
+	assert.Equal(uint16(4), binary.LittleEndian.Uint16(header[6:]))
+	assert.Equal(uint32(96), binary.LittleEndian.Uint32(header[16:]))
+	assert.Equal(uint32(pcapLinkTypeEthernet), binary.LittleEndian.Uint32(header[20:]))
+
+	buf.Reset()
+	ts := time.Unix(1600000000, 123456789)
+	assert.NoError(writePcapRecord(&buf, ts, []byte{1, 2, 3}, 1500))
+
+	record := buf.Bytes()
+	assert.Len(record, 19)
+	assert.Equal(uint32(1600000000), binary.LittleEndian.Uint32(record[0:]))
+	assert.Equal(uint32(123456), binary.LittleEndian.Uint32(record[4:]))
+	assert.Equal(uint32(3), binary.LittleEndian.Uint32(record[8:]))
+	assert.Equal(uint32(1500), binary.LittleEndian.Uint32(record[12:]))
+	assert.Equal([]byte{1, 2, 3}, record[16:])
+}
+
+func TestCaptureHostNetwork(t *testing.T) {
+	assert := assert.New(t)
+	if tc.NotValid(ktu.NeedRoot()) {
+		t.Skip(testDisabledAsNonRoot)
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(err)
+	defer conn.Close()
+
+	// Send a packet on the loopback interface while it's captured
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		sender, err := net.Dial("udp", conn.LocalAddr().String())
+		if err != nil {
+			return
+		}
+		defer sender.Close()
+		sender.Write([]byte("kata-capture"))
+	}()
+
+	var buf bytes.Buffer
+	config := NetworkCaptureConfig{Device: "lo", Duration: time.Second, SnapLen: 128}
+	assert.NoError(captureHostNetwork(context.Background(), "", &buf, config))
+
+	assert.True(buf.Len() > 24)
+	assert.Contains(buf.String(), "kata-capture")
+
+	assert.Error(captureHostNetwork(context.Background(), "", &buf, NetworkCaptureConfig{Device: "kata-nodev", Duration: time.Second}))
+}
@@ -0,0 +1,410 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+// NetworkInspection is the network configuration of a sandbox, on the host
+// side, in its network namespace, and on the guest side, as reported by the
+// agent, along with their differences.
+type NetworkInspection struct {
+	Host  NetworkSnapshot `json:"host"`
+	Guest NetworkSnapshot `json:"guest"`
+
+	// Differences describe the host configuration missing in the guest,
+	// or the other way around, e.g. "interface eth0: address
+	// 10.0.0.2/24 is missing in the guest".
+	Differences []string `json:"differences,omitempty"`
+}
+
+// NetworkSnapshot is the network configuration of one side of a sandbox.
+// The agent doesn't report the neighbors and the tc filters of the guest.
+type NetworkSnapshot struct {
+	Interfaces []InspectedInterface `json:"interfaces,omitempty"`
+	Routes     []InspectedRoute     `json:"routes,omitempty"`
+	Neighbors  []InspectedNeighbor  `json:"neighbors,omitempty"`
+	Filters    []InspectedFilter    `json:"filters,omitempty"`
+}
+
+// InspectedInterface is a network interface of a NetworkSnapshot.
+type InspectedInterface struct {
+	Name   string `json:"name"`
+	HwAddr string `json:"hw_addr,omitempty"`
+	MTU    uint64 `json:"mtu,omitempty"`
+	// Addresses are in CIDR notation, e.g. "10.0.0.2/24"
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// InspectedRoute is a route of a NetworkSnapshot.
+type InspectedRoute struct {
+	Dest    string `json:"dest,omitempty"`
+	Gateway string `json:"gateway,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Device  string `json:"device"`
+}
+
+// InspectedNeighbor is a neighbor table entry of a NetworkSnapshot.
+type InspectedNeighbor struct {
+	IP     string `json:"ip"`
+	HwAddr string `json:"hw_addr,omitempty"`
+	Device string `json:"device"`
+	// Permanent entries are copied to the guest
+	Permanent bool `json:"permanent,omitempty"`
+}
+
+// InspectedFilter is a tc filter redirecting the traffic received by
+// Device to Redirect, e.g. from the pod interface to the VM tap interface.
+type InspectedFilter struct {
+	Device   string `json:"device"`
+	Redirect string `json:"redirect"`
+}
+
+// InspectNetwork returns the network configuration of the sandbox in its
+// network namespace and in the guest, along with their differences.
+func (s *Sandbox) InspectNetwork(ctx context.Context) (NetworkInspection, error) {
+	var inspection NetworkInspection
+	var err error
+
+	if inspection.Host, err = inspectHostNetwork(s.networkNS.NetNsPath); err != nil {
+		return NetworkInspection{}, fmt.Errorf("failed to inspect the sandbox network namespace: %v", err)
+	}
+
+	ifaces, err := s.agent.listInterfaces(ctx)
+	if err != nil {
+		return NetworkInspection{}, fmt.Errorf("failed to list the guest interfaces: %v", err)
+	}
+
+	routes, err := s.agent.listRoutes(ctx)
+	if err != nil {
+		return NetworkInspection{}, fmt.Errorf("failed to list the guest routes: %v", err)
+	}
+
+	inspection.Guest = guestNetworkSnapshot(ifaces, routes)
+
+	tcFilter := s.config != nil && s.config.NetworkConfig.InterworkingModel == NetXConnectTCFilterModel
+	inspection.Differences = diffNetwork(inspection.Host, inspection.Guest, tcFilter)
+
+	return inspection, nil
+}
+
+// inspectHostNetwork returns the network configuration of the network
+// namespace, without its loopback interface.
+func inspectHostNetwork(netNSPath string) (NetworkSnapshot, error) {
+	var snapshot NetworkSnapshot
+
+	if netNSPath == "" {
+		return snapshot, nil
+	}
+
+	netnsHandle, err := netns.GetFromPath(netNSPath)
+	if err != nil {
+		return snapshot, err
+	}
+	defer netnsHandle.Close()
+
+	netlinkHandle, err := netlink.NewHandleAt(netnsHandle)
+	if err != nil {
+		return snapshot, err
+	}
+	defer netlinkHandle.Delete()
+
+	links, err := netlinkHandle.LinkList()
+	if err != nil {
+		return snapshot, err
+	}
+
+	names := make(map[int]string, len(links))
+	for _, link := range links {
+		names[link.Attrs().Index] = link.Attrs().Name
+	}
+
+	for _, link := range links {
+		if link.Attrs().Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		info, err := networkInfoFromLink(netlinkHandle, link)
+		if err != nil {
+			return snapshot, err
+		}
+
+		snapshot.Interfaces = append(snapshot.Interfaces, hostInspectedInterface(info))
+		snapshot.Routes = append(snapshot.Routes, hostInspectedRoutes(link.Attrs().Name, info.Routes)...)
+
+		for _, neigh := range info.Neighbors {
+			n := InspectedNeighbor{
+				IP:        neigh.IP.String(),
+				Device:    link.Attrs().Name,
+				Permanent: neigh.State == netlink.NUD_PERMANENT,
+			}
+			if neigh.HardwareAddr != nil {
+				n.HwAddr = neigh.HardwareAddr.String()
+			}
+			snapshot.Neighbors = append(snapshot.Neighbors, n)
+		}
+
+		filters, err := hostRedirectFilters(netlinkHandle, link, names)
+		if err != nil {
+			return snapshot, err
+		}
+		snapshot.Filters = append(snapshot.Filters, filters...)
+	}
+
+	return snapshot, nil
+}
+
+func hostInspectedInterface(info NetworkInfo) InspectedInterface {
+	iface := InspectedInterface{
+		Name: info.Iface.Name,
+		MTU:  uint64(info.Iface.MTU),
+	}
+
+	if info.Iface.HardwareAddr != nil {
+		iface.HwAddr = info.Iface.HardwareAddr.String()
+	}
+
+	for _, addr := range info.Addrs {
+		ones, _ := addr.Mask.Size()
+		iface.Addresses = append(iface.Addresses, fmt.Sprintf("%s/%d", addr.IP, ones))
+	}
+
+	return iface
+}
+
+// hostInspectedRoutes returns the routes of the device, without the routes
+// added by the kernel, which are not copied to the guest.
+func hostInspectedRoutes(device string, routes []netlink.Route) []InspectedRoute {
+	var inspected []InspectedRoute
+
+	for _, route := range routes {
+		if route.Protocol == unix.RTPROT_KERNEL {
+			continue
+		}
+
+		r := InspectedRoute{Device: device}
+		if route.Dst != nil {
+			r.Dest = route.Dst.String()
+		}
+		if route.Gw != nil {
+			r.Gateway = route.Gw.String()
+		}
+		if route.Src != nil {
+			r.Source = route.Src.String()
+		}
+
+		inspected = append(inspected, r)
+	}
+
+	return inspected
+}
+
+// hostRedirectFilters returns the tc filters of the ingress qdisc of the link
+// redirecting its traffic to another link.
+func hostRedirectFilters(handle *netlink.Handle, link netlink.Link, names map[int]string) ([]InspectedFilter, error) {
+	qdiscs, err := handle.QdiscList(link)
+	if err != nil {
+		return nil, err
+	}
+
+	ingress := false
+	for _, q := range qdiscs {
+		if _, ok := q.(*netlink.Ingress); ok {
+			ingress = true
+		}
+	}
+
+	if !ingress {
+		return nil, nil
+	}
+
+	// Handle 0xffff is used for ingress
+	filters, err := handle.FilterList(link, netlink.MakeHandle(0xffff, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	var inspected []InspectedFilter
+	for _, f := range filters {
+		u32, ok := f.(*netlink.U32)
+		if !ok {
+			continue
+		}
+
+		for _, a := range u32.Actions {
+			if mirred, ok := a.(*netlink.MirredAction); ok && mirred.MirredAction == netlink.TCA_EGRESS_REDIR {
+				inspected = append(inspected, InspectedFilter{
+					Device:   link.Attrs().Name,
+					Redirect: names[mirred.Ifindex],
+				})
+			}
+		}
+	}
+
+	return inspected, nil
+}
+
+func guestNetworkSnapshot(ifaces []*pbTypes.Interface, routes []*pbTypes.Route) NetworkSnapshot {
+	var snapshot NetworkSnapshot
+
+	for _, i := range ifaces {
+		iface := InspectedInterface{
+			Name:   i.Name,
+			HwAddr: i.HwAddr,
+			MTU:    i.Mtu,
+		}
+
+		for _, addr := range i.IPAddresses {
+			iface.Addresses = append(iface.Addresses, fmt.Sprintf("%s/%s", addr.Address, addr.Mask))
+		}
+
+		snapshot.Interfaces = append(snapshot.Interfaces, iface)
+	}
+
+	for _, r := range routes {
+		snapshot.Routes = append(snapshot.Routes, InspectedRoute{
+			Dest:    r.Dest,
+			Gateway: r.Gateway,
+			Source:  r.Source,
+			Device:  r.Device,
+		})
+	}
+
+	return snapshot
+}
+
+// routeKey identifies a route on both sides. The agent reports the routes
+// through a gateway with a default destination, so they are identified by
+// their gateway only.
+func routeKey(r InspectedRoute) string {
+	if r.Gateway != "" {
+		return fmt.Sprintf("via %s dev %s", r.Gateway, r.Device)
+	}
+
+	return fmt.Sprintf("%s dev %s", r.Dest, r.Device)
+}
+
+func (r InspectedRoute) String() string {
+	dest := r.Dest
+	if dest == "" {
+		dest = "default"
+	}
+
+	s := dest
+	if r.Gateway != "" {
+		s += " via " + r.Gateway
+	}
+
+	return s + " dev " + r.Device
+}
+
+// diffNetwork returns the differences between the host and the guest network
+// configurations. The host interfaces without any address, e.g. the VM tap
+// interfaces, are not copied to the guest, the others are matched with the
+// guest interfaces by hardware address. The guest routes without a gateway
+// are left out, the guest kernel adds them along with the addresses.
+func diffNetwork(host, guest NetworkSnapshot, tcFilter bool) []string {
+	var diffs []string
+
+	guestIfaces := make(map[string]InspectedInterface, len(guest.Interfaces))
+	for _, iface := range guest.Interfaces {
+		guestIfaces[strings.ToLower(iface.HwAddr)] = iface
+	}
+
+	redirected := make(map[string]bool)
+	for _, f := range host.Filters {
+		redirected[f.Device] = true
+	}
+
+	// The host device names in the guest
+	devices := make(map[string]string)
+
+	for _, hostIface := range host.Interfaces {
+		if len(hostIface.Addresses) == 0 {
+			continue
+		}
+
+		guestIface, ok := guestIfaces[strings.ToLower(hostIface.HwAddr)]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("interface %s (%s) is missing in the guest", hostIface.Name, hostIface.HwAddr))
+			continue
+		}
+		devices[hostIface.Name] = guestIface.Name
+
+		if hostIface.MTU != guestIface.MTU {
+			diffs = append(diffs, fmt.Sprintf("interface %s: MTU %d on the host, %d in the guest", hostIface.Name, hostIface.MTU, guestIface.MTU))
+		}
+
+		for _, addr := range missingStrings(hostIface.Addresses, guestIface.Addresses) {
+			diffs = append(diffs, fmt.Sprintf("interface %s: address %s is missing in the guest", hostIface.Name, addr))
+		}
+
+		for _, addr := range missingStrings(guestIface.Addresses, hostIface.Addresses) {
+			diffs = append(diffs, fmt.Sprintf("interface %s: address %s is only in the guest", hostIface.Name, addr))
+		}
+
+		if tcFilter && !redirected[hostIface.Name] {
+			diffs = append(diffs, fmt.Sprintf("interface %s: no tc filter redirects its traffic to the VM", hostIface.Name))
+		}
+	}
+
+	guestRoutes := make(map[string]bool, len(guest.Routes))
+	for _, r := range guest.Routes {
+		guestRoutes[routeKey(r)] = true
+	}
+
+	hostRoutes := make(map[string]bool, len(host.Routes))
+	for _, r := range host.Routes {
+		device, ok := devices[r.Device]
+		if !ok {
+			continue
+		}
+		r.Device = device
+		hostRoutes[routeKey(r)] = true
+
+		if !guestRoutes[routeKey(r)] {
+			diffs = append(diffs, fmt.Sprintf("route %s is missing in the guest", r))
+		}
+	}
+
+	for _, r := range guest.Routes {
+		if r.Gateway != "" && !hostRoutes[routeKey(r)] {
+			diffs = append(diffs, fmt.Sprintf("route %s is only in the guest", r))
+		}
+	}
+
+	return diffs
+}
+
+// missingStrings returns the strings of a missing in b.
+func missingStrings(a, b []string) []string {
+	var missing []string
+
+	for _, s := range a {
+		found := false
+		for _, t := range b {
+			if s == t {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, s)
+		}
+	}
+
+	return missing
+}
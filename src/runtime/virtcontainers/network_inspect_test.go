@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"testing"
+
+	pbTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffNetwork(t *testing.T) {
+	assert := assert.New(t)
+
+	host := NetworkSnapshot{
+		Interfaces: []InspectedInterface{
+			{Name: "eth0", HwAddr: "02:42:AC:11:00:02", MTU: 1500, Addresses: []string{"10.0.0.2/24"}},
+			{Name: "tap0_kata", HwAddr: "d6:b4:0c:3e:1f:77", MTU: 1500},
+		},
+		Routes: []InspectedRoute{
+			{Gateway: "10.0.0.1", Device: "eth0"},
+			{Dest: "192.168.0.0/16", Device: "eth0"},
+		},
+		Filters: []InspectedFilter{
+			{Device: "eth0", Redirect: "tap0_kata"},
+			{Device: "tap0_kata", Redirect: "eth0"},
+		},
+	}
+
+	guest := guestNetworkSnapshot(
+		[]*pbTypes.Interface{
+			{
+				Name:        "eth0",
+				HwAddr:      "02:42:ac:11:00:02",
+				Mtu:         1500,
+				IPAddresses: []*pbTypes.IPAddress{{Address: "10.0.0.2", Mask: "24"}},
+			},
+		},
+		[]*pbTypes.Route{
+			// The agent reports a default destination for the
+			// routes through a gateway
+			{Dest: "0.0.0.0", Gateway: "10.0.0.1", Device: "eth0"},
+			{Dest: "192.168.0.0/16", Device: "eth0"},
+			{Dest: "10.0.0.0/24", Device: "eth0"},
+		},
+	)
+
+	assert.Empty(diffNetwork(host, guest, true))
+
+	// The guest lost its address, a route and the MTU, the host the
+	// redirection
+	guest.Interfaces[0].Addresses = []string{"10.0.0.3/24"}
+	guest.Interfaces[0].MTU = 1450
+	guest.Routes = []InspectedRoute{
+		{Dest: "0.0.0.0", Gateway: "10.0.0.254", Device: "eth0"},
+		{Dest: "192.168.0.0/16", Device: "eth0"},
+	}
+	host.Filters = nil
+
+	assert.Equal([]string{
+		"interface eth0: MTU 1500 on the host, 1450 in the guest",
+		"interface eth0: address 10.0.0.2/24 is missing in the guest",
+		"interface eth0: address 10.0.0.3/24 is only in the guest",
+		"interface eth0: no tc filter redirects its traffic to the VM",
+		"route default via 10.0.0.1 dev eth0 is missing in the guest",
+		"route 0.0.0.0 via 10.0.0.254 dev eth0 is only in the guest",
+	}, diffNetwork(host, guest, true))
+
+	// Without the tc filter model, and the guest interface missing
+	assert.Equal([]string{
+		"interface eth0 (02:42:AC:11:00:02) is missing in the guest",
+	}, diffNetwork(host, NetworkSnapshot{}, false))
+}
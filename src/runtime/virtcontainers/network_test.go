@@ -53,6 +53,7 @@ func TestGenerateInterfacesAndRoutes(t *testing.T) {
 
 	neighs := []netlink.Neigh{
 		{LinkIndex: 329, IP: net.IPv4(192, 168, 0, 101), State: netlink.NUD_PERMANENT, HardwareAddr: arpMAC},
+		{LinkIndex: 329, IP: net.ParseIP("2001:db8:1::101"), State: netlink.NUD_PERMANENT, HardwareAddr: arpMAC},
 	}
 
 	networkInfo := NetworkInfo{
@@ -104,6 +105,12 @@ func TestGenerateInterfacesAndRoutes(t *testing.T) {
 			Lladdr:      "6a:92:3a:59:70:aa",
 			ToIPAddress: &pbTypes.IPAddress{Address: "192.168.0.101", Family: utils.ConvertNetlinkFamily(netlink.FAMILY_V4)},
 		},
+		{
+			Device:      "eth0",
+			State:       netlink.NUD_PERMANENT,
+			Lladdr:      "6a:92:3a:59:70:aa",
+			ToIPAddress: &pbTypes.IPAddress{Address: "2001:db8:1::101", Family: utils.ConvertNetlinkFamily(netlink.FAMILY_V6)},
+		},
 	}
 
 	for _, r := range resRoutes {
@@ -0,0 +1,100 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"strconv"
+
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+)
+
+const (
+	pcieExpanderBusPrefix = "pxb"
+
+	// The bus numbers of the expander buses start at pcieExpanderBusFirstNr,
+	// each of them has pcieExpanderBusNrs bus numbers for itself and its
+	// root ports, i.e. up to 15 devices.
+	pcieExpanderBusFirstNr = 0x80
+	pcieExpanderBusNrs     = 0x10
+	maxPCIeExpanderBuses   = (0x100 - pcieExpanderBusFirstNr) / pcieExpanderBusNrs
+)
+
+// pcieExpanderBus is a guest PCIe root complex standing for a host root
+// complex, its root ports holding the VFIO devices of the host one.
+type pcieExpanderBus struct {
+	id      string
+	busNr   uint32
+	chassis int
+	ports   int
+}
+
+// pcieTopology mirrors the host PCIe locality of the cold plugged VFIO
+// devices in the guest: the devices of a host root complex, e.g. a GPU and
+// the NIC it does GPUDirect RDMA with, are plugged on the root ports of a
+// same guest root complex, and the devices of distinct host root complexes
+// on distinct guest ones, so that the guest drivers see the peer to peer
+// topology of the host.
+type pcieTopology struct {
+	buses map[string]*pcieExpanderBus
+}
+
+func newPCIeTopology() *pcieTopology {
+	return &pcieTopology{
+		buses: make(map[string]*pcieExpanderBus),
+	}
+}
+
+// placeVFIODevice sets the bus of vfioDev to a new root port of the guest
+// root complex of its host root complex, and returns devices with the
+// expander bus, when it is new, and the root port appended.
+func (t *pcieTopology) placeVFIODevice(devices []govmmQemu.Device, vfioDev *config.VFIODev) ([]govmmQemu.Device, error) {
+	rootComplex, err := drivers.GetPCIRootComplex(vfioDev.SysfsDev)
+	if err != nil {
+		return devices, err
+	}
+
+	bus, ok := t.buses[rootComplex]
+	if !ok {
+		if len(t.buses) == maxPCIeExpanderBuses {
+			return devices, fmt.Errorf("VFIO device %s: too many host PCI root complexes, at most %d are supported", vfioDev.SysfsDev, maxPCIeExpanderBuses)
+		}
+
+		// The (chassis, slot) pairs of the root ports must be unique, the
+		// chassis 0 is the one of the pcie_root_port ones.
+		bus = &pcieExpanderBus{
+			id:      fmt.Sprintf("%s%d", pcieExpanderBusPrefix, len(t.buses)),
+			busNr:   uint32(pcieExpanderBusFirstNr + len(t.buses)*pcieExpanderBusNrs),
+			chassis: len(t.buses) + 1,
+		}
+		t.buses[rootComplex] = bus
+
+		devices = append(devices, govmmQemu.PCIeExpanderBusDevice{
+			ID:    bus.id,
+			Bus:   defaultBridgeBus,
+			BusNr: bus.busNr,
+		})
+	}
+
+	if bus.ports == pcieExpanderBusNrs-1 {
+		return devices, fmt.Errorf("VFIO device %s: too many devices on host PCI root complex %s, at most %d are supported", vfioDev.SysfsDev, rootComplex, pcieExpanderBusNrs-1)
+	}
+
+	port := govmmQemu.PCIeRootPortDevice{
+		ID:      fmt.Sprintf("%s-%s%d", bus.id, pcieRootPortPrefix, bus.ports),
+		Bus:     bus.id,
+		Chassis: strconv.Itoa(bus.chassis),
+		Slot:    strconv.Itoa(bus.ports),
+	}
+	bus.ports++
+	devices = append(devices, port)
+
+	vfioDev.Bus = port.ID
+
+	return devices, nil
+}
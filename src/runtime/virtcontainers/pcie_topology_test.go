@@ -0,0 +1,101 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSysfsPCIDevice creates the sysfs directory of the PCI device bdf below
+// the host root complex rootComplex and returns its path.
+func fakeSysfsPCIDevice(t *testing.T, sysfs, rootComplex, bdf string) string {
+	path := filepath.Join(sysfs, "devices", rootComplex, bdf)
+	assert.NoError(t, os.MkdirAll(path, 0755))
+	return path
+}
+
+func TestPCIeTopologyPlaceVFIODevice(t *testing.T) {
+	assert := assert.New(t)
+	sysfs := t.TempDir()
+
+	gpu0 := config.VFIODev{SysfsDev: fakeSysfsPCIDevice(t, sysfs, "pci0000:3a", "0000:3b:00.0")}
+	nic0 := config.VFIODev{SysfsDev: fakeSysfsPCIDevice(t, sysfs, "pci0000:3a", "0000:3c:00.0")}
+	gpu1 := config.VFIODev{SysfsDev: fakeSysfsPCIDevice(t, sysfs, "pci0000:d7", "0000:d8:00.0")}
+
+	topology := newPCIeTopology()
+	var devices []govmmQemu.Device
+	var err error
+
+	for _, dev := range []*config.VFIODev{&gpu0, &gpu1, &nic0} {
+		devices, err = topology.placeVFIODevice(devices, dev)
+		assert.NoError(err)
+	}
+
+	assert.Equal("pxb0-rp0", gpu0.Bus)
+	assert.Equal("pxb0-rp1", nic0.Bus)
+	assert.Equal("pxb1-rp0", gpu1.Bus)
+
+	assert.Equal([]govmmQemu.Device{
+		govmmQemu.PCIeExpanderBusDevice{ID: "pxb0", Bus: "pcie.0", BusNr: 0x80},
+		govmmQemu.PCIeRootPortDevice{ID: "pxb0-rp0", Bus: "pxb0", Chassis: "1", Slot: "0"},
+		govmmQemu.PCIeExpanderBusDevice{ID: "pxb1", Bus: "pcie.0", BusNr: 0x90},
+		govmmQemu.PCIeRootPortDevice{ID: "pxb1-rp0", Bus: "pxb1", Chassis: "2", Slot: "0"},
+		govmmQemu.PCIeRootPortDevice{ID: "pxb0-rp1", Bus: "pxb0", Chassis: "1", Slot: "1"},
+	}, devices)
+
+	assert.Equal([]string{"-device", "pxb-pcie,id=pxb0,bus=pcie.0,bus_nr=128"}, devices[0].QemuParams(nil))
+
+	// The device is not below a root complex
+	_, err = topology.placeVFIODevice(devices, &config.VFIODev{SysfsDev: sysfs})
+	assert.Error(err)
+
+	// Too many devices on a root complex
+	topology = newPCIeTopology()
+	devices = nil
+	for i := 0; i < pcieExpanderBusNrs-1; i++ {
+		devices, err = topology.placeVFIODevice(devices, &config.VFIODev{SysfsDev: gpu0.SysfsDev})
+		assert.NoError(err)
+	}
+	_, err = topology.placeVFIODevice(devices, &config.VFIODev{SysfsDev: gpu0.SysfsDev})
+	assert.Error(err)
+}
+
+func TestQemuAddDeviceVFIOPreserveTopology(t *testing.T) {
+	assert := assert.New(t)
+	sysfs := t.TempDir()
+	sysfsDev := fakeSysfsPCIDevice(t, sysfs, "pci0000:3a", "0000:3b:00.0")
+
+	q := &qemu{
+		ctx:  context.Background(),
+		arch: &qemuArchBase{},
+		config: HypervisorConfig{
+			PreserveVFIOTopology:  true,
+			HypervisorMachineType: QemuMicrovm,
+		},
+	}
+
+	dev := config.VFIODev{
+		Type:     config.VFIODeviceNormalType,
+		BDF:      "3b:00.0",
+		SysfsDev: sysfsDev,
+		Bus:      "rp0",
+	}
+
+	// q35 is required
+	assert.Error(q.addDevice(context.Background(), dev, vfioDev))
+
+	q.config.HypervisorMachineType = QemuQ35
+	assert.NoError(q.addDevice(context.Background(), dev, vfioDev))
+	assert.Len(q.qemuConfig.Devices, 3)
+	assert.Equal(govmmQemu.VFIODevice{BDF: "3b:00.0", Bus: "pxb0-rp0"}, q.qemuConfig.Devices[2])
+}
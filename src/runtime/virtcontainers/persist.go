@@ -21,7 +21,7 @@ var (
 
 func (s *Sandbox) dumpVersion(ss *persistapi.SandboxState) {
 	// New created sandbox has a uninitialized `PersistVersion` which should be set to current version when do the first saving;
-	// Old restored sandbox should keep its original version and shouldn't be modified any more after it's initialized.
+	// Restored sandbox has already been migrated to the current version when it was loaded from the persist driver.
 	ss.PersistVersion = s.state.PersistVersion
 	if ss.PersistVersion == 0 {
 		ss.PersistVersion = persistapi.CurPersistVersion
@@ -35,6 +35,8 @@ func (s *Sandbox) dumpState(ss *persistapi.SandboxState, cs map[string]persistap
 	ss.State = string(s.state.State)
 	ss.CgroupPath = s.state.CgroupPath
 	ss.CgroupPaths = s.state.CgroupPaths
+	ss.BootTimes = s.boot.dump()
+	ss.Labels = s.Labels()
 
 	for id, cont := range s.containers {
 		state := persistapi.ContainerState{}
@@ -43,8 +45,9 @@ func (s *Sandbox) dumpState(ss *persistapi.SandboxState, cs map[string]persistap
 		}
 		state.State = string(cont.state.State)
 		state.Rootfs = persistapi.RootfsState{
-			BlockDeviceID: cont.state.BlockDeviceID,
-			FsType:        cont.state.Fstype,
+			BlockDeviceID:  cont.state.BlockDeviceID,
+			FsType:         cont.state.Fstype,
+			LayerDeviceIDs: cont.state.LayerDeviceIDs,
 		}
 		state.CgroupPath = cont.state.CgroupPath
 		cs[id] = state
@@ -162,14 +165,39 @@ func (s *Sandbox) dumpAgent(ss *persistapi.SandboxState) {
 }
 
 func (s *Sandbox) dumpNetwork(ss *persistapi.SandboxState) {
-	ss.Network = persistapi.NetworkInfo{
-		NetNsPath:    s.networkNS.NetNsPath,
-		NetmonPID:    s.networkNS.NetmonPID,
-		NetNsCreated: s.networkNS.NetNsCreated,
+	ss.Network = saveNetworkNS(s.networkNS)
+}
+
+func (s *Sandbox) dumpContNetwork(cs map[string]persistapi.ContainerState) {
+	for id, cont := range s.containers {
+		state := persistapi.ContainerState{}
+		if v, ok := cs[id]; ok {
+			state = v
+		}
+
+		state.Network = saveNetworkNS(cont.networkNS)
+		cs[id] = state
+	}
+
+	// delete removed containers
+	for id := range cs {
+		if _, ok := s.containers[id]; !ok {
+			delete(cs, id)
+		}
+	}
+}
+
+func saveNetworkNS(networkNS NetworkNamespace) persistapi.NetworkInfo {
+	netInfo := persistapi.NetworkInfo{
+		NetNsPath:    networkNS.NetNsPath,
+		NetmonPID:    networkNS.NetmonPID,
+		NetNsCreated: networkNS.NetNsCreated,
 	}
-	for _, e := range s.networkNS.Endpoints {
-		ss.Network.Endpoints = append(ss.Network.Endpoints, e.save())
+	for _, e := range networkNS.Endpoints {
+		netInfo.Endpoints = append(netInfo.Endpoints, e.save())
 	}
+
+	return netInfo
 }
 
 func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
@@ -189,6 +217,13 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		SandboxCgroupOnly:   sconfig.SandboxCgroupOnly,
 		DisableGuestSeccomp: sconfig.DisableGuestSeccomp,
 		Cgroups:             sconfig.Cgroups,
+		Hooks: persistapi.SandboxHooks{
+			PreVMStart:     sconfig.Hooks.PreVMStart,
+			PostVMStart:    sconfig.Hooks.PostVMStart,
+			PostAgentReady: sconfig.Hooks.PostAgentReady,
+			PreVMStop:      sconfig.Hooks.PreVMStop,
+			Timeout:        sconfig.Hooks.Timeout,
+		},
 	}
 
 	ss.Config.SandboxBindMounts = append(ss.Config.SandboxBindMounts, sconfig.SandboxBindMounts...)
@@ -213,6 +248,7 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		FirmwarePath:            sconfig.HypervisorConfig.FirmwarePath,
 		MachineAccelerators:     sconfig.HypervisorConfig.MachineAccelerators,
 		CPUFeatures:             sconfig.HypervisorConfig.CPUFeatures,
+		CPUModel:                sconfig.HypervisorConfig.CPUModel,
 		HypervisorPath:          sconfig.HypervisorConfig.HypervisorPath,
 		HypervisorPathList:      sconfig.HypervisorConfig.HypervisorPathList,
 		HypervisorCtlPath:       sconfig.HypervisorConfig.HypervisorCtlPath,
@@ -224,6 +260,8 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		MemoryPath:              sconfig.HypervisorConfig.MemoryPath,
 		DevicesStatePath:        sconfig.HypervisorConfig.DevicesStatePath,
 		EntropySource:           sconfig.HypervisorConfig.EntropySource,
+		EntropyMaxBytes:         sconfig.HypervisorConfig.EntropyMaxBytes,
+		EntropyPeriod:           sconfig.HypervisorConfig.EntropyPeriod,
 		EntropySourceList:       sconfig.HypervisorConfig.EntropySourceList,
 		SharedFS:                sconfig.HypervisorConfig.SharedFS,
 		VirtioFSDaemon:          sconfig.HypervisorConfig.VirtioFSDaemon,
@@ -235,6 +273,14 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		BlockDeviceCacheNoflush: sconfig.HypervisorConfig.BlockDeviceCacheNoflush,
 		DisableBlockDeviceUse:   sconfig.HypervisorConfig.DisableBlockDeviceUse,
 		EnableIOThreads:         sconfig.HypervisorConfig.EnableIOThreads,
+		HotplugIOThreads:        sconfig.HypervisorConfig.HotplugIOThreads,
+		HotplugConcurrency:      sconfig.HypervisorConfig.HotplugConcurrency,
+		BalloonStatsInterval:    sconfig.HypervisorConfig.BalloonStatsInterval,
+		EmulatorThreadsWeight:   sconfig.HypervisorConfig.EmulatorThreadsWeight,
+		VCPUThreadsWeight:       sconfig.HypervisorConfig.VCPUThreadsWeight,
+		IOThreadsWeight:         sconfig.HypervisorConfig.IOThreadsWeight,
+		EnableNUMAPinning:       sconfig.HypervisorConfig.EnableNUMAPinning,
+		NUMANode:                sconfig.HypervisorConfig.NUMANode,
 		Debug:                   sconfig.HypervisorConfig.Debug,
 		MemPrealloc:             sconfig.HypervisorConfig.MemPrealloc,
 		HugePages:               sconfig.HypervisorConfig.HugePages,
@@ -245,9 +291,13 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		DisableNestingChecks:    sconfig.HypervisorConfig.DisableNestingChecks,
 		DisableImageNvdimm:      sconfig.HypervisorConfig.DisableImageNvdimm,
 		HotplugVFIOOnRootBus:    sconfig.HypervisorConfig.HotplugVFIOOnRootBus,
+		ReadOnlyImage:           sconfig.HypervisorConfig.ReadOnlyImage,
+		ColdPlugDevices:         sconfig.HypervisorConfig.ColdPlugDevices,
+		VhostUserNet:            sconfig.HypervisorConfig.VhostUserNet,
 		PCIeRootPort:            sconfig.HypervisorConfig.PCIeRootPort,
 		BootToBeTemplate:        sconfig.HypervisorConfig.BootToBeTemplate,
 		BootFromTemplate:        sconfig.HypervisorConfig.BootFromTemplate,
+		MigrationIncoming:       sconfig.HypervisorConfig.MigrationIncoming,
 		DisableVhostNet:         sconfig.HypervisorConfig.DisableVhostNet,
 		EnableVhostUserStore:    sconfig.HypervisorConfig.EnableVhostUserStore,
 		VhostUserStorePath:      sconfig.HypervisorConfig.VhostUserStorePath,
@@ -260,6 +310,17 @@ func (s *Sandbox) dumpConfig(ss *persistapi.SandboxState) {
 		EnableAnnotations:       sconfig.HypervisorConfig.EnableAnnotations,
 	}
 
+	if len(sconfig.HypervisorConfig.BlockVolumeClasses) > 0 {
+		ss.Config.HypervisorConfig.BlockVolumeClasses = make(map[string]persistapi.BlockVolumeClass)
+		for name, class := range sconfig.HypervisorConfig.BlockVolumeClasses {
+			ss.Config.HypervisorConfig.BlockVolumeClasses[name] = persistapi.BlockVolumeClass{
+				Cache:        class.Cache,
+				Discard:      class.Discard,
+				DetectZeroes: class.DetectZeroes,
+			}
+		}
+	}
+
 	ss.Config.KataAgentConfig = &persistapi.KataAgentConfig{
 		LongLiveConn: sconfig.AgentConfig.LongLiveConn,
 	}
@@ -286,6 +347,7 @@ func (s *Sandbox) Save() error {
 	s.dumpDevices(&ss, cs)
 	s.dumpProcess(cs)
 	s.dumpMounts(cs)
+	s.dumpContNetwork(cs)
 	s.dumpAgent(&ss)
 	s.dumpNetwork(&ss)
 	s.dumpConfig(&ss)
@@ -305,14 +367,20 @@ func (s *Sandbox) loadState(ss persistapi.SandboxState) {
 	s.state.CgroupPath = ss.CgroupPath
 	s.state.CgroupPaths = ss.CgroupPaths
 	s.state.GuestMemoryHotplugProbe = ss.GuestMemoryHotplugProbe
+	s.boot.load(ss.BootTimes)
+
+	s.labelsLock.Lock()
+	s.labels = ss.Labels
+	s.labelsLock.Unlock()
 }
 
 func (c *Container) loadContState(cs persistapi.ContainerState) {
 	c.state = types.ContainerState{
-		State:         types.StateString(cs.State),
-		BlockDeviceID: cs.Rootfs.BlockDeviceID,
-		Fstype:        cs.Rootfs.FsType,
-		CgroupPath:    cs.CgroupPath,
+		State:          types.StateString(cs.State),
+		BlockDeviceID:  cs.Rootfs.BlockDeviceID,
+		Fstype:         cs.Rootfs.FsType,
+		LayerDeviceIDs: cs.Rootfs.LayerDeviceIDs,
+		CgroupPath:     cs.CgroupPath,
 	}
 }
 
@@ -366,7 +434,15 @@ func (c *Container) loadContProcess(cs persistapi.ContainerState) {
 }
 
 func (s *Sandbox) loadNetwork(netInfo persistapi.NetworkInfo) {
-	s.networkNS = NetworkNamespace{
+	s.networkNS = loadNetworkNS(netInfo)
+}
+
+func (c *Container) loadContNetwork(cs persistapi.ContainerState) {
+	c.networkNS = loadNetworkNS(cs.Network)
+}
+
+func loadNetworkNS(netInfo persistapi.NetworkInfo) NetworkNamespace {
+	networkNS := NetworkNamespace{
 		NetNsPath:    netInfo.NetNsPath,
 		NetmonPID:    netInfo.NetmonPID,
 		NetNsCreated: netInfo.NetNsCreated,
@@ -390,12 +466,14 @@ func (s *Sandbox) loadNetwork(netInfo persistapi.NetworkInfo) {
 		case IPVlanEndpointType:
 			ep = &IPVlanEndpoint{}
 		default:
-			s.Logger().WithField("endpoint-type", e.Type).Error("unknown endpoint type")
+			networkLogger().WithField("endpoint-type", e.Type).Error("unknown endpoint type")
 			continue
 		}
 		ep.load(e)
-		s.networkNS.Endpoints = append(s.networkNS.Endpoints, ep)
+		networkNS.Endpoints = append(networkNS.Endpoints, ep)
 	}
+
+	return networkNS
 }
 
 // Restore will restore sandbox data from persist file on disk
@@ -429,6 +507,7 @@ func (c *Container) Restore() error {
 	c.loadContDevices(cs)
 	c.loadContProcess(cs)
 	c.loadContMounts(cs)
+	c.loadContNetwork(cs)
 	return nil
 }
 
@@ -460,6 +539,13 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		SandboxCgroupOnly:   savedConf.SandboxCgroupOnly,
 		DisableGuestSeccomp: savedConf.DisableGuestSeccomp,
 		Cgroups:             savedConf.Cgroups,
+		Hooks: SandboxHooks{
+			PreVMStart:     savedConf.Hooks.PreVMStart,
+			PostVMStart:    savedConf.Hooks.PostVMStart,
+			PostAgentReady: savedConf.Hooks.PostAgentReady,
+			PreVMStop:      savedConf.Hooks.PreVMStop,
+			Timeout:        savedConf.Hooks.Timeout,
+		},
 	}
 	sconfig.SandboxBindMounts = append(sconfig.SandboxBindMounts, savedConf.SandboxBindMounts...)
 
@@ -484,6 +570,7 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		FirmwarePath:            hconf.FirmwarePath,
 		MachineAccelerators:     hconf.MachineAccelerators,
 		CPUFeatures:             hconf.CPUFeatures,
+		CPUModel:                hconf.CPUModel,
 		HypervisorPath:          hconf.HypervisorPath,
 		HypervisorPathList:      hconf.HypervisorPathList,
 		HypervisorCtlPath:       hconf.HypervisorCtlPath,
@@ -495,6 +582,8 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		MemoryPath:              hconf.MemoryPath,
 		DevicesStatePath:        hconf.DevicesStatePath,
 		EntropySource:           hconf.EntropySource,
+		EntropyMaxBytes:         hconf.EntropyMaxBytes,
+		EntropyPeriod:           hconf.EntropyPeriod,
 		EntropySourceList:       hconf.EntropySourceList,
 		SharedFS:                hconf.SharedFS,
 		VirtioFSDaemon:          hconf.VirtioFSDaemon,
@@ -506,6 +595,14 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		BlockDeviceCacheNoflush: hconf.BlockDeviceCacheNoflush,
 		DisableBlockDeviceUse:   hconf.DisableBlockDeviceUse,
 		EnableIOThreads:         hconf.EnableIOThreads,
+		HotplugIOThreads:        hconf.HotplugIOThreads,
+		HotplugConcurrency:      hconf.HotplugConcurrency,
+		BalloonStatsInterval:    hconf.BalloonStatsInterval,
+		EmulatorThreadsWeight:   hconf.EmulatorThreadsWeight,
+		VCPUThreadsWeight:       hconf.VCPUThreadsWeight,
+		IOThreadsWeight:         hconf.IOThreadsWeight,
+		EnableNUMAPinning:       hconf.EnableNUMAPinning,
+		NUMANode:                hconf.NUMANode,
 		Debug:                   hconf.Debug,
 		MemPrealloc:             hconf.MemPrealloc,
 		HugePages:               hconf.HugePages,
@@ -516,9 +613,13 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		DisableNestingChecks:    hconf.DisableNestingChecks,
 		DisableImageNvdimm:      hconf.DisableImageNvdimm,
 		HotplugVFIOOnRootBus:    hconf.HotplugVFIOOnRootBus,
+		ReadOnlyImage:           hconf.ReadOnlyImage,
+		ColdPlugDevices:         hconf.ColdPlugDevices,
+		VhostUserNet:            hconf.VhostUserNet,
 		PCIeRootPort:            hconf.PCIeRootPort,
 		BootToBeTemplate:        hconf.BootToBeTemplate,
 		BootFromTemplate:        hconf.BootFromTemplate,
+		MigrationIncoming:       hconf.MigrationIncoming,
 		DisableVhostNet:         hconf.DisableVhostNet,
 		EnableVhostUserStore:    hconf.EnableVhostUserStore,
 		VhostUserStorePath:      hconf.VhostUserStorePath,
@@ -531,6 +632,17 @@ func loadSandboxConfig(id string) (*SandboxConfig, error) {
 		EnableAnnotations:       hconf.EnableAnnotations,
 	}
 
+	if len(hconf.BlockVolumeClasses) > 0 {
+		sconfig.HypervisorConfig.BlockVolumeClasses = make(map[string]BlockVolumeClass)
+		for name, class := range hconf.BlockVolumeClasses {
+			sconfig.HypervisorConfig.BlockVolumeClasses[name] = BlockVolumeClass{
+				Cache:        class.Cache,
+				Discard:      class.Discard,
+				DetectZeroes: class.DetectZeroes,
+			}
+		}
+	}
+
 	sconfig.AgentConfig = KataAgentConfig{
 		LongLiveConn: savedConf.KataAgentConfig.LongLiveConn,
 	}
@@ -7,10 +7,25 @@
 package persistapi
 
 import (
+	"time"
+
 	"github.com/opencontainers/runc/libcontainer/configs"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
+// BlockVolumeClass are the options of the block volumes of a class.
+type BlockVolumeClass struct {
+	// Cache is the cache mode of the volumes, "none" or "writeback".
+	Cache string
+
+	// Discard passes the discard requests of the guest to the volumes.
+	Discard bool
+
+	// DetectZeroes is the detect-zeroes mode of the volumes, "off", "on"
+	// or "unmap".
+	DetectZeroes string
+}
+
 // HypervisorConfig saves configurations of sandbox hypervisor
 type HypervisorConfig struct {
 	// NumVCPUs specifies default number of vCPUs for the VM.
@@ -57,6 +72,9 @@ type HypervisorConfig struct {
 	// CPUFeatures are cpu specific features
 	CPUFeatures string
 
+	// CPUModel is the guest CPU model
+	CPUModel string
+
 	// HypervisorPath is the hypervisor executable host path.
 	HypervisorPath string
 
@@ -96,6 +114,14 @@ type HypervisorConfig struct {
 	// entropy (/dev/random, /dev/urandom or real hardware RNG device)
 	EntropySource string
 
+	// EntropyMaxBytes is the number of bytes the guest can read from the
+	// entropy source per EntropyPeriod, unlimited when it is 0.
+	EntropyMaxBytes uint32
+
+	// EntropyPeriod is the period of the entropy rate limit, in
+	// milliseconds.
+	EntropyPeriod uint32
+
 	// EntropySourceList is the list of valid entropy sources
 	EntropySourceList []string
 
@@ -140,6 +166,48 @@ type HypervisorConfig struct {
 	// Supported currently for virtio-scsi driver.
 	EnableIOThreads bool
 
+	// HotplugIOThreads is the number of iothreads the hot plugged
+	// virtio-blk devices are distributed across, round-robin. The
+	// iothreads are added on demand, 0 disables them.
+	HotplugIOThreads uint32
+
+	// HotplugConcurrency is the number of devices of a container attached
+	// at once, the host side preparation of the devices, e.g. the VFIO
+	// driver binding, running concurrently. 0 or 1 attaches them one
+	// after the other.
+	HotplugConcurrency uint32
+
+	// BalloonStatsInterval is the interval, in seconds, the guest memory
+	// statistics of the virtio-balloon device are polled at.
+	BalloonStatsInterval uint32
+
+	// BlockVolumeClasses are the block volume classes by name.
+	BlockVolumeClasses map[string]BlockVolumeClass
+
+	// EmulatorThreadsWeight is the cgroup cpu weight of the hypervisor
+	// threads that are neither vCPU nor iothreads. When set, these threads
+	// are moved into their own child cgroup of the sandbox cgroup.
+	EmulatorThreadsWeight uint64
+
+	// VCPUThreadsWeight is the cgroup cpu weight of the vCPU threads.
+	// When set, these threads are moved into their own child cgroup of
+	// the sandbox cgroup.
+	VCPUThreadsWeight uint64
+
+	// IOThreadsWeight is the cgroup cpu weight of the iothreads. When
+	// set, these threads are moved into their own child cgroup of the
+	// sandbox cgroup.
+	IOThreadsWeight uint64
+
+	// EnableNUMAPinning binds the guest memory to the host NUMA node
+	// NUMANode, and places the iothreads and the virtiofsd threads on the
+	// CPUs of that node.
+	EnableNUMAPinning bool
+
+	// NUMANode is the host NUMA node the guest memory is bound to, when
+	// EnableNUMAPinning is set.
+	NUMANode uint32
+
 	// Debug changes the default hypervisor and kernel parameters to
 	// enable debug output where available.
 	Debug bool
@@ -172,6 +240,17 @@ type HypervisorConfig struct {
 	// root bus instead of a bridge.
 	HotplugVFIOOnRootBus bool
 
+	// ReadOnlyImage is used to make the guest image strictly read-only
+	ReadOnlyImage bool
+
+	// VhostUserNet is set when the sandbox network is backed by vhost-user
+	// sockets, the guest memory is then shared with the vhost-user backends.
+	VhostUserNet bool
+
+	// ColdPlugDevices is used to indicate that devices cannot be hot
+	// plugged, they are cold plugged at sandbox creation.
+	ColdPlugDevices bool
+
 	// PCIeRootPort is used to indicate the number of PCIe Root Port devices
 	// The PCIe Root Port device is used to hot-plug the PCIe device
 	PCIeRootPort uint32
@@ -182,6 +261,10 @@ type HypervisorConfig struct {
 	// BootFromTemplate used to indicate if the VM should be created from a template VM
 	BootFromTemplate bool
 
+	// MigrationIncoming is used to launch the VM waiting for an incoming
+	// live migration
+	MigrationIncoming bool
+
 	// DisableVhostNet is used to indicate if host supports vhost_net
 	DisableVhostNet bool
 
@@ -237,6 +320,15 @@ type NetworkConfig struct {
 	InterworkingModel int
 }
 
+// SandboxHooks are the host binaries run at the sandbox lifecycle points.
+type SandboxHooks struct {
+	PreVMStart     []string
+	PostVMStart    []string
+	PostAgentReady []string
+	PreVMStop      []string
+	Timeout        time.Duration
+}
+
 type ContainerConfig struct {
 	ID          string
 	Annotations map[string]string
@@ -281,6 +373,9 @@ type SandboxConfig struct {
 	// Experimental enables experimental features
 	Experimental []string
 
+	// Hooks are the host binaries run at the sandbox lifecycle points
+	Hooks SandboxHooks
+
 	// Information for fields not saved:
 	// * Annotation: this is kind of casual data, we don't need casual data in persist file,
 	// 				if you know this data needs to persist, please gives it
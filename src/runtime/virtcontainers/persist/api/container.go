@@ -64,6 +64,10 @@ type RootfsState struct {
 
 	// RootFStype is file system of the rootfs incase it is block device
 	FsType string
+
+	// LayerDeviceIDs represents the block devices of the rootfs layers
+	// when they are passed as EROFS images
+	LayerDeviceIDs []string
 }
 
 // Process gathers data related to a container process.
@@ -102,6 +106,10 @@ type ContainerState struct {
 	// Mounts is mount info from OCI spec
 	Mounts []Mount
 
+	// Network is the network of the container, when it has its own
+	// rather than the sandbox one
+	Network NetworkInfo
+
 	// Process on host representing container process
 	Process Process
 
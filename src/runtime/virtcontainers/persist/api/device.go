@@ -47,6 +47,9 @@ type BlockDrive struct {
 	// Pmem enabled persistent memory. Use File as backing file
 	// for a nvdimm device in the guest.
 	Pmem bool
+
+	// Serial is the serial number of the drive
+	Serial string
 }
 
 // VFIODev represents a VFIO drive used for hotplugging
@@ -62,6 +65,19 @@ type VFIODev struct {
 
 	// Sysfsdev of VFIO mediated device
 	SysfsDev string
+
+	// APDevices are the AP queues of a VFIO-AP mediated device
+	APDevices []string
+}
+
+// PCIHostBinding is the host driver of a PCI device bound to vfio-pci by
+// the runtime
+type PCIHostBinding struct {
+	// BDF (Bus:Device.Function) of the PCI address
+	BDF string
+
+	// Driver is the host driver
+	Driver string
 }
 
 // VhostUserDeviceAttrs represents data shared by most vhost-user devices
@@ -73,6 +89,14 @@ type VhostUserDeviceAttrs struct {
 	// MacAddress is only meaningful for vhost user net device
 	MacAddress string
 
+	// Reconnect is the reconnect interval in seconds of the socket
+	Reconnect uint32
+
+	// NumQueues and QueueSize are only meaningful for vhost user block
+	// devices
+	NumQueues uint32
+	QueueSize uint32
+
 	// PCIPath is the PCI path used to identify the slot at which the drive is attached.
 	// It is only meaningful for vhost user block devices
 	PCIPath vcTypes.PciPath
@@ -81,6 +105,16 @@ type VhostUserDeviceAttrs struct {
 	Index int
 }
 
+// MultipathDevice represents a dm-mpath device whose paths are attached
+// as block devices
+type MultipathDevice struct {
+	// WWID is the World Wide Identifier of the multipath device
+	WWID string
+
+	// Paths are the states of the block devices of the paths
+	Paths []DeviceState
+}
+
 // DeviceState is sandbox level resource which represents host devices
 // plugged to hypervisor, one Device can be shared among containers in POD
 // Refs: virtcontainers/device/drivers/generic.go:GenericDevice
@@ -120,7 +154,18 @@ type DeviceState struct {
 	// VFIODev is specific VFIO device driver
 	VFIODevs []*VFIODev `json:",omitempty"`
 
+	// VFIOHostBindings are the host drivers of the PCI devices of a VFIO
+	// device bound to vfio-pci by the runtime
+	VFIOHostBindings []PCIHostBinding `json:",omitempty"`
+
+	// VFIOCreatedMdevs are the sysfs paths of the mediated devices of a
+	// VFIO device created by the runtime
+	VFIOCreatedMdevs []string `json:",omitempty"`
+
 	// VhostUserDeviceAttrs is specific for vhost-user device driver
 	VhostUserDev *VhostUserDeviceAttrs `json:",omitempty"`
+
+	// Multipath is specific for multipath device driver
+	Multipath *MultipathDevice `json:",omitempty"`
 	// ============ end device driver specific data ===========
 }
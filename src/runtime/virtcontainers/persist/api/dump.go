@@ -0,0 +1,21 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package persistapi
+
+// SandboxDump is a self-contained copy of the persist data of one sandbox,
+// used to back up the state of a sandbox and restore it later, possibly
+// with a newer runtime.
+type SandboxDump struct {
+	// PersistVersion of the dumped data, it always matches
+	// Sandbox.PersistVersion.
+	PersistVersion uint
+
+	// Sandbox is the sandbox level persist data
+	Sandbox SandboxState
+
+	// Containers is the persist data of every container of the sandbox
+	Containers map[string]ContainerState
+}
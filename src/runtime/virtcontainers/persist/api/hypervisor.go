@@ -40,8 +40,12 @@ type HypervisorState struct {
 	HotpluggedVCPUs      []CPUDevice
 	HotpluggedMemory     int
 	VirtiofsdPid         int
+	VhostUserGPUPid      int `json:",omitempty"`
 	HotplugVFIOOnRootBus bool
 	PCIeRootPort         int
+	// HotplugIOThreads maps the hot plugged block devices to the
+	// iothread processing their IO.
+	HotplugIOThreads map[string]string
 
 	// clh sepcific: refer to 'virtcontainers/clh.go:CloudHypervisorState'
 	APISocket string
@@ -0,0 +1,160 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package persistapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// persistVersionKey is the JSON key holding the persist data version
+// in the raw sandbox data.
+const persistVersionKey = "PersistVersion"
+
+// Migration converts raw persist data written with version From into the
+// layout expected by version From+1.
+//
+// Data is handed over as generic JSON objects rather than Go types, so that
+// a migration can still access fields which were renamed or removed from
+// the persistapi types.
+type Migration struct {
+	// From is the persist version the migration upgrades from.
+	From uint
+
+	// Sandbox converts the sandbox data in place, it can be nil.
+	Sandbox func(ss map[string]interface{}) error
+
+	// Container converts the data of container cid in place, it can be nil.
+	Container func(cid string, cs map[string]interface{}) error
+}
+
+var migrations = map[uint]Migration{}
+
+// asIsVersions are the persist versions whose data decodes as-is into the
+// types of the next version, they need no migration.
+var asIsVersions = map[uint]bool{
+	1: true,
+}
+
+// RegisterMigration adds a migration step from version m.From to m.From+1.
+// Any bump of CurPersistVersion must come with a registered migration from
+// the previous version, or with the previous version in asIsVersions,
+// otherwise sandboxes created by older runtimes can't be restored anymore.
+func RegisterMigration(m Migration) error {
+	if m.From == 0 || m.From >= CurPersistVersion {
+		return fmt.Errorf("invalid migration source version %d (current version is %d)", m.From, CurPersistVersion)
+	}
+
+	if _, ok := migrations[m.From]; ok {
+		return fmt.Errorf("migration from persist version %d already registered", m.From)
+	}
+
+	if asIsVersions[m.From] {
+		return fmt.Errorf("persist version %d data decodes as-is into version %d", m.From, m.From+1)
+	}
+
+	migrations[m.From] = m
+	return nil
+}
+
+// Migrate decodes raw sandbox and container persist data, upgrading it to
+// CurPersistVersion first when it was written by an older runtime.
+// Data written by a newer runtime is rejected as it can't be interpreted
+// safely.
+func Migrate(sandbox []byte, containers map[string][]byte) (SandboxState, map[string]ContainerState, error) {
+	ss := SandboxState{}
+	cs := make(map[string]ContainerState)
+
+	rawSandbox := make(map[string]interface{})
+	if err := json.Unmarshal(sandbox, &rawSandbox); err != nil {
+		return ss, nil, fmt.Errorf("failed to decode sandbox persist data: %v", err)
+	}
+
+	rawContainers := make(map[string]map[string]interface{})
+	for cid, data := range containers {
+		raw := make(map[string]interface{})
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return ss, nil, fmt.Errorf("failed to decode persist data of container %s: %v", cid, err)
+		}
+		rawContainers[cid] = raw
+	}
+
+	version, err := rawVersion(rawSandbox)
+	if err != nil {
+		return ss, nil, err
+	}
+
+	if version > CurPersistVersion {
+		return ss, nil, fmt.Errorf("persist data version %d is newer than supported version %d", version, CurPersistVersion)
+	}
+
+	for ; version < CurPersistVersion; version++ {
+		m, ok := migrations[version]
+		if !ok {
+			if asIsVersions[version] {
+				continue
+			}
+			return ss, nil, fmt.Errorf("no migration available from persist version %d", version)
+		}
+
+		if m.Sandbox != nil {
+			if err := m.Sandbox(rawSandbox); err != nil {
+				return ss, nil, fmt.Errorf("failed to migrate sandbox persist data from version %d: %v", version, err)
+			}
+		}
+
+		if m.Container != nil {
+			for cid, raw := range rawContainers {
+				if err := m.Container(cid, raw); err != nil {
+					return ss, nil, fmt.Errorf("failed to migrate persist data of container %s from version %d: %v", cid, version, err)
+				}
+			}
+		}
+	}
+	rawSandbox[persistVersionKey] = CurPersistVersion
+
+	if err := remarshal(rawSandbox, &ss); err != nil {
+		return ss, nil, fmt.Errorf("failed to decode migrated sandbox persist data: %v", err)
+	}
+
+	for cid, raw := range rawContainers {
+		var state ContainerState
+		if err := remarshal(raw, &state); err != nil {
+			return ss, nil, fmt.Errorf("failed to decode migrated persist data of container %s: %v", cid, err)
+		}
+		cs[cid] = state
+	}
+
+	return ss, cs, nil
+}
+
+// rawVersion returns the persist version of raw sandbox data. Data missing
+// a version predates versioning and is handled as version 1.
+func rawVersion(raw map[string]interface{}) (uint, error) {
+	v, ok := raw[persistVersionKey]
+	if !ok || v == nil {
+		return 1, nil
+	}
+
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != float64(uint(f)) {
+		return 0, fmt.Errorf("invalid persist data version %v", v)
+	}
+
+	if f == 0 {
+		return 1, nil
+	}
+
+	return uint(f), nil
+}
+
+func remarshal(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
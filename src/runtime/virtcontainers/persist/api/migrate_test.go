@@ -0,0 +1,128 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package persistapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateCurrentVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	sandbox := []byte(fmt.Sprintf(`{"PersistVersion":%d,"SandboxContainer":"sid","State":"running"}`, CurPersistVersion))
+	containers := map[string][]byte{
+		"cid": []byte(`{"State":"ready"}`),
+	}
+
+	ss, cs, err := Migrate(sandbox, containers)
+	assert.NoError(err)
+	assert.Equal(CurPersistVersion, ss.PersistVersion)
+	assert.Equal("sid", ss.SandboxContainer)
+	assert.Equal("running", ss.State)
+	assert.Len(cs, 1)
+	assert.Equal("ready", cs["cid"].State)
+}
+
+func TestMigrateUnversioned(t *testing.T) {
+	assert := assert.New(t)
+
+	ss, cs, err := Migrate([]byte(`{"SandboxContainer":"sid"}`), nil)
+	assert.NoError(err)
+	assert.Equal(CurPersistVersion, ss.PersistVersion)
+	assert.Equal("sid", ss.SandboxContainer)
+	assert.Empty(cs)
+}
+
+func TestMigrateNewerVersion(t *testing.T) {
+	sandbox := []byte(fmt.Sprintf(`{"PersistVersion":%d}`, CurPersistVersion+1))
+
+	_, _, err := Migrate(sandbox, nil)
+	assert.Error(t, err)
+}
+
+func TestMigrateInvalidData(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, err := Migrate([]byte(`not json`), nil)
+	assert.Error(err)
+
+	_, _, err = Migrate([]byte(`{"PersistVersion":"two"}`), nil)
+	assert.Error(err)
+
+	_, _, err = Migrate([]byte(`{}`), map[string][]byte{"cid": []byte(`[]`)})
+	assert.Error(err)
+}
+
+func TestMigrateSteps(t *testing.T) {
+	assert := assert.New(t)
+
+	saved, registered := migrations[CurPersistVersion-1]
+	asIs := asIsVersions[CurPersistVersion-1]
+	defer func() {
+		if registered {
+			migrations[CurPersistVersion-1] = saved
+		} else {
+			delete(migrations, CurPersistVersion-1)
+		}
+		asIsVersions[CurPersistVersion-1] = asIs
+	}()
+	asIsVersions[CurPersistVersion-1] = false
+
+	migrations[CurPersistVersion-1] = Migration{
+		From: CurPersistVersion - 1,
+		Sandbox: func(ss map[string]interface{}) error {
+			ss["State"] = ss["OldState"]
+			delete(ss, "OldState")
+			return nil
+		},
+		Container: func(cid string, cs map[string]interface{}) error {
+			cs["State"] = cid + "-" + cs["OldState"].(string)
+			return nil
+		},
+	}
+
+	sandbox := []byte(fmt.Sprintf(`{"PersistVersion":%d,"OldState":"paused"}`, CurPersistVersion-1))
+	containers := map[string][]byte{
+		"cid": []byte(`{"OldState":"stopped"}`),
+	}
+
+	ss, cs, err := Migrate(sandbox, containers)
+	assert.NoError(err)
+	assert.Equal(CurPersistVersion, ss.PersistVersion)
+	assert.Equal("paused", ss.State)
+	assert.Equal("cid-stopped", cs["cid"].State)
+
+	migrations[CurPersistVersion-1] = Migration{
+		From: CurPersistVersion - 1,
+		Sandbox: func(ss map[string]interface{}) error {
+			return fmt.Errorf("migration failure")
+		},
+	}
+	_, _, err = Migrate(sandbox, containers)
+	assert.Error(err)
+
+	delete(migrations, CurPersistVersion-1)
+	_, _, err = Migrate(sandbox, containers)
+	assert.Error(err)
+
+	// The data of an as-is version decodes without migration
+	asIsVersions[CurPersistVersion-1] = true
+	ss, _, err = Migrate([]byte(fmt.Sprintf(`{"PersistVersion":%d,"State":"paused"}`, CurPersistVersion-1)), nil)
+	assert.NoError(err)
+	assert.Equal("paused", ss.State)
+}
+
+func TestRegisterMigration(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Error(RegisterMigration(Migration{From: 0}))
+	assert.Error(RegisterMigration(Migration{From: CurPersistVersion}))
+	// the previous version decodes as-is
+	assert.Error(RegisterMigration(Migration{From: CurPersistVersion - 1}))
+}
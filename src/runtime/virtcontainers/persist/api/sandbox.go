@@ -6,12 +6,46 @@
 
 package persistapi
 
+import "time"
+
 // ============= sandbox level resources =============
 
 // AgentState save agent state data
 type AgentState struct {
 	// URL to connect to agent
 	URL string
+
+	// Capabilities of the agent negotiated when the sandbox started
+	Capabilities AgentCapabilities
+}
+
+// AgentCapabilities save the negotiated capabilities of the agent
+type AgentCapabilities struct {
+	// APIVersion is the API version reported by the agent
+	APIVersion string
+
+	// Seccomp is set when the agent applies the seccomp profiles
+	Seccomp bool
+
+	// Policy is set when the agent enforces or audits a policy
+	Policy bool
+
+	// ImagePull is set when the agent formats the guest image store
+	ImagePull bool
+
+	// UnifiedCgroup is set when the agent applies the unified resources
+	UnifiedCgroup bool
+
+	// DeviceCgroup is set when the agent enforces the device cgroup rules
+	DeviceCgroup bool
+}
+
+// BootTimes save the boot time breakdown of the sandbox
+type BootTimes struct {
+	VMCreate      time.Duration
+	KernelBoot    time.Duration
+	AgentReady    time.Duration
+	WorkloadStart time.Duration
 }
 
 // SandboxState contains state information of sandbox
@@ -54,4 +88,10 @@ type SandboxState struct {
 
 	// Config saves config information of sandbox
 	Config SandboxConfig
+
+	// BootTimes saves the boot time breakdown of sandbox
+	BootTimes BootTimes
+
+	// Labels saves the key/value metadata of sandbox
+	Labels map[string]string
 }
@@ -15,5 +15,8 @@ const (
 	// If you can't be sure if the change in persistapi package
 	// requires a bump of CurPersistVersion or not, do it for peace!
 	// --@WeiZhang555
+	// Every bump needs a migration from the previous version, see
+	// `RegisterMigration`, so that data written by older runtimes is
+	// upgraded when it is loaded.
 	CurPersistVersion uint = 2
 )
@@ -0,0 +1,193 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package persist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+)
+
+// persistFile is the file the persist data of the sandbox and of its
+// containers is written to by the fs driver.
+const persistFile = "persist.json"
+
+// dumpDirMode is the mode of the sandbox directories created by a restore.
+const dumpDirMode = os.FileMode(0700)
+
+// rawSandboxDump mirrors persistapi.SandboxDump but keeps the states
+// undecoded, so that they can go through persistapi.Migrate.
+type rawSandboxDump struct {
+	Sandbox    json.RawMessage
+	Containers map[string]json.RawMessage
+}
+
+// DumpSandbox returns the persist data of sandbox sid as a JSON encoded
+// persistapi.SandboxDump.
+func DumpSandbox(driver persistapi.PersistDriver, sid string) ([]byte, error) {
+	unlock, err := driver.Lock(sid, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ss, cs, err := driver.FromDisk(sid)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(persistapi.SandboxDump{
+		PersistVersion: ss.PersistVersion,
+		Sandbox:        ss,
+		Containers:     cs,
+	}, "", "  ")
+}
+
+// RestoreSandbox writes back a dump created by DumpSandbox, migrating it to
+// the current persist version if needed, and returns the sandbox ID. The
+// sandbox and container IDs of the dump are checked with verifyID before
+// anything is written. Existing persist data of the sandbox is only
+// overwritten if force is set, and kept if the dump can't be written.
+func RestoreSandbox(driver persistapi.PersistDriver, data []byte, force bool, verifyID func(id string) error) (string, error) {
+	var dump rawSandboxDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return "", fmt.Errorf("failed to decode sandbox dump: %v", err)
+	}
+
+	if len(dump.Sandbox) == 0 {
+		return "", fmt.Errorf("sandbox dump has no sandbox data")
+	}
+
+	containers := make(map[string][]byte)
+	for cid, raw := range dump.Containers {
+		containers[cid] = raw
+	}
+
+	ss, cs, err := persistapi.Migrate(dump.Sandbox, containers)
+	if err != nil {
+		return "", err
+	}
+
+	sid := ss.SandboxContainer
+	if sid == "" {
+		return "", fmt.Errorf("sandbox dump has no sandbox ID")
+	}
+
+	if err := verifyID(sid); err != nil {
+		return "", fmt.Errorf("invalid sandbox ID %q in dump: %v", sid, err)
+	}
+	for cid := range cs {
+		if err := verifyID(cid); err != nil {
+			return "", fmt.Errorf("invalid container ID %q in dump: %v", cid, err)
+		}
+	}
+
+	// The sandbox directory is created first when missing, so that it is
+	// locked either way.
+	if err := os.MkdirAll(driver.RunStoragePath(), dumpDirMode); err != nil {
+		return "", err
+	}
+
+	sandboxDir := filepath.Join(driver.RunStoragePath(), sid)
+	created := true
+	if err := os.Mkdir(sandboxDir, dumpDirMode); err != nil {
+		if !os.IsExist(err) {
+			return "", err
+		}
+		if !force {
+			return "", fmt.Errorf("persist data for sandbox %s already exists", sid)
+		}
+		created = false
+	}
+
+	unlock, err := driver.Lock(sid, true)
+	if err != nil {
+		if created {
+			os.Remove(sandboxDir)
+		}
+		return "", err
+	}
+	defer unlock()
+
+	if created {
+		// The driver removes the sandbox directory if it fails
+		err = driver.ToDisk(ss, cs)
+	} else {
+		err = replaceSandbox(driver, sandboxDir, ss, cs)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return sid, nil
+}
+
+// replaceSandbox writes persist data over the existing data of a sandbox.
+// The sandbox directory is moved aside first, and moved back if the data
+// can't be written. Once it is written, what the previous directory held
+// besides persist data, e.g. the journal of the sandbox or the hypervisor
+// state, is moved to the new one.
+func replaceSandbox(driver persistapi.PersistDriver, sandboxDir string, ss persistapi.SandboxState, cs map[string]persistapi.ContainerState) error {
+	// A hidden directory, the sandbox IDs start with an alphanumeric
+	backupRoot, err := ioutil.TempDir(driver.RunStoragePath(), "."+filepath.Base(sandboxDir)+"-")
+	if err != nil {
+		return err
+	}
+
+	backupDir := filepath.Join(backupRoot, "sandbox")
+	if err := os.Rename(sandboxDir, backupDir); err != nil {
+		os.RemoveAll(backupRoot)
+		return err
+	}
+
+	if err := driver.ToDisk(ss, cs); err != nil {
+		os.RemoveAll(sandboxDir)
+		if rerr := os.Rename(backupDir, sandboxDir); rerr != nil {
+			return fmt.Errorf("%v (the previous persist data is kept in %s: %v)", err, backupDir, rerr)
+		}
+		os.RemoveAll(backupRoot)
+		return err
+	}
+	defer os.RemoveAll(backupRoot)
+
+	entries, err := ioutil.ReadDir(backupDir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		src := filepath.Join(backupDir, e.Name())
+		if isPersistData(src, e) {
+			continue
+		}
+
+		dst := filepath.Join(sandboxDir, e.Name())
+		if _, err := os.Lstat(dst); err == nil {
+			continue
+		}
+
+		if err := os.Rename(src, dst); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isPersistData tells whether an entry of a sandbox directory is persist
+// data, the sandbox one or a container directory, replaced by a restore.
+func isPersistData(path string, info os.FileInfo) bool {
+	if !info.IsDir() {
+		return info.Name() == persistFile
+	}
+
+	_, err := os.Stat(filepath.Join(path, persistFile))
+	return err == nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package persist
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+// verifyTestID stands for katautils.VerifyContainerID, which can't be
+// imported here.
+func verifyTestID(id string) error {
+	if id == "" || strings.ContainsAny(id, "/.") {
+		return errors.New("invalid ID")
+	}
+	return nil
+}
+
+// failingDriver writes the persist data but reports a failure.
+type failingDriver struct {
+	persistapi.PersistDriver
+}
+
+func (d failingDriver) ToDisk(ss persistapi.SandboxState, cs map[string]persistapi.ContainerState) error {
+	if err := d.PersistDriver.ToDisk(ss, cs); err != nil {
+		return err
+	}
+	return errors.New("ToDisk failure")
+}
+
+func TestDumpRestoreSandbox(t *testing.T) {
+	assert := assert.New(t)
+	defer fs.MockStorageDestroy()
+
+	driver, err := fs.MockFSInit()
+	assert.NoError(err)
+
+	sid := "test-dump-sandbox"
+	ss := persistapi.SandboxState{
+		PersistVersion:   persistapi.CurPersistVersion,
+		SandboxContainer: sid,
+		State:            "running",
+	}
+	cs := map[string]persistapi.ContainerState{
+		"test-container": {State: "ready"},
+	}
+	assert.NoError(driver.ToDisk(ss, cs))
+
+	_, err = DumpSandbox(driver, "non-existent")
+	assert.Error(err)
+
+	data, err := DumpSandbox(driver, sid)
+	assert.NoError(err)
+
+	var dump persistapi.SandboxDump
+	assert.NoError(json.Unmarshal(data, &dump))
+	assert.Equal(persistapi.CurPersistVersion, dump.PersistVersion)
+	assert.Equal("running", dump.Sandbox.State)
+	assert.Equal("ready", dump.Containers["test-container"].State)
+
+	// existing data is only overwritten when forced
+	_, err = RestoreSandbox(driver, data, false, verifyTestID)
+	assert.Error(err)
+
+	assert.NoError(driver.Destroy(sid))
+
+	restored, err := RestoreSandbox(driver, data, false, verifyTestID)
+	assert.NoError(err)
+	assert.Equal(sid, restored)
+
+	restored, err = RestoreSandbox(driver, data, true, verifyTestID)
+	assert.NoError(err)
+	assert.Equal(sid, restored)
+
+	rss, rcs, err := driver.FromDisk(sid)
+	assert.NoError(err)
+	assert.Equal("running", rss.State)
+	assert.Equal("ready", rcs["test-container"].State)
+}
+
+func TestRestoreSandboxInvalid(t *testing.T) {
+	assert := assert.New(t)
+	defer fs.MockStorageDestroy()
+
+	driver, err := fs.MockFSInit()
+	assert.NoError(err)
+
+	_, err = RestoreSandbox(driver, []byte("invalid"), false, verifyTestID)
+	assert.Error(err)
+
+	_, err = RestoreSandbox(driver, []byte(`{}`), false, verifyTestID)
+	assert.Error(err)
+
+	// missing sandbox ID
+	_, err = RestoreSandbox(driver, []byte(`{"Sandbox":{"State":"running"}}`), false, verifyTestID)
+	assert.Error(err)
+
+	// written by a newer runtime
+	_, err = RestoreSandbox(driver, []byte(`{"Sandbox":{"PersistVersion":1000,"SandboxContainer":"sid"}}`), false, verifyTestID)
+	assert.Error(err)
+}
+
+func TestRestoreSandboxInvalidIDs(t *testing.T) {
+	assert := assert.New(t)
+	defer fs.MockStorageDestroy()
+
+	driver, err := fs.MockFSInit()
+	assert.NoError(err)
+
+	_, err = RestoreSandbox(driver, []byte(`{"Sandbox":{"SandboxContainer":"../sid"}}`), true, verifyTestID)
+	assert.Error(err)
+
+	_, err = RestoreSandbox(driver, []byte(`{"Sandbox":{"SandboxContainer":"sid"},"Containers":{"../cid":{}}}`), true, verifyTestID)
+	assert.Error(err)
+
+	// Nothing is written
+	_, err = os.Stat(filepath.Join(driver.RunStoragePath(), "sid"))
+	assert.True(os.IsNotExist(err))
+}
+
+func TestRestoreSandboxForce(t *testing.T) {
+	assert := assert.New(t)
+	defer fs.MockStorageDestroy()
+
+	driver, err := fs.MockFSInit()
+	assert.NoError(err)
+
+	sid := "test-force-sandbox"
+	sandboxDir := filepath.Join(driver.RunStoragePath(), sid)
+	assert.NoError(driver.ToDisk(persistapi.SandboxState{
+		PersistVersion:   persistapi.CurPersistVersion,
+		SandboxContainer: sid,
+		State:            "running",
+	}, map[string]persistapi.ContainerState{
+		"old-container": {State: "running"},
+	}))
+	journal := filepath.Join(sandboxDir, "journal")
+	assert.NoError(ioutil.WriteFile(journal, []byte("events"), 0600))
+
+	data, err := json.Marshal(persistapi.SandboxDump{
+		PersistVersion: persistapi.CurPersistVersion,
+		Sandbox: persistapi.SandboxState{
+			PersistVersion:   persistapi.CurPersistVersion,
+			SandboxContainer: sid,
+			State:            "paused",
+		},
+		Containers: map[string]persistapi.ContainerState{
+			"new-container": {State: "paused"},
+		},
+	})
+	assert.NoError(err)
+
+	// The previous data is kept when the dump can't be written
+	_, err = RestoreSandbox(failingDriver{driver}, data, true, verifyTestID)
+	assert.Error(err)
+
+	ss, cs, err := driver.FromDisk(sid)
+	assert.NoError(err)
+	assert.Equal("running", ss.State)
+	assert.Contains(cs, "old-container")
+	assert.FileExists(journal)
+
+	_, err = RestoreSandbox(driver, data, true, verifyTestID)
+	assert.NoError(err)
+
+	ss, cs, err = driver.FromDisk(sid)
+	assert.NoError(err)
+	assert.Equal("paused", ss.State)
+	assert.NotContains(cs, "old-container")
+	assert.Contains(cs, "new-container")
+	assert.FileExists(journal)
+
+	// No backup is left behind
+	entries, err := ioutil.ReadDir(driver.RunStoragePath())
+	assert.NoError(err)
+	assert.Len(entries, 1)
+}
@@ -164,7 +164,8 @@ func (fs *FS) ToDisk(ss persistapi.SandboxState, cs map[string]persistapi.Contai
 	return nil
 }
 
-// FromDisk restores state for sandbox with name sid
+// FromDisk restores state for sandbox with name sid. Data written by an
+// older runtime is migrated to the current persist version on the fly.
 func (fs *FS) FromDisk(sid string) (persistapi.SandboxState, map[string]persistapi.ContainerState, error) {
 	ss := persistapi.SandboxState{}
 	if sid == "" {
@@ -178,15 +179,10 @@ func (fs *FS) FromDisk(sid string) (persistapi.SandboxState, map[string]persista
 
 	// get sandbox configuration from persist data
 	sandboxFile := filepath.Join(sandboxDir, persistFile)
-	f, err := os.OpenFile(sandboxFile, os.O_RDONLY, fileMode)
+	sandboxData, err := ioutil.ReadFile(sandboxFile)
 	if err != nil {
 		return ss, nil, err
 	}
-	defer f.Close()
-
-	if err := json.NewDecoder(f).Decode(fs.sandboxState); err != nil {
-		return ss, nil, err
-	}
 
 	// walk sandbox dir and find container
 	files, err := ioutil.ReadDir(sandboxDir)
@@ -194,6 +190,7 @@ func (fs *FS) FromDisk(sid string) (persistapi.SandboxState, map[string]persista
 		return ss, nil, err
 	}
 
+	containerData := make(map[string][]byte)
 	for _, file := range files {
 		if !file.IsDir() {
 			continue
@@ -201,7 +198,7 @@ func (fs *FS) FromDisk(sid string) (persistapi.SandboxState, map[string]persista
 
 		cid := file.Name()
 		cfile := filepath.Join(sandboxDir, cid, persistFile)
-		cf, err := os.OpenFile(cfile, os.O_RDONLY, fileMode)
+		data, err := ioutil.ReadFile(cfile)
 		if err != nil {
 			// if persist.json doesn't exist, ignore and go to next
 			if os.IsNotExist(err) {
@@ -210,12 +207,16 @@ func (fs *FS) FromDisk(sid string) (persistapi.SandboxState, map[string]persista
 			return ss, nil, err
 		}
 
-		defer cf.Close()
-		var cstate persistapi.ContainerState
-		if err := json.NewDecoder(cf).Decode(&cstate); err != nil {
-			return ss, nil, err
-		}
+		containerData[cid] = data
+	}
+
+	ss, cs, err := persistapi.Migrate(sandboxData, containerData)
+	if err != nil {
+		return ss, nil, err
+	}
 
+	*fs.sandboxState = ss
+	for cid, cstate := range cs {
 		fs.containerState[cid] = cstate
 	}
 
@@ -7,7 +7,9 @@ package fs
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
@@ -182,3 +184,31 @@ func TestGlobalReadWrite(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Nil(t, out)
 }
+
+func TestFsDriverLegacyData(t *testing.T) {
+	defer initTestDir()()
+
+	fs, err := getFsDriver()
+	assert.Nil(t, err)
+	assert.NotNil(t, fs)
+
+	id := "test-fs-driver"
+	sandboxDir, err := fs.sandboxDir(id)
+	assert.Nil(t, err)
+	assert.Nil(t, os.MkdirAll(filepath.Join(sandboxDir, "test-container"), dirMode))
+
+	// persist data written before versioning was introduced
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(sandboxDir, persistFile), []byte(`{"SandboxContainer":"test-fs-driver","State":"running"}`), fileMode))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(sandboxDir, "test-container", persistFile), []byte(`{"State":"ready"}`), fileMode))
+
+	ss, cs, err := fs.FromDisk(id)
+	assert.Nil(t, err)
+	assert.Equal(t, persistapi.CurPersistVersion, ss.PersistVersion)
+	assert.Equal(t, "running", ss.State)
+	assert.Equal(t, "ready", cs["test-container"].State)
+
+	// persist data written by a newer runtime
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(sandboxDir, persistFile), []byte(`{"PersistVersion":1000,"SandboxContainer":"test-fs-driver"}`), fileMode))
+	_, _, err = fs.FromDisk(id)
+	assert.NotNil(t, err)
+}
@@ -437,3 +437,33 @@ func MockHybridVSockDialer(sock string, timeout time.Duration) (net.Conn, error)
 	timeoutErr := grpcStatus.Errorf(codes.DeadlineExceeded, "timed out connecting to mock hybrid vsocket %s", sock)
 	return commonDialer(timeout, dialFunc, timeoutErr)
 }
+
+// AgentPortDialer dials a vsock port of the VM reached through the agent
+// socket sock, e.g. the debug console port.
+func AgentPortDialer(sock string, port uint32, timeout time.Duration) (net.Conn, error) {
+	addr, err := url.Parse(sock)
+	if err != nil {
+		return nil, err
+	}
+
+	switch addr.Scheme {
+	case VSockSocketScheme:
+		// vsock://31513974:1024
+		if addr.Hostname() == "" {
+			return nil, fmt.Errorf("Invalid vsock scheme: %s", sock)
+		}
+		return VsockDialer(fmt.Sprintf("%s:%s:%d", VSockSocketScheme, addr.Hostname(), port), timeout)
+	case HybridVSockScheme:
+		// hvsock:///run/vc/firecracker/340b412c97bf1375cdda56bfa8f18c8a/root/kata.hvsock:1024
+		hvsocket := strings.Split(addr.Path, ":")
+		if len(hvsocket) != 2 {
+			return nil, fmt.Errorf("Invalid hybrid vsock scheme: %s", sock)
+		}
+		return HybridVSockDialer(fmt.Sprintf("%s:%s:%d", HybridVSockScheme, hvsocket[0], port), timeout)
+	// just for tests use.
+	case MockHybridVSockScheme:
+		return MockHybridVSockDialer(MockHybridVSockScheme+":"+addr.Path, timeout)
+	}
+
+	return nil, fmt.Errorf("schema %s not found", addr.Scheme)
+}
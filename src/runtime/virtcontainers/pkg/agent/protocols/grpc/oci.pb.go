@@ -804,10 +804,13 @@ type LinuxResources struct {
 	// Hugetlb limit (in bytes)
 	HugepageLimits []LinuxHugepageLimit `protobuf:"bytes,6,rep,name=HugepageLimits,json=hugepageLimits,proto3" json:"HugepageLimits"`
 	// Network restriction configuration
-	Network              *LinuxNetwork `protobuf:"bytes,7,opt,name=Network,json=network,proto3" json:"Network,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Network *LinuxNetwork `protobuf:"bytes,7,opt,name=Network,json=network,proto3" json:"Network,omitempty"`
+	// Unified resources, the cgroup v2 interface files of the container
+	// by name, e.g. memory.high.
+	Unified              map[string]string `protobuf:"bytes,8,rep,name=Unified,json=unified,proto3" json:"Unified,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *LinuxResources) Reset()      { *m = LinuxResources{} }
@@ -1525,6 +1528,7 @@ func init() {
 	proto.RegisterType((*LinuxNamespace)(nil), "grpc.LinuxNamespace")
 	proto.RegisterType((*LinuxDevice)(nil), "grpc.LinuxDevice")
 	proto.RegisterType((*LinuxResources)(nil), "grpc.LinuxResources")
+	proto.RegisterMapType((map[string]string)(nil), "grpc.LinuxResources.UnifiedEntry")
 	proto.RegisterType((*LinuxMemory)(nil), "grpc.LinuxMemory")
 	proto.RegisterType((*LinuxCPU)(nil), "grpc.LinuxCPU")
 	proto.RegisterType((*LinuxWeightDevice)(nil), "grpc.LinuxWeightDevice")
@@ -1546,150 +1550,152 @@ func init() {
 }
 
 var fileDescriptor_e42fef2823778fc8 = []byte{
-	// 2282 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcd, 0x73, 0x23, 0x47,
-	0xd9, 0xdf, 0xd1, 0x8c, 0xa4, 0x51, 0xcb, 0x1f, 0xbb, 0x9d, 0xc4, 0xd1, 0xbb, 0x6f, 0x4a, 0x71,
-	0x86, 0x14, 0x18, 0x58, 0xec, 0x62, 0xc3, 0x47, 0x08, 0x1f, 0x55, 0xb2, 0xbd, 0x1b, 0xab, 0xb2,
-	0x5e, 0x8b, 0x96, 0x9d, 0x85, 0x1c, 0x52, 0xd5, 0x9e, 0x69, 0x49, 0x13, 0x8f, 0xa6, 0x87, 0xee,
-	0x96, 0xb5, 0xce, 0x09, 0x6e, 0xdc, 0x39, 0x70, 0xe6, 0xc2, 0xc7, 0x7f, 0x40, 0x71, 0xe2, 0xc6,
-	0x16, 0x27, 0x8e, 0x54, 0x51, 0x05, 0xac, 0xef, 0xdc, 0x39, 0x52, 0x4f, 0x77, 0xcf, 0xa8, 0x65,
-	0x7b, 0x21, 0x0b, 0x27, 0xcd, 0xf3, 0x7b, 0x9e, 0x7e, 0xba, 0xfb, 0xf9, 0x6e, 0xa1, 0xa3, 0x71,
-	0xaa, 0x26, 0xb3, 0xd3, 0xed, 0x98, 0x4f, 0x77, 0xce, 0xa8, 0xa2, 0x5f, 0x89, 0x79, 0xae, 0x68,
-	0x9a, 0x33, 0x21, 0xaf, 0xd1, 0x52, 0xc4, 0x3b, 0x74, 0xcc, 0x72, 0xb5, 0x53, 0x08, 0xae, 0x78,
-	0xcc, 0x33, 0x69, 0xbe, 0xe4, 0x0e, 0x8f, 0xd3, 0x6d, 0xfd, 0x89, 0x83, 0xb1, 0x28, 0xe2, 0xbb,
-	0xd1, 0x98, 0x8f, 0xb9, 0x61, 0x9e, 0xce, 0x46, 0x3b, 0x40, 0x69, 0x42, 0x7f, 0x19, 0xc9, 0xe8,
-	0x0f, 0x3e, 0x0a, 0x86, 0x05, 0x8b, 0x71, 0x07, 0x35, 0x3f, 0x64, 0x42, 0xa6, 0x3c, 0xef, 0x78,
-	0x9b, 0xde, 0x56, 0x8b, 0x34, 0xcf, 0x0d, 0x89, 0xbf, 0x80, 0x9a, 0x03, 0xc1, 0x63, 0x26, 0x65,
-	0xa7, 0xb6, 0xe9, 0x6d, 0xb5, 0xef, 0xaf, 0x6e, 0x83, 0xfa, 0x6d, 0x0b, 0x92, 0x66, 0x61, 0x3e,
-	0x70, 0x17, 0x05, 0x84, 0x73, 0xd5, 0xf1, 0xb5, 0x14, 0x32, 0x52, 0x80, 0x90, 0x40, 0x70, 0xae,
-	0xf0, 0x5d, 0x14, 0x1e, 0x70, 0xa9, 0x72, 0x3a, 0x65, 0x9d, 0x40, 0xef, 0x11, 0x4e, 0x2c, 0x8d,
-	0xbf, 0x88, 0x1a, 0x87, 0x7c, 0x96, 0x2b, 0xd9, 0xa9, 0x6f, 0xfa, 0x5b, 0xed, 0xfb, 0x6d, 0xb3,
-	0x5a, 0x63, 0xbb, 0xc1, 0xb3, 0xbf, 0xbe, 0x79, 0x8b, 0x34, 0xa6, 0x5a, 0x00, 0xbf, 0x85, 0xea,
-	0x07, 0x9c, 0x9f, 0xc9, 0x4e, 0x43, 0xef, 0x63, 0x25, 0x35, 0x44, 0xea, 0x13, 0xf8, 0xc1, 0xdf,
-	0x45, 0xed, 0x5e, 0x9e, 0x73, 0x45, 0x55, 0xca, 0x73, 0xd9, 0x69, 0x6a, 0x95, 0xff, 0x6f, 0x04,
-	0xe1, 0xb6, 0xdb, 0x0e, 0xf7, 0x41, 0xae, 0xc4, 0x05, 0x69, 0xd3, 0x05, 0x02, 0x3b, 0x3c, 0x4a,
-	0xf3, 0xd9, 0xd3, 0x4e, 0xe8, 0xee, 0xa0, 0x21, 0x52, 0xcf, 0xe0, 0x07, 0x8c, 0x32, 0xe4, 0x19,
-	0x15, 0xa9, 0xec, 0xb4, 0x5c, 0xa3, 0x58, 0x90, 0x34, 0xa5, 0xf9, 0x00, 0xc1, 0x27, 0x69, 0x9e,
-	0xf0, 0xb9, 0xec, 0x20, 0x57, 0xd0, 0x82, 0xa4, 0x39, 0x37, 0x1f, 0x77, 0xbf, 0x87, 0x6e, 0x5f,
-	0x3d, 0x15, 0xbe, 0x8d, 0xfc, 0x33, 0x76, 0x61, 0x1d, 0x02, 0x9f, 0xf8, 0x55, 0x54, 0x3f, 0xa7,
-	0xd9, 0x8c, 0x69, 0x57, 0xb4, 0x88, 0x21, 0xde, 0xab, 0xbd, 0xeb, 0x45, 0xbf, 0xf3, 0x2b, 0x3f,
-	0x81, 0xa5, 0x8f, 0x99, 0x98, 0xa6, 0x39, 0xcd, 0xf4, 0xe2, 0x90, 0x84, 0xca, 0xd2, 0xf8, 0xcb,
-	0xa8, 0xbd, 0xc7, 0x73, 0xc9, 0x33, 0x36, 0x4c, 0x3f, 0x65, 0xd6, 0xa5, 0x2d, 0x73, 0xa8, 0x5d,
-	0xfe, 0x94, 0xb4, 0xe3, 0x05, 0x17, 0xbf, 0x8d, 0x82, 0x13, 0xc9, 0xc4, 0xb2, 0x4b, 0x01, 0xb1,
-	0x3e, 0x09, 0x66, 0x92, 0x09, 0x8c, 0x51, 0xd0, 0x13, 0x63, 0xd9, 0x09, 0x36, 0xfd, 0xad, 0x16,
-	0x09, 0xa8, 0x18, 0x4b, 0x38, 0xfa, 0x83, 0xfc, 0x5c, 0x7b, 0xb3, 0x45, 0x7c, 0x96, 0x9f, 0x03,
-	0xb2, 0x37, 0x4f, 0xb4, 0xd7, 0x5a, 0xc4, 0x8f, 0xe7, 0x09, 0xfe, 0x36, 0x5a, 0xd9, 0xa3, 0x05,
-	0x3d, 0x4d, 0xb3, 0x54, 0xa5, 0x0c, 0xfc, 0x04, 0xbb, 0xbc, 0xee, 0x98, 0xdb, 0x65, 0x93, 0x95,
-	0xd8, 0xa1, 0xf0, 0x57, 0x51, 0x93, 0x64, 0xe9, 0x34, 0x55, 0xb2, 0x13, 0x6a, 0xff, 0xde, 0xb1,
-	0x61, 0x79, 0x34, 0xec, 0xff, 0xc0, 0x70, 0xec, 0x21, 0x9b, 0xc2, 0xc8, 0xe1, 0x2d, 0xb4, 0xfe,
-	0x98, 0x3f, 0x66, 0xf3, 0x81, 0x48, 0xcf, 0xd3, 0x8c, 0x8d, 0x99, 0x71, 0x5e, 0x48, 0xd6, 0xf3,
-	0x65, 0x18, 0x24, 0x7b, 0x45, 0x41, 0xc5, 0x94, 0x8b, 0x81, 0xe0, 0xa3, 0x34, 0x63, 0xda, 0x7b,
-	0x2d, 0xb2, 0x4e, 0x97, 0x61, 0xbc, 0x89, 0xda, 0x47, 0x47, 0x87, 0xc3, 0x98, 0x0b, 0xd6, 0x4b,
-	0x3e, 0xe9, 0xb4, 0x37, 0xbd, 0x2d, 0x9f, 0xb4, 0xf9, 0x02, 0xc2, 0x11, 0x5a, 0x19, 0x32, 0x1d,
-	0x35, 0x8f, 0xe8, 0x29, 0xcb, 0x3a, 0x2b, 0x5a, 0xd1, 0x8a, 0x74, 0xb0, 0xe8, 0x1d, 0xe4, 0xef,
-	0xf2, 0xa7, 0x78, 0x03, 0x35, 0x0e, 0x58, 0x3a, 0x9e, 0x28, 0xed, 0xb5, 0x55, 0xd2, 0x98, 0x68,
-	0x0a, 0xbc, 0xfe, 0x24, 0x4d, 0xd4, 0x44, 0x7b, 0x6b, 0x95, 0xd4, 0xe7, 0x40, 0x44, 0xb9, 0x71,
-	0x0e, 0x18, 0xf6, 0xa4, 0xbf, 0x6f, 0x97, 0xf8, 0xb3, 0xfe, 0x3e, 0x20, 0xef, 0xf7, 0xf7, 0xad,
-	0xb4, 0x3f, 0xee, 0xef, 0xe3, 0xcf, 0xa3, 0xb5, 0x5e, 0x92, 0xa4, 0x10, 0x5b, 0x34, 0x7b, 0x3f,
-	0x4d, 0x64, 0xc7, 0xdf, 0xf4, 0xb7, 0x56, 0xc9, 0x1a, 0x5d, 0x42, 0x21, 0x72, 0x40, 0xa7, 0x9b,
-	0xa3, 0x33, 0x4b, 0x47, 0xbf, 0xf2, 0xd0, 0x9d, 0x6b, 0x5e, 0x81, 0x15, 0xbb, 0x7c, 0x96, 0x27,
-	0x69, 0x3e, 0xee, 0x78, 0xda, 0xdb, 0xe1, 0xa9, 0xa5, 0xf1, 0x1b, 0xa8, 0xf5, 0x60, 0x34, 0x62,
-	0xb1, 0x4a, 0xcf, 0x21, 0xd2, 0x80, 0xd9, 0x62, 0x25, 0x00, 0xa6, 0xeb, 0xe7, 0x13, 0x26, 0x52,
-	0x45, 0x4f, 0x33, 0xa6, 0x0f, 0xd4, 0x22, 0xed, 0x74, 0x01, 0xc1, 0xfa, 0x01, 0xc4, 0xad, 0x52,
-	0x2c, 0xb1, 0xd1, 0xd5, 0x2a, 0x4a, 0x00, 0x4a, 0x56, 0x6f, 0x7a, 0x9a, 0xb2, 0x5c, 0xd9, 0x30,
-	0x6b, 0x52, 0x43, 0x46, 0x7d, 0xd4, 0x76, 0xc2, 0x00, 0xe2, 0xf3, 0xf8, 0xa2, 0x60, 0x36, 0x8f,
-	0x02, 0x75, 0x51, 0x30, 0xc0, 0x0e, 0xa8, 0x48, 0xb4, 0x8d, 0x02, 0x12, 0x4c, 0xa8, 0x48, 0x00,
-	0x1b, 0xf2, 0x91, 0x29, 0x60, 0x01, 0x09, 0x24, 0x1f, 0xa9, 0x88, 0xa3, 0xba, 0x2e, 0x42, 0x70,
-	0xda, 0x84, 0x49, 0x95, 0xe6, 0x3a, 0x41, 0xad, 0x2e, 0x17, 0x02, 0xef, 0x49, 0x3e, 0x13, 0x71,
-	0x99, 0x9c, 0x96, 0x02, 0xb5, 0xb0, 0xa5, 0x56, 0x5b, 0x6e, 0xdf, 0x41, 0x4d, 0x5e, 0x98, 0xea,
-	0x64, 0xee, 0x55, 0x92, 0xd1, 0x37, 0x4c, 0x15, 0x85, 0x55, 0x03, 0xaa, 0x26, 0xe5, 0xa1, 0x0b,
-	0xaa, 0x26, 0x60, 0x6b, 0xc2, 0x68, 0xc2, 0xf3, 0xec, 0x42, 0xef, 0x11, 0x92, 0x50, 0x58, 0x3a,
-	0xfa, 0x99, 0x67, 0xeb, 0x22, 0xbe, 0x87, 0xc2, 0x81, 0x60, 0x52, 0x51, 0xa1, 0xb4, 0x47, 0xaa,
-	0xc4, 0x05, 0xb6, 0xcd, 0x89, 0xb0, 0xb0, 0x12, 0x78, 0x1b, 0xb5, 0x06, 0x5c, 0x2a, 0x23, 0x5e,
-	0x7b, 0x81, 0x78, 0xab, 0x28, 0x45, 0xb4, 0x76, 0x4d, 0xf0, 0x42, 0xbb, 0xec, 0x66, 0xed, 0x56,
-	0x22, 0xfa, 0x08, 0x05, 0x80, 0xdf, 0x78, 0x9b, 0xb2, 0x6c, 0xd4, 0xae, 0x97, 0x0d, 0x7f, 0x51,
-	0x36, 0x3a, 0xa8, 0x79, 0x9c, 0x4e, 0x19, 0x9f, 0x29, 0x1d, 0x90, 0x3e, 0x69, 0x2a, 0x43, 0x46,
-	0xbf, 0xa9, 0xdb, 0x3a, 0x8d, 0xbf, 0x83, 0xda, 0x27, 0xfd, 0xfd, 0x43, 0x5a, 0x14, 0x69, 0x3e,
-	0x96, 0xf6, 0xd2, 0xaf, 0x3a, 0x75, 0xa4, 0x62, 0xda, 0x03, 0xb6, 0x67, 0x0b, 0x71, 0x58, 0xfd,
-	0xbe, 0xb3, 0xba, 0xf6, 0x9f, 0x57, 0x8f, 0x9d, 0xd5, 0x3b, 0xa8, 0x31, 0xbc, 0x90, 0xb1, 0xca,
-	0xac, 0x35, 0xdc, 0xf2, 0xb5, 0x6d, 0x38, 0xa6, 0xc5, 0x34, 0xa4, 0x26, 0xf0, 0x7d, 0xd4, 0x22,
-	0xcc, 0x84, 0x86, 0xd4, 0x57, 0x5a, 0xde, 0xac, 0xe2, 0x91, 0x96, 0x28, 0x3f, 0x21, 0xf8, 0xf6,
-	0xc6, 0x82, 0xcf, 0x0a, 0xa9, 0xad, 0x58, 0x37, 0xc1, 0x17, 0x2f, 0x20, 0xfc, 0x1e, 0x42, 0x8f,
-	0xe9, 0x94, 0xc9, 0x82, 0x82, 0xda, 0xc6, 0xb5, 0x3b, 0x54, 0x4c, 0x7b, 0x07, 0x94, 0x57, 0xd2,
-	0x50, 0x4a, 0xf7, 0xd9, 0x79, 0x1a, 0xb3, 0xb2, 0x55, 0xde, 0x71, 0x16, 0x1a, 0x4e, 0x59, 0x4a,
-	0x13, 0x23, 0x87, 0xef, 0xa1, 0xe6, 0x90, 0xc5, 0x31, 0x9f, 0x16, 0xb6, 0x49, 0x62, 0x67, 0x89,
-	0xe5, 0x90, 0xa6, 0x34, 0x1f, 0xf8, 0x1e, 0xba, 0x03, 0x31, 0x3d, 0x92, 0x03, 0xc1, 0x0b, 0x3a,
-	0x36, 0x19, 0xd4, 0xd2, 0x97, 0xb8, 0x23, 0xae, 0x32, 0xe0, 0xb2, 0x87, 0x54, 0x9e, 0xb1, 0x04,
-	0x2e, 0x06, 0x6d, 0x53, 0xd7, 0x85, 0xe9, 0x02, 0xc2, 0x6f, 0xa3, 0xd5, 0x32, 0x0f, 0x8c, 0x4c,
-	0x5b, 0xcb, 0xac, 0x0a, 0x17, 0xc4, 0x5d, 0x84, 0x74, 0xea, 0xba, 0x65, 0x17, 0x4d, 0x2b, 0x04,
-	0xef, 0xa0, 0xb0, 0x9f, 0x2b, 0x96, 0x91, 0x44, 0x75, 0x56, 0xf5, 0x25, 0x5e, 0x71, 0x9d, 0x6e,
-	0x59, 0x24, 0x4c, 0xed, 0xd7, 0xdd, 0x6f, 0xa1, 0xb6, 0xe3, 0xd0, 0x97, 0xea, 0xce, 0x6f, 0x56,
-	0x63, 0x00, 0x08, 0x25, 0xb3, 0xe9, 0xb4, 0x5c, 0x68, 0x08, 0x10, 0xb0, 0xb3, 0xc3, 0x0b, 0x04,
-	0x3e, 0x46, 0x6b, 0xcb, 0xc1, 0xa8, 0xbb, 0x05, 0x97, 0xaa, 0x2a, 0xfd, 0x8d, 0x89, 0xa6, 0x74,
-	0xb0, 0x94, 0x03, 0x63, 0xd5, 0x05, 0xda, 0xf1, 0x02, 0xd2, 0x85, 0x0e, 0x9a, 0xbf, 0xaf, 0x59,
-	0x81, 0x4c, 0x3f, 0x65, 0xd1, 0xbb, 0x56, 0x7f, 0x15, 0x28, 0x2f, 0x2a, 0x9b, 0x3a, 0x02, 0x6b,
-	0x8b, 0x3c, 0x8e, 0x7e, 0xe1, 0xa1, 0xb6, 0x13, 0x2a, 0x2f, 0xca, 0x75, 0xad, 0xab, 0xe6, 0xe8,
-	0x7a, 0x15, 0xd5, 0x0f, 0xe9, 0x27, 0xdc, 0x4c, 0x17, 0x3e, 0xa9, 0x4f, 0x81, 0xd0, 0x68, 0x9a,
-	0x73, 0x61, 0xb3, 0xbd, 0x3e, 0x05, 0x02, 0x2a, 0xdf, 0xc3, 0x34, 0x63, 0x87, 0x3c, 0x61, 0x3a,
-	0xfa, 0x57, 0x49, 0x38, 0xb2, 0x74, 0xd9, 0xff, 0x1a, 0xd7, 0xfa, 0x5f, 0xb3, 0xea, 0x7f, 0xd1,
-	0xdf, 0x6a, 0xf6, 0x7a, 0x55, 0x7a, 0xe1, 0x6f, 0x2e, 0xa2, 0xde, 0xbb, 0x96, 0xb9, 0x86, 0x63,
-	0x72, 0xee, 0x6a, 0xec, 0xc3, 0xac, 0xca, 0xa6, 0x5c, 0x5c, 0xd8, 0xe1, 0xc9, 0xcd, 0x16, 0xc3,
-	0x20, 0x8d, 0xa9, 0xfe, 0xc5, 0x9b, 0xc8, 0xdf, 0x1b, 0x9c, 0xd8, 0xf1, 0x69, 0xcd, 0x1d, 0x6c,
-	0x06, 0x27, 0xc4, 0x8f, 0x07, 0x27, 0xf8, 0x73, 0x28, 0x18, 0x40, 0x3b, 0x36, 0x85, 0x60, 0xdd,
-	0x11, 0x01, 0x98, 0x04, 0x05, 0x74, 0xe5, 0x7b, 0xa8, 0xb9, 0x9b, 0xf1, 0xf8, 0xac, 0x7f, 0xa4,
-	0x2f, 0xbf, 0x9c, 0x6d, 0x96, 0x43, 0x9a, 0xa7, 0xe6, 0x03, 0x3f, 0x44, 0x6b, 0x07, 0xb3, 0x31,
-	0x2b, 0xe8, 0x98, 0x3d, 0x32, 0x03, 0x92, 0x29, 0x07, 0x1d, 0x67, 0xd1, 0x92, 0x80, 0xbd, 0xe0,
-	0xda, 0x64, 0x69, 0x15, 0xec, 0xfa, 0x98, 0xa9, 0x39, 0x17, 0x67, 0x76, 0x32, 0x73, 0x77, 0xb5,
-	0x1c, 0xd2, 0xcc, 0xcd, 0x47, 0xf4, 0x97, 0x32, 0x0a, 0x8c, 0x09, 0xc0, 0x8f, 0x5a, 0x8f, 0x0e,
-	0x03, 0x1f, 0xe6, 0x66, 0x68, 0xc5, 0x9b, 0xa8, 0x4d, 0x98, 0x64, 0xe2, 0xdc, 0xd4, 0x80, 0x9a,
-	0x19, 0x97, 0xc4, 0x02, 0xd2, 0xb1, 0x39, 0xa7, 0x85, 0x0d, 0x8a, 0x40, 0xce, 0x69, 0x01, 0x91,
-	0xfe, 0x01, 0x13, 0x39, 0xcb, 0x6c, 0x50, 0x34, 0xce, 0x34, 0x05, 0xf3, 0x81, 0xc1, 0x8f, 0xf7,
-	0x06, 0xda, 0x32, 0x3e, 0x69, 0x9d, 0x95, 0x00, 0xe4, 0x3f, 0x68, 0x2a, 0xd2, 0x1c, 0xde, 0x2e,
-	0x0d, 0xdd, 0xd4, 0x91, 0xac, 0x10, 0xfc, 0x25, 0x74, 0x7b, 0x3f, 0x95, 0x30, 0x68, 0x1c, 0x1d,
-	0x1d, 0x7e, 0x90, 0x66, 0x19, 0x13, 0xfa, 0xa2, 0x21, 0xb9, 0x9d, 0x5c, 0xc1, 0xa3, 0x3f, 0x7a,
-	0x28, 0x2c, 0x1d, 0x07, 0xc7, 0x19, 0x4e, 0xa8, 0xd0, 0x81, 0x03, 0x4a, 0x1b, 0x52, 0x53, 0x70,
-	0xe5, 0xef, 0xcf, 0xb8, 0xa2, 0xf6, 0x5a, 0xf5, 0x1f, 0x01, 0x01, 0xd2, 0x03, 0x26, 0x52, 0x9e,
-	0xd8, 0xb9, 0xa2, 0x51, 0x68, 0x0a, 0x66, 0x4c, 0xc2, 0x68, 0x06, 0xdd, 0x8c, 0xcc, 0x72, 0xf8,
-	0xb1, 0xb7, 0x5b, 0x17, 0xcb, 0x30, 0x0c, 0x6f, 0xa5, 0xa4, 0xd5, 0x54, 0xd7, 0x9a, 0xd6, 0xc4,
-	0x12, 0x0a, 0xa6, 0xdb, 0x2b, 0x66, 0xd2, 0x8e, 0xd8, 0x41, 0x5c, 0xcc, 0x24, 0x60, 0x87, 0x6c,
-	0x6a, 0x66, 0xeb, 0x16, 0x09, 0xa6, 0x6c, 0x2a, 0xa3, 0xb9, 0x9d, 0xe3, 0x9e, 0xe8, 0xe9, 0xd2,
-	0x66, 0x6d, 0x95, 0x8d, 0xde, 0x8d, 0xd9, 0x58, 0x73, 0xb3, 0x71, 0x03, 0x35, 0xcc, 0x5a, 0x5b,
-	0x41, 0x1a, 0x73, 0x33, 0xa7, 0x76, 0x11, 0x7a, 0xc4, 0xe8, 0xc8, 0xf2, 0x02, 0xcd, 0x43, 0x59,
-	0x85, 0x44, 0x27, 0xe8, 0x15, 0xbd, 0xf1, 0xf1, 0x44, 0x70, 0xa5, 0x32, 0xf6, 0x5f, 0x6c, 0x8d,
-	0x51, 0x40, 0xa8, 0x62, 0xe5, 0x8c, 0x26, 0xa8, 0x62, 0xd1, 0x3f, 0x7c, 0xb4, 0xe2, 0xa6, 0x82,
-	0x73, 0x3e, 0xef, 0xdf, 0x9c, 0xaf, 0x76, 0xf5, 0x7c, 0xb8, 0x87, 0x56, 0x5c, 0x9b, 0xdc, 0xd0,
-	0xd1, 0x5d, 0xb6, 0x4d, 0x9b, 0x95, 0xb9, 0x6b, 0xc6, 0x13, 0xf4, 0x5a, 0x79, 0x3b, 0x68, 0x51,
-	0xbb, 0x85, 0xb4, 0xba, 0x02, 0xad, 0xeb, 0xff, 0x1c, 0x5d, 0xcb, 0x56, 0xb0, 0xda, 0x5e, 0x53,
-	0x37, 0xad, 0xc6, 0x4f, 0xd0, 0x46, 0x29, 0xfe, 0x44, 0xa4, 0x8a, 0x2d, 0xf4, 0xd6, 0x3f, 0x9b,
-	0xde, 0x0d, 0x75, 0xe3, 0x72, 0x57, 0x31, 0xec, 0xd8, 0x3f, 0x1a, 0x0c, 0xad, 0xe2, 0xc6, 0x4b,
-	0x2a, 0x5e, 0x5e, 0x8e, 0x7f, 0x88, 0x5e, 0x5f, 0x3a, 0xb1, 0xa3, 0xb9, 0xf9, 0xd9, 0x34, 0xbf,
-	0xae, 0x6e, 0x5e, 0x1f, 0xbd, 0x85, 0x5a, 0x55, 0x85, 0xbc, 0xb9, 0xce, 0x44, 0x3f, 0x29, 0xdf,
-	0x2a, 0x6e, 0x21, 0x07, 0xd9, 0x5e, 0x96, 0xf1, 0xb9, 0x7d, 0x14, 0xd7, 0x29, 0x10, 0xff, 0x73,
-	0x6f, 0xda, 0x40, 0x8d, 0x5e, 0xac, 0xff, 0x1f, 0x31, 0x73, 0x59, 0x83, 0x6a, 0x2a, 0xca, 0x6c,
-	0x54, 0xda, 0x52, 0x09, 0x93, 0xec, 0x5e, 0x46, 0xa5, 0xac, 0x1a, 0x76, 0x33, 0x36, 0x24, 0xde,
-	0x45, 0x68, 0x20, 0x52, 0x2e, 0xcc, 0x33, 0xd8, 0x0c, 0xa0, 0x6f, 0x5c, 0x99, 0x45, 0xc4, 0x88,
-	0xc6, 0xcc, 0x4a, 0x5d, 0x94, 0x43, 0x5c, 0x51, 0xad, 0x8a, 0x1e, 0x22, 0x7c, 0xbd, 0xb2, 0x43,
-	0xdf, 0x1c, 0xd0, 0x31, 0x83, 0x0e, 0x6f, 0xfb, 0x71, 0x58, 0x58, 0x7a, 0x61, 0x39, 0xf3, 0x06,
-	0xb2, 0x96, 0x3b, 0x40, 0x1b, 0x37, 0xef, 0x09, 0x76, 0x82, 0xe1, 0xa0, 0xec, 0xeb, 0xfa, 0x7f,
-	0x1b, 0xd0, 0x6f, 0xf9, 0x36, 0x9f, 0x42, 0x7b, 0xa6, 0x8b, 0xe8, 0x97, 0x9e, 0x35, 0x80, 0x9d,
-	0x07, 0x61, 0x6c, 0xdb, 0x67, 0x23, 0x3a, 0xcb, 0x54, 0x2f, 0x76, 0x1e, 0x51, 0xab, 0x89, 0x0b,
-	0x82, 0x54, 0x4f, 0xc4, 0x93, 0x54, 0xb1, 0x58, 0xcd, 0x04, 0x2b, 0xdf, 0x07, 0xab, 0xd4, 0x05,
-	0xe1, 0xf0, 0x0f, 0x33, 0x3a, 0x96, 0xf6, 0xa9, 0x50, 0x1f, 0x01, 0x81, 0xbf, 0x86, 0x42, 0x98,
-	0xd0, 0x68, 0x96, 0x49, 0x9b, 0x70, 0x4b, 0x73, 0xa9, 0x61, 0x95, 0x8f, 0x14, 0x69, 0x25, 0xa3,
-	0x14, 0xad, 0xbb, 0xe7, 0xec, 0x89, 0x31, 0xa8, 0xef, 0xe7, 0x09, 0x7b, 0x6a, 0x2b, 0x7c, 0x3d,
-	0x05, 0x02, 0xd0, 0x0f, 0xab, 0xf9, 0x2e, 0xb0, 0xf3, 0x1d, 0xd8, 0x40, 0xa3, 0xc7, 0x73, 0x6e,
-	0xcb, 0x52, 0x78, 0x6e, 0x69, 0xbc, 0x86, 0x6a, 0x47, 0x85, 0x7d, 0x49, 0xd7, 0x78, 0x11, 0xfd,
-	0xbc, 0xb2, 0x89, 0xd9, 0x1c, 0x54, 0xea, 0x89, 0xcb, 0xbe, 0x9d, 0xeb, 0x7a, 0x2c, 0x37, 0x21,
-	0x55, 0x75, 0x48, 0x1d, 0x52, 0xda, 0x36, 0x6f, 0xa0, 0x90, 0x09, 0x91, 0x73, 0xc1, 0x6c, 0xe9,
-	0x3d, 0xb8, 0x45, 0x2a, 0x04, 0xef, 0x38, 0xff, 0xc3, 0xb4, 0xef, 0xbf, 0x76, 0x7d, 0x22, 0xef,
-	0x89, 0xf2, 0x09, 0xa3, 0x5f, 0x5b, 0xbb, 0x08, 0x85, 0x0f, 0x60, 0x31, 0x61, 0x2a, 0xfa, 0x3a,
-	0x5a, 0x5d, 0x9a, 0x7b, 0xc1, 0x0f, 0x8f, 0xde, 0xd9, 0xa3, 0xf1, 0x84, 0x0d, 0xe3, 0x09, 0x9b,
-	0xd2, 0xd2, 0x5b, 0x99, 0x0b, 0xee, 0xfe, 0xd4, 0x7b, 0xf6, 0xbc, 0x7b, 0xeb, 0xcf, 0xcf, 0xbb,
-	0xb7, 0xfe, 0xf9, 0xbc, 0xeb, 0xfd, 0xf8, 0xb2, 0xeb, 0xfd, 0xfa, 0xb2, 0xeb, 0xfd, 0xf6, 0xb2,
-	0xeb, 0xfd, 0xfe, 0xb2, 0xeb, 0x3d, 0xbb, 0xec, 0x7a, 0x7f, 0xba, 0xec, 0x7a, 0x7f, 0xbf, 0xec,
-	0x7a, 0x1f, 0x7d, 0xfc, 0x92, 0x7f, 0x73, 0x0a, 0xd3, 0xfe, 0x76, 0xce, 0x53, 0xa1, 0x1c, 0x56,
-	0x71, 0x36, 0xbe, 0xf6, 0x0f, 0x28, 0xdc, 0xf4, 0xb4, 0xa1, 0xe9, 0x77, 0xfe, 0x15, 0x00, 0x00,
-	0xff, 0xff, 0x63, 0x45, 0x7b, 0x17, 0x4f, 0x15, 0x00, 0x00,
+	// 2308 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0xcb, 0x73, 0x1c, 0x47,
+	0x19, 0xf7, 0x68, 0x66, 0x77, 0x66, 0x7b, 0x25, 0xd9, 0xee, 0x24, 0xce, 0x60, 0x52, 0x8a, 0x32,
+	0xa4, 0x40, 0x80, 0x91, 0x0a, 0x87, 0x47, 0x48, 0x80, 0xaa, 0x95, 0x64, 0x47, 0xaa, 0x58, 0xd6,
+	0xd2, 0xb2, 0x62, 0xc8, 0x21, 0x55, 0xad, 0x99, 0xde, 0xdd, 0x8e, 0x66, 0xa7, 0x87, 0xee, 0x5e,
+	0xad, 0x95, 0x13, 0xdc, 0xb8, 0x73, 0xe0, 0xcc, 0x85, 0xc7, 0x7f, 0x40, 0x71, 0xe2, 0x46, 0x8a,
+	0x13, 0xc7, 0x54, 0x51, 0x45, 0x61, 0xdd, 0xb9, 0x73, 0xa4, 0xbe, 0xee, 0x9e, 0xdd, 0x5e, 0x4b,
+	0x86, 0x18, 0x4e, 0x3b, 0xdf, 0xef, 0x7b, 0xf4, 0xe3, 0x7b, 0xf6, 0xa2, 0xc3, 0x21, 0xd7, 0xa3,
+	0xc9, 0xc9, 0x66, 0x2e, 0xc6, 0x5b, 0xa7, 0x54, 0xd3, 0x6f, 0xe4, 0xa2, 0xd2, 0x94, 0x57, 0x4c,
+	0xaa, 0x4b, 0xb4, 0x92, 0xf9, 0x16, 0x1d, 0xb2, 0x4a, 0x6f, 0xd5, 0x52, 0x68, 0x91, 0x8b, 0x52,
+	0xd9, 0x2f, 0xb5, 0x25, 0x72, 0xbe, 0x69, 0x3e, 0x71, 0x34, 0x94, 0x75, 0x7e, 0x3b, 0x1b, 0x8a,
+	0xa1, 0xb0, 0xcc, 0x93, 0xc9, 0x60, 0x0b, 0x28, 0x43, 0x98, 0x2f, 0x2b, 0x99, 0xfd, 0x39, 0x44,
+	0xd1, 0x51, 0xcd, 0x72, 0x9c, 0xa2, 0xf8, 0x03, 0x26, 0x15, 0x17, 0x55, 0x1a, 0xac, 0x07, 0x1b,
+	0x1d, 0x12, 0x9f, 0x59, 0x12, 0x7f, 0x05, 0xc5, 0x7d, 0x29, 0x72, 0xa6, 0x54, 0xba, 0xb4, 0x1e,
+	0x6c, 0x74, 0xef, 0xae, 0x6c, 0x82, 0xf9, 0x4d, 0x07, 0x92, 0xb8, 0xb6, 0x1f, 0x78, 0x0d, 0x45,
+	0x44, 0x08, 0x9d, 0x86, 0x46, 0x0a, 0x59, 0x29, 0x40, 0x48, 0x24, 0x85, 0xd0, 0xf8, 0x36, 0x4a,
+	0xf6, 0x84, 0xd2, 0x15, 0x1d, 0xb3, 0x34, 0x32, 0x6b, 0x24, 0x23, 0x47, 0xe3, 0xaf, 0xa2, 0xf6,
+	0x81, 0x98, 0x54, 0x5a, 0xa5, 0xad, 0xf5, 0x70, 0xa3, 0x7b, 0xb7, 0x6b, 0xb5, 0x0d, 0xb6, 0x1d,
+	0x7d, 0xfa, 0xf7, 0xd7, 0xaf, 0x91, 0xf6, 0xd8, 0x08, 0xe0, 0x37, 0x50, 0x6b, 0x4f, 0x88, 0x53,
+	0x95, 0xb6, 0xd7, 0x83, 0xb9, 0xa4, 0x81, 0x48, 0x6b, 0x04, 0x3f, 0xf8, 0x07, 0xa8, 0xdb, 0xab,
+	0x2a, 0xa1, 0xa9, 0xe6, 0xa2, 0x52, 0x69, 0x6c, 0x4c, 0x7e, 0xd1, 0x0a, 0xc2, 0x69, 0x37, 0x3d,
+	0xee, 0xbd, 0x4a, 0xcb, 0x73, 0xd2, 0xa5, 0x73, 0x04, 0x56, 0x78, 0xc0, 0xab, 0xc9, 0x93, 0x34,
+	0xf1, 0x57, 0x30, 0x10, 0x69, 0x95, 0xf0, 0x03, 0x97, 0x72, 0x24, 0x4a, 0x2a, 0xb9, 0x4a, 0x3b,
+	0xfe, 0xa5, 0x38, 0x90, 0xc4, 0xca, 0x7e, 0x80, 0xe0, 0x63, 0x5e, 0x15, 0x62, 0xaa, 0x52, 0xe4,
+	0x0b, 0x3a, 0x90, 0xc4, 0x53, 0xfb, 0x71, 0xfb, 0x87, 0xe8, 0xc6, 0xb3, 0xbb, 0xc2, 0x37, 0x50,
+	0x78, 0xca, 0xce, 0x9d, 0x43, 0xe0, 0x13, 0xbf, 0x8c, 0x5a, 0x67, 0xb4, 0x9c, 0x30, 0xe3, 0x8a,
+	0x0e, 0xb1, 0xc4, 0x3b, 0x4b, 0x6f, 0x07, 0xd9, 0x1f, 0xc3, 0x99, 0x9f, 0xe0, 0xa6, 0x1f, 0x31,
+	0x39, 0xe6, 0x15, 0x2d, 0x8d, 0x72, 0x42, 0x12, 0xed, 0x68, 0xfc, 0x75, 0xd4, 0xdd, 0x11, 0x95,
+	0x12, 0x25, 0x3b, 0xe2, 0x9f, 0x30, 0xe7, 0xd2, 0x8e, 0xdd, 0xd4, 0xb6, 0x78, 0x42, 0xba, 0xf9,
+	0x9c, 0x8b, 0xdf, 0x44, 0xd1, 0xb1, 0x62, 0x72, 0xd1, 0xa5, 0x80, 0x38, 0x9f, 0x44, 0x13, 0xc5,
+	0x24, 0xc6, 0x28, 0xea, 0xc9, 0xa1, 0x4a, 0xa3, 0xf5, 0x70, 0xa3, 0x43, 0x22, 0x2a, 0x87, 0x0a,
+	0xb6, 0x7e, 0xaf, 0x3a, 0x33, 0xde, 0xec, 0x90, 0x90, 0x55, 0x67, 0x80, 0xec, 0x4c, 0x0b, 0xe3,
+	0xb5, 0x0e, 0x09, 0xf3, 0x69, 0x81, 0xdf, 0x45, 0xcb, 0x3b, 0xb4, 0xa6, 0x27, 0xbc, 0xe4, 0x9a,
+	0x33, 0xf0, 0x13, 0xac, 0xf2, 0xaa, 0x77, 0xdd, 0x3e, 0x9b, 0x2c, 0xe7, 0x1e, 0x85, 0xbf, 0x89,
+	0x62, 0x52, 0xf2, 0x31, 0xd7, 0x2a, 0x4d, 0x8c, 0x7f, 0x6f, 0xba, 0xb0, 0x3c, 0x3c, 0xda, 0xff,
+	0xb1, 0xe5, 0xb8, 0x4d, 0xc6, 0xd2, 0xca, 0xe1, 0x0d, 0x74, 0xfd, 0xa1, 0x78, 0xc8, 0xa6, 0x7d,
+	0xc9, 0xcf, 0x78, 0xc9, 0x86, 0xcc, 0x3a, 0x2f, 0x21, 0xd7, 0xab, 0x45, 0x18, 0x24, 0x7b, 0x75,
+	0x4d, 0xe5, 0x58, 0xc8, 0xbe, 0x14, 0x03, 0x5e, 0x32, 0xe3, 0xbd, 0x0e, 0xb9, 0x4e, 0x17, 0x61,
+	0xbc, 0x8e, 0xba, 0x87, 0x87, 0x07, 0x47, 0xb9, 0x90, 0xac, 0x57, 0x7c, 0x9c, 0x76, 0xd7, 0x83,
+	0x8d, 0x90, 0x74, 0xc5, 0x1c, 0xc2, 0x19, 0x5a, 0x3e, 0x62, 0x26, 0x6a, 0x1e, 0xd0, 0x13, 0x56,
+	0xa6, 0xcb, 0xc6, 0xd0, 0xb2, 0xf2, 0xb0, 0xec, 0x2d, 0x14, 0x6e, 0x8b, 0x27, 0xf8, 0x16, 0x6a,
+	0xef, 0x31, 0x3e, 0x1c, 0x69, 0xe3, 0xb5, 0x15, 0xd2, 0x1e, 0x19, 0x0a, 0xbc, 0xfe, 0x98, 0x17,
+	0x7a, 0x64, 0xbc, 0xb5, 0x42, 0x5a, 0x53, 0x20, 0xb2, 0xca, 0x3a, 0x07, 0x2e, 0xf6, 0x78, 0x7f,
+	0xd7, 0xa9, 0x84, 0x93, 0xfd, 0x5d, 0x40, 0xde, 0xdb, 0xdf, 0x75, 0xd2, 0xe1, 0x70, 0x7f, 0x17,
+	0x7f, 0x19, 0xad, 0xf6, 0x8a, 0x82, 0x43, 0x6c, 0xd1, 0xf2, 0x3d, 0x5e, 0xa8, 0x34, 0x5c, 0x0f,
+	0x37, 0x56, 0xc8, 0x2a, 0x5d, 0x40, 0x21, 0x72, 0xc0, 0xa6, 0x9f, 0xa3, 0x13, 0x47, 0x67, 0xbf,
+	0x0d, 0xd0, 0xcd, 0x4b, 0x5e, 0x01, 0x8d, 0x6d, 0x31, 0xa9, 0x0a, 0x5e, 0x0d, 0xd3, 0xc0, 0x78,
+	0x3b, 0x39, 0x71, 0x34, 0x7e, 0x0d, 0x75, 0xee, 0x0d, 0x06, 0x2c, 0xd7, 0xfc, 0x0c, 0x22, 0x0d,
+	0x98, 0x1d, 0xd6, 0x00, 0x70, 0x75, 0xfb, 0xd5, 0x88, 0x49, 0xae, 0xe9, 0x49, 0xc9, 0xcc, 0x86,
+	0x3a, 0xa4, 0xcb, 0xe7, 0x10, 0xe8, 0xf7, 0x21, 0x6e, 0xb5, 0x66, 0x85, 0x8b, 0xae, 0x4e, 0xdd,
+	0x00, 0x50, 0xb2, 0x7a, 0xe3, 0x13, 0xce, 0x2a, 0xed, 0xc2, 0x2c, 0xa6, 0x96, 0xcc, 0xf6, 0x51,
+	0xd7, 0x0b, 0x03, 0x88, 0xcf, 0x47, 0xe7, 0x35, 0x73, 0x79, 0x14, 0xe9, 0xf3, 0x9a, 0x01, 0xb6,
+	0x47, 0x65, 0x61, 0xee, 0x28, 0x22, 0xd1, 0x88, 0xca, 0x02, 0xb0, 0x23, 0x31, 0xb0, 0x05, 0x2c,
+	0x22, 0x91, 0x12, 0x03, 0x9d, 0x09, 0xd4, 0x32, 0x45, 0x08, 0x76, 0x5b, 0x30, 0xa5, 0x79, 0x65,
+	0x12, 0xd4, 0xd9, 0xf2, 0x21, 0xf0, 0x9e, 0x12, 0x13, 0x99, 0x37, 0xc9, 0xe9, 0x28, 0x30, 0x0b,
+	0x4b, 0xa6, 0xa1, 0xb7, 0x7c, 0x8a, 0x62, 0x51, 0xdb, 0xea, 0x64, 0xcf, 0xd5, 0x90, 0xd9, 0x77,
+	0x6c, 0x15, 0x05, 0xad, 0x3e, 0xd5, 0xa3, 0x66, 0xd3, 0x35, 0xd5, 0x23, 0xb8, 0x6b, 0xc2, 0x68,
+	0x21, 0xaa, 0xf2, 0xdc, 0xac, 0x91, 0x90, 0x44, 0x3a, 0x3a, 0xfb, 0x65, 0xe0, 0xea, 0x22, 0xbe,
+	0x83, 0x92, 0xbe, 0x64, 0x4a, 0x53, 0xa9, 0x8d, 0x47, 0x66, 0x89, 0x0b, 0x6c, 0x97, 0x13, 0x49,
+	0xed, 0x24, 0xf0, 0x26, 0xea, 0xf4, 0x85, 0xd2, 0x56, 0x7c, 0xe9, 0x39, 0xe2, 0x9d, 0xba, 0x11,
+	0x31, 0xd6, 0x0d, 0x21, 0xea, 0x34, 0x7c, 0x8e, 0x78, 0x52, 0x3b, 0x89, 0xec, 0x43, 0x14, 0x01,
+	0x7e, 0xe5, 0x69, 0x9a, 0xb2, 0xb1, 0x74, 0xb9, 0x6c, 0x84, 0xf3, 0xb2, 0x91, 0xa2, 0xf8, 0x11,
+	0x1f, 0x33, 0x31, 0xd1, 0x26, 0x20, 0x43, 0x12, 0x6b, 0x4b, 0x66, 0xbf, 0x6f, 0xb9, 0x3a, 0x8d,
+	0xbf, 0x8f, 0xba, 0xc7, 0xfb, 0xbb, 0x07, 0xb4, 0xae, 0x79, 0x35, 0x54, 0xee, 0xd0, 0x2f, 0x7b,
+	0x75, 0x64, 0xc6, 0x74, 0x1b, 0xec, 0x4e, 0xe6, 0xe2, 0xa0, 0xfd, 0x9e, 0xa7, 0xbd, 0xf4, 0xdf,
+	0xb5, 0x87, 0x9e, 0xf6, 0x16, 0x6a, 0x1f, 0x9d, 0xab, 0x5c, 0x97, 0xee, 0x36, 0xfc, 0xf2, 0xb5,
+	0x69, 0x39, 0xb6, 0xc5, 0xb4, 0x95, 0x21, 0xf0, 0x5d, 0xd4, 0x21, 0xcc, 0x86, 0x86, 0x32, 0x47,
+	0x5a, 0x5c, 0x6c, 0xc6, 0x23, 0x1d, 0xd9, 0x7c, 0x42, 0xf0, 0xed, 0x0c, 0xa5, 0x98, 0xd4, 0xca,
+	0xdc, 0x62, 0xcb, 0x06, 0x5f, 0x3e, 0x87, 0xf0, 0x3b, 0x08, 0x3d, 0xa4, 0x63, 0xa6, 0x6a, 0x0a,
+	0x66, 0xdb, 0x97, 0xce, 0x30, 0x63, 0xba, 0x33, 0xa0, 0x6a, 0x26, 0x0d, 0xa5, 0x74, 0x97, 0x9d,
+	0xf1, 0x9c, 0x35, 0xad, 0xf2, 0xa6, 0xa7, 0x68, 0x39, 0x4d, 0x29, 0x2d, 0xac, 0x1c, 0xbe, 0x83,
+	0xe2, 0x23, 0x96, 0xe7, 0x62, 0x5c, 0xbb, 0x26, 0x89, 0x3d, 0x15, 0xc7, 0x21, 0xb1, 0xb2, 0x1f,
+	0xf8, 0x0e, 0xba, 0x09, 0x31, 0x3d, 0x50, 0x7d, 0x29, 0x6a, 0x3a, 0xb4, 0x19, 0xd4, 0x31, 0x87,
+	0xb8, 0x29, 0x9f, 0x65, 0xc0, 0x61, 0x0f, 0xa8, 0x3a, 0x65, 0x05, 0x1c, 0x0c, 0xda, 0xa6, 0xa9,
+	0x0b, 0xe3, 0x39, 0x84, 0xdf, 0x44, 0x2b, 0x4d, 0x1e, 0x58, 0x99, 0xae, 0x91, 0x59, 0x91, 0x3e,
+	0x88, 0xd7, 0x10, 0x32, 0xa9, 0xeb, 0x97, 0x5d, 0x34, 0x9e, 0x21, 0x78, 0x0b, 0x25, 0xfb, 0x95,
+	0x66, 0x25, 0x29, 0x74, 0xba, 0x62, 0x0e, 0xf1, 0x92, 0xef, 0x74, 0xc7, 0x22, 0x09, 0x77, 0x5f,
+	0xb7, 0xbf, 0x87, 0xba, 0x9e, 0x43, 0x5f, 0xa8, 0x3b, 0xbf, 0x3e, 0x1b, 0x03, 0x40, 0xa8, 0x98,
+	0x8c, 0xc7, 0x8d, 0xa2, 0x25, 0x40, 0xc0, 0xcd, 0x0e, 0xcf, 0x11, 0xf8, 0x08, 0xad, 0x2e, 0x06,
+	0xa3, 0xe9, 0x16, 0x42, 0xe9, 0x59, 0xe9, 0x6f, 0x8f, 0x0c, 0x65, 0x82, 0xa5, 0x19, 0x18, 0x67,
+	0x5d, 0xa0, 0x9b, 0xcf, 0x21, 0x53, 0xe8, 0xf8, 0x27, 0xb6, 0x22, 0xad, 0x90, 0x48, 0xf1, 0x4f,
+	0x58, 0xf6, 0x36, 0x5a, 0x5d, 0x0c, 0x94, 0xe7, 0x95, 0x4d, 0x13, 0x81, 0x4b, 0xf3, 0x3c, 0xce,
+	0x7e, 0x1d, 0xa0, 0xae, 0x17, 0x2a, 0xcf, 0xcb, 0x75, 0x63, 0x6b, 0xc9, 0xb3, 0xf5, 0x32, 0x6a,
+	0x1d, 0xd0, 0x8f, 0x85, 0x9d, 0x2e, 0x42, 0xd2, 0x1a, 0x03, 0x61, 0x50, 0x5e, 0x09, 0xe9, 0xb2,
+	0xbd, 0x35, 0x06, 0x02, 0x2a, 0xdf, 0x7d, 0x5e, 0xb2, 0x03, 0x51, 0x30, 0x13, 0xfd, 0x2b, 0x24,
+	0x19, 0x38, 0xba, 0xe9, 0x7f, 0xed, 0x4b, 0xfd, 0x2f, 0x9e, 0xf5, 0xbf, 0xec, 0xb3, 0x10, 0xad,
+	0x2e, 0xa6, 0x17, 0xfe, 0xee, 0x3c, 0xea, 0x83, 0x4b, 0x99, 0x6b, 0x39, 0x36, 0xe7, 0x9e, 0x8d,
+	0x7d, 0x98, 0x55, 0xd9, 0x58, 0xc8, 0x73, 0x37, 0x3c, 0xf9, 0xd9, 0x62, 0x19, 0xa4, 0x3d, 0x36,
+	0xbf, 0x78, 0x1d, 0x85, 0x3b, 0xfd, 0x63, 0x37, 0x3e, 0xad, 0xfa, 0x83, 0x4d, 0xff, 0x98, 0x84,
+	0x79, 0xff, 0x18, 0x7f, 0x09, 0x45, 0x7d, 0x68, 0xc7, 0xb6, 0x10, 0x5c, 0xf7, 0x44, 0x00, 0x26,
+	0x51, 0x0d, 0x5d, 0xf9, 0x0e, 0x8a, 0xb7, 0x4b, 0x91, 0x9f, 0xee, 0x1f, 0xa6, 0xad, 0x4b, 0xd9,
+	0xe6, 0x38, 0x24, 0x3e, 0xb1, 0x1f, 0xf8, 0x3e, 0x5a, 0xdd, 0x9b, 0x0c, 0x59, 0x4d, 0x87, 0xec,
+	0x81, 0x1d, 0x90, 0x6c, 0x39, 0x48, 0x3d, 0xa5, 0x05, 0x01, 0x77, 0xc0, 0xd5, 0xd1, 0x82, 0x16,
+	0xac, 0xfa, 0x90, 0xe9, 0xa9, 0x90, 0xa7, 0x69, 0x7c, 0x69, 0x55, 0xc7, 0x21, 0x71, 0x65, 0x3f,
+	0xf0, 0xbb, 0x28, 0x3e, 0xae, 0xf8, 0x80, 0xb3, 0xc2, 0xcd, 0x63, 0x6f, 0x5c, 0x55, 0xd4, 0x36,
+	0x9d, 0x8c, 0x2d, 0x89, 0xf1, 0xc4, 0x52, 0xb7, 0xdf, 0x41, 0xcb, 0x3e, 0xe3, 0x85, 0x52, 0xeb,
+	0x6f, 0x4d, 0xf8, 0xd9, 0xbb, 0x07, 0x49, 0x73, 0x00, 0xa3, 0x1d, 0x92, 0x96, 0x9d, 0x01, 0xd6,
+	0x51, 0x97, 0x30, 0xc5, 0xe4, 0x99, 0x2d, 0x3e, 0x4b, 0x86, 0xd7, 0x95, 0x73, 0xc8, 0x24, 0xc5,
+	0x94, 0xd6, 0x2e, 0x1a, 0x23, 0x35, 0xa5, 0x35, 0xa4, 0xd8, 0xfb, 0x4c, 0x56, 0xac, 0x74, 0xd1,
+	0xd8, 0x3e, 0x35, 0x14, 0x0c, 0x26, 0x16, 0x7f, 0xb4, 0xd3, 0x37, 0x2e, 0x09, 0x49, 0xe7, 0xb4,
+	0x01, 0xa0, 0xf0, 0x80, 0xa5, 0x9a, 0x57, 0xf0, 0x68, 0x6a, 0x9b, 0x69, 0x02, 0xa9, 0x19, 0x82,
+	0xbf, 0x86, 0x6e, 0xec, 0x72, 0x05, 0x13, 0xce, 0xe1, 0xe1, 0xc1, 0xfb, 0xbc, 0x2c, 0x99, 0x34,
+	0x37, 0x9c, 0x90, 0x1b, 0xc5, 0x33, 0x78, 0xf6, 0x97, 0x00, 0x25, 0x4d, 0xc4, 0xc0, 0x76, 0x8e,
+	0x46, 0x54, 0x9a, 0x88, 0x05, 0xa3, 0x6d, 0x65, 0x28, 0x38, 0xf2, 0x8f, 0x26, 0x42, 0x53, 0x77,
+	0xac, 0xd6, 0x4f, 0x81, 0x00, 0xe9, 0x3e, 0x93, 0x5c, 0x14, 0x6e, 0xa0, 0x69, 0xd7, 0x86, 0x82,
+	0xe1, 0x96, 0x30, 0x5a, 0x42, 0x1b, 0x25, 0x93, 0x0a, 0x7e, 0xdc, 0xe9, 0xae, 0xcb, 0x45, 0x18,
+	0xa6, 0xc6, 0x46, 0xd2, 0x59, 0x6a, 0x19, 0x4b, 0xab, 0x72, 0x01, 0x85, 0xab, 0xdb, 0xa9, 0x27,
+	0xca, 0xcd, 0xf6, 0x51, 0x5e, 0x4f, 0x14, 0x60, 0x07, 0x6c, 0x6c, 0x87, 0xfa, 0x0e, 0x89, 0xc6,
+	0x6c, 0xac, 0xb2, 0xa9, 0x1b, 0x20, 0x1f, 0x9b, 0xb1, 0xd6, 0x95, 0x8b, 0x59, 0x19, 0x08, 0xae,
+	0x2c, 0x03, 0x4b, 0x7e, 0x19, 0xb8, 0x85, 0xda, 0x56, 0xd7, 0x95, 0xae, 0xf6, 0xd4, 0x50, 0x70,
+	0xe3, 0x0f, 0x18, 0x1d, 0x38, 0x5e, 0x64, 0x78, 0xa8, 0x9c, 0x21, 0xd9, 0x31, 0x7a, 0xc9, 0x2c,
+	0xfc, 0x68, 0x24, 0x85, 0xd6, 0x25, 0xfb, 0x1f, 0x96, 0xc6, 0x28, 0x22, 0x54, 0xb3, 0x66, 0x38,
+	0x94, 0x54, 0xb3, 0xec, 0x9f, 0x21, 0x5a, 0xf6, 0x73, 0xd0, 0xdb, 0x5f, 0xf0, 0x1f, 0xf6, 0xb7,
+	0xf4, 0xec, 0xfe, 0x70, 0x0f, 0x2d, 0xfb, 0x77, 0x72, 0xc5, 0x28, 0xe1, 0xb3, 0x5d, 0xbe, 0x2e,
+	0x4f, 0xfd, 0x6b, 0x3c, 0x46, 0xaf, 0x34, 0xa7, 0x83, 0xde, 0xb8, 0x5d, 0x2b, 0x67, 0x2b, 0x32,
+	0xb6, 0xbe, 0xe0, 0xd9, 0x5a, 0xbc, 0x05, 0x67, 0xed, 0x15, 0x7d, 0x95, 0x36, 0x7e, 0x8c, 0x6e,
+	0x35, 0xe2, 0x8f, 0x25, 0xd7, 0x6c, 0x6e, 0xb7, 0xf5, 0xf9, 0xec, 0xde, 0xd2, 0x57, 0xaa, 0xfb,
+	0x86, 0x61, 0xc5, 0xfd, 0xc3, 0xfe, 0x91, 0x33, 0xdc, 0x7e, 0x41, 0xc3, 0x8b, 0xea, 0xf8, 0x27,
+	0xe8, 0xd5, 0x85, 0x1d, 0x7b, 0x96, 0xe3, 0xcf, 0x67, 0xf9, 0x55, 0x7d, 0xb5, 0x7e, 0xf6, 0x06,
+	0xea, 0xcc, 0x4a, 0xf3, 0xd5, 0x75, 0x26, 0xfb, 0x79, 0xf3, 0x48, 0xf2, 0x3b, 0x08, 0xc8, 0xf6,
+	0xca, 0x52, 0x4c, 0xdd, 0x6b, 0xbc, 0x45, 0x81, 0xf8, 0xbf, 0x9b, 0xe2, 0x2d, 0xd4, 0xee, 0xe5,
+	0xe6, 0x8f, 0x19, 0x3b, 0x10, 0xb6, 0xa9, 0xa1, 0xb2, 0x12, 0x2d, 0xfb, 0x35, 0x1a, 0x46, 0xe8,
+	0x9d, 0x92, 0x2a, 0x35, 0x9b, 0x14, 0xe2, 0xdc, 0x92, 0x78, 0x1b, 0xa1, 0xbe, 0xe4, 0x42, 0xda,
+	0xf7, 0xb7, 0x9d, 0x7c, 0x5f, 0x7b, 0x66, 0x08, 0x92, 0x03, 0x9a, 0x33, 0x27, 0x75, 0xde, 0x4c,
+	0x8f, 0xf5, 0x4c, 0x2b, 0xbb, 0x8f, 0xf0, 0xe5, 0x96, 0x02, 0x0d, 0xbb, 0x4f, 0x87, 0x0c, 0x46,
+	0x0b, 0x57, 0xc6, 0x93, 0xda, 0xd1, 0xf3, 0x9b, 0xb3, 0x8f, 0x2f, 0x77, 0x73, 0x7b, 0xe8, 0xd6,
+	0xd5, 0x6b, 0xc2, 0x3d, 0xc1, 0x54, 0xd2, 0x0c, 0x14, 0xe6, 0x0f, 0x23, 0xb0, 0xef, 0xf8, 0x2e,
+	0x9f, 0x12, 0xb7, 0xa7, 0xf3, 0xec, 0x37, 0x01, 0x5a, 0xf6, 0x07, 0x51, 0x98, 0x17, 0x77, 0xd9,
+	0x80, 0x4e, 0x4a, 0xdd, 0xcb, 0xbd, 0xd7, 0xdb, 0x4a, 0xe1, 0x83, 0x20, 0xd5, 0x93, 0xf9, 0x88,
+	0x6b, 0x96, 0xeb, 0x89, 0x64, 0xcd, 0xc3, 0x64, 0x85, 0xfa, 0x20, 0x6c, 0xfe, 0x7e, 0x49, 0x87,
+	0xca, 0xbd, 0x51, 0x5a, 0x03, 0x20, 0xf0, 0xb7, 0x50, 0x02, 0xa3, 0x21, 0x2d, 0x4b, 0xe5, 0x12,
+	0x6e, 0x61, 0x20, 0xb6, 0xac, 0xe6, 0x75, 0xa4, 0x9c, 0x64, 0xc6, 0xd1, 0x75, 0x7f, 0x9f, 0x3d,
+	0x39, 0x04, 0xf3, 0xfb, 0x55, 0xc1, 0x9e, 0xb8, 0x0a, 0xdf, 0xe2, 0x40, 0x00, 0xfa, 0xc1, 0xac,
+	0xfb, 0x45, 0xae, 0xfb, 0xc1, 0x1d, 0x18, 0xf4, 0xd1, 0x54, 0xb8, 0xb2, 0x94, 0x9c, 0x39, 0x1a,
+	0xaf, 0xa2, 0xa5, 0xc3, 0xda, 0x3d, 0xe1, 0x97, 0x44, 0x9d, 0xfd, 0x6a, 0x76, 0x27, 0x76, 0x71,
+	0x30, 0x69, 0x46, 0x3d, 0xf7, 0x68, 0x6f, 0x99, 0xf7, 0x80, 0x0d, 0xa9, 0x59, 0x87, 0x34, 0x21,
+	0x05, 0x14, 0x7e, 0x0d, 0x25, 0x4c, 0xca, 0x4a, 0x48, 0xe6, 0x4a, 0xef, 0xde, 0x35, 0x32, 0x43,
+	0xf0, 0x96, 0xf7, 0x07, 0x50, 0xf7, 0xee, 0x2b, 0x97, 0x9f, 0x02, 0x3d, 0xd9, 0xbc, 0x9d, 0xcc,
+	0x33, 0x6f, 0x1b, 0xa1, 0xe4, 0x1e, 0x28, 0x13, 0xa6, 0xb3, 0x6f, 0xa3, 0x95, 0x85, 0x81, 0x1b,
+	0xfc, 0xf0, 0xe0, 0xad, 0x1d, 0x9a, 0x8f, 0xd8, 0x51, 0x3e, 0x62, 0x63, 0xda, 0x78, 0xab, 0xf4,
+	0xc1, 0xed, 0x5f, 0x04, 0x9f, 0x3e, 0x5d, 0xbb, 0xf6, 0xd9, 0xd3, 0xb5, 0x6b, 0xff, 0x7a, 0xba,
+	0x16, 0xfc, 0xec, 0x62, 0x2d, 0xf8, 0xdd, 0xc5, 0x5a, 0xf0, 0x87, 0x8b, 0xb5, 0xe0, 0x4f, 0x17,
+	0x6b, 0xc1, 0xa7, 0x17, 0x6b, 0xc1, 0x5f, 0x2f, 0xd6, 0x82, 0x7f, 0x5c, 0xac, 0x05, 0x1f, 0x7e,
+	0xf4, 0x82, 0xff, 0xaf, 0x4a, 0xdb, 0xfe, 0xb6, 0xce, 0xb8, 0xd4, 0x1e, 0xab, 0x3e, 0x1d, 0x5e,
+	0xfa, 0xeb, 0x15, 0x4e, 0x7a, 0xd2, 0x36, 0xf4, 0x5b, 0xff, 0x1e, 0x00, 0x42, 0x57, 0x0d, 0xef,
+	0xc8, 0x15, 0x00, 0x00,
 }
 
 func (this *Spec) Equal(that interface{}) bool {
@@ -2474,6 +2480,14 @@ func (this *LinuxResources) Equal(that interface{}) bool {
 	if !this.Network.Equal(that1.Network) {
 		return false
 	}
+	if len(this.Unified) != len(that1.Unified) {
+		return false
+	}
+	for i := range this.Unified {
+		if this.Unified[i] != that1.Unified[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4204,6 +4218,25 @@ func (m *LinuxResources) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Unified) > 0 {
+		for k := range m.Unified {
+			v := m.Unified[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintOci(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintOci(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintOci(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
 	if m.Network != nil {
 		{
 			size, err := m.Network.MarshalToSizedBuffer(dAtA[:i])
@@ -5478,8 +5511,15 @@ func NewPopulatedLinuxResources(r randyOci, easy bool) *LinuxResources {
 	if r.Intn(5) != 0 {
 		this.Network = NewPopulatedLinuxNetwork(r, easy)
 	}
+	if r.Intn(5) != 0 {
+		v39 := r.Intn(10)
+		this.Unified = make(map[string]string)
+		for i := 0; i < v39; i++ {
+			this.Unified[randStringOci(r)] = randStringOci(r)
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedOci(r, 8)
+		this.XXX_unrecognized = randUnrecognizedOci(r, 9)
 	}
 	return this
 }
@@ -5575,43 +5615,43 @@ func NewPopulatedLinuxBlockIO(r randyOci, easy bool) *LinuxBlockIO {
 	this.Weight = uint32(r.Uint32())
 	this.LeafWeight = uint32(r.Uint32())
 	if r.Intn(5) != 0 {
-		v39 := r.Intn(5)
-		this.WeightDevice = make([]LinuxWeightDevice, v39)
-		for i := 0; i < v39; i++ {
-			v40 := NewPopulatedLinuxWeightDevice(r, easy)
-			this.WeightDevice[i] = *v40
+		v40 := r.Intn(5)
+		this.WeightDevice = make([]LinuxWeightDevice, v40)
+		for i := 0; i < v40; i++ {
+			v41 := NewPopulatedLinuxWeightDevice(r, easy)
+			this.WeightDevice[i] = *v41
 		}
 	}
 	if r.Intn(5) != 0 {
-		v41 := r.Intn(5)
-		this.ThrottleReadBpsDevice = make([]LinuxThrottleDevice, v41)
-		for i := 0; i < v41; i++ {
-			v42 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleReadBpsDevice[i] = *v42
+		v42 := r.Intn(5)
+		this.ThrottleReadBpsDevice = make([]LinuxThrottleDevice, v42)
+		for i := 0; i < v42; i++ {
+			v43 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleReadBpsDevice[i] = *v43
 		}
 	}
 	if r.Intn(5) != 0 {
-		v43 := r.Intn(5)
-		this.ThrottleWriteBpsDevice = make([]LinuxThrottleDevice, v43)
-		for i := 0; i < v43; i++ {
-			v44 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleWriteBpsDevice[i] = *v44
+		v44 := r.Intn(5)
+		this.ThrottleWriteBpsDevice = make([]LinuxThrottleDevice, v44)
+		for i := 0; i < v44; i++ {
+			v45 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleWriteBpsDevice[i] = *v45
 		}
 	}
 	if r.Intn(5) != 0 {
-		v45 := r.Intn(5)
-		this.ThrottleReadIOPSDevice = make([]LinuxThrottleDevice, v45)
-		for i := 0; i < v45; i++ {
-			v46 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleReadIOPSDevice[i] = *v46
+		v46 := r.Intn(5)
+		this.ThrottleReadIOPSDevice = make([]LinuxThrottleDevice, v46)
+		for i := 0; i < v46; i++ {
+			v47 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleReadIOPSDevice[i] = *v47
 		}
 	}
 	if r.Intn(5) != 0 {
-		v47 := r.Intn(5)
-		this.ThrottleWriteIOPSDevice = make([]LinuxThrottleDevice, v47)
-		for i := 0; i < v47; i++ {
-			v48 := NewPopulatedLinuxThrottleDevice(r, easy)
-			this.ThrottleWriteIOPSDevice[i] = *v48
+		v48 := r.Intn(5)
+		this.ThrottleWriteIOPSDevice = make([]LinuxThrottleDevice, v48)
+		for i := 0; i < v48; i++ {
+			v49 := NewPopulatedLinuxThrottleDevice(r, easy)
+			this.ThrottleWriteIOPSDevice[i] = *v49
 		}
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -5655,11 +5695,11 @@ func NewPopulatedLinuxNetwork(r randyOci, easy bool) *LinuxNetwork {
 	this := &LinuxNetwork{}
 	this.ClassID = uint32(r.Uint32())
 	if r.Intn(5) != 0 {
-		v49 := r.Intn(5)
-		this.Priorities = make([]LinuxInterfacePriority, v49)
-		for i := 0; i < v49; i++ {
-			v50 := NewPopulatedLinuxInterfacePriority(r, easy)
-			this.Priorities[i] = *v50
+		v50 := r.Intn(5)
+		this.Priorities = make([]LinuxInterfacePriority, v50)
+		for i := 0; i < v50; i++ {
+			v51 := NewPopulatedLinuxInterfacePriority(r, easy)
+			this.Priorities[i] = *v51
 		}
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -5691,22 +5731,22 @@ func NewPopulatedLinuxInterfacePriority(r randyOci, easy bool) *LinuxInterfacePr
 func NewPopulatedLinuxSeccomp(r randyOci, easy bool) *LinuxSeccomp {
 	this := &LinuxSeccomp{}
 	this.DefaultAction = string(randStringOci(r))
-	v51 := r.Intn(10)
-	this.Architectures = make([]string, v51)
-	for i := 0; i < v51; i++ {
-		this.Architectures[i] = string(randStringOci(r))
-	}
 	v52 := r.Intn(10)
-	this.Flags = make([]string, v52)
+	this.Architectures = make([]string, v52)
 	for i := 0; i < v52; i++ {
+		this.Architectures[i] = string(randStringOci(r))
+	}
+	v53 := r.Intn(10)
+	this.Flags = make([]string, v53)
+	for i := 0; i < v53; i++ {
 		this.Flags[i] = string(randStringOci(r))
 	}
 	if r.Intn(5) != 0 {
-		v53 := r.Intn(5)
-		this.Syscalls = make([]LinuxSyscall, v53)
-		for i := 0; i < v53; i++ {
-			v54 := NewPopulatedLinuxSyscall(r, easy)
-			this.Syscalls[i] = *v54
+		v54 := r.Intn(5)
+		this.Syscalls = make([]LinuxSyscall, v54)
+		for i := 0; i < v54; i++ {
+			v55 := NewPopulatedLinuxSyscall(r, easy)
+			this.Syscalls[i] = *v55
 		}
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -5729,9 +5769,9 @@ func NewPopulatedLinuxSeccompArg(r randyOci, easy bool) *LinuxSeccompArg {
 
 func NewPopulatedLinuxSyscall(r randyOci, easy bool) *LinuxSyscall {
 	this := &LinuxSyscall{}
-	v55 := r.Intn(10)
-	this.Names = make([]string, v55)
-	for i := 0; i < v55; i++ {
+	v56 := r.Intn(10)
+	this.Names = make([]string, v56)
+	for i := 0; i < v56; i++ {
 		this.Names[i] = string(randStringOci(r))
 	}
 	this.Action = string(randStringOci(r))
@@ -5741,11 +5781,11 @@ func NewPopulatedLinuxSyscall(r randyOci, easy bool) *LinuxSyscall {
 		this.ErrnoRet = NewPopulatedLinuxSyscall_Errnoret(r, easy)
 	}
 	if r.Intn(5) != 0 {
-		v56 := r.Intn(5)
-		this.Args = make([]LinuxSeccompArg, v56)
-		for i := 0; i < v56; i++ {
-			v57 := NewPopulatedLinuxSeccompArg(r, easy)
-			this.Args[i] = *v57
+		v57 := r.Intn(5)
+		this.Args = make([]LinuxSeccompArg, v57)
+		for i := 0; i < v57; i++ {
+			v58 := NewPopulatedLinuxSeccompArg(r, easy)
+			this.Args[i] = *v58
 		}
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -5787,9 +5827,9 @@ func randUTF8RuneOci(r randyOci) rune {
 	return rune(ru + 61)
 }
 func randStringOci(r randyOci) string {
-	v58 := r.Intn(100)
-	tmps := make([]rune, v58)
-	for i := 0; i < v58; i++ {
+	v59 := r.Intn(100)
+	tmps := make([]rune, v59)
+	for i := 0; i < v59; i++ {
 		tmps[i] = randUTF8RuneOci(r)
 	}
 	return string(tmps)
@@ -5811,11 +5851,11 @@ func randFieldOci(dAtA []byte, r randyOci, fieldNumber int, wire int) []byte {
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateOci(dAtA, uint64(key))
-		v59 := r.Int63()
+		v60 := r.Int63()
 		if r.Intn(2) == 0 {
-			v59 *= -1
+			v60 *= -1
 		}
-		dAtA = encodeVarintPopulateOci(dAtA, uint64(v59))
+		dAtA = encodeVarintPopulateOci(dAtA, uint64(v60))
 	case 1:
 		dAtA = encodeVarintPopulateOci(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -6406,6 +6446,14 @@ func (m *LinuxResources) Size() (n int) {
 		l = m.Network.Size()
 		n += 1 + l + sovOci(uint64(l))
 	}
+	if len(m.Unified) > 0 {
+		for k, v := range m.Unified {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovOci(uint64(len(k))) + 1 + len(v) + sovOci(uint64(len(v)))
+			n += mapEntrySize + 1 + sovOci(uint64(mapEntrySize))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -7112,6 +7160,16 @@ func (this *LinuxResources) String() string {
 		repeatedStringForHugepageLimits += strings.Replace(strings.Replace(f.String(), "LinuxHugepageLimit", "LinuxHugepageLimit", 1), `&`, ``, 1) + ","
 	}
 	repeatedStringForHugepageLimits += "}"
+	keysForUnified := make([]string, 0, len(this.Unified))
+	for k, _ := range this.Unified {
+		keysForUnified = append(keysForUnified, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForUnified)
+	mapStringForUnified := "map[string]string{"
+	for _, k := range keysForUnified {
+		mapStringForUnified += fmt.Sprintf("%v: %v,", k, this.Unified[k])
+	}
+	mapStringForUnified += "}"
 	s := strings.Join([]string{`&LinuxResources{`,
 		`Devices:` + repeatedStringForDevices + `,`,
 		`Memory:` + strings.Replace(this.Memory.String(), "LinuxMemory", "LinuxMemory", 1) + `,`,
@@ -7120,6 +7178,7 @@ func (this *LinuxResources) String() string {
 		`BlockIO:` + strings.Replace(this.BlockIO.String(), "LinuxBlockIO", "LinuxBlockIO", 1) + `,`,
 		`HugepageLimits:` + repeatedStringForHugepageLimits + `,`,
 		`Network:` + strings.Replace(this.Network.String(), "LinuxNetwork", "LinuxNetwork", 1) + `,`,
+		`Unified:` + mapStringForUnified + `,`,
 		`XXX_unrecognized:` + fmt.Sprintf("%v", this.XXX_unrecognized) + `,`,
 		`}`,
 	}, "")
@@ -10954,6 +11013,133 @@ func (m *LinuxResources) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unified", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOci
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOci
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOci
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Unified == nil {
+				m.Unified = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOci
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOci
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthOci
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthOci
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOci
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthOci
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthOci
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipOci(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthOci
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Unified[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOci(dAtA[iNdEx:])
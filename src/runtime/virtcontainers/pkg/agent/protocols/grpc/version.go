@@ -8,4 +8,4 @@ package grpc
 
 // APIVersion specifies the version of the gRPC communications protocol used
 // by Kata Containers.
-const APIVersion = "0.0.1"
+const APIVersion = "0.3.0"
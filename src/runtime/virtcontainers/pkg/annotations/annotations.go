@@ -21,6 +21,13 @@ const (
 	ContainerTypeKey = kataAnnotationsPrefix + "pkg.oci.container_type"
 
 	SandboxConfigPathKey = kataAnnotationsPrefix + "config_path"
+
+	// KataAnnotationsPrefix is the prefix of all the Kata annotations.
+	KataAnnotationsPrefix = kataAnnotationsPrefix
+
+	// KataAnnotationOCIPrefix is the prefix of the annotations set by the
+	// runtime itself, never taken from the pod annotations.
+	KataAnnotationOCIPrefix = kataAnnotationsPrefix + "pkg.oci."
 )
 
 // Annotations related to Hypervisor configuration
@@ -94,6 +101,13 @@ const (
 	// CPUFeatures is a sandbox annotation to specify cpu specific features.
 	CPUFeatures = kataAnnotHypervisorPrefix + "cpu_features"
 
+	// CPUModel is a sandbox annotation to specify the guest cpu model.
+	CPUModel = kataAnnotHypervisorPrefix + "cpu_model"
+
+	// MigrationIncoming is a sandbox annotation to specify that the VM waits
+	// for an incoming live migration.
+	MigrationIncoming = kataAnnotHypervisorPrefix + "migration_incoming"
+
 	// DisableVhostNet is a sandbox annotation to specify if vhost-net is not available on the host.
 	DisableVhostNet = kataAnnotHypervisorPrefix + "disable_vhost_net"
 
@@ -114,6 +128,32 @@ const (
 	// root bus instead of a bridge.
 	HotplugVFIOOnRootBus = kataAnnotHypervisorPrefix + "hotplug_vfio_on_root_bus"
 
+	// PreserveVFIOTopology is a sandbox annotation used to indicate if the cold plugged VFIO devices
+	// of a same host PCI root complex are plugged on a same guest root complex.
+	PreserveVFIOTopology = kataAnnotHypervisorPrefix + "preserve_vfio_topology"
+
+	// ColdPlugDevices is a sandbox annotation used to indicate if devices need to be cold plugged
+	// before the VM boots instead of being hot plugged.
+	ColdPlugDevices = kataAnnotHypervisorPrefix + "cold_plug_devices"
+
+	// ColdPlugDevicePaths is a sandbox annotation for passing a comma separated list of the host
+	// devices to cold plug, e.g. the devices of the pod containers which are not known at sandbox creation.
+	ColdPlugDevicePaths = kataAnnotHypervisorPrefix + "cold_plug_device_paths"
+
+	// VFIOAPDevices is a sandbox annotation for passing a comma separated list of the sysfs paths
+	// of the VFIO-AP mediated devices to attach to the sandbox, e.g. /sys/devices/vfio_ap/matrix/<uuid>.
+	VFIOAPDevices = kataAnnotHypervisorPrefix + "vfio_ap_devices"
+
+	// VFIOBindDevices is a sandbox annotation for passing a comma separated list of the sysfs paths
+	// of the PCI devices to bind to vfio-pci and attach to the sandbox, e.g. /sys/bus/pci/devices/0000:3b:00.0.
+	VFIOBindDevices = kataAnnotHypervisorPrefix + "vfio_bind_devices"
+
+	// VFIOMdevDevices is a sandbox annotation for passing a comma separated list of the sysfs paths
+	// of the mediated devices, e.g. /sys/bus/mdev/devices/<uuid>, or of the mediated device types to
+	// create a device of, e.g. /sys/class/mdev_bus/0000:3b:00.0/mdev_supported_types/nvidia-63,
+	// to attach to the sandbox.
+	VFIOMdevDevices = kataAnnotHypervisorPrefix + "vfio_mdev_devices"
+
 	// PCIeRootPort is used to indicate the number of PCIe Root Port devices
 	// The PCIe Root Port device is used to hot-plug the PCIe device
 	PCIeRootPort = kataAnnotHypervisorPrefix + "pcie_root_port"
@@ -122,6 +162,17 @@ const (
 	// entropy (/dev/random, /dev/urandom or real hardware RNG device)
 	EntropySource = kataAnnotHypervisorPrefix + "entropy_source"
 
+	// EntropyMaxBytes is a sandbox annotation to limit the number of bytes the guest can read
+	// from the entropy source per entropy period
+	EntropyMaxBytes = kataAnnotHypervisorPrefix + "entropy_max_bytes"
+
+	// EntropyPeriod is a sandbox annotation to specify the period of the entropy rate limit, in milliseconds
+	EntropyPeriod = kataAnnotHypervisorPrefix + "entropy_period"
+
+	// GuestRNGSeed is a sandbox annotation used to indicate if the guest random number generator
+	// is seeded with host entropy once the agent is up
+	GuestRNGSeed = kataAnnotHypervisorPrefix + "guest_rng_seed"
+
 	//
 	//	CPU Annotations
 	//
@@ -243,6 +294,53 @@ const (
 
 	// DisableNewNetNs is a sandbox annotation that determines if create a netns for hypervisor process.
 	DisableNewNetNs = kataAnnotRuntimePrefix + "disable_new_netns"
+
+	// SharePidNs is a sandbox annotation that determines if all the containers share a pid namespace,
+	// whose init process is started by the agent with the sandbox.
+	SharePidNs = kataAnnotRuntimePrefix + "share_pid_ns"
+
+	// VhostUserNetSockets is a sandbox annotation for passing a comma separated list of
+	// <interface>=<socket> pairs, the network interfaces backed by vhost-user sockets, e.g. from OVS-DPDK.
+	VhostUserNetSockets = kataAnnotRuntimePrefix + "vhost_user_net_sockets"
+)
+
+// Container related annotations
+const (
+	kataAnnotContainerPrefix = kataAnnotationsPrefix + "container."
+
+	// KataAnnotationContainerPrefix is the prefix of the container annotations.
+	KataAnnotationContainerPrefix = kataAnnotContainerPrefix
+
+	// LUKSVolumes is a container annotation for passing a comma separated list of
+	// <destination>=<key reference> pairs, the LUKS encrypted block volumes of the container,
+	// unlocked in the guest by the agent. A "file://" key reference is a path in the guest,
+	// other references are resolved by the agent.luks_key_helper command, e.g. from a key broker.
+	LUKSVolumes = kataAnnotContainerPrefix + "luks_volumes"
+
+	// ConfidentialEmptyDirs is a container annotation for passing a comma separated list of
+	// <emptyDir name>=<size> pairs, the Kubernetes emptyDir volumes of the container backed by
+	// a scratch disk of the given size, encrypted in the guest with an ephemeral key, instead
+	// of being shared from the host.
+	ConfidentialEmptyDirs = kataAnnotContainerPrefix + "confidential_empty_dirs"
+
+	// BlockVolumeClasses is a container annotation for passing a comma separated list of
+	// <destination>=<class> pairs, the block volume classes of the block volumes of the
+	// container, setting their cache, discard and detect-zeroes modes. The classes are
+	// defined by the hypervisor block_volume_classes configuration.
+	BlockVolumeClasses = kataAnnotContainerPrefix + "block_volume_classes"
+
+	// ContainerNetworkNS is a container annotation for passing the path of a host network
+	// namespace, configured e.g. by CNI, whose interfaces are given to the container only:
+	// they are hotplugged in the VM and moved to a network namespace of the container.
+	// It requires the "container_network" experimental feature.
+	ContainerNetworkNS = kataAnnotContainerPrefix + "network_ns"
+)
+
+// Sandbox label annotations
+const (
+	// KataAnnotationLabelPrefix is the prefix of the sandbox annotations setting its labels,
+	// io.katacontainers.label.<key>=<value> sets the <key> label when the sandbox is created.
+	KataAnnotationLabelPrefix = kataAnnotationsPrefix + "label."
 )
 
 // Agent related annotations
@@ -262,6 +360,11 @@ const (
 	//
 	KernelModules = kataAnnotAgentPrefix + "kernel_modules"
 
+	// GuestSysctls is a sandbox annotation adding sysctls set for the whole
+	// VM, semicolon separated "key=value" pairs, e.g.
+	// "vm.max_map_count=262144; net.core.rmem_max=8388608".
+	GuestSysctls = kataAnnotAgentPrefix + "guest_sysctls"
+
 	// AgentTrace is a sandbox annotation to enable tracing for the agent.
 	AgentTrace = kataAnnotAgentPrefix + "enable_tracing"
 
@@ -290,4 +393,13 @@ const (
 	// Supported suffixes are: Ki | Mi | Gi | Ti | Pi | Ei . For example: 4Mi
 	// For more information about supported suffixes see https://physics.nist.gov/cuu/Units/binary.html
 	SGXEPC = "sgx.intel.com/epc"
+
+	// IngressBandwidth is the pod annotation of the bandwidth CNI plugin
+	// limiting the rate of the traffic received by the sandbox, in bits/sec.
+	// It accepts the quantity suffixes, for example: 10M
+	IngressBandwidth = "kubernetes.io/ingress-bandwidth"
+
+	// EgressBandwidth is the pod annotation of the bandwidth CNI plugin
+	// limiting the rate of the traffic sent by the sandbox, in bits/sec.
+	EgressBandwidth = "kubernetes.io/egress-bandwidth"
 )
@@ -0,0 +1,107 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cgroups
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	libcontcgroups "github.com/opencontainers/runc/libcontainer/cgroups"
+	"golang.org/x/sys/unix"
+)
+
+// unifiedMountpoint is the mount point of the cgroup v2 hierarchy
+const unifiedMountpoint = "/sys/fs/cgroup"
+
+// clone3(CLONE_INTO_CGROUP) was added in Linux 5.7
+const (
+	cloneIntoCgroupKernelMajor = 5
+	cloneIntoCgroupKernelMinor = 7
+)
+
+// CloneIntoCgroupSupported tells if the processes can be started directly in
+// a cgroup, see CloneIntoCgroup: it requires cgroup v2, a kernel providing
+// clone3(CLONE_INTO_CGROUP) and a runtime built with go1.20 or later.
+var CloneIntoCgroupSupported = func() bool {
+	if !cloneIntoCgroupBuilt || !libcontcgroups.IsCgroup2UnifiedMode() {
+		return false
+	}
+
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return false
+	}
+
+	major, minor, err := parseKernelVersion(unix.ByteSliceToString(uts.Release[:]))
+	if err != nil {
+		cgroupsLogger.WithError(err).Warn("Could not parse the kernel version")
+		return false
+	}
+
+	return major > cloneIntoCgroupKernelMajor ||
+		(major == cloneIntoCgroupKernelMajor && minor >= cloneIntoCgroupKernelMinor)
+}
+
+// parseKernelVersion returns the major and minor versions of a kernel
+// release, e.g. 5.10.0-8-amd64.
+func parseKernelVersion(release string) (int, int, error) {
+	fields := strings.SplitN(release, ".", 3)
+	if len(fields) < 2 {
+		return 0, 0, fmt.Errorf("invalid kernel release %q", release)
+	}
+
+	major, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kernel release %q: %v", release, err)
+	}
+
+	// The minor version may be directly followed by the local version,
+	// e.g. 5.7-rc1
+	minor := fields[1]
+	if i := strings.IndexFunc(minor, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minor = minor[:i]
+	}
+	minorVersion, err := strconv.Atoi(minor)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid kernel release %q: %v", release, err)
+	}
+
+	return major, minorVersion, nil
+}
+
+// UnifiedPath returns the directory of the cgroup v2 of a cgroup path, as
+// returned by ValidCgroupPath. The systemd cgroup paths are not supported.
+func UnifiedPath(cgroupPath string) (string, error) {
+	if IsSystemdCgroup(cgroupPath) {
+		return "", fmt.Errorf("systemd cgroup path %q not supported", cgroupPath)
+	}
+
+	return filepath.Join(unifiedMountpoint, filepath.Clean("/"+cgroupPath)), nil
+}
+
+// CloneIntoCgroup returns the attributes of a process to be started directly
+// in the cgroup v2 directory path, created if needed, along with the opened
+// directory, which must be closed once the process is started.
+func CloneIntoCgroup(path string) (*syscall.SysProcAttr, *os.File, error) {
+	if !cloneIntoCgroupBuilt {
+		return nil, nil, fmt.Errorf("clone into cgroup is not supported by this build")
+	}
+
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, nil, err
+	}
+
+	dir, err := os.OpenFile(path, unix.O_DIRECTORY|unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cloneIntoCgroupAttr(int(dir.Fd())), dir, nil
+}
@@ -0,0 +1,19 @@
+// +build go1.20
+
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cgroups
+
+import "syscall"
+
+const cloneIntoCgroupBuilt = true
+
+func cloneIntoCgroupAttr(fd int) *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		UseCgroupFD: true,
+		CgroupFD:    fd,
+	}
+}
@@ -0,0 +1,17 @@
+// +build !go1.20
+
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cgroups
+
+import "syscall"
+
+// syscall.SysProcAttr.CgroupFD was added in go1.20
+const cloneIntoCgroupBuilt = false
+
+func cloneIntoCgroupAttr(fd int) *syscall.SysProcAttr {
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cgroups
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKernelVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, t := range []struct {
+		release string
+		major   int
+		minor   int
+		error   bool
+	}{
+		{"5.10.0-8-amd64", 5, 10, false},
+		{"5.7-rc1", 5, 7, false},
+		{"4.19.0", 4, 19, false},
+		{"6.1", 6, 1, false},
+		{"5", 0, 0, true},
+		{"", 0, 0, true},
+		{"a.b.c", 0, 0, true},
+		{"5.-rc1", 0, 0, true},
+	} {
+		major, minor, err := parseKernelVersion(t.release)
+		if t.error {
+			assert.Error(err, t.release)
+			continue
+		}
+		assert.NoError(err, t.release)
+		assert.Equal(t.major, major, t.release)
+		assert.Equal(t.minor, minor, t.release)
+	}
+}
+
+func TestUnifiedPath(t *testing.T) {
+	assert := assert.New(t)
+
+	path, err := UnifiedPath("/kata/afhts2e5d4g5s")
+	assert.NoError(err)
+	assert.Equal("/sys/fs/cgroup/kata/afhts2e5d4g5s", path)
+
+	path, err = UnifiedPath("kata/../afhts2e5d4g5s")
+	assert.NoError(err)
+	assert.Equal("/sys/fs/cgroup/afhts2e5d4g5s", path)
+
+	_, err = UnifiedPath("system.slice:kata:afhts2e5d4g5s")
+	assert.Error(err)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/opencontainers/runc/libcontainer"
 	libcontcgroups "github.com/opencontainers/runc/libcontainer/cgroups"
 	libcontcgroupsfs "github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	libcontcgroupsfs2 "github.com/opencontainers/runc/libcontainer/cgroups/fs2"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/specconv"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -52,6 +53,23 @@ type Manager struct {
 const (
 	// file in the cgroup that contains the pids
 	cgroupProcs = "cgroup.procs"
+	// file in the cgroup that contains the thread ids, cgroup v1
+	cgroupTasks = "tasks"
+	// file in the cgroup that contains the cpu weight, cgroup v1
+	cpuShares = "cpu.shares"
+	// file in the cgroup that contains the thread ids, cgroup v2
+	cgroupThreads = "cgroup.threads"
+	// file in the cgroup that contains its type, cgroup v2
+	cgroupType = "cgroup.type"
+	// type of the cgroups of a threaded subtree, cgroup v2
+	cgroupTypeThreaded = "threaded"
+	// file in the cgroup that enables the controllers of its children, cgroup v2
+	cgroupSubtreeControl = "cgroup.subtree_control"
+	// file in the cgroup that contains the cpu weight, cgroup v2
+	cpuWeight = "cpu.weight"
+
+	// MaxCPUWeight is the maximum cgroup v2 cpu.weight value
+	MaxCPUWeight = 10000
 )
 
 var (
@@ -165,11 +183,27 @@ func New(config *Config) (*Manager, error) {
 		}, nil
 	}
 
+	if UnifiedMode() {
+		mgr, err := libcontcgroupsfs2.NewManager(cgroups, "", rootless)
+		if err != nil {
+			return nil, fmt.Errorf("Could not create cgroup v2 manager: %v", err)
+		}
+
+		return &Manager{
+			mgr: mgr,
+		}, nil
+	}
+
 	return &Manager{
 		mgr: libcontcgroupsfs.NewManager(cgroups, cgroupPaths, rootless),
 	}, nil
 }
 
+// UnifiedMode tells if the host only has the cgroup v2 hierarchy.
+var UnifiedMode = func() bool {
+	return libcontcgroups.IsCgroup2UnifiedMode()
+}
+
 // read all the pids in cgroupPath
 func readPids(cgroupPath string) ([]int, error) {
 	pids := []int{}
@@ -206,6 +240,12 @@ func writePids(pids []int, cgroupPath string) error {
 	return nil
 }
 
+// CPUWeightToShares converts a cgroup v2 cpu.weight value to the cgroup v1
+// cpu.shares value with the same ratio to the defaults, 100 and 1024.
+func CPUWeightToShares(weight uint64) uint64 {
+	return weight * 1024 / 100
+}
+
 func (m *Manager) logger() *logrus.Entry {
 	return cgroupsLogger.WithField("source", "cgroup-manager")
 }
@@ -285,6 +325,13 @@ func (m *Manager) GetPaths() map[string]string {
 	return m.mgr.GetPaths()
 }
 
+// GetStats returns the statistics of the cgroups
+func (m *Manager) GetStats() (*libcontcgroups.Stats, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.mgr.GetStats()
+}
+
 func (m *Manager) Destroy() error {
 	// cgroup can't be destroyed if it contains running processes
 	if err := m.moveToParent(); err != nil {
@@ -355,3 +402,99 @@ func (m *Manager) SetCPUSet(cpuset, memset string) error {
 
 	return m.Apply()
 }
+
+// SetThreadGroup moves the threads tids into the child cgroup name of the cpu
+// cgroup, created if needed, and sets its cpu weight. The threads then only
+// compete for the cpu time of the cgroup with its other children and threads.
+// On cgroup v2 the child cgroup is a threaded one, the cgroup becoming the
+// domain of its threaded subtree.
+func (m *Manager) SetThreadGroup(name string, weight uint64, tids []int) error {
+	if rootless.IsRootless() {
+		m.logger().Debug("Unable to setup thread group: running rootless")
+		return nil
+	}
+
+	if weight == 0 || weight > MaxCPUWeight {
+		return fmt.Errorf("invalid cpu weight %d for thread group %s", weight, name)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if UnifiedMode() {
+		path, ok := m.mgr.GetPaths()[""]
+		if !ok || path == "" {
+			return errors.New("unified cgroup not found")
+		}
+
+		return setThreadGroupV2(path, name, weight, tids)
+	}
+
+	cpuPath, ok := m.mgr.GetPaths()["cpu"]
+	if !ok || cpuPath == "" {
+		return errors.New("cpu cgroup not found")
+	}
+
+	return setThreadGroupV1(cpuPath, name, weight, tids)
+}
+
+// setThreadGroupV1 sets up the thread group name in the cgroup v1 cpu cgroup
+// cpuPath, with the cpu.shares matching weight.
+func setThreadGroupV1(cpuPath, name string, weight uint64, tids []int) error {
+	groupPath := filepath.Join(cpuPath, name)
+	if err := os.MkdirAll(groupPath, 0755); err != nil {
+		return err
+	}
+
+	if err := writeCgroupFile(groupPath, cpuShares, strconv.FormatUint(CPUWeightToShares(weight), 10)); err != nil {
+		return err
+	}
+
+	return writeThreads(tids, filepath.Join(groupPath, cgroupTasks))
+}
+
+// setThreadGroupV2 sets up the thread group name in the cgroup v2 cgroup path.
+// The group is made threaded before the cpu controller is enabled for the
+// children of path: path has processes, only the threaded controllers can be
+// enabled for its children once it is the domain of a threaded subtree.
+func setThreadGroupV2(path, name string, weight uint64, tids []int) error {
+	groupPath := filepath.Join(path, name)
+	if err := os.MkdirAll(groupPath, 0755); err != nil {
+		return err
+	}
+
+	if err := writeCgroupFile(groupPath, cgroupType, cgroupTypeThreaded); err != nil {
+		return err
+	}
+
+	if err := writeCgroupFile(path, cgroupSubtreeControl, "+cpu"); err != nil {
+		return err
+	}
+
+	if err := writeCgroupFile(groupPath, cpuWeight, strconv.FormatUint(weight, 10)); err != nil {
+		return err
+	}
+
+	return writeThreads(tids, filepath.Join(groupPath, cgroupThreads))
+}
+
+func writeCgroupFile(cgroupPath, file, data string) error {
+	return ioutil.WriteFile(filepath.Join(cgroupPath, file), []byte(data), os.FileMode(0))
+}
+
+// write the thread ids, one at a time, into the tasks or cgroup.threads file
+func writeThreads(tids []int, threadsPath string) error {
+	for _, tid := range tids {
+		if err := ioutil.WriteFile(threadsPath,
+			[]byte(strconv.Itoa(tid)),
+			os.FileMode(0),
+		); err != nil {
+			// the thread may have exited in the meantime
+			if !strings.Contains(err.Error(), "no such process") {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
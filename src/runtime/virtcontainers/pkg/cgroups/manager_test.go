@@ -6,6 +6,9 @@
 package cgroups
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,3 +39,45 @@ func TestNew(t *testing.T) {
 	assert.NotNil(mgr.mgr)
 
 }
+
+func TestCPUWeightToShares(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(uint64(10), CPUWeightToShares(1))
+	assert.Equal(uint64(1024), CPUWeightToShares(100))
+	assert.Equal(uint64(102400), CPUWeightToShares(MaxCPUWeight))
+}
+
+func TestSetThreadGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	// The cgroupfs files the kernel creates along with the cgroups
+	newCgroup := func(path string, files ...string) {
+		assert.NoError(os.MkdirAll(path, 0755))
+		for _, f := range files {
+			assert.NoError(ioutil.WriteFile(filepath.Join(path, f), nil, 0644))
+		}
+	}
+	readFile := func(path string) string {
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(err)
+		return string(data)
+	}
+
+	// cgroup v1
+	cpuPath := t.TempDir()
+	newCgroup(filepath.Join(cpuPath, "vcpu"), cpuShares, cgroupTasks)
+	assert.NoError(setThreadGroupV1(cpuPath, "vcpu", 400, []int{102}))
+	assert.Equal("4096", readFile(filepath.Join(cpuPath, "vcpu", cpuShares)))
+	assert.Equal("102", readFile(filepath.Join(cpuPath, "vcpu", cgroupTasks)))
+
+	// cgroup v2
+	path := t.TempDir()
+	newCgroup(path, cgroupSubtreeControl)
+	newCgroup(filepath.Join(path, "iothread"), cgroupType, cpuWeight, cgroupThreads)
+	assert.NoError(setThreadGroupV2(path, "iothread", 50, []int{104}))
+	assert.Equal("+cpu", readFile(filepath.Join(path, cgroupSubtreeControl)))
+	assert.Equal(cgroupTypeThreaded, readFile(filepath.Join(path, "iothread", cgroupType)))
+	assert.Equal("50", readFile(filepath.Join(path, "iothread", cpuWeight)))
+	assert.Equal("104", readFile(filepath.Join(path, "iothread", cgroupThreads)))
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cgroups
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The resources of the cgroup v2 misc controller, the hardware IDs of the
+// confidential guests the kernel accounts. The SEV-ES and SEV-SNP guests
+// take their ASIDs from the sev_es ones.
+const (
+	MiscSEV   = "sev"
+	MiscSEVES = "sev_es"
+	MiscTDX   = "tdx"
+)
+
+const (
+	// file of the root cgroup with the capacity of the misc resources
+	miscCapacity = "misc.capacity"
+	// file of the cgroups with the usage of the misc resources
+	miscCurrent = "misc.current"
+)
+
+// miscRoot is the root cgroup, holding the misc controller capacity
+var miscRoot = unifiedMountpoint
+
+// parseMiscFile parses a flat keyed misc controller file, a "<resource>
+// <value>" line per resource. A "max" value is returned as math.MaxUint64.
+func parseMiscFile(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line %q in %s", scanner.Text(), path)
+		}
+
+		if fields[1] == "max" {
+			values[fields[0]] = math.MaxUint64
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s in %s: %v", fields[0], path, err)
+		}
+		values[fields[0]] = value
+	}
+
+	return values, scanner.Err()
+}
+
+// MiscCapacity returns the capacity of the misc resources of the host, by
+// resource. It is empty when the misc controller is not available, it
+// requires cgroup v2 and Linux 5.13 or later.
+func MiscCapacity() (map[string]uint64, error) {
+	capacity, err := parseMiscFile(filepath.Join(miscRoot, miscCapacity))
+	if os.IsNotExist(err) {
+		return map[string]uint64{}, nil
+	}
+
+	return capacity, err
+}
+
+// MiscUsage returns the usage of the misc resources of the host, by
+// resource. The root cgroup only reports it with the recent kernels, it is
+// otherwise the sum of the usages of its children, the usage of a cgroup
+// including the one of its descendants.
+func MiscUsage() (map[string]uint64, error) {
+	usage, err := parseMiscFile(filepath.Join(miscRoot, miscCurrent))
+	if !os.IsNotExist(err) {
+		return usage, err
+	}
+
+	entries, err := ioutil.ReadDir(miscRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	usage = make(map[string]uint64)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		current, err := parseMiscFile(filepath.Join(miscRoot, e.Name(), miscCurrent))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for resource, value := range current {
+			usage[resource] += value
+		}
+	}
+
+	return usage, nil
+}
+
+// MiscCurrent returns the usage of the misc resources of the cgroup, by
+// resource, on cgroup v2 hosts.
+func (m *Manager) MiscCurrent() (map[string]uint64, error) {
+	if !UnifiedMode() {
+		return nil, errors.New("the misc controller requires cgroup v2")
+	}
+
+	path, ok := m.GetPaths()[""]
+	if !ok || path == "" {
+		return nil, errors.New("unified cgroup not found")
+	}
+
+	current, err := parseMiscFile(filepath.Join(path, miscCurrent))
+	if os.IsNotExist(err) {
+		return map[string]uint64{}, nil
+	}
+
+	return current, err
+}
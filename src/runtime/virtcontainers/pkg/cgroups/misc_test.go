@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package cgroups
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiscResources(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	savedMiscRoot := miscRoot
+	miscRoot = root
+	defer func() {
+		miscRoot = savedMiscRoot
+	}()
+
+	// No misc controller
+	capacity, err := MiscCapacity()
+	assert.NoError(err)
+	assert.Empty(capacity)
+
+	assert.NoError(ioutil.WriteFile(filepath.Join(root, miscCapacity), []byte("sev 15\nsev_es 494\n"), 0644))
+	capacity, err = MiscCapacity()
+	assert.NoError(err)
+	assert.Equal(map[string]uint64{MiscSEV: 15, MiscSEVES: 494}, capacity)
+
+	// The usage is summed over the children of the root
+	for dir, current := range map[string]string{
+		"kubepods": "sev 3\nsev_es 1\n",
+		"system":   "sev 1\nsev_es 0\n",
+		"empty":    "",
+	} {
+		assert.NoError(os.Mkdir(filepath.Join(root, dir), 0755))
+		assert.NoError(ioutil.WriteFile(filepath.Join(root, dir, miscCurrent), []byte(current), 0644))
+	}
+	assert.NoError(os.Mkdir(filepath.Join(root, "nomisc"), 0755))
+
+	usage, err := MiscUsage()
+	assert.NoError(err)
+	assert.Equal(map[string]uint64{MiscSEV: 4, MiscSEVES: 1}, usage)
+
+	// Unless the root reports it
+	assert.NoError(ioutil.WriteFile(filepath.Join(root, miscCurrent), []byte("sev 5\nsev_es 2\n"), 0644))
+	usage, err = MiscUsage()
+	assert.NoError(err)
+	assert.Equal(map[string]uint64{MiscSEV: 5, MiscSEVES: 2}, usage)
+}
+
+func TestParseMiscFile(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "misc.max")
+
+	assert.NoError(ioutil.WriteFile(path, []byte("sev max\ntdx 2\n"), 0644))
+	values, err := parseMiscFile(path)
+	assert.NoError(err)
+	assert.Equal(map[string]uint64{MiscSEV: math.MaxUint64, MiscTDX: 2}, values)
+
+	for _, content := range []string{"sev\n", "sev 1 2\n", "sev -1\n"} {
+		assert.NoError(ioutil.WriteFile(path, []byte(content), 0644))
+		_, err = parseMiscFile(path)
+		assert.Error(err, content)
+	}
+}
@@ -176,7 +176,9 @@ func (p *HybridVSockTTRPCMockImp) Check(ctx context.Context, req *pb.CheckReques
 }
 
 func (p *HybridVSockTTRPCMockImp) Version(ctx context.Context, req *pb.CheckRequest) (*pb.VersionCheckResponse, error) {
-	return &pb.VersionCheckResponse{}, nil
+	return &pb.VersionCheckResponse{
+		GrpcVersion: pb.APIVersion,
+	}, nil
 }
 
 func (p *HybridVSockTTRPCMockImp) PauseContainer(ctx context.Context, req *pb.PauseContainerRequest) (*gpb.Empty, error) {
@@ -219,6 +221,38 @@ func (p *HybridVSockTTRPCMockImp) GetOOMEvent(ctx context.Context, req *pb.GetOO
 	return &pb.OOMEvent{}, nil
 }
 
+func (p *HybridVSockTTRPCMockImp) ResizeVolume(ctx context.Context, req *pb.ResizeVolumeRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) GetGuestDiagnostics(ctx context.Context, req *pb.GuestDiagnosticsRequest) (*pb.GuestDiagnostics, error) {
+	return &pb.GuestDiagnostics{Meminfo: "MemTotal: 2048000 kB\n"}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) RemountSharedFS(ctx context.Context, req *pb.RemountSharedFSRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) SyncFilesystems(ctx context.Context, req *pb.SyncFilesystemsRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) StartNetworkCapture(ctx context.Context, req *pb.StartNetworkCaptureRequest) (*pb.StartNetworkCaptureResponse, error) {
+	return &pb.StartNetworkCaptureResponse{CaptureId: 1}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) StopNetworkCapture(ctx context.Context, req *pb.StopNetworkCaptureRequest) (*gpb.Empty, error) {
+	return &gpb.Empty{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) CheckpointContainer(ctx context.Context, req *pb.CheckpointContainerRequest) (*pb.CheckpointContainerResponse, error) {
+	return &pb.CheckpointContainerResponse{}, nil
+}
+
+func (p *HybridVSockTTRPCMockImp) RestoreContainer(ctx context.Context, req *pb.RestoreContainerRequest) (*pb.RestoreContainerResponse, error) {
+	return &pb.RestoreContainerResponse{}, nil
+}
+
 func (p *HybridVSockTTRPCMockImp) GetMetrics(ctx context.Context, req *pb.GetMetricsRequest) (*pb.Metrics, error) {
 	return &pb.Metrics{}, nil
 }
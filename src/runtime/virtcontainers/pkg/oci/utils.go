@@ -23,12 +23,14 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/nri"
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	exp "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/experimental"
 	vcAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations"
 	dockershimAnnotations "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/annotations/dockershim"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 )
 
 type annotationContainerType struct {
@@ -77,6 +79,10 @@ const (
 
 const KernelModulesSeparator = ";"
 
+// GuestSysctlsSeparator is the separator of the sysctls of the guest_sysctls
+// annotation.
+const GuestSysctlsSeparator = ";"
+
 // FactoryConfig is a structure to set the VM factory configuration.
 type FactoryConfig struct {
 	// Template enables VM templating support in VM factory.
@@ -101,6 +107,8 @@ type RuntimeConfig struct {
 
 	AgentConfig vc.KataAgentConfig
 
+	MetricsConfig vc.MetricsConfig
+
 	//Determines how the VM should be connected to the
 	//the container network interface
 	InterNetworkModel vc.NetInterworkingModel
@@ -131,6 +139,13 @@ type RuntimeConfig struct {
 
 	// Determines if enable pprof
 	EnablePprof bool
+
+	// Host binaries run at the sandbox lifecycle points
+	SandboxHooks vc.SandboxHooks
+
+	// NRI plugins invoked by the shim before the VM and the containers
+	// are created
+	NRIConfig nri.Config
 }
 
 // AddKernelParam allows the addition of new kernel parameters to an existing
@@ -199,6 +214,76 @@ func containerMounts(spec specs.Spec) []vc.Mount {
 	return mnts
 }
 
+// addLUKSVolumes sets the key references of the LUKS encrypted volumes listed
+// by the LUKSVolumes annotation on their mounts.
+func addLUKSVolumes(ocispec specs.Spec, mounts []vc.Mount) error {
+	value, ok := ocispec.Annotations[vcAnnotations.LUKSVolumes]
+	if !ok {
+		return nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return fmt.Errorf("Invalid LUKS volume %q specified in annotation %s", pair, vcAnnotations.LUKSVolumes)
+		}
+
+		found := false
+		for i := range mounts {
+			if mounts[i].Destination == kv[0] {
+				mounts[i].LUKSKeyRef = kv[1]
+				found = true
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("LUKS volume %s specified in annotation %s is not a container mount", kv[0], vcAnnotations.LUKSVolumes)
+		}
+	}
+
+	return nil
+}
+
+// addBlockVolumeClasses sets the classes of the block volumes listed by the
+// BlockVolumeClasses annotation on their mounts.
+func addBlockVolumeClasses(ocispec specs.Spec, mounts []vc.Mount) error {
+	value, ok := ocispec.Annotations[vcAnnotations.BlockVolumeClasses]
+	if !ok {
+		return nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return fmt.Errorf("Invalid block volume class %q specified in annotation %s", pair, vcAnnotations.BlockVolumeClasses)
+		}
+
+		found := false
+		for i := range mounts {
+			if mounts[i].Destination == kv[0] {
+				mounts[i].BlockVolumeClass = kv[1]
+				found = true
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("Block volume %s specified in annotation %s is not a container mount", kv[0], vcAnnotations.BlockVolumeClasses)
+		}
+	}
+
+	return nil
+}
+
 func contains(strings []string, toFind string) bool {
 	for _, candidate := range strings {
 		if candidate == toFind {
@@ -230,6 +315,32 @@ func checkPathIsInGlobs(globs []string, path string) bool {
 	return false
 }
 
+// checkKernelModuleIsAllowed checks if a kernel module name matches one of
+// the glob patterns of the allow-list, "-" and "_" being equivalent as for
+// modprobe.
+func checkKernelModuleIsAllowed(globs []string, module string) bool {
+	module = strings.Replace(module, "-", "_", -1)
+	for _, glob := range globs {
+		if matched, _ := filepath.Match(strings.Replace(glob, "-", "_", -1), module); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkSysctlIsAllowed checks if a sysctl key matches one of the glob
+// patterns of the allow-list.
+func checkSysctlIsAllowed(globs []string, key string) bool {
+	for _, glob := range globs {
+		if matched, _ := filepath.Match(glob, key); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Check if an annotation name either belongs to another prefix, matches regexp list
 func checkAnnotationNameIsValid(list []string, name string, prefix string) bool {
 	if strings.HasPrefix(name, prefix) {
@@ -394,9 +505,27 @@ func addAnnotations(ocispec specs.Spec, config *vc.SandboxConfig, runtime Runtim
 	if err := addAgentConfigOverrides(ocispec, config); err != nil {
 		return err
 	}
+
+	addSandboxLabels(ocispec, config)
 	return nil
 }
 
+// addSandboxLabels sets the labels of the sandbox from its
+// io.katacontainers.label.<key> annotations, they are checked when the
+// sandbox is created.
+func addSandboxLabels(ocispec specs.Spec, config *vc.SandboxConfig) {
+	for key, value := range ocispec.Annotations {
+		if !strings.HasPrefix(key, vcAnnotations.KataAnnotationLabelPrefix) {
+			continue
+		}
+
+		if config.Labels == nil {
+			config.Labels = make(map[string]string)
+		}
+		config.Labels[strings.TrimPrefix(key, vcAnnotations.KataAnnotationLabelPrefix)] = value
+	}
+}
+
 func addAssetAnnotations(ocispec specs.Spec, config *vc.SandboxConfig) error {
 	assetAnnotations, err := types.AssetAnnotations()
 	if err != nil {
@@ -475,6 +604,87 @@ func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig,
 		return err
 	}
 
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.PreserveVFIOTopology).setBool(func(preserveVFIOTopology bool) {
+		config.HypervisorConfig.PreserveVFIOTopology = preserveVFIOTopology
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.ColdPlugDevices).setBool(func(coldPlugDevices bool) {
+		config.HypervisorConfig.ColdPlugDevices = coldPlugDevices
+	}); err != nil {
+		return err
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.ColdPlugDevicePaths]; ok {
+		var paths []string
+		for _, path := range strings.Split(value, ",") {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				continue
+			}
+			if !checkPathIsInGlobs(runtime.HypervisorConfig.ColdPlugDevicePathList, path) {
+				return fmt.Errorf("cold plug device path %v required from annotation is not valid", path)
+			}
+			paths = append(paths, path)
+		}
+		config.HypervisorConfig.ColdPlugDevicePaths = paths
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.VFIOAPDevices]; ok {
+		var paths []string
+		for _, path := range strings.Split(value, ",") {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				continue
+			}
+			if !utils.IsAPVFIOMediatedDevice(path) {
+				return fmt.Errorf("%v required from annotation is not a VFIO-AP mediated device", path)
+			}
+			if !checkPathIsInGlobs(runtime.HypervisorConfig.VFIOAPDevicePathList, path) {
+				return fmt.Errorf("VFIO-AP device %v required from annotation is not valid", path)
+			}
+			paths = append(paths, path)
+		}
+		config.HypervisorConfig.VFIOAPDevices = paths
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.VFIOBindDevices]; ok {
+		var paths []string
+		for _, path := range strings.Split(value, ",") {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				continue
+			}
+			if !checkPathIsInGlobs(runtime.HypervisorConfig.VFIOBindDevicePathList, path) {
+				return fmt.Errorf("PCI device %v required from annotation is not valid", path)
+			}
+			paths = append(paths, path)
+		}
+		config.HypervisorConfig.VFIOBindDevices = paths
+	}
+
+	if value, ok := ocispec.Annotations[vcAnnotations.VFIOMdevDevices]; ok {
+		var paths []string
+		for _, path := range strings.Split(value, ",") {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				continue
+			}
+			if !checkPathIsInGlobs(runtime.HypervisorConfig.VFIOMdevDevicePathList, path) {
+				return fmt.Errorf("mediated device %v required from annotation is not valid", path)
+			}
+			paths = append(paths, path)
+		}
+		config.HypervisorConfig.VFIOMdevDevices = paths
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.MigrationIncoming).setBool(func(migrationIncoming bool) {
+		config.HypervisorConfig.MigrationIncoming = migrationIncoming
+	}); err != nil {
+		return err
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.PCIeRootPort).setUint(func(pcieRootPort uint64) {
 		config.HypervisorConfig.PCIeRootPort = uint32(pcieRootPort)
 	}); err != nil {
@@ -489,6 +699,25 @@ func addHypervisorConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig,
 			config.HypervisorConfig.EntropySource = value
 		}
 	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.EntropyMaxBytes).setUint(func(maxBytes uint64) {
+		config.HypervisorConfig.EntropyMaxBytes = uint32(maxBytes)
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.EntropyPeriod).setUint(func(period uint64) {
+		config.HypervisorConfig.EntropyPeriod = uint32(period)
+	}); err != nil {
+		return err
+	}
+
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.GuestRNGSeed).setBool(func(guestRNGSeed bool) {
+		config.HypervisorConfig.GuestRNGSeed = guestRNGSeed
+	}); err != nil {
+		return err
+	}
+
 	if epcSize, ok := ocispec.Annotations[vcAnnotations.SGXEPC]; ok {
 		quantity, err := resource.ParseQuantity(epcSize)
 		if err != nil {
@@ -755,6 +984,12 @@ func addHypervisporNetworkOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConf
 		}
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.CPUModel]; ok {
+		if value != "" {
+			sbConfig.HypervisorConfig.CPUModel = value
+		}
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.DisableVhostNet).setBool(func(disableVhostNet bool) {
 		sbConfig.HypervisorConfig.DisableVhostNet = disableVhostNet
 	}); err != nil {
@@ -767,9 +1002,50 @@ func addHypervisporNetworkOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConf
 		return err
 	}
 
-	return newAnnotationConfiguration(ocispec, vcAnnotations.TxRateLimiterMaxRate).setUint(func(txRateLimiterMaxRate uint64) {
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.TxRateLimiterMaxRate).setUint(func(txRateLimiterMaxRate uint64) {
 		sbConfig.HypervisorConfig.TxRateLimiterMaxRate = txRateLimiterMaxRate
-	})
+	}); err != nil {
+		return err
+	}
+
+	return addBandwidthOverrides(ocispec, sbConfig)
+}
+
+// addBandwidthOverrides honours the pod bandwidth annotations with the rate
+// limiters of the sandbox, since the bandwidth CNI plugin shapes the veth of
+// the pod, which the tc filters redirecting the traffic of the VM bypass.
+// An annotation never raises the rate set by the configuration.
+func addBandwidthOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig) error {
+	limits := []struct {
+		key  string
+		rate *uint64
+	}{
+		{vcAnnotations.IngressBandwidth, &sbConfig.HypervisorConfig.RxRateLimiterMaxRate},
+		{vcAnnotations.EgressBandwidth, &sbConfig.HypervisorConfig.TxRateLimiterMaxRate},
+	}
+
+	for _, limit := range limits {
+		value, ok := ocispec.Annotations[limit.key]
+		if !ok {
+			continue
+		}
+
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("Couldn't parse %v '%v': %v", limit.key, value, err)
+		}
+
+		bandwidth := quantity.Value()
+		if bandwidth <= 0 {
+			return fmt.Errorf("Invalid %v '%v': the bandwidth must be positive", limit.key, value)
+		}
+
+		if *limit.rate == 0 || uint64(bandwidth) < *limit.rate {
+			*limit.rate = uint64(bandwidth)
+		}
+	}
+
+	return nil
 }
 
 func addRuntimeConfigOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, runtime RuntimeConfig) error {
@@ -805,6 +1081,12 @@ func addRuntimeConfigOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, r
 		return err
 	}
 
+	if err := newAnnotationConfiguration(ocispec, vcAnnotations.SharePidNs).setBool(func(sharePidNs bool) {
+		sbConfig.SharePidNs = sharePidNs
+	}); err != nil {
+		return err
+	}
+
 	if value, ok := ocispec.Annotations[vcAnnotations.InterNetworkModel]; ok {
 		runtimeConfig := RuntimeConfig{}
 		if err := runtimeConfig.InterNetworkModel.SetModel(value); err != nil {
@@ -814,6 +1096,25 @@ func addRuntimeConfigOverrides(ocispec specs.Spec, sbConfig *vc.SandboxConfig, r
 		sbConfig.NetworkConfig.InterworkingModel = runtimeConfig.InterNetworkModel
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.VhostUserNetSockets]; ok {
+		sockets := make(map[string]string)
+		for _, pair := range strings.Split(value, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+				return fmt.Errorf("Invalid vhost-user network socket %q specified in annotation %s", pair, vcAnnotations.VhostUserNetSockets)
+			}
+			if !checkPathIsInGlobs(runtime.HypervisorConfig.VhostUserNetSocketPathList, kv[1]) {
+				return fmt.Errorf("vhost-user network socket path %v required from annotation is not valid", kv[1])
+			}
+			sockets[kv[0]] = kv[1]
+		}
+		sbConfig.NetworkConfig.VhostUserNetSockets = sockets
+	}
+
 	return nil
 }
 
@@ -822,10 +1123,35 @@ func addAgentConfigOverrides(ocispec specs.Spec, config *vc.SandboxConfig) error
 
 	if value, ok := ocispec.Annotations[vcAnnotations.KernelModules]; ok {
 		modules := strings.Split(value, KernelModulesSeparator)
+		for _, m := range modules {
+			fields := strings.Fields(m)
+			if len(fields) > 0 && !checkKernelModuleIsAllowed(c.KernelModulesList, fields[0]) {
+				return fmt.Errorf("kernel module %v required from annotation is not valid", fields[0])
+			}
+		}
 		c.KernelModules = modules
 		config.AgentConfig = c
 	}
 
+	if value, ok := ocispec.Annotations[vcAnnotations.GuestSysctls]; ok {
+		sysctls := append([]string{}, c.GuestSysctls...)
+		for _, sysctl := range strings.Split(value, GuestSysctlsSeparator) {
+			if strings.TrimSpace(sysctl) == "" {
+				continue
+			}
+			key, _, err := vc.ParseGuestSysctl(sysctl)
+			if err != nil {
+				return err
+			}
+			if !checkSysctlIsAllowed(c.GuestSysctlsList, key) {
+				return fmt.Errorf("guest sysctl %v required from annotation is not valid", key)
+			}
+			sysctls = append(sysctls, strings.TrimSpace(sysctl))
+		}
+		c.GuestSysctls = sysctls
+		config.AgentConfig = c
+	}
+
 	if err := newAnnotationConfiguration(ocispec, vcAnnotations.AgentTrace).setBool(func(trace bool) {
 		c.Trace = trace
 	}); err != nil {
@@ -900,6 +1226,8 @@ func SandboxConfig(ocispec specs.Spec, runtime RuntimeConfig, bundlePath, cid, c
 		// Spec: &ocispec,
 
 		Experimental: runtime.Experimental,
+
+		Hooks: runtime.SandboxHooks,
 	}
 
 	if err := addAnnotations(ocispec, &sandboxConfig, runtime); err != nil {
@@ -941,6 +1269,23 @@ func ContainerConfig(ocispec specs.Spec, bundlePath, cid, console string, detach
 		return vc.ContainerConfig{}, err
 	}
 
+	mounts := containerMounts(ocispec)
+	if err := addLUKSVolumes(ocispec, mounts); err != nil {
+		return vc.ContainerConfig{}, err
+	}
+
+	if err := addBlockVolumeClasses(ocispec, mounts); err != nil {
+		return vc.ContainerConfig{}, err
+	}
+
+	if _, err := vc.ConfidentialEmptyDirSizes(ocispec.Annotations); err != nil {
+		return vc.ContainerConfig{}, err
+	}
+
+	if _, err := vc.ContainerNetworkNSPath(ocispec.Annotations); err != nil {
+		return vc.ContainerConfig{}, err
+	}
+
 	if ocispec.Process != nil {
 		cmd.Capabilities = ocispec.Process.Capabilities
 	}
@@ -953,7 +1298,7 @@ func ContainerConfig(ocispec specs.Spec, bundlePath, cid, console string, detach
 		Annotations: map[string]string{
 			vcAnnotations.BundlePathKey: bundlePath,
 		},
-		Mounts:      containerMounts(ocispec),
+		Mounts:      mounts,
 		DeviceInfos: deviceInfos,
 		Resources:   *ocispec.Linux.Resources,
 
@@ -1051,6 +1396,56 @@ func IsCRIOContainerManager(spec *specs.Spec) bool {
 	return false
 }
 
+// AddCRIOAnnotations resolves the Kata annotations of a spec created by
+// CRI-O, which passes the pod annotations as a JSON object in a single
+// annotation, so that they are honored as under containerd, where they are
+// set on the spec. The annotations set on the spec take precedence. A
+// container also inherits the container annotations of its sandbox spec, as
+// containerd passes the pod annotations to all the containers of the pod.
+func AddCRIOAnnotations(spec *specs.Spec, sandboxSpec *specs.Spec) error {
+	if spec == nil || !IsCRIOContainerManager(spec) {
+		return nil
+	}
+
+	if spec.Annotations == nil {
+		spec.Annotations = make(map[string]string)
+	}
+
+	if value, ok := spec.Annotations[crioAnnotations.Annotations]; ok && value != "" {
+		var podAnnotations map[string]string
+		if err := json.Unmarshal([]byte(value), &podAnnotations); err != nil {
+			return fmt.Errorf("Error parsing annotation for %s: %v", crioAnnotations.Annotations, err)
+		}
+
+		for key, val := range podAnnotations {
+			// The bandwidth annotations of the pod are honoured too
+			bandwidth := key == vcAnnotations.IngressBandwidth || key == vcAnnotations.EgressBandwidth
+			if !bandwidth && (!strings.HasPrefix(key, vcAnnotations.KataAnnotationsPrefix) ||
+				strings.HasPrefix(key, vcAnnotations.KataAnnotationOCIPrefix)) {
+				continue
+			}
+			if _, ok := spec.Annotations[key]; !ok {
+				spec.Annotations[key] = val
+			}
+		}
+	}
+
+	if sandboxSpec == nil || spec.Annotations[crioAnnotations.ContainerType] != crioAnnotations.ContainerTypeContainer {
+		return nil
+	}
+
+	for key, val := range sandboxSpec.Annotations {
+		if !strings.HasPrefix(key, vcAnnotations.KataAnnotationContainerPrefix) {
+			continue
+		}
+		if _, ok := spec.Annotations[key]; !ok {
+			spec.Annotations[key] = val
+		}
+	}
+
+	return nil
+}
+
 const (
 	errAnnotationPositiveNumericKey = "Error parsing annotation for %s: Please specify positive numeric value"
 	errAnnotationBoolKey            = "Error parsing annotation for %s: Please specify boolean value 'true|false'"
@@ -16,6 +16,7 @@ import (
 	"strings"
 	"testing"
 
+	criContainerdAnnotations "github.com/containerd/cri-containerd/pkg/annotations"
 	"github.com/cri-o/cri-o/pkg/annotations"
 	crioAnnotations "github.com/cri-o/cri-o/pkg/annotations"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
@@ -583,7 +584,9 @@ func TestAddAgentAnnotations(t *testing.T) {
 
 	config := vc.SandboxConfig{
 		Annotations: make(map[string]string),
-		AgentConfig: vc.KataAgentConfig{},
+		AgentConfig: vc.KataAgentConfig{
+			KernelModulesList: []string{"e1000e", "i9*"},
+		},
 	}
 
 	ocispec := specs.Spec{
@@ -595,6 +598,7 @@ func TestAddAgentAnnotations(t *testing.T) {
 			"e1000e InterruptThrottleRate=3000,3000,3000 EEE=1",
 			"i915 enable_ppgtt=0",
 		},
+		KernelModulesList: []string{"e1000e", "i9*"},
 		ContainerPipeSize: 1024,
 	}
 
@@ -605,10 +609,102 @@ func TestAddAgentAnnotations(t *testing.T) {
 
 	ocispec.Annotations[vcAnnotations.KernelModules] = strings.Join(expectedAgentConfig.KernelModules, KernelModulesSeparator)
 	ocispec.Annotations[vcAnnotations.AgentContainerPipeSize] = "1024"
-	addAnnotations(ocispec, &config, runtimeConfig)
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
 	assert.Exactly(expectedAgentConfig, config.AgentConfig)
 }
 
+func TestAddKernelModulesAnnotationNotAllowed(t *testing.T) {
+	assert := assert.New(t)
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+		Console:        consolePath,
+	}
+
+	for _, tc := range []struct {
+		allowed []string
+		modules string
+		valid   bool
+	}{
+		{nil, "nbd", false},
+		{[]string{"nbd"}, "nbd nbds_max=4", true},
+		{[]string{"nbd"}, "nbd; sctp", false},
+		{[]string{"nbd", "sctp"}, "nbd; sctp", true},
+		{[]string{"nf_conntrack*"}, "nf-conntrack-ftp", true},
+		{[]string{"nf-conntrack"}, "nf_conntrack", true},
+		{[]string{"nf_conntrack*"}, "xt_conntrack", false},
+	} {
+		config := vc.SandboxConfig{
+			Annotations: make(map[string]string),
+			AgentConfig: vc.KataAgentConfig{
+				KernelModules:     []string{"e1000e"},
+				KernelModulesList: tc.allowed,
+			},
+		}
+		ocispec := specs.Spec{
+			Annotations: map[string]string{vcAnnotations.KernelModules: tc.modules},
+		}
+
+		err := addAnnotations(ocispec, &config, runtimeConfig)
+		if tc.valid {
+			assert.NoError(err, tc.modules)
+			assert.Equal(strings.Split(tc.modules, KernelModulesSeparator), config.AgentConfig.KernelModules)
+		} else {
+			assert.Error(err, tc.modules)
+			assert.Equal([]string{"e1000e"}, config.AgentConfig.KernelModules)
+		}
+	}
+}
+
+func TestAddGuestSysctlsAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+		Console:        consolePath,
+	}
+
+	for _, tc := range []struct {
+		allowed []string
+		sysctls string
+		valid   bool
+	}{
+		{nil, "vm.max_map_count=262144", false},
+		{[]string{"vm.max_map_count"}, "vm.max_map_count=262144", true},
+		{[]string{"vm.max_map_count"}, "vm.max_map_count=262144; vm.swappiness=10", false},
+		{[]string{"vm.*"}, "vm.max_map_count=262144; vm.swappiness=10", true},
+		{[]string{"net.core.*"}, "net.core.rmem_max=8388608", true},
+		{[]string{"net.core.*"}, "net.ipv4.ip_forward=1", false},
+		{[]string{"*"}, "vm.swappiness", false},
+		{[]string{"*"}, "../vm.swappiness=1", false},
+	} {
+		config := vc.SandboxConfig{
+			Annotations: make(map[string]string),
+			AgentConfig: vc.KataAgentConfig{
+				GuestSysctls:     []string{"kernel.pid_max=65536"},
+				GuestSysctlsList: tc.allowed,
+			},
+		}
+		ocispec := specs.Spec{
+			Annotations: map[string]string{vcAnnotations.GuestSysctls: tc.sysctls},
+		}
+
+		err := addAnnotations(ocispec, &config, runtimeConfig)
+		if tc.valid {
+			assert.NoError(err, tc.sysctls)
+			expected := []string{"kernel.pid_max=65536"}
+			for _, s := range strings.Split(tc.sysctls, GuestSysctlsSeparator) {
+				expected = append(expected, strings.TrimSpace(s))
+			}
+			assert.Equal(expected, config.AgentConfig.GuestSysctls)
+		} else {
+			assert.Error(err, tc.sysctls)
+			assert.Equal([]string{"kernel.pid_max=65536"}, config.AgentConfig.GuestSysctls)
+		}
+	}
+}
+
 func TestContainerPipeSizeAnnotation(t *testing.T) {
 	assert := assert.New(t)
 
@@ -667,6 +763,7 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	runtimeConfig.HypervisorConfig.EnableAnnotations = []string{".*"}
 	runtimeConfig.HypervisorConfig.FileBackedMemRootList = []string{"/dev/shm*"}
 	runtimeConfig.HypervisorConfig.VirtioFSDaemonList = []string{"/bin/*ls*"}
+	runtimeConfig.HypervisorConfig.ColdPlugDevicePathList = []string{"/dev/*ull", "/dev/zero"}
 
 	ocispec.Annotations[vcAnnotations.KernelParams] = "vsyscall=emulate iommu=on"
 	addHypervisorConfigOverrides(ocispec, &config, runtimeConfig)
@@ -697,13 +794,21 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.MachineType] = "q35"
 	ocispec.Annotations[vcAnnotations.MachineAccelerators] = "nofw"
 	ocispec.Annotations[vcAnnotations.CPUFeatures] = "pmu=off"
+	ocispec.Annotations[vcAnnotations.CPUModel] = "Cascadelake-Server"
 	ocispec.Annotations[vcAnnotations.DisableVhostNet] = "true"
 	ocispec.Annotations[vcAnnotations.GuestHookPath] = "/usr/bin/"
 	ocispec.Annotations[vcAnnotations.DisableImageNvdimm] = "true"
 	ocispec.Annotations[vcAnnotations.HotplugVFIOOnRootBus] = "true"
+	ocispec.Annotations[vcAnnotations.PreserveVFIOTopology] = "true"
 	ocispec.Annotations[vcAnnotations.PCIeRootPort] = "2"
+	ocispec.Annotations[vcAnnotations.ColdPlugDevices] = "true"
+	ocispec.Annotations[vcAnnotations.ColdPlugDevicePaths] = "/dev/null, /dev/zero"
+	ocispec.Annotations[vcAnnotations.MigrationIncoming] = "true"
 	ocispec.Annotations[vcAnnotations.IOMMUPlatform] = "true"
 	ocispec.Annotations[vcAnnotations.SGXEPC] = "64Mi"
+	ocispec.Annotations[vcAnnotations.EntropyMaxBytes] = "1024"
+	ocispec.Annotations[vcAnnotations.EntropyPeriod] = "1000"
+	ocispec.Annotations[vcAnnotations.GuestRNGSeed] = "true"
 	// 10Mbit
 	ocispec.Annotations[vcAnnotations.RxRateLimiterMaxRate] = "10000000"
 	ocispec.Annotations[vcAnnotations.TxRateLimiterMaxRate] = "10000000"
@@ -734,13 +839,21 @@ func TestAddHypervisorAnnotations(t *testing.T) {
 	assert.Equal(config.HypervisorConfig.HypervisorMachineType, "q35")
 	assert.Equal(config.HypervisorConfig.MachineAccelerators, "nofw")
 	assert.Equal(config.HypervisorConfig.CPUFeatures, "pmu=off")
+	assert.Equal(config.HypervisorConfig.CPUModel, "Cascadelake-Server")
 	assert.Equal(config.HypervisorConfig.DisableVhostNet, true)
 	assert.Equal(config.HypervisorConfig.GuestHookPath, "/usr/bin/")
 	assert.Equal(config.HypervisorConfig.DisableImageNvdimm, true)
 	assert.Equal(config.HypervisorConfig.HotplugVFIOOnRootBus, true)
+	assert.Equal(config.HypervisorConfig.PreserveVFIOTopology, true)
 	assert.Equal(config.HypervisorConfig.PCIeRootPort, uint32(2))
+	assert.Equal(config.HypervisorConfig.ColdPlugDevices, true)
+	assert.Equal(config.HypervisorConfig.MigrationIncoming, true)
+	assert.Equal(config.HypervisorConfig.ColdPlugDevicePaths, []string{"/dev/null", "/dev/zero"})
 	assert.Equal(config.HypervisorConfig.IOMMUPlatform, true)
 	assert.Equal(config.HypervisorConfig.SGXEPCSize, int64(67108864))
+	assert.Equal(config.HypervisorConfig.EntropyMaxBytes, uint32(1024))
+	assert.Equal(config.HypervisorConfig.EntropyPeriod, uint32(1000))
+	assert.Equal(config.HypervisorConfig.GuestRNGSeed, true)
 	assert.Equal(config.HypervisorConfig.RxRateLimiterMaxRate, uint64(10000000))
 	assert.Equal(config.HypervisorConfig.TxRateLimiterMaxRate, uint64(10000000))
 
@@ -789,6 +902,19 @@ func TestAddProtectedHypervisorAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.FileBackedMemRootDir] = "/dev/shm"
 	ocispec.Annotations[vcAnnotations.VirtioFSDaemon] = "/bin/false"
 	ocispec.Annotations[vcAnnotations.EntropySource] = "/dev/urandom"
+	ocispec.Annotations[vcAnnotations.ColdPlugDevicePaths] = "/dev/null"
+	apMatrix := filepath.Join(t.TempDir(), "vfio_ap", "matrix")
+	apDevice := filepath.Join(apMatrix, "a297db4a-f4c2-11e6-90f6-d3b88d6c9525")
+	assert.NoError(os.MkdirAll(apDevice, 0755))
+	ocispec.Annotations[vcAnnotations.VFIOAPDevices] = apDevice
+	pciDevices := filepath.Join(t.TempDir(), "devices")
+	pciDevice := filepath.Join(pciDevices, "0000:3b:00.0")
+	assert.NoError(os.MkdirAll(pciDevice, 0755))
+	ocispec.Annotations[vcAnnotations.VFIOBindDevices] = pciDevice
+	mdevTypes := filepath.Join(t.TempDir(), "mdev_bus", "0000:3b:00.0", "mdev_supported_types")
+	mdevType := filepath.Join(mdevTypes, "nvidia-63")
+	assert.NoError(os.MkdirAll(mdevType, 0755))
+	ocispec.Annotations[vcAnnotations.VFIOMdevDevices] = mdevType
 
 	config.HypervisorConfig.FileBackedMemRootDir = "do-not-touch"
 	config.HypervisorConfig.VirtioFSDaemon = "dangerous-daemon"
@@ -799,16 +925,34 @@ func TestAddProtectedHypervisorAnnotations(t *testing.T) {
 	assert.Equal(config.HypervisorConfig.FileBackedMemRootDir, "do-not-touch")
 	assert.Equal(config.HypervisorConfig.VirtioFSDaemon, "dangerous-daemon")
 	assert.Equal(config.HypervisorConfig.EntropySource, "truly-random")
+	assert.Empty(config.HypervisorConfig.ColdPlugDevicePaths)
+	assert.Empty(config.HypervisorConfig.VFIOAPDevices)
+	assert.Empty(config.HypervisorConfig.VFIOBindDevices)
+	assert.Empty(config.HypervisorConfig.VFIOMdevDevices)
 
 	// Now enable them and check again
 	runtimeConfig.HypervisorConfig.FileBackedMemRootList = []string{"/dev/*m"}
 	runtimeConfig.HypervisorConfig.VirtioFSDaemonList = []string{"/bin/*ls*"}
 	runtimeConfig.HypervisorConfig.EntropySourceList = []string{"/dev/*random*"}
+	runtimeConfig.HypervisorConfig.ColdPlugDevicePathList = []string{"/dev/*ull", "/dev/zero"}
+	runtimeConfig.HypervisorConfig.VFIOAPDevicePathList = []string{filepath.Join(apMatrix, "*"), "/dev/*"}
+	runtimeConfig.HypervisorConfig.VFIOBindDevicePathList = []string{filepath.Join(pciDevices, "0000:3b:*")}
+	runtimeConfig.HypervisorConfig.VFIOMdevDevicePathList = []string{filepath.Join(mdevTypes, "nvidia-*")}
 	err = addAnnotations(ocispec, &config, runtimeConfig)
 	assert.NoError(err)
 	assert.Equal(config.HypervisorConfig.FileBackedMemRootDir, "/dev/shm")
 	assert.Equal(config.HypervisorConfig.VirtioFSDaemon, "/bin/false")
 	assert.Equal(config.HypervisorConfig.EntropySource, "/dev/urandom")
+	assert.Equal(config.HypervisorConfig.ColdPlugDevicePaths, []string{"/dev/null"})
+	assert.Equal(config.HypervisorConfig.VFIOAPDevices, []string{apDevice})
+	assert.Equal(config.HypervisorConfig.VFIOBindDevices, []string{pciDevice})
+	assert.Equal(config.HypervisorConfig.VFIOMdevDevices, []string{mdevType})
+
+	// Only VFIO-AP mediated devices can be requested
+	ocispec.Annotations[vcAnnotations.VFIOAPDevices] = "/dev/null"
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+	delete(ocispec.Annotations, vcAnnotations.VFIOAPDevices)
 
 	// In case an absurd large value is provided, the config value if not over-ridden
 	ocispec.Annotations[vcAnnotations.DefaultVCPUs] = "655536"
@@ -849,14 +993,173 @@ func TestAddRuntimeAnnotations(t *testing.T) {
 	ocispec.Annotations[vcAnnotations.SandboxCgroupOnly] = "true"
 	ocispec.Annotations[vcAnnotations.DisableNewNetNs] = "true"
 	ocispec.Annotations[vcAnnotations.InterNetworkModel] = "macvtap"
+	ocispec.Annotations[vcAnnotations.SharePidNs] = "true"
 
 	addAnnotations(ocispec, &config, runtimeConfig)
 	assert.Equal(config.DisableGuestSeccomp, true)
 	assert.Equal(config.SandboxCgroupOnly, true)
 	assert.Equal(config.NetworkConfig.DisableNewNetNs, true)
+	assert.Equal(config.SharePidNs, true)
 	assert.Equal(config.NetworkConfig.InterworkingModel, vc.NetXConnectMacVtapModel)
 }
 
+func TestAddSandboxLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{}
+	ocispec := specs.Spec{
+		Annotations: map[string]string{
+			"example.com/unrelated": "value",
+		},
+	}
+
+	addSandboxLabels(ocispec, &config)
+	assert.Nil(config.Labels)
+
+	ocispec.Annotations[vcAnnotations.KataAnnotationLabelPrefix+"tenant"] = "acme"
+	ocispec.Annotations[vcAnnotations.KataAnnotationLabelPrefix+"example.com/billing-code"] = "42"
+
+	addSandboxLabels(ocispec, &config)
+	assert.Equal(map[string]string{"tenant": "acme", "example.com/billing-code": "42"}, config.Labels)
+}
+
+func TestAddLUKSVolumes(t *testing.T) {
+	assert := assert.New(t)
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	mounts := []vc.Mount{
+		{Destination: "/data"},
+		{Destination: "/logs"},
+	}
+
+	assert.NoError(addLUKSVolumes(ocispec, mounts))
+	assert.Empty(mounts[0].LUKSKeyRef)
+
+	ocispec.Annotations[vcAnnotations.LUKSVolumes] = "/data=kbs:///default/key/1, /logs=file:///run/secrets/key"
+	assert.NoError(addLUKSVolumes(ocispec, mounts))
+	assert.Equal("kbs:///default/key/1", mounts[0].LUKSKeyRef)
+	assert.Equal("file:///run/secrets/key", mounts[1].LUKSKeyRef)
+
+	ocispec.Annotations[vcAnnotations.LUKSVolumes] = "/data"
+	assert.Error(addLUKSVolumes(ocispec, mounts))
+
+	ocispec.Annotations[vcAnnotations.LUKSVolumes] = "/unknown=kbs:///default/key/1"
+	assert.Error(addLUKSVolumes(ocispec, mounts))
+}
+
+func TestAddBlockVolumeClasses(t *testing.T) {
+	assert := assert.New(t)
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	mounts := []vc.Mount{
+		{Destination: "/var/lib/db"},
+		{Destination: "/scratch"},
+	}
+
+	assert.NoError(addBlockVolumeClasses(ocispec, mounts))
+	assert.Empty(mounts[0].BlockVolumeClass)
+
+	ocispec.Annotations[vcAnnotations.BlockVolumeClasses] = "/var/lib/db=database, /scratch=scratch"
+	assert.NoError(addBlockVolumeClasses(ocispec, mounts))
+	assert.Equal("database", mounts[0].BlockVolumeClass)
+	assert.Equal("scratch", mounts[1].BlockVolumeClass)
+
+	ocispec.Annotations[vcAnnotations.BlockVolumeClasses] = "/var/lib/db="
+	assert.Error(addBlockVolumeClasses(ocispec, mounts))
+
+	ocispec.Annotations[vcAnnotations.BlockVolumeClasses] = "/unknown=database"
+	assert.Error(addBlockVolumeClasses(ocispec, mounts))
+}
+
+func TestAddBandwidthAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+		Console:        consolePath,
+	}
+
+	ocispec.Annotations[vcAnnotations.IngressBandwidth] = "10M"
+	ocispec.Annotations[vcAnnotations.EgressBandwidth] = "1G"
+	err := addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(uint64(10000000), config.HypervisorConfig.RxRateLimiterMaxRate)
+	assert.Equal(uint64(1000000000), config.HypervisorConfig.TxRateLimiterMaxRate)
+
+	// The annotations don't raise a lower configured rate
+	config.HypervisorConfig.RxRateLimiterMaxRate = 1000000
+	config.HypervisorConfig.TxRateLimiterMaxRate = 2000000000
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(uint64(1000000), config.HypervisorConfig.RxRateLimiterMaxRate)
+	assert.Equal(uint64(1000000000), config.HypervisorConfig.TxRateLimiterMaxRate)
+
+	for _, value := range []string{"fast", "0", "-10M"} {
+		ocispec.Annotations[vcAnnotations.IngressBandwidth] = value
+		err = addAnnotations(ocispec, &config, runtimeConfig)
+		assert.Error(err, "bandwidth %q", value)
+	}
+}
+
+func TestAddVhostUserNetSocketsAnnotation(t *testing.T) {
+	assert := assert.New(t)
+
+	config := vc.SandboxConfig{
+		Annotations: make(map[string]string),
+	}
+
+	ocispec := specs.Spec{
+		Annotations: make(map[string]string),
+	}
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+		Console:        consolePath,
+	}
+
+	tmpdir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	vhu0 := filepath.Join(tmpdir, "vhu0")
+	vhu1 := filepath.Join(tmpdir, "vhu1")
+	for _, path := range []string{vhu0, vhu1} {
+		assert.NoError(ioutil.WriteFile(path, nil, 0600))
+	}
+
+	ocispec.Annotations[vcAnnotations.VhostUserNetSockets] = fmt.Sprintf("eth0=%s, eth1=%s", vhu0, vhu1)
+
+	// The socket paths must be valid
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+
+	runtimeConfig.HypervisorConfig.VhostUserNetSocketPathList = []string{filepath.Join(tmpdir, "vhu*")}
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"eth0": vhu0,
+		"eth1": vhu1,
+	}, config.NetworkConfig.VhostUserNetSockets)
+
+	ocispec.Annotations[vcAnnotations.VhostUserNetSockets] = vhu0
+	err = addAnnotations(ocispec, &config, runtimeConfig)
+	assert.Error(err)
+}
+
 func TestRegexpContains(t *testing.T) {
 	assert := assert.New(t)
 
@@ -947,6 +1250,83 @@ func TestIsCRIOContainerManager(t *testing.T) {
 	}
 }
 
+func TestAddCRIOAnnotations(t *testing.T) {
+	assert := assert.New(t)
+
+	runtimeConfig := RuntimeConfig{
+		HypervisorType: vc.QemuHypervisor,
+	}
+	runtimeConfig.HypervisorConfig.EnableAnnotations = []string{".*"}
+
+	// The Kata annotations are set on the spec under containerd, the
+	// sandbox resolves the same configuration under both implementations.
+	containerdSpec := specs.Spec{
+		Annotations: map[string]string{
+			criContainerdAnnotations.ContainerType: criContainerdAnnotations.ContainerTypeSandbox,
+			vcAnnotations.DefaultVCPUs:             "1",
+			vcAnnotations.DefaultMemory:            "1024",
+		},
+	}
+	crioSpec := specs.Spec{
+		Annotations: map[string]string{
+			crioAnnotations.ContainerType: crioAnnotations.ContainerTypeSandbox,
+			crioAnnotations.Annotations: `{"` + vcAnnotations.DefaultVCPUs + `":"1","` +
+				vcAnnotations.DefaultMemory + `":"1024","` +
+				vcAnnotations.BundlePathKey + `":"/evil","` +
+				vcAnnotations.IngressBandwidth + `":"10M","foo":"bar"}`,
+		},
+	}
+
+	assert.NoError(AddCRIOAnnotations(&containerdSpec, nil))
+	assert.Len(containerdSpec.Annotations, 3)
+	assert.NoError(AddCRIOAnnotations(&crioSpec, nil))
+
+	assert.Equal("1", crioSpec.Annotations[vcAnnotations.DefaultVCPUs])
+	assert.NotContains(crioSpec.Annotations, vcAnnotations.BundlePathKey)
+	assert.NotContains(crioSpec.Annotations, "foo")
+	assert.Equal("10M", crioSpec.Annotations[vcAnnotations.IngressBandwidth])
+
+	for _, spec := range []specs.Spec{containerdSpec, crioSpec} {
+		config := vc.SandboxConfig{
+			Annotations: make(map[string]string),
+		}
+		assert.NoError(addHypervisorConfigOverrides(spec, &config, runtimeConfig))
+		assert.Equal(uint32(1), config.HypervisorConfig.NumVCPUs)
+		assert.Equal(uint32(1024), config.HypervisorConfig.MemorySize)
+	}
+
+	// The annotations of the spec take precedence
+	crioSpec.Annotations[vcAnnotations.DefaultVCPUs] = "4"
+	assert.NoError(AddCRIOAnnotations(&crioSpec, nil))
+	assert.Equal("4", crioSpec.Annotations[vcAnnotations.DefaultVCPUs])
+
+	// A container inherits the container annotations of its sandbox
+	sandboxSpec := specs.Spec{
+		Annotations: map[string]string{
+			crioAnnotations.ContainerType:       crioAnnotations.ContainerTypeSandbox,
+			vcAnnotations.ConfidentialEmptyDirs: "cache=64Mi",
+			vcAnnotations.DefaultVCPUs:          "2",
+		},
+	}
+	containerSpec := specs.Spec{
+		Annotations: map[string]string{
+			crioAnnotations.ContainerType: crioAnnotations.ContainerTypeContainer,
+			crioAnnotations.Annotations:   `{"` + vcAnnotations.LUKSVolumes + `":"/data=file:///key"}`,
+		},
+	}
+	assert.NoError(AddCRIOAnnotations(&containerSpec, &sandboxSpec))
+	assert.Equal("cache=64Mi", containerSpec.Annotations[vcAnnotations.ConfidentialEmptyDirs])
+	assert.Equal("/data=file:///key", containerSpec.Annotations[vcAnnotations.LUKSVolumes])
+	assert.NotContains(containerSpec.Annotations, vcAnnotations.DefaultVCPUs)
+
+	sizes, err := vc.ConfidentialEmptyDirSizes(containerSpec.Annotations)
+	assert.NoError(err)
+	assert.Len(sizes, 1)
+
+	crioSpec.Annotations[crioAnnotations.Annotations] = "{"
+	assert.Error(AddCRIOAnnotations(&crioSpec, nil))
+}
+
 func TestParseAnnotationUintConfiguration(t *testing.T) {
 	assert := assert.New(t)
 
@@ -18,3 +18,90 @@ var (
 	ErrNoSuchContainer   = errors.New("Container does not exist")
 	ErrInvalidConfigType = errors.New("Invalid config type")
 )
+
+// ErrorCode classifies the failures of a sandbox, so they can be
+// aggregated and alerted on regardless of their message.
+type ErrorCode string
+
+const (
+	// ErrCodeUnknown is the code of the errors that were not classified.
+	ErrCodeUnknown ErrorCode = "unknown"
+
+	// ErrCodeHypervisorLaunch is the code of the failures to launch the
+	// hypervisor and boot the guest.
+	ErrCodeHypervisorLaunch ErrorCode = "hypervisor-launch-failure"
+
+	// ErrCodeAgentTimeout is the code of the agent requests that did not
+	// complete in time.
+	ErrCodeAgentTimeout ErrorCode = "agent-timeout"
+
+	// ErrCodeDeviceHotplug is the code of the failures to hotplug a device
+	// to the guest.
+	ErrCodeDeviceHotplug ErrorCode = "device-hotplug-failed"
+
+	// ErrCodeFsShare is the code of the failures to share the host files
+	// with the guest.
+	ErrCodeFsShare ErrorCode = "fs-share-failure"
+
+	// ErrCodeNetworkSetup is the code of the failures to set up the
+	// sandbox network.
+	ErrCodeNetworkSetup ErrorCode = "network-setup-failure"
+)
+
+// Error is an error classified with a code, wrapping the original error.
+type Error struct {
+	Err  error
+	Code ErrorCode
+}
+
+// The classified errors, to be matched with errors.Is whatever the error
+// they wrap.
+var (
+	ErrHypervisorLaunch = &Error{Code: ErrCodeHypervisorLaunch}
+	ErrAgentTimeout     = &Error{Code: ErrCodeAgentTimeout}
+	ErrDeviceHotplug    = &Error{Code: ErrCodeDeviceHotplug}
+	ErrFsShare          = &Error{Code: ErrCodeFsShare}
+	ErrNetworkSetup     = &Error{Code: ErrCodeNetworkSetup}
+)
+
+// NewError classifies err with code, it returns nil if err is nil.
+// An error that was already classified keeps its code.
+func NewError(code ErrorCode, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the errors with the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// ErrorCodeOf returns the code err was classified with, ErrCodeUnknown if
+// it was not.
+func ErrorCodeOf(err error) ErrorCode {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ErrCodeUnknown
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewError(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(NewError(ErrCodeAgentTimeout, nil))
+
+	cause := errors.New("no route to host")
+	err := NewError(ErrCodeNetworkSetup, cause)
+	assert.Equal(cause.Error(), err.Error())
+	assert.True(errors.Is(err, cause))
+	assert.True(errors.Is(err, ErrNetworkSetup))
+	assert.False(errors.Is(err, ErrHypervisorLaunch))
+	assert.Equal(ErrCodeNetworkSetup, ErrorCodeOf(err))
+
+	// The code survives the wrapping and is not overridden
+	wrapped := fmt.Errorf("failed to create sandbox: %w", err)
+	assert.True(errors.Is(wrapped, ErrNetworkSetup))
+	err = NewError(ErrCodeHypervisorLaunch, wrapped)
+	assert.Equal(ErrCodeNetworkSetup, ErrorCodeOf(err))
+	assert.False(errors.Is(err, ErrHypervisorLaunch))
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(ErrCodeUnknown, ErrorCodeOf(nil))
+	assert.Equal(ErrCodeUnknown, ErrorCodeOf(errors.New("foobar")))
+	assert.Equal(ErrCodeFsShare, ErrorCodeOf(ErrFsShare))
+	assert.Equal(string(ErrCodeFsShare), ErrFsShare.Error())
+}
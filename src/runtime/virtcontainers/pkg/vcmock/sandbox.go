@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"syscall"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
@@ -216,6 +217,22 @@ func (s *Sandbox) ListRoutes(ctx context.Context) ([]*pbTypes.Route, error) {
 	return nil, nil
 }
 
+// InspectNetwork implements the VCSandbox function of the same name.
+func (s *Sandbox) InspectNetwork(ctx context.Context) (vc.NetworkInspection, error) {
+	if s.InspectNetworkFunc != nil {
+		return s.InspectNetworkFunc()
+	}
+	return vc.NetworkInspection{}, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// CaptureNetwork implements the VCSandbox function of the same name.
+func (s *Sandbox) CaptureNetwork(ctx context.Context, w io.Writer, config vc.NetworkCaptureConfig) error {
+	if s.CaptureNetworkFunc != nil {
+		return s.CaptureNetworkFunc(w, config)
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
 func (s *Sandbox) GetOOMEvent(ctx context.Context) (string, error) {
 	return "", nil
 }
@@ -236,6 +253,14 @@ func (s *Sandbox) GetAgentMetrics(ctx context.Context) (string, error) {
 	return "", nil
 }
 
+// GuestMemoryStats implements the VCSandbox function of the same name.
+func (s *Sandbox) GuestMemoryStats(ctx context.Context) (vc.GuestMemoryStats, error) {
+	if s.GuestMemoryStatsFunc != nil {
+		return s.GuestMemoryStatsFunc()
+	}
+	return vc.GuestMemoryStats{}, nil
+}
+
 // Stats implements the VCSandbox function of the same name.
 func (s *Sandbox) Stats(ctx context.Context) (vc.SandboxStats, error) {
 	if s.StatsFunc != nil {
@@ -251,6 +276,150 @@ func (s *Sandbox) GetAgentURL() (string, error) {
 	return "", nil
 }
 
+// DumpGuestMemory implements the VCSandbox function of the same name.
+func (s *Sandbox) DumpGuestMemory(ctx context.Context, opts vc.GuestMemoryDumpOptions) (string, error) {
+	if s.DumpGuestMemoryFunc != nil {
+		return s.DumpGuestMemoryFunc(opts)
+	}
+	return "", nil
+}
+
+// Journal implements the VCSandbox function of the same name.
+func (s *Sandbox) Journal() ([]vc.JournalEvent, error) {
+	if s.JournalFunc != nil {
+		return s.JournalFunc()
+	}
+	return nil, nil
+}
+
+// HypervisorLog implements the VCSandbox function of the same name.
+func (s *Sandbox) HypervisorLog(size int) []byte {
+	if s.HypervisorLogFunc != nil {
+		return s.HypervisorLogFunc(size)
+	}
+	return nil
+}
+
+// PortForward implements the VCSandbox function of the same name.
+func (s *Sandbox) PortForward(ctx context.Context, port uint32) (net.Conn, error) {
+	if s.PortForwardFunc != nil {
+		return s.PortForwardFunc(port)
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// PolicyDecisions implements the VCSandbox function of the same name.
+func (s *Sandbox) PolicyDecisions(ctx context.Context) (net.Conn, error) {
+	if s.PolicyDecisionsFunc != nil {
+		return s.PolicyDecisionsFunc()
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// MigrationPrepareReceive implements the VCSandbox function of the same name.
+func (s *Sandbox) MigrationPrepareReceive(ctx context.Context, uri string) error {
+	if s.MigrationPrepareReceiveFunc != nil {
+		return s.MigrationPrepareReceiveFunc(uri)
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// MigrationStart implements the VCSandbox function of the same name.
+func (s *Sandbox) MigrationStart(ctx context.Context, uri string) error {
+	if s.MigrationStartFunc != nil {
+		return s.MigrationStartFunc(uri)
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// MigrationStatus implements the VCSandbox function of the same name.
+func (s *Sandbox) MigrationStatus(ctx context.Context) (string, error) {
+	if s.MigrationStatusFunc != nil {
+		return s.MigrationStatusFunc()
+	}
+	return "", fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// SyncGuestFilesystems implements the VCSandbox function of the same name.
+func (s *Sandbox) SyncGuestFilesystems(ctx context.Context) error {
+	if s.SyncGuestFilesystemsFunc != nil {
+		return s.SyncGuestFilesystemsFunc()
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// CheckpointContainer implements the VCSandbox function of the same name.
+func (s *Sandbox) CheckpointContainer(ctx context.Context, containerID string, w io.Writer, leaveRunning bool) error {
+	if s.CheckpointContainerFunc != nil {
+		return s.CheckpointContainerFunc(containerID, w, leaveRunning)
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// RestoreContainer implements the VCSandbox function of the same name.
+func (s *Sandbox) RestoreContainer(ctx context.Context, containerID string, images io.Reader, size int64) error {
+	if s.RestoreContainerFunc != nil {
+		return s.RestoreContainerFunc(containerID, images, size)
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// MigrationSwitchover implements the VCSandbox function of the same name.
+func (s *Sandbox) MigrationSwitchover(ctx context.Context) error {
+	if s.MigrationSwitchoverFunc != nil {
+		return s.MigrationSwitchoverFunc()
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
 func (s *Sandbox) GetHypervisorPid() (int, error) {
 	return 0, nil
 }
+
+// VMResources implements the VCSandbox function of the same name.
+func (s *Sandbox) VMResources() vc.VMResources {
+	if s.VMResourcesFunc != nil {
+		return s.VMResourcesFunc()
+	}
+	return vc.VMResources{}
+}
+
+// ResizeVM implements the VCSandbox function of the same name.
+func (s *Sandbox) ResizeVM(ctx context.Context, target vc.VMResources) (vc.VMResources, error) {
+	if s.ResizeVMFunc != nil {
+		return s.ResizeVMFunc(target)
+	}
+	return vc.VMResources{}, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// Labels implements the VCSandbox function of the same name.
+func (s *Sandbox) Labels() map[string]string {
+	if s.LabelsFunc != nil {
+		return s.LabelsFunc()
+	}
+	return map[string]string{}
+}
+
+// UpdateLabels implements the VCSandbox function of the same name.
+func (s *Sandbox) UpdateLabels(ctx context.Context, set map[string]string, remove []string) (map[string]string, error) {
+	if s.UpdateLabelsFunc != nil {
+		return s.UpdateLabelsFunc(set, remove)
+	}
+	return nil, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// ResizeVolume implements the VCSandbox function of the same name.
+func (s *Sandbox) ResizeVolume(ctx context.Context, volumePath string, size uint64) error {
+	if s.ResizeVolumeFunc != nil {
+		return s.ResizeVolumeFunc(volumePath, size)
+	}
+	return fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
+
+// GuestDiagnostics implements the VCSandbox function of the same name.
+func (s *Sandbox) GuestDiagnostics(ctx context.Context, opts vc.GuestDiagnosticsOptions) (vc.GuestDiagnostics, error) {
+	if s.GuestDiagnosticsFunc != nil {
+		return s.GuestDiagnosticsFunc(opts)
+	}
+	return vc.GuestDiagnostics{}, fmt.Errorf("%s: %s (%+v): sandboxID: %v", mockErrorPrefix, getSelf(), s, s.MockID)
+}
@@ -8,6 +8,7 @@ package vcmock
 import (
 	"context"
 	"io"
+	"net"
 	"syscall"
 
 	vc "github.com/kata-containers/kata-containers/src/runtime/virtcontainers"
@@ -63,10 +64,36 @@ type Sandbox struct {
 	ListInterfacesFunc       func() ([]*pbTypes.Interface, error)
 	UpdateRoutesFunc         func(routes []*pbTypes.Route) ([]*pbTypes.Route, error)
 	ListRoutesFunc           func() ([]*pbTypes.Route, error)
+	InspectNetworkFunc       func() (vc.NetworkInspection, error)
+	CaptureNetworkFunc       func(w io.Writer, config vc.NetworkCaptureConfig) error
 	UpdateRuntimeMetricsFunc func() error
 	GetAgentMetricsFunc      func() (string, error)
+	GuestMemoryStatsFunc     func() (vc.GuestMemoryStats, error)
 	StatsFunc                func() (vc.SandboxStats, error)
 	GetAgentURLFunc          func() (string, error)
+	DumpGuestMemoryFunc      func(opts vc.GuestMemoryDumpOptions) (string, error)
+	JournalFunc              func() ([]vc.JournalEvent, error)
+	HypervisorLogFunc        func(size int) []byte
+	PortForwardFunc          func(port uint32) (net.Conn, error)
+	PolicyDecisionsFunc      func() (net.Conn, error)
+	SyncGuestFilesystemsFunc func() error
+	CheckpointContainerFunc  func(containerID string, w io.Writer, leaveRunning bool) error
+	RestoreContainerFunc     func(containerID string, images io.Reader, size int64) error
+
+	MigrationPrepareReceiveFunc func(uri string) error
+	MigrationStartFunc          func(uri string) error
+	MigrationStatusFunc         func() (string, error)
+	MigrationSwitchoverFunc     func() error
+
+	VMResourcesFunc func() vc.VMResources
+	ResizeVMFunc    func(target vc.VMResources) (vc.VMResources, error)
+
+	LabelsFunc       func() map[string]string
+	UpdateLabelsFunc func(set map[string]string, remove []string) (map[string]string, error)
+
+	ResizeVolumeFunc func(volumePath string, size uint64) error
+
+	GuestDiagnosticsFunc func(opts vc.GuestDiagnosticsOptions) (vc.GuestDiagnostics, error)
 }
 
 // Container is a fake Container type used for testing
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cpuset"
+)
+
+var sysNUMANodeCPUListPath = "/sys/devices/system/node/node%d/cpulist"
+
+// numaPlacement places the host threads doing the guest I/O, the iothreads
+// and the virtiofsd threads, on the CPUs of the host NUMA node the guest
+// memory is bound to, so that their DMA to the guest memory does not cross
+// NUMA nodes.
+type numaPlacement struct {
+	node uint32
+	cpus unix.CPUSet
+}
+
+// newNUMAPlacement returns the placement of the hypervisor threads on the
+// NUMA node of the guest memory, nil when NUMA pinning is disabled.
+func newNUMAPlacement(config *HypervisorConfig) (*numaPlacement, error) {
+	if !config.EnableNUMAPinning {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(fmt.Sprintf(sysNUMANodeCPUListPath, config.NUMANode))
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the CPUs of NUMA node %d: %v", config.NUMANode, err)
+	}
+
+	cpus, err := cpuset.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CPUs of NUMA node %d: %v", config.NUMANode, err)
+	}
+	if cpus.IsEmpty() {
+		return nil, fmt.Errorf("NUMA node %d has no CPU", config.NUMANode)
+	}
+
+	p := &numaPlacement{node: config.NUMANode}
+	for _, cpu := range cpus.ToSlice() {
+		p.cpus.Set(cpu)
+	}
+
+	return p, nil
+}
+
+// placeThreads sets the CPU affinity of the threads tids to the CPUs of the
+// NUMA node. The threads they create inherit it.
+func (p *numaPlacement) placeThreads(tids []int) error {
+	for _, tid := range tids {
+		if err := unix.SchedSetaffinity(tid, &p.cpus); err != nil {
+			return fmt.Errorf("Could not place thread %d on NUMA node %d: %v", tid, p.node, err)
+		}
+	}
+
+	return nil
+}
+
+// placeProcess places all the threads of the process pid on the NUMA node.
+func (p *numaPlacement) placeProcess(pid int) error {
+	entries, err := ioutil.ReadDir(fmt.Sprintf(procTaskPath, pid))
+	if err != nil {
+		return fmt.Errorf("Could not list threads of PID %d: %v", pid, err)
+	}
+
+	var tids []int
+	for _, e := range entries {
+		if tid, err := strconv.Atoi(e.Name()); err == nil {
+			tids = append(tids, tid)
+		}
+	}
+
+	return p.placeThreads(tids)
+}
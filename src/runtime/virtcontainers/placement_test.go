@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// setNUMANodeCPUs fakes the CPU lists of the NUMA nodes, by node.
+func setNUMANodeCPUs(t *testing.T, nodes map[int]string) {
+	dir, err := ioutil.TempDir("", "numa")
+	assert.NoError(t, err)
+
+	for node, cpus := range nodes {
+		nodeDir := filepath.Join(dir, fmt.Sprintf("node%d", node))
+		assert.NoError(t, os.MkdirAll(nodeDir, 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(nodeDir, "cpulist"), []byte(cpus+"\n"), 0644))
+	}
+
+	savedSysNUMANodeCPUListPath := sysNUMANodeCPUListPath
+	sysNUMANodeCPUListPath = filepath.Join(dir, "node%d", "cpulist")
+	t.Cleanup(func() {
+		sysNUMANodeCPUListPath = savedSysNUMANodeCPUListPath
+		os.RemoveAll(dir)
+	})
+}
+
+func TestNewNUMAPlacement(t *testing.T) {
+	assert := assert.New(t)
+
+	setNUMANodeCPUs(t, map[int]string{0: "0-1,4", 1: ""})
+
+	placement, err := newNUMAPlacement(&HypervisorConfig{})
+	assert.NoError(err)
+	assert.Nil(placement)
+
+	placement, err = newNUMAPlacement(&HypervisorConfig{EnableNUMAPinning: true})
+	assert.NoError(err)
+	assert.Equal(3, placement.cpus.Count())
+	assert.True(placement.cpus.IsSet(4))
+	assert.False(placement.cpus.IsSet(2))
+
+	// no CPU
+	_, err = newNUMAPlacement(&HypervisorConfig{EnableNUMAPinning: true, NUMANode: 1})
+	assert.Error(err)
+
+	// no such node
+	_, err = newNUMAPlacement(&HypervisorConfig{EnableNUMAPinning: true, NUMANode: 2})
+	assert.Error(err)
+}
+
+func TestNUMAPlacementPlaceProcess(t *testing.T) {
+	assert := assert.New(t)
+
+	// Place the test process on the CPUs it already runs on
+	var current unix.CPUSet
+	assert.NoError(unix.SchedGetaffinity(0, &current))
+
+	var cpus []string
+	for cpu := 0; cpu < len(current)*64; cpu++ {
+		if current.IsSet(cpu) {
+			cpus = append(cpus, fmt.Sprint(cpu))
+		}
+	}
+	setNUMANodeCPUs(t, map[int]string{0: strings.Join(cpus, ",")})
+
+	placement, err := newNUMAPlacement(&HypervisorConfig{EnableNUMAPinning: true})
+	assert.NoError(err)
+	assert.NoError(placement.placeProcess(os.Getpid()))
+
+	var placed unix.CPUSet
+	assert.NoError(unix.SchedGetaffinity(0, &placed))
+	assert.Equal(current, placed)
+
+	assert.Error(placement.placeProcess(-1))
+
+	// The iothreads are queried from QEMU
+	q := &qemu{config: HypervisorConfig{EnableNUMAPinning: true}}
+	qmp := &mockIOThreadQMP{threads: map[string]bool{hotplugIOThreadPrefix + "0": true}}
+	assert.NoError(q.placeIOThreads(qmp))
+	assert.NotNil(q.placement)
+
+	qmp.err = errors.New("qmp error")
+	assert.Error(q.placeIOThreads(qmp))
+}
@@ -14,6 +14,7 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -22,7 +23,7 @@ import (
 	"time"
 	"unsafe"
 
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -32,6 +33,7 @@ import (
 	pkgUtils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	persistapi "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/api"
+	vccgroups "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/cgroups"
 	vcTypes "github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/uuid"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -82,7 +84,11 @@ type QemuState struct {
 	UUID                 string
 	HotplugVFIOOnRootBus bool
 	VirtiofsdPid         int
+	VhostUserGPUPid      int
 	PCIeRootPort         int
+	// HotplugIOThreads maps the hot plugged block devices to the
+	// iothread processing their IO.
+	HotplugIOThreads map[string]string
 }
 
 // qemu is an Hypervisor interface implementation for the Linux qemu hypervisor.
@@ -109,12 +115,42 @@ type qemu struct {
 
 	stopped bool
 
+	// stopping is set once the VM is being stopped, virtiofsd quitting is
+	// then expected.
+	stopping bool
+
 	store persistapi.PersistDriver
 
 	// if in memory dump progress
 	memoryDumpFlag sync.Mutex
 
 	virtiofsd Virtiofsd
+
+	sandbox *Sandbox
+
+	// ioThreads are the iothreads the hot plugged block devices are
+	// distributed across.
+	ioThreads    []string
+	nextIOThread int
+
+	// vmmCgroup is the cgroup v2 directory QEMU is started in, see
+	// setVMMCgroup.
+	vmmCgroup string
+
+	// placement places the iothreads and the virtiofsd threads on the
+	// NUMA node of the guest memory, nil until needed.
+	placement *numaPlacement
+
+	// pcieTopology places the cold plugged VFIO devices on the guest root
+	// complexes of their host ones, nil until needed.
+	pcieTopology *pcieTopology
+}
+
+// ioThreadQMP is the subset of QMP used to manage the hot plug iothreads.
+type ioThreadQMP interface {
+	ExecQueryIOThreads(ctx context.Context) ([]govmmQemu.IOThreadInfo, error)
+	ExecuteIOThreadAdd(ctx context.Context, id string) error
+	ExecuteIOThreadDel(ctx context.Context, id string) error
 }
 
 const (
@@ -125,11 +161,29 @@ const (
 	// memory dump format will be set to elf
 	memoryDumpFormat = "elf"
 
+	// hotplugIOThreadPrefix is the prefix of the iothreads added for the
+	// hot plugged block devices.
+	hotplugIOThreadPrefix = "hotplug-iothread-"
+
+	// guestMemoryDumpPrefix is the file name prefix of guest memory dumps
+	guestMemoryDumpPrefix = "vmcore-"
+
+	// guestMemoryDumpFDName is the name of the file descriptor passed to
+	// qemu for encrypted guest memory dumps.
+	guestMemoryDumpFDName = "kata-memory-dump"
+
 	qmpCapErrMsg  = "Failed to negotiate QMP capabilities"
 	qmpExecCatCmd = "exec:cat"
 
+	// qmpCapPauseBeforeSwitchover pauses the migration in the
+	// qmpMigrationPreSwitchover state, until it's continued.
+	qmpCapPauseBeforeSwitchover = "pause-before-switchover"
+	qmpMigrationPreSwitchover   = "pre-switchover"
+
 	scsiControllerID         = "scsi0"
 	rngID                    = "rng0"
+	balloonID                = "balloon0"
+	soundID                  = "sound0"
 	fallbackFileBackedMemDir = "/dev/shm"
 
 	qemuStopSandboxTimeoutSecs = 15
@@ -183,6 +237,9 @@ func (q *qemu) kernelParameters() string {
 	// set the maximum number of vCPUs
 	params = append(params, Param{"nr_cpus", fmt.Sprintf("%d", q.config.DefaultMaxVCPUs)})
 
+	// have the agent pet the guest watchdog
+	params = append(params, q.config.watchdogKernelParams()...)
+
 	// add the params specified by the provided config. As the kernel
 	// honours the last parameter value set and since the config-provided
 	// params are added here, they will take priority over the defaults.
@@ -419,9 +476,15 @@ func (q *qemu) buildDevices(ctx context.Context, initrdPath string) ([]govmmQemu
 		devices, _ = q.arch.appendPVPanicDevice(devices)
 	}
 
+	if q.config.Watchdog != "" {
+		devices = append(devices, q.watchdogDevice())
+	}
+
 	var ioThread *govmmQemu.IOThread
 	if q.config.BlockDeviceDriver == config.VirtioSCSI {
-		return q.arch.appendSCSIController(ctx, devices, q.config.EnableIOThreads)
+		// The hot plugged SCSI disks all sit behind this controller,
+		// so their IO is processed by its iothread.
+		return q.arch.appendSCSIController(ctx, devices, q.config.EnableIOThreads || q.config.HotplugIOThreads > 0)
 	}
 
 	return devices, ioThread, nil
@@ -443,6 +506,10 @@ func (q *qemu) setupTemplate(knobs *govmmQemu.Knobs, memory *govmmQemu.Memory) g
 		}
 	}
 
+	if q.config.MigrationIncoming {
+		incoming.MigrationType = govmmQemu.MigrationDefer
+	}
+
 	return incoming
 }
 
@@ -463,6 +530,132 @@ func (q *qemu) setupFileBackedMem(knobs *govmmQemu.Knobs, memory *govmmQemu.Memo
 	memory.Path = target
 }
 
+// cpuModel returns the guest CPU model and its features.
+func (q *qemu) cpuModel() string {
+	cpuModel := q.arch.cpuModel()
+
+	// Keep the architecture specific features of the default model.
+	if q.config.CPUModel != "" {
+		fields := strings.SplitN(cpuModel, ",", 2)
+		fields[0] = q.config.CPUModel
+		cpuModel = strings.Join(fields, ",")
+	}
+
+	if q.config.CPUFeatures != "" {
+		cpuModel += "," + q.config.CPUFeatures
+	}
+
+	return cpuModel
+}
+
+// cpuHelpCache caches the "qemu -cpu help" output per QEMU binary, so that
+// it is only run once and not on every sandbox creation.
+var cpuHelpCache = struct {
+	sync.Mutex
+	output map[string]string
+}{output: make(map[string]string)}
+
+// qemuCPUHelp returns the "qemu -cpu help" output of qemuPath.
+func qemuCPUHelp(qemuPath string) (string, error) {
+	cpuHelpCache.Lock()
+	defer cpuHelpCache.Unlock()
+
+	if output, ok := cpuHelpCache.output[qemuPath]; ok {
+		return output, nil
+	}
+
+	output, err := exec.Command(qemuPath, "-cpu", "help").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list the %s CPU models: %v", qemuPath, err)
+	}
+
+	cpuHelpCache.output[qemuPath] = string(output)
+
+	return string(output), nil
+}
+
+// checkCPUModel checks the CPU model and the "+feature" and "-feature"
+// flags of the features against the "qemu -cpu help" output, so that an
+// invalid guest CPU ABI is reported before the VM is launched. The other
+// features, e.g. "pmu=off", may be CPU properties and are not checked.
+func checkCPUModel(qemuPath, model, features string) error {
+	var flags []string
+	for _, f := range strings.Split(features, ",") {
+		if strings.HasPrefix(f, "+") || strings.HasPrefix(f, "-") {
+			flags = append(flags, f[1:])
+		}
+	}
+
+	if model == "" && len(flags) == 0 {
+		return nil
+	}
+
+	output, err := qemuCPUHelp(qemuPath)
+	if err != nil {
+		return err
+	}
+
+	return validateCPUModel(output, model, flags)
+}
+
+func validateCPUModel(cpuHelp, model string, flags []string) error {
+	models, knownFlags := parseCPUHelp(cpuHelp)
+
+	if model != "" && !models[model] {
+		return fmt.Errorf("unknown CPU model %q", model)
+	}
+
+	// Not all the architectures list the CPU flags.
+	if len(knownFlags) == 0 {
+		return nil
+	}
+
+	for _, f := range flags {
+		if !knownFlags[f] {
+			return fmt.Errorf("unknown CPU feature %q", f)
+		}
+	}
+
+	return nil
+}
+
+// parseCPUHelp parses the "qemu -cpu help" output, which lists a CPU model
+// per line, e.g. "x86 Cascadelake-Server  Intel Xeon Processor (Cascadelake)"
+// or "  cortex-a57", optionally followed by the recognized CPU flags.
+func parseCPUHelp(cpuHelp string) (map[string]bool, map[string]bool) {
+	models := make(map[string]bool)
+	flags := make(map[string]bool)
+
+	inFlags := false
+	for _, line := range strings.Split(cpuHelp, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if strings.HasSuffix(line, ":") {
+			inFlags = strings.Contains(line, "flags")
+			continue
+		}
+
+		if inFlags {
+			for _, f := range fields {
+				flags[f] = true
+			}
+			continue
+		}
+
+		// Lines which are not indented start with the architecture name.
+		if line[0] != ' ' && line[0] != '\t' && len(fields) > 1 {
+			models[fields[1]] = true
+		} else {
+			models[fields[0]] = true
+		}
+	}
+
+	return models, flags
+}
+
 // createSandbox is the Hypervisor sandbox creation implementation for govmmQemu.
 func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNamespace, hypervisorConfig *HypervisorConfig) error {
 	// Save the tracing context
@@ -487,6 +680,10 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		return err
 	}
 
+	if q.config.EnableNUMAPinning {
+		memory.HostNodes = strconv.FormatUint(uint64(q.config.NUMANode), 10)
+	}
+
 	knobs := govmmQemu.Knobs{
 		NoUserConfig:  true,
 		NoDefaults:    true,
@@ -519,11 +716,11 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 	incoming := q.setupTemplate(&knobs, &memory)
 
 	// With the current implementations, VM templating will not work with file
-	// based memory (stand-alone) or virtiofs. This is because VM templating
-	// builds the first VM with file-backed memory and shared=on and the
-	// subsequent ones with shared=off. virtio-fs always requires shared=on for
-	// memory.
-	if q.config.SharedFS == config.VirtioFS || q.config.FileBackedMemRootDir != "" {
+	// based memory (stand-alone), virtiofs, vhost-user network or GPU. This
+	// is because VM templating builds the first VM with file-backed memory
+	// and shared=on and the subsequent ones with shared=off. virtio-fs and
+	// the vhost-user backends always require shared=on for memory.
+	if q.config.SharedFS == config.VirtioFS || q.config.FileBackedMemRootDir != "" || q.config.VhostUserNet || q.config.VhostUserGPUDaemon != "" {
 		if !(q.config.BootToBeTemplate || q.config.BootFromTemplate) {
 			q.setupFileBackedMem(&knobs, &memory)
 		} else {
@@ -563,9 +760,6 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		return err
 	}
 
-	cpuModel := q.arch.cpuModel()
-	cpuModel += "," + q.config.CPUFeatures
-
 	firmwarePath, err := q.config.FirmwareAssetPath()
 	if err != nil {
 		return err
@@ -581,6 +775,11 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		return err
 	}
 
+	if err := checkCPUModel(qemuPath, q.config.CPUModel, q.config.CPUFeatures); err != nil {
+		return err
+	}
+	cpuModel := q.cpuModel()
+
 	qemuConfig := govmmQemu.Config{
 		Name:        fmt.Sprintf("sandbox-%s", q.id),
 		UUID:        q.state.UUID,
@@ -615,12 +814,36 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 	rngDev := config.RNGDev{
 		ID:       rngID,
 		Filename: q.config.EntropySource,
+		MaxBytes: q.config.EntropyMaxBytes,
+		Period:   q.config.EntropyPeriod,
 	}
 	qemuConfig.Devices, err = q.arch.appendRNGDevice(ctx, qemuConfig.Devices, rngDev)
 	if err != nil {
 		return err
 	}
 
+	if q.config.BalloonStatsInterval > 0 {
+		qemuConfig.Devices, err = q.arch.appendBalloonDevice(ctx, qemuConfig.Devices, balloonID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if q.config.VhostUserGPUDaemon != "" {
+		gpuDev, err := q.vhostUserGPUDevice()
+		if err != nil {
+			return err
+		}
+		qemuConfig.Devices, err = q.arch.appendVhostUserDevice(ctx, qemuConfig.Devices, gpuDev)
+		if err != nil {
+			return err
+		}
+	}
+
+	if q.config.VirtioSound != "" {
+		qemuConfig.Devices = q.arch.appendSoundDevice(qemuConfig.Devices, soundID, q.config.VirtioSound)
+	}
+
 	// Add PCIe Root Port devices to hypervisor
 	// The pcie.0 bus do not support hot-plug, but PCIe device can be hot-plugged into PCIe Root Port.
 	// For more details, please see https://github.com/qemu/qemu/blob/master/docs/pcie.txt
@@ -640,6 +863,8 @@ func (q *qemu) createSandbox(ctx context.Context, id string, networkNS NetworkNa
 		sourcePath: filepath.Join(getSharePath(q.id)),
 		socketPath: virtiofsdSocketPath,
 		extraArgs:  q.config.VirtioFSExtraArgs,
+		sandbox:    q.config.VirtioFSSandbox,
+		seccomp:    q.config.VirtioFSSeccomp,
 		debug:      q.config.Debug,
 		cache:      q.config.VirtioFSCache,
 	}
@@ -653,16 +878,49 @@ func (q *qemu) vhostFSSocketPath(id string) (string, error) {
 
 func (q *qemu) setupVirtiofsd(ctx context.Context) (err error) {
 	pid, err := q.virtiofsd.Start(ctx, func() {
-		q.stopSandbox(ctx, false)
+		q.virtiofsdQuit(ctx)
 	})
 	if err != nil {
-		return err
+		return vcTypes.NewError(vcTypes.ErrCodeFsShare, err)
 	}
 	q.state.VirtiofsdPid = pid
 
 	return nil
 }
 
+// virtiofsdQuit is called when virtiofsd quit. Unless the VM is being
+// stopped, the sandbox attempts to restart it when restarts are configured,
+// or the VM is stopped.
+func (q *qemu) virtiofsdQuit(ctx context.Context) {
+	if q.stopping {
+		return
+	}
+
+	if q.sandbox == nil || q.config.VirtioFSRestarts == 0 {
+		q.stopSandbox(ctx, false)
+		return
+	}
+
+	q.sandbox.handleVirtiofsdQuit(ctx, q.restartVirtiofsd)
+}
+
+// restartVirtiofsd starts a new virtiofsd serving the socket of the one
+// which quit, QEMU reconnects to it.
+func (q *qemu) restartVirtiofsd(ctx context.Context) error {
+	socketPath, err := q.vhostFSSocketPath(q.id)
+	if err != nil {
+		return err
+	}
+
+	// The socket of the previous daemon is left behind
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	q.state.VirtiofsdPid = 0
+
+	return q.setupVirtiofsd(ctx)
+}
+
 func (q *qemu) stopVirtiofsd(ctx context.Context) (err error) {
 	if q.state.VirtiofsdPid == 0 {
 		q.Logger().Warn("The virtiofsd had stopped")
@@ -695,7 +953,7 @@ func (q *qemu) getMemArgs() (bool, string, string, error) {
 			return share, target, "", fmt.Errorf("Vhost-user-blk/scsi requires hugepage memory")
 		}
 
-		if q.config.SharedFS == config.VirtioFS || q.config.FileBackedMemRootDir != "" {
+		if q.config.SharedFS == config.VirtioFS || q.config.FileBackedMemRootDir != "" || q.config.VhostUserNet || q.config.VhostUserGPUDaemon != "" {
 			target = q.qemuConfig.Memory.Path
 			memoryBack = "memory-backend-file"
 		}
@@ -819,8 +1077,32 @@ func (q *qemu) startSandbox(ctx context.Context, timeout int) error {
 
 	}
 
+	if q.config.VhostUserGPUDaemon != "" {
+		if err = q.startVhostUserGPU(); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				if stopErr := q.stopVhostUserGPU(); stopErr != nil {
+					q.Logger().WithError(stopErr).Warn("failed to stop the vhost-user-gpu backend")
+				}
+			}
+		}()
+	}
+
+	if q.vmmCgroup != "" {
+		var cgroupDir *os.File
+		q.qemuConfig.SysProcAttr, cgroupDir, err = vccgroups.CloneIntoCgroup(q.vmmCgroup)
+		if err != nil {
+			return fmt.Errorf("failed to open the cgroup %s of qemu: %v", q.vmmCgroup, err)
+		}
+		defer cgroupDir.Close()
+	}
+
 	var strErr string
-	strErr, err = govmmQemu.LaunchQemu(q.qemuConfig, newQMPLogger())
+	strErr, err = q.config.launchWithRetry(ctx, q.Logger(), func() (string, error) {
+		return govmmQemu.LaunchQemu(q.qemuConfig, newQMPLogger())
+	})
 	if err != nil {
 		if q.config.Debug && q.qemuConfig.LogFile != "" {
 			b, err := ioutil.ReadFile(q.qemuConfig.LogFile)
@@ -829,6 +1111,11 @@ func (q *qemu) startSandbox(ctx context.Context, timeout int) error {
 			}
 		}
 		q.Logger().WithError(err).Errorf("failed to launch qemu: %s", strErr)
+		if q.sandbox != nil {
+			// The output is attached to the error by the sandbox.
+			q.sandbox.hypervisorLog.addOutput(hypervisorLogHypervisor, strErr)
+			return fmt.Errorf("failed to launch qemu: %s", err)
+		}
 		return fmt.Errorf("failed to launch qemu: %s, error messages from qemu log: %s", err, strErr)
 	}
 
@@ -837,6 +1124,18 @@ func (q *qemu) startSandbox(ctx context.Context, timeout int) error {
 		return err
 	}
 
+	if q.config.EnableNUMAPinning {
+		if err = q.placeOnNUMANode(); err != nil {
+			return err
+		}
+	}
+
+	if q.config.ReadOnlyImage && q.config.ImagePath != "" {
+		if err = checkFileReadOnly(q.getPids()[0], q.config.ImagePath); err != nil {
+			return err
+		}
+	}
+
 	if q.config.BootFromTemplate {
 		if err = q.bootFromTemplate(); err != nil {
 			return err
@@ -844,12 +1143,28 @@ func (q *qemu) startSandbox(ctx context.Context, timeout int) error {
 	}
 
 	if q.config.VirtioMem {
-		err = q.setupVirtioMem(ctx)
+		if err = q.setupVirtioMem(ctx); err != nil {
+			return err
+		}
+	}
+
+	if q.config.BalloonStatsInterval > 0 {
+		err = q.setupBalloonStats()
 	}
 
 	return err
 }
 
+// setupBalloonStats makes QEMU poll the guest memory statistics of the
+// virtio-balloon device.
+func (q *qemu) setupBalloonStats() error {
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	return q.qmpMonitorCh.qmp.ExecQomSet(q.qmpMonitorCh.ctx, balloonID, "guest-stats-polling-interval", uint64(q.config.BalloonStatsInterval))
+}
+
 func (q *qemu) bootFromTemplate() error {
 	if err := q.qmpSetup(); err != nil {
 		return err
@@ -930,6 +1245,7 @@ func (q *qemu) stopSandbox(ctx context.Context, waitOnly bool) error {
 		q.Logger().Info("Already stopped")
 		return nil
 	}
+	q.stopping = true
 
 	defer func() {
 		q.cleanupVM()
@@ -977,6 +1293,10 @@ func (q *qemu) stopSandbox(ctx context.Context, waitOnly bool) error {
 		return err
 	}
 
+	if err := q.stopVhostUserGPU(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1036,7 +1356,10 @@ func (q *qemu) qmpSetup() error {
 	}
 
 	events := make(chan govmmQemu.QMPEvent)
-	go q.loopQMPEvent(events)
+	go func() {
+		q.qmpEventRouter().Run(events)
+		q.Logger().Infof("QMP event channel closed")
+	}()
 
 	cfg := govmmQemu.QMPConfig{
 		Logger:  newQMPLogger(),
@@ -1064,24 +1387,70 @@ func (q *qemu) qmpSetup() error {
 	return nil
 }
 
-func (q *qemu) loopQMPEvent(event chan govmmQemu.QMPEvent) {
-	for e := range event {
+// qmpEventRouter returns the router of the QMP events, with the handlers
+// of the events the runtime reacts to.
+func (q *qemu) qmpEventRouter() *govmmQemu.QMPEventRouter {
+	router := govmmQemu.NewQMPEventRouter(newQMPLogger())
+
+	router.Subscribe("", func(e govmmQemu.QMPEvent) {
 		q.Logger().WithField("event", e).Debug("got QMP event")
-		if e.Name == "GUEST_PANICKED" {
-			go q.handleGuestPanic()
-		}
-	}
-	q.Logger().Infof("QMP event channel closed")
+	})
+	router.OnGuestPanicked(func(govmmQemu.GuestPanickedEvent) {
+		go q.handleGuestPanic()
+	})
+	router.OnWatchdog(func(govmmQemu.WatchdogEvent) {
+		go q.handleGuestWatchdog()
+	})
+	router.OnBlockIOError(func(e govmmQemu.BlockIOErrorEvent) {
+		q.Logger().WithFields(logrus.Fields{
+			"device":    e.Device,
+			"node-name": e.NodeName,
+			"operation": e.Operation,
+			"action":    e.Action,
+			"nospace":   e.NoSpace,
+		}).Errorf("block I/O error: %s", e.Reason)
+	})
+	router.OnRTCChange(func(e govmmQemu.RTCChangeEvent) {
+		q.Logger().WithField("offset", e.Offset).Info("guest RTC changed")
+	})
+
+	return router
 }
 
 func (q *qemu) handleGuestPanic() {
-	if err := q.dumpGuestMemory(q.config.GuestMemoryDumpPath); err != nil {
+	if q.sandbox != nil {
+		q.sandbox.handleGuestPanic(q.ctx)
+		return
+	}
+
+	if _, _, err := q.dumpGuestMemory(q.config.GuestMemoryDumpPath, nil); err != nil {
 		q.Logger().WithError(err).Error("failed to dump guest memory")
 	}
+}
+
+// watchdogDevice returns the guest watchdog device. Only the reset action is
+// handled by qemu, the guest is left as is for the other ones so that the
+// runtime can handle the WATCHDOG event.
+func (q *qemu) watchdogDevice() govmmQemu.WatchdogDevice {
+	action := govmmQemu.WatchdogNone
+	if q.config.WatchdogAction == WatchdogActionReset {
+		action = govmmQemu.WatchdogReset
+	}
 
-	// TODO: how to notify the upper level sandbox to handle the error
-	// to do a fast fail(shutdown or others).
-	// tracked by https://github.com/kata-containers/kata-containers/issues/1026
+	return govmmQemu.WatchdogDevice{
+		Model:  govmmQemu.WatchdogModel(q.config.Watchdog),
+		ID:     "watchdog0",
+		Action: action,
+	}
+}
+
+func (q *qemu) handleGuestWatchdog() {
+	if q.sandbox != nil {
+		q.sandbox.handleGuestWatchdog(q.ctx)
+		return
+	}
+
+	q.Logger().WithField("action", q.config.WatchdogAction).Error("guest watchdog expired")
 }
 
 // canDumpGuestMemory check if can do a guest memory dump operation.
@@ -1124,9 +1493,14 @@ func (q *qemu) dumpSandboxMetaInfo(dumpSavePath string) {
 	if output, err := pkgUtils.RunCommandFull(command, true); err != nil {
 		q.Logger().WithError(err).WithField("output", output).Error("failed to save state")
 	}
-	// save hypervisor meta information
+	// save hypervisor meta information, confidential guests may pass
+	// secrets through the kernel command line, so don't leak it.
+	conf := q.config
+	if conf.ConfidentialGuest {
+		conf.KernelParams = nil
+	}
 	fileName := filepath.Join(dumpSavePath, "hypervisor.conf")
-	data, _ := json.MarshalIndent(q.config, "", " ")
+	data, _ := json.MarshalIndent(conf, "", " ")
 	if err := ioutil.WriteFile(fileName, data, defaultFilePerms); err != nil {
 		q.Logger().WithError(err).WithField("hypervisor.conf", data).Error("write to hypervisor.conf file failed")
 	}
@@ -1143,9 +1517,17 @@ func (q *qemu) dumpSandboxMetaInfo(dumpSavePath string) {
 	}
 }
 
-func (q *qemu) dumpGuestMemory(dumpSavePath string) error {
+// dumpGuestMemory saves the guest memory and sandbox meta information
+// under dumpSavePath and returns the directory holding them, along with the
+// name of the dump file. When encryptionKey is set, qemu writes the dump to
+// a pipe and only the encrypted stream reaches the disk.
+func (q *qemu) dumpGuestMemory(dumpSavePath string, encryptionKey []byte) (string, string, error) {
 	if dumpSavePath == "" {
-		return nil
+		return "", "", nil
+	}
+
+	if q.config.ConfidentialGuest && len(encryptionKey) == 0 {
+		return "", "", errors.New("guest memory dump of a confidential guest requires an encryption key")
 	}
 
 	q.memoryDumpFlag.Lock()
@@ -1156,7 +1538,7 @@ func (q *qemu) dumpGuestMemory(dumpSavePath string) error {
 	dumpSavePath = filepath.Join(dumpSavePath, q.id)
 	dumpStatePath := filepath.Join(dumpSavePath, "state")
 	if err := pkgUtils.EnsureDir(dumpStatePath, DirMode); err != nil {
-		return err
+		return "", "", err
 	}
 
 	// save meta information for sandbox
@@ -1166,25 +1548,68 @@ func (q *qemu) dumpGuestMemory(dumpSavePath string) error {
 	// check device free space and estimated dump size
 	if err := q.canDumpGuestMemory(dumpSavePath); err != nil {
 		q.Logger().Warnf("can't dump guest memory: %s", err.Error())
-		return err
+		return "", "", err
 	}
 
-	// dump guest memory
-	protocol := fmt.Sprintf("file:%s/vmcore-%s.%s", dumpSavePath, time.Now().Format("20060102150405.999"), memoryDumpFormat)
-	q.Logger().Infof("try to dump guest memory to %s", protocol)
-
 	if err := q.qmpSetup(); err != nil {
 		q.Logger().WithError(err).Error("setup manage QMP failed")
+		return "", "", err
+	}
+
+	// dump guest memory
+	name := fmt.Sprintf("%s%s.%s", guestMemoryDumpPrefix, time.Now().Format("20060102150405.999"), memoryDumpFormat)
+	if len(encryptionKey) > 0 {
+		name += encryptedDumpSuffix
+		if err := q.dumpGuestMemoryEncrypted(filepath.Join(dumpSavePath, name), encryptionKey); err != nil {
+			q.Logger().WithError(err).Error("dump guest memory failed")
+			return "", "", err
+		}
+	} else {
+		protocol := fmt.Sprintf("file:%s", filepath.Join(dumpSavePath, name))
+		q.Logger().Infof("try to dump guest memory to %s", protocol)
+
+		if err := q.qmpMonitorCh.qmp.ExecuteDumpGuestMemory(q.qmpMonitorCh.ctx, protocol, q.config.GuestMemoryDumpPaging, memoryDumpFormat); err != nil {
+			q.Logger().WithError(err).Error("dump guest memory failed")
+			return "", "", err
+		}
+	}
+
+	q.Logger().Info("dump guest memory completed")
+	return dumpSavePath, name, nil
+}
+
+// dumpGuestMemoryEncrypted makes qemu write the guest memory dump to a pipe,
+// which is encrypted into path as it is read.
+func (q *qemu) dumpGuestMemoryEncrypted(path string, encryptionKey []byte) error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	// qemu gets its own copy of the write end: the reader sees the end of
+	// the stream once qemu closes it, at the end of the dump.
+	err = q.qmpMonitorCh.qmp.ExecuteGetFD(q.qmpMonitorCh.ctx, guestMemoryDumpFDName, w)
+	w.Close()
+	if err != nil {
 		return err
 	}
 
+	done := make(chan error, 1)
+	go func() {
+		done <- encryptGuestMemoryDump(r, path, encryptionKey)
+	}()
+
+	q.Logger().WithField("path", path).Info("try to dump encrypted guest memory")
+	protocol := "fd:" + guestMemoryDumpFDName
 	if err := q.qmpMonitorCh.qmp.ExecuteDumpGuestMemory(q.qmpMonitorCh.ctx, protocol, q.config.GuestMemoryDumpPaging, memoryDumpFormat); err != nil {
-		q.Logger().WithError(err).Error("dump guest memory failed")
+		// qemu may still hold the write end, unblock the reader.
+		r.Close()
+		<-done
 		return err
 	}
 
-	q.Logger().Info("dump guest memory completed")
-	return nil
+	return <-done
 }
 
 func (q *qemu) qmpShutdown() {
@@ -1201,6 +1626,194 @@ func (q *qemu) qmpShutdown() {
 	}
 }
 
+// hotplugIOThread returns the iothread the next hot plugged virtio-blk
+// device is attached to, or an empty string if hot plug iothreads are
+// disabled. The iothreads are added on demand up to HotplugIOThreads, then
+// they are reused round-robin. added is true if the iothread was added by
+// this call.
+func (q *qemu) hotplugIOThread(qmp ioThreadQMP) (id string, added bool, err error) {
+	if q.config.HotplugIOThreads == 0 {
+		return "", false, nil
+	}
+
+	// Pick up the iothreads added before a restart of the runtime.
+	if len(q.ioThreads) == 0 {
+		ioThreads, err := qmp.ExecQueryIOThreads(q.qmpMonitorCh.ctx)
+		if err != nil {
+			return "", false, err
+		}
+		for _, t := range ioThreads {
+			if strings.HasPrefix(t.ID, hotplugIOThreadPrefix) {
+				q.ioThreads = append(q.ioThreads, t.ID)
+			}
+		}
+	}
+
+	if uint32(len(q.ioThreads)) < q.config.HotplugIOThreads {
+		id := q.newIOThreadID()
+		if err := qmp.ExecuteIOThreadAdd(q.qmpMonitorCh.ctx, id); err != nil {
+			return "", false, err
+		}
+		q.ioThreads = append(q.ioThreads, id)
+
+		// The new iothread is created by the QEMU main loop, it does
+		// not inherit the CPU affinity of the other iothreads.
+		if q.config.EnableNUMAPinning {
+			if err := q.placeIOThreads(qmp); err != nil {
+				q.Logger().WithError(err).Warn("failed to place the iothreads on the NUMA node")
+			}
+		}
+		return id, true, nil
+	}
+
+	return q.roundRobinIOThread(), false, nil
+}
+
+// numaPlacement returns the placement of the threads on the NUMA node of the
+// guest memory.
+func (q *qemu) numaPlacement() (*numaPlacement, error) {
+	if q.placement == nil {
+		placement, err := newNUMAPlacement(&q.config)
+		if err != nil {
+			return nil, err
+		}
+		q.placement = placement
+	}
+
+	return q.placement, nil
+}
+
+// placeIOThreads places the iothreads on the NUMA node of the guest memory.
+func (q *qemu) placeIOThreads(qmp ioThreadQMP) error {
+	placement, err := q.numaPlacement()
+	if err != nil {
+		return err
+	}
+
+	ioThreads, err := qmp.ExecQueryIOThreads(q.qmpMonitorCh.ctx)
+	if err != nil {
+		return err
+	}
+
+	var tids []int
+	for _, t := range ioThreads {
+		if t.ThreadID > 0 {
+			tids = append(tids, t.ThreadID)
+		}
+	}
+
+	return placement.placeThreads(tids)
+}
+
+// placeOnNUMANode places the virtiofsd threads and the iothreads, the host
+// threads doing the guest I/O, on the NUMA node the guest memory is bound
+// to.
+func (q *qemu) placeOnNUMANode() error {
+	placement, err := q.numaPlacement()
+	if err != nil {
+		return err
+	}
+
+	if q.state.VirtiofsdPid > 0 {
+		if err := placement.placeProcess(q.state.VirtiofsdPid); err != nil {
+			return err
+		}
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	return q.placeIOThreads(q.qmpMonitorCh.qmp)
+}
+
+// newIOThreadID returns the lowest hot plug iothread ID not in use.
+func (q *qemu) newIOThreadID() string {
+	for i := 0; ; i++ {
+		id := fmt.Sprintf("%s%d", hotplugIOThreadPrefix, i)
+		used := false
+		for _, t := range q.ioThreads {
+			if t == id {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return id
+		}
+	}
+}
+
+// releaseIOThread forgets that the block device driveID uses its iothread,
+// and removes the iothread once no device uses it anymore.
+func (q *qemu) releaseIOThread(qmp ioThreadQMP, driveID string) error {
+	id, ok := q.state.HotplugIOThreads[driveID]
+	if !ok {
+		return nil
+	}
+	delete(q.state.HotplugIOThreads, driveID)
+
+	for _, t := range q.state.HotplugIOThreads {
+		if t == id {
+			return nil
+		}
+	}
+
+	return q.removeIOThread(qmp, id)
+}
+
+// removeIOThread removes the hot plug iothread id from the VM.
+func (q *qemu) removeIOThread(qmp ioThreadQMP, id string) error {
+	if err := qmp.ExecuteIOThreadDel(q.qmpMonitorCh.ctx, id); err != nil {
+		return err
+	}
+
+	for i, t := range q.ioThreads {
+		if t == id {
+			q.ioThreads = append(q.ioThreads[:i], q.ioThreads[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// roundRobinIOThread returns the next iothread, round-robin.
+func (q *qemu) roundRobinIOThread() string {
+	if len(q.ioThreads) == 0 {
+		return ""
+	}
+
+	id := q.ioThreads[q.nextIOThread%len(q.ioThreads)]
+	q.nextIOThread++
+
+	return id
+}
+
+// blockdevOptions returns the options of the block device of a block volume
+// class, the block device cache options of the hypervisor applying when the
+// class doesn't set the cache mode.
+func (q *qemu) blockdevOptions(drive *config.BlockDrive) govmmQemu.BlockdevOptions {
+	options := govmmQemu.BlockdevOptions{
+		Discard:      drive.Discard,
+		DetectZeroes: drive.DetectZeroes,
+	}
+
+	switch drive.Cache {
+	case "none":
+		options.CacheSet = true
+		options.CacheDirect = true
+	case "writeback":
+		options.CacheSet = true
+	default:
+		options.CacheSet = q.config.BlockDeviceCacheSet
+		options.CacheDirect = q.config.BlockDeviceCacheDirect
+		options.CacheNoFlush = q.config.BlockDeviceCacheNoflush
+	}
+
+	return options
+}
+
 func (q *qemu) hotplugAddBlockDevice(ctx context.Context, drive *config.BlockDrive, op operation, devID string) (err error) {
 	// drive can be a pmem device, in which case it's used as backing file for a nvdimm device
 	if q.config.BlockDeviceDriver == config.Nvdimm || drive.Pmem {
@@ -1232,7 +1845,13 @@ func (q *qemu) hotplugAddBlockDevice(ctx context.Context, drive *config.BlockDri
 		return nil
 	}
 
-	if q.config.BlockDeviceCacheSet {
+	// The cache options don't apply to the scratch disks discarding the
+	// blocks they free, which are sparse files.
+	if drive.Cache != "" || drive.DetectZeroes != "" {
+		err = q.qmpMonitorCh.qmp.ExecuteBlockdevAddWithOptions(q.qmpMonitorCh.ctx, drive.File, drive.ID, drive.ReadOnly, q.blockdevOptions(drive))
+	} else if drive.Discard {
+		err = q.qmpMonitorCh.qmp.ExecuteBlockdevAddWithDiscard(q.qmpMonitorCh.ctx, drive.File, drive.ID, drive.ReadOnly)
+	} else if q.config.BlockDeviceCacheSet {
 		err = q.qmpMonitorCh.qmp.ExecuteBlockdevAddWithCache(q.qmpMonitorCh.ctx, drive.File, drive.ID, q.config.BlockDeviceCacheDirect, q.config.BlockDeviceCacheNoflush, drive.ReadOnly)
 	} else {
 		err = q.qmpMonitorCh.qmp.ExecuteBlockdevAdd(q.qmpMonitorCh.ctx, drive.File, drive.ID, drive.ReadOnly)
@@ -1293,9 +1912,26 @@ func (q *qemu) hotplugAddBlockDevice(ctx context.Context, drive *config.BlockDri
 			return err
 		}
 
-		if err = q.qmpMonitorCh.qmp.ExecutePCIDeviceAdd(q.qmpMonitorCh.ctx, drive.ID, devID, driver, addr, bridge.ID, romFile, 0, true, defaultDisableModern); err != nil {
+		ioThread, added, err := q.hotplugIOThread(q.qmpMonitorCh.qmp)
+		if err != nil {
 			return err
 		}
+
+		if err = q.qmpMonitorCh.qmp.ExecutePCIDeviceAddWithIOThread(q.qmpMonitorCh.ctx, drive.ID, devID, driver, addr, bridge.ID, romFile, ioThread, 0, true, defaultDisableModern); err != nil {
+			if added {
+				if delErr := q.removeIOThread(q.qmpMonitorCh.qmp, ioThread); delErr != nil {
+					q.Logger().WithError(delErr).WithField("iothread", ioThread).Warn("failed to remove iothread")
+				}
+			}
+			return err
+		}
+
+		if ioThread != "" {
+			if q.state.HotplugIOThreads == nil {
+				q.state.HotplugIOThreads = make(map[string]string)
+			}
+			q.state.HotplugIOThreads[drive.ID] = ioThread
+		}
 	case q.config.BlockDeviceDriver == config.VirtioSCSI:
 		driver := "scsi-hd"
 
@@ -1308,7 +1944,7 @@ func (q *qemu) hotplugAddBlockDevice(ctx context.Context, drive *config.BlockDri
 			return err
 		}
 
-		if err = q.qmpMonitorCh.qmp.ExecuteSCSIDeviceAdd(q.qmpMonitorCh.ctx, drive.ID, devID, driver, bus, romFile, scsiID, lun, true, defaultDisableModern); err != nil {
+		if err = q.qmpMonitorCh.qmp.ExecuteSCSIDeviceAddWithSerial(q.qmpMonitorCh.ctx, drive.ID, devID, driver, bus, romFile, drive.Serial, scsiID, lun, true, defaultDisableModern); err != nil {
 			return err
 		}
 	default:
@@ -1319,7 +1955,7 @@ func (q *qemu) hotplugAddBlockDevice(ctx context.Context, drive *config.BlockDri
 }
 
 func (q *qemu) hotplugAddVhostUserBlkDevice(ctx context.Context, vAttr *config.VhostUserDeviceAttrs, op operation, devID string) (err error) {
-	err = q.qmpMonitorCh.qmp.ExecuteCharDevUnixSocketAdd(q.qmpMonitorCh.ctx, vAttr.DevID, vAttr.SocketPath, false, false)
+	err = q.qmpMonitorCh.qmp.ExecuteCharDevUnixSocketReconnectAdd(q.qmpMonitorCh.ctx, vAttr.DevID, vAttr.SocketPath, vAttr.Reconnect)
 	if err != nil {
 		return err
 	}
@@ -1352,7 +1988,7 @@ func (q *qemu) hotplugAddVhostUserBlkDevice(ctx context.Context, vAttr *config.V
 	}
 	vAttr.PCIPath, err = vcTypes.PciPathFromSlots(bridgeSlot, devSlot)
 
-	if err = q.qmpMonitorCh.qmp.ExecutePCIVhostUserDevAdd(q.qmpMonitorCh.ctx, driver, devID, vAttr.DevID, addr, bridge.ID); err != nil {
+	if err = q.qmpMonitorCh.qmp.ExecutePCIVhostUserBlkDevAdd(q.qmpMonitorCh.ctx, driver, devID, vAttr.DevID, addr, bridge.ID, vAttr.NumQueues, vAttr.QueueSize); err != nil {
 		return err
 	}
 
@@ -1379,9 +2015,31 @@ func (q *qemu) hotplugBlockDevice(ctx context.Context, drive *config.BlockDrive,
 		return err
 	}
 
+	if err := q.releaseIOThread(q.qmpMonitorCh.qmp, drive.ID); err != nil {
+		q.Logger().WithError(err).WithField("drive", drive.ID).Warn("failed to remove iothread")
+	}
+
 	return q.qmpMonitorCh.qmp.ExecuteBlockdevDel(q.qmpMonitorCh.ctx, drive.ID)
 }
 
+// resizeBlockDevice grows the block device of the drive with block_resize,
+// the virtio-blk and virtio-scsi devices notify the guest of their new
+// capacity.
+func (q *qemu) resizeBlockDevice(ctx context.Context, drive *config.BlockDrive, size uint64) error {
+	span, _ := katatrace.Trace(ctx, q.Logger(), "resizeBlockDevice", q.tracingTags())
+	defer span.End()
+
+	if q.config.BlockDeviceDriver == config.Nvdimm || drive.Pmem {
+		return fmt.Errorf("the nvdimm device of drive %s cannot be resized", drive.ID)
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	return q.qmpMonitorCh.qmp.ExecuteBlockResize(q.qmpMonitorCh.ctx, drive.ID, size)
+}
+
 func (q *qemu) hotplugVhostUserDevice(ctx context.Context, vAttr *config.VhostUserDeviceAttrs, op operation) error {
 	if err := q.qmpSetup(); err != nil {
 		return err
@@ -1427,6 +2085,16 @@ func (q *qemu) hotplugVFIODevice(ctx context.Context, device *config.VFIODev, op
 			"device-info":              string(buf),
 		}).Info("Start hot-plug VFIO device")
 
+		// The AP queues of the matrix are passed through a vfio-ap device,
+		// which is not plugged on a PCI bus.
+		if device.Type == config.VFIODeviceMediatedType && utils.IsAPVFIOMediatedDevice(device.SysfsDev) {
+			return q.qmpMonitorCh.qmp.ExecuteAPVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, devID, device.SysfsDev)
+		}
+
+		if q.config.PreserveVFIOTopology {
+			q.Logger().WithField("dev-id", devID).Warn("the host PCIe topology is only preserved for the cold plugged VFIO devices")
+		}
+
 		// In case HotplugVFIOOnRootBus is true, devices are hotplugged on the root bus
 		// for pc machine type instead of bridge. This is useful for devices that require
 		// a large PCI BAR which is a currently a limitation with PCI bridges.
@@ -1447,9 +2115,6 @@ func (q *qemu) hotplugVFIODevice(ctx context.Context, device *config.VFIODev, op
 			case config.VFIODeviceNormalType:
 				return q.qmpMonitorCh.qmp.ExecuteVFIODeviceAdd(q.qmpMonitorCh.ctx, devID, device.BDF, device.Bus, romFile)
 			case config.VFIODeviceMediatedType:
-				if utils.IsAPVFIOMediatedDevice(device.SysfsDev) {
-					return q.qmpMonitorCh.qmp.ExecuteAPVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, device.SysfsDev)
-				}
 				return q.qmpMonitorCh.qmp.ExecutePCIVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, devID, device.SysfsDev, "", device.Bus, romFile)
 			default:
 				return fmt.Errorf("Incorrect VFIO device type found")
@@ -1471,9 +2136,6 @@ func (q *qemu) hotplugVFIODevice(ctx context.Context, device *config.VFIODev, op
 		case config.VFIODeviceNormalType:
 			return q.qmpMonitorCh.qmp.ExecutePCIVFIODeviceAdd(q.qmpMonitorCh.ctx, devID, device.BDF, addr, bridge.ID, romFile)
 		case config.VFIODeviceMediatedType:
-			if utils.IsAPVFIOMediatedDevice(device.SysfsDev) {
-				return q.qmpMonitorCh.qmp.ExecuteAPVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, device.SysfsDev)
-			}
 			return q.qmpMonitorCh.qmp.ExecutePCIVFIOMediatedDeviceAdd(q.qmpMonitorCh.ctx, devID, device.SysfsDev, addr, bridge.ID, romFile)
 		default:
 			return fmt.Errorf("Incorrect VFIO device type found")
@@ -1481,6 +2143,11 @@ func (q *qemu) hotplugVFIODevice(ctx context.Context, device *config.VFIODev, op
 	} else {
 		q.Logger().WithField("dev-id", devID).Info("Start hot-unplug VFIO device")
 
+		// The vfio-ap device is not plugged on a PCI bridge.
+		if device.Type == config.VFIODeviceMediatedType && utils.IsAPVFIOMediatedDevice(device.SysfsDev) {
+			return q.qmpMonitorCh.qmp.ExecuteDeviceDel(q.qmpMonitorCh.ctx, devID)
+		}
+
 		if !q.state.HotplugVFIOOnRootBus {
 			if err := q.arch.removeDeviceFromBridge(devID); err != nil {
 				return err
@@ -1565,6 +2232,10 @@ func (q *qemu) hotplugNetDevice(ctx context.Context, endpoint Endpoint, op opera
 		pciPath, err := vcTypes.PciPathFromSlots(bridgeSlot, devSlot)
 		endpoint.SetPciPath(pciPath)
 
+		// The guest driver learns the MTU of the network namespace
+		// interface, the tap already having it on the host
+		hostMTU := endpoint.Properties().Iface.MTU
+
 		var machine govmmQemu.Machine
 		machine, err = q.getQemuMachine()
 		if err != nil {
@@ -1572,9 +2243,9 @@ func (q *qemu) hotplugNetDevice(ctx context.Context, endpoint Endpoint, op opera
 		}
 		if machine.Type == QemuCCWVirtio {
 			devNoHotplug := fmt.Sprintf("fe.%x.%x", bridge.Addr, addr)
-			return q.qmpMonitorCh.qmp.ExecuteNetCCWDeviceAdd(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), devNoHotplug, int(q.config.NumVCPUs))
+			return q.qmpMonitorCh.qmp.ExecuteNetCCWDeviceAddWithMTU(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), devNoHotplug, int(q.config.NumVCPUs), hostMTU)
 		}
-		return q.qmpMonitorCh.qmp.ExecuteNetPCIDeviceAdd(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), addr, bridge.ID, romFile, int(q.config.NumVCPUs), defaultDisableModern)
+		return q.qmpMonitorCh.qmp.ExecuteNetPCIDeviceAddWithMTU(q.qmpMonitorCh.ctx, tap.Name, devID, endpoint.HardwareAddr(), addr, bridge.ID, romFile, int(q.config.NumVCPUs), defaultDisableModern, hostMTU)
 
 	}
 
@@ -1590,6 +2261,13 @@ func (q *qemu) hotplugNetDevice(ctx context.Context, endpoint Endpoint, op opera
 }
 
 func (q *qemu) hotplugDevice(ctx context.Context, devInfo interface{}, devType deviceType, op operation) (interface{}, error) {
+	switch devType {
+	case vfioDev, netDev, vhostuserDev:
+		if caps := q.arch.capabilities(); !caps.IsDeviceHotplugSupported() {
+			return nil, fmt.Errorf("cannot hotplug device: not supported by the %s machine type", q.arch.machine().Type)
+		}
+	}
+
 	switch devType {
 	case blockDev:
 		drive := devInfo.(*config.BlockDrive)
@@ -1885,6 +2563,10 @@ func (q *qemu) addDevice(ctx context.Context, devInfo interface{}, devType devic
 				CacheSize: q.config.VirtioFSCacheSize,
 				Cache:     q.config.VirtioFSCache,
 			}
+			// QEMU reconnects to a restarted virtiofsd
+			if q.config.VirtioFSRestarts > 0 {
+				vhostDev.Reconnect = virtiofsdReconnectInterval
+			}
 			vhostDev.SocketPath = sockPath
 			vhostDev.DevID = id
 
@@ -1902,9 +2584,22 @@ func (q *qemu) addDevice(ctx context.Context, devInfo interface{}, devType devic
 		q.qemuConfig.Devices, err = q.arch.appendNetwork(ctx, q.qemuConfig.Devices, v)
 	case config.BlockDrive:
 		q.qemuConfig.Devices, err = q.arch.appendBlockDevice(ctx, q.qemuConfig.Devices, v)
+	case *config.BlockDrive:
+		err = q.coldPlugBlockDevice(ctx, v)
 	case config.VhostUserDeviceAttrs:
 		q.qemuConfig.Devices, err = q.arch.appendVhostUserDevice(ctx, q.qemuConfig.Devices, v)
 	case config.VFIODev:
+		if q.config.PreserveVFIOTopology && !(v.Type == config.VFIODeviceMediatedType && utils.IsAPVFIOMediatedDevice(v.SysfsDev)) {
+			if q.config.HypervisorMachineType != QemuQ35 {
+				return fmt.Errorf("preserve_vfio_topology requires the %s machine type", QemuQ35)
+			}
+			if q.pcieTopology == nil {
+				q.pcieTopology = newPCIeTopology()
+			}
+			if q.qemuConfig.Devices, err = q.pcieTopology.placeVFIODevice(q.qemuConfig.Devices, &v); err != nil {
+				return err
+			}
+		}
 		q.qemuConfig.Devices = q.arch.appendVFIODevice(q.qemuConfig.Devices, v)
 	default:
 		q.Logger().WithField("dev-type", v).Warn("Could not append device: unsupported device type")
@@ -1913,6 +2608,54 @@ func (q *qemu) addDevice(ctx context.Context, devInfo interface{}, devType devic
 	return err
 }
 
+// coldPlugBlockDevice adds a virtio-blk device on a PCI bridge before the VM
+// boots, and sets the drive PCI path so that the agent can find it.
+func (q *qemu) coldPlugBlockDevice(ctx context.Context, drive *config.BlockDrive) (err error) {
+	if q.config.BlockDeviceDriver != config.VirtioBlock {
+		return fmt.Errorf("cold plugging block devices requires the %s block device driver, not %s", config.VirtioBlock, q.config.BlockDeviceDriver)
+	}
+
+	addr, bridge, err := q.arch.addDeviceToBridge(ctx, drive.ID, types.PCI)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			q.arch.removeDeviceFromBridge(drive.ID)
+		}
+	}()
+
+	bridgeSlot, err := vcTypes.PciSlotFromInt(bridge.Addr)
+	if err != nil {
+		return err
+	}
+	devSlot, err := vcTypes.PciSlotFromString(addr)
+	if err != nil {
+		return err
+	}
+	drive.PCIPath, err = vcTypes.PciPathFromSlots(bridgeSlot, devSlot)
+	if err != nil {
+		return err
+	}
+
+	devices, err := q.arch.appendBlockDevice(ctx, nil, *drive)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range devices {
+		if blkDev, ok := d.(govmmQemu.BlockDevice); ok {
+			blkDev.Bus = bridge.ID
+			blkDev.Addr = addr
+			d = blkDev
+		}
+		q.qemuConfig.Devices = append(q.qemuConfig.Devices, d)
+	}
+
+	return nil
+}
+
 // getSandboxConsole builds the path of the console where we can read
 // logs coming from the sandbox.
 func (q *qemu) getSandboxConsole(ctx context.Context, id string) (string, string, error) {
@@ -1953,6 +2696,129 @@ func (q *qemu) saveSandbox() error {
 	return q.waitMigration()
 }
 
+func (q *qemu) prepareMigrationIncoming(ctx context.Context, uri string) error {
+	span, _ := katatrace.Trace(ctx, q.Logger(), "prepareMigrationIncoming", q.tracingTags())
+	defer span.End()
+
+	if !q.config.MigrationIncoming {
+		return errors.New("the VM is not waiting for an incoming migration")
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	q.Logger().WithField("uri", uri).Info("prepare incoming migration")
+
+	return q.qmpMonitorCh.qmp.ExecuteMigrationIncoming(q.qmpMonitorCh.ctx, uri)
+}
+
+func (q *qemu) startMigration(ctx context.Context, uri string) error {
+	span, _ := katatrace.Trace(ctx, q.Logger(), "startMigration", q.tracingTags())
+	defer span.End()
+
+	if q.config.MigrationIncoming {
+		return errors.New("the VM is waiting for an incoming migration")
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	// The orchestrator decides when to switch over to the destination.
+	err := q.qmpMonitorCh.qmp.ExecSetMigrationCaps(q.qmpMonitorCh.ctx, []map[string]interface{}{
+		{
+			"capability": qmpCapPauseBeforeSwitchover,
+			"state":      true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	q.Logger().WithField("uri", uri).Info("start migration")
+
+	return q.qmpMonitorCh.qmp.ExecSetMigrateArguments(q.qmpMonitorCh.ctx, uri)
+}
+
+func (q *qemu) migrationStatus(ctx context.Context) (string, error) {
+	if err := q.qmpSetup(); err != nil {
+		return "", err
+	}
+
+	status, err := q.qmpMonitorCh.qmp.ExecuteQueryMigration(q.qmpMonitorCh.ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return status.Status, nil
+}
+
+// guestMemoryStats returns the guest memory statistics polled from the
+// virtio-balloon device, the statistics the guest doesn't report are left
+// out. The statistics are named after the QEMU ones without their "stat-"
+// prefix, e.g. "stat-swap-in" is "swap_in".
+func (q *qemu) guestMemoryStats(ctx context.Context) (GuestMemoryStats, error) {
+	if q.config.BalloonStatsInterval == 0 {
+		return GuestMemoryStats{}, nil
+	}
+
+	if err := q.qmpSetup(); err != nil {
+		return GuestMemoryStats{}, err
+	}
+
+	info, err := q.qmpMonitorCh.qmp.ExecuteQueryBalloon(q.qmpMonitorCh.ctx)
+	if err != nil {
+		return GuestMemoryStats{}, err
+	}
+
+	guestStats, err := q.qmpMonitorCh.qmp.ExecuteQueryBalloonGuestStats(q.qmpMonitorCh.ctx, balloonID)
+	if err != nil {
+		return GuestMemoryStats{}, err
+	}
+
+	return newGuestMemoryStats(info, guestStats), nil
+}
+
+func newGuestMemoryStats(info govmmQemu.BalloonInfo, guestStats govmmQemu.BalloonGuestStats) GuestMemoryStats {
+	stats := GuestMemoryStats{
+		Stats: map[string]uint64{"actual": uint64(info.Actual)},
+	}
+
+	// The guest didn't report any statistics yet.
+	if guestStats.LastUpdate == 0 {
+		return stats
+	}
+
+	for name, value := range guestStats.Stats {
+		if value < 0 {
+			continue
+		}
+		name = strings.Replace(strings.TrimPrefix(name, "stat-"), "-", "_", -1)
+		stats.Stats[name] = uint64(value)
+	}
+
+	return stats
+}
+
+func (q *qemu) migrationSwitchover(ctx context.Context) error {
+	span, _ := katatrace.Trace(ctx, q.Logger(), "migrationSwitchover", q.tracingTags())
+	defer span.End()
+
+	if err := q.qmpSetup(); err != nil {
+		return err
+	}
+
+	if !q.config.MigrationIncoming {
+		err := q.qmpMonitorCh.qmp.ExecuteMigrateContinue(q.qmpMonitorCh.ctx, qmpMigrationPreSwitchover)
+		if err != nil {
+			return err
+		}
+	}
+
+	return q.waitMigration()
+}
+
 func (q *qemu) waitMigration() error {
 	t := time.NewTimer(qmpMigrationWaitTimeout)
 	defer t.Stop()
@@ -2205,6 +3071,20 @@ func (q *qemu) getThreadIDs(ctx context.Context) (vcpuThreadIDs, error) {
 			tid.vcpus[i.CPU] = i.ThreadID
 		}
 	}
+
+	// The iothreads are only used to place them in their cgroup, not
+	// knowing them is not fatal.
+	ioThreadInfos, err := q.qmpMonitorCh.qmp.ExecQueryIOThreads(q.qmpMonitorCh.ctx)
+	if err != nil {
+		q.Logger().WithError(err).Warn("failed to query iothread infos")
+		return tid, nil
+	}
+
+	for _, i := range ioThreadInfos {
+		if i.ThreadID > 0 {
+			tid.ioThreads = append(tid.ioThreads, i.ThreadID)
+		}
+	}
 	return tid, nil
 }
 
@@ -2281,10 +3161,65 @@ func (q *qemu) getPids() []int {
 	if q.state.VirtiofsdPid != 0 {
 		pids = append(pids, q.state.VirtiofsdPid)
 	}
+	if q.state.VhostUserGPUPid != 0 {
+		pids = append(pids, q.state.VhostUserGPUPid)
+	}
 
 	return pids
 }
 
+// checkFileReadOnly returns an error if the process pid has path opened
+// for writing.
+func checkFileReadOnly(pid int, path string) error {
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+
+	fdDir := fmt.Sprintf("/proc/%d/fd", pid)
+	fds, err := ioutil.ReadDir(fdDir)
+	if err != nil {
+		return err
+	}
+
+	for _, fd := range fds {
+		target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+		if err != nil || target != realPath {
+			continue
+		}
+
+		flags, err := fdFlags(pid, fd.Name())
+		if err != nil {
+			return err
+		}
+
+		if flags&unix.O_ACCMODE != unix.O_RDONLY {
+			return fmt.Errorf("%s is opened read-write by process %d", path, pid)
+		}
+	}
+
+	return nil
+}
+
+// fdFlags returns the file status flags of the file descriptor fd of the
+// process pid.
+func fdFlags(pid int, fd string) (int, error) {
+	data, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/fdinfo/%s", pid, fd))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "flags:" {
+			flags, err := strconv.ParseInt(fields[1], 8, 64)
+			return int(flags), err
+		}
+	}
+
+	return 0, fmt.Errorf("no flags found for fd %s of process %d", fd, pid)
+}
+
 func (q *qemu) getVirtioFsPid() *int {
 	return &q.state.VirtiofsdPid
 }
@@ -2356,11 +3291,13 @@ func (q *qemu) save() (s persistapi.HypervisorState) {
 		s.Pid = pids[0]
 	}
 	s.VirtiofsdPid = q.state.VirtiofsdPid
+	s.VhostUserGPUPid = q.state.VhostUserGPUPid
 	s.Type = string(QemuHypervisor)
 	s.UUID = q.state.UUID
 	s.HotpluggedMemory = q.state.HotpluggedMemory
 	s.HotplugVFIOOnRootBus = q.state.HotplugVFIOOnRootBus
 	s.PCIeRootPort = q.state.PCIeRootPort
+	s.HotplugIOThreads = q.state.HotplugIOThreads
 
 	for _, bridge := range q.arch.getBridges() {
 		s.Bridges = append(s.Bridges, persistapi.Bridge{
@@ -2384,7 +3321,9 @@ func (q *qemu) load(s persistapi.HypervisorState) {
 	q.state.HotpluggedMemory = s.HotpluggedMemory
 	q.state.HotplugVFIOOnRootBus = s.HotplugVFIOOnRootBus
 	q.state.VirtiofsdPid = s.VirtiofsdPid
+	q.state.VhostUserGPUPid = s.VhostUserGPUPid
 	q.state.PCIeRootPort = s.PCIeRootPort
+	q.state.HotplugIOThreads = s.HotplugIOThreads
 
 	for _, bridge := range s.Bridges {
 		q.state.Bridges = append(q.state.Bridges, types.NewBridge(types.Type(bridge.Type), bridge.ID, bridge.DeviceAddr, bridge.Addr))
@@ -2425,5 +3364,14 @@ func (q *qemu) isRateLimiterBuiltin() bool {
 	return false
 }
 
+// setVMMCgroup makes QEMU start with clone3(CLONE_INTO_CGROUP), so that it
+// is in the cgroup from its first instruction, the pid file only serving to
+// find the daemonized process.
+func (q *qemu) setVMMCgroup(path string) bool {
+	q.vmmCgroup = path
+	return true
+}
+
 func (q *qemu) setSandbox(sandbox *Sandbox) {
+	q.sandbox = sandbox
 }
@@ -14,7 +14,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/intel-go/cpuid"
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 )
 
 type qemuAmd64 struct {
@@ -158,8 +158,14 @@ func (q *qemuAmd64) capabilities() types.Capabilities {
 		caps.SetBlockDeviceHotplugSupport()
 	}
 
+	// microvm has no PCI bus, nothing can be hot plugged.
+	if q.qemuMachine.Type != QemuMicrovm {
+		caps.SetDeviceHotplugSupport()
+	}
+
 	caps.SetMultiQueueSupport()
 	caps.SetFsSharingSupport()
+	caps.SetRNGDeviceSupport()
 
 	return caps
 }
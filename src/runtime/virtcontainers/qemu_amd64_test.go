@@ -13,7 +13,8 @@ import (
 	"testing"
 
 	"github.com/intel-go/cpuid"
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -45,10 +46,34 @@ func TestQemuAmd64Capabilities(t *testing.T) {
 	amd64 := newTestQemu(assert, QemuQ35)
 	caps := amd64.capabilities()
 	assert.True(caps.IsBlockDeviceHotplugSupported())
+	assert.True(caps.IsDeviceHotplugSupported())
 
 	amd64 = newTestQemu(assert, QemuMicrovm)
 	caps = amd64.capabilities()
 	assert.False(caps.IsBlockDeviceHotplugSupported())
+	assert.False(caps.IsDeviceHotplugSupported())
+	assert.True(caps.IsRNGDeviceSupported())
+}
+
+func TestQemuAmd64MicrovmHotplug(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		ctx:    context.Background(),
+		id:     "qemuTest",
+		config: newQemuConfig(),
+		arch:   newTestQemu(assert, QemuMicrovm),
+	}
+
+	_, err := q.hotplugAddDevice(q.ctx, &config.VFIODev{}, vfioDev)
+	assert.Error(err)
+	assert.Contains(err.Error(), "microvm")
+
+	_, err = q.hotplugAddDevice(q.ctx, &VethEndpoint{}, netDev)
+	assert.Error(err)
+
+	_, err = q.hotplugAddDevice(q.ctx, &config.VhostUserDeviceAttrs{}, vhostuserDev)
+	assert.Error(err)
 }
 
 func TestQemuAmd64Bridges(t *testing.T) {
@@ -15,7 +15,7 @@ import (
 	"strconv"
 	"strings"
 
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -105,6 +105,12 @@ type qemuArch interface {
 	// appendRNGDevice appends a RNG device to devices
 	appendRNGDevice(ctx context.Context, devices []govmmQemu.Device, rngDevice config.RNGDev) ([]govmmQemu.Device, error)
 
+	// appendBalloonDevice appends a virtio-balloon device to devices
+	appendBalloonDevice(ctx context.Context, devices []govmmQemu.Device, id string) ([]govmmQemu.Device, error)
+
+	// appendSoundDevice appends a virtio-sound device to devices
+	appendSoundDevice(devices []govmmQemu.Device, id, audioDriver string) []govmmQemu.Device
+
 	// addDeviceToBridge adds devices to the bus
 	addDeviceToBridge(ctx context.Context, ID string, t types.Type) (string, types.Bridge, error)
 
@@ -181,6 +187,7 @@ type qemuArchBase struct {
 	vhost                bool
 	disableNvdimm        bool
 	dax                  bool
+	readOnlyImage        bool
 	protection           guestProtection
 	qemuMachine          govmmQemu.Machine
 	qemuExePath          string
@@ -302,8 +309,10 @@ func (q *qemuArchBase) kernelParameters(debug bool) []Param {
 func (q *qemuArchBase) capabilities() types.Capabilities {
 	var caps types.Capabilities
 	caps.SetBlockDeviceHotplugSupport()
+	caps.SetDeviceHotplugSupport()
 	caps.SetMultiQueueSupport()
 	caps.SetFsSharingSupport()
+	caps.SetRNGDeviceSupport()
 	return caps
 }
 
@@ -406,6 +415,7 @@ func (q *qemuArchBase) appendNvdimmImage(devices []govmmQemu.Device, path string
 		ID:       "mem0",
 		MemPath:  path,
 		Size:     (uint64)(imageStat.Size()),
+		ReadOnly: q.readOnlyImage,
 	}
 
 	devices = append(devices, object)
@@ -617,6 +627,10 @@ func genericNetwork(endpoint Endpoint, vhost, nestedRun bool, index int) (govmmQ
 		return govmmQemu.NetDevice{}, fmt.Errorf("Unknown type for endpoint")
 	}
 
+	// Advertise the MTU of the network namespace interface to the guest
+	// driver, so that it does not exceed the one of the host side
+	d.HostMTU = endpoint.Properties().Iface.MTU
+
 	return d, nil
 }
 
@@ -674,22 +688,56 @@ func (q *qemuArchBase) appendVhostUserDevice(ctx context.Context, devices []govm
 		qemuVhostUserDevice.VhostUserType = govmmQemu.VhostUserSCSI
 	case config.VhostUserBlk:
 		qemuVhostUserDevice.VhostUserType = govmmQemu.VhostUserBlk
+		qemuVhostUserDevice.NumQueues = attr.NumQueues
+		qemuVhostUserDevice.QueueSize = attr.QueueSize
 	case config.VhostUserFS:
 		qemuVhostUserDevice.TypeDevID = utils.MakeNameID("fs", attr.DevID, maxDevIDSize)
 		qemuVhostUserDevice.Tag = attr.Tag
 		qemuVhostUserDevice.CacheSize = attr.CacheSize
 		qemuVhostUserDevice.VhostUserType = govmmQemu.VhostUserFS
+	case config.VhostUserGPU:
+		qemuVhostUserDevice.VhostUserType = govmmQemu.VhostUserGPU
 	}
 
 	qemuVhostUserDevice.SocketPath = attr.SocketPath
 	qemuVhostUserDevice.CharDevID = utils.MakeNameID("char", attr.DevID, maxDevIDSize)
+	qemuVhostUserDevice.Reconnect = attr.Reconnect
 
 	devices = append(devices, qemuVhostUserDevice)
 
 	return devices, nil
 }
 
+// apVFIODevice is the vfio-ap device passing the AP queues of a VFIO-AP
+// mediated device through to the VM.
+type apVFIODevice struct {
+	sysfsDev string
+}
+
+// Valid returns true if the vfio-ap device has a mediated device.
+func (d apVFIODevice) Valid() bool {
+	return d.sysfsDev != ""
+}
+
+// QemuParams returns the qemu parameters of the vfio-ap device.
+func (d apVFIODevice) QemuParams(_ *govmmQemu.Config) []string {
+	return []string{"-device", fmt.Sprintf("%s,sysfsdev=%s", govmmQemu.VfioAP, d.sysfsDev)}
+}
+
 func (q *qemuArchBase) appendVFIODevice(devices []govmmQemu.Device, vfioDev config.VFIODev) []govmmQemu.Device {
+	if vfioDev.Type == config.VFIODeviceMediatedType && utils.IsAPVFIOMediatedDevice(vfioDev.SysfsDev) {
+		return append(devices, apVFIODevice{sysfsDev: vfioDev.SysfsDev})
+	}
+
+	if vfioDev.Type == config.VFIODeviceMediatedType {
+		return append(devices,
+			govmmQemu.VFIODevice{
+				SysfsDev: vfioDev.SysfsDev,
+				Bus:      vfioDev.Bus,
+			},
+		)
+	}
+
 	if vfioDev.BDF == "" {
 		return devices
 	}
@@ -711,14 +759,38 @@ func (q *qemuArchBase) appendRNGDevice(_ context.Context, devices []govmmQemu.De
 		govmmQemu.RngDevice{
 			ID:       rngDev.ID,
 			Filename: rngDev.Filename,
+			MaxBytes: uint(rngDev.MaxBytes),
+			Period:   uint(rngDev.Period),
 		},
 	)
 
 	return devices, nil
 }
 
+func (q *qemuArchBase) appendBalloonDevice(_ context.Context, devices []govmmQemu.Device, id string) ([]govmmQemu.Device, error) {
+	devices = append(devices,
+		govmmQemu.BalloonDevice{
+			ID:            id,
+			DeflateOnOOM:  true,
+			DisableModern: q.nestedRun,
+		},
+	)
+
+	return devices, nil
+}
+
+func (q *qemuArchBase) appendSoundDevice(devices []govmmQemu.Device, id, audioDriver string) []govmmQemu.Device {
+	return append(devices,
+		govmmQemu.VirtioSoundDevice{
+			ID:          id,
+			AudioDriver: audioDriver,
+		},
+	)
+}
+
 func (q *qemuArchBase) handleImagePath(config HypervisorConfig) {
 	if config.ImagePath != "" {
+		q.readOnlyImage = config.ReadOnlyImage
 		kernelRootParams := commonVirtioblkKernelRootParams
 		if !q.disableNvdimm {
 			q.qemuMachine.Options = strings.Join([]string{
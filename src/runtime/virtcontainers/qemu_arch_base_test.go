@@ -14,13 +14,14 @@ import (
 	"path/filepath"
 	"testing"
 
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist/fs"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
 )
 
 const (
@@ -119,6 +120,7 @@ func TestQemuArchBaseCapabilities(t *testing.T) {
 
 	c := qemuArchBase.capabilities()
 	assert.True(c.IsBlockDeviceHotplugSupported())
+	assert.True(c.IsRNGDeviceSupported())
 }
 
 func TestQemuArchBaseBridges(t *testing.T) {
@@ -293,6 +295,33 @@ func TestQemuArchBaseAppendImage(t *testing.T) {
 	assert.Equal(expectedOut, devices)
 }
 
+func TestQemuArchBaseAppendNvdimmImageReadOnly(t *testing.T) {
+	var devices []govmmQemu.Device
+	assert := assert.New(t)
+	qemuArchBase := newQemuArchBase()
+
+	image, err := ioutil.TempFile("", "img")
+	assert.NoError(err)
+	defer os.Remove(image.Name())
+	_, err = image.WriteString("image")
+	assert.NoError(err)
+	err = image.Close()
+	assert.NoError(err)
+
+	qemuArchBase.handleImagePath(HypervisorConfig{
+		ImagePath:     image.Name(),
+		ReadOnlyImage: true,
+	})
+
+	devices, err = qemuArchBase.appendNvdimmImage(devices, image.Name())
+	assert.NoError(err)
+	assert.Len(devices, 1)
+
+	object, ok := devices[0].(govmmQemu.Object)
+	assert.True(ok)
+	assert.True(object.ReadOnly)
+}
+
 func TestQemuArchBaseAppendBridges(t *testing.T) {
 	var devices []govmmQemu.Device
 	assert := assert.New(t)
@@ -418,6 +447,67 @@ func TestQemuArchBaseAppendVhostUserDevice(t *testing.T) {
 	vhostUserDevice.SocketPath = socketPath
 
 	testQemuArchBaseAppend(t, vhostUserDevice, expectedOut)
+
+	// The virtio-fs device reconnects to a restarted virtiofsd
+	expectedOut = []govmmQemu.Device{
+		govmmQemu.VhostUserDevice{
+			SocketPath:    socketPath,
+			CharDevID:     fmt.Sprintf("char-%s", id),
+			TypeDevID:     fmt.Sprintf("fs-%s", id),
+			Tag:           "kataShared",
+			VhostUserType: govmmQemu.VhostUserFS,
+			Reconnect:     1,
+		},
+	}
+
+	vhostUserDevice = config.VhostUserDeviceAttrs{
+		Type:      config.VhostUserFS,
+		Tag:       "kataShared",
+		Reconnect: 1,
+	}
+	vhostUserDevice.DevID = id
+	vhostUserDevice.SocketPath = socketPath
+
+	testQemuArchBaseAppend(t, vhostUserDevice, expectedOut)
+
+	expectedOut = []govmmQemu.Device{
+		govmmQemu.VhostUserDevice{
+			SocketPath:    socketPath,
+			CharDevID:     fmt.Sprintf("char-%s", id),
+			VhostUserType: govmmQemu.VhostUserGPU,
+		},
+	}
+
+	vhostUserDevice = config.VhostUserDeviceAttrs{
+		Type:       config.VhostUserGPU,
+		DevID:      id,
+		SocketPath: socketPath,
+	}
+
+	testQemuArchBaseAppend(t, vhostUserDevice, expectedOut)
+
+	assert.Equal(t, []string{
+		"-chardev", fmt.Sprintf("socket,id=char-%s,path=%s", id, socketPath),
+		"-device", fmt.Sprintf("vhost-user-gpu-pci,chardev=char-%s", id),
+	}, expectedOut[0].QemuParams(&govmmQemu.Config{}))
+}
+
+func TestQemuArchBaseAppendSoundDevice(t *testing.T) {
+	assert := assert.New(t)
+	qemuArchBase := newQemuArchBase()
+
+	devices := qemuArchBase.appendSoundDevice(nil, soundID, "none")
+	assert.Equal([]govmmQemu.Device{
+		govmmQemu.VirtioSoundDevice{
+			ID:          soundID,
+			AudioDriver: "none",
+		},
+	}, devices)
+
+	assert.Equal([]string{
+		"-audiodev", "none,id=sound0-audiodev",
+		"-device", "virtio-sound-pci,id=sound0,audiodev=sound0-audiodev",
+	}, devices[0].QemuParams(&govmmQemu.Config{}))
 }
 
 func TestQemuArchBaseAppendVFIODevice(t *testing.T) {
@@ -436,6 +526,27 @@ func TestQemuArchBaseAppendVFIODevice(t *testing.T) {
 	testQemuArchBaseAppend(t, vfDevice, expectedOut)
 }
 
+func TestQemuArchBaseAppendRNGDevice(t *testing.T) {
+	assert := assert.New(t)
+	qemuArchBase := newQemuArchBase()
+
+	devices, err := qemuArchBase.appendRNGDevice(context.Background(), nil, config.RNGDev{
+		ID:       "rng0",
+		Filename: "/dev/urandom",
+		MaxBytes: 1024,
+		Period:   1000,
+	})
+	assert.NoError(err)
+	assert.Equal([]govmmQemu.Device{
+		govmmQemu.RngDevice{
+			ID:       "rng0",
+			Filename: "/dev/urandom",
+			MaxBytes: 1024,
+			Period:   1000,
+		},
+	}, devices)
+}
+
 func TestQemuArchBaseAppendVFIODeviceWithVendorDeviceID(t *testing.T) {
 	bdf := "02:10.1"
 	vendorID := "0x1234"
@@ -458,6 +569,49 @@ func TestQemuArchBaseAppendVFIODeviceWithVendorDeviceID(t *testing.T) {
 	testQemuArchBaseAppend(t, vfDevice, expectedOut)
 }
 
+func TestQemuArchBaseAppendAPVFIODevice(t *testing.T) {
+	assert := assert.New(t)
+	sysfsDev := "/sys/devices/vfio_ap/matrix/a297db4a-f4c2-11e6-90f6-d3b88d6c9525"
+
+	expectedOut := []govmmQemu.Device{
+		apVFIODevice{
+			sysfsDev: sysfsDev,
+		},
+	}
+
+	vfDevice := config.VFIODev{
+		Type:     config.VFIODeviceMediatedType,
+		SysfsDev: sysfsDev,
+	}
+
+	testQemuArchBaseAppend(t, vfDevice, expectedOut)
+
+	assert.Equal([]string{"-device", "vfio-ap,sysfsdev=" + sysfsDev}, expectedOut[0].QemuParams(nil))
+}
+
+func TestQemuArchBaseAppendMdevVFIODevice(t *testing.T) {
+	assert := assert.New(t)
+	sysfsDev := "/sys/bus/mdev/devices/f79944e4-5a3d-11e8-99ce-479cbab002e4"
+
+	expectedOut := []govmmQemu.Device{
+		govmmQemu.VFIODevice{
+			SysfsDev: sysfsDev,
+			Bus:      "pcie.0",
+		},
+	}
+
+	vfDevice := config.VFIODev{
+		Type:     config.VFIODeviceMediatedType,
+		SysfsDev: sysfsDev,
+		Bus:      "pcie.0",
+	}
+
+	testQemuArchBaseAppend(t, vfDevice, expectedOut)
+
+	params := expectedOut[0].QemuParams(&govmmQemu.Config{})
+	assert.Equal([]string{"-device", "vfio-pci,sysfsdev=" + sysfsDev + ",bus=pcie.0"}, params)
+}
+
 func TestQemuArchBaseAppendSCSIController(t *testing.T) {
 	var devices []govmmQemu.Device
 	assert := assert.New(t)
@@ -509,7 +663,8 @@ func TestQemuArchBaseAppendNetwork(t *testing.T) {
 		EndpointType: MacvtapEndpointType,
 		EndpointProperties: NetworkInfo{
 			Iface: NetlinkIface{
-				Type: "macvtap",
+				LinkAttrs: netlink.LinkAttrs{MTU: 1450},
+				Type:      "macvtap",
 			},
 		},
 	}
@@ -536,6 +691,7 @@ func TestQemuArchBaseAppendNetwork(t *testing.T) {
 			Script:     "no",
 			FDs:        macvtapEp.VMFds,
 			VhostFDs:   macvtapEp.VhostFds,
+			HostMTU:    1450,
 		},
 	}
 
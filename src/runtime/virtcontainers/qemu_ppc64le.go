@@ -9,7 +9,7 @@ import (
 	"fmt"
 	"time"
 
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/sirupsen/logrus"
 )
@@ -17,6 +17,10 @@ import (
 type qemuPPC64le struct {
 	// inherit from qemuArchBase, overwrite methods if needed
 	qemuArchBase
+
+	// mmu is the MMU mode of the host, MMURadix or MMUHash, empty when
+	// it cannot be determined.
+	mmu string
 }
 
 const defaultQemuPath = "/usr/bin/qemu-system-ppc64"
@@ -70,7 +74,7 @@ func newQemuArch(config HypervisorConfig) (qemuArch, error) {
 	}
 
 	q := &qemuPPC64le{
-		qemuArchBase{
+		qemuArchBase: qemuArchBase{
 			qemuMachine:          supportedQemuMachine,
 			qemuExePath:          defaultQemuPath,
 			memoryOffset:         config.MemOffset,
@@ -81,6 +85,17 @@ func newQemuArch(config HypervisorConfig) (qemuArch, error) {
 		},
 	}
 
+	mmu, err := HostMMU(procCPUInfo)
+	if err != nil {
+		q.Logger().WithError(err).Warn("Cannot determine the host MMU mode")
+	}
+	q.mmu = mmu
+
+	// KVM-HV on a hash MMU host can only run hash MMU guests.
+	if q.mmu == MMUHash {
+		q.kernelParams = append(q.kernelParams, Param{"disable_radix", ""})
+	}
+
 	if config.ConfidentialGuest {
 		if err := q.enableProtection(); err != nil {
 			return nil, err
@@ -100,10 +115,12 @@ func (q *qemuPPC64le) capabilities() types.Capabilities {
 	// pseries machine type supports hotplugging drives
 	if q.qemuMachine.Type == QemuPseries {
 		caps.SetBlockDeviceHotplugSupport()
+		caps.SetDeviceHotplugSupport()
 	}
 
 	caps.SetMultiQueueSupport()
 	caps.SetFsSharingSupport()
+	caps.SetRNGDeviceSupport()
 
 	return caps
 }
@@ -142,10 +159,9 @@ func (q *qemuPPC64le) enableProtection() error {
 
 	switch q.protection {
 	case pefProtection:
-		if q.qemuMachine.Options != "" {
-			q.qemuMachine.Options += ","
+		if err := q.enablePEF(); err != nil {
+			return err
 		}
-		q.qemuMachine.Options += fmt.Sprintf("confidential-guest-support=%s", pefID)
 		virtLog.WithFields(logrus.Fields{
 			"subsystem":     "qemuPPC64le",
 			"machine":       q.qemuMachine,
@@ -158,6 +174,25 @@ func (q *qemuPPC64le) enableProtection() error {
 	}
 }
 
+// enablePEF configures the machine and the guest kernel for the Protected
+// Execution Facility. The secure guests can only run in radix MMU mode, the
+// guest kernel switches to secure mode with "svm=on", and the count cache
+// flush assist is not available to them through the ultravisor.
+func (q *qemuPPC64le) enablePEF() error {
+	if q.mmu == MMUHash {
+		return fmt.Errorf("PEF guests require the radix MMU, the host uses the hash MMU")
+	}
+
+	if q.qemuMachine.Options != "" {
+		q.qemuMachine.Options += ","
+	}
+	q.qemuMachine.Options += fmt.Sprintf("confidential-guest-support=%s,cap-ccf-assist=off", pefID)
+
+	q.kernelParams = append(q.kernelParams, Param{"svm", "on"})
+
+	return nil
+}
+
 // append protection device
 func (q *qemuPPC64le) appendProtectionDevice(devices []govmmQemu.Device, firmware string) ([]govmmQemu.Device, string, error) {
 	switch q.protection {
@@ -9,7 +9,7 @@ import (
 	"fmt"
 	"testing"
 
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -100,3 +100,21 @@ func TestQemuPPC64leAppendProtectionDevice(t *testing.T) {
 	assert.Equal(expectedOut, devices)
 
 }
+
+func TestQemuPPC64leEnablePEF(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemuPPC64le{
+		qemuArchBase: qemuArchBase{
+			qemuMachine:  supportedQemuMachine,
+			kernelParams: kernelParams,
+		},
+		mmu: MMUHash,
+	}
+	assert.Error(q.enablePEF())
+
+	q.mmu = MMURadix
+	assert.NoError(q.enablePEF())
+	assert.Equal(defaultQemuMachineOptions+",confidential-guest-support="+pefID+",cap-ccf-assist=off", q.qemuMachine.Options)
+	assert.Contains(q.kernelParams, Param{"svm", "on"})
+}
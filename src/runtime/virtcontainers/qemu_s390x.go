@@ -10,7 +10,7 @@ import (
 	"fmt"
 	"time"
 
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
@@ -232,6 +232,8 @@ func (q *qemuS390x) appendRNGDevice(ctx context.Context, devices []govmmQemu.Dev
 		govmmQemu.RngDevice{
 			ID:       rngDev.ID,
 			Filename: rngDev.Filename,
+			MaxBytes: uint(rngDev.MaxBytes),
+			Period:   uint(rngDev.Period),
 			DevNo:    devno,
 		},
 	)
@@ -239,6 +241,28 @@ func (q *qemuS390x) appendRNGDevice(ctx context.Context, devices []govmmQemu.Dev
 	return devices, nil
 }
 
+func (q *qemuS390x) appendBalloonDevice(ctx context.Context, devices []govmmQemu.Device, id string) ([]govmmQemu.Device, error) {
+	addr, b, err := q.addDeviceToBridge(ctx, id, types.CCW)
+	if err != nil {
+		return devices, fmt.Errorf("Failed to append balloon device %v", err)
+	}
+	var devno string
+	devno, err = b.AddressFormatCCW(addr)
+	if err != nil {
+		return devices, fmt.Errorf("Failed to append balloon device %v", err)
+	}
+
+	devices = append(devices,
+		govmmQemu.BalloonDevice{
+			ID:           id,
+			DeflateOnOOM: true,
+			DevNo:        devno,
+		},
+	)
+
+	return devices, nil
+}
+
 func (q *qemuS390x) append9PVolume(ctx context.Context, devices []govmmQemu.Device, volume types.Volume) ([]govmmQemu.Device, error) {
 	if volume.MountTag == "" || volume.HostPath == "" {
 		return devices, nil
@@ -11,9 +11,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
 
-	govmmQemu "github.com/kata-containers/govmm/qemu"
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
@@ -585,3 +586,317 @@ func TestQemuGetpids(t *testing.T) {
 	assert.True(pids[0] == 100)
 	assert.True(pids[1] == 200)
 }
+
+func TestQemuHotplugIOThread(t *testing.T) {
+	assert := assert.New(t)
+
+	qmp := &mockIOThreadQMP{threads: map[string]bool{}}
+	q := &qemu{}
+	ioThread, added, err := q.hotplugIOThread(qmp)
+	assert.NoError(err)
+	assert.Empty(ioThread)
+	assert.False(added)
+
+	q.config.HotplugIOThreads = 2
+
+	// the iothreads are added on demand
+	for _, expected := range []string{"0", "1"} {
+		ioThread, added, err = q.hotplugIOThread(qmp)
+		assert.NoError(err)
+		assert.True(added)
+		assert.Equal(hotplugIOThreadPrefix+expected, ioThread)
+		assert.True(qmp.threads[ioThread])
+	}
+
+	// then reused round-robin
+	for _, expected := range []string{"0", "1", "0"} {
+		ioThread, added, err = q.hotplugIOThread(qmp)
+		assert.NoError(err)
+		assert.False(added)
+		assert.Equal(hotplugIOThreadPrefix+expected, ioThread)
+	}
+
+	// an iothread is removed with its last device
+	q.state.HotplugIOThreads = map[string]string{
+		"drive-a": hotplugIOThreadPrefix + "0",
+		"drive-b": hotplugIOThreadPrefix + "0",
+		"drive-c": hotplugIOThreadPrefix + "1",
+	}
+	assert.NoError(q.releaseIOThread(qmp, "drive-a"))
+	assert.True(qmp.threads[hotplugIOThreadPrefix+"0"])
+	assert.NoError(q.releaseIOThread(qmp, "drive-b"))
+	assert.False(qmp.threads[hotplugIOThreadPrefix+"0"])
+	assert.Equal([]string{hotplugIOThreadPrefix + "1"}, q.ioThreads)
+	assert.NoError(q.releaseIOThread(qmp, "unknown"))
+
+	// the freed ID is used by the next iothread
+	ioThread, added, err = q.hotplugIOThread(qmp)
+	assert.NoError(err)
+	assert.True(added)
+	assert.Equal(hotplugIOThreadPrefix+"0", ioThread)
+
+	// errors are reported, and the iothreads known after a restart
+	// are picked up
+	q = &qemu{config: HypervisorConfig{HotplugIOThreads: 2}}
+	qmp.err = errors.New("qmp error")
+	_, _, err = q.hotplugIOThread(qmp)
+	assert.Error(err)
+
+	qmp.err = nil
+	qmp.threads = map[string]bool{hotplugIOThreadPrefix + "0": true, hotplugIOThreadPrefix + "1": true}
+	ioThread, added, err = q.hotplugIOThread(qmp)
+	assert.NoError(err)
+	assert.False(added)
+	assert.Len(q.ioThreads, 2)
+
+	q.state.HotplugIOThreads = map[string]string{"drive-a": ioThread}
+	qmp.err = errors.New("qmp error")
+	assert.Error(q.releaseIOThread(qmp, "drive-a"))
+}
+
+type mockIOThreadQMP struct {
+	threads map[string]bool
+	err     error
+}
+
+func (m *mockIOThreadQMP) ExecQueryIOThreads(ctx context.Context) ([]govmmQemu.IOThreadInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var infos []govmmQemu.IOThreadInfo
+	for id := range m.threads {
+		infos = append(infos, govmmQemu.IOThreadInfo{ID: id})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos, nil
+}
+
+func (m *mockIOThreadQMP) ExecuteIOThreadAdd(ctx context.Context, id string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.threads[id] = true
+	return nil
+}
+
+func (m *mockIOThreadQMP) ExecuteIOThreadDel(ctx context.Context, id string) error {
+	if m.err != nil {
+		return m.err
+	}
+	delete(m.threads, id)
+	return nil
+}
+
+func TestQemuBlockdevOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		config: HypervisorConfig{
+			BlockDeviceCacheSet:     true,
+			BlockDeviceCacheNoflush: true,
+		},
+	}
+
+	options := q.blockdevOptions(&config.BlockDrive{Cache: "none", Discard: true, DetectZeroes: "unmap"})
+	assert.Equal(govmmQemu.BlockdevOptions{CacheSet: true, CacheDirect: true, Discard: true, DetectZeroes: "unmap"}, options)
+
+	options = q.blockdevOptions(&config.BlockDrive{Cache: "writeback"})
+	assert.Equal(govmmQemu.BlockdevOptions{CacheSet: true}, options)
+
+	// The hypervisor cache options apply without a cache mode
+	options = q.blockdevOptions(&config.BlockDrive{DetectZeroes: "on"})
+	assert.Equal(govmmQemu.BlockdevOptions{CacheSet: true, CacheNoFlush: true, DetectZeroes: "on"}, options)
+}
+
+func TestNewGuestMemoryStats(t *testing.T) {
+	assert := assert.New(t)
+
+	info := govmmQemu.BalloonInfo{Actual: 2048 << 20}
+
+	// The guest didn't report the statistics yet
+	stats := newGuestMemoryStats(info, govmmQemu.BalloonGuestStats{
+		Stats: map[string]int64{"stat-free-memory": -1},
+	})
+	assert.Equal(map[string]uint64{"actual": 2048 << 20}, stats.Stats)
+
+	stats = newGuestMemoryStats(info, govmmQemu.BalloonGuestStats{
+		LastUpdate: 1634000000,
+		Stats: map[string]int64{
+			"stat-available-memory": 1024 << 20,
+			"stat-swap-in":          4096,
+			"stat-major-faults":     42,
+			"stat-htlb-pgalloc":     -1,
+		},
+	})
+	assert.Equal(map[string]uint64{
+		"actual":           2048 << 20,
+		"available_memory": 1024 << 20,
+		"swap_in":          4096,
+		"major_faults":     42,
+	}, stats.Stats)
+}
+
+func TestCheckFileReadOnly(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	image := filepath.Join(tmpdir, "image")
+	assert.NoError(ioutil.WriteFile(image, []byte("image"), 0644))
+
+	// not opened
+	assert.NoError(checkFileReadOnly(os.Getpid(), image))
+
+	f, err := os.Open(image)
+	assert.NoError(err)
+	assert.NoError(checkFileReadOnly(os.Getpid(), image))
+	f.Close()
+
+	f, err = os.OpenFile(image, os.O_RDWR, 0)
+	assert.NoError(err)
+	assert.Error(checkFileReadOnly(os.Getpid(), image))
+	f.Close()
+
+	// the image path is resolved
+	link := filepath.Join(tmpdir, "link")
+	assert.NoError(os.Symlink(image, link))
+	f, err = os.OpenFile(image, os.O_WRONLY, 0)
+	assert.NoError(err)
+	assert.Error(checkFileReadOnly(os.Getpid(), link))
+	f.Close()
+
+	assert.Error(checkFileReadOnly(os.Getpid(), filepath.Join(tmpdir, "foo")))
+}
+
+func TestQemuCPUModel(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{
+		arch: &qemuArchBase{},
+	}
+	assert.Equal(defaultCPUModel, q.cpuModel())
+
+	q.config.CPUFeatures = "pmu=off"
+	assert.Equal(defaultCPUModel+",pmu=off", q.cpuModel())
+
+	q.config.CPUModel = "Cascadelake-Server"
+	q.config.CPUFeatures = "-kvm-steal-time,+avx512f"
+	assert.Equal("Cascadelake-Server,-kvm-steal-time,+avx512f", q.cpuModel())
+}
+
+func TestValidateCPUModel(t *testing.T) {
+	assert := assert.New(t)
+
+	x86Help := `Available CPUs:
+x86 486                   (alias configured by machine type)
+x86 Cascadelake-Server    Intel Xeon Processor (Cascadelake)
+x86 host                  KVM processor with all supported host features
+
+Recognized CPUID flags:
+  3dnow avx512f kvm-steal-time
+  vmx
+`
+	arm64Help := `Available CPUs:
+  cortex-a57
+  host
+  max
+`
+
+	type testData struct {
+		cpuHelp   string
+		model     string
+		flags     []string
+		expectErr bool
+	}
+
+	data := []testData{
+		{x86Help, "", nil, false},
+		{x86Help, "Cascadelake-Server", nil, false},
+		{x86Help, "host", []string{"kvm-steal-time", "avx512f"}, false},
+		{x86Help, "Intel", nil, true},
+		{x86Help, "Cascadelake-Server", []string{"avx512"}, true},
+		{arm64Help, "cortex-a57", nil, false},
+		{arm64Help, "cortex-a53", nil, true},
+		// no flags are listed
+		{arm64Help, "max", []string{"foo"}, false},
+	}
+
+	for i, d := range data {
+		err := validateCPUModel(d.cpuHelp, d.model, d.flags)
+		if d.expectErr {
+			assert.Error(err, "test %d (%+v)", i, d)
+		} else {
+			assert.NoError(err, "test %d (%+v)", i, d)
+		}
+	}
+
+	// nothing to check
+	assert.NoError(checkCPUModel("/does/not/exist", "", "pmu=off"))
+	assert.Error(checkCPUModel("/does/not/exist", "host", ""))
+
+	// the "qemu -cpu help" output is only listed once
+	cpuHelpCache.Lock()
+	cpuHelpCache.output["/cached/qemu"] = "x86 host  KVM processor with all supported host features\n"
+	cpuHelpCache.Unlock()
+	defer func() {
+		cpuHelpCache.Lock()
+		delete(cpuHelpCache.output, "/cached/qemu")
+		cpuHelpCache.Unlock()
+	}()
+	assert.NoError(checkCPUModel("/cached/qemu", "host", ""))
+	assert.Error(checkCPUModel("/cached/qemu", "Skylake-Server", ""))
+}
+
+func TestQemuQMPEventRouter(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{config: newQemuConfig()}
+	router := q.qmpEventRouter()
+
+	var ioErrors []govmmQemu.BlockIOErrorEvent
+	var names []string
+	router.OnBlockIOError(func(e govmmQemu.BlockIOErrorEvent) {
+		ioErrors = append(ioErrors, e)
+	})
+	unsubscribe := router.Subscribe("", func(e govmmQemu.QMPEvent) {
+		names = append(names, e.Name)
+	})
+
+	events := make(chan govmmQemu.QMPEvent, 3)
+	events <- govmmQemu.QMPEvent{
+		Name: govmmQemu.EventBlockIOError,
+		Data: map[string]interface{}{
+			"device":    "",
+			"node-name": "drive-1",
+			"operation": "write",
+			"action":    "report",
+			"nospace":   true,
+			"reason":    "No space left on device",
+		},
+	}
+	events <- govmmQemu.QMPEvent{
+		Name: govmmQemu.EventRTCChange,
+		Data: map[string]interface{}{"offset": float64(42)},
+	}
+	close(events)
+	router.Run(events)
+
+	assert.Equal([]string{govmmQemu.EventBlockIOError, govmmQemu.EventRTCChange}, names)
+	assert.Equal([]govmmQemu.BlockIOErrorEvent{{
+		NodeName:  "drive-1",
+		Operation: "write",
+		Action:    "report",
+		NoSpace:   true,
+		Reason:    "No space left on device",
+	}}, ioErrors)
+
+	// Unsubscribed handlers are no longer called
+	unsubscribe()
+	events = make(chan govmmQemu.QMPEvent, 1)
+	events <- govmmQemu.QMPEvent{Name: govmmQemu.EventDeviceDeleted}
+	close(events)
+	router.Run(events)
+	assert.Len(names, 2)
+}
@@ -14,9 +14,12 @@ import (
 	"math"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/containerd/cgroups"
 	"github.com/containernetworking/plugins/pkg/ns"
@@ -111,6 +114,10 @@ type SandboxConfig struct {
 	// with e.g. reverse domain notation (org.clearlinux.key).
 	Annotations map[string]string
 
+	// Labels are the key/value metadata of the sandbox, e.g. its tenant,
+	// which can be updated while it runs and are persisted with its state.
+	Labels map[string]string
+
 	ShmSize uint64
 
 	// SharePidNs sets all containers to share the same sandbox level pid namespace.
@@ -130,6 +137,9 @@ type SandboxConfig struct {
 	// Experimental features enabled
 	Experimental []exp.Feature
 
+	// Hooks are the host binaries run at the sandbox lifecycle points
+	Hooks SandboxHooks
+
 	// Cgroups specifies specific cgroup settings for the various subsystems that the container is
 	// placed into to limit the resources the container has available
 	Cgroups *configs.Cgroup
@@ -182,11 +192,13 @@ type Sandbox struct {
 
 	annotationsLock *sync.RWMutex
 
+	labels     map[string]string
+	labelsLock sync.RWMutex
+
 	wg *sync.WaitGroup
 
 	shmSize           uint64
 	sharePidNs        bool
-	seccompSupported  bool
 	disableVMShutdown bool
 
 	cgroupMgr *vccgroups.Manager
@@ -194,8 +206,42 @@ type Sandbox struct {
 	ctx context.Context
 
 	cw *consoleWatcher
+
+	// hypervisorLog keeps the last output of the hypervisor and of the
+	// guest console.
+	hypervisorLog *hypervisorLog
+
+	journal *eventJournal
+
+	boot *bootTimer
+
+	// coldPlugging is set while the devices are cold plugged, before the
+	// VM boots.
+	coldPlugging bool
+
+	// virtiofsdRestarts counts the restarts of virtiofsd after it quit.
+	virtiofsdRestarts uint32
+
+	// hotplugLock serializes the hypervisor device operations and
+	// blockIndexLock the block index allocations, the devices of a
+	// container being attached concurrently.
+	hotplugLock    sync.Mutex
+	blockIndexLock sync.Mutex
+
+	// agentAway is set while the guest is not running in this sandbox
+	// VM, i.e. while it waits for an incoming live migration and once it
+	// switched over to the destination. The agent can't be reached then.
+	agentAway int32
+
+	// vmResources are the resources of the VM, as last resized.
+	vmResources VMResources
 }
 
+// encryptedScratchImage is the name of the backing file of the scratch disk
+// of a confidential emptyDir, created in the emptyDir itself so that it is
+// accounted to the pod and removed with it.
+const encryptedScratchImage = ".kata-encrypted-scratch.img"
+
 // ID returns the sandbox identifier string.
 func (s *Sandbox) ID() string {
 	return s.id
@@ -426,9 +472,6 @@ func (s *Sandbox) getAndStoreGuestDetails(ctx context.Context) error {
 
 	if guestDetailRes != nil {
 		s.state.GuestMemoryBlockSizeMB = uint32(guestDetailRes.MemBlockSizeBytes >> 20)
-		if guestDetailRes.AgentDetails != nil {
-			s.seccompSupported = guestDetailRes.AgentDetails.SupportsSeccomp
-		}
 		s.state.GuestMemoryHotplugProbe = guestDetailRes.SupportMemHotplugProbe
 	}
 
@@ -476,6 +519,7 @@ func createSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Fac
 	if err := s.setSandboxState(types.StateReady); err != nil {
 		return nil, err
 	}
+	s.journal.record(JournalSandboxCreated, "", "sandbox created")
 
 	return s, nil
 }
@@ -490,6 +534,17 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 		return nil, fmt.Errorf("Invalid sandbox configuration")
 	}
 
+	if err := validSandboxLabels(sandboxConfig.Labels); err != nil {
+		return nil, err
+	}
+
+	// The hypervisor is constrained apart from the containers with the
+	// cgroup v1 hierarchies only.
+	if !sandboxConfig.SandboxCgroupOnly && vccgroups.UnifiedMode() {
+		virtLog.WithField("sandbox", sandboxConfig.ID).Info("Enabling sandbox_cgroup_only, required by the cgroup v2 hosts")
+		sandboxConfig.SandboxCgroupOnly = true
+	}
+
 	// create agent instance
 	agent := getNewAgentFunc(ctx)()
 
@@ -508,19 +563,29 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 		containers:      map[string]*Container{},
 		state:           types.SandboxState{BlockIndexMap: make(map[int]struct{})},
 		annotationsLock: &sync.RWMutex{},
+		labels:          sandboxConfig.Labels,
 		wg:              &sync.WaitGroup{},
 		shmSize:         sandboxConfig.ShmSize,
 		sharePidNs:      sandboxConfig.SharePidNs,
 		networkNS:       NetworkNamespace{NetNsPath: sandboxConfig.NetworkConfig.NetNSPath},
 		ctx:             ctx,
+		boot:            &bootTimer{},
+		hypervisorLog:   newHypervisorLog(),
 	}
 
 	hypervisor.setSandbox(s)
 
+	// The vhost-user backends need to access the guest memory.
+	if len(sandboxConfig.NetworkConfig.VhostUserNetSockets) > 0 {
+		sandboxConfig.HypervisorConfig.VhostUserNet = true
+	}
+
 	if s.store, err = persist.GetDriver(); err != nil || s.store == nil {
 		return nil, fmt.Errorf("failed to get fs persist driver: %v", err)
 	}
 
+	s.journal = newEventJournal(filepath.Join(s.store.RunStoragePath(), s.id))
+
 	defer func() {
 		if retErr != nil {
 			s.Logger().WithError(retErr).Error("Create new sandbox failed")
@@ -535,7 +600,8 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 
 	s.devManager = deviceManager.NewDeviceManager(sandboxConfig.HypervisorConfig.BlockDeviceDriver,
 		sandboxConfig.HypervisorConfig.EnableVhostUserStore,
-		sandboxConfig.HypervisorConfig.VhostUserStorePath, nil)
+		sandboxConfig.HypervisorConfig.VhostUserStorePath,
+		sandboxConfig.HypervisorConfig.EnableMultipath, nil)
 
 	// Ignore the error. Restore can fail for a new sandbox
 	if err := s.Restore(); err != nil {
@@ -543,9 +609,11 @@ func newSandbox(ctx context.Context, sandboxConfig SandboxConfig, factory Factor
 	}
 
 	// store doesn't require hypervisor to be stored immediately
+	s.boot.reset()
 	if err = s.hypervisor.createSandbox(ctx, s.id, s.networkNS, &sandboxConfig.HypervisorConfig); err != nil {
 		return nil, err
 	}
+	s.boot.mark(BootPhaseVMCreate)
 
 	if s.disableVMShutdown, err = s.agent.init(ctx, s, sandboxConfig.AgentConfig); err != nil {
 		return nil, err
@@ -655,6 +723,19 @@ func (s *Sandbox) findContainer(containerID string) (*Container, error) {
 		containerID, s.id)
 }
 
+// relinkContainerConfigs points the containers to their configuration in
+// the sandbox configuration, which moves when a container is added to or
+// removed from it. The resources of the containers are updated through
+// these pointers, and the sandbox VM is sized from the sandbox
+// configuration.
+func (s *Sandbox) relinkContainerConfigs() {
+	for i := range s.config.Containers {
+		if c, ok := s.containers[s.config.Containers[i].ID]; ok {
+			c.config = &s.config.Containers[i]
+		}
+	}
+}
+
 // removeContainer removes a container from the containers list held by the
 // sandbox structure, based on a container ID.
 func (s *Sandbox) removeContainer(containerID string) error {
@@ -767,7 +848,7 @@ func (s *Sandbox) createNetwork(ctx context.Context) error {
 		// Add the network
 		endpoints, err := s.network.Add(ctx, &s.config.NetworkConfig, s, false)
 		if err != nil {
-			return err
+			return vcTypes.NewError(vcTypes.ErrCodeNetworkSetup, err)
 		}
 
 		s.networkNS.Endpoints = endpoints
@@ -836,7 +917,7 @@ func (s *Sandbox) AddInterface(ctx context.Context, inf *pbTypes.Interface) (*pb
 		return nil, err
 	}
 
-	endpoint, err := createEndpoint(netInfo, len(s.networkNS.Endpoints), s.config.NetworkConfig.InterworkingModel, nil)
+	endpoint, err := createEndpoint(netInfo, len(s.networkNS.Endpoints), s.config.NetworkConfig.InterworkingModel, nil, s.config.NetworkConfig.VhostUserNetSockets)
 	if err != nil {
 		return nil, err
 	}
@@ -901,6 +982,10 @@ const (
 
 	// pty type of console.
 	consoleProtoPty = "pty"
+
+	// consoleRingSize is the number of guest console lines kept for
+	// diagnostics, e.g. when the guest kernel panics.
+	consoleRingSize = 512
 )
 
 // console watcher is designed to monitor guest console output.
@@ -909,6 +994,12 @@ type consoleWatcher struct {
 	consoleURL string
 	conn       net.Conn
 	ptyConsole *os.File
+
+	// ring holds the last consoleRingSize lines of the guest console,
+	// next is the index of the oldest one once ring is full.
+	ringLock sync.Mutex
+	ring     []string
+	next     int
 }
 
 func newConsoleWatcher(ctx context.Context, s *Sandbox) (*consoleWatcher, error) {
@@ -950,6 +1041,8 @@ func (cw *consoleWatcher) start(s *Sandbox) (err error) {
 
 	go func() {
 		for scanner.Scan() {
+			cw.record(scanner.Text())
+			s.hypervisorLog.add(hypervisorLogConsole, scanner.Text())
 			s.Logger().WithFields(logrus.Fields{
 				"console-protocol": cw.proto,
 				"console-url":      cw.consoleURL,
@@ -974,6 +1067,30 @@ func (cw *consoleWatcher) start(s *Sandbox) (err error) {
 	return nil
 }
 
+// record adds a guest console line to the console ring.
+func (cw *consoleWatcher) record(line string) {
+	cw.ringLock.Lock()
+	defer cw.ringLock.Unlock()
+
+	if len(cw.ring) < consoleRingSize {
+		cw.ring = append(cw.ring, line)
+		return
+	}
+
+	cw.ring[cw.next] = line
+	cw.next = (cw.next + 1) % consoleRingSize
+}
+
+// lines returns the guest console lines in the console ring, oldest first.
+func (cw *consoleWatcher) lines() []string {
+	cw.ringLock.Lock()
+	defer cw.ringLock.Unlock()
+
+	lines := make([]string, 0, len(cw.ring))
+	lines = append(lines, cw.ring[cw.next:]...)
+	return append(lines, cw.ring[:cw.next]...)
+}
+
 // check if the console watcher has already watched the vm console.
 func (cw *consoleWatcher) consoleWatched() bool {
 	return cw.conn != nil || cw.ptyConsole != nil
@@ -997,6 +1114,10 @@ func (s *Sandbox) startVM(ctx context.Context) (err error) {
 	span, ctx := katatrace.Trace(ctx, s.Logger(), "startVM", s.tracingTags())
 	defer span.End()
 
+	defer func() {
+		err = s.withHypervisorLog(err)
+	}()
+
 	s.Logger().Info("Starting VM")
 
 	if s.config.HypervisorConfig.Debug {
@@ -1008,6 +1129,26 @@ func (s *Sandbox) startVM(ctx context.Context) (err error) {
 		s.cw = consoleWatcher
 	}
 
+	if s.factory == nil {
+		s.setVMMCgroup()
+	}
+
+	if err := s.runHooks(ctx, HookPreVMStart); err != nil {
+		return err
+	}
+
+	if s.factory == nil {
+		if err := checkGuestArtifacts(&s.config.HypervisorConfig, s.Logger()); err != nil {
+			return vcTypes.NewError(vcTypes.ErrCodeHypervisorLaunch, err)
+		}
+
+		if err := s.checkConfidentialIDs(); err != nil {
+			return vcTypes.NewError(vcTypes.ErrCodeHypervisorLaunch, err)
+		}
+	}
+
+	s.boot.reset()
+	launchStart := time.Now()
 	if err := s.network.Run(ctx, s.networkNS.NetNsPath, func() error {
 		if s.factory != nil {
 			vm, err := s.factory.GetVM(ctx, VMConfig{
@@ -1024,8 +1165,10 @@ func (s *Sandbox) startVM(ctx context.Context) (err error) {
 
 		return s.hypervisor.startSandbox(ctx, vmStartTimeout)
 	}); err != nil {
-		return err
+		return vcTypes.NewError(vcTypes.ErrCodeHypervisorLaunch, err)
 	}
+	s.boot.mark(BootPhaseVMCreate)
+	observeDuration(ctx, hypervisorLaunchDurationsHistogram, time.Since(launchStart))
 
 	defer func() {
 		if err != nil {
@@ -1033,12 +1176,16 @@ func (s *Sandbox) startVM(ctx context.Context) (err error) {
 		}
 	}()
 
+	if err := s.runHooks(ctx, HookPostVMStart); err != nil {
+		return err
+	}
+
 	// In case of vm factory, network interfaces are hotplugged
 	// after vm is started.
 	if s.factory != nil {
 		endpoints, err := s.network.Add(ctx, &s.config.NetworkConfig, s, true)
 		if err != nil {
-			return err
+			return vcTypes.NewError(vcTypes.ErrCodeNetworkSetup, err)
 		}
 
 		s.networkNS.Endpoints = endpoints
@@ -1051,6 +1198,7 @@ func (s *Sandbox) startVM(ctx context.Context) (err error) {
 	}
 
 	s.Logger().Info("VM started")
+	s.journal.record(JournalVMStarted, "", "%s VM started", s.config.HypervisorType)
 
 	if s.cw != nil {
 		s.Logger().Debug("console watcher starts")
@@ -1060,6 +1208,13 @@ func (s *Sandbox) startVM(ctx context.Context) (err error) {
 		}
 	}
 
+	// The guest, including the agent sandbox, comes with the incoming
+	// live migration, see MigrationSwitchover.
+	if s.config.HypervisorConfig.MigrationIncoming {
+		s.Logger().Info("VM waiting for an incoming migration")
+		return nil
+	}
+
 	// Once the hypervisor is done starting the sandbox,
 	// we want to guarantee that it is manageable.
 	// For that we need to ask the agent to start the
@@ -1068,9 +1223,15 @@ func (s *Sandbox) startVM(ctx context.Context) (err error) {
 		return err
 	}
 
+	s.boot.mark(BootPhaseAgentReady)
 	s.Logger().Info("Agent started in the sandbox")
+	s.journal.record(JournalAgentStarted, "", "agent started in the VM")
 
-	return nil
+	if err := s.seedGuestRNG(ctx); err != nil {
+		return err
+	}
+
+	return s.runHooks(ctx, HookPostAgentReady)
 }
 
 // stopVM: stop the sandbox's VM
@@ -1078,6 +1239,10 @@ func (s *Sandbox) stopVM(ctx context.Context) error {
 	span, ctx := katatrace.Trace(ctx, s.Logger(), "stopVM", s.tracingTags())
 	defer span.End()
 
+	if err := s.runHooks(ctx, HookPreVMStop); err != nil {
+		s.Logger().WithError(err).Warning("pre-vm-stop hook failed")
+	}
+
 	s.Logger().Info("Stopping sandbox in the VM")
 	if err := s.agent.stopSandbox(ctx, s); err != nil {
 		s.Logger().WithError(err).WithField("sandboxid", s.id).Warning("Agent did not stop sandbox")
@@ -1103,6 +1268,7 @@ func (s *Sandbox) addContainer(c *Container) error {
 func (s *Sandbox) CreateContainer(ctx context.Context, contConfig ContainerConfig) (VCContainer, error) {
 	// Update sandbox config to include the new container's config
 	s.config.Containers = append(s.config.Containers, contConfig)
+	s.relinkContainerConfigs()
 
 	var err error
 
@@ -1179,6 +1345,7 @@ func (s *Sandbox) StartContainer(ctx context.Context, containerID string) (VCCon
 	if err != nil {
 		return nil, err
 	}
+	s.boot.mark(BootPhaseWorkloadStart)
 
 	if err = s.storeSandbox(ctx); err != nil {
 		return nil, err
@@ -1260,6 +1427,7 @@ func (s *Sandbox) DeleteContainer(ctx context.Context, containerID string) (VCCo
 			break
 		}
 	}
+	s.relinkContainerConfigs()
 
 	// update the sandbox cgroup
 	if err = s.cgroupsUpdate(ctx); err != nil {
@@ -1359,6 +1527,10 @@ func (s *Sandbox) Stats(ctx context.Context) (SandboxStats, error) {
 		return SandboxStats{}, fmt.Errorf("sandbox cgroup path is empty")
 	}
 
+	if s.config.SandboxCgroupOnly && vccgroups.UnifiedMode() {
+		return s.unifiedStats(ctx)
+	}
+
 	var path string
 	var cgroupSubsystems cgroups.Hierarchy
 
@@ -1393,6 +1565,31 @@ func (s *Sandbox) Stats(ctx context.Context) (SandboxStats, error) {
 	return stats, nil
 }
 
+// unifiedStats returns the stats of a running sandbox on the cgroup v2 hosts,
+// from its cgroup manager.
+func (s *Sandbox) unifiedStats(ctx context.Context) (SandboxStats, error) {
+	if s.cgroupMgr == nil {
+		return SandboxStats{}, fmt.Errorf("sandbox cgroup manager is not set up")
+	}
+
+	metrics, err := s.cgroupMgr.GetStats()
+	if err != nil {
+		return SandboxStats{}, fmt.Errorf("Could not get the stats of the sandbox cgroup %v: %v", s.state.CgroupPath, err)
+	}
+
+	stats := SandboxStats{}
+
+	stats.CgroupStats.CPUStats.CPUUsage.TotalUsage = metrics.CpuStats.CpuUsage.TotalUsage
+	stats.CgroupStats.MemoryStats.Usage.Usage = metrics.MemoryStats.Usage.Usage
+	tids, err := s.hypervisor.getThreadIDs(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Cpus = len(tids.vcpus)
+
+	return stats, nil
+}
+
 // PauseContainer pauses a running container.
 func (s *Sandbox) PauseContainer(ctx context.Context, containerID string) error {
 	// Fetch the container.
@@ -1471,6 +1668,10 @@ func (s *Sandbox) createContainers(ctx context.Context) error {
 // Start starts a sandbox. The containers that are making the sandbox
 // will be started.
 func (s *Sandbox) Start(ctx context.Context) error {
+	if s.config.HypervisorConfig.MigrationIncoming && s.isAgentAway() {
+		return errors.New("the sandbox is waiting for an incoming migration, it must be switched over first")
+	}
+
 	if err := s.state.ValidTransition(s.state.State, types.StateRunning); err != nil {
 		return err
 	}
@@ -1488,16 +1689,23 @@ func (s *Sandbox) Start(ctx context.Context) error {
 		}
 	}()
 	for _, c := range s.containers {
+		// The containers of a migrated sandbox already run in the guest.
+		if c.state.State == types.StateRunning {
+			continue
+		}
+
 		if startErr = c.start(ctx); startErr != nil {
 			return startErr
 		}
 	}
+	s.boot.mark(BootPhaseWorkloadStart)
 
 	if err := s.storeSandbox(ctx); err != nil {
 		return err
 	}
 
 	s.Logger().Info("Sandbox is started")
+	s.journal.record(JournalSandboxStarted, "", "sandbox started")
 
 	return nil
 }
@@ -1524,10 +1732,26 @@ func (s *Sandbox) Stop(ctx context.Context, force bool) error {
 		}
 	}
 
+	if err := s.detachEncryptedScratchDevices(ctx); err != nil && !force {
+		return err
+	}
+
+	if err := s.detachGuestImageStoreDevice(ctx); err != nil && !force {
+		return err
+	}
+
 	if err := s.stopVM(ctx); err != nil && !force {
 		return err
 	}
 
+	if err := s.bindVFIODevicesToHost(); err != nil && !force {
+		return err
+	}
+
+	if err := s.removeVFIOMdevDevices(); err != nil && !force {
+		return err
+	}
+
 	// shutdown console watcher if exists
 	if s.cw != nil {
 		s.Logger().Debug("stop the console watcher")
@@ -1537,6 +1761,7 @@ func (s *Sandbox) Stop(ctx context.Context, force bool) error {
 	if err := s.setSandboxState(types.StateStopped); err != nil {
 		return err
 	}
+	s.journal.record(JournalSandboxStopped, "", "sandbox stopped")
 
 	// Remove the network.
 	if err := s.removeNetwork(ctx); err != nil && !force {
@@ -1584,6 +1809,9 @@ const maxBlockIndex = 65535
 // the BlockIndexMap and marks it as used. This index is used to maintain the
 // index at which a block device is assigned to a container in the sandbox.
 func (s *Sandbox) getAndSetSandboxBlockIndex() (int, error) {
+	s.blockIndexLock.Lock()
+	defer s.blockIndexLock.Unlock()
+
 	currentIndex := -1
 	for i := 0; i < maxBlockIndex; i++ {
 		if _, ok := s.state.BlockIndexMap[i]; !ok {
@@ -1602,6 +1830,9 @@ func (s *Sandbox) getAndSetSandboxBlockIndex() (int, error) {
 // unsetSandboxBlockIndex deletes the current sandbox block index from BlockIndexMap.
 // This is used to recover from failure while adding a block device.
 func (s *Sandbox) unsetSandboxBlockIndex(index int) error {
+	s.blockIndexLock.Lock()
+	defer s.blockIndexLock.Unlock()
+
 	var err error
 	original := index
 	delete(s.state.BlockIndexMap, index)
@@ -1614,12 +1845,97 @@ func (s *Sandbox) unsetSandboxBlockIndex(index int) error {
 	return nil
 }
 
+// encryptedScratchDevice returns the scratch disk of the confidential emptyDir
+// hostPath, attached to the VM by the first container using it. The disk is
+// backed by a sparse file of size bytes.
+func (s *Sandbox) encryptedScratchDevice(ctx context.Context, hostPath string, size int64) (api.Device, error) {
+	image := filepath.Join(hostPath, encryptedScratchImage)
+
+	for _, dev := range s.encryptedScratchDevices() {
+		if dev.GetDeviceInfo().(*config.BlockDrive).File == image {
+			return dev, nil
+		}
+	}
+
+	f, err := os.OpenFile(image, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	err = f.Truncate(size)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	dev, err := s.devManager.NewDevice(config.DeviceInfo{
+		HostPath:      image,
+		ContainerPath: hostPath,
+		DevType:       "b",
+		// not a host block device
+		Major: -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.devManager.AttachDevice(ctx, dev.DeviceID(), s); err != nil {
+		s.devManager.RemoveDevice(dev.DeviceID())
+		return nil, fmt.Errorf("failed to attach the scratch disk of %s: %v", hostPath, err)
+	}
+
+	return dev, nil
+}
+
+// encryptedScratchDevices returns the attached scratch disks of the
+// confidential emptyDirs.
+func (s *Sandbox) encryptedScratchDevices() []api.Device {
+	var devices []api.Device
+	if s.devManager == nil {
+		return devices
+	}
+
+	for _, dev := range s.devManager.GetAllDevices() {
+		if dev.DeviceType() != config.DeviceBlock {
+			continue
+		}
+
+		drive, ok := dev.GetDeviceInfo().(*config.BlockDrive)
+		if ok && drive != nil && filepath.Base(drive.File) == encryptedScratchImage {
+			devices = append(devices, dev)
+		}
+	}
+
+	return devices
+}
+
+// detachEncryptedScratchDevices detaches the scratch disks of the
+// confidential emptyDirs, once the containers are stopped.
+func (s *Sandbox) detachEncryptedScratchDevices(ctx context.Context) error {
+	for _, dev := range s.encryptedScratchDevices() {
+		if err := s.devManager.DetachDevice(ctx, dev.DeviceID(), s); err != nil {
+			return err
+		}
+
+		if err := s.devManager.RemoveDevice(dev.DeviceID()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // HotplugAddDevice is used for add a device to sandbox
 // Sandbox implement DeviceReceiver interface from device/api/interface.go
-func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devType config.DeviceType) error {
+func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devType config.DeviceType) (err error) {
 	span, ctx := katatrace.Trace(ctx, s.Logger(), "HotplugAddDevice", s.tracingTags())
 	defer span.End()
 
+	defer func() {
+		if err == nil {
+			s.journal.record(JournalDeviceHotplugged, "", "%s device %s (%s)", devType, device.DeviceID(), device.GetHostPath())
+		}
+	}()
+
 	if s.config.SandboxCgroupOnly {
 		// We are about to add a device to the hypervisor,
 		// the device cgroup MUST be updated since the hypervisor
@@ -1631,6 +1947,16 @@ func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devTy
 		}
 	}
 
+	s.hotplugLock.Lock()
+	defer s.hotplugLock.Unlock()
+
+	if s.config.HypervisorConfig.ColdPlugDevices && devType != config.DeviceGeneric {
+		if !s.coldPlugging {
+			return s.errColdPlugOnly(device, devType)
+		}
+		return s.coldPlugDevice(ctx, device, devType)
+	}
+
 	switch devType {
 	case config.DeviceVFIO:
 		vfioDevices, ok := device.GetDeviceInfo().([]*config.VFIODev)
@@ -1647,7 +1973,7 @@ func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devTy
 						"vfio-device-ID":  dev.ID,
 						"vfio-device-BDF": dev.BDF,
 					}).WithError(err).Error("failed to hotplug VFIO device")
-				return err
+				return vcTypes.NewError(vcTypes.ErrCodeDeviceHotplug, err)
 			}
 		}
 		return nil
@@ -1657,14 +1983,14 @@ func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devTy
 			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
 		}
 		_, err := s.hypervisor.hotplugAddDevice(ctx, blockDevice.BlockDrive, blockDev)
-		return err
+		return vcTypes.NewError(vcTypes.ErrCodeDeviceHotplug, err)
 	case config.VhostUserBlk:
 		vhostUserBlkDevice, ok := device.(*drivers.VhostUserBlkDevice)
 		if !ok {
 			return fmt.Errorf("device type mismatch, expect device type to be %s", devType)
 		}
 		_, err := s.hypervisor.hotplugAddDevice(ctx, vhostUserBlkDevice.VhostUserDeviceAttrs, vhostuserDev)
-		return err
+		return vcTypes.NewError(vcTypes.ErrCodeDeviceHotplug, err)
 	case config.DeviceGeneric:
 		// TODO: what?
 		return nil
@@ -1674,8 +2000,12 @@ func (s *Sandbox) HotplugAddDevice(ctx context.Context, device api.Device, devTy
 
 // HotplugRemoveDevice is used for removing a device from sandbox
 // Sandbox implement DeviceReceiver interface from device/api/interface.go
-func (s *Sandbox) HotplugRemoveDevice(ctx context.Context, device api.Device, devType config.DeviceType) error {
+func (s *Sandbox) HotplugRemoveDevice(ctx context.Context, device api.Device, devType config.DeviceType) (err error) {
 	defer func() {
+		if err == nil {
+			s.journal.record(JournalDeviceUnplugged, "", "%s device %s (%s)", devType, device.DeviceID(), device.GetHostPath())
+		}
+
 		if s.config.SandboxCgroupOnly {
 			// Remove device from cgroup, the hypervisor
 			// should not have access to such device anymore.
@@ -1687,6 +2017,9 @@ func (s *Sandbox) HotplugRemoveDevice(ctx context.Context, device api.Device, de
 		}
 	}()
 
+	s.hotplugLock.Lock()
+	defer s.hotplugLock.Unlock()
+
 	switch devType {
 	case config.DeviceVFIO:
 		vfioDevices, ok := device.GetDeviceInfo().([]*config.VFIODev)
@@ -1765,6 +2098,133 @@ func (s *Sandbox) AppendDevice(ctx context.Context, device api.Device) error {
 	return fmt.Errorf("unsupported device type")
 }
 
+// attachVFIOAPDevices attaches the VFIO-AP mediated devices of the sandbox
+// configuration, they are kept for the sandbox lifetime. They are attached
+// before the VM boots with cold plugged devices.
+func (s *Sandbox) attachVFIOAPDevices(ctx context.Context) error {
+	if s.config.HypervisorConfig.ColdPlugDevices {
+		return nil
+	}
+
+	for _, sysfsDev := range s.config.HypervisorConfig.VFIOAPDevices {
+		info, err := vfioAPDeviceInfo(sysfsDev)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.AddDevice(ctx, *info); err != nil {
+			return fmt.Errorf("failed to attach VFIO-AP device %s: %v", sysfsDev, err)
+		}
+	}
+
+	return nil
+}
+
+// attachVFIOBindDevices binds the PCI devices of the sandbox configuration
+// to vfio-pci and attaches their VFIO groups, they are kept for the sandbox
+// lifetime. They are attached before the VM boots with cold plugged devices.
+func (s *Sandbox) attachVFIOBindDevices(ctx context.Context) error {
+	if s.config.HypervisorConfig.ColdPlugDevices {
+		return nil
+	}
+
+	for _, sysfsDev := range s.config.HypervisorConfig.VFIOBindDevices {
+		info, err := vfioBindDeviceInfo(sysfsDev)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.AddDevice(ctx, *info); err != nil {
+			return fmt.Errorf("failed to attach PCI device %s: %v", sysfsDev, err)
+		}
+	}
+
+	return nil
+}
+
+// attachVFIOMdevDevices attaches the VFIO groups of the mediated devices of
+// the sandbox configuration, creating the ones requested by their type, they
+// are kept for the sandbox lifetime. They are attached before the VM boots
+// with cold plugged devices.
+func (s *Sandbox) attachVFIOMdevDevices(ctx context.Context) error {
+	if s.config.HypervisorConfig.ColdPlugDevices {
+		return nil
+	}
+
+	for _, sysfsDev := range s.config.HypervisorConfig.VFIOMdevDevices {
+		info, err := vfioMdevDeviceInfo(sysfsDev)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.AddDevice(ctx, *info); err != nil {
+			return fmt.Errorf("failed to attach mediated device %s: %v", sysfsDev, err)
+		}
+	}
+
+	return nil
+}
+
+// removeVFIOMdevDevices removes the mediated devices created by the device
+// manager, once the VM is stopped.
+func (s *Sandbox) removeVFIOMdevDevices() error {
+	if s.devManager == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, dev := range s.devManager.GetAllDevices() {
+		vfioDev, ok := dev.(*drivers.VFIODevice)
+		if !ok {
+			continue
+		}
+
+		var remaining []string
+		for _, sysfsDev := range vfioDev.CreatedMdevs {
+			if err := drivers.RemoveMediatedDevice(sysfsDev); err != nil {
+				s.Logger().WithError(err).WithField("mdev-sysfsdev", sysfsDev).Error("failed to remove mediated device")
+				remaining = append(remaining, sysfsDev)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		vfioDev.CreatedMdevs = remaining
+	}
+
+	return firstErr
+}
+
+// bindVFIODevicesToHost binds the PCI devices bound to vfio-pci by the
+// device manager back to their host driver, once the VM is stopped.
+func (s *Sandbox) bindVFIODevicesToHost() error {
+	if s.devManager == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, dev := range s.devManager.GetAllDevices() {
+		vfioDev, ok := dev.(*drivers.VFIODevice)
+		if !ok {
+			continue
+		}
+
+		var remaining []config.PCIHostBinding
+		for _, binding := range vfioDev.HostBindings {
+			if err := drivers.BindPCIDeviceToHost(binding); err != nil {
+				s.Logger().WithError(err).WithField("device-bdf", binding.BDF).Error("failed to bind device back to host driver")
+				remaining = append(remaining, binding)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
+		}
+		vfioDev.HostBindings = remaining
+	}
+
+	return firstErr
+}
+
 // AddDevice will add a device to sandbox
 func (s *Sandbox) AddDevice(ctx context.Context, info config.DeviceInfo) (api.Device, error) {
 	if s.devManager == nil {
@@ -1820,12 +2280,67 @@ func (s *Sandbox) updateResources(ctx context.Context) error {
 	// Add default / rsvd memory for sandbox.
 	sandboxMemoryByte += int64(s.hypervisor.hypervisorConfig().MemorySize) << utils.MibToBytesShift
 
+	_, err = s.resizeVM(ctx, VMResources{
+		VCPUs:    sandboxVCPUs,
+		MemoryMB: uint32(sandboxMemoryByte >> utils.MibToBytesShift),
+	})
+	return err
+}
+
+// VMResources are the vCPUs and the memory of a sandbox VM.
+type VMResources struct {
+	VCPUs    uint32
+	MemoryMB uint32
+}
+
+// VMResources returns the resources of the sandbox VM.
+func (s *Sandbox) VMResources() VMResources {
+	if s.vmResources.VCPUs == 0 {
+		s.vmResources = VMResources{
+			VCPUs:    s.config.HypervisorConfig.NumVCPUs,
+			MemoryMB: s.config.HypervisorConfig.MemorySize,
+		}
+	}
+
+	return s.vmResources
+}
+
+// ResizeVM hotplugs or unplugs the vCPUs and the memory of the sandbox VM to
+// reach target, whatever the resources of its containers, e.g. for an
+// in-place pod resize. The resources of the containers apply again when one
+// of them is updated. It returns the resources of the VM, which may differ
+// from target on failure or when the memory cannot be unplugged.
+func (s *Sandbox) ResizeVM(ctx context.Context, target VMResources) (VMResources, error) {
+	if target.VCPUs == 0 || target.MemoryMB == 0 {
+		return s.VMResources(), fmt.Errorf("invalid VM resources %+v", target)
+	}
+
+	if max := s.config.HypervisorConfig.DefaultMaxVCPUs; max != 0 && target.VCPUs > max {
+		return s.VMResources(), fmt.Errorf("%d vCPUs requested, the VM has at most %d", target.VCPUs, max)
+	}
+
+	resources, err := s.resizeVM(ctx, target)
+	s.journal.record(JournalVMResized, "", "%d vCPUs, %d MB", resources.VCPUs, resources.MemoryMB)
+
+	if storeErr := s.storeSandbox(ctx); storeErr != nil && err == nil {
+		err = storeErr
+	}
+
+	return resources, err
+}
+
+// resizeVM resizes the VM to target and has the agent online the added
+// vCPUs and memory.
+func (s *Sandbox) resizeVM(ctx context.Context, target VMResources) (VMResources, error) {
+	s.VMResources()
+
 	// Update VCPUs
-	s.Logger().WithField("cpus-sandbox", sandboxVCPUs).Debugf("Request to hypervisor to update vCPUs")
-	oldCPUs, newCPUs, err := s.hypervisor.resizeVCPUs(ctx, sandboxVCPUs)
+	s.Logger().WithField("cpus-sandbox", target.VCPUs).Debugf("Request to hypervisor to update vCPUs")
+	oldCPUs, newCPUs, err := s.hypervisor.resizeVCPUs(ctx, target.VCPUs)
 	if err != nil {
-		return err
+		return s.vmResources, err
 	}
+	s.vmResources.VCPUs = newCPUs
 
 	s.Logger().Debugf("Request to hypervisor to update oldCPUs/newCPUs: %d/%d", oldCPUs, newCPUs)
 	// If the CPUs were increased, ask agent to online them
@@ -1833,19 +2348,22 @@ func (s *Sandbox) updateResources(ctx context.Context) error {
 		vcpusAdded := newCPUs - oldCPUs
 		s.Logger().Debugf("Request to onlineCPUMem with %d CPUs", vcpusAdded)
 		if err := s.agent.onlineCPUMem(ctx, vcpusAdded, true); err != nil {
-			return err
+			return s.vmResources, err
 		}
 	}
 	s.Logger().Debugf("Sandbox CPUs: %d", newCPUs)
 
 	// Update Memory
-	s.Logger().WithField("memory-sandbox-size-byte", sandboxMemoryByte).Debugf("Request to hypervisor to update memory")
-	newMemory, updatedMemoryDevice, err := s.hypervisor.resizeMemory(ctx, uint32(sandboxMemoryByte>>utils.MibToBytesShift), s.state.GuestMemoryBlockSizeMB, s.state.GuestMemoryHotplugProbe)
+	s.Logger().WithField("memory-sandbox-size-mb", target.MemoryMB).Debugf("Request to hypervisor to update memory")
+	newMemory, updatedMemoryDevice, err := s.hypervisor.resizeMemory(ctx, target.MemoryMB, s.state.GuestMemoryBlockSizeMB, s.state.GuestMemoryHotplugProbe)
+	if newMemory != 0 {
+		s.vmResources.MemoryMB = newMemory
+	}
 	if err != nil {
 		if err == noGuestMemHotplugErr {
 			s.Logger().Warnf("%s, memory specifications cannot be guaranteed", err)
 		} else {
-			return err
+			return s.vmResources, err
 		}
 	}
 	s.Logger().Debugf("Sandbox memory size: %d MB", newMemory)
@@ -1853,13 +2371,13 @@ func (s *Sandbox) updateResources(ctx context.Context) error {
 		// notify the guest kernel about memory hot-add event, before onlining them
 		s.Logger().Debugf("notify guest kernel memory hot-add event via probe interface, memory device located at 0x%x", updatedMemoryDevice.addr)
 		if err := s.agent.memHotplugByProbe(ctx, updatedMemoryDevice.addr, uint32(updatedMemoryDevice.sizeMB), s.state.GuestMemoryBlockSizeMB); err != nil {
-			return err
+			return s.vmResources, err
 		}
 	}
 	if err := s.agent.onlineCPUMem(ctx, 0, false); err != nil {
-		return err
+		return s.vmResources, err
 	}
-	return nil
+	return s.vmResources, nil
 }
 
 func (s *Sandbox) calculateSandboxMemory() int64 {
@@ -1871,7 +2389,7 @@ func (s *Sandbox) calculateSandboxMemory() int64 {
 			continue
 		}
 
-		if m := c.Resources.Memory; m != nil && m.Limit != nil {
+		if m := c.Resources.Memory; m != nil && m.Limit != nil && *m.Limit > 0 {
 			memorySandbox += *m.Limit
 		}
 	}
@@ -1937,7 +2455,7 @@ func (s *Sandbox) cgroupsUpdate(ctx context.Context) error {
 			return err
 		}
 
-		return nil
+		return s.setupThreadCgroups(ctx)
 	}
 
 	if s.state.CgroupPath == "" {
@@ -2091,6 +2609,53 @@ func (s *Sandbox) constrainHypervisor(ctx context.Context, cgroup cgroups.Cgroup
 	return nil
 }
 
+// setupThreadCgroups places the hypervisor emulator, vCPU and iothreads into
+// child cgroups of the sandbox cgroup, for the thread kinds that have a cpu
+// weight configured, so that they do not starve each other under contention.
+// The VMM must already be in the sandbox cgroup, i.e. SandboxCgroupOnly.
+func (s *Sandbox) setupThreadCgroups(ctx context.Context) error {
+	hconfig := s.config.HypervisorConfig
+	if hconfig.EmulatorThreadsWeight == 0 &&
+		hconfig.VCPUThreadsWeight == 0 &&
+		hconfig.IOThreadsWeight == 0 {
+		return nil
+	}
+
+	pids := s.hypervisor.getPids()
+	if len(pids) == 0 || pids[0] <= 0 {
+		return fmt.Errorf("Invalid hypervisor PID: %+v", pids)
+	}
+
+	// vCPUs and iothreads can be hotplugged, query them every time.
+	tids, err := s.hypervisor.getThreadIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get thread ids from hypervisor: %v", err)
+	}
+
+	groups, err := hypervisorThreadGroups(pids[0], tids)
+	if err != nil {
+		return err
+	}
+
+	weights := map[string]uint64{
+		threadGroupEmulator: hconfig.EmulatorThreadsWeight,
+		threadGroupVCPU:     hconfig.VCPUThreadsWeight,
+		threadGroupIOThread: hconfig.IOThreadsWeight,
+	}
+
+	for _, name := range []string{threadGroupEmulator, threadGroupVCPU, threadGroupIOThread} {
+		if weights[name] == 0 {
+			continue
+		}
+
+		if err := s.cgroupMgr.SetThreadGroup(name, weights[name], groups[name]); err != nil {
+			return fmt.Errorf("Could not setup %s threads cgroup: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
 func (s *Sandbox) resources() (specs.LinuxResources, error) {
 	resources := specs.LinuxResources{
 		CPU: s.cpuResources(),
@@ -2161,6 +2726,26 @@ func (s *Sandbox) cpuResources() *specs.LinuxCPU {
 	return validCPUResources(cpu)
 }
 
+// setVMMCgroup makes the hypervisor start the VMM directly in the sandbox
+// cgroup when the host supports it, rather than relying on the VMM
+// inheriting the cgroup of the runtime.
+func (s *Sandbox) setVMMCgroup() {
+	if s.state.CgroupPath == "" ||
+		VMMCgroupMechanism(s.config.HypervisorType, s.config.SandboxCgroupOnly) != VMMCgroupClone {
+		return
+	}
+
+	path, err := vccgroups.UnifiedPath(s.state.CgroupPath)
+	if err != nil {
+		s.Logger().WithError(err).Info("VMM not cloned into the sandbox cgroup")
+		return
+	}
+
+	if s.hypervisor.setVMMCgroup(path) {
+		s.Logger().WithField("cgroup", path).Debug("VMM cloned into the sandbox cgroup")
+	}
+}
+
 // setupSandboxCgroup creates and joins sandbox cgroups for the sandbox config
 func (s *Sandbox) setupSandboxCgroup() error {
 	var err error
@@ -2228,13 +2813,175 @@ func (s *Sandbox) GetPatchedOCISpec() *specs.Spec {
 }
 
 func (s *Sandbox) GetOOMEvent(ctx context.Context) (string, error) {
-	return s.agent.getOOMEvent(ctx)
+	containerID, err := s.agent.getOOMEvent(ctx)
+	if err == nil {
+		s.journal.record(JournalOOM, containerID, "container hit its memory limit")
+	}
+	return containerID, err
 }
 
 func (s *Sandbox) GetAgentURL() (string, error) {
 	return s.agent.getAgentURL()
 }
 
+// PortForward returns a connection to a TCP port of the sandbox, tunnelled
+// through the agent. This doesn't depend on the sandbox network.
+func (s *Sandbox) PortForward(ctx context.Context, port uint32) (net.Conn, error) {
+	if s.config.HypervisorConfig.ConfidentialGuest {
+		return nil, errors.New("port forwarding is not supported by confidential guests")
+	}
+
+	return s.agent.portForward(ctx, port)
+}
+
+// PolicyDecisions returns a connection streaming the decisions of the agent
+// policy, a JSON object per line.
+func (s *Sandbox) PolicyDecisions(ctx context.Context) (net.Conn, error) {
+	return s.agent.policyDecisions(ctx)
+}
+
+// MigrationPrepareReceive makes the sandbox, created waiting for an incoming
+// live migration, receive it on uri.
+func (s *Sandbox) MigrationPrepareReceive(ctx context.Context, uri string) error {
+	if uri == "" {
+		return errors.New("missing migration uri")
+	}
+
+	if err := s.hypervisor.prepareMigrationIncoming(ctx, uri); err != nil {
+		return err
+	}
+
+	s.journal.record(JournalMigrationStarted, "", "receiving migration on %s", uri)
+	return nil
+}
+
+// MigrationStart starts the live migration of the sandbox to uri, where the
+// destination sandbox is receiving it. The migration is paused before the
+// switchover to the destination, see MigrationSwitchover.
+func (s *Sandbox) MigrationStart(ctx context.Context, uri string) error {
+	if uri == "" {
+		return errors.New("missing migration uri")
+	}
+
+	if err := s.hypervisor.startMigration(ctx, uri); err != nil {
+		return err
+	}
+
+	s.journal.record(JournalMigrationStarted, "", "migrating to %s", uri)
+	return nil
+}
+
+// SyncGuestFilesystems has the agent write the dirty data of the guest file
+// systems, e.g. before the sandbox is stopped for a node maintenance.
+func (s *Sandbox) SyncGuestFilesystems(ctx context.Context) error {
+	return s.agent.syncFilesystems(ctx)
+}
+
+// MigrationStatus returns the hypervisor status of the sandbox live
+// migration, e.g. "active", "pre-switchover" or "completed".
+func (s *Sandbox) MigrationStatus(ctx context.Context) (string, error) {
+	return s.hypervisor.migrationStatus(ctx)
+}
+
+// MigrationSwitchover completes the live migration of the sandbox. On the
+// source sandbox, it switches over to the destination once the migration
+// reached the "pre-switchover" status, the source VM stays paused. On the
+// destination sandbox, it waits for the migration to complete and for the
+// agent to be reachable, then it restores the migrated containers.
+func (s *Sandbox) MigrationSwitchover(ctx context.Context) error {
+	if err := s.hypervisor.migrationSwitchover(ctx); err != nil {
+		return err
+	}
+
+	if !s.config.HypervisorConfig.MigrationIncoming {
+		// The guest now runs on the destination, the source VM is paused.
+		s.setAgentAway(true)
+
+		if err := s.setSandboxState(types.StatePaused); err != nil {
+			return err
+		}
+
+		if err := s.storeSandbox(ctx); err != nil {
+			return err
+		}
+
+		s.journal.record(JournalMigrationDone, "", "migration completed")
+		return nil
+	}
+
+	if err := s.agent.setAgentURL(); err != nil {
+		return err
+	}
+
+	if err := s.agent.check(ctx); err != nil {
+		return err
+	}
+	s.setAgentAway(false)
+
+	// The guest clock stood still while the VM was moved.
+	if s.config.AgentConfig.TimeSyncInterval > 0 {
+		if err := s.agent.setGuestDateTime(ctx, time.Now()); err != nil {
+			s.Logger().WithError(err).Warn("Could not sync guest time")
+		}
+	}
+
+	if err := s.getAndStoreGuestDetails(ctx); err != nil {
+		return err
+	}
+
+	if err := s.restoreMigratedContainers(ctx); err != nil {
+		return err
+	}
+
+	s.journal.record(JournalMigrationDone, "", "migration completed")
+	return nil
+}
+
+// restoreMigratedContainers adds the containers of the sandbox, which came
+// with the incoming live migration and already run in the guest.
+func (s *Sandbox) restoreMigratedContainers(ctx context.Context) error {
+	for i := range s.config.Containers {
+		c, err := newContainer(ctx, s, &s.config.Containers[i])
+		if err != nil {
+			return err
+		}
+
+		// The agent process of a container is identified by the
+		// container ID, see kataAgent.createContainer.
+		process, err := buildProcessFromExecID(c.id)
+		if err != nil {
+			return err
+		}
+		c.process = *process
+
+		if err := s.addContainer(c); err != nil {
+			return err
+		}
+
+		if err := c.setContainerState(types.StateRunning); err != nil {
+			return err
+		}
+	}
+
+	if err := s.cgroupsUpdate(ctx); err != nil {
+		return err
+	}
+
+	return s.storeSandbox(ctx)
+}
+
+func (s *Sandbox) isAgentAway() bool {
+	return atomic.LoadInt32(&s.agentAway) == 1
+}
+
+func (s *Sandbox) setAgentAway(away bool) {
+	var v int32
+	if away {
+		v = 1
+	}
+	atomic.StoreInt32(&s.agentAway, v)
+}
+
 // getSandboxCPUSet returns the union of each of the sandbox's containers' CPU sets'
 // cpus and mems as a string in canonical linux CPU/mems list format
 func (s *Sandbox) getSandboxCPUSet() (string, string, error) {
@@ -0,0 +1,152 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+)
+
+// Sandbox lifecycle points the sandbox hooks are run at
+const (
+	HookPreVMStart     = "pre-vm-start"
+	HookPostVMStart    = "post-vm-start"
+	HookPostAgentReady = "post-agent-ready"
+	HookPreVMStop      = "pre-vm-stop"
+)
+
+// defaultSandboxHookTimeout bounds the run of a sandbox hook when no
+// timeout is configured.
+const defaultSandboxHookTimeout = 10 * time.Second
+
+// SandboxHooks are the host binaries run at the sandbox lifecycle points.
+// Like the OCI hooks, but for the sandbox rather than for a container, each
+// hook gets the SandboxHookState in JSON on its stdin, and the lifecycle
+// point as its only argument.
+type SandboxHooks struct {
+	// PreVMStart hooks are run before the VM is launched
+	PreVMStart []string
+
+	// PostVMStart hooks are run once the VM is launched, before the agent
+	// is reached
+	PostVMStart []string
+
+	// PostAgentReady hooks are run once the agent set up the sandbox
+	PostAgentReady []string
+
+	// PreVMStop hooks are run before the VM is stopped, their failures are
+	// only logged
+	PreVMStop []string
+
+	// Timeout bounds the run of each hook, defaultSandboxHookTimeout when 0
+	Timeout time.Duration
+}
+
+// SandboxHookState is the state of the sandbox passed to the sandbox hooks
+type SandboxHookState struct {
+	ID            string            `json:"id"`
+	Hook          string            `json:"hook"`
+	HypervisorPid int               `json:"hypervisor_pid,omitempty"`
+	NetNSPath     string            `json:"netns,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+func (h *SandboxHooks) hooks(point string) []string {
+	switch point {
+	case HookPreVMStart:
+		return h.PreVMStart
+	case HookPostVMStart:
+		return h.PostVMStart
+	case HookPostAgentReady:
+		return h.PostAgentReady
+	case HookPreVMStop:
+		return h.PreVMStop
+	}
+
+	return nil
+}
+
+func (s *Sandbox) hookState(point string) SandboxHookState {
+	state := SandboxHookState{
+		ID:        s.id,
+		Hook:      point,
+		NetNSPath: s.networkNS.NetNsPath,
+	}
+
+	if pid, err := s.GetHypervisorPid(); err == nil {
+		state.HypervisorPid = pid
+	}
+
+	s.annotationsLock.RLock()
+	defer s.annotationsLock.RUnlock()
+
+	if len(s.config.Annotations) > 0 {
+		state.Annotations = make(map[string]string, len(s.config.Annotations))
+		for k, v := range s.config.Annotations {
+			state.Annotations[k] = v
+		}
+	}
+
+	return state
+}
+
+// runHooks runs the sandbox hooks of the lifecycle point in order, up to the
+// first failing one.
+func (s *Sandbox) runHooks(ctx context.Context, point string) error {
+	hooks := s.config.Hooks.hooks(point)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	span, ctx := katatrace.Trace(ctx, s.Logger(), "runHooks", s.tracingTags())
+	katatrace.AddTag(span, "hook", point)
+	defer span.End()
+
+	stateJSON, err := json.Marshal(s.hookState(point))
+	if err != nil {
+		return err
+	}
+
+	timeout := s.config.Hooks.Timeout
+	if timeout == 0 {
+		timeout = defaultSandboxHookTimeout
+	}
+
+	for _, hook := range hooks {
+		if err := runSandboxHook(ctx, hook, point, stateJSON, timeout); err != nil {
+			return fmt.Errorf("%s hook %s failed: %v", point, hook, err)
+		}
+		s.Logger().WithField("hook", hook).Debugf("%s hook done", point)
+	}
+
+	return nil
+}
+
+func runSandboxHook(ctx context.Context, hook, point string, state []byte, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, hook, point)
+	cmd.Stdin = bytes.NewReader(state)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %v", timeout)
+		}
+		return fmt.Errorf("%v: stdout: %s, stderr: %s", err, stdout.String(), stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeHookScript(t *testing.T, dir, name, script string) string {
+	path := filepath.Join(dir, name)
+	err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0700)
+	assert.NoError(t, err)
+	return path
+}
+
+func TestSandboxRunHooks(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "sandbox-hooks")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	statePath := filepath.Join(dir, "state.json")
+	argPath := filepath.Join(dir, "arg")
+	hook := writeHookScript(t, dir, "hook", fmt.Sprintf("echo -n $1 > %s\ncat > %s", argPath, statePath))
+
+	s := &Sandbox{
+		id:              "sandbox-hooks",
+		hypervisor:      &mockHypervisor{mockPid: 1234},
+		annotationsLock: &sync.RWMutex{},
+		config: &SandboxConfig{
+			Annotations: map[string]string{"io.katacontainers.test": "value"},
+			Hooks:       SandboxHooks{PostAgentReady: []string{hook}},
+		},
+	}
+
+	// No hook at this point
+	assert.NoError(s.runHooks(context.Background(), HookPreVMStart))
+	_, err = os.Stat(statePath)
+	assert.True(os.IsNotExist(err))
+
+	assert.NoError(s.runHooks(context.Background(), HookPostAgentReady))
+
+	arg, err := ioutil.ReadFile(argPath)
+	assert.NoError(err)
+	assert.Equal(HookPostAgentReady, string(arg))
+
+	data, err := ioutil.ReadFile(statePath)
+	assert.NoError(err)
+	var state SandboxHookState
+	assert.NoError(json.Unmarshal(data, &state))
+	assert.Equal(SandboxHookState{
+		ID:            "sandbox-hooks",
+		Hook:          HookPostAgentReady,
+		HypervisorPid: 1234,
+		Annotations:   map[string]string{"io.katacontainers.test": "value"},
+	}, state)
+
+	// A failing hook stops the run of the following ones
+	failing := writeHookScript(t, dir, "failing", "echo unreachable >&2; exit 1")
+	os.Remove(argPath)
+	s.config.Hooks.PreVMStart = []string{failing, hook}
+	err = s.runHooks(context.Background(), HookPreVMStart)
+	assert.Error(err)
+	assert.Contains(err.Error(), "unreachable")
+	_, err = os.Stat(argPath)
+	assert.True(os.IsNotExist(err))
+
+	// A hook running past the timeout is killed
+	s.config.Hooks.PreVMStop = []string{writeHookScript(t, dir, "sleeping", "exec sleep 10")}
+	s.config.Hooks.Timeout = 100 * time.Millisecond
+	err = s.runHooks(context.Background(), HookPreVMStop)
+	assert.Error(err)
+	assert.Contains(err.Error(), "timed out")
+}
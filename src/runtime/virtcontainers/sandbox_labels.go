@@ -0,0 +1,105 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxSandboxLabels bounds the labels of a sandbox, they are persisted
+	// with its state.
+	maxSandboxLabels = 64
+
+	maxSandboxLabelKeyLen   = 253
+	maxSandboxLabelValueLen = 4096
+)
+
+var sandboxLabelKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$`)
+
+// validSandboxLabel checks that a sandbox label can be set: its key is made
+// of alphanumerics, '-', '_', '.' and '/', starting and ending with an
+// alphanumeric.
+func validSandboxLabel(key, value string) error {
+	if len(key) > maxSandboxLabelKeyLen || !sandboxLabelKeyRegexp.MatchString(key) {
+		return fmt.Errorf("invalid sandbox label key %q", key)
+	}
+
+	if len(value) > maxSandboxLabelValueLen {
+		return fmt.Errorf("sandbox label %q: value longer than %d bytes", key, maxSandboxLabelValueLen)
+	}
+
+	return nil
+}
+
+// validSandboxLabels checks that the labels of a sandbox can be set.
+func validSandboxLabels(labels map[string]string) error {
+	if len(labels) > maxSandboxLabels {
+		return fmt.Errorf("too many sandbox labels: %d, the maximum is %d", len(labels), maxSandboxLabels)
+	}
+
+	for key, value := range labels {
+		if err := validSandboxLabel(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Labels returns a copy of the labels of the sandbox, the key/value metadata
+// set by its creator and the management tools, persisted with its state.
+func (s *Sandbox) Labels() map[string]string {
+	s.labelsLock.RLock()
+	defer s.labelsLock.RUnlock()
+
+	labels := make(map[string]string, len(s.labels))
+	for key, value := range s.labels {
+		labels[key] = value
+	}
+
+	return labels
+}
+
+// UpdateLabels sets the labels of set and removes the ones of remove, then
+// persists them. It returns the labels of the sandbox once updated, none is
+// changed when one of them is invalid.
+func (s *Sandbox) UpdateLabels(ctx context.Context, set map[string]string, remove []string) (map[string]string, error) {
+	s.labelsLock.Lock()
+
+	// The labels are updated on a copy, kept unchanged when one is invalid.
+	labels := make(map[string]string, len(s.labels)+len(set))
+	for key, value := range s.labels {
+		labels[key] = value
+	}
+	for _, key := range remove {
+		delete(labels, key)
+	}
+	for key, value := range set {
+		labels[key] = value
+	}
+
+	if err := validSandboxLabels(labels); err != nil {
+		s.labelsLock.Unlock()
+		return s.Labels(), err
+	}
+
+	s.labels = labels
+	s.labelsLock.Unlock()
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	s.journal.record(JournalLabelsUpdated, "", "set [%s], removed [%s]", strings.Join(keys, " "), strings.Join(remove, " "))
+
+	return s.Labels(), s.storeSandbox(ctx)
+}
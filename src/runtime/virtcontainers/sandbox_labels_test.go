@@ -0,0 +1,77 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidSandboxLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validSandboxLabels(nil))
+	assert.NoError(validSandboxLabels(map[string]string{
+		"tenant":                   "acme",
+		"example.com/billing-code": "42",
+		"empty":                    "",
+	}))
+
+	for _, key := range []string{"", "-tenant", "tenant/", "ten ant", "tenant=acme", strings.Repeat("a", maxSandboxLabelKeyLen+1)} {
+		assert.Error(validSandboxLabels(map[string]string{key: "value"}), key)
+	}
+
+	assert.Error(validSandboxLabels(map[string]string{"tenant": strings.Repeat("a", maxSandboxLabelValueLen+1)}))
+}
+
+func TestSandboxUpdateLabels(t *testing.T) {
+	assert := assert.New(t)
+	ctx := WithNewAgentFunc(context.Background(), newMockAgent)
+
+	defer cleanUp()
+
+	config := SandboxConfig{
+		ID:               testSandboxID,
+		HypervisorType:   MockHypervisor,
+		HypervisorConfig: newHypervisorConfig(nil, nil),
+		Annotations:      sandboxAnnotations,
+		Labels:           map[string]string{"-invalid": "value"},
+	}
+
+	_, err := createSandbox(ctx, config, nil)
+	assert.Error(err)
+
+	config.Labels = map[string]string{"tenant": "acme", "team": "storage"}
+	s, err := createSandbox(ctx, config, nil)
+	assert.NoError(err)
+	assert.Equal(config.Labels, s.Labels())
+
+	labels, err := s.UpdateLabels(ctx, map[string]string{"billing-code": "42", "tenant": "initech"}, []string{"team"})
+	assert.NoError(err)
+	expected := map[string]string{"tenant": "initech", "billing-code": "42"}
+	assert.Equal(expected, labels)
+
+	// An invalid label changes none of them
+	labels, err = s.UpdateLabels(ctx, map[string]string{"tenant": "acme", "/invalid": "value"}, nil)
+	assert.Error(err)
+	assert.Equal(expected, labels)
+
+	// The labels are persisted
+	ss, _, err := s.store.FromDisk(s.id)
+	assert.NoError(err)
+	assert.Equal(expected, ss.Labels)
+
+	s.labels = nil
+	assert.NoError(s.Restore())
+	assert.Equal(expected, s.Labels())
+
+	events, err := s.Journal()
+	assert.NoError(err)
+	assert.Equal(JournalLabelsUpdated, events[len(events)-1].Type)
+}
@@ -7,17 +7,59 @@ package virtcontainers
 
 import (
 	"context"
+	"time"
 
 	mutils "github.com/kata-containers/kata-containers/src/runtime/pkg/utils"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/pkg/agent/protocols/grpc"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/procfs"
+	otelTrace "go.opentelemetry.io/otel/trace"
 )
 
 const namespaceHypervisor = "kata_hypervisor"
 const namespaceKatashim = "kata_shim"
 const namespaceVirtiofsd = "kata_virtiofsd"
 
+// exemplarTraceID is the exemplar label holding the trace ID of an
+// observation.
+const exemplarTraceID = "trace_id"
+
+var (
+	defaultAgentRPCBuckets         = prometheus.ExponentialBuckets(1, 2, 10)
+	defaultHypervisorLaunchBuckets = prometheus.ExponentialBuckets(50, 2, 8)
+)
+
+// MetricsConfig is the configuration of the sandbox metrics.
+type MetricsConfig struct {
+	// AgentRPCBuckets are the bucket boundaries, in milliseconds, of the
+	// agent RPC durations histogram.
+	AgentRPCBuckets []float64
+
+	// HypervisorLaunchBuckets are the bucket boundaries, in milliseconds,
+	// of the hypervisor launch durations histogram.
+	HypervisorLaunchBuckets []float64
+}
+
+func newAgentRPCDurationsHistogram(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespaceKatashim,
+		Name:      "agent_rpc_durations_histogram_milliseconds",
+		Help:      "RPC latency distributions.",
+		Buckets:   buckets,
+	},
+		[]string{"action"},
+	)
+}
+
+func newHypervisorLaunchDurationsHistogram(buckets []float64) prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespaceHypervisor,
+		Name:      "launch_durations_histogram_milliseconds",
+		Help:      "Hypervisor launch latency distributions.",
+		Buckets:   buckets,
+	})
+}
+
 var (
 	// hypervisor
 	hypervisorThreads = prometheus.NewGauge(prometheus.GaugeOpts{
@@ -64,14 +106,26 @@ var (
 		Help:      "Open FDs for hypervisor.",
 	})
 
+	hypervisorLaunchDurationsHistogram = newHypervisorLaunchDurationsHistogram(defaultHypervisorLaunchBuckets)
+
 	// agent
-	agentRPCDurationsHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	agentRPCDurationsHistogram = newAgentRPCDurationsHistogram(defaultAgentRPCBuckets)
+
+	// sandbox
+	sandboxBootTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: namespaceKatashim,
-		Name:      "agent_rpc_durations_histogram_milliseconds",
-		Help:      "RPC latency distributions.",
-		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		Name:      "boot_time_milliseconds",
+		Help:      "Sandbox boot time breakdown.",
 	},
-		[]string{"action"},
+		[]string{"phase"},
+	)
+
+	confidentialIDs = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespaceKatashim,
+		Name:      "confidential_ids",
+		Help:      "Hardware IDs of the confidential guests, SEV ASIDs or TDX key IDs, of the host (capacity, used) and of the sandbox.",
+	},
+		[]string{"resource", "item"},
 	)
 
 	// virtiofsd
@@ -112,6 +166,19 @@ var (
 	})
 )
 
+// ConfigureMetrics applies the metrics configuration. It must be called
+// before creating the sandbox, as the histograms are replaced when their
+// buckets are configured.
+func ConfigureMetrics(config MetricsConfig) {
+	if len(config.AgentRPCBuckets) > 0 {
+		agentRPCDurationsHistogram = newAgentRPCDurationsHistogram(config.AgentRPCBuckets)
+	}
+
+	if len(config.HypervisorLaunchBuckets) > 0 {
+		hypervisorLaunchDurationsHistogram = newHypervisorLaunchDurationsHistogram(config.HypervisorLaunchBuckets)
+	}
+}
+
 func RegisterMetrics() {
 	// hypervisor
 	prometheus.MustRegister(hypervisorThreads)
@@ -120,8 +187,12 @@ func RegisterMetrics() {
 	prometheus.MustRegister(hypervisorNetdev)
 	prometheus.MustRegister(hypervisorIOStat)
 	prometheus.MustRegister(hypervisorOpenFDs)
+	prometheus.MustRegister(hypervisorLaunchDurationsHistogram)
 	// agent
 	prometheus.MustRegister(agentRPCDurationsHistogram)
+	// sandbox
+	prometheus.MustRegister(sandboxBootTime)
+	prometheus.MustRegister(confidentialIDs)
 	// virtiofsd
 	prometheus.MustRegister(virtiofsdThreads)
 	prometheus.MustRegister(virtiofsdProcStatus)
@@ -132,6 +203,15 @@ func RegisterMetrics() {
 
 // UpdateRuntimeMetrics update shim/hypervisor's metrics
 func (s *Sandbox) UpdateRuntimeMetrics() error {
+	// sandbox boot times
+	for phase, d := range s.BootTimes().Phases() {
+		sandboxBootTime.WithLabelValues(phase).Set(float64(d) / float64(time.Millisecond))
+	}
+
+	if err := s.updateConfidentialIDsMetrics(); err != nil {
+		s.Logger().WithError(err).Warn("Could not update the confidential guest IDs metrics")
+	}
+
 	pids := s.hypervisor.getPids()
 	if len(pids) == 0 {
 		return nil
@@ -225,3 +305,23 @@ func (s *Sandbox) GetAgentMetrics(ctx context.Context) (string, error) {
 	}
 	return r.Metrics, nil
 }
+
+// GuestMemoryStats returns the guest memory statistics reported by the
+// virtio-balloon device of the sandbox VM, none when it has no such device.
+func (s *Sandbox) GuestMemoryStats(ctx context.Context) (GuestMemoryStats, error) {
+	return s.hypervisor.guestMemoryStats(ctx)
+}
+
+// observeDuration observes the duration in milliseconds, with the trace ID
+// of ctx as exemplar when tracing is enabled.
+func observeDuration(ctx context.Context, o prometheus.Observer, d time.Duration) {
+	v := float64(d.Nanoseconds() / int64(time.Millisecond))
+
+	sc := otelTrace.SpanContextFromContext(ctx)
+	if eo, ok := o.(prometheus.ExemplarObserver); ok && sc.HasTraceID() {
+		eo.ObserveWithExemplar(v, prometheus.Labels{exemplarTraceID: sc.TraceID.String()})
+		return
+	}
+
+	o.Observe(v)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestConfigureMetrics(t *testing.T) {
+	assert := assert.New(t)
+
+	savedAgent, savedHypervisor := agentRPCDurationsHistogram, hypervisorLaunchDurationsHistogram
+	defer func() {
+		agentRPCDurationsHistogram, hypervisorLaunchDurationsHistogram = savedAgent, savedHypervisor
+	}()
+
+	// nothing configured, keep the default histograms
+	ConfigureMetrics(MetricsConfig{})
+	assert.Equal(savedAgent, agentRPCDurationsHistogram)
+	assert.Equal(savedHypervisor, hypervisorLaunchDurationsHistogram)
+
+	ConfigureMetrics(MetricsConfig{
+		HypervisorLaunchBuckets: []float64{100, 1000},
+	})
+	assert.Equal(savedAgent, agentRPCDurationsHistogram)
+
+	hypervisorLaunchDurationsHistogram.Observe(500)
+
+	m := &dto.Metric{}
+	assert.NoError(hypervisorLaunchDurationsHistogram.Write(m))
+
+	buckets := m.GetHistogram().GetBucket()
+	assert.Len(buckets, 2)
+	assert.Equal(float64(100), buckets[0].GetUpperBound())
+	assert.Equal(uint64(0), buckets[0].GetCumulativeCount())
+	assert.Equal(float64(1000), buckets[1].GetUpperBound())
+	assert.Equal(uint64(1), buckets[1].GetCumulativeCount())
+}
+
+func TestObserveDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_durations",
+		Buckets: []float64{10, 100},
+	})
+
+	getExemplar := func() *dto.Exemplar {
+		m := &dto.Metric{}
+		assert.NoError(h.Write(m))
+		return m.GetHistogram().GetBucket()[1].GetExemplar()
+	}
+
+	// not traced
+	observeDuration(context.Background(), h, 50*time.Millisecond)
+	assert.Nil(getExemplar())
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	observeDuration(ctx, h, 60*time.Millisecond)
+
+	exemplar := getExemplar()
+	assert.NotNil(exemplar)
+	assert.Equal(float64(60), exemplar.GetValue())
+	assert.Len(exemplar.GetLabel(), 1)
+	assert.Equal(exemplarTraceID, exemplar.GetLabel()[0].GetName())
+	assert.Equal(span.SpanContext().TraceID.String(), exemplar.GetLabel()[0].GetValue())
+}
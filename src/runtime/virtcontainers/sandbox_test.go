@@ -544,7 +544,7 @@ func TestSandboxAttachDevicesVFIO(t *testing.T) {
 		config.SysIOMMUPath = savedIOMMUPath
 	}()
 
-	dm := manager.NewDeviceManager(manager.VirtioSCSI, false, "", nil)
+	dm := manager.NewDeviceManager(manager.VirtioSCSI, false, "", false, nil)
 	path := filepath.Join(vfioPath, testFDIOGroup)
 	deviceInfo := config.DeviceInfo{
 		HostPath:      path,
@@ -595,7 +595,7 @@ func TestSandboxAttachDevicesVhostUserBlk(t *testing.T) {
 	tmpDir, err := ioutil.TempDir("", "")
 	assert.Nil(t, err)
 	os.RemoveAll(tmpDir)
-	dm := manager.NewDeviceManager(manager.VirtioSCSI, true, tmpDir, nil)
+	dm := manager.NewDeviceManager(manager.VirtioSCSI, true, tmpDir, false, nil)
 
 	vhostUserDevNodePath := filepath.Join(tmpDir, "/block/devices/")
 	vhostUserSockPath := filepath.Join(tmpDir, "/block/sockets/")
@@ -903,6 +903,56 @@ func TestDeleteContainer(t *testing.T) {
 	assert.Nil(t, err, "Failed to delete container %s in sandbox %s: %v", contID, s.ID(), err)
 }
 
+func TestSandboxUpdateContainerResizesVM(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	s, err := testCreateSandbox(t, testSandboxID, MockHypervisor, newHypervisorConfig(nil, nil), NetworkConfig{}, nil, nil)
+	assert.NoError(err)
+	defer cleanUp()
+
+	assert.NoError(s.Start(ctx))
+
+	// Adding and removing containers moves their configuration in the
+	// sandbox configuration
+	for _, id := range []string{"100", "101", "102"} {
+		_, err = s.CreateContainer(ctx, newTestContainerConfigNoop(id))
+		assert.NoError(err)
+	}
+	_, err = s.DeleteContainer(ctx, "101")
+	assert.NoError(err)
+
+	for i := range s.config.Containers {
+		assert.True(s.containers[s.config.Containers[i].ID].config == &s.config.Containers[i])
+	}
+
+	limit := int64(512 << 20)
+	quota := int64(200000)
+	period := uint64(100000)
+	err = s.UpdateContainer(ctx, "100", specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &limit},
+		CPU:    &specs.LinuxCPU{Quota: &quota, Period: &period},
+	})
+	assert.NoError(err)
+
+	assert.Equal(limit, s.calculateSandboxMemory())
+	vcpus, err := s.calculateSandboxCPUs()
+	assert.NoError(err)
+	assert.Equal(uint32(2), vcpus)
+
+	// Updating the CPU keeps the memory limit
+	quota = int64(400000)
+	err = s.UpdateContainer(ctx, "100", specs.LinuxResources{
+		CPU: &specs.LinuxCPU{Quota: &quota, Period: &period},
+	})
+	assert.NoError(err)
+
+	assert.Equal(limit, s.calculateSandboxMemory())
+	vcpus, err = s.calculateSandboxCPUs()
+	assert.NoError(err)
+	assert.Equal(uint32(4), vcpus)
+}
+
 func TestStartContainer(t *testing.T) {
 	s, err := testCreateSandbox(t, testSandboxID, MockHypervisor, newHypervisorConfig(nil, nil), NetworkConfig{}, nil, nil)
 	assert.Nil(t, err, "VirtContainers should not allow empty sandboxes")
@@ -1193,7 +1243,7 @@ func TestAttachBlockDevice(t *testing.T) {
 		DevType:       "b",
 	}
 
-	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", nil)
+	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", false, nil)
 	device, err := dm.NewDevice(deviceInfo)
 	assert.Nil(t, err)
 	_, ok := device.(*drivers.BlockDevice)
@@ -1249,7 +1299,7 @@ func TestPreAddDevice(t *testing.T) {
 		HypervisorConfig: hConfig,
 	}
 
-	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", nil)
+	dm := manager.NewDeviceManager(config.VirtioBlock, false, "", false, nil)
 	// create a sandbox first
 	sandbox := &Sandbox{
 		id:         testSandboxID,
@@ -1444,6 +1494,40 @@ func TestSandboxUpdateResources(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSandboxResizeVM(t *testing.T) {
+	assert := assert.New(t)
+	hConfig := newHypervisorConfig(nil, nil)
+	hConfig.DefaultMaxVCPUs = 4
+
+	defer cleanUp()
+	s, err := testCreateSandbox(t,
+		testSandboxID,
+		MockHypervisor,
+		hConfig,
+		NetworkConfig{},
+		nil,
+		nil)
+	assert.NoError(err)
+
+	initial := VMResources{VCPUs: s.config.HypervisorConfig.NumVCPUs, MemoryMB: s.config.HypervisorConfig.MemorySize}
+	assert.Equal(initial, s.VMResources())
+
+	resources, err := s.ResizeVM(context.Background(), VMResources{VCPUs: 0, MemoryMB: 1024})
+	assert.Error(err)
+	assert.Equal(initial, resources)
+
+	resources, err = s.ResizeVM(context.Background(), VMResources{VCPUs: 8, MemoryMB: 1024})
+	assert.Error(err)
+	assert.Equal(initial, resources)
+
+	_, err = s.ResizeVM(context.Background(), VMResources{VCPUs: 2, MemoryMB: 1024})
+	assert.NoError(err)
+
+	events, err := s.Journal()
+	assert.NoError(err)
+	assert.Equal(JournalVMResized, events[len(events)-1].Type)
+}
+
 func TestSandboxExperimentalFeature(t *testing.T) {
 	testFeature := exp.Feature{
 		Name:        "mock",
@@ -1674,3 +1758,35 @@ func TestGetSandboxCpuSet(t *testing.T) {
 		})
 	}
 }
+
+func TestSandboxMigration(t *testing.T) {
+	defer cleanUp()
+	assert := assert.New(t)
+
+	ctx := WithNewAgentFunc(context.Background(), newMockAgent)
+	p, _, err := createAndStartSandbox(ctx, newTestSandboxConfigNoop())
+	assert.NoError(err)
+	s := p.(*Sandbox)
+
+	assert.Error(s.MigrationStart(ctx, ""))
+	assert.NoError(s.MigrationStart(ctx, "tcp:dest:4444"))
+
+	status, err := s.MigrationStatus(ctx)
+	assert.NoError(err)
+	assert.Equal("completed", status)
+
+	// the source VM stays paused once switched over
+	assert.NoError(s.MigrationSwitchover(ctx))
+	assert.Equal(types.StatePaused, s.state.State)
+	assert.True(s.isAgentAway())
+
+	events, err := s.journal.read()
+	assert.NoError(err)
+	var migrationEvents []string
+	for _, e := range events {
+		if e.Type == JournalMigrationStarted || e.Type == JournalMigrationDone {
+			migrationEvents = append(migrationEvents, e.Type)
+		}
+	}
+	assert.Equal([]string{JournalMigrationStarted, JournalMigrationDone}, migrationEvents)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+)
+
+// volumeMount returns the block volume mount of the sandbox containers whose
+// source is volumePath.
+func (s *Sandbox) volumeMount(volumePath string) (Mount, error) {
+	for _, c := range s.containers {
+		for _, m := range c.mounts {
+			if m.Source == volumePath && m.BlockDeviceID != "" {
+				return m, nil
+			}
+		}
+	}
+
+	return Mount{}, fmt.Errorf("no block volume %s in sandbox %s", volumePath, s.id)
+}
+
+// ResizeVolume resizes the block volume at volumePath, the mount source of a
+// container, once its device has been grown to size bytes on the host, e.g.
+// by a CSI driver expanding the volume. The hypervisor notifies the guest of
+// the new capacity of the device, then the agent grows the filesystem the
+// guest mounted from it. The devices bind mounted in the containers are left
+// to their users.
+func (s *Sandbox) ResizeVolume(ctx context.Context, volumePath string, size uint64) error {
+	span, ctx := katatrace.Trace(ctx, s.Logger(), "ResizeVolume", s.tracingTags())
+	defer span.End()
+
+	m, err := s.volumeMount(volumePath)
+	if err != nil {
+		return err
+	}
+
+	device := s.devManager.GetDeviceByID(m.BlockDeviceID)
+	if device == nil {
+		return fmt.Errorf("failed to find device by id (id=%s)", m.BlockDeviceID)
+	}
+
+	if err := s.resizeVolumeDevice(ctx, device, size); err != nil {
+		return fmt.Errorf("failed to resize the device of volume %s: %v", volumePath, err)
+	}
+
+	if m.Type != "bind" && m.GuestDeviceMount != "" {
+		if err := s.agent.resizeGuestVolume(ctx, m.GuestDeviceMount, size); err != nil {
+			return fmt.Errorf("failed to resize the filesystem of volume %s: %v", volumePath, err)
+		}
+	}
+
+	s.journal.record(JournalVolumeResized, "", "%s resized to %d bytes", volumePath, size)
+	return nil
+}
+
+// resizeVolumeDevice has the hypervisor grow the block device of a volume.
+// The vhost-user-blk devices are resized by their vhost target, which
+// notifies the guest.
+func (s *Sandbox) resizeVolumeDevice(ctx context.Context, device api.Device, size uint64) error {
+	switch device.DeviceType() {
+	case config.DeviceBlock:
+		drive, ok := device.GetDeviceInfo().(*config.BlockDrive)
+		if !ok || drive == nil {
+			return fmt.Errorf("malformed block drive")
+		}
+		return s.hypervisor.resizeBlockDevice(ctx, drive, size)
+	case config.VhostUserBlk:
+		return nil
+	default:
+		return fmt.Errorf("%s devices cannot be resized", device.DeviceType())
+	}
+}
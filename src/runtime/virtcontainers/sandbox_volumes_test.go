@@ -0,0 +1,60 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/api"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/drivers"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/manager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxResizeVolume(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	bDev := drivers.NewBlockDevice(&config.DeviceInfo{ID: "volume"})
+	bDev.BlockDrive = &config.BlockDrive{ID: "drive-volume"}
+
+	hypervisor := &mockHypervisor{}
+	s := &Sandbox{
+		id:         "resize-sandbox",
+		hypervisor: hypervisor,
+		agent:      &mockAgent{},
+		devManager: manager.NewDeviceManager(config.VirtioSCSI, false, "", false, []api.Device{bDev}),
+		containers: map[string]*Container{
+			"foo": {
+				mounts: []Mount{
+					{
+						Source:           "/volume",
+						Destination:      "/data",
+						Type:             "ext4",
+						BlockDeviceID:    "volume",
+						GuestDeviceMount: "/run/kata-containers/sandbox/storage/volume",
+					},
+					{
+						Source:      "/shared",
+						Destination: "/shared",
+						Type:        "bind",
+					},
+				},
+			},
+		},
+	}
+
+	assert.NoError(s.ResizeVolume(ctx, "/volume", 1<<30))
+
+	// Only the block volumes can be resized
+	assert.Error(s.ResizeVolume(ctx, "/shared", 1<<30))
+	assert.Error(s.ResizeVolume(ctx, "/unknown", 1<<30))
+
+	hypervisor.faults.set([]MockFault{{Operation: MockFaultResize, FailAll: true}})
+	assert.Error(s.ResizeVolume(ctx, "/volume", 1<<30))
+}
@@ -10,6 +10,8 @@ const (
 	blockDeviceHotplugSupport
 	multiQueueSupport
 	fsSharingSupported
+	deviceHotplugSupport
+	rngDeviceSupport
 )
 
 // Capabilities describe a virtcontainers hypervisor capabilities
@@ -57,3 +59,25 @@ func (caps *Capabilities) IsFsSharingSupported() bool {
 func (caps *Capabilities) SetFsSharingSupport() {
 	caps.flags |= fsSharingSupported
 }
+
+// IsDeviceHotplugSupported tells if an hypervisor supports hotplugging
+// devices other than block devices, i.e. network, VFIO and vhost-user devices.
+func (caps *Capabilities) IsDeviceHotplugSupported() bool {
+	return caps.flags&deviceHotplugSupport != 0
+}
+
+// SetDeviceHotplugSupport sets the device hotplugging capability to true.
+func (caps *Capabilities) SetDeviceHotplugSupport() {
+	caps.flags |= deviceHotplugSupport
+}
+
+// IsRNGDeviceSupported tells if an hypervisor provides the guest with a
+// virtio-rng device fed by the host entropy source.
+func (caps *Capabilities) IsRNGDeviceSupported() bool {
+	return caps.flags&rngDeviceSupport != 0
+}
+
+// SetRNGDeviceSupport sets the RNG device capability to true.
+func (caps *Capabilities) SetRNGDeviceSupport() {
+	caps.flags |= rngDeviceSupport
+}
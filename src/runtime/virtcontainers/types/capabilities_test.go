@@ -43,3 +43,19 @@ func TestMultiQueueCapability(t *testing.T) {
 	caps.SetMultiQueueSupport()
 	assert.True(caps.IsMultiQueueSupported())
 }
+
+func TestDeviceHotplugCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsDeviceHotplugSupported())
+	caps.SetDeviceHotplugSupport()
+	assert.True(t, caps.IsDeviceHotplugSupported())
+}
+
+func TestRNGDeviceCapability(t *testing.T) {
+	var caps Capabilities
+
+	assert.False(t, caps.IsRNGDeviceSupported())
+	caps.SetRNGDeviceSupport()
+	assert.True(t, caps.IsRNGDeviceSupported())
+}
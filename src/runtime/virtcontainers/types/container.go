@@ -11,6 +11,10 @@ type ContainerState struct {
 
 	BlockDeviceID string
 
+	// LayerDeviceIDs are the block devices of the rootfs layers, when
+	// they are passed as EROFS images
+	LayerDeviceIDs []string `json:"layerDeviceIDs,omitempty"`
+
 	// File system of the rootfs incase it is block device
 	Fstype string `json:"fstype"`
 
@@ -31,6 +31,9 @@ const (
 
 	// StateCreating represents a sandbox/container that's in creating.
 	StateCreating StateString = "creating"
+
+	// StateCrashed represents a sandbox whose guest kernel panicked.
+	StateCrashed StateString = "crashed"
 )
 
 const (
@@ -78,7 +81,7 @@ func (state *SandboxState) ValidTransition(oldState StateString, newState StateS
 }
 
 func (state *StateString) valid() bool {
-	for _, validState := range []StateString{StateReady, StateRunning, StatePaused, StateStopped} {
+	for _, validState := range []StateString{StateReady, StateRunning, StatePaused, StateStopped, StateCrashed} {
 		if *state == validState {
 			return true
 		}
@@ -94,17 +97,17 @@ func (state *StateString) validTransition(oldState StateString, newState StateSt
 
 	switch *state {
 	case StateReady:
-		if newState == StateRunning || newState == StateStopped {
+		if newState == StateRunning || newState == StateStopped || newState == StateCrashed {
 			return nil
 		}
 
 	case StateRunning:
-		if newState == StatePaused || newState == StateStopped {
+		if newState == StatePaused || newState == StateStopped || newState == StateCrashed {
 			return nil
 		}
 
 	case StatePaused:
-		if newState == StateRunning || newState == StateStopped {
+		if newState == StateRunning || newState == StateStopped || newState == StateCrashed {
 			return nil
 		}
 
@@ -112,6 +115,11 @@ func (state *StateString) validTransition(oldState StateString, newState StateSt
 		if newState == StateRunning {
 			return nil
 		}
+
+	case StateCrashed:
+		if newState == StateStopped {
+			return nil
+		}
 	}
 
 	return fmt.Errorf("Can not move from %v to %v",
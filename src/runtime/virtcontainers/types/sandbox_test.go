@@ -59,6 +59,21 @@ func TestSandboxStateStoppedReady(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSandboxStateRunningCrashed(t *testing.T) {
+	err := testSandboxStateTransition(t, StateRunning, StateCrashed)
+	assert.NoError(t, err)
+}
+
+func TestSandboxStateCrashedStopped(t *testing.T) {
+	err := testSandboxStateTransition(t, StateCrashed, StateStopped)
+	assert.NoError(t, err)
+}
+
+func TestSandboxStateCrashedRunning(t *testing.T) {
+	err := testSandboxStateTransition(t, StateCrashed, StateRunning)
+	assert.Error(t, err)
+}
+
 func testStateValid(t *testing.T, stateStr StateString, expected bool) {
 	state := &SandboxState{
 		State: stateStr,
@@ -73,6 +88,7 @@ func TestStateValidSuccessful(t *testing.T) {
 	testStateValid(t, StateRunning, true)
 	testStateValid(t, StatePaused, true)
 	testStateValid(t, StateStopped, true)
+	testStateValid(t, StateCrashed, true)
 }
 
 func TestStateValidFailing(t *testing.T) {
@@ -0,0 +1,129 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/device/config"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	vhostUserGPUSocket = "vhost-user-gpu.sock"
+	vhostUserGPUID     = "vgpu"
+)
+
+func (q *qemu) vhostUserGPUSocketPath(id string) (string, error) {
+	return utils.BuildSocketPath(q.store.RunVMStoragePath(), id, vhostUserGPUSocket)
+}
+
+// vhostUserGPUDevice returns the vhost-user-gpu device served by the
+// vhost_user_gpu_daemon backend.
+func (q *qemu) vhostUserGPUDevice() (config.VhostUserDeviceAttrs, error) {
+	socketPath, err := q.vhostUserGPUSocketPath(q.id)
+	if err != nil {
+		return config.VhostUserDeviceAttrs{}, err
+	}
+
+	return config.VhostUserDeviceAttrs{
+		DevID:      vhostUserGPUID,
+		SocketPath: socketPath,
+		Type:       config.VhostUserGPU,
+	}, nil
+}
+
+// startVhostUserGPU starts the vhost-user-gpu backend, e.g. the
+// vhost-user-gpu program of QEMU, before QEMU connects to its socket. The
+// socket is created by the runtime and passed to the backend with --fd, the
+// backend is not restarted when it quits.
+func (q *qemu) startVhostUserGPU() error {
+	socketPath, err := q.vhostUserGPUSocketPath(q.id)
+	if err != nil {
+		return err
+	}
+
+	// The socket of a previous backend is left behind
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		return err
+	}
+	// no longer needed since fd is a dup
+	defer listener.Close()
+	listener.SetUnlinkOnClose(false)
+
+	socketFD, err := listener.File()
+	if err != nil {
+		return err
+	}
+	defer socketFD.Close()
+
+	// The socket is the first extra file, i.e. fd 3
+	args := append([]string{"--fd=3"}, q.config.VhostUserGPUExtraArgs...)
+	cmd := exec.Command(q.config.VhostUserGPUDaemon, args...)
+	cmd.ExtraFiles = []*os.File{socketFD}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	q.Logger().WithFields(logrus.Fields{
+		"path": q.config.VhostUserGPUDaemon,
+		"args": strings.Join(args, " "),
+	}).Info("starting vhost-user-gpu backend")
+
+	if err := utils.StartCmd(cmd); err != nil {
+		return fmt.Errorf("failed to start the vhost-user-gpu backend %s: %v", q.config.VhostUserGPUDaemon, err)
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			q.Logger().WithField("source", "vhost-user-gpu").Info(scanner.Text())
+		}
+		// Wait to release resources of the backend process
+		cmd.Process.Wait()
+		if !q.stopping {
+			q.Logger().Warn("the vhost-user-gpu backend quit, the guest GPU is no longer functional")
+		}
+	}()
+
+	q.state.VhostUserGPUPid = cmd.Process.Pid
+
+	return nil
+}
+
+// stopVhostUserGPU kills the vhost-user-gpu backend, once QEMU is stopped.
+func (q *qemu) stopVhostUserGPU() error {
+	if q.state.VhostUserGPUPid == 0 {
+		return nil
+	}
+
+	if err := syscall.Kill(q.state.VhostUserGPUPid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	q.state.VhostUserGPUPid = 0
+
+	if socketPath, err := q.vhostUserGPUSocketPath(q.id); err == nil {
+		if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+			q.Logger().WithError(err).WithField("path", socketPath).Warn("removing vhost-user-gpu socket failed")
+		}
+	}
+
+	return nil
+}
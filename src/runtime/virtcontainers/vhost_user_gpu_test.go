@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/persist"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQemuVhostUserGPU(t *testing.T) {
+	assert := assert.New(t)
+
+	// Starting a process opens /dev/null for its standard input and output
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Skipf("cannot open %s, the device cgroup was likely restricted by a previous test: %v", os.DevNull, err)
+	}
+	devNull.Close()
+
+	store, err := persist.GetDriver()
+	assert.NoError(err)
+
+	tmpDir := t.TempDir()
+	argsPath := filepath.Join(tmpDir, "args")
+	daemonPath := filepath.Join(tmpDir, "vhost-user-gpu")
+	assert.NoError(ioutil.WriteFile(daemonPath, []byte("#!/bin/sh\necho \"$@\" > "+argsPath+"\nexec sleep 60\n"), 0755))
+
+	// The tests fake the started processes with their own PID
+	savedStartCmd := utils.StartCmd
+	utils.StartCmd = func(c *exec.Cmd) error {
+		return c.Start()
+	}
+	defer func() {
+		utils.StartCmd = savedStartCmd
+	}()
+
+	pidFile := filepath.Join(tmpDir, "pid")
+	assert.NoError(ioutil.WriteFile(pidFile, []byte("1234\n"), 0644))
+
+	q := &qemu{
+		ctx:   context.Background(),
+		id:    "testVhostUserGPU",
+		store: store,
+		config: HypervisorConfig{
+			VhostUserGPUDaemon:    daemonPath,
+			VhostUserGPUExtraArgs: []string{"--virgl"},
+		},
+	}
+	q.qemuConfig.PidFile = pidFile
+
+	socketPath, err := q.vhostUserGPUSocketPath(q.id)
+	assert.NoError(err)
+	assert.NoError(os.MkdirAll(filepath.Dir(socketPath), 0750))
+	defer os.RemoveAll(filepath.Dir(socketPath))
+
+	dev, err := q.vhostUserGPUDevice()
+	assert.NoError(err)
+	assert.Equal(socketPath, dev.SocketPath)
+
+	assert.NoError(q.startVhostUserGPU())
+	pid := q.state.VhostUserGPUPid
+	assert.NotZero(pid)
+	assert.Equal([]int{1234, pid}, q.getPids())
+
+	// The backend gets the listening socket
+	assert.Eventually(func() bool {
+		args, err := ioutil.ReadFile(argsPath)
+		return err == nil && string(args) == "--fd=3 --virgl\n"
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = os.Stat(socketPath)
+	assert.NoError(err)
+
+	q.stopping = true
+	assert.NoError(q.stopVhostUserGPU())
+	assert.Zero(q.state.VhostUserGPUPid)
+	assert.Eventually(func() bool {
+		return syscall.Kill(pid, 0) == syscall.ESRCH
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = os.Stat(socketPath)
+	assert.True(os.IsNotExist(err))
+
+	// Nothing to stop
+	assert.NoError(q.stopVhostUserGPU())
+}
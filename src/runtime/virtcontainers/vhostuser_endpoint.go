@@ -158,6 +158,27 @@ func vhostUserSocketPath(info interface{}) (string, error) {
 
 }
 
+// vhostUserNetSocketPath returns the vhost-user socket explicitly provided
+// for a network interface, e.g. by OVS-DPDK through the sandbox annotations,
+// and falls back to the socket discovery otherwise.
+func vhostUserNetSocketPath(netInfo NetworkInfo, sockets map[string]string) (string, error) {
+	socketPath, ok := sockets[netInfo.Iface.Name]
+	if !ok {
+		return vhostUserSocketPath(netInfo)
+	}
+
+	fi, err := os.Stat(socketPath)
+	if err != nil {
+		return "", fmt.Errorf("vhost-user socket of interface %s: %v", netInfo.Iface.Name, err)
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return "", fmt.Errorf("vhost-user socket of interface %s: %s is not a socket", netInfo.Iface.Name, socketPath)
+	}
+
+	return socketPath, nil
+}
+
 func (endpoint *VhostUserEndpoint) save() persistapi.NetworkEndpoint {
 	return persistapi.NetworkEndpoint{
 		Type: string(endpoint.Type()),
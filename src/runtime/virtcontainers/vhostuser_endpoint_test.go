@@ -8,8 +8,10 @@ package virtcontainers
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,6 +73,43 @@ func TestVhostUserSocketPath(t *testing.T) {
 	assert.NoError(os.Remove(expectedPath))
 }
 
+func TestVhostUserNetSocketPath(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(tmpdir)
+
+	socketPath := filepath.Join(tmpdir, "vhu.sock")
+	l, err := net.Listen("unix", socketPath)
+	assert.NoError(err)
+	defer l.Close()
+
+	filePath := filepath.Join(tmpdir, "file")
+	assert.NoError(ioutil.WriteFile(filePath, nil, 0600))
+
+	netInfo := NetworkInfo{
+		Iface: NetlinkIface{
+			LinkAttrs: netlink.LinkAttrs{Name: "eth0"},
+		},
+	}
+
+	path, err := vhostUserNetSocketPath(netInfo, map[string]string{"eth0": socketPath})
+	assert.NoError(err)
+	assert.Equal(socketPath, path)
+
+	_, err = vhostUserNetSocketPath(netInfo, map[string]string{"eth0": filePath})
+	assert.Error(err)
+
+	_, err = vhostUserNetSocketPath(netInfo, map[string]string{"eth0": filepath.Join(tmpdir, "missing")})
+	assert.Error(err)
+
+	// Fall back to the socket discovery
+	path, err = vhostUserNetSocketPath(netInfo, map[string]string{"eth1": socketPath})
+	assert.NoError(err)
+	assert.Empty(path)
+}
+
 func TestVhostUserEndpointAttach(t *testing.T) {
 	assert := assert.New(t)
 	v := &VhostUserEndpoint{
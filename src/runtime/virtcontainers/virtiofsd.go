@@ -16,6 +16,7 @@ import (
 	"strings"
 	"syscall"
 
+	"github.com/blang/semver"
 	"github.com/kata-containers/kata-containers/src/runtime/pkg/katautils/katatrace"
 	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/utils"
 	"github.com/pkg/errors"
@@ -36,6 +37,37 @@ var (
 	errVirtiofsdSourceNotAvailable = errors.New("virtiofsd source path not available")
 )
 
+const (
+	// VirtiofsdSandboxNamespace isolates virtiofsd in its own namespaces,
+	// with the shared directory as its root.
+	VirtiofsdSandboxNamespace = "namespace"
+
+	// VirtiofsdSandboxChroot only changes the root of virtiofsd to the
+	// shared directory.
+	VirtiofsdSandboxChroot = "chroot"
+
+	// VirtiofsdSandboxNone doesn't isolate virtiofsd, the rust
+	// implementation only.
+	VirtiofsdSandboxNone = "none"
+
+	// VirtiofsdSeccompKill kills virtiofsd on a denied system call.
+	VirtiofsdSeccompKill = "kill"
+
+	// VirtiofsdSeccompLog only logs the denied system calls.
+	VirtiofsdSeccompLog = "log"
+
+	// VirtiofsdSeccompTrap sends a SIGSYS to virtiofsd on a denied system
+	// call.
+	VirtiofsdSeccompTrap = "trap"
+
+	// VirtiofsdSeccompNone disables the seccomp filter.
+	VirtiofsdSeccompNone = "none"
+)
+
+// virtiofsdSandboxMinVersion is the first QEMU release whose virtiofsd
+// accepts the sandbox option.
+var virtiofsdSandboxMinVersion = semver.MustParse("6.0.0")
+
 type Virtiofsd interface {
 	// Start virtiofsd, return pid of virtiofsd process
 	Start(context.Context, onQuitFunc) (pid int, err error)
@@ -57,6 +89,12 @@ type virtiofsd struct {
 	extraArgs []string
 	// sourcePath path that daemon will help to share
 	sourcePath string
+	// sandbox mode of virtiofsd, its default when empty
+	sandbox string
+	// seccomp action of virtiofsd, its default when empty
+	seccomp string
+	// rust is set when the daemon is the rust implementation
+	rust bool
 	// debug flag
 	debug bool
 	// PID process ID of virtiosd process
@@ -97,6 +135,10 @@ func (v *virtiofsd) Start(ctx context.Context, onQuit onQuitFunc) (int, error) {
 		return pid, err
 	}
 
+	if err := v.checkSandboxing(); err != nil {
+		return pid, err
+	}
+
 	cmd := exec.Command(v.path)
 
 	socketFD, err := v.getSocketFD()
@@ -181,6 +223,17 @@ func (v *virtiofsd) args(FdSocketNumber uint) ([]string, error) {
 		args = append(args, "-f")
 	}
 
+	if v.rust {
+		if v.sandbox != "" {
+			args = append(args, "--sandbox="+v.sandbox)
+		}
+		if v.seccomp != "" {
+			args = append(args, "--seccomp="+v.seccomp)
+		}
+	} else if v.sandbox != "" {
+		args = append(args, "-o", "sandbox="+v.sandbox)
+	}
+
 	if len(v.extraArgs) != 0 {
 		args = append(args, v.extraArgs...)
 	}
@@ -188,6 +241,89 @@ func (v *virtiofsd) args(FdSocketNumber uint) ([]string, error) {
 	return args, nil
 }
 
+// checkSandboxing probes the implementation of the daemon when sandboxing
+// options are set, and fails if it doesn't support them.
+func (v *virtiofsd) checkSandboxing() error {
+	if v.sandbox == "" && v.seccomp == "" {
+		return nil
+	}
+
+	output, err := exec.Command(v.path, "--version").Output()
+	if err != nil {
+		return fmt.Errorf("Running checking virtiofsd version command failed: %v", err)
+	}
+
+	rust, version, err := parseVirtiofsdVersion(string(output))
+	if err != nil {
+		return err
+	}
+	v.rust = rust
+
+	// The rust implementation supports all the sandboxing options
+	if rust {
+		return nil
+	}
+
+	if v.sandbox != "" {
+		if v.sandbox == VirtiofsdSandboxNone {
+			return fmt.Errorf("virtiofsd %v doesn't support the %q sandbox mode, use the rust implementation", version, v.sandbox)
+		}
+		if version.LT(virtiofsdSandboxMinVersion) {
+			return fmt.Errorf("virtiofsd %v doesn't support sandboxing, the minimum version is %v", version, virtiofsdSandboxMinVersion)
+		}
+	}
+
+	// The seccomp filter of the C implementation always kills the daemon
+	if v.seccomp != "" && v.seccomp != VirtiofsdSeccompKill {
+		return fmt.Errorf("virtiofsd %v doesn't support the %q seccomp action, use the rust implementation", version, v.seccomp)
+	}
+
+	return nil
+}
+
+// checkVirtiofsdConfig validates the sandboxing options of virtiofsd.
+func (conf *HypervisorConfig) checkVirtiofsdConfig() error {
+	switch conf.VirtioFSSandbox {
+	case "", VirtiofsdSandboxNamespace, VirtiofsdSandboxChroot, VirtiofsdSandboxNone:
+	default:
+		return fmt.Errorf("Invalid virtiofsd sandbox mode %v", conf.VirtioFSSandbox)
+	}
+
+	switch conf.VirtioFSSeccomp {
+	case "", VirtiofsdSeccompKill, VirtiofsdSeccompLog, VirtiofsdSeccompTrap, VirtiofsdSeccompNone:
+	default:
+		return fmt.Errorf("Invalid virtiofsd seccomp action %v", conf.VirtioFSSeccomp)
+	}
+
+	return nil
+}
+
+// parseVirtiofsdVersion parses the output of virtiofsd --version, which is
+// "virtiofsd 1.1.0" for the rust implementation, and
+// "virtiofsd version 6.2.0 (...)" for the C implementation of QEMU.
+func parseVirtiofsdVersion(output string) (bool, semver.Version, error) {
+	fields := strings.Fields(output)
+
+	var rust bool
+	var version string
+	switch {
+	case len(fields) > 2 && fields[0] == "virtiofsd" && fields[1] == "version":
+		version = fields[2]
+	case len(fields) > 1 && fields[0] == "virtiofsd":
+		rust = true
+		version = fields[1]
+	default:
+		return false, semver.Version{}, errors.New("getting virtiofsd version failed, the output is malformed")
+	}
+
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return false, semver.Version{}, fmt.Errorf("Malformed virtiofsd version: %v", err)
+	}
+
+	return rust, v, nil
+}
+
 func (v *virtiofsd) valid() error {
 	if v.path == "" {
 		return errVirtiofsdDaemonPathEmpty
@@ -0,0 +1,109 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// virtiofsdReconnectInterval is the interval in seconds at which QEMU
+// reconnects to the socket of a restarted virtiofsd.
+const virtiofsdReconnectInterval = 1
+
+// VirtiofsdCrashError is sent to the sandbox monitor watchers when virtiofsd
+// quit while the sandbox was running.
+type VirtiofsdCrashError struct {
+	SandboxID string
+
+	// Containers are the containers of the sandbox, whose file systems
+	// are served by virtiofsd.
+	Containers []string
+
+	// Recovered is set when virtiofsd was restarted and the shared file
+	// system mounted again in the guest.
+	Recovered bool
+
+	// Err is the error of the last recovery attempt.
+	Err error
+}
+
+func (e *VirtiofsdCrashError) Error() string {
+	if e.Recovered {
+		return fmt.Sprintf("virtiofsd of sandbox %s quit and was restarted", e.SandboxID)
+	}
+
+	return fmt.Sprintf("virtiofsd of sandbox %s quit and could not be restarted: %v", e.SandboxID, e.Err)
+}
+
+// Fatal returns true if virtiofsd could not be recovered, the sandbox is
+// then marked as crashed and must be torn down.
+func (e *VirtiofsdCrashError) Fatal() bool {
+	return !e.Recovered
+}
+
+// handleVirtiofsdQuit is called by the hypervisor when virtiofsd quit while
+// the VM is running. virtiofsd is restarted with restart, up to the
+// configured number of restarts, and the shared file system is mounted
+// again in the guest. If it can't be recovered, the sandbox is marked as
+// crashed and the VM is stopped. The sandbox monitor watchers are notified
+// in both cases.
+func (s *Sandbox) handleVirtiofsdQuit(ctx context.Context, restart func(context.Context) error) {
+	s.Logger().Error("virtiofsd quit unexpectedly")
+
+	crashErr := &VirtiofsdCrashError{SandboxID: s.id}
+	for id := range s.containers {
+		crashErr.Containers = append(crashErr.Containers, id)
+	}
+	sort.Strings(crashErr.Containers)
+
+	crashErr.Err = errors.New("no virtiofsd restart left")
+	for s.virtiofsdRestarts < s.config.HypervisorConfig.VirtioFSRestarts {
+		s.virtiofsdRestarts++
+
+		crashErr.Err = s.recoverVirtiofsd(ctx, restart)
+		if crashErr.Err == nil {
+			crashErr.Recovered = true
+			break
+		}
+
+		s.Logger().WithError(crashErr.Err).WithField("restart", s.virtiofsdRestarts).Warn("failed to recover virtiofsd")
+	}
+
+	if crashErr.Recovered {
+		s.journal.record(JournalVirtiofsdCrash, "", "virtiofsd quit, restart %d succeeded", s.virtiofsdRestarts)
+	} else {
+		s.journal.record(JournalVirtiofsdCrash, "", "virtiofsd quit, recovery failed: %v", crashErr.Err)
+		s.markCrashed()
+
+		// The containers can't access their file systems anymore
+		if err := s.hypervisor.stopSandbox(ctx, false); err != nil {
+			s.Logger().WithError(err).Warn("failed to stop the VM")
+		}
+	}
+
+	if s.monitor == nil {
+		return
+	}
+
+	if crashErr.Fatal() {
+		s.monitor.notify(ctx, crashErr)
+	} else {
+		s.monitor.notifyEvent(crashErr)
+	}
+}
+
+// recoverVirtiofsd restarts virtiofsd and has the agent mount the shared
+// file system again.
+func (s *Sandbox) recoverVirtiofsd(ctx context.Context, restart func(context.Context) error) error {
+	if err := restart(ctx); err != nil {
+		return err
+	}
+
+	return s.agent.remountSharedFS(ctx, virtioFSSharedDirOptions(s.config.HypervisorConfig))
+}
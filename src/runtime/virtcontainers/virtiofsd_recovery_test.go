@@ -0,0 +1,76 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVirtioFSSharedDirOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := HypervisorConfig{VirtioFSCache: typeVirtioFSNoCache, VirtioFSCacheSize: 1024}
+	assert.Empty(virtioFSSharedDirOptions(conf))
+
+	conf.VirtioFSCache = "auto"
+	assert.Equal([]string{"dax"}, virtioFSSharedDirOptions(conf))
+	// The options are not accumulated
+	assert.Equal([]string{"dax"}, virtioFSSharedDirOptions(conf))
+
+	conf.VirtioFSCacheSize = 0
+	assert.Empty(virtioFSSharedDirOptions(conf))
+}
+
+func TestSandboxHandleVirtiofsdQuit(t *testing.T) {
+	contConfig := newTestContainerConfigNoop("505")
+	hConfig := newHypervisorConfig(nil, nil)
+	hConfig.VirtioFSRestarts = 2
+	assert := assert.New(t)
+
+	s, err := testCreateSandbox(t, testSandboxID, MockHypervisor, hConfig, NetworkConfig{}, []ContainerConfig{contConfig}, nil)
+	assert.NoError(err)
+	defer cleanUp()
+
+	s.state.State = types.StateRunning
+
+	ch, err := s.Monitor(context.Background())
+	assert.NoError(err)
+	defer s.monitor.stop()
+
+	// The first restart fails, the second one succeeds
+	restarts := 0
+	restart := func(context.Context) error {
+		restarts++
+		if restarts == 1 {
+			return errors.New("restart failed")
+		}
+		return nil
+	}
+
+	s.handleVirtiofsdQuit(context.Background(), restart)
+	assert.Equal(2, restarts)
+	assert.Equal(types.StateRunning, s.state.State)
+
+	crashErr, ok := (<-ch).(*VirtiofsdCrashError)
+	assert.True(ok)
+	assert.False(crashErr.Fatal())
+	assert.Equal([]string{"505"}, crashErr.Containers)
+
+	// No restart is left
+	s.handleVirtiofsdQuit(context.Background(), restart)
+	assert.Equal(2, restarts)
+	assert.Equal(types.StateCrashed, s.state.State)
+
+	crashErr, ok = (<-ch).(*VirtiofsdCrashError)
+	assert.True(ok)
+	assert.True(crashErr.Fatal())
+	assert.Error(crashErr.Err)
+}
@@ -7,8 +7,10 @@ package virtcontainers
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -96,6 +98,103 @@ func TestVirtiofsdArgs(t *testing.T) {
 	args, err = v.args(456)
 	assert.NoError(err)
 	assert.Equal(expected, strings.Join(args, " "))
+
+	v.sandbox = VirtiofsdSandboxNamespace
+	v.seccomp = VirtiofsdSeccompKill
+	expected = "--syslog -o cache=none -o no_posix_lock -o source=/run/kata-shared/foo --fd=456 -f -o sandbox=namespace"
+	args, err = v.args(456)
+	assert.NoError(err)
+	assert.Equal(expected, strings.Join(args, " "))
+
+	v.rust = true
+	expected = "--syslog -o cache=none -o no_posix_lock -o source=/run/kata-shared/foo --fd=456 -f --sandbox=namespace --seccomp=kill"
+	args, err = v.args(456)
+	assert.NoError(err)
+	assert.Equal(expected, strings.Join(args, " "))
+}
+
+func TestParseVirtiofsdVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	rust, version, err := parseVirtiofsdVersion("virtiofsd 1.1.0\n")
+	assert.NoError(err)
+	assert.True(rust)
+	assert.Equal("1.1.0", version.String())
+
+	rust, version, err = parseVirtiofsdVersion("virtiofsd version 6.2.0 (Debian 1:6.2+dfsg-2)\nCopyright (c) 2003-2021 Fabrice Bellard and the QEMU Project developers\n")
+	assert.NoError(err)
+	assert.False(rust)
+	assert.Equal("6.2.0", version.String())
+
+	for _, output := range []string{"", "qemu-system-x86_64 6.2.0", "virtiofsd version", "virtiofsd devel"} {
+		_, _, err = parseVirtiofsdVersion(output)
+		assert.Error(err, "output %q", output)
+	}
+}
+
+func TestVirtiofsdCheckSandboxing(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	daemon := func(name, version string) string {
+		path := filepath.Join(dir, name)
+		script := fmt.Sprintf("#!/bin/sh\n[ \"$1\" = \"--version\" ] && echo \"%s\"\n", version)
+		assert.NoError(ioutil.WriteFile(path, []byte(script), 0700))
+		return path
+	}
+
+	rust := daemon("rust", "virtiofsd 1.1.0")
+	qemu := daemon("qemu", "virtiofsd version 6.2.0 (v6.2.0)")
+	oldQemu := daemon("old-qemu", "virtiofsd version 5.2.0 (v5.2.0)")
+
+	tests := []struct {
+		path    string
+		sandbox string
+		seccomp string
+		rust    bool
+		wantErr bool
+	}{
+		{"/does/not/exist", "", "", false, false},
+		{"/does/not/exist", VirtiofsdSandboxNamespace, "", false, true},
+		{rust, VirtiofsdSandboxNone, VirtiofsdSeccompLog, true, false},
+		{qemu, VirtiofsdSandboxChroot, VirtiofsdSeccompKill, false, false},
+		{qemu, VirtiofsdSandboxNone, "", false, true},
+		{qemu, "", VirtiofsdSeccompTrap, false, true},
+		{oldQemu, VirtiofsdSandboxNamespace, "", false, true},
+		{oldQemu, "", VirtiofsdSeccompKill, false, false},
+	}
+
+	for _, tt := range tests {
+		v := &virtiofsd{path: tt.path, sandbox: tt.sandbox, seccomp: tt.seccomp}
+		err := v.checkSandboxing()
+		if tt.wantErr {
+			assert.Error(err, "%+v", tt)
+			continue
+		}
+		assert.NoError(err, "%+v", tt)
+		assert.Equal(tt.rust, v.rust, "%+v", tt)
+	}
+}
+
+func TestCheckVirtiofsdConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := &HypervisorConfig{}
+	assert.NoError(conf.checkVirtiofsdConfig())
+
+	conf.VirtioFSSandbox = VirtiofsdSandboxChroot
+	conf.VirtioFSSeccomp = VirtiofsdSeccompNone
+	assert.NoError(conf.checkVirtiofsdConfig())
+
+	conf.VirtioFSSandbox = "jail"
+	assert.Error(conf.checkVirtiofsdConfig())
+
+	conf.VirtioFSSandbox = ""
+	conf.VirtioFSSeccomp = "allow"
+	assert.Error(conf.checkVirtiofsdConfig())
 }
 
 func TestValid(t *testing.T) {
@@ -130,6 +130,10 @@ func NewVM(ctx context.Context, config VMConfig) (*VM, error) {
 	}
 
 	// 3. boot up guest vm
+	if err = checkGuestArtifacts(&config.HypervisorConfig, virtLog.WithField("vm", id)); err != nil {
+		return nil, err
+	}
+
 	if err = hypervisor.startSandbox(ctx, vmStartTimeout); err != nil {
 		return nil, err
 	}
@@ -300,20 +304,7 @@ func (v *VM) OnlineCPUMemory(ctx context.Context) error {
 // and reseeds it.
 func (v *VM) ReseedRNG(ctx context.Context) error {
 	v.logger().Infof("reseed guest random number generator")
-	urandomDev := "/dev/urandom"
-	data := make([]byte, 512)
-	f, err := os.OpenFile(urandomDev, os.O_RDONLY, 0)
-	if err != nil {
-		v.logger().WithError(err).Warnf("fail to open %s", urandomDev)
-		return err
-	}
-	defer f.Close()
-	if _, err = f.Read(data); err != nil {
-		v.logger().WithError(err).Warnf("fail to read %s", urandomDev)
-		return err
-	}
-
-	return v.agent.reseedRNG(ctx, data)
+	return reseedGuestRNG(ctx, v.agent)
 }
 
 // SyncTime syncs guest time with host time.
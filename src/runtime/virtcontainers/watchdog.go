@@ -0,0 +1,117 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+)
+
+const (
+	// WatchdogActionReset resets the VM when the guest watchdog expires.
+	WatchdogActionReset = "reset"
+
+	// WatchdogActionFail marks the sandbox as crashed and tears it down
+	// when the guest watchdog expires.
+	WatchdogActionFail = "fail"
+
+	// WatchdogActionNotify only reports the expired guest watchdog, the
+	// VM is left as is.
+	WatchdogActionNotify = "notify"
+
+	// defaultWatchdogTimeout is the default guest watchdog timeout, in
+	// seconds.
+	defaultWatchdogTimeout = 30
+
+	// kernelParamWatchdogTimeout tells the agent to pet the guest watchdog
+	// and sets its timeout.
+	kernelParamWatchdogTimeout = "agent.watchdog_timeout"
+)
+
+// GuestWatchdogError is sent to the sandbox monitor watchers when the guest
+// watchdog expired, i.e. when the guest is hung.
+type GuestWatchdogError struct {
+	SandboxID string
+
+	// Action is the configured watchdog recovery action.
+	Action string
+}
+
+func (e *GuestWatchdogError) Error() string {
+	return fmt.Sprintf("guest watchdog of sandbox %s expired (action %s)", e.SandboxID, e.Action)
+}
+
+// Fatal returns true if the sandbox has been marked as crashed and must be
+// torn down.
+func (e *GuestWatchdogError) Fatal() bool {
+	return e.Action == WatchdogActionFail
+}
+
+// checkWatchdogConfig validates the guest watchdog configuration and sets
+// its defaults.
+func (conf *HypervisorConfig) checkWatchdogConfig() error {
+	if conf.Watchdog == "" {
+		return nil
+	}
+
+	switch govmmQemu.WatchdogModel(conf.Watchdog) {
+	case govmmQemu.I6300ESB, govmmQemu.IB700, govmmQemu.Diag288:
+	default:
+		return fmt.Errorf("Invalid watchdog model %v", conf.Watchdog)
+	}
+
+	switch conf.WatchdogAction {
+	case "":
+		conf.WatchdogAction = WatchdogActionReset
+	case WatchdogActionReset, WatchdogActionFail, WatchdogActionNotify:
+	default:
+		return fmt.Errorf("Invalid watchdog action %v", conf.WatchdogAction)
+	}
+
+	if conf.WatchdogTimeout == 0 {
+		conf.WatchdogTimeout = defaultWatchdogTimeout
+	}
+
+	return nil
+}
+
+// watchdogKernelParams returns the kernel parameters enabling the agent
+// watchdog petting.
+func (conf *HypervisorConfig) watchdogKernelParams() []Param {
+	if conf.Watchdog == "" {
+		return nil
+	}
+
+	return []Param{{kernelParamWatchdogTimeout, strconv.FormatUint(uint64(conf.WatchdogTimeout), 10)}}
+}
+
+// handleGuestWatchdog is called by the hypervisor when the guest watchdog
+// expired. Depending on the configured action, the sandbox is marked as
+// crashed, then the sandbox monitor watchers are notified.
+func (s *Sandbox) handleGuestWatchdog(ctx context.Context) {
+	action := s.config.HypervisorConfig.WatchdogAction
+	s.Logger().WithField("action", action).Error("guest watchdog expired")
+
+	wdErr := &GuestWatchdogError{SandboxID: s.id, Action: action}
+	s.journal.record(JournalGuestWatchdog, "", "guest watchdog expired, action %s", action)
+
+	if wdErr.Fatal() {
+		s.markCrashed()
+	}
+
+	if s.monitor == nil {
+		return
+	}
+
+	if wdErr.Fatal() {
+		s.monitor.notify(ctx, wdErr)
+	} else {
+		s.monitor.notifyEvent(wdErr)
+	}
+}
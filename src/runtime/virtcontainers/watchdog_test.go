@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package virtcontainers
+
+import (
+	"context"
+	"testing"
+
+	govmmQemu "github.com/kata-containers/kata-containers/src/runtime/pkg/govmm/qemu"
+	"github.com/kata-containers/kata-containers/src/runtime/virtcontainers/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckWatchdogConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	conf := HypervisorConfig{}
+	assert.NoError(conf.checkWatchdogConfig())
+	assert.Empty(conf.watchdogKernelParams())
+
+	conf.Watchdog = "i6300esb"
+	assert.NoError(conf.checkWatchdogConfig())
+	assert.Equal(WatchdogActionReset, conf.WatchdogAction)
+	assert.Equal(uint32(defaultWatchdogTimeout), conf.WatchdogTimeout)
+	assert.Equal([]Param{{kernelParamWatchdogTimeout, "30"}}, conf.watchdogKernelParams())
+
+	conf.WatchdogAction = "explode"
+	assert.Error(conf.checkWatchdogConfig())
+
+	conf.WatchdogAction = WatchdogActionFail
+	conf.Watchdog = "foo"
+	assert.Error(conf.checkWatchdogConfig())
+}
+
+func TestQemuWatchdogDevice(t *testing.T) {
+	assert := assert.New(t)
+
+	q := &qemu{config: HypervisorConfig{Watchdog: "i6300esb", WatchdogAction: WatchdogActionReset}}
+	dev := q.watchdogDevice()
+	assert.True(dev.Valid())
+	assert.Equal(govmmQemu.WatchdogReset, dev.Action)
+	assert.Equal([]string{"-device", "i6300esb,id=watchdog0", "-action", "watchdog=reset"}, dev.QemuParams(nil))
+
+	for _, action := range []string{WatchdogActionFail, WatchdogActionNotify} {
+		q.config.WatchdogAction = action
+		assert.Equal(govmmQemu.WatchdogNone, q.watchdogDevice().Action)
+	}
+}
+
+func TestSandboxHandleGuestWatchdog(t *testing.T) {
+	contConfig := newTestContainerConfigNoop("505")
+	hConfig := newHypervisorConfig(nil, nil)
+	hConfig.Watchdog = "i6300esb"
+	hConfig.WatchdogAction = WatchdogActionNotify
+	assert := assert.New(t)
+
+	s, err := testCreateSandbox(t, testSandboxID, MockHypervisor, hConfig, NetworkConfig{}, []ContainerConfig{contConfig}, nil)
+	assert.NoError(err)
+	defer cleanUp()
+
+	s.state.State = types.StateRunning
+
+	ch, err := s.Monitor(context.Background())
+	assert.NoError(err)
+	defer s.monitor.stop()
+
+	s.handleGuestWatchdog(context.Background())
+	assert.Equal(types.StateRunning, s.state.State)
+
+	wdErr, ok := (<-ch).(*GuestWatchdogError)
+	assert.True(ok)
+	assert.False(wdErr.Fatal())
+
+	s.config.HypervisorConfig.WatchdogAction = WatchdogActionFail
+	s.handleGuestWatchdog(context.Background())
+	assert.Equal(types.StateCrashed, s.state.State)
+
+	wdErr, ok = (<-ch).(*GuestWatchdogError)
+	assert.True(ok)
+	assert.True(wdErr.Fatal())
+}
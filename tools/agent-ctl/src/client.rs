@@ -132,6 +132,11 @@ static AGENT_CMDS: &'static [AgentCmd] = &[
         st: ServiceType::Agent,
         fp: agent_cmd_sandbox_get_guest_details,
     },
+    AgentCmd {
+        name: "GetGuestDiagnostics",
+        st: ServiceType::Agent,
+        fp: agent_cmd_sandbox_get_guest_diagnostics,
+    },
     AgentCmd {
         name: "GetMetrics",
         st: ServiceType::Agent,
@@ -187,6 +192,11 @@ static AGENT_CMDS: &'static [AgentCmd] = &[
         st: ServiceType::Agent,
         fp: agent_cmd_container_remove,
     },
+    AgentCmd {
+        name: "ResizeVolume",
+        st: ServiceType::Agent,
+        fp: agent_cmd_sandbox_resize_volume,
+    },
     AgentCmd {
         name: "ResumeContainer",
         st: ServiceType::Agent,
@@ -1093,6 +1103,39 @@ fn agent_cmd_sandbox_get_guest_details(
     Ok(())
 }
 
+fn agent_cmd_sandbox_get_guest_diagnostics(
+    ctx: &Context,
+    client: &AgentServiceClient,
+    _health: &HealthClient,
+    options: &mut Options,
+    args: &str,
+) -> Result<()> {
+    let mut req = GuestDiagnosticsRequest::default();
+
+    let ctx = clone_context(ctx);
+
+    let dmesg_lines_str = utils::get_option("dmesg_lines", options, args);
+
+    if dmesg_lines_str != "" {
+        let dmesg_lines = dmesg_lines_str
+            .parse::<u32>()
+            .map_err(|e| anyhow!(e).context("invalid dmesg_lines value"))?;
+
+        req.set_dmesg_lines(dmesg_lines);
+    }
+
+    debug!(sl!(), "sending request"; "request" => format!("{:?}", req));
+
+    let reply = client
+        .get_guest_diagnostics(ctx, &req)
+        .map_err(|e| anyhow!("{:?}", e).context(ERR_API_FAILED))?;
+
+    info!(sl!(), "response received";
+        "response" => format!("{:?}", reply));
+
+    Ok(())
+}
+
 fn agent_cmd_container_wait_process(
     ctx: &Context,
     client: &AgentServiceClient,
@@ -1656,6 +1699,42 @@ fn agent_cmd_sandbox_reseed_random_dev(
     Ok(())
 }
 
+fn agent_cmd_sandbox_resize_volume(
+    ctx: &Context,
+    client: &AgentServiceClient,
+    _health: &HealthClient,
+    options: &mut Options,
+    args: &str,
+) -> Result<()> {
+    let mut req = ResizeVolumeRequest::default();
+
+    let ctx = clone_context(ctx);
+
+    let guest_path = utils::get_option("volume_guest_path", options, args);
+    req.set_volume_guest_path(guest_path);
+
+    let size_str = utils::get_option("size", options, args);
+
+    if size_str != "" {
+        let size = size_str
+            .parse::<u64>()
+            .map_err(|e| anyhow!(e).context("invalid size value"))?;
+
+        req.set_size(size);
+    }
+
+    debug!(sl!(), "sending request"; "request" => format!("{:?}", req));
+
+    let reply = client
+        .resize_volume(ctx, &req)
+        .map_err(|e| anyhow!("{:?}", e).context(ERR_API_FAILED))?;
+
+    info!(sl!(), "response received";
+        "response" => format!("{:?}", reply));
+
+    Ok(())
+}
+
 fn agent_cmd_sandbox_online_cpu_mem(
     ctx: &Context,
     client: &AgentServiceClient,
@@ -0,0 +1,160 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kata-containers/kata-containers/tools/packaging/cmd/kata-confgen/confgen"
+)
+
+var (
+	// empty variables are set by "go build -ldflags" option
+	name    = ""
+	version = ""
+	commit  = ""
+
+	defaultPrefix = "/opt/kata"
+	defaultBase   = "share/defaults/kata-containers/configuration-qemu.toml"
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `NAME:
+   %s - Generate the Kata Containers configuration variants
+
+USAGE:
+   %s [options]
+
+%s generates the configuration of each variant from a single base
+configuration, the QEMU one by default, and validates them all before
+writing any of them, as configuration-<variant>.toml files.
+
+Options:
+`, name, name, name)
+
+	flag.PrintDefaults()
+}
+
+// selectVariants returns the variants named in list, comma separated, all
+// the variants when it is empty.
+func selectVariants(variants []confgen.Overlay, list string) ([]confgen.Overlay, error) {
+	if list == "" {
+		return variants, nil
+	}
+
+	byName := make(map[string]confgen.Overlay)
+	for _, v := range variants {
+		byName[v.Name] = v
+	}
+
+	var selected []confgen.Overlay
+	for _, n := range strings.Split(list, ",") {
+		v, ok := byName[strings.TrimSpace(n)]
+		if !ok {
+			return nil, fmt.Errorf("unknown variant %q", n)
+		}
+		selected = append(selected, v)
+	}
+	return selected, nil
+}
+
+// writeFile writes the file atomically, for the runtime not to read a
+// partial configuration.
+func writeFile(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func run(prefix, base, outputDir, variantList string, dryRun bool) error {
+	if base == "" {
+		base = filepath.Join(prefix, defaultBase)
+	}
+	if outputDir == "" {
+		outputDir = filepath.Dir(base)
+	}
+
+	data, err := ioutil.ReadFile(base)
+	if err != nil {
+		return err
+	}
+
+	variants, err := selectVariants(confgen.Variants(prefix), variantList)
+	if err != nil {
+		return err
+	}
+
+	configs, err := confgen.Generate(data, variants)
+	if err != nil {
+		return err
+	}
+
+	files := make([]string, 0, len(configs))
+	for file := range configs {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		path := filepath.Join(outputDir, file)
+		if dryRun {
+			fmt.Printf("%s is valid\n", path)
+			continue
+		}
+
+		if err := writeFile(path, configs[file]); err != nil {
+			return err
+		}
+		fmt.Printf("%s written\n", path)
+	}
+
+	return nil
+}
+
+func main() {
+	flag.Usage = usage
+
+	prefix := flag.String("prefix", defaultPrefix, "installation prefix of the Kata Containers artifacts")
+	base := flag.String("base", "", "base configuration file (default <prefix>/"+defaultBase+")")
+	outputDir := flag.String("output-dir", "", "directory the configurations are written to (default the directory of the base)")
+	variants := flag.String("variants", "", "comma separated variants to generate (default all)")
+	dryRun := flag.Bool("dry-run", false, "validate the configurations without writing them")
+	showVersion := flag.Bool("version", false, "print the version and exit")
+
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("%s %s (commit %s)\n", name, version, commit)
+		return
+	}
+
+	if err := run(*prefix, *base, *outputDir, *variants, *dryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
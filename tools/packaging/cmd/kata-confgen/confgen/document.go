@@ -0,0 +1,171 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package confgen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var (
+	tableRE = regexp.MustCompile(`^\s*\[([^\[\]]+)\]\s*(#.*)?$`)
+	keyRE   = regexp.MustCompile(`^(\s*)([A-Za-z0-9_-]+)\s*=`)
+	// The settings documented in the comments, e.g. "#pcie_root_port = 2"
+	commentedKeyRE = regexp.MustCompile(`^(\s*)#\s*([A-Za-z0-9_-]+)\s*=`)
+)
+
+// Document is a TOML configuration file, edited line by line so that the
+// comments documenting its settings are kept.
+type Document struct {
+	lines []string
+}
+
+// Parse returns the document of a TOML configuration file.
+func Parse(data []byte) *Document {
+	text := strings.TrimSuffix(string(data), "\n")
+	return &Document{lines: strings.Split(text, "\n")}
+}
+
+// Bytes returns the content of the document.
+func (d *Document) Bytes() []byte {
+	return []byte(strings.Join(d.lines, "\n") + "\n")
+}
+
+// Copy returns a copy of the document.
+func (d *Document) Copy() *Document {
+	return &Document{lines: append([]string(nil), d.lines...)}
+}
+
+// Tables returns the names of the tables of the document, in order.
+func (d *Document) Tables() []string {
+	var tables []string
+	for _, line := range d.lines {
+		if m := tableRE.FindStringSubmatch(line); m != nil {
+			tables = append(tables, strings.TrimSpace(m[1]))
+		}
+	}
+	return tables
+}
+
+// tableWithPrefix returns the only table of the document whose name starts
+// with prefix, e.g. "hypervisor.".
+func (d *Document) tableWithPrefix(prefix string) (string, error) {
+	var found []string
+	for _, table := range d.Tables() {
+		if strings.HasPrefix(table, prefix) {
+			found = append(found, table)
+		}
+	}
+
+	if len(found) != 1 {
+		return "", fmt.Errorf("expected a single %s* table, found %v", prefix, found)
+	}
+	return found[0], nil
+}
+
+// tableLines returns the range of the lines of table, its header excluded.
+func (d *Document) tableLines(table string) (int, int, error) {
+	start := -1
+	for i, line := range d.lines {
+		m := tableRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if start >= 0 {
+			return start, i, nil
+		}
+		if strings.TrimSpace(m[1]) == table {
+			start = i + 1
+		}
+	}
+
+	if start < 0 {
+		return 0, 0, fmt.Errorf("table %s not found", table)
+	}
+	return start, len(d.lines), nil
+}
+
+// RenameTable renames the table from to to.
+func (d *Document) RenameTable(from, to string) error {
+	start, _, err := d.tableLines(from)
+	if err != nil {
+		return err
+	}
+
+	d.lines[start-1] = fmt.Sprintf("[%s]", to)
+	return nil
+}
+
+// formatSetting returns the line of the setting of key to value.
+func formatSetting(key string, value interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(map[string]interface{}{key: value}); err != nil {
+		return "", fmt.Errorf("invalid value of %s: %v", key, err)
+	}
+
+	line := strings.TrimSpace(buf.String())
+	if strings.Contains(line, "\n") || !strings.HasPrefix(line, key+" = ") {
+		return "", fmt.Errorf("invalid value of %s: %v is not a TOML value", key, value)
+	}
+	return line, nil
+}
+
+// Set sets key to value in table. An existing setting of key is replaced,
+// else its setting documented in a comment is uncommented, else the setting
+// is added at the end of the table.
+func (d *Document) Set(table, key string, value interface{}) error {
+	setting, err := formatSetting(key, value)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := d.tableLines(table)
+	if err != nil {
+		return err
+	}
+
+	commented := -1
+	last := start - 1
+	for i := start; i < end; i++ {
+		if m := keyRE.FindStringSubmatch(d.lines[i]); m != nil {
+			last = i
+			if m[2] == key {
+				d.lines[i] = m[1] + setting
+				return nil
+			}
+		} else if m := commentedKeyRE.FindStringSubmatch(d.lines[i]); m != nil && m[2] == key {
+			commented = i
+		}
+	}
+
+	if commented >= 0 {
+		d.lines[commented] = commentedKeyRE.FindStringSubmatch(d.lines[commented])[1] + setting
+		return nil
+	}
+
+	d.lines = append(d.lines[:last+1], append([]string{setting}, d.lines[last+1:]...)...)
+	return nil
+}
+
+// Unset comments the setting of key in table out, the runtime then using
+// its default value.
+func (d *Document) Unset(table, key string) error {
+	start, end, err := d.tableLines(table)
+	if err != nil {
+		return err
+	}
+
+	for i := start; i < end; i++ {
+		if m := keyRE.FindStringSubmatch(d.lines[i]); m != nil && m[2] == key {
+			d.lines[i] = m[1] + "#" + strings.TrimPrefix(d.lines[i], m[1])
+		}
+	}
+	return nil
+}
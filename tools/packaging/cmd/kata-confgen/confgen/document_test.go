@@ -0,0 +1,87 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package confgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDocument = `# Base configuration
+[hypervisor.qemu]
+path = "/opt/kata/bin/qemu-system-x86_64"
+  kernel = "/opt/kata/share/kata-containers/vmlinuz.container"
+
+# Enable confidential guests
+# confidential_guest = true
+
+[runtime]
+# Enable debug
+#enable_debug = true
+`
+
+func TestDocumentSet(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := Parse([]byte(testDocument))
+	assert.Equal(testDocument, string(doc.Bytes()))
+	assert.Equal([]string{"hypervisor.qemu", "runtime"}, doc.Tables())
+
+	// Replaced, indentation kept
+	assert.NoError(doc.Set("hypervisor.qemu", "kernel", "/vmlinux"))
+	// Uncommented
+	assert.NoError(doc.Set("hypervisor.qemu", "confidential_guest", false))
+	assert.NoError(doc.Set("runtime", "enable_debug", true))
+	// Added after the last setting of the table
+	assert.NoError(doc.Set("hypervisor.qemu", "valid_hypervisor_paths", []string{"/qemu"}))
+	assert.NoError(doc.Set("hypervisor.qemu", "default_memory", 4096))
+
+	assert.Equal(`# Base configuration
+[hypervisor.qemu]
+path = "/opt/kata/bin/qemu-system-x86_64"
+  kernel = "/vmlinux"
+
+# Enable confidential guests
+confidential_guest = false
+valid_hypervisor_paths = ["/qemu"]
+default_memory = 4096
+
+[runtime]
+# Enable debug
+enable_debug = true
+`, string(doc.Bytes()))
+
+	assert.Error(doc.Set("agent.kata", "enable_debug", true))
+	assert.Error(doc.Set("runtime", "enable_debug", struct{}{}))
+}
+
+func TestDocumentUnsetRename(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := Parse([]byte(testDocument))
+	copied := doc.Copy()
+
+	assert.NoError(doc.Unset("hypervisor.qemu", "kernel"))
+	assert.NoError(doc.RenameTable("hypervisor.qemu", "hypervisor.clh"))
+	assert.Error(doc.RenameTable("hypervisor.qemu", "hypervisor.clh"))
+
+	assert.Equal(`# Base configuration
+[hypervisor.clh]
+path = "/opt/kata/bin/qemu-system-x86_64"
+  #kernel = "/opt/kata/share/kata-containers/vmlinuz.container"
+
+# Enable confidential guests
+# confidential_guest = true
+
+[runtime]
+# Enable debug
+#enable_debug = true
+`, string(doc.Bytes()))
+
+	// The copy is left untouched
+	assert.Equal(testDocument, string(copied.Bytes()))
+}
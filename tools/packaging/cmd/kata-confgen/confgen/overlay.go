@@ -0,0 +1,195 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package confgen
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	hypervisorTablePrefix = "hypervisor."
+	agentTablePrefix      = "agent."
+	runtimeTable          = "runtime"
+)
+
+// Settings are the values of the keys of a table.
+type Settings map[string]interface{}
+
+// Overlay is a configuration variant, the changes it makes to the base
+// configuration.
+type Overlay struct {
+	// Name is the name of the variant, its configuration is written to
+	// configuration-<Name>.toml
+	Name string
+
+	// Hypervisor is the hypervisor of the variant, the hypervisor table of
+	// the base being renamed after it. The hypervisor of the base is kept
+	// when empty.
+	Hypervisor string
+
+	// HypervisorSettings, AgentSettings and RuntimeSettings are set in the
+	// hypervisor, agent and runtime tables.
+	HypervisorSettings Settings
+	AgentSettings      Settings
+	RuntimeSettings    Settings
+
+	// HypervisorUnset are the keys of the hypervisor table commented out,
+	// for the runtime to use their default values.
+	HypervisorUnset []string
+}
+
+// FileName returns the name of the configuration file of the variant.
+func (o Overlay) FileName() string {
+	return fmt.Sprintf("configuration-%s.toml", o.Name)
+}
+
+func setAll(doc *Document, table string, settings Settings) error {
+	// The settings are set in order for the output to be reproducible
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := doc.Set(table, key, settings[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply returns the configuration of the variant of base described by the
+// overlay, base being left untouched.
+func Apply(base *Document, o Overlay) (*Document, error) {
+	doc := base.Copy()
+
+	hypervisorTable, err := doc.tableWithPrefix(hypervisorTablePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Hypervisor != "" {
+		table := hypervisorTablePrefix + o.Hypervisor
+		if err := doc.RenameTable(hypervisorTable, table); err != nil {
+			return nil, err
+		}
+		hypervisorTable = table
+	}
+
+	for _, key := range o.HypervisorUnset {
+		if err := doc.Unset(hypervisorTable, key); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setAll(doc, hypervisorTable, o.HypervisorSettings); err != nil {
+		return nil, err
+	}
+
+	if len(o.AgentSettings) > 0 {
+		agentTable, err := doc.tableWithPrefix(agentTablePrefix)
+		if err != nil {
+			return nil, err
+		}
+		if err := setAll(doc, agentTable, o.AgentSettings); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setAll(doc, runtimeTable, o.RuntimeSettings); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Variants returns the configuration variants installed by kata-deploy under
+// prefix, e.g. /opt/kata, from the QEMU configuration as a base.
+func Variants(prefix string) []Overlay {
+	bin := func(name string) string {
+		return filepath.Join(prefix, "bin", name)
+	}
+	share := func(name string) string {
+		return filepath.Join(prefix, "share", "kata-containers", name)
+	}
+	firmware := func(name string) string {
+		return filepath.Join(prefix, "share", "ovmf", name)
+	}
+
+	return []Overlay{
+		{
+			// The base itself
+			Name: "qemu",
+		},
+		{
+			Name:       "clh",
+			Hypervisor: "clh",
+			HypervisorSettings: Settings{
+				"path":                   bin("cloud-hypervisor"),
+				"kernel":                 share("vmlinux.container"),
+				"valid_hypervisor_paths": []string{bin("cloud-hypervisor")},
+				"shared_fs":              "virtio-fs",
+				"block_device_driver":    "virtio-blk",
+			},
+			HypervisorUnset: []string{"machine_type", "machine_accelerators", "firmware", "initrd"},
+		},
+		{
+			Name: "snp",
+			HypervisorSettings: Settings{
+				"confidential_guest": true,
+				"kernel":             share("vmlinuz-snp.container"),
+				"firmware":           firmware("AMDSEV.fd"),
+				"machine_type":       "q35",
+			},
+		},
+		{
+			Name: "tdx",
+			HypervisorSettings: Settings{
+				"confidential_guest": true,
+				"kernel":             share("vmlinuz-tdx.container"),
+				"firmware":           firmware("OVMF.inteltdx.fd"),
+				"machine_type":       "q35",
+			},
+		},
+		{
+			Name: "nvidia",
+			HypervisorSettings: Settings{
+				"kernel":                   share("vmlinuz-nvidia-gpu.container"),
+				"machine_type":             "q35",
+				"hotplug_vfio_on_root_bus": true,
+				"pcie_root_port":           1,
+				"default_memory":           8192,
+			},
+		},
+	}
+}
+
+// Generate returns the configurations of the variants of base, by file name.
+// The configurations are all validated, none is returned when one of them is
+// not valid.
+func Generate(base []byte, variants []Overlay) (map[string][]byte, error) {
+	doc := Parse(base)
+	configs := make(map[string][]byte)
+
+	for _, o := range variants {
+		variant, err := Apply(doc, o)
+		if err != nil {
+			return nil, fmt.Errorf("variant %s: %v", o.Name, err)
+		}
+
+		data := variant.Bytes()
+		if err := Validate(data); err != nil {
+			return nil, fmt.Errorf("variant %s: %v", o.Name, err)
+		}
+
+		configs[o.FileName()] = data
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package confgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBase = `[hypervisor.qemu]
+path = "/opt/kata/bin/qemu-system-x86_64"
+kernel = "/opt/kata/share/kata-containers/vmlinuz.container"
+image = "/opt/kata/share/kata-containers/kata-containers.img"
+machine_type = "q35"
+# confidential_guest = true
+firmware = ""
+default_memory = 2048
+shared_fs = "virtio-fs"
+block_device_driver = "virtio-scsi"
+#hotplug_vfio_on_root_bus = true
+#pcie_root_port = 2
+
+[agent.kata]
+#enable_port_forward = true
+
+[runtime]
+#enable_debug = true
+`
+
+func TestGenerateVariants(t *testing.T) {
+	assert := assert.New(t)
+
+	configs, err := Generate([]byte(testBase), Variants("/opt/kata"))
+	assert.NoError(err)
+	assert.Len(configs, 5)
+
+	assert.Equal(testBase, string(configs["configuration-qemu.toml"]))
+
+	var clh struct {
+		Hypervisor map[string]map[string]interface{}
+	}
+	_, err = toml.Decode(string(configs["configuration-clh.toml"]), &clh)
+	assert.NoError(err)
+	assert.Contains(clh.Hypervisor, "clh")
+	assert.Equal("/opt/kata/bin/cloud-hypervisor", clh.Hypervisor["clh"]["path"])
+	assert.NotContains(clh.Hypervisor["clh"], "machine_type")
+
+	assert.Contains(string(configs["configuration-tdx.toml"]), "\nconfidential_guest = true\n")
+	assert.Contains(string(configs["configuration-nvidia.toml"]), "\npcie_root_port = 1\n")
+}
+
+func TestGenerateInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Generate([]byte(testBase), []Overlay{{
+		Name:          "snp",
+		AgentSettings: Settings{"enable_port_forward": true},
+		HypervisorSettings: Settings{
+			"confidential_guest": true,
+			"firmware":           "/opt/kata/share/ovmf/AMDSEV.fd",
+		},
+	}})
+	assert.Error(err)
+	assert.Contains(err.Error(), "variant snp")
+
+	// No hypervisor table
+	_, err = Generate([]byte(strings.Replace(testBase, "[hypervisor.qemu]", "", 1)), Variants("/opt/kata"))
+	assert.Error(err)
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(Validate([]byte(testBase)))
+
+	for _, invalid := range []string{
+		strings.Replace(testBase, "/opt/kata/bin/qemu-system-x86_64", "@QEMUPATH@", 1),
+		strings.Replace(testBase, "/opt/kata/bin/qemu-system-x86_64", "qemu-system-x86_64", 1),
+		strings.Replace(testBase, "default_memory = 2048", "default_memory = ", 1),
+		strings.Replace(testBase, "[hypervisor.qemu]", "[hypervisor.bhyve]", 1),
+		strings.Replace(testBase, "image = ", "initrd = \"/initrd\"\nimage = ", 1),
+		strings.Replace(testBase, "image = ", "#image = ", 1),
+		strings.Replace(testBase, "# confidential_guest", "confidential_guest", 1),
+		testBase + "[hypervisor.clh]\npath = \"/clh\"\n",
+	} {
+		assert.Error(Validate([]byte(invalid)), invalid)
+	}
+
+	// The placeholders of the comments are ignored
+	assert.NoError(Validate([]byte("# path = @QEMUPATH@\n" + testBase)))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package confgen
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// The placeholders of the configuration templates, e.g. @QEMUPATH@
+var placeholderRE = regexp.MustCompile(`@[A-Z0-9_]+@`)
+
+var supportedHypervisors = map[string]bool{
+	"qemu": true,
+	"clh":  true,
+	"fc":   true,
+	"acrn": true,
+}
+
+// The settings checked, a subset of the runtime configuration
+type hypervisorConfig struct {
+	Path              string `toml:"path"`
+	Kernel            string `toml:"kernel"`
+	Image             string `toml:"image"`
+	Initrd            string `toml:"initrd"`
+	Firmware          string `toml:"firmware"`
+	ConfidentialGuest bool   `toml:"confidential_guest"`
+	PCIeRootPort      uint32 `toml:"pcie_root_port"`
+}
+
+type agentConfig struct {
+	EnablePortForward    bool `toml:"enable_port_forward"`
+	EnableNetworkCapture bool `toml:"enable_network_capture"`
+}
+
+type config struct {
+	Hypervisor map[string]hypervisorConfig `toml:"hypervisor"`
+	Agent      map[string]agentConfig      `toml:"agent"`
+}
+
+// Validate checks that a configuration is valid TOML, with no placeholder
+// left, and that the settings of its hypervisor are consistent. The checks
+// of the runtime which do not depend on the host are performed ahead of
+// writing the configuration.
+func Validate(data []byte) error {
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if placeholder := placeholderRE.FindString(line); placeholder != "" {
+			return fmt.Errorf("line %d: placeholder %s not replaced", i+1, placeholder)
+		}
+	}
+
+	var c config
+	if _, err := toml.Decode(string(data), &c); err != nil {
+		return err
+	}
+
+	if len(c.Hypervisor) != 1 {
+		return fmt.Errorf("expected a single hypervisor, found %d", len(c.Hypervisor))
+	}
+
+	for name, h := range c.Hypervisor {
+		if !supportedHypervisors[name] {
+			return fmt.Errorf("unsupported hypervisor %s", name)
+		}
+
+		if err := h.validate(name); err != nil {
+			return fmt.Errorf("hypervisor %s: %v", name, err)
+		}
+
+		if !h.ConfidentialGuest {
+			continue
+		}
+		for _, a := range c.Agent {
+			if a.EnablePortForward {
+				return errors.New("enable_port_forward is not supported with confidential_guest")
+			}
+			if a.EnableNetworkCapture {
+				return errors.New("enable_network_capture is not supported with confidential_guest")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h hypervisorConfig) validate(name string) error {
+	for key, path := range map[string]string{
+		"path":     h.Path,
+		"kernel":   h.Kernel,
+		"image":    h.Image,
+		"initrd":   h.Initrd,
+		"firmware": h.Firmware,
+	} {
+		if path != "" && !filepath.IsAbs(path) {
+			return fmt.Errorf("%s %q must be an absolute path", key, path)
+		}
+	}
+
+	if h.Path == "" {
+		return errors.New("path must be set")
+	}
+	if h.Kernel == "" {
+		return errors.New("kernel must be set")
+	}
+
+	if h.Image != "" && h.Initrd != "" {
+		return errors.New("having both an image and an initrd defined in the configuration file is not supported")
+	}
+	if h.Image == "" && h.Initrd == "" {
+		return errors.New("either image or initrd must be defined in the configuration file")
+	}
+
+	if h.ConfidentialGuest {
+		if name != "qemu" {
+			return errors.New("confidential_guest is only supported with qemu")
+		}
+		if h.Firmware == "" {
+			return errors.New("confidential_guest requires a firmware")
+		}
+	}
+
+	if h.PCIeRootPort > 0 && name != "qemu" {
+		return errors.New("pcie_root_port is only supported with qemu")
+	}
+
+	return nil
+}
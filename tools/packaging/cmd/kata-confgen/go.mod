@@ -0,0 +1,8 @@
+module github.com/kata-containers/kata-containers/tools/packaging/cmd/kata-confgen
+
+go 1.14
+
+require (
+	github.com/BurntSushi/toml v0.3.1
+	github.com/stretchr/testify v1.6.1
+)
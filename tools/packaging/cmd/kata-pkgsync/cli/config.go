@@ -13,6 +13,8 @@ type CfgOBSProject struct {
 	}
 	Releases []string
 	Archs    []string `yaml:"architectures"`
+	// Keyring validating the signature of the published repositories
+	GPGKeyring string `yaml:"gpg-keyring"`
 }
 
 type CfgPackagecloud struct {
@@ -25,6 +25,8 @@ var (
 	commit         = ""
 	defaultConfig  = "config.yaml"
 	defaultOBSDest = "obs-packages"
+
+	defaultConcurrency = 4
 )
 
 func usage() {
@@ -43,13 +45,23 @@ Options:
 	flag.PrintDefaults()
 }
 
-func getOBSProjects(cfgProjects map[string]CfgOBSProject) []obsgo.Project {
-	var projects []obsgo.Project
+// OBSProject is an OBS project to synchronize
+type OBSProject struct {
+	obsgo.Project
+	// Keyring validating the signature of the repositories metadata, if set
+	GPGKeyring string
+}
+
+func getOBSProjects(cfgProjects map[string]CfgOBSProject) []OBSProject {
+	var projects []OBSProject
 
 	for n, p := range cfgProjects {
-		proj := obsgo.Project{
-			User:     p.Auth.User,
-			Password: p.Auth.Password,
+		proj := OBSProject{
+			Project: obsgo.Project{
+				User:     p.Auth.User,
+				Password: p.Auth.Password,
+			},
+			GPGKeyring: p.GPGKeyring,
 		}
 
 		if len(p.Archs) == 0 {
@@ -124,6 +136,8 @@ func main() {
 	dryRun := flag.Bool("dry-run", false, "dry-run mode (do not download/upload files)")
 	pcDelete := flag.Bool("delete", false, "Delete Packagecloud packages that are not published on OBS")
 	showVersion := flag.Bool("version", false, "show the version")
+	concurrency := flag.Int("concurrency", defaultConcurrency, "Number of packages transferred concurrently")
+	bandwidthLimit := flag.Int64("bandwidth-limit", 0, "Limit the total bandwidth of the transfers to the given KiB/s (0 means unlimited)")
 
 	flag.Parse()
 
@@ -152,6 +166,8 @@ func main() {
 	}
 	logrus.Debugf("Configuration file content: %+v", cfg)
 
+	limitBandwidth(*bandwidthLimit * 1024)
+
 	var pc PCClient
 	if err := pc.PackagecloudClient(
 		cfg.Packagecloud.Auth.User,
@@ -176,6 +192,9 @@ func main() {
 	// lookup table for pcPackages packages that should NOT be deleted from Packagecloud
 	pcPackagesNeeded := make([]bool, len(pcPackages))
 
+	// number of packages that failed the checksum or signature verification
+	totalCorrupted := 0
+
 	projects := getOBSProjects(cfg.OBSProjects)
 	for _, proj := range projects {
 		logrus.WithFields(logrus.Fields{
@@ -194,7 +213,7 @@ func main() {
 			"OBS project": proj.Name,
 		}).Infof("Found %d packages", len(obsPackages))
 
-		totalXferred := 0
+		var jobs []xferJob
 		for _, pkg := range obsPackages {
 			pcDistro, found := cfg.DistroMapping[pkg.Repo]
 			if !found {
@@ -225,42 +244,32 @@ func main() {
 				"pkg":     pkg.Name,
 				"repo":    pkg.Repo,
 				"# files": len(xferBins),
-			}).Info("Downloading from OBS")
-
-			if *dryRun {
-				continue
-			}
+			}).Info("Files to synchronize")
 
 			pkg.Files = xferBins
-			paths, err := proj.DownloadPackageFiles(pkg, *dlPath)
-			if err != nil {
-				logrus.WithError(err).Warnf("Failed to download binaries for %s on %s/%s", pkg.Name, pc.Repo, pcDistro)
-				continue
-			}
-
-			logrus.WithFields(logrus.Fields{
-				"pkg":     pkg.Name,
-				"distro":  pcDistro,
-				"# files": len(xferBins),
-			}).Info("Uploading to Packagecloud")
-
-			err = pc.PackagecloudPush(paths, pcDistro)
-			if err != nil {
-				logrus.WithError(err).WithFields(logrus.Fields{
-					"package": pkg.Name,
-					"distro":  pcDistro,
-				}).Error("Failed to push binaries to Packagecloud")
-			}
+			jobs = append(jobs, xferJob{pkg, pcDistro})
+		}
 
-			totalXferred += len(xferBins)
+		if *dryRun {
+			continue
 		}
 
+		totalXferred, corrupted := transferPackages(pc, proj, jobs, *dlPath, *concurrency)
+		totalCorrupted += corrupted
+
 		logrus.WithFields(logrus.Fields{
 			"OBS project":       proj.Name,
 			"Packagecloud Repo": pc.Repo,
 		}).Infof("Successfully transferred %d files", totalXferred)
 	}
 
+	if totalCorrupted > 0 {
+		logrus.WithFields(logrus.Fields{
+			"Packagecloud Repo": pc.Repo,
+		}).Errorf("%d packages failed the verification and were not transferred", totalCorrupted)
+		os.Exit(-1)
+	}
+
 	if !*pcDelete {
 		return
 	}
@@ -0,0 +1,334 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcov/obsgo"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+//nolint[:gochecknoglobals]
+var (
+	obsBaseURL = "https://api.opensuse.org/build"
+
+	// Number of attempts to download a file, each attempt resuming from
+	// where the previous one stopped
+	downloadAttempts = 3
+)
+
+// Largest chunk of data transferred at once when the bandwidth is limited,
+// keeping the transfers of the concurrent workers interleaved
+const rateLimitChunk = 32 * 1024
+
+// rateLimiter is a token bucket shared by all the transfers, allowing up to
+// one second worth of burst.
+type rateLimiter struct {
+	sync.Mutex
+	rate      float64 // bytes per second
+	available float64
+	last      time.Time
+}
+
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{
+		rate:      float64(bytesPerSecond),
+		available: float64(bytesPerSecond),
+		last:      time.Now(),
+	}
+}
+
+// wait blocks until n bytes can be transferred without exceeding the rate.
+func (l *rateLimiter) wait(n int) {
+	if l == nil || n <= 0 {
+		return
+	}
+
+	l.Lock()
+	now := time.Now()
+	l.available += now.Sub(l.last).Seconds() * l.rate
+	if l.available > l.rate {
+		l.available = l.rate
+	}
+	l.last = now
+	l.available -= float64(n)
+
+	var delay time.Duration
+	if l.available < 0 {
+		delay = time.Duration(-l.available / l.rate * float64(time.Second))
+	}
+	l.Unlock()
+
+	time.Sleep(delay)
+}
+
+type rateLimitedReader struct {
+	io.ReadCloser
+	limiter *rateLimiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > rateLimitChunk {
+		p = p[:rateLimitChunk]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.limiter.wait(n)
+	return n, err
+}
+
+// rateLimitedTransport limits the bandwidth of both the request and the
+// response bodies, i.e. of the uploads to Packagecloud as well as of the
+// downloads from OBS.
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiter   *rateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req = req.Clone(req.Context())
+		req.Body = &rateLimitedReader{req.Body, t.limiter}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &rateLimitedReader{resp.Body, t.limiter}
+
+	return resp, nil
+}
+
+// limitBandwidth limits the bandwidth of all the HTTP transfers of the
+// process to bytesPerSecond, the OBS and Packagecloud clients using the
+// default transport.
+func limitBandwidth(bytesPerSecond int64) {
+	limiter := newRateLimiter(bytesPerSecond)
+	if limiter == nil {
+		return
+	}
+
+	http.DefaultTransport = &rateLimitedTransport{http.DefaultTransport, limiter}
+}
+
+// downloadFile downloads remotePath from the OBS project into localFile. A
+// partially downloaded localFile is resumed with an HTTP range request.
+func downloadFile(proj obsgo.Project, remotePath, localFile string, size int64) error {
+	var offset int64
+	info, err := os.Stat(localFile)
+	switch {
+	case err == nil:
+		offset = info.Size()
+	case !os.IsNotExist(err):
+		return err
+	}
+
+	if offset == size {
+		return nil
+	}
+	if offset > size {
+		offset = 0
+	}
+
+	req, err := http.NewRequest(http.MethodGet, obsBaseURL+path.Join("/", proj.Name, remotePath), nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(proj.User, proj.Password)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_WRONLY | os.O_CREATE
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return errors.Errorf("unexpected content range %q", resp.Header.Get("Content-Range"))
+		}
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// The server ignored the range, start over
+		offset = 0
+		flags |= os.O_TRUNC
+	default:
+		return errors.Errorf("Unexpected HTTP status code: %d", resp.StatusCode)
+	}
+
+	if offset > 0 {
+		logrus.WithFields(logrus.Fields{
+			"file":   localFile,
+			"offset": offset,
+		}).Debug("Resuming OBS file download")
+	}
+
+	f, err := os.OpenFile(localFile, flags, 0600)
+	if err != nil {
+		return err
+	}
+
+	written, err := io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if offset+written != size {
+		return errors.Errorf("incomplete download, got %d of %d bytes", offset+written, size)
+	}
+
+	return nil
+}
+
+// downloadPackageFiles downloads the files of pkg from the OBS project into
+// the root directory, and returns the paths of the downloaded files. The
+// files already downloaded are skipped and the partial ones are resumed.
+func downloadPackageFiles(proj obsgo.Project, pkg obsgo.PackageInfo, root string) ([]string, error) {
+	paths := make([]string, 0, len(pkg.Files))
+	for _, f := range pkg.Files {
+		remotePath := path.Join(pkg.Path, f.Filename)
+		localFile := filepath.Join(root, proj.Name, remotePath)
+
+		size, err := strconv.ParseInt(f.Size, 10, 64)
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not parse file size %s", localFile)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(localFile), 0700); err != nil {
+			return paths, errors.Wrapf(err, "could not mkdir path %s", remotePath)
+		}
+
+		for attempt := 1; ; attempt++ {
+			err = downloadFile(proj, remotePath, localFile, size)
+			if err == nil || attempt == downloadAttempts {
+				break
+			}
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"filename": f.Filename,
+				"attempt":  attempt,
+			}).Warn("OBS file download interrupted, resuming")
+		}
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not download binary at %s", remotePath)
+		}
+
+		paths = append(paths, localFile)
+	}
+
+	return paths, nil
+}
+
+// xferJob is a package whose files need to be synchronized from OBS to
+// Packagecloud.
+type xferJob struct {
+	pkg      obsgo.PackageInfo
+	pcDistro string
+}
+
+// transferPackages downloads the files of the jobs from the OBS project,
+// verifies them and uploads them to Packagecloud, using up to concurrency
+// workers. It returns the number of files transferred and the number of
+// packages that failed the verification.
+func transferPackages(pc PCClient, proj OBSProject, jobs []xferJob, dlPath string, concurrency int) (int, int) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg             sync.WaitGroup
+		lock           sync.Mutex
+		totalXferred   int
+		totalCorrupted int
+	)
+
+	checksums := newChecksumCache(dlPath)
+	jobCh := make(chan xferJob)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				err := transferPackage(pc, proj, checksums, job, dlPath)
+
+				lock.Lock()
+				if err == nil {
+					totalXferred += len(job.pkg.Files)
+				} else if _, ok := err.(verificationError); ok {
+					totalCorrupted++
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		jobCh <- job
+	}
+	close(jobCh)
+	wg.Wait()
+
+	return totalXferred, totalCorrupted
+}
+
+func transferPackage(pc PCClient, proj OBSProject, checksums *checksumCache, job xferJob, dlPath string) error {
+	logrus.WithFields(logrus.Fields{
+		"pkg":     job.pkg.Name,
+		"repo":    job.pkg.Repo,
+		"# files": len(job.pkg.Files),
+	}).Info("Downloading from OBS")
+
+	paths, err := downloadPackageFiles(proj.Project, job.pkg, dlPath)
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to download binaries for %s on %s/%s", job.pkg.Name, pc.Repo, job.pcDistro)
+		return err
+	}
+
+	if err := verifyPackageFiles(checksums, proj.Project, proj.GPGKeyring, job.pkg, paths); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"package": job.pkg.Name,
+			"repo":    job.pkg.Repo,
+		}).Error("Failed to verify binaries downloaded from OBS")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"pkg":     job.pkg.Name,
+		"distro":  job.pcDistro,
+		"# files": len(job.pkg.Files),
+	}).Info("Uploading to Packagecloud")
+
+	if err := pc.PackagecloudPush(paths, job.pcDistro); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"package": job.pkg.Name,
+			"distro":  job.pcDistro,
+		}).Error("Failed to push binaries to Packagecloud")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,346 @@
+// Copyright (c) 2021 Kata Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/marcov/obsgo"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+//nolint[:gochecknoglobals]
+var obsDownloadURL = "https://download.opensuse.org/repositories"
+
+// verificationError is returned when a downloaded package does not match
+// the metadata published on OBS, or when the metadata cannot be trusted.
+type verificationError struct {
+	error
+}
+
+// repoChecksums are the sha256 checksums of the packages published in an
+// OBS repository, indexed by file name.
+type repoChecksums map[string]string
+
+// checksumCache fetches the checksums of each OBS repository once.
+type checksumCache struct {
+	sync.Mutex
+	root  string
+	repos map[string]repoChecksums
+}
+
+func newChecksumCache(root string) *checksumCache {
+	return &checksumCache{
+		root:  root,
+		repos: make(map[string]repoChecksums),
+	}
+}
+
+// get returns the checksums of the repository repo of the OBS project. When
+// keyring is set, the signature of the repository metadata is validated
+// against it.
+func (c *checksumCache) get(proj obsgo.Project, repo, keyring string) (repoChecksums, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	key := path.Join(proj.Name, repo)
+	if sums, ok := c.repos[key]; ok {
+		return sums, nil
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"project": proj.Name,
+		"repo":    repo,
+	}).Debug("Retrieving OBS repository metadata")
+
+	r := repoMetadata{
+		url:     obsDownloadURL + "/" + strings.Replace(proj.Name, ":", ":/", -1) + "/" + repo,
+		dir:     filepath.Join(c.root, proj.Name, repo, "metadata"),
+		keyring: keyring,
+	}
+
+	sums, err := r.rpmChecksums()
+	if err == errNoMetadata {
+		sums, err = r.debChecksums()
+	}
+	if err != nil {
+		return nil, verificationError{errors.Wrapf(err, "could not get the metadata of repo %s", key)}
+	}
+
+	c.repos[key] = sums
+	return sums, nil
+}
+
+var errNoMetadata = errors.New("no repository metadata")
+
+// repoMetadata is the published metadata of an OBS repository, the signed
+// index, i.e. repomd.xml or Release, holding the checksum of the packages
+// list, i.e. primary.xml or Packages, which holds the checksums of the
+// packages.
+type repoMetadata struct {
+	url     string
+	dir     string
+	keyring string
+}
+
+// fetch downloads the metadata file name and returns its content.
+func (r *repoMetadata) fetch(name string) ([]byte, error) {
+	resp, err := http.Get(r.url + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNoMetadata
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Unexpected HTTP status code %d for %s", resp.StatusCode, name)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// fetchSigned downloads the metadata file name and, when a keyring is set,
+// validates its detached signature sigName.
+func (r *repoMetadata) fetchSigned(name, sigName string) ([]byte, error) {
+	data, err := r.fetch(name)
+	if err != nil || r.keyring == "" {
+		return data, err
+	}
+
+	sig, err := r.fetch(sigName)
+	if err == errNoMetadata {
+		return nil, errors.Errorf("%s is not signed", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(r.dir, 0700); err != nil {
+		return nil, err
+	}
+	dataFile := filepath.Join(r.dir, path.Base(name))
+	sigFile := filepath.Join(r.dir, path.Base(sigName))
+	if err := ioutil.WriteFile(dataFile, data, 0600); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(sigFile, sig, 0600); err != nil {
+		return nil, err
+	}
+
+	out, err := exec.Command("gpgv", "--keyring", r.keyring, sigFile, dataFile).CombinedOutput()
+	if err != nil {
+		return nil, errors.Errorf("invalid signature of %s: %s", name, strings.TrimSpace(string(out)))
+	}
+
+	return data, nil
+}
+
+type xmlChecksum struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlLocation struct {
+	Href string `xml:"href,attr"`
+}
+
+type xmlRepomd struct {
+	XMLName xml.Name `xml:"repomd"`
+	Data    []struct {
+		Type     string      `xml:"type,attr"`
+		Checksum xmlChecksum `xml:"checksum"`
+		Location xmlLocation `xml:"location"`
+	} `xml:"data"`
+}
+
+type xmlPrimary struct {
+	XMLName  xml.Name `xml:"metadata"`
+	Packages []struct {
+		Checksum xmlChecksum `xml:"checksum"`
+		Location xmlLocation `xml:"location"`
+	} `xml:"package"`
+}
+
+// rpmChecksums returns the checksums of an rpm-md repository.
+func (r *repoMetadata) rpmChecksums() (repoChecksums, error) {
+	data, err := r.fetchSigned("repodata/repomd.xml", "repodata/repomd.xml.asc")
+	if err != nil {
+		return nil, err
+	}
+
+	var repomd xmlRepomd
+	if err := xml.Unmarshal(data, &repomd); err != nil {
+		return nil, errors.Wrap(err, "invalid repomd.xml")
+	}
+
+	for _, d := range repomd.Data {
+		if d.Type != "primary" {
+			continue
+		}
+
+		if d.Checksum.Type != "sha256" {
+			return nil, errors.Errorf("unsupported %s checksum of the primary metadata", d.Checksum.Type)
+		}
+
+		primary, err := r.fetch(d.Location.Href)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkSHA256(bytes.NewReader(primary), d.Checksum.Value); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", d.Location.Href)
+		}
+
+		if strings.HasSuffix(d.Location.Href, ".gz") {
+			gz, err := gzip.NewReader(bytes.NewReader(primary))
+			if err != nil {
+				return nil, err
+			}
+			if primary, err = ioutil.ReadAll(gz); err != nil {
+				return nil, err
+			}
+		}
+
+		var metadata xmlPrimary
+		if err := xml.Unmarshal(primary, &metadata); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", d.Location.Href)
+		}
+
+		sums := make(repoChecksums, len(metadata.Packages))
+		for _, p := range metadata.Packages {
+			if p.Checksum.Type == "sha256" {
+				sums[path.Base(p.Location.Href)] = p.Checksum.Value
+			}
+		}
+		return sums, nil
+	}
+
+	return nil, errors.New("no primary metadata in repomd.xml")
+}
+
+// debChecksums returns the checksums of a flat Debian repository.
+func (r *repoMetadata) debChecksums() (repoChecksums, error) {
+	release, err := r.fetchSigned("Release", "Release.gpg")
+	if err != nil {
+		return nil, err
+	}
+
+	var packagesSum string
+	for _, f := range debianFields(release, "SHA256") {
+		// "<sha256> <size> <file name>"
+		if fields := strings.Fields(f); len(fields) == 3 && fields[2] == "Packages" {
+			packagesSum = fields[0]
+		}
+	}
+	if packagesSum == "" {
+		return nil, errors.New("no Packages checksum in Release")
+	}
+
+	packages, err := r.fetch("Packages")
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSHA256(bytes.NewReader(packages), packagesSum); err != nil {
+		return nil, errors.Wrap(err, "invalid Packages")
+	}
+
+	sums := make(repoChecksums)
+	for _, paragraph := range bytes.Split(packages, []byte("\n\n")) {
+		filename := debianFields(paragraph, "Filename")
+		sum := debianFields(paragraph, "SHA256")
+		if len(filename) == 1 && len(sum) == 1 {
+			sums[path.Base(filename[0])] = sum[0]
+		}
+	}
+
+	return sums, nil
+}
+
+// debianFields returns the values of the field name of a Debian control
+// paragraph, one per line for the multiline fields.
+func debianFields(paragraph []byte, name string) []string {
+	var values []string
+	inField := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(paragraph))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, " ") {
+			if inField {
+				values = append(values, strings.TrimSpace(line))
+			}
+			continue
+		}
+
+		inField = strings.HasPrefix(line, name+":")
+		if inField {
+			if value := strings.TrimSpace(strings.TrimPrefix(line, name+":")); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return values
+}
+
+func checkSHA256(r io.Reader, expected string) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != expected {
+		return errors.Errorf("sha256 mismatch, expected %s, got %s", expected, sum)
+	}
+
+	return nil
+}
+
+// verifyPackageFiles checks the sha256 of the downloaded files of pkg
+// against the checksums published on OBS. The files that do not match are
+// removed, to be downloaded again by the next sync.
+func verifyPackageFiles(checksums *checksumCache, proj obsgo.Project, keyring string, pkg obsgo.PackageInfo, paths []string) error {
+	sums, err := checksums.get(proj, pkg.Repo, keyring)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		expected, ok := sums[filepath.Base(p)]
+		if !ok {
+			return verificationError{errors.Errorf("no published checksum for %s", filepath.Base(p))}
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		err = checkSHA256(f, expected)
+		f.Close()
+		if err != nil {
+			os.Remove(p)
+			return verificationError{errors.Wrapf(err, "corrupted package %s", filepath.Base(p))}
+		}
+	}
+
+	return nil
+}
@@ -92,11 +92,9 @@ function configure_different_shims_base() {
 			fi
 		fi
 
-		cat << EOT | tee "$shim_file"
-#!/bin/bash
-KATA_CONF_FILE=/opt/kata/share/defaults/kata-containers/configuration-${shim}.toml /opt/kata/bin/containerd-shim-kata-v2 "\$@"
-EOT
-		chmod +x "$shim_file"
+		# The shim loads the configuration-${shim}.toml file named after
+		# the binary it is invoked through.
+		ln -sf /opt/kata/bin/containerd-shim-kata-v2 "${shim_file}"
 	done
 }
 
@@ -64,6 +64,12 @@ options:
 -p      : push tarball to ${project_to_attach}
 -w <dir>: directory where tarball will be created
 
+Environment variables:
+
+ARTIFACTS_SIGNING_KEY: PEM encoded ed25519 private key the manifest of the
+                       guest artifacts is signed with, it is not signed if
+                       empty.
+
 
 EOT
 
@@ -206,6 +212,29 @@ untar_qemu_binaries() {
 	tar xf kata-static-qemu.tar.gz -C "${destdir}"
 }
 
+# Create the manifest of the guest artifacts, the runtime verifies them
+# against it before launching the VMs, and sign it when a key is provided.
+create_artifacts_manifest() {
+	local signing_key="${ARTIFACTS_SIGNING_KEY:-}"
+	local manifest="artifacts.sha256"
+	local configs_dir="${destdir}/${prefix}/share/defaults/${project}"
+
+	info "Create the guest artifacts manifest"
+	pushd "${destdir}/${prefix}/share/kata-containers/" >>/dev/null
+	find . -type f ! -name "${manifest}*" -printf '%P\n' | sort | xargs -r sha256sum >"${manifest}"
+	popd >>/dev/null
+	sed -i -e "s|^#artifacts_manifest = |artifacts_manifest = |" "${configs_dir}"/configuration-*.toml
+
+	[ -n "${signing_key}" ] || return 0
+
+	info "Sign the guest artifacts manifest"
+	pushd "${destdir}/${prefix}/share/kata-containers/" >>/dev/null
+	openssl pkeyutl -sign -rawin -inkey "${signing_key}" -in "${manifest}" -out "${manifest}.sig"
+	openssl pkey -in "${signing_key}" -pubout -out "${manifest}.pub"
+	popd >>/dev/null
+	sed -i -e "s|^#artifacts_manifest_public_key = |artifacts_manifest_public_key = |" "${configs_dir}"/configuration-*.toml
+}
+
 main() {
 	while getopts "hlpw:" opt; do
 		case $opt in
@@ -237,6 +266,7 @@ main() {
 	install_image
 
 	untar_qemu_binaries
+	create_artifacts_manifest
 
 	tarball_name="${destdir}.tar.xz"
 	pushd "${destdir}" >>/dev/null